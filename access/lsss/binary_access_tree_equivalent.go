@@ -0,0 +1,111 @@
+package lsss
+
+import (
+	"fmt"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"math/rand"
+)
+
+const (
+	// equivalentExhaustiveLimit 是穷举真值表的最大属性个数，超过时改为随机抽样
+	equivalentExhaustiveLimit = 16
+	// equivalentSampleCount 是属性个数超过 equivalentExhaustiveLimit 时抽样检查的属性子集个数
+	equivalentSampleCount = 1 << 12
+)
+
+// Equivalent 判断两棵访问树是否表示相同的访问结构，即任意属性集合要么同时满足两者，要么同时都不满足。
+// 两棵树共同涉及的属性不超过 equivalentExhaustiveLimit 个时穷举全部属性子集，结果是精确的；
+// 否则检查空集、全集以及 equivalentSampleCount 个随机子集，返回 true 只表示抽样中未发现差异。
+//
+// 参数:
+//   - a, b: 待比较的两棵访问树
+//
+// 返回值:
+//   - bool: 两棵树表示相同的访问结构时返回 true
+//   - error: 访问树为空或包含非法节点时返回错误
+func Equivalent(a, b *BinaryAccessTree) (bool, error) {
+	if a == nil || b == nil {
+		return false, fmt.Errorf("access tree cannot be nil")
+	}
+
+	universe := a.Attributes()
+	seen := make(map[fr.Element]struct{}, len(universe))
+	for _, attr := range universe {
+		seen[attr] = struct{}{}
+	}
+	for _, attr := range b.Attributes() {
+		if _, ok := seen[attr]; !ok {
+			seen[attr] = struct{}{}
+			universe = append(universe, attr)
+		}
+	}
+
+	check := func(selected func(i int) bool) (bool, error) {
+		attributes := make(map[fr.Element]struct{}, len(universe))
+		for i, attr := range universe {
+			if selected(i) {
+				attributes[attr] = struct{}{}
+			}
+		}
+		satisfiedA, err := a.satisfiedBy(attributes)
+		if err != nil {
+			return false, err
+		}
+		satisfiedB, err := b.satisfiedBy(attributes)
+		if err != nil {
+			return false, err
+		}
+		return satisfiedA == satisfiedB, nil
+	}
+
+	if len(universe) <= equivalentExhaustiveLimit {
+		for mask := 0; mask < 1<<len(universe); mask++ {
+			same, err := check(func(i int) bool { return mask&(1<<i) != 0 })
+			if err != nil || !same {
+				return false, err
+			}
+		}
+		return true, nil
+	}
+
+	for _, all := range []bool{false, true} {
+		same, err := check(func(int) bool { return all })
+		if err != nil || !same {
+			return false, err
+		}
+	}
+	for i := 0; i < equivalentSampleCount; i++ {
+		same, err := check(func(int) bool { return rand.Intn(2) == 1 })
+		if err != nil || !same {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// satisfiedBy 判断属性集合 attributes 是否满足访问树
+func (t *BinaryAccessTree) satisfiedBy(attributes map[fr.Element]struct{}) (bool, error) {
+	if t == nil {
+		return false, fmt.Errorf("access tree contains a nil node")
+	}
+	switch t.Type {
+	case NodeTypeLeave:
+		_, ok := attributes[t.Attribute]
+		return ok, nil
+	case NodeTypeAnd, NodeTypeOr:
+		left, err := t.Left.satisfiedBy(attributes)
+		if err != nil {
+			return false, err
+		}
+		right, err := t.Right.satisfiedBy(attributes)
+		if err != nil {
+			return false, err
+		}
+		if t.Type == NodeTypeAnd {
+			return left && right, nil
+		}
+		return left || right, nil
+	default:
+		return false, fmt.Errorf("unknown node type %q", t.Type)
+	}
+}
@@ -0,0 +1,74 @@
+package lsss
+
+import (
+	"fmt"
+	"testing"
+)
+
+// TestEquivalentExample12CNF 测试 Example12 与其按分配律展开的合取范式等价
+// ((A and B) or (C and D)) = (A or C) and (A or D) and (B or C) and (B or D)
+//
+// BinaryAccessTree 只有 AND、OR 与叶子节点，没有 NOT（NOT 只存在于 access/tree 包的门限树中），
+// 因此无法构造 De Morgan 变换后的等价策略；这里用分配律展开作为单调策略下的等价改写。
+func TestEquivalentExample12CNF(t *testing.T) {
+	tree, formula := GetExample12()
+	cnf := And(
+		Or(LeafFromString("A"), LeafFromString("C")),
+		Or(LeafFromString("A"), LeafFromString("D")),
+		Or(LeafFromString("B"), LeafFromString("C")),
+		Or(LeafFromString("B"), LeafFromString("D")),
+	)
+
+	equivalent, err := Equivalent(tree, cnf)
+	if err != nil {
+		t.Fatalf("Equivalent failed: %v", err)
+	}
+	if !equivalent {
+		t.Errorf("%s should be equivalent to its CNF expansion", formula)
+	}
+
+	normalized := Normalize(tree)
+	if equivalent, _ := Equivalent(tree, normalized); !equivalent {
+		t.Errorf("%s should be equivalent to its normalized form", formula)
+	}
+}
+
+// TestEquivalentDifferentPolicies 测试不同的访问结构被判定为不等价
+func TestEquivalentDifferentPolicies(t *testing.T) {
+	tree, formula := GetExample12()
+	cases := []*BinaryAccessTree{
+		Or(And(LeafFromString("A"), LeafFromString("B")), LeafFromString("C")),
+		And(Or(LeafFromString("A"), LeafFromString("B")), Or(LeafFromString("C"), LeafFromString("D"))),
+		Or(And(LeafFromString("A"), LeafFromString("B")), And(LeafFromString("C"), LeafFromString("E"))),
+	}
+	for i, other := range cases {
+		equivalent, err := Equivalent(tree, other)
+		if err != nil {
+			t.Fatalf("Equivalent failed: %v", err)
+		}
+		if equivalent {
+			t.Errorf("%s should not be equivalent to case %d", formula, i)
+		}
+	}
+}
+
+// TestEquivalentSampled 测试属性个数超过穷举上限时的抽样检查
+func TestEquivalentSampled(t *testing.T) {
+	var leaves []*BinaryAccessTree
+	for i := 0; i < equivalentExhaustiveLimit+4; i++ {
+		leaves = append(leaves, LeafFromString(fmt.Sprintf("attr%d", i)))
+	}
+	left := Or(leaves...)
+	right := OrRight(leaves...)
+	if equivalent, err := Equivalent(left, right); err != nil || !equivalent {
+		t.Errorf("left and right associative OR chains should be equivalent: %v", err)
+	}
+
+	if equivalent, err := Equivalent(left, And(leaves...)); err != nil || equivalent {
+		t.Errorf("OR chain and AND chain should not be equivalent: %v", err)
+	}
+
+	if _, err := Equivalent(left, nil); err == nil {
+		t.Error("expected error for nil tree")
+	}
+}
@@ -0,0 +1,120 @@
+package lsss
+
+import (
+	"fmt"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"strings"
+)
+
+// Explain 返回访问树在用户属性集合下的求值轨迹，用于回答"为什么无法解密"
+//
+// 叶子节点以属性编码的前 4 字节（十六进制）标识，需要显示属性名称时使用 ExplainWithNames。
+//
+// 参数：
+//   - tree: 访问树
+//   - userAttrs: 用户持有的属性集合
+//
+// 返回值：
+//   - string: 多行求值轨迹，格式见 ExplainWithNames
+func Explain(tree *BinaryAccessTree, userAttrs []fr.Element) string {
+	return ExplainWithNames(tree, userAttrs, nil)
+}
+
+// ExplainWithNames 返回访问树在用户属性集合下的求值轨迹，叶子节点用属性字典中的名称标识
+//
+// 每个门占一行，标注是否满足，并在同一行列出作为其子节点的叶子及用户是否持有，例如：
+//
+//	OR(unsatisfied)
+//	  AND(unsatisfied) -> [A: have, B: MISSING]
+//	  AND(unsatisfied) -> [C: MISSING, D: have]
+//
+// 连续的同类门合并为一个 n 元门显示，子门缩进两个空格列在下方。与 MissingAttributes 不同，
+// 轨迹指出的是哪一个分支失败，而不是补充哪些属性后可以满足。
+//
+// 参数：
+//   - tree: 访问树
+//   - userAttrs: 用户持有的属性集合
+//   - dictionary: 属性到名称的映射，可以为 nil；不在字典中的属性以编码前 4 字节的十六进制标识
+//
+// 返回值：
+//   - string: 多行求值轨迹，不以换行结尾
+func ExplainWithNames(tree *BinaryAccessTree, userAttrs []fr.Element, dictionary AttributeDictionary) string {
+	held := make(map[fr.Element]bool, len(userAttrs))
+	for _, a := range userAttrs {
+		held[a] = true
+	}
+	e := &explainer{held: held, dictionary: dictionary}
+	_, lines := e.node(tree)
+	return strings.Join(lines, "\n")
+}
+
+// explainer 保存一次 ExplainWithNames 调用的用户属性与属性字典
+type explainer struct {
+	held       map[fr.Element]bool
+	dictionary AttributeDictionary
+}
+
+// node 返回节点是否满足以及以该节点为根的轨迹行
+func (e *explainer) node(t *BinaryAccessTree) (bool, []string) {
+	if t == nil {
+		return false, []string{"<nil>(unsatisfied)"}
+	}
+	switch t.Type {
+	case NodeTypeLeave:
+		satisfied, item := e.leaf(t)
+		return satisfied, []string{item}
+	case NodeTypeAnd, NodeTypeOr:
+		satisfied := t.Type == NodeTypeAnd
+		var items, children []string
+		for _, child := range explainChain(t, t.Type) {
+			var ok bool
+			if child != nil && child.Type == NodeTypeLeave {
+				var item string
+				ok, item = e.leaf(child)
+				items = append(items, item)
+			} else {
+				var lines []string
+				ok, lines = e.node(child)
+				for _, line := range lines {
+					children = append(children, "  "+line)
+				}
+			}
+			if t.Type == NodeTypeAnd {
+				satisfied = satisfied && ok
+			} else {
+				satisfied = satisfied || ok
+			}
+		}
+		line := fmt.Sprintf("%s(%s)", strings.ToUpper(string(t.Type)), satisfiedLabel(satisfied))
+		if len(items) > 0 {
+			line += " -> [" + strings.Join(items, ", ") + "]"
+		}
+		return satisfied, append([]string{line}, children...)
+	default:
+		return false, []string{fmt.Sprintf("unknown node type %q(unsatisfied)", t.Type)}
+	}
+}
+
+// leaf 返回叶子节点是否被用户持有以及形如 "A: have" 的描述
+func (e *explainer) leaf(t *BinaryAccessTree) (bool, string) {
+	name := e.dictionary.Describe(t.Attribute)
+	if e.held[t.Attribute] {
+		return true, name + ": have"
+	}
+	return false, name + ": MISSING"
+}
+
+// explainChain 与 flattenChain 相同，但遇到 nil 子节点时将其原样返回而不是继续展开
+func explainChain(node *BinaryAccessTree, op nodeType) []*BinaryAccessTree {
+	if node == nil || node.Type != op {
+		return []*BinaryAccessTree{node}
+	}
+	return append(explainChain(node.Left, op), explainChain(node.Right, op)...)
+}
+
+func satisfiedLabel(satisfied bool) string {
+	if satisfied {
+		return "satisfied"
+	}
+	return "unsatisfied"
+}
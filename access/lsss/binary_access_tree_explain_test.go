@@ -0,0 +1,81 @@
+package lsss
+
+import (
+	"fmt"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/mmsyan/GoPairingBasedCryptography/hash"
+	"strings"
+	"testing"
+)
+
+func explainAttrs(names ...string) []fr.Element {
+	attrs := make([]fr.Element, len(names))
+	for i, name := range names {
+		attrs[i] = hash.ToField(name)
+	}
+	return attrs
+}
+
+// TestExplainExample12 ((A and B) or (C and D)) 在只持有 A、D 时两个 AND 分支都被标为失败
+func TestExplainExample12(t *testing.T) {
+	tree, _ := GetExample12()
+	dictionary := NewAttributeDictionary("A", "B", "C", "D")
+	got := ExplainWithNames(tree, explainAttrs("A", "D"), dictionary)
+	want := strings.Join([]string{
+		"OR(unsatisfied)",
+		"  AND(unsatisfied) -> [A: have, B: MISSING]",
+		"  AND(unsatisfied) -> [C: MISSING, D: have]",
+	}, "\n")
+	if got != want {
+		t.Fatalf("轨迹不符:\n%s\n期望:\n%s", got, want)
+	}
+
+	got = ExplainWithNames(tree, explainAttrs("C", "D"), dictionary)
+	if !strings.HasPrefix(got, "OR(satisfied)\n") || !strings.Contains(got, "AND(satisfied) -> [C: have, D: have]") {
+		t.Fatalf("满足的分支应被标为 satisfied:\n%s", got)
+	}
+}
+
+// TestExplainExample14 (((A and B) or (C and D)) or ((A or B) and (C or D))) 的 OR 链合并显示，
+// 只持有 A 时轨迹指出失败的是 (C or D)
+func TestExplainExample14(t *testing.T) {
+	tree, _ := GetExample14()
+	got := ExplainWithNames(tree, explainAttrs("A"), NewAttributeDictionary("A", "B", "C", "D"))
+	want := strings.Join([]string{
+		"OR(unsatisfied)",
+		"  AND(unsatisfied) -> [A: have, B: MISSING]",
+		"  AND(unsatisfied) -> [C: MISSING, D: MISSING]",
+		"  AND(unsatisfied)",
+		"    OR(satisfied) -> [A: have, B: MISSING]",
+		"    OR(unsatisfied) -> [C: MISSING, D: MISSING]",
+	}, "\n")
+	if got != want {
+		t.Fatalf("轨迹不符:\n%s\n期望:\n%s", got, want)
+	}
+
+	// 根节点的标注与 satisfiedBy 的结论一致
+	for _, attrs := range [][]string{{"A", "C"}, {"B", "D"}, {"C"}, {}} {
+		held := make(map[fr.Element]struct{})
+		for _, a := range explainAttrs(attrs...) {
+			held[a] = struct{}{}
+		}
+		satisfied, err := tree.satisfiedBy(held)
+		if err != nil {
+			t.Fatal(err)
+		}
+		root := strings.SplitN(Explain(tree, explainAttrs(attrs...)), "\n", 2)[0]
+		if want := "OR(" + satisfiedLabel(satisfied) + ")"; root != want {
+			t.Fatalf("%v: 根节点标注为 %s，期望 %s", attrs, root, want)
+		}
+	}
+}
+
+// TestExplainWithoutDictionary 没有字典时叶子以属性编码前 4 字节的十六进制标识
+func TestExplainWithoutDictionary(t *testing.T) {
+	a := hash.ToField("A")
+	b := a.Bytes()
+	got := Explain(LeafFromString("A"), []fr.Element{a})
+	if want := fmt.Sprintf("%x: have", b[:4]); got != want {
+		t.Fatalf("叶子标识为 %s，期望 %s", got, want)
+	}
+}
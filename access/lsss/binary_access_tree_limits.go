@@ -0,0 +1,97 @@
+package lsss
+
+import (
+	"errors"
+	"fmt"
+)
+
+// MaxPolicyNodes 是访问树允许的最大节点个数（叶子与门都计入）。
+// Lewko-Waters 矩阵的行数为叶子个数、列数为 AND 门个数加一，限制节点个数即限制了矩阵规模：
+// 默认值下矩阵最多约 2048 × 2048 个元素。需要更大的策略时可以在程序初始化时调整。
+var MaxPolicyNodes = 4096
+
+// MaxPolicyDepth 是访问树允许的最大层数（与 Depth 的定义一致，只有一个叶子的树为 1 层）。
+// Copy 与矩阵构造都按层递归，限制层数即限制了递归深度。
+// 左结合的 n 元合取/析取链有 n 层，默认值保证 1024 个属性的扁平链可以通过检查。
+var MaxPolicyDepth = 1024
+
+// ErrPolicyTooLarge 表示访问树的节点个数超过 MaxPolicyNodes
+var ErrPolicyTooLarge = errors.New("access policy has too many nodes")
+
+// ErrPolicyTooDeep 表示访问树的层数超过 MaxPolicyDepth
+var ErrPolicyTooDeep = errors.New("access policy is nested too deeply")
+
+// CheckLimits 检查访问树的节点个数与层数是否在 MaxPolicyNodes 与 MaxPolicyDepth 之内。
+// 检查使用显式栈而不是递归，一旦超限立即返回，因此对任意深、任意大的树都不会耗尽栈。
+//
+// 返回值：
+//   - error: 超过节点上限时包装 ErrPolicyTooLarge，超过层数上限时包装 ErrPolicyTooDeep
+func (t *BinaryAccessTree) CheckLimits() error {
+	type frame struct {
+		node  *BinaryAccessTree
+		depth int
+	}
+	nodes := 0
+	stack := []frame{{t, 1}}
+	for len(stack) > 0 {
+		f := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if f.node == nil {
+			continue
+		}
+		nodes++
+		if nodes > MaxPolicyNodes {
+			return fmt.Errorf("%w: limit is %d", ErrPolicyTooLarge, MaxPolicyNodes)
+		}
+		if f.depth > MaxPolicyDepth {
+			return fmt.Errorf("%w: limit is %d", ErrPolicyTooDeep, MaxPolicyDepth)
+		}
+		stack = append(stack, frame{f.node.Right, f.depth + 1}, frame{f.node.Left, f.depth + 1})
+	}
+	return nil
+}
+
+// CopyChecked 先用 CheckLimits 检查访问树，通过后再返回 Copy 得到的深拷贝。
+// 访问树来自不可信输入时应使用该方法代替 Copy。
+//
+// 返回值：
+//   - *BinaryAccessTree: 访问树的深拷贝
+//   - error: 超过 MaxPolicyNodes 或 MaxPolicyDepth 时返回 CheckLimits 的错误
+func (t *BinaryAccessTree) CopyChecked() (*BinaryAccessTree, error) {
+	if err := t.CheckLimits(); err != nil {
+		return nil, err
+	}
+	return t.Copy(), nil
+}
+
+// NewLSSSMatrixFromBinaryTreeChecked 与 NewLSSSMatrixFromBinaryTree 相同，
+// 但在构造矩阵之前先用 CheckLimits 检查访问树。
+//
+// 参数：
+//   - root: 访问树的根节点
+//
+// 返回值：
+//   - *LewkoWatersLsssMatrix: 构造好的LSSS矩阵
+//   - error: 超过 MaxPolicyNodes 或 MaxPolicyDepth 时返回 CheckLimits 的错误
+func NewLSSSMatrixFromBinaryTreeChecked(root *BinaryAccessTree) (*LewkoWatersLsssMatrix, error) {
+	if err := root.CheckLimits(); err != nil {
+		return nil, err
+	}
+	return NewLSSSMatrixFromBinaryTree(root), nil
+}
+
+// NewLSSSMatrixFromNaryTreeChecked 与 NewLSSSMatrixFromNaryTree 相同，
+// 但在构造矩阵之前先用 CheckLimits 检查访问树。
+//
+// 参数：
+//   - root: 访问树的根节点
+//
+// 返回值：
+//   - *LewkoWatersLsssMatrix: 构造好的LSSS矩阵
+//   - error: 超过 MaxPolicyNodes 或 MaxPolicyDepth 时返回 CheckLimits 的错误
+func NewLSSSMatrixFromNaryTreeChecked(root *BinaryAccessTree) (*LewkoWatersLsssMatrix, error) {
+	if err := root.CheckLimits(); err != nil {
+		return nil, err
+	}
+	return NewLSSSMatrixFromNaryTree(root), nil
+}
@@ -0,0 +1,112 @@
+package lsss
+
+import (
+	"encoding/binary"
+	"errors"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/mmsyan/GoPairingBasedCryptography/serialization"
+	"strings"
+	"testing"
+)
+
+// deepAndChain 迭代地构造有 levels 层的左结合 AND 链，构造本身不递归
+func deepAndChain(levels int) *BinaryAccessTree {
+	tree := LeafFromString("A")
+	for i := 1; i < levels; i++ {
+		tree = NewBinaryAccessTree(NodeTypeAnd, fr.Element{}, tree, LeafFromString("B"))
+	}
+	return tree
+}
+
+// withPolicyLimits 临时调整节点与层数上限，测试结束后恢复
+func withPolicyLimits(t *testing.T, nodes, depth int) {
+	t.Helper()
+	oldNodes, oldDepth := MaxPolicyNodes, MaxPolicyDepth
+	MaxPolicyNodes, MaxPolicyDepth = nodes, depth
+	t.Cleanup(func() { MaxPolicyNodes, MaxPolicyDepth = oldNodes, oldDepth })
+}
+
+// TestCheckLimitsExamples 示例访问树都应在默认上限之内
+func TestCheckLimitsExamples(t *testing.T) {
+	trees, _ := GetExamples()
+	for i, tree := range trees {
+		if err := tree.CheckLimits(); err != nil {
+			t.Errorf("示例 %d 超过默认上限: %v", i+1, err)
+		}
+	}
+	if err := deepAndChain(MaxPolicyDepth).CheckLimits(); err != nil {
+		t.Fatalf("恰好 MaxPolicyDepth 层的访问树不应被拒绝: %v", err)
+	}
+}
+
+// TestCheckLimitsTooDeep 超过层数上限的访问树在拷贝和构造矩阵时返回错误而不是崩溃
+func TestCheckLimitsTooDeep(t *testing.T) {
+	tree := deepAndChain(MaxPolicyDepth + 1)
+	if err := tree.CheckLimits(); !errors.Is(err, ErrPolicyTooDeep) {
+		t.Fatalf("期望 ErrPolicyTooDeep，得到 %v", err)
+	}
+	if _, err := tree.CopyChecked(); !errors.Is(err, ErrPolicyTooDeep) {
+		t.Fatalf("CopyChecked 期望 ErrPolicyTooDeep，得到 %v", err)
+	}
+	if _, err := NewLSSSMatrixFromBinaryTreeChecked(tree); !errors.Is(err, ErrPolicyTooDeep) {
+		t.Fatalf("NewLSSSMatrixFromBinaryTreeChecked 期望 ErrPolicyTooDeep，得到 %v", err)
+	}
+	if _, err := NewLSSSMatrixFromNaryTreeChecked(tree); !errors.Is(err, ErrPolicyTooDeep) {
+		t.Fatalf("NewLSSSMatrixFromNaryTreeChecked 期望 ErrPolicyTooDeep，得到 %v", err)
+	}
+
+	// 层数远超上限时检查仍在常量栈空间内完成
+	withPolicyLimits(t, 1<<30, MaxPolicyDepth)
+	if err := deepAndChain(1 << 20).CheckLimits(); !errors.Is(err, ErrPolicyTooDeep) {
+		t.Fatalf("期望 ErrPolicyTooDeep，得到 %v", err)
+	}
+}
+
+// TestCheckLimitsTooLarge 超过节点上限的访问树被拒绝，未超限时结果与不检查的版本一致
+func TestCheckLimitsTooLarge(t *testing.T) {
+	withPolicyLimits(t, 15, MaxPolicyDepth)
+	small := And(Attrs("A", "B", "C", "D", "E", "F", "G", "H")...) // 15 个节点
+	m, err := NewLSSSMatrixFromBinaryTreeChecked(small.Copy())
+	if err != nil {
+		t.Fatalf("15 个节点的访问树不应被拒绝: %v", err)
+	}
+	if m.Fingerprint() != NewLSSSMatrixFromBinaryTree(small.Copy()).Fingerprint() {
+		t.Fatal("检查后构造的矩阵与直接构造的矩阵不同")
+	}
+
+	large := And(small, LeafFromString("I")) // 17 个节点
+	if err := large.CheckLimits(); !errors.Is(err, ErrPolicyTooLarge) {
+		t.Fatalf("期望 ErrPolicyTooLarge，得到 %v", err)
+	}
+	if _, err := large.CopyChecked(); !errors.Is(err, ErrPolicyTooLarge) {
+		t.Fatalf("CopyChecked 期望 ErrPolicyTooLarge，得到 %v", err)
+	}
+}
+
+// TestParseBooleanFormulaLimits 解析器在节点或层数超限时返回错误
+func TestParseBooleanFormulaLimits(t *testing.T) {
+	// 2049 个属性的析取式有 4097 个节点，超过默认节点上限
+	formula := strings.Repeat("A or ", MaxPolicyNodes/2) + "A"
+	if _, err := ParseBooleanFormula(formula); !errors.Is(err, ErrPolicyTooLarge) {
+		t.Fatalf("期望 ErrPolicyTooLarge，得到 %v", err)
+	}
+
+	withPolicyLimits(t, MaxPolicyNodes, 3)
+	if _, err := ParseBooleanFormula("A and B and C"); err != nil {
+		t.Fatalf("3 层的访问树不应被拒绝: %v", err)
+	}
+	if _, err := ParseBooleanFormula("A and B and C and D"); !errors.Is(err, ErrPolicyTooDeep) {
+		t.Fatalf("期望 ErrPolicyTooDeep，得到 %v", err)
+	}
+}
+
+// TestUnmarshalBinaryTooLarge 行数超过节点上限的矩阵编码在分配内存之前被拒绝
+func TestUnmarshalBinaryTooLarge(t *testing.T) {
+	data := serialization.AppendHeader(nil, serialization.SchemeLSSSMatrix)
+	data = binary.BigEndian.AppendUint32(data, uint32(MaxPolicyNodes+1))
+	data = binary.BigEndian.AppendUint32(data, 1)
+	var m LewkoWatersLsssMatrix
+	if err := m.UnmarshalBinary(data); !errors.Is(err, ErrPolicyTooLarge) {
+		t.Fatalf("期望 ErrPolicyTooLarge，得到 %v", err)
+	}
+}
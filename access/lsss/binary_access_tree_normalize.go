@@ -0,0 +1,144 @@
+package lsss
+
+import (
+	"sort"
+	"strings"
+)
+
+// Normalize 返回与 tree 等价的规范化访问树，可用于在转换为 LSSS 矩阵前缩小矩阵规模。
+//
+// 规范化包含以下步骤（均保持可满足性不变）：
+//   - 展平结合链：Or(Or(A,B),C) 与 And(A,And(B,C)) 视为多元 OR/AND
+//   - 去重：同一门下完全相同的子策略只保留一个，例如 A or A => A
+//   - 吸收律：A or (A and B) => A，A and (A or B) => A
+//
+// 在 Lewko-Waters 构造中，每个叶子对应矩阵的一行，每个 AND 门增加一列，OR 门不增加列。
+// 因此单纯展平不会改变矩阵维度，行数与列数的减少来自展平后才能发现的重复子策略和被吸收的分支
+// （例如 OR 链中被吸收掉的 AND 分支会同时减少行和列）。
+// 当前访问树不包含 NOT 节点，因此无需 De Morgan 下推。
+//
+// 参数：
+//   - tree: 待规范化的访问树，不会被修改
+//
+// 返回值：
+//   - *BinaryAccessTree: 规范化后的新访问树（左结合构建），tree 为 nil 时返回 nil
+func Normalize(tree *BinaryAccessTree) *BinaryAccessTree {
+	if tree == nil {
+		return nil
+	}
+	node, _ := normalizeNode(tree)
+	return node
+}
+
+// normalizeNode 递归规范化节点，同时返回节点的规范键。
+// 规范键与子节点顺序无关，相同的键表示逻辑上相同的子策略。
+func normalizeNode(node *BinaryAccessTree) (*BinaryAccessTree, string) {
+	if node.Type == NodeTypeLeave {
+		return Leaf(node.Attribute), leafKey(node)
+	}
+
+	var children []*BinaryAccessTree
+	var keys []string
+	seen := make(map[string]bool)
+	for _, child := range flattenChain(node, node.Type) {
+		normalized, key := normalizeNode(child)
+		// 子节点规范化后可能退化为与当前节点同类型的门，
+		// 例如 Or(And(Or(A,B),Or(A,B)),C) 中的 AND 去重后变为 Or(A,B)，需要再次展平
+		var parts []*BinaryAccessTree
+		var partKeys []string
+		if normalized.Type == node.Type {
+			for _, part := range flattenChain(normalized, node.Type) {
+				p, k := normalizeNode(part)
+				parts = append(parts, p)
+				partKeys = append(partKeys, k)
+			}
+		} else {
+			parts = []*BinaryAccessTree{normalized}
+			partKeys = []string{key}
+		}
+		for i := range parts {
+			if seen[partKeys[i]] {
+				continue
+			}
+			seen[partKeys[i]] = true
+			children = append(children, parts[i])
+			keys = append(keys, partKeys[i])
+		}
+	}
+
+	// 吸收律：若子节点 d 的"对偶门展开集合"是子节点 c 的子集，则 c 被 d 吸收。
+	// 对 OR 门，对偶门为 AND：c = And(d, ...) 蕴含 d，故 d or c = d；AND 门同理。
+	dual := NodeTypeAnd
+	if node.Type == NodeTypeAnd {
+		dual = NodeTypeOr
+	}
+	sets := make([]map[string]bool, len(children))
+	for i, child := range children {
+		sets[i] = dualKeySet(child, keys[i], dual)
+	}
+	var kept []*BinaryAccessTree
+	var keptKeys []string
+	for i := range children {
+		absorbed := false
+		for j := range children {
+			if i != j && isSubset(sets[j], sets[i]) {
+				absorbed = true
+				break
+			}
+		}
+		if !absorbed {
+			kept = append(kept, children[i])
+			keptKeys = append(keptKeys, keys[i])
+		}
+	}
+
+	if len(kept) == 1 {
+		return kept[0], keptKeys[0]
+	}
+	sortedKeys := append([]string(nil), keptKeys...)
+	sort.Strings(sortedKeys)
+	key := string(node.Type) + "(" + strings.Join(sortedKeys, ",") + ")"
+	if node.Type == NodeTypeOr {
+		return Or(kept...), key
+	}
+	return And(kept...), key
+}
+
+// flattenChain 收集以 node 为根、类型均为 op 的连续门下的所有子节点
+func flattenChain(node *BinaryAccessTree, op nodeType) []*BinaryAccessTree {
+	if node.Type != op {
+		return []*BinaryAccessTree{node}
+	}
+	return append(flattenChain(node.Left, op), flattenChain(node.Right, op)...)
+}
+
+// dualKeySet 返回节点作为对偶门时各子节点的规范键集合；非对偶门节点视为只含自身的集合
+func dualKeySet(node *BinaryAccessTree, key string, dual nodeType) map[string]bool {
+	set := make(map[string]bool)
+	if node.Type != dual {
+		set[key] = true
+		return set
+	}
+	for _, part := range flattenChain(node, dual) {
+		_, k := normalizeNode(part)
+		set[k] = true
+	}
+	return set
+}
+
+func isSubset(a, b map[string]bool) bool {
+	if len(a) > len(b) {
+		return false
+	}
+	for k := range a {
+		if !b[k] {
+			return false
+		}
+	}
+	return true
+}
+
+func leafKey(node *BinaryAccessTree) string {
+	b := node.Attribute.Bytes()
+	return "leave:" + string(b[:])
+}
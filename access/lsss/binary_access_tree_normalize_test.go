@@ -0,0 +1,101 @@
+package lsss
+
+import (
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"testing"
+)
+
+// allAttributeSubsets 枚举 names 的全部子集对应的属性集合
+func allAttributeSubsets(names []string) [][]fr.Element {
+	var subsets [][]fr.Element
+	for mask := 0; mask < 1<<len(names); mask++ {
+		var attrs []fr.Element
+		for i, name := range names {
+			if mask&(1<<i) != 0 {
+				attrs = append(attrs, LeafFromString(name).Attribute)
+			}
+		}
+		subsets = append(subsets, attrs)
+	}
+	return subsets
+}
+
+// assertSameSatisfiability 检查两棵访问树对所有属性子集的可满足性完全一致
+func assertSameSatisfiability(t *testing.T, original, normalized *BinaryAccessTree, names []string) {
+	t.Helper()
+	m1 := NewLSSSMatrixFromBinaryTree(original.Copy())
+	m2 := NewLSSSMatrixFromBinaryTree(normalized.Copy())
+	for _, attrs := range allAttributeSubsets(names) {
+		rows1, _ := m1.FindLinearCombinationWeight(attrs)
+		rows2, _ := m2.FindLinearCombinationWeight(attrs)
+		if (rows1 != nil) != (rows2 != nil) {
+			t.Fatalf("规范化前后可满足性不一致: 属性数 %d, 原策略 %v, 规范化后 %v", len(attrs), rows1 != nil, rows2 != nil)
+		}
+	}
+}
+
+func TestNormalizeOrChain(t *testing.T) {
+	// ((((A or B) or A) or (A and C)) or B) or D，共 5 层 OR
+	tree := Or(
+		LeafFromString("A"),
+		LeafFromString("B"),
+		LeafFromString("A"),
+		And(LeafFromString("A"), LeafFromString("C")),
+		LeafFromString("B"),
+		LeafFromString("D"),
+	)
+	normalized := Normalize(tree)
+
+	before := NewLSSSMatrixFromBinaryTree(tree.Copy())
+	after := NewLSSSMatrixFromBinaryTree(normalized.Copy())
+	if before.RowNumber() != 7 || before.ColumnNumber() != 2 {
+		t.Fatalf("原矩阵维度不符合预期: %dx%d", before.RowNumber(), before.ColumnNumber())
+	}
+	if after.RowNumber() != 3 || after.ColumnNumber() != 1 {
+		t.Fatalf("规范化矩阵维度不符合预期: %dx%d", after.RowNumber(), after.ColumnNumber())
+	}
+	assertSameSatisfiability(t, tree, normalized, []string{"A", "B", "C", "D"})
+}
+
+func TestNormalizeAndChain(t *testing.T) {
+	// (A and B) and (B and (C or (C and D)))
+	tree := And(
+		And(LeafFromString("A"), LeafFromString("B")),
+		And(LeafFromString("B"), Or(LeafFromString("C"), And(LeafFromString("C"), LeafFromString("D")))),
+	)
+	normalized := Normalize(tree)
+
+	before := NewLSSSMatrixFromBinaryTree(tree.Copy())
+	after := NewLSSSMatrixFromBinaryTree(normalized.Copy())
+	if after.RowNumber() != 3 || after.ColumnNumber() != 3 {
+		t.Fatalf("规范化矩阵维度不符合预期: %dx%d", after.RowNumber(), after.ColumnNumber())
+	}
+	if after.ColumnNumber() >= before.ColumnNumber() {
+		t.Fatalf("规范化后列数应减少: %d -> %d", before.ColumnNumber(), after.ColumnNumber())
+	}
+	assertSameSatisfiability(t, tree, normalized, []string{"A", "B", "C", "D"})
+}
+
+func TestNormalizeCollapsedChild(t *testing.T) {
+	// (A or B) and (A or B) 会退化为 A or B，随后应并入外层 OR
+	tree := Or(And(Or(LeafFromString("A"), LeafFromString("B")), Or(LeafFromString("B"), LeafFromString("A"))), LeafFromString("C"))
+	normalized := Normalize(tree)
+	m := NewLSSSMatrixFromBinaryTree(normalized.Copy())
+	if m.RowNumber() != 3 || m.ColumnNumber() != 1 {
+		t.Fatalf("规范化矩阵维度不符合预期: %dx%d", m.RowNumber(), m.ColumnNumber())
+	}
+	assertSameSatisfiability(t, tree, normalized, []string{"A", "B", "C"})
+}
+
+func TestNormalizeExamples(t *testing.T) {
+	trees, formulas := GetExamples()
+	for i := range trees {
+		normalized := Normalize(trees[i])
+		before := NewLSSSMatrixFromBinaryTree(trees[i].Copy())
+		after := NewLSSSMatrixFromBinaryTree(normalized.Copy())
+		if after.RowNumber() > before.RowNumber() || after.ColumnNumber() > before.ColumnNumber() {
+			t.Fatalf("%s: 规范化后矩阵不应变大", formulas[i])
+		}
+		assertSameSatisfiability(t, trees[i], normalized, []string{"A", "B", "C", "D", "E"})
+	}
+}
@@ -0,0 +1,271 @@
+package lsss
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/mmsyan/GoPairingBasedCryptography/hash"
+)
+
+// DefaultMaxNestingDepth 是 ParseBooleanFormula 允许的最大括号嵌套深度，
+// 用于限制递归下降解析时的栈使用量。
+const DefaultMaxNestingDepth = 64
+
+// ParseError 表示布尔公式解析错误，Pos 为出错位置的字节偏移（从 0 开始）。
+type ParseError struct {
+	Pos int
+	Msg string
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("%s at position %d", e.Msg, e.Pos)
+}
+
+// tokenType 定义 token 类型
+type tokenType int
+
+const (
+	tokenEOF tokenType = iota
+	tokenAttribute
+	tokenAnd
+	tokenOr
+	tokenLeftParen
+	tokenRightParen
+)
+
+// token 表示一个词法单元，pos 为其在输入中的起始字节偏移
+type token struct {
+	typ   tokenType
+	value string
+	pos   int
+}
+
+func (t token) describe() string {
+	if t.typ == tokenEOF {
+		return "end of input"
+	}
+	return fmt.Sprintf("token '%s'", t.value)
+}
+
+// lexer 词法分析器，按字节扫描输入
+type lexer struct {
+	input string
+	pos   int
+}
+
+func isIdentifierByte(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+func isSpaceByte(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\v' || c == '\f'
+}
+
+// next 返回下一个 token，遇到非法字符时返回 *ParseError
+func (l *lexer) next() (token, error) {
+	for l.pos < len(l.input) && isSpaceByte(l.input[l.pos]) {
+		l.pos++
+	}
+	if l.pos >= len(l.input) {
+		return token{typ: tokenEOF, pos: l.pos}, nil
+	}
+
+	start := l.pos
+	c := l.input[l.pos]
+	switch {
+	case c == '(':
+		l.pos++
+		return token{typ: tokenLeftParen, value: "(", pos: start}, nil
+	case c == ')':
+		l.pos++
+		return token{typ: tokenRightParen, value: ")", pos: start}, nil
+	case isIdentifierByte(c):
+		for l.pos < len(l.input) && isIdentifierByte(l.input[l.pos]) {
+			l.pos++
+		}
+		ident := l.input[start:l.pos]
+		switch strings.ToLower(ident) {
+		case "and":
+			return token{typ: tokenAnd, value: ident, pos: start}, nil
+		case "or":
+			return token{typ: tokenOr, value: ident, pos: start}, nil
+		default:
+			return token{typ: tokenAttribute, value: ident, pos: start}, nil
+		}
+	default:
+		return token{}, &ParseError{Pos: start, Msg: fmt.Sprintf("unexpected character %q", c)}
+	}
+}
+
+// parser 递归下降语法分析器
+//
+// 文法:
+//
+//	or      := and ( "or" and )*
+//	and     := primary ( "and" primary )*
+//	primary := attribute | "(" or ")"
+type parser struct {
+	lexer    lexer
+	cur      token
+	depth    int
+	maxDepth int
+	nodes    int // 已创建的节点个数，超过 MaxPolicyNodes 时立即停止解析
+}
+
+// newNode 创建访问树节点并计数，节点个数超过 MaxPolicyNodes 时返回包装 ErrPolicyTooLarge 的错误
+func (p *parser) newNode(nodeType nodeType, attr fr.Element, left, right *BinaryAccessTree) (*BinaryAccessTree, error) {
+	p.nodes++
+	if p.nodes > MaxPolicyNodes {
+		return nil, fmt.Errorf("%w: limit is %d, exceeded at position %d", ErrPolicyTooLarge, MaxPolicyNodes, p.cur.pos)
+	}
+	return NewBinaryAccessTree(nodeType, attr, left, right), nil
+}
+
+func (p *parser) advance() error {
+	tok, err := p.lexer.next()
+	if err != nil {
+		return err
+	}
+	p.cur = tok
+	return nil
+}
+
+// parseOr 解析 OR 表达式（最低优先级），结果为左结合的二叉树
+func (p *parser) parseOr() (*BinaryAccessTree, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.cur.typ == tokenOr {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left, err = p.newNode(NodeTypeOr, fr.Element{}, left, right)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return left, nil
+}
+
+// parseAnd 解析 AND 表达式（较高优先级），结果为左结合的二叉树
+func (p *parser) parseAnd() (*BinaryAccessTree, error) {
+	left, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	for p.cur.typ == tokenAnd {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		right, err := p.parsePrimary()
+		if err != nil {
+			return nil, err
+		}
+		left, err = p.newNode(NodeTypeAnd, fr.Element{}, left, right)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return left, nil
+}
+
+// parsePrimary 解析基本表达式（属性或括号表达式）
+func (p *parser) parsePrimary() (*BinaryAccessTree, error) {
+	switch p.cur.typ {
+	case tokenAttribute:
+		leaf, err := p.newNode(NodeTypeLeave, hash.ToField(p.cur.value), nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		return leaf, nil
+
+	case tokenLeftParen:
+		open := p.cur
+		p.depth++
+		if p.depth > p.maxDepth {
+			return nil, &ParseError{Pos: open.pos, Msg: fmt.Sprintf("nesting depth exceeds limit %d", p.maxDepth)}
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.cur.typ != tokenRightParen {
+			return nil, &ParseError{Pos: p.cur.pos, Msg: fmt.Sprintf("expected ')' to close '(' at position %d, got %s", open.pos, p.cur.describe())}
+		}
+		p.depth--
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		return expr, nil
+
+	default:
+		return nil, &ParseError{Pos: p.cur.pos, Msg: "unexpected " + p.cur.describe()}
+	}
+}
+
+// ParseBooleanFormula 解析布尔表达式字符串并返回二叉访问树，
+// 括号嵌套深度限制为 DefaultMaxNestingDepth，得到的访问树受 MaxPolicyNodes 与 MaxPolicyDepth 限制。
+// 属性名由字母、数字和下划线组成，运算符 and/or 大小写不敏感，and 的优先级高于 or。
+//
+// 参数:
+//   - formula: 布尔表达式，例如 "(A and B) or C"
+//
+// 返回值:
+//   - *BinaryAccessTree: 解析得到的访问树
+//   - error: 解析失败时返回 *ParseError，包含出错位置的字节偏移；
+//     访问树超过节点上限或层数上限时返回包装 ErrPolicyTooLarge 或 ErrPolicyTooDeep 的错误
+func ParseBooleanFormula(formula string) (*BinaryAccessTree, error) {
+	return ParseBooleanFormulaWithMaxDepth(formula, DefaultMaxNestingDepth)
+}
+
+// ParseBooleanFormulaWithMaxDepth 与 ParseBooleanFormula 相同，但允许指定最大括号嵌套深度。
+//
+// 参数:
+//   - formula: 布尔表达式
+//   - maxDepth: 允许的最大括号嵌套深度
+//
+// 返回值:
+//   - *BinaryAccessTree: 解析得到的访问树
+//   - error: 解析失败时返回 *ParseError，超过 MaxPolicyNodes 或 MaxPolicyDepth 时返回 CheckLimits 的错误
+func ParseBooleanFormulaWithMaxDepth(formula string, maxDepth int) (*BinaryAccessTree, error) {
+	p := &parser{lexer: lexer{input: formula}, maxDepth: maxDepth}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	if p.cur.typ == tokenEOF {
+		return nil, &ParseError{Pos: p.cur.pos, Msg: "empty formula"}
+	}
+	tree, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.cur.typ != tokenEOF {
+		return nil, &ParseError{Pos: p.cur.pos, Msg: "unexpected " + p.cur.describe()}
+	}
+	// 节点个数已在解析过程中限制，这里检查 and/or 链形成的层数
+	if err := tree.CheckLimits(); err != nil {
+		return nil, err
+	}
+	return tree, nil
+}
+
+// MustParseBooleanFormula 解析布尔表达式，如果失败则 panic（方便测试使用）
+func MustParseBooleanFormula(formula string) *BinaryAccessTree {
+	tree, err := ParseBooleanFormula(formula)
+	if err != nil {
+		panic(fmt.Sprintf("failed to parse formula '%s': %v", formula, err))
+	}
+	return tree
+}
@@ -0,0 +1,232 @@
+package lsss
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/mmsyan/GoPairingBasedCryptography/hash"
+)
+
+// TestParseBooleanFormula_SimpleExpressions 测试简单表达式
+func TestParseBooleanFormula_SimpleExpressions(t *testing.T) {
+	tests := []struct {
+		name     string
+		formula  string
+		wantType nodeType
+	}{
+		{name: "Simple OR", formula: "A or B", wantType: NodeTypeOr},
+		{name: "Simple AND", formula: "A and B", wantType: NodeTypeAnd},
+		{name: "Single attribute", formula: "A", wantType: NodeTypeLeave},
+		{name: "OR with parentheses", formula: "(A or B)", wantType: NodeTypeOr},
+		{name: "AND with parentheses", formula: "(A and B)", wantType: NodeTypeAnd},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tree, err := ParseBooleanFormula(tt.formula)
+			if err != nil {
+				t.Fatalf("ParseBooleanFormula() error = %v", err)
+			}
+			if tree.Type != tt.wantType {
+				t.Errorf("ParseBooleanFormula() Type = %v, want %v", tree.Type, tt.wantType)
+			}
+		})
+	}
+}
+
+// TestParseBooleanFormula_OperatorPrecedence 测试运算符优先级
+func TestParseBooleanFormula_OperatorPrecedence(t *testing.T) {
+	tests := []struct {
+		name         string
+		formula      string
+		wantRootType nodeType
+		wantLeftType nodeType
+	}{
+		{name: "AND has higher precedence than OR", formula: "A or B and C", wantRootType: NodeTypeOr, wantLeftType: NodeTypeLeave},
+		{name: "Parentheses override precedence", formula: "(A or B) and C", wantRootType: NodeTypeAnd, wantLeftType: NodeTypeOr},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tree, err := ParseBooleanFormula(tt.formula)
+			if err != nil {
+				t.Fatalf("ParseBooleanFormula() error = %v", err)
+			}
+			if tree.Type != tt.wantRootType {
+				t.Errorf("Root Type = %v, want %v", tree.Type, tt.wantRootType)
+			}
+			if tree.Left != nil && tree.Left.Type != tt.wantLeftType {
+				t.Errorf("Left Type = %v, want %v", tree.Left.Type, tt.wantLeftType)
+			}
+		})
+	}
+}
+
+// TestParseBooleanFormula_AttributeValues 测试属性值是否正确设置
+func TestParseBooleanFormula_AttributeValues(t *testing.T) {
+	tree, err := ParseBooleanFormula("A or B")
+	if err != nil {
+		t.Fatalf("ParseBooleanFormula() error = %v", err)
+	}
+	if tree.Left == nil || tree.Left.Type != NodeTypeLeave || tree.Left.Attribute != hash.ToField("A") {
+		t.Errorf("Left child mismatch")
+	}
+	if tree.Right == nil || tree.Right.Type != NodeTypeLeave || tree.Right.Attribute != hash.ToField("B") {
+		t.Errorf("Right child mismatch")
+	}
+}
+
+// TestParseBooleanFormula_CaseInsensitiveAndWhitespace 测试操作符大小写不敏感与空白字符处理
+func TestParseBooleanFormula_CaseInsensitiveAndWhitespace(t *testing.T) {
+	formulas := []string{
+		"a or b and c",
+		"A OR B AND C",
+		"A Or B AnD C",
+		"(A or B)and C",
+		"  ( A   or   B )  and  C  ",
+		"A\tor\nB",
+		"User_Role or Admin123",
+	}
+	for _, formula := range formulas {
+		if _, err := ParseBooleanFormula(formula); err != nil {
+			t.Errorf("ParseBooleanFormula(%q) error = %v", formula, err)
+		}
+	}
+}
+
+// TestParseBooleanFormula_Errors 测试错误情况及其报告的位置
+func TestParseBooleanFormula_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		formula string
+		wantPos int
+		wantMsg string
+	}{
+		{name: "Missing closing parenthesis", formula: "(A or B", wantPos: 7, wantMsg: "expected ')' to close '(' at position 0, got end of input at position 7"},
+		{name: "Missing opening parenthesis", formula: "A or B)", wantPos: 6, wantMsg: "unexpected token ')' at position 6"},
+		{name: "Empty expression", formula: "", wantPos: 0, wantMsg: "empty formula at position 0"},
+		{name: "Whitespace only", formula: "   ", wantPos: 3, wantMsg: "empty formula at position 3"},
+		{name: "Only operator", formula: "and", wantPos: 0, wantMsg: "unexpected token 'and' at position 0"},
+		{name: "Missing operand", formula: "A or", wantPos: 4, wantMsg: "unexpected end of input at position 4"},
+		{name: "Dangling operator before paren", formula: "(A or)", wantPos: 5, wantMsg: "unexpected token ')' at position 5"},
+		{name: "Adjacent attributes", formula: "A B", wantPos: 2, wantMsg: "unexpected token 'B' at position 2"},
+		{name: "Invalid character", formula: "A & B", wantPos: 2, wantMsg: "unexpected character '&' at position 2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseBooleanFormula(tt.formula)
+			var parseErr *ParseError
+			if !errors.As(err, &parseErr) {
+				t.Fatalf("expected *ParseError for formula %q, got %v", tt.formula, err)
+			}
+			if parseErr.Pos != tt.wantPos {
+				t.Errorf("Pos = %d, want %d", parseErr.Pos, tt.wantPos)
+			}
+			if err.Error() != tt.wantMsg {
+				t.Errorf("Error() = %q, want %q", err.Error(), tt.wantMsg)
+			}
+		})
+	}
+}
+
+// TestParseBooleanFormula_MaxDepth 测试最大嵌套深度限制
+func TestParseBooleanFormula_MaxDepth(t *testing.T) {
+	nested := func(depth int) string {
+		return strings.Repeat("(", depth) + "A" + strings.Repeat(")", depth)
+	}
+
+	if _, err := ParseBooleanFormulaWithMaxDepth(nested(3), 3); err != nil {
+		t.Fatalf("depth 3 should be accepted: %v", err)
+	}
+	if _, err := ParseBooleanFormulaWithMaxDepth(nested(4), 3); err == nil {
+		t.Fatal("depth 4 should exceed limit 3")
+	}
+	if _, err := ParseBooleanFormula(nested(100000)); err == nil {
+		t.Fatal("pathological nesting should be rejected")
+	}
+}
+
+// TestMustParseBooleanFormula_Panic 测试 MustParse 在错误时会 panic
+func TestMustParseBooleanFormula_Panic(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("MustParseBooleanFormula() should panic on invalid formula")
+		}
+	}()
+	MustParseBooleanFormula("(A or B")
+}
+
+// TestParseBooleanFormula_CompareWithExamples 测试与预定义示例的兼容性
+func TestParseBooleanFormula_CompareWithExamples(t *testing.T) {
+	exampleTrees, formulas := GetExamples()
+
+	for i, formula := range formulas {
+		t.Run(formula, func(t *testing.T) {
+			parsed, err := ParseBooleanFormula(formula)
+			if err != nil {
+				t.Fatalf("ParseBooleanFormula() error = %v", err)
+			}
+			if !compareTreeStructure(parsed, exampleTrees[i]) {
+				t.Errorf("Tree structure mismatch for formula: %s", formula)
+			}
+		})
+	}
+}
+
+// compareTreeStructure 递归比较两棵树的结构是否相同
+func compareTreeStructure(t1, t2 *BinaryAccessTree) bool {
+	if t1 == nil && t2 == nil {
+		return true
+	}
+	if t1 == nil || t2 == nil {
+		return false
+	}
+	if t1.Type != t2.Type {
+		return false
+	}
+	if t1.Type == NodeTypeLeave {
+		return t1.Attribute == t2.Attribute
+	}
+	return compareTreeStructure(t1.Left, t2.Left) && compareTreeStructure(t1.Right, t2.Right)
+}
+
+// FuzzParseBooleanFormula 模糊测试：任意输入都不能导致 panic，且错误必须是带位置信息的 *ParseError
+func FuzzParseBooleanFormula(f *testing.F) {
+	_, formulas := GetExamples()
+	for _, formula := range formulas {
+		f.Add(formula)
+	}
+	f.Add("")
+	f.Add("(A or B")
+	f.Add("A or B)")
+	f.Add("A or")
+	f.Add("and")
+	f.Add(strings.Repeat("(", 200) + "A")
+
+	f.Fuzz(func(t *testing.T, formula string) {
+		tree, err := ParseBooleanFormula(formula)
+		if err != nil {
+			var parseErr *ParseError
+			if !errors.As(err, &parseErr) {
+				t.Fatalf("error is not *ParseError: %v", err)
+			}
+			if parseErr.Pos < 0 || parseErr.Pos > len(formula) {
+				t.Fatalf("error position %d out of range for input of length %d", parseErr.Pos, len(formula))
+			}
+			return
+		}
+		if tree == nil {
+			t.Fatal("nil tree without error")
+		}
+	})
+}
+
+// BenchmarkParseBooleanFormula_Complex 复杂表达式性能测试
+func BenchmarkParseBooleanFormula_Complex(b *testing.B) {
+	formula := "(((A and B) or (C and D)) or ((A or B) and (C or D)))"
+	for i := 0; i < b.N; i++ {
+		_, _ = ParseBooleanFormula(formula)
+	}
+}
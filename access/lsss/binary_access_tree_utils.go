@@ -98,6 +98,30 @@ func Attrs(names ...string) []*BinaryAccessTree {
 	return nodes
 }
 
+// AtLeast 创建一个门限子树：attrs 中至少 k 个属性同时满足时该子树才满足，例如 AtLeast(2, "A", "B", "C")。
+// 访问树只有 AND/OR 两种门，门限按 T(k, a₁…aₙ) = (a₁ AND T(k-1, a₂…aₙ)) OR T(k, a₂…aₙ) 递归展开，
+// T(1, ·) 为析取式、T(n, ·) 为合取式。同一属性会出现在多个叶子上，叶子数量随 C(n, k) 增长，
+// 属性较多时应配合 CheckLimits 使用。attrs 中的属性应互不相同，重复的属性会被计入多次。
+//
+// 参数:
+//   - k: 门限值，需满足 1 <= k <= len(attrs)
+//   - attrs: 属性名称
+//
+// 返回值:
+//   - *BinaryAccessTree: 门限子树，每次调用都返回新的节点
+func AtLeast(k int, attrs ...string) *BinaryAccessTree {
+	if k < 1 || k > len(attrs) {
+		panic("AtLeast() requires 1 <= k <= len(attrs)")
+	}
+	switch k {
+	case 1:
+		return Or(Attrs(attrs...)...)
+	case len(attrs):
+		return And(Attrs(attrs...)...)
+	}
+	return Or(And(LeafFromString(attrs[0]), AtLeast(k-1, attrs[1:]...)), AtLeast(k, attrs[1:]...))
+}
+
 //// 预定义的别名，提供更短的函数名
 //var (
 //	// L 是 LeafFromString 的简写
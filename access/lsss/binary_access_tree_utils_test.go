@@ -3,6 +3,7 @@ package lsss
 import (
 	"testing"
 
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
 	"github.com/mmsyan/GoPairingBasedCryptography/hash"
 )
 
@@ -351,6 +352,61 @@ func ExampleAnd() {
 //	_ = tree // ((A and B) or (C and D))
 //}
 
+// TestAtLeast 对 {A,B,C,D} 的每个子集检查 AtLeast(k, ...) 构造的矩阵恰好在子集大小不小于 k 时可以重构秘密
+func TestAtLeast(t *testing.T) {
+	names := []string{"A", "B", "C", "D"}
+	for k := 1; k <= len(names); k++ {
+		matrix := NewLSSSMatrixFromBinaryTree(AtLeast(k, names...))
+		for mask := 0; mask < 1<<len(names); mask++ {
+			var attrs []fr.Element
+			for i, name := range names {
+				if mask&(1<<i) != 0 {
+					attrs = append(attrs, hash.ToField(name))
+				}
+			}
+			rows, weights := matrix.FindLinearCombinationWeight(attrs)
+			if satisfied := rows != nil; satisfied != (len(attrs) >= k) {
+				t.Fatalf("AtLeast(%d): %d 个属性的满足结果为 %v", k, len(attrs), satisfied)
+			}
+			if rows == nil {
+				continue
+			}
+			// 权重组合出的向量应为 (1, 0, ..., 0)
+			for col := 0; col < matrix.ColumnNumber(); col++ {
+				unit := make([]fr.Element, matrix.ColumnNumber())
+				unit[col].SetOne()
+				var sum fr.Element
+				for j, row := range rows {
+					entry := matrix.ComputeVector(row, unit)
+					entry.Mul(&entry, &weights[j])
+					sum.Add(&sum, &entry)
+				}
+				var want fr.Element
+				if col == 0 {
+					want.SetOne()
+				}
+				if !sum.Equal(&want) {
+					t.Fatalf("AtLeast(%d): 第 %d 列的组合结果错误", k, col)
+				}
+			}
+		}
+	}
+}
+
+// TestAtLeastInvalidThreshold 门限值超出 [1, len(attrs)] 时 panic
+func TestAtLeastInvalidThreshold(t *testing.T) {
+	for _, k := range []int{0, 3} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("AtLeast(%d, A, B) 应当 panic", k)
+				}
+			}()
+			AtLeast(k, "A", "B")
+		}()
+	}
+}
+
 // ExampleAttrs 示例：批量创建属性
 func ExampleAttrs() {
 	tree := Or(Attrs("A", "B", "C")...)
@@ -0,0 +1,49 @@
+package lsss
+
+import (
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+)
+
+// Walk 以先序（根-左-右）方式遍历访问树，对每个节点调用 visit
+// 参数 visit 接收当前节点及其深度，根节点深度为 0
+func (t *BinaryAccessTree) Walk(visit func(node *BinaryAccessTree, depth int)) {
+	t.walk(visit, 0)
+}
+
+func (t *BinaryAccessTree) walk(visit func(node *BinaryAccessTree, depth int), depth int) {
+	if t == nil {
+		return
+	}
+	visit(t, depth)
+	t.Left.walk(visit, depth+1)
+	t.Right.walk(visit, depth+1)
+}
+
+// Attributes 返回访问树中出现的全部属性（去重），顺序为叶子节点从左到右首次出现的顺序
+func (t *BinaryAccessTree) Attributes() []fr.Element {
+	var attributes []fr.Element
+	seen := make(map[fr.Element]struct{})
+	t.Walk(func(node *BinaryAccessTree, depth int) {
+		if node.Type != NodeTypeLeave {
+			return
+		}
+		if _, ok := seen[node.Attribute]; ok {
+			return
+		}
+		seen[node.Attribute] = struct{}{}
+		attributes = append(attributes, node.Attribute)
+	})
+	return attributes
+}
+
+// Depth 返回访问树的层数，即从根到最深叶子路径上的节点个数
+// 只有一个叶子节点的树深度为 1，空树深度为 0
+func (t *BinaryAccessTree) Depth() int {
+	depth := 0
+	t.Walk(func(node *BinaryAccessTree, d int) {
+		if d+1 > depth {
+			depth = d + 1
+		}
+	})
+	return depth
+}
@@ -0,0 +1,64 @@
+package lsss
+
+import (
+	"testing"
+
+	"github.com/mmsyan/GoPairingBasedCryptography/hash"
+)
+
+// TestAttributesExample15 测试 Example15 的属性集合为 {A,B,C,D,E}
+func TestAttributesExample15(t *testing.T) {
+	tree, formula := GetExample15()
+	got := tree.Attributes()
+
+	want := []string{"E", "A", "B", "C", "D"}
+	if len(got) != len(want) {
+		t.Fatalf("Attributes() of %s returned %d attributes, want %d", formula, len(got), len(want))
+	}
+	for i, name := range want {
+		if got[i] != hash.ToField(name) {
+			t.Errorf("Attributes()[%d] mismatch, want %s", i, name)
+		}
+	}
+}
+
+// TestDepthExample15 测试 Example15 的深度
+// (E and (((A and B) or (C and D)) or ((A or B) and (C or D))))
+// 最深路径为 and -> or -> or -> and -> A，共 5 层
+func TestDepthExample15(t *testing.T) {
+	tree, formula := GetExample15()
+	if depth := tree.Depth(); depth != 5 {
+		t.Errorf("Depth() of %s = %d, want 5", formula, depth)
+	}
+
+	if depth := LeafFromString("A").Depth(); depth != 1 {
+		t.Errorf("Depth() of a single leaf = %d, want 1", depth)
+	}
+	var empty *BinaryAccessTree
+	if depth := empty.Depth(); depth != 0 {
+		t.Errorf("Depth() of a nil tree = %d, want 0", depth)
+	}
+}
+
+// TestWalkVisitsAllNodes 测试 Walk 按先序访问所有节点
+func TestWalkVisitsAllNodes(t *testing.T) {
+	tree := And(Or(LeafFromString("A"), LeafFromString("B")), LeafFromString("C"))
+
+	var types []nodeType
+	var depths []int
+	tree.Walk(func(node *BinaryAccessTree, depth int) {
+		types = append(types, node.Type)
+		depths = append(depths, depth)
+	})
+
+	wantTypes := []nodeType{NodeTypeAnd, NodeTypeOr, NodeTypeLeave, NodeTypeLeave, NodeTypeLeave}
+	wantDepths := []int{0, 1, 2, 2, 1}
+	if len(types) != len(wantTypes) {
+		t.Fatalf("Walk() visited %d nodes, want %d", len(types), len(wantTypes))
+	}
+	for i := range wantTypes {
+		if types[i] != wantTypes[i] || depths[i] != wantDepths[i] {
+			t.Errorf("Walk() node %d = (%s, %d), want (%s, %d)", i, types[i], depths[i], wantTypes[i], wantDepths[i])
+		}
+	}
+}
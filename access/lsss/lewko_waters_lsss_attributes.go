@@ -0,0 +1,101 @@
+package lsss
+
+import (
+	"errors"
+	"fmt"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/mmsyan/GoPairingBasedCryptography/hash"
+	"github.com/mmsyan/GoPairingBasedCryptography/utils"
+)
+
+// ErrUnknownAttribute 表示属性不在调用方提供的属性字典中
+var ErrUnknownAttribute = errors.New("attribute not found in dictionary")
+
+// DistinctAttributes 返回矩阵中出现的全部属性，重复的属性只保留第一次出现，顺序与 rho 一致
+//
+// 与 Attributes 不同，返回值是新切片，不与矩阵共享底层数组。
+//
+// 返回值：
+//   - []fr.Element: 去重后的属性列表
+func (m *LewkoWatersLsssMatrix) DistinctAttributes() []fr.Element {
+	return utils.DedupAttributes(m.rho)
+}
+
+// AttributeNames 用属性字典把属性逐个反查为名称
+//
+// 参数：
+//   - attributes: 要反查的属性列表
+//   - dictionary: 属性到名称的映射，通常由 hash.ToField(name) -> name 构成，可以直接传入 AttributeDictionary
+//
+// 返回值：
+//   - []string: 与 attributes 一一对应的名称
+//   - error: 某个属性不在字典中时返回包装了 ErrUnknownAttribute 的错误，指明该属性（十六进制）
+func AttributeNames(attributes []fr.Element, dictionary map[fr.Element]string) ([]string, error) {
+	names := make([]string, len(attributes))
+	for i, a := range attributes {
+		name, ok := dictionary[a]
+		if !ok {
+			b := a.Bytes()
+			return nil, fmt.Errorf("attribute %x: %w", b[:], ErrUnknownAttribute)
+		}
+		names[i] = name
+	}
+	return names, nil
+}
+
+// AttributeDictionary 记录已知属性名称与其域元素编码 hash.ToField(name) 之间的对应关系，
+// 用于在调试输出中把不透明的域元素还原为属性名称
+//
+// 底层类型即 map[fr.Element]string，可以直接传给 AttributeNames、ExplainWithNames 等接受属性字典的函数。
+type AttributeDictionary map[fr.Element]string
+
+// NewAttributeDictionary 由属性名称构造属性字典
+//
+// 参数：
+//   - names: 属性名称，编码方式与 ParseBooleanFormula、NewLeafNode 相同
+//
+// 返回值：
+//   - AttributeDictionary: 包含全部名称的属性字典
+func NewAttributeDictionary(names ...string) AttributeDictionary {
+	d := make(AttributeDictionary, len(names))
+	d.Add(names...)
+	return d
+}
+
+// Add 向字典中加入属性名称
+func (d AttributeDictionary) Add(names ...string) {
+	for _, name := range names {
+		d[hash.ToField(name)] = name
+	}
+}
+
+// Element 返回已知属性名称的域元素编码
+//
+// 返回值：
+//   - fr.Element: 属性的域元素编码
+//   - bool: 名称不在字典中时为 false
+func (d AttributeDictionary) Element(name string) (fr.Element, bool) {
+	e := hash.ToField(name)
+	known, ok := d[e]
+	return e, ok && known == name
+}
+
+// Name 反查属性的名称
+//
+// 返回值：
+//   - string: 属性名称
+//   - bool: 属性不在字典中时为 false
+func (d AttributeDictionary) Name(attribute fr.Element) (string, bool) {
+	name, ok := d[attribute]
+	return name, ok
+}
+
+// Describe 返回属性的可读标识：在字典中时为名称，否则为编码前 4 字节的十六进制。
+// 字典为 nil 时同样可用，所有属性都以十六进制标识
+func (d AttributeDictionary) Describe(attribute fr.Element) string {
+	if name, ok := d[attribute]; ok {
+		return name
+	}
+	b := attribute.Bytes()
+	return fmt.Sprintf("%x", b[:4])
+}
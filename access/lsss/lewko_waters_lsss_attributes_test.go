@@ -0,0 +1,60 @@
+package lsss
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/mmsyan/GoPairingBasedCryptography/hash"
+	"strings"
+	"testing"
+)
+
+// TestAttributeDictionary 正向查找只接受字典中的名称，反向查找与 Describe 对未知属性给出十六进制标识
+func TestAttributeDictionary(t *testing.T) {
+	dictionary := NewAttributeDictionary("A", "B")
+	a := hash.ToField("A")
+	if e, ok := dictionary.Element("A"); !ok || !e.Equal(&a) {
+		t.Fatal("A 应在字典中且编码为 hash.ToField(\"A\")")
+	}
+	if _, ok := dictionary.Element("C"); ok {
+		t.Fatal("C 不应在字典中")
+	}
+	// hash.ToField 把字节串按大整数解释，"\x00A" 与 "A" 的编码相同，但不是字典中记录的名称
+	if _, ok := dictionary.Element("\x00A"); ok {
+		t.Fatal("与 A 编码相同的其他名称不应被视为已知名称")
+	}
+	if name, ok := dictionary.Name(hash.ToField("B")); !ok || name != "B" {
+		t.Fatalf("反查 B 得到 %q, %v", name, ok)
+	}
+	c := hash.ToField("C")
+	b := c.Bytes()
+	if got := dictionary.Describe(c); got != fmt.Sprintf("%x", b[:4]) {
+		t.Fatalf("未知属性的标识为 %q", got)
+	}
+	dictionary.Add("C")
+	if got := dictionary.Describe(c); got != "C" {
+		t.Fatalf("加入字典后 C 的标识为 %q", got)
+	}
+}
+
+// TestPrintWithNames 为 {A,B,C,D,E} 构造字典后，矩阵输出中的属性显示为名称；不提供字典时输出保持原样
+func TestPrintWithNames(t *testing.T) {
+	tree, _ := GetExample15()
+	matrix := NewLSSSMatrixFromBinaryTree(tree)
+	dictionary := NewAttributeDictionary("A", "B", "C", "D", "E")
+
+	var named, plain bytes.Buffer
+	matrix.Fprint(&named, dictionary)
+	matrix.Fprint(&plain, nil)
+	for _, name := range []string{"A", "B", "C", "D", "E"} {
+		if !strings.Contains(named.String(), "attribute: "+name+" ||") {
+			t.Fatalf("输出中缺少属性 %s:\n%s", name, named.String())
+		}
+		e := hash.ToField(name)
+		if !strings.Contains(plain.String(), "attribute: "+e.String()+" ||") {
+			t.Fatalf("不提供字典时属性 %s 应以十进制值显示:\n%s", name, plain.String())
+		}
+	}
+	if got := strings.Count(named.String(), "index "); got != matrix.RowNumber() {
+		t.Fatalf("输出了 %d 行，矩阵有 %d 行", got, matrix.RowNumber())
+	}
+}
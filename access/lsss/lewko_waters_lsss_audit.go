@@ -0,0 +1,16 @@
+package lsss
+
+// DecryptionAuditor 接收基于 LSSS 策略的 ABE 方案（Waters11、LW11 DABE）的每一次解密尝试，
+// 用于合规审计。回调只得到策略指纹与满足的行，不会得到明文或私钥。
+//
+// 回调在 Decrypt 返回前同步调用，可能被多个 goroutine 同时调用，实现需要自行保证并发安全，
+// 并且应尽快返回，耗时操作（写文件、发送网络请求）应交给其他 goroutine。
+type DecryptionAuditor interface {
+	// OnDecryptAttempt 在每次解密尝试结束时调用
+	//
+	// 参数：
+	//   - policyFingerprint: 密文访问矩阵的 Fingerprint
+	//   - success: 解密是否成功
+	//   - satisfiedRows: 解密使用的矩阵行索引（按 rho 映射到属性即为满足策略的属性）；失败时为 nil
+	OnDecryptAttempt(policyFingerprint [32]byte, success bool, satisfiedRows []int)
+}
@@ -0,0 +1,135 @@
+package lsss
+
+import (
+	"fmt"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+)
+
+// LSSSBuilder 增量构造Lewko-Waters LSSS矩阵
+//
+// NewLSSSMatrixFromBinaryTree 对访问树的任何修改都需要重新遍历整棵树，
+// LSSSBuilder 则直接在已有的行向量上执行与其相同的counter/向量规则，每次编辑只修改受影响的行：
+//   - OR：新行复制被扩展节点的向量
+//   - AND：一个子节点为 (v, 0, ..., 0, 1)，另一个为 (0, ..., 0, -1)，新列的下标为counter，之后counter加一
+//
+// 对根节点做AND时，原策略中所有继承了根向量的行都需要在新列写入1。
+// 由于只有根向量的第一列非零、AND的另一个子节点第一列为0，某行继承根向量当且仅当其第一列为1，
+// 因此无需保存访问树即可定位这些行。
+type LSSSBuilder struct {
+	counter int            // 当前列数
+	rows    [][]fr.Element // 行向量，长度可能小于counter，缺失部分视为0
+	rho     []fr.Element   // 行索引到属性的映射
+}
+
+// NewLSSSBuilder 创建只包含单个属性的策略
+//
+// 参数：
+//   - attr: 初始属性
+//
+// 返回值：
+//   - *LSSSBuilder: 增量构造器
+func NewLSSSBuilder(attr fr.Element) *LSSSBuilder {
+	return &LSSSBuilder{
+		counter: 1,
+		rows:    [][]fr.Element{{fr.NewElement(1)}},
+		rho:     []fr.Element{attr},
+	}
+}
+
+// AddOrClause 将当前策略P修改为 (P or attr)
+//
+// 新行复制根向量 (1, 0, ..., 0)，其余行不变。
+func (b *LSSSBuilder) AddOrClause(attr fr.Element) {
+	b.rows = append(b.rows, []fr.Element{fr.NewElement(1)})
+	b.rho = append(b.rho, attr)
+}
+
+// AddAndClause 将当前策略P修改为 (attr and P)
+//
+// P作为AND的右子节点，继承根向量的行在新列写入1；attr作为左子节点，向量为 (0, ..., 0, -1)。
+func (b *LSSSBuilder) AddAndClause(attr fr.Element) {
+	one := fr.NewElement(1)
+	column := b.counter
+	for i := range b.rows {
+		if b.rows[i][0].IsOne() {
+			b.rows[i] = b.padded(i, column)
+			b.rows[i] = append(b.rows[i], one)
+		}
+	}
+	b.appendAndLeft(column, attr)
+}
+
+// ExpandOr 将第row行对应的叶子节点替换为 (ρ(row) or attr)
+//
+// 参数：
+//   - row: 待扩展的行索引
+//   - attr: 新属性
+//
+// 返回值：
+//   - error: 行索引越界时返回错误
+func (b *LSSSBuilder) ExpandOr(row int, attr fr.Element) error {
+	if row < 0 || row >= len(b.rows) {
+		return fmt.Errorf("row index %d out of range [0, %d)", row, len(b.rows))
+	}
+	b.rows = append(b.rows, b.padded(row, len(b.rows[row])))
+	b.rho = append(b.rho, attr)
+	return nil
+}
+
+// ExpandAnd 将第row行对应的叶子节点替换为 (attr and ρ(row))
+//
+// 第row行作为AND的右子节点在新列写入1，attr作为左子节点，向量为 (0, ..., 0, -1)，其余行不变。
+//
+// 参数：
+//   - row: 待扩展的行索引
+//   - attr: 新属性
+//
+// 返回值：
+//   - error: 行索引越界时返回错误
+func (b *LSSSBuilder) ExpandAnd(row int, attr fr.Element) error {
+	if row < 0 || row >= len(b.rows) {
+		return fmt.Errorf("row index %d out of range [0, %d)", row, len(b.rows))
+	}
+	column := b.counter
+	b.rows[row] = b.padded(row, column)
+	b.rows[row] = append(b.rows[row], fr.NewElement(1))
+	b.appendAndLeft(column, attr)
+	return nil
+}
+
+// Matrix 返回当前策略对应的LSSS矩阵，返回的矩阵与构造器互不共享内存
+//
+// 返回值：
+//   - *LewkoWatersLsssMatrix: 当前的LSSS矩阵
+func (b *LSSSBuilder) Matrix() *LewkoWatersLsssMatrix {
+	matrix := make([][]fr.Element, len(b.rows))
+	for i := range b.rows {
+		matrix[i] = b.padded(i, b.counter)
+	}
+	rho := make([]fr.Element, len(b.rho))
+	copy(rho, b.rho)
+	return &LewkoWatersLsssMatrix{
+		rowNumber:    len(matrix),
+		columnNumber: b.counter,
+		accessMatrix: matrix,
+		rho:          rho,
+	}
+}
+
+// appendAndLeft 追加AND左子节点对应的行 (0, ..., 0, -1)，-1位于第column列，并将counter加一
+func (b *LSSSBuilder) appendAndLeft(column int, attr fr.Element) {
+	minusOne := fr.NewElement(1)
+	minusOne.Neg(&minusOne)
+	left := make([]fr.Element, column+1)
+	left[column] = minusOne
+	b.rows = append(b.rows, left)
+	b.rho = append(b.rho, attr)
+	b.counter++
+}
+
+// padded 返回第row行补零到length列后的副本
+func (b *LSSSBuilder) padded(row, length int) []fr.Element {
+	result := make([]fr.Element, length)
+	copy(result, b.rows[row])
+	return result
+}
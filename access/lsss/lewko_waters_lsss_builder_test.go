@@ -0,0 +1,139 @@
+package lsss
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+)
+
+// rank 使用逐列选主元的高斯消元计算矩阵的秩
+func rank(rows [][]fr.Element) int {
+	if len(rows) == 0 {
+		return 0
+	}
+	m := make([][]fr.Element, len(rows))
+	for i := range rows {
+		m[i] = append([]fr.Element(nil), rows[i]...)
+	}
+	r := 0
+	for col := 0; col < len(m[0]) && r < len(m); col++ {
+		pivot := -1
+		for i := r; i < len(m); i++ {
+			if !m[i][col].IsZero() {
+				pivot = i
+				break
+			}
+		}
+		if pivot == -1 {
+			continue
+		}
+		m[r], m[pivot] = m[pivot], m[r]
+		var inv fr.Element
+		inv.Inverse(&m[r][col])
+		for i := r + 1; i < len(m); i++ {
+			var factor fr.Element
+			factor.Mul(&m[i][col], &inv)
+			for j := col; j < len(m[i]); j++ {
+				var tmp fr.Element
+				tmp.Mul(&factor, &m[r][j])
+				m[i][j].Sub(&m[i][j], &tmp)
+			}
+		}
+		r++
+	}
+	return r
+}
+
+// satisfiedBySpan 判断属性集合对应的行是否张成目标向量 (1, 0, ..., 0)。
+// 直接比较秩，与 FindLinearCombinationWeight 的消元相互独立，可作为其参照。
+func satisfiedBySpan(m *LewkoWatersLsssMatrix, attrs []fr.Element) bool {
+	held := make(map[fr.Element]bool, len(attrs))
+	for _, a := range attrs {
+		held[a] = true
+	}
+	var rows [][]fr.Element
+	for i := 0; i < m.RowNumber(); i++ {
+		if held[m.Rho(i)] {
+			rows = append(rows, m.accessMatrix[i])
+		}
+	}
+	target := make([]fr.Element, m.ColumnNumber())
+	target[0].SetOne()
+	return rank(rows) == rank(append(rows, target))
+}
+
+// assertMatrixMatchesTree 检查增量构造的矩阵与访问树批量构造的矩阵对所有属性子集的可满足性一致
+func assertMatrixMatchesTree(t *testing.T, step string, incremental *LewkoWatersLsssMatrix, tree *BinaryAccessTree, names []string) {
+	t.Helper()
+	batch := NewLSSSMatrixFromBinaryTree(tree.Copy())
+	if incremental.RowNumber() != batch.RowNumber() || incremental.ColumnNumber() != batch.ColumnNumber() {
+		t.Errorf("%s: 矩阵维度不一致: 增量 %dx%d, 批量 %dx%d", step, incremental.RowNumber(), incremental.ColumnNumber(), batch.RowNumber(), batch.ColumnNumber())
+	}
+	for _, attrs := range allAttributeSubsets(names) {
+		satisfied1 := satisfiedBySpan(incremental, attrs)
+		satisfied2 := satisfiedBySpan(batch, attrs)
+		if satisfied1 != satisfied2 {
+			t.Fatalf("%s: 可满足性不一致: 属性数 %d, 增量 %v, 批量 %v", step, len(attrs), satisfied1, satisfied2)
+		}
+		held := make(map[fr.Element]struct{}, len(attrs))
+		for _, a := range attrs {
+			held[a] = struct{}{}
+		}
+		if want, _ := tree.satisfiedBy(held); satisfied1 != want {
+			t.Fatalf("%s: 矩阵可满足性与访问树不一致: 属性数 %d, 矩阵 %v, 访问树 %v", step, len(attrs), satisfied1, want)
+		}
+	}
+}
+
+func TestLSSSBuilderEdits(t *testing.T) {
+	names := []string{"A", "B", "C", "D", "E", "F"}
+	attr := func(name string) *BinaryAccessTree { return LeafFromString(name) }
+
+	builder := NewLSSSBuilder(attr("A").Attribute)
+	assertMatrixMatchesTree(t, "A", builder.Matrix(), attr("A"), names)
+
+	// A or B
+	builder.AddOrClause(attr("B").Attribute)
+	assertMatrixMatchesTree(t, "A or B", builder.Matrix(), Or(attr("A"), attr("B")), names)
+
+	// C and (A or B)
+	builder.AddAndClause(attr("C").Attribute)
+	assertMatrixMatchesTree(t, "C and (A or B)", builder.Matrix(),
+		And(attr("C"), Or(attr("A"), attr("B"))), names)
+
+	// C and ((D and A) or B)，第0行为A
+	if err := builder.ExpandAnd(0, attr("D").Attribute); err != nil {
+		t.Fatal(err)
+	}
+	assertMatrixMatchesTree(t, "C and ((D and A) or B)", builder.Matrix(),
+		And(attr("C"), Or(And(attr("D"), attr("A")), attr("B"))), names)
+
+	// (C or E) and ((D and A) or B)，第2行为C
+	if err := builder.ExpandOr(2, attr("E").Attribute); err != nil {
+		t.Fatal(err)
+	}
+	assertMatrixMatchesTree(t, "(C or E) and ((D and A) or B)", builder.Matrix(),
+		And(Or(attr("C"), attr("E")), Or(And(attr("D"), attr("A")), attr("B"))), names)
+
+	// F and ((C or E) and ((D and A) or B))
+	builder.AddAndClause(attr("F").Attribute)
+	assertMatrixMatchesTree(t, "F and (...)", builder.Matrix(),
+		And(attr("F"), And(Or(attr("C"), attr("E")), Or(And(attr("D"), attr("A")), attr("B")))), names)
+
+	// (F and (...)) or B
+	builder.AddOrClause(attr("B").Attribute)
+	assertMatrixMatchesTree(t, "(F and (...)) or B", builder.Matrix(),
+		Or(And(attr("F"), And(Or(attr("C"), attr("E")), Or(And(attr("D"), attr("A")), attr("B")))), attr("B")), names)
+}
+
+func TestLSSSBuilderMatrixIsSnapshot(t *testing.T) {
+	builder := NewLSSSBuilder(LeafFromString("A").Attribute)
+	before := builder.Matrix()
+	builder.AddAndClause(LeafFromString("B").Attribute)
+	if before.RowNumber() != 1 || before.ColumnNumber() != 1 {
+		t.Errorf("之前返回的矩阵不应被后续编辑修改: %dx%d", before.RowNumber(), before.ColumnNumber())
+	}
+	if err := builder.ExpandAnd(5, LeafFromString("C").Attribute); err == nil {
+		t.Error("行索引越界时应当返回错误")
+	}
+}
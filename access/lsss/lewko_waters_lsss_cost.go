@@ -0,0 +1,54 @@
+package lsss
+
+// Cost 描述在某个访问矩阵上执行一次 CP-ABE 操作的运算量，用于部署前的容量评估。
+//
+// 运算次数按 Waters11 CP-ABE（本仓库 cpabe/waters11 包）的实现统计：
+// ScalarMults 统计全部群上的幂运算（G1/G2 上的标量乘法与 GT 上的幂运算），
+// 配对与幂运算之外的域运算、点加开销相对很小，不计入。
+type Cost struct {
+	Rows    int // 矩阵行数 l，即策略中叶子属性的个数
+	Columns int // 矩阵列数 n，即 AND 门个数加一
+
+	WorstCasePairings    int // 最坏情况下的配对次数
+	WorstCaseScalarMults int // 最坏情况下的群幂运算次数
+}
+
+// PolicyCost 给出在访问矩阵上解密一个 Waters11 密文的最坏情况开销。
+//
+// 解密计算 e(C', K) / ∏ (e(C_i, L) · e(D_i, K_ρ(i)))^{w_i}：固定 1 次配对，
+// 线性组合用到的每一行各需 2 次配对和 1 次 GT 幂运算。实际用到的行数取决于用户属性，
+// 不会超过矩阵行数，因此最坏情况为 1 + 2·l 次配对与 l 次幂运算。
+//
+// 参数：
+//   - m: 访问矩阵
+//
+// 返回值：
+//   - Cost: 解密开销
+func PolicyCost(m *LewkoWatersLsssMatrix) Cost {
+	return Cost{
+		Rows:                 m.rowNumber,
+		Columns:              m.columnNumber,
+		WorstCasePairings:    1 + 2*m.rowNumber,
+		WorstCaseScalarMults: m.rowNumber,
+	}
+}
+
+// EncryptionCost 给出在访问矩阵上生成一个 Waters11 密文的开销，加密开销与用户无关，最坏情况即实际开销。
+//
+// 加密不需要配对（e(g1, g2)^α 已在公共参数中预计算），幂运算包括：
+// e(g1, g2)^{αs} 与 C' = g2^s 各 1 次，每一行 C_i = (g1^a)^{λ_i} · h_ρ(i)^{-r_i} 需要 2 次 G1 标量乘法，
+// D_i = g2^{r_i} 需要 1 次 G2 标量乘法，共 2 + 3·l 次。
+//
+// 参数：
+//   - m: 访问矩阵
+//
+// 返回值：
+//   - Cost: 加密开销
+func EncryptionCost(m *LewkoWatersLsssMatrix) Cost {
+	return Cost{
+		Rows:                 m.rowNumber,
+		Columns:              m.columnNumber,
+		WorstCasePairings:    0,
+		WorstCaseScalarMults: 2 + 3*m.rowNumber,
+	}
+}
@@ -0,0 +1,30 @@
+package lsss
+
+import (
+	"testing"
+)
+
+// TestPolicyCostExample15 Example15 为 (E and (((A and B) or (C and D)) or ((A or B) and (C or D))))：
+// 叶子 E, A, B, C, D, A, B, C, D 共 9 行，AND 门 4 个，共 5 列。
+// 解密最坏 1 + 2·9 = 19 次配对、9 次 GT 幂运算；加密 2 + 3·9 = 29 次幂运算、不需要配对
+func TestPolicyCostExample15(t *testing.T) {
+	tree, _ := GetExample15()
+	m := NewLSSSMatrixFromBinaryTree(tree)
+
+	want := Cost{Rows: 9, Columns: 5, WorstCasePairings: 19, WorstCaseScalarMults: 9}
+	if got := PolicyCost(m); got != want {
+		t.Fatalf("PolicyCost = %+v, want %+v", got, want)
+	}
+	want = Cost{Rows: 9, Columns: 5, WorstCasePairings: 0, WorstCaseScalarMults: 29}
+	if got := EncryptionCost(m); got != want {
+		t.Fatalf("EncryptionCost = %+v, want %+v", got, want)
+	}
+}
+
+// TestPolicyCostSingleLeaf 单个属性的策略：1 行 1 列，解密 3 次配对
+func TestPolicyCostSingleLeaf(t *testing.T) {
+	m := NewLSSSMatrixFromBinaryTree(LeafFromString("A"))
+	if got := PolicyCost(m); got.Rows != 1 || got.Columns != 1 || got.WorstCasePairings != 3 || got.WorstCaseScalarMults != 1 {
+		t.Fatalf("PolicyCost = %+v", got)
+	}
+}
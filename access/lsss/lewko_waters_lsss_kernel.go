@@ -0,0 +1,104 @@
+package lsss
+
+import "github.com/consensys/gnark-crypto/ecc/bn254/fr"
+
+// CombineRows 计算矩阵各行的线性组合 Σ coeffs[i] × Mᵢ
+//
+// 参数：
+//   - coeffs: 每一行的系数，长度必须等于矩阵行数
+//
+// 返回值：
+//   - []fr.Element: 长度为列数的组合结果；coeffs 长度不等于行数时返回 nil
+func (m *LewkoWatersLsssMatrix) CombineRows(coeffs []fr.Element) []fr.Element {
+	if len(coeffs) != m.rowNumber {
+		return nil
+	}
+	sum := make([]fr.Element, m.columnNumber)
+	for i := range coeffs {
+		if coeffs[i].IsZero() {
+			continue
+		}
+		for j := 0; j < m.columnNumber; j++ {
+			var temp fr.Element
+			temp.Mul(&coeffs[i], &m.accessMatrix[i][j])
+			sum[j].Add(&sum[j], &temp)
+		}
+	}
+	return sum
+}
+
+// LeftKernelBasis 返回左零空间 {λ ∈ F^l : Σ λᵢ × Mᵢ = 0} 的一组基，l 为矩阵行数
+//
+// 对 Mᵀ 做高斯-约当消元化为行最简形，每个自由变量取 1、其余自由变量取 0 得到一个基向量。
+// 左零空间中均匀随机的元素可以由基向量的随机线性组合得到，这在需要隐藏具体权重、
+// 只公开"某个权重向量组合出 (c, 0, ..., 0)"的场合（例如零知识证明）中很有用。
+//
+// 返回值：
+//   - [][]fr.Element: 基向量列表，每个长度为行数；行向量线性无关时返回空列表
+func (m *LewkoWatersLsssMatrix) LeftKernelBasis() [][]fr.Element {
+	l, n := m.rowNumber, m.columnNumber
+
+	// reduced 是 Mᵀ（n×l），reduced[j][i] = M[i][j]
+	reduced := make([][]fr.Element, n)
+	for j := 0; j < n; j++ {
+		reduced[j] = make([]fr.Element, l)
+		for i := 0; i < l; i++ {
+			reduced[j][i] = m.accessMatrix[i][j]
+		}
+	}
+
+	pivotOfColumn := make([]int, l) // 主元列对应的主元行，自由列为 -1
+	for i := range pivotOfColumn {
+		pivotOfColumn[i] = -1
+	}
+	pivotRow := 0
+	for col := 0; col < l && pivotRow < n; col++ {
+		found := -1
+		for row := pivotRow; row < n; row++ {
+			if !reduced[row][col].IsZero() {
+				found = row
+				break
+			}
+		}
+		if found == -1 {
+			continue
+		}
+		reduced[pivotRow], reduced[found] = reduced[found], reduced[pivotRow]
+
+		var pivotInv fr.Element
+		pivotInv.Inverse(&reduced[pivotRow][col])
+		for k := col; k < l; k++ {
+			reduced[pivotRow][k].Mul(&reduced[pivotRow][k], &pivotInv)
+		}
+		for row := 0; row < n; row++ {
+			if row == pivotRow || reduced[row][col].IsZero() {
+				continue
+			}
+			factor := reduced[row][col]
+			for k := col; k < l; k++ {
+				var temp fr.Element
+				temp.Mul(&factor, &reduced[pivotRow][k])
+				reduced[row][k].Sub(&reduced[row][k], &temp)
+			}
+		}
+		pivotOfColumn[col] = pivotRow
+		pivotRow++
+	}
+
+	// 自由变量 free 取 1 时，主元变量 λ_col = -reduced[pivotOfColumn[col]][free]
+	var basis [][]fr.Element
+	for free := 0; free < l; free++ {
+		if pivotOfColumn[free] != -1 {
+			continue
+		}
+		v := make([]fr.Element, l)
+		v[free].SetOne()
+		for col := 0; col < l; col++ {
+			if row := pivotOfColumn[col]; row != -1 {
+				v[col].Neg(&reduced[row][free])
+			}
+		}
+		basis = append(basis, v)
+	}
+	return basis
+}
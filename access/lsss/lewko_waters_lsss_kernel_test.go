@@ -0,0 +1,50 @@
+package lsss
+
+import (
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"testing"
+)
+
+// TestLeftKernelBasis 基向量组合各行得到零向量，个数等于行数减去秩；Σ wᵢ × Mᵢ 与 CombineRows 一致
+func TestLeftKernelBasis(t *testing.T) {
+	a, b, c := fr.NewElement(1), fr.NewElement(2), fr.NewElement(3)
+	for _, tc := range []struct {
+		name    string
+		tree    *BinaryAccessTree
+		nullity int
+	}{
+		// OR 门的两行相同，秩为 1
+		{"or", Or(Leaf(a), Leaf(b)), 1},
+		// AND 门的两行 (1, -1)、(0, 1) 线性无关
+		{"and", And(Leaf(a), Leaf(b)), 0},
+		// 行 (1, -1)、(0, 1)、(0, 1)，秩为 2
+		{"and-or", And(Leaf(a), Or(Leaf(b), Leaf(c))), 1},
+	} {
+		m := NewLSSSMatrixFromBinaryTree(tc.tree)
+		basis := m.LeftKernelBasis()
+		if len(basis) != tc.nullity {
+			t.Fatalf("%s: got %d basis vectors, want %d", tc.name, len(basis), tc.nullity)
+		}
+		for _, v := range basis {
+			for _, x := range m.CombineRows(v) {
+				if !x.IsZero() {
+					t.Fatalf("%s: basis vector does not combine the rows to zero", tc.name)
+				}
+			}
+		}
+	}
+
+	m := NewLSSSMatrixFromBinaryTree(And(Leaf(a), Or(Leaf(b), Leaf(c))))
+	rows, weights := m.FindLinearCombinationWeight([]fr.Element{a, c})
+	coeffs := make([]fr.Element, m.RowNumber())
+	for k, i := range rows {
+		coeffs[i] = weights[k]
+	}
+	sum := m.CombineRows(coeffs)
+	if !sum[0].IsOne() || !sum[1].IsZero() {
+		t.Fatal("CombineRows of the reconstruction weights should be (1, 0)")
+	}
+	if m.CombineRows(coeffs[:1]) != nil {
+		t.Fatal("coefficients of the wrong length should be rejected")
+	}
+}
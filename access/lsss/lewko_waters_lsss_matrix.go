@@ -3,6 +3,8 @@ package lsss
 import (
 	"fmt"
 	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"io"
+	"os"
 )
 
 // LewkoWatersLsssMatrix 表示Lewko-Waters线性秘密共享方案(LSSS)矩阵
@@ -151,6 +153,15 @@ func (m *LewkoWatersLsssMatrix) ComputeVector(rowIndex int, vector []fr.Element)
 //
 // 时间复杂度：O(n·m²)，其中n是列数，m是满足条件的行数
 //
+// 确定性：满足条件的行按 rho 的下标顺序收集，属性映射只用于成员判断，不参与迭代；
+// 高斯消元总是选取每列第一个非零主元，自由变量取 0。因此结果只取决于矩阵和属性集合本身，
+// 与 attributes 中元素的顺序、重复无关，多次调用返回完全相同的行和权重，且行索引严格递增。
+// 当用户属性集合存在多种满足方式（冗余路径）时，返回的行线性无关，
+// 去掉其中任意一行都不能再组合出目标向量，即返回的是一个极小的满足行集合。
+//
+// 单列矩阵（纯 OR 链策略）是一个显式处理的特例：每个满足的非零行单独就能组合出目标向量，
+// 总是返回下标最小的满足行及其权重 1/M[i][0]（OR 链中为 1），使解密只需处理一行。
+//
 // 参数：
 //   - attributes: 用户拥有的属性集合
 //
@@ -185,9 +196,17 @@ func (m *LewkoWatersLsssMatrix) FindLinearCombinationWeight(attributes []fr.Elem
 		return nil, nil
 	}
 
+	// 单列矩阵：取下标最小的非零满足行，无需消元
+	if m.columnNumber == 1 {
+		return m.firstSingleColumnRow(satisfiedRows)
+	}
+
 	// 提取满足条件的行，构造子矩阵
 	subMatrix := make([][]fr.Element, len(satisfiedRows))
 	for i, rowIdx := range satisfiedRows {
+		if len(m.accessMatrix[rowIdx]) != m.columnNumber {
+			return nil, nil // 行长度与列数不一致的矩阵不可信，拒绝求解
+		}
 		subMatrix[i] = make([]fr.Element, m.columnNumber)
 		for j := 0; j < m.columnNumber; j++ {
 			subMatrix[i][j] = m.accessMatrix[rowIdx][j]
@@ -217,28 +236,111 @@ func (m *LewkoWatersLsssMatrix) FindLinearCombinationWeight(attributes []fr.Elem
 		return nil, nil
 	}
 
+	// 在原矩阵上复核过滤后的线性组合，防止求解器的边界情况产生错误权重
+	if !m.reconstructsTarget(resultRows, resultCoeffs) {
+		return nil, nil
+	}
+
 	return resultRows, resultCoeffs
 }
 
+// firstSingleColumnRow 在单列矩阵中返回 satisfiedRows 里第一个非零行及其权重 1/M[i][0]
+//
+// 参数：
+//   - satisfiedRows: 按下标递增排列的满足行
+//
+// 返回值：
+//   - []int: 只含一行的行索引列表
+//   - []fr.Element: 对应的权重
+//   - 所有满足行都为零（或行长度不一致）时返回 (nil, nil)
+func (m *LewkoWatersLsssMatrix) firstSingleColumnRow(satisfiedRows []int) ([]int, []fr.Element) {
+	for _, rowIdx := range satisfiedRows {
+		if len(m.accessMatrix[rowIdx]) != 1 {
+			return nil, nil
+		}
+		if entry := m.accessMatrix[rowIdx][0]; !entry.IsZero() {
+			var w fr.Element
+			w.Inverse(&entry)
+			return []int{rowIdx}, []fr.Element{w}
+		}
+	}
+	return nil, nil
+}
+
+// reconstructsTarget 检查 Σ weights[k] × M[rows[k]] 是否恰好等于 (1, 0, ..., 0)
+//
+// 参数：
+//   - rows: 行索引
+//   - weights: 与 rows 一一对应的权重
+//
+// 返回值：
+//   - bool: 线性组合等于目标向量时返回 true
+func (m *LewkoWatersLsssMatrix) reconstructsTarget(rows []int, weights []fr.Element) bool {
+	if len(rows) != len(weights) {
+		return false
+	}
+	sum := make([]fr.Element, m.columnNumber)
+	for k, rowIdx := range rows {
+		if rowIdx < 0 || rowIdx >= m.rowNumber {
+			return false
+		}
+		for j := 0; j < m.columnNumber; j++ {
+			var temp fr.Element
+			temp.Mul(&weights[k], &m.accessMatrix[rowIdx][j])
+			sum[j].Add(&sum[j], &temp)
+		}
+	}
+	if !sum[0].IsOne() {
+		return false
+	}
+	for j := 1; j < m.columnNumber; j++ {
+		if !sum[j].IsZero() {
+			return false
+		}
+	}
+	return true
+}
+
 // Print 打印LSSS矩阵的详细信息
 //
 // 输出格式包括：
 //   - 矩阵维度信息
 //   - 每一行的索引、对应属性、以及向量值
 func (m *LewkoWatersLsssMatrix) Print() {
-	fmt.Println()
-	fmt.Println("------------------------------------------------")
-	fmt.Printf("matrix rowNumber: %d, columnNumber: %d \n", m.rowNumber, m.columnNumber)
-	fmt.Println("ρ(i)  Matrix")
+	m.Fprint(os.Stdout, nil)
+}
+
+// PrintWithNames 与 Print 相同，但属性以属性字典中的名称显示
+//
+// 参数：
+//   - dictionary: 属性字典；不在字典中的属性以编码前 4 字节的十六进制显示
+func (m *LewkoWatersLsssMatrix) PrintWithNames(dictionary AttributeDictionary) {
+	m.Fprint(os.Stdout, dictionary)
+}
+
+// Fprint 将 Print 的输出写入 w
+//
+// 参数：
+//   - w: 输出目标
+//   - dictionary: 属性字典，可以为 nil；为 nil 时属性以完整的十进制值显示，与 Print 相同
+func (m *LewkoWatersLsssMatrix) Fprint(w io.Writer, dictionary AttributeDictionary) {
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "------------------------------------------------")
+	fmt.Fprintf(w, "matrix rowNumber: %d, columnNumber: %d \n", m.rowNumber, m.columnNumber)
+	fmt.Fprintln(w, "ρ(i)  Matrix")
 	for i := range m.accessMatrix {
-		fmt.Printf("index %d || attribute: %s ||  ", i, m.rho[i].String())
+		attribute := m.rho[i].String()
+		if dictionary != nil {
+			attribute = dictionary.Describe(m.rho[i])
+		}
+		fmt.Fprintf(w, "index %d || attribute: %s ||  ", i, attribute)
 		for j := range m.accessMatrix[i] {
-			fmt.Printf(" %s ", (m.accessMatrix[i][j]).String())
+			fmt.Fprintf(w, " %s ", (m.accessMatrix[i][j]).String())
 		}
-		fmt.Println()
+		fmt.Fprintln(w)
 	}
-	fmt.Println("------------------------------------------------")
-	fmt.Println()
+	fmt.Fprintln(w, "------------------------------------------------")
+	fmt.Fprintln(w)
 }
 
 // findWeightsGaussian 使用高斯消元法在有限域上求解线性方程组
@@ -248,9 +350,9 @@ func (m *LewkoWatersLsssMatrix) Print() {
 //
 // 算法步骤：
 //  1. 构造增广矩阵 [A^T | b]，其中A^T是向量转置矩阵，b=(1,0,...,0)
-//  2. 高斯消元：通过行变换将矩阵化为阶梯形
-//  3. 回代求解：从下往上计算每个权重
-//  4. 验证解的正确性
+//  2. 高斯-约当消元：通过行变换将矩阵化为行最简形，主元行只在找到主元时前进
+//  3. 取特解：自由变量取0，主元变量直接读出
+//  4. 验证解的正确性：Σ(wᵢ × vᵢ) 必须逐分量等于 (1, 0, ..., 0)
 //
 // 注意事项：
 //   - 在有限域上进行运算，所有除法通过乘以逆元实现
@@ -288,120 +390,64 @@ func findWeightsGaussian(vectors [][]fr.Element, n int) []fr.Element {
 		}
 	}
 
-	// 高斯消元法 - 前向消元
-	for pivot := 0; pivot < min(n, m); pivot++ {
-		// 部分主元选取：找到该列第一个非零元素
-		maxRow := -1
-		for row := pivot; row < n; row++ {
-			if !augmented[row][pivot].IsZero() {
-				maxRow = row
+	// 高斯-约当消元：pivotRow 只在找到主元时前进，避免重复行或线性相关行导致主元错位
+	pivotColumns := make([]int, 0, min(n, m))
+	pivotRow := 0
+	for col := 0; col < m && pivotRow < n; col++ {
+		// 找到该列在 pivotRow 及其下方的第一个非零元素
+		found := -1
+		for row := pivotRow; row < n; row++ {
+			if !augmented[row][col].IsZero() {
+				found = row
 				break
 			}
 		}
 
-		if maxRow == -1 {
-			continue // 该列全为0，跳过
+		if found == -1 {
+			continue // 该列没有主元，对应自由变量，取 0
 		}
 
 		// 交换行
-		if maxRow != pivot {
-			augmented[pivot], augmented[maxRow] = augmented[maxRow], augmented[pivot]
+		if found != pivotRow {
+			augmented[pivotRow], augmented[found] = augmented[found], augmented[pivotRow]
 		}
 
-		// 计算主元的逆元
+		// 主元行归一化
 		var pivotInv fr.Element
-		pivotInv.Inverse(&augmented[pivot][pivot])
+		pivotInv.Inverse(&augmented[pivotRow][col])
+		for k := col; k <= m; k++ {
+			augmented[pivotRow][k].Mul(&augmented[pivotRow][k], &pivotInv)
+		}
 
-		// 消元：将pivot列下方的元素变为0
-		for row := pivot + 1; row < n; row++ {
-			if augmented[row][pivot].IsZero() {
+		// 消元：将该列其余所有行的元素变为0
+		for row := 0; row < n; row++ {
+			if row == pivotRow || augmented[row][col].IsZero() {
 				continue
 			}
-
-			// factor = augmented[row][pivot] / augmented[pivot][pivot]
-			var factor fr.Element
-			factor.Mul(&augmented[row][pivot], &pivotInv)
-
-			// 更新该行的所有元素
-			for col := pivot; col <= m; col++ {
-				// augmented[row][col] -= factor * augmented[pivot][col]
+			factor := augmented[row][col]
+			for k := col; k <= m; k++ {
+				// augmented[row][k] -= factor * augmented[pivotRow][k]
 				var temp fr.Element
-				temp.Mul(&factor, &augmented[pivot][col])
-				augmented[row][col].Sub(&augmented[row][col], &temp)
+				temp.Mul(&factor, &augmented[pivotRow][k])
+				augmented[row][k].Sub(&augmented[row][k], &temp)
 			}
 		}
+
+		pivotColumns = append(pivotColumns, col)
+		pivotRow++
 	}
 
-	// 检查是否有矛盾方程（左侧全0但右侧非0）
-	for i := 0; i < n; i++ {
-		allZero := true
-		for j := 0; j < m; j++ {
-			if !augmented[i][j].IsZero() {
-				allZero = false
-				break
-			}
-		}
-		if allZero && !augmented[i][m].IsZero() {
+	// 检查是否有矛盾方程（左侧全0但右侧非0）；主元行之下的行左侧必然全0
+	for i := pivotRow; i < n; i++ {
+		if !augmented[i][m].IsZero() {
 			return nil // 无解
 		}
 	}
 
-	// 回代求解
+	// 取特解：自由变量为0，主元变量等于对应行的右侧值
 	w := make([]fr.Element, m)
-	for i := 0; i < m; i++ {
-		w[i].SetZero()
-	}
-
-	// 从下往上回代
-	for i := min(n, m) - 1; i >= 0; i-- {
-		if augmented[i][i].IsZero() {
-			// 寻找该行是否有其他非零元素
-			hasNonZero := false
-			for j := i; j < m; j++ {
-				if !augmented[i][j].IsZero() {
-					hasNonZero = true
-					break
-				}
-			}
-			if !hasNonZero {
-				continue
-			}
-			// 如果有非零元素但对角线为0，说明需要特殊处理
-			// 这种情况在欠定系统中可能出现，我们寻找一个特解
-			for j := i; j < m; j++ {
-				if !augmented[i][j].IsZero() {
-					// 设置这个变量为 (右侧值 / 系数)
-					var inv fr.Element
-					inv.Inverse(&augmented[i][j])
-
-					sum := augmented[i][m]
-					for k := j + 1; k < m; k++ {
-						var temp fr.Element
-						temp.Mul(&augmented[i][k], &w[k])
-						sum.Sub(&sum, &temp)
-					}
-
-					w[j].Mul(&sum, &inv)
-					break
-				}
-			}
-			continue
-		}
-
-		// sum = augmented[i][m]
-		sum := augmented[i][m]
-
-		// sum -= Σ(augmented[i][j] * w[j]) for j > i
-		for j := i + 1; j < m; j++ {
-			var temp fr.Element
-			temp.Mul(&augmented[i][j], &w[j])
-			sum.Sub(&sum, &temp)
-		}
-
-		// w[i] = sum / augmented[i][i]
-		var diagInv fr.Element
-		diagInv.Inverse(&augmented[i][i])
-		w[i].Mul(&sum, &diagInv)
+	for i, col := range pivotColumns {
+		w[col] = augmented[i][m]
 	}
 
 	// 验证解的正确性
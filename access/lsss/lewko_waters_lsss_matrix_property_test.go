@@ -0,0 +1,206 @@
+package lsss
+
+import (
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"math/rand"
+	"testing"
+)
+
+// randomAccessTree 随机生成含 leaves 个叶子的访问树，叶子属性从 names 中有放回地抽取，
+// 因此同一属性可能出现在多行，产生重复行或线性相关行
+func randomAccessTree(r *rand.Rand, leaves int, names []string) *BinaryAccessTree {
+	if leaves == 1 {
+		return LeafFromString(names[r.Intn(len(names))])
+	}
+	left := 1 + r.Intn(leaves-1)
+	if r.Intn(2) == 0 {
+		return And(randomAccessTree(r, left, names), randomAccessTree(r, leaves-left, names))
+	}
+	return Or(randomAccessTree(r, left, names), randomAccessTree(r, leaves-left, names))
+}
+
+// assertWeightsReconstructTarget 检查返回的行都属于属性集合且 Σ wᵢ·Mᵢ == (1, 0, ..., 0)
+func assertWeightsReconstructTarget(t *testing.T, m *LewkoWatersLsssMatrix, attrs []fr.Element, rows []int, weights []fr.Element) {
+	t.Helper()
+	held := make(map[fr.Element]bool, len(attrs))
+	for _, a := range attrs {
+		held[a] = true
+	}
+	if len(rows) != len(weights) {
+		t.Fatalf("行数 %d 与权重数 %d 不一致", len(rows), len(weights))
+	}
+	sum := make([]fr.Element, m.ColumnNumber())
+	for k, i := range rows {
+		if !held[m.Rho(i)] {
+			t.Fatalf("第 %d 行的属性不在用户属性集合中", i)
+		}
+		for j := range sum {
+			var temp fr.Element
+			temp.Mul(&weights[k], &m.accessMatrix[i][j])
+			sum[j].Add(&sum[j], &temp)
+		}
+	}
+	if !sum[0].IsOne() {
+		t.Fatal("线性组合的第一个分量不为 1")
+	}
+	for j := 1; j < len(sum); j++ {
+		if !sum[j].IsZero() {
+			t.Fatalf("线性组合的第 %d 个分量不为 0", j)
+		}
+	}
+}
+
+// TestFindLinearCombinationWeightProperty 对随机策略和全部属性子集检查：
+// 返回权重时线性组合等于目标向量；返回 nil 时按秩判断确实不存在满足的组合，且访问树也不被满足
+func TestFindLinearCombinationWeightProperty(t *testing.T) {
+	names := []string{"A", "B", "C", "D", "E"}
+	r := rand.New(rand.NewSource(1122))
+	for trial := 0; trial < 200; trial++ {
+		tree := randomAccessTree(r, 1+r.Intn(8), names)
+		m := NewLSSSMatrixFromBinaryTree(tree.Copy())
+		for _, attrs := range allAttributeSubsets(names) {
+			rows, weights := m.FindLinearCombinationWeight(attrs)
+			spans := satisfiedBySpan(m, attrs)
+			held := make(map[fr.Element]struct{}, len(attrs))
+			for _, a := range attrs {
+				held[a] = struct{}{}
+			}
+			want, err := tree.satisfiedBy(held)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if rows == nil {
+				if weights != nil {
+					t.Fatal("rows 为 nil 时 weights 也应为 nil")
+				}
+				if spans {
+					t.Fatalf("第 %d 次: 存在满足的线性组合但求解器返回 nil", trial)
+				}
+				if want {
+					t.Fatalf("第 %d 次: 访问树被满足但求解器返回 nil", trial)
+				}
+				continue
+			}
+			if !want {
+				t.Fatalf("第 %d 次: 访问树不被满足但求解器返回了权重", trial)
+			}
+			assertWeightsReconstructTarget(t, m, attrs, rows, weights)
+		}
+	}
+}
+
+// TestFindLinearCombinationWeightLargeRandom 对较大的随机矩阵检查求解结果与秩判断一致
+func TestFindLinearCombinationWeightLargeRandom(t *testing.T) {
+	names := []string{"A", "B", "C", "D", "E", "F", "G", "H", "I", "J", "K", "L"}
+	r := rand.New(rand.NewSource(2211))
+	for trial := 0; trial < 30; trial++ {
+		m := NewLSSSMatrixFromBinaryTree(randomAccessTree(r, 20+r.Intn(20), names))
+		for k := 0; k < 20; k++ {
+			var attrs []fr.Element
+			for _, name := range names {
+				if r.Intn(3) != 0 {
+					attrs = append(attrs, LeafFromString(name).Attribute)
+				}
+			}
+			rows, weights := m.FindLinearCombinationWeight(attrs)
+			if spans := satisfiedBySpan(m, attrs); spans != (rows != nil) {
+				t.Fatalf("第 %d 次: 秩判断 %v 与求解结果不一致", trial, spans)
+			}
+			if rows != nil {
+				assertWeightsReconstructTarget(t, m, attrs, rows, weights)
+			}
+		}
+	}
+}
+
+// TestFindWeightsGaussianDegenerateRows 全零行、重复行和线性相关行不应导致漏解或错误权重
+func TestFindWeightsGaussianDegenerateRows(t *testing.T) {
+	one, zero := fr.NewElement(1), fr.NewElement(0)
+	minusOne := *new(fr.Element).Neg(&one)
+	two := fr.NewElement(2)
+
+	// (0,0), (1,-1), (1,-1), (0,1): 需要跳过全零行和重复行才能找到 (1,-1)+(0,1)
+	vectors := [][]fr.Element{{zero, zero}, {one, minusOne}, {one, minusOne}, {zero, one}}
+	w := findWeightsGaussian(vectors, 2)
+	if w == nil {
+		t.Fatal("存在解但求解器返回 nil")
+	}
+	var c0, c1 fr.Element
+	for j := range vectors {
+		var temp fr.Element
+		c0.Add(&c0, temp.Mul(&w[j], &vectors[j][0]))
+		c1.Add(&c1, temp.Mul(&w[j], &vectors[j][1]))
+	}
+	if !c0.IsOne() || !c1.IsZero() {
+		t.Fatal("权重不能重构目标向量")
+	}
+
+	// (2,2), (1,1): 线性相关且不张成 (1,0)
+	if w := findWeightsGaussian([][]fr.Element{{two, two}, {one, one}}, 2); w != nil {
+		t.Fatal("不存在解但求解器返回了权重")
+	}
+}
+
+// TestFindLinearCombinationWeightDeterministic 用户持有 20 个属性、策略存在多条冗余满足路径时，
+// 重复查询、打乱属性顺序或加入重复属性都返回完全相同的行和权重，且返回的行是极小的满足集合
+func TestFindLinearCombinationWeightDeterministic(t *testing.T) {
+	names := []string{"A", "B", "C", "D", "E", "F", "G", "H", "I", "J",
+		"K", "L", "M", "N", "O", "P", "Q", "R", "S", "T"}
+	tree := Or(
+		Or(And(Attrs("A", "B", "C")...), And(Attrs("D", "E")...)),
+		Or(
+			And(Or(Attrs("F", "G", "H")...), And(Attrs("I", "J")...)),
+			Or(And(Attrs("K", "L", "M", "N")...), And(Or(Attrs("O", "P")...), Or(Attrs("Q", "R", "S", "T")...))),
+		),
+	)
+	m := NewLSSSMatrixFromBinaryTree(tree)
+	attrs := Attrs(names...)
+	var attributes []fr.Element
+	for _, a := range attrs {
+		attributes = append(attributes, a.Attribute)
+	}
+
+	wantRows, wantWeights := m.FindLinearCombinationWeight(attributes)
+	if wantRows == nil {
+		t.Fatal("持有全部属性时策略应被满足")
+	}
+	assertWeightsReconstructTarget(t, m, attributes, wantRows, wantWeights)
+	for k := 1; k < len(wantRows); k++ {
+		if wantRows[k] <= wantRows[k-1] {
+			t.Fatalf("返回的行索引不是严格递增的: %v", wantRows)
+		}
+	}
+
+	// 去掉任意一行后剩余的行都不能组合出目标向量
+	for skip := range wantRows {
+		var vectors [][]fr.Element
+		for k, i := range wantRows {
+			if k != skip {
+				vectors = append(vectors, m.accessMatrix[i])
+			}
+		}
+		if len(vectors) > 0 && findWeightsGaussian(vectors, m.ColumnNumber()) != nil {
+			t.Fatalf("去掉第 %d 行后仍能满足策略，返回的行集合不是极小的", wantRows[skip])
+		}
+	}
+
+	r := rand.New(rand.NewSource(1141))
+	for trial := 0; trial < 100; trial++ {
+		query := attributes
+		if trial%2 == 1 {
+			// 打乱顺序并重复一部分属性，结果不应改变
+			query = append([]fr.Element(nil), attributes...)
+			query = append(query, attributes[:r.Intn(len(attributes))]...)
+			r.Shuffle(len(query), func(i, j int) { query[i], query[j] = query[j], query[i] })
+		}
+		rows, weights := m.FindLinearCombinationWeight(query)
+		if len(rows) != len(wantRows) || len(weights) != len(wantWeights) {
+			t.Fatalf("第 %d 次查询返回了 %d 行，期望 %d 行", trial, len(rows), len(wantRows))
+		}
+		for k := range rows {
+			if rows[k] != wantRows[k] || !weights[k].Equal(&wantWeights[k]) {
+				t.Fatalf("第 %d 次查询的第 %d 个结果与第一次不同", trial, k)
+			}
+		}
+	}
+}
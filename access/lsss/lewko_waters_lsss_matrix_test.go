@@ -432,3 +432,41 @@ func TestLewkoWatersLsssMatrix_FindLinearCombinationWeightSpecial2(t *testing.T)
 		fmt.Println("rows and wis are nil")
 	}
 }
+
+// TestFindLinearCombinationWeightAllOr 纯 OR 链策略的矩阵只有一列，用户持有 {B, D} 时只返回下标较小的 B 行，权重为 1
+func TestFindLinearCombinationWeightAllOr(t *testing.T) {
+	b, d := hash.ToField("B"), hash.ToField("D")
+	tree, err := ParseBooleanFormula("((A or B) or C) or D")
+	if err != nil {
+		t.Fatal(err)
+	}
+	matrix := NewLSSSMatrixFromBinaryTree(tree)
+	if matrix.ColumnNumber() != 1 {
+		t.Fatalf("all-OR policy should give a single column, got %d", matrix.ColumnNumber())
+	}
+	bRow, dRow := -1, -1
+	for i := 0; i < matrix.RowNumber(); i++ {
+		switch rho := matrix.Rho(i); {
+		case rho.Equal(&b):
+			bRow = i
+		case rho.Equal(&d):
+			dRow = i
+		}
+	}
+	if bRow < 0 || dRow < 0 || bRow > dRow {
+		t.Fatalf("expected B (row %d) before D (row %d)", bRow, dRow)
+	}
+
+	for _, attrs := range [][]fr.Element{{b, d}, {d, b}, {d, b, d}} {
+		rows, weights := matrix.FindLinearCombinationWeight(attrs)
+		if len(rows) != 1 || len(weights) != 1 {
+			t.Fatalf("expected exactly one row, got rows %v", rows)
+		}
+		if rows[0] != bRow {
+			t.Fatalf("expected row %d (B), got %d", bRow, rows[0])
+		}
+		if !weights[0].IsOne() {
+			t.Fatalf("expected weight 1, got %s", weights[0].String())
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package lsss
+
+import (
+	"errors"
+	"fmt"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"math/bits"
+)
+
+// maxMissingAttributeSearch 是 MissingAttributes 搜索的缺失属性个数上限，
+// 搜索量随缺失属性个数指数增长，超过上限时不做搜索
+const maxMissingAttributeSearch = 16
+
+// ErrTooManyMissingAttributes 表示缺失属性个数超过 maxMissingAttributeSearch，MissingAttributes 不做搜索
+var ErrTooManyMissingAttributes = errors.New("too many missing attributes to search")
+
+// MissingAttributes 给出用户还需补充哪些属性才能满足访问矩阵
+//
+// 候选属性是矩阵中出现但用户不持有的属性。函数按集合大小从小到大枚举候选属性的子集，
+// 用 FindLinearCombinationWeight 检查用户属性并上该子集后能否重构 (1, 0, ..., 0)，
+// 并跳过已找到集合的超集，因此返回的每个集合都是极小的：去掉其中任一属性都不再满足。
+// 返回的集合按大小升序排列；集合内的属性按其在 rho 中首次出现的顺序排列。
+//
+// 参数：
+//   - userAttrs: 用户已持有的属性集合
+//
+// 返回值：
+//   - [][]fr.Element: 全部极小补充属性集合；用户已满足矩阵时为 nil，
+//     补充任何属性都无法满足时为空切片
+//   - error: 缺失属性超过 maxMissingAttributeSearch 个时包装 ErrTooManyMissingAttributes
+func (m *LewkoWatersLsssMatrix) MissingAttributes(userAttrs []fr.Element) ([][]fr.Element, error) {
+	if rows, _ := m.FindLinearCombinationWeight(userAttrs); rows != nil {
+		return nil, nil
+	}
+
+	held := make(map[fr.Element]bool, len(userAttrs))
+	for _, a := range userAttrs {
+		held[a] = true
+	}
+	var missing []fr.Element
+	for _, a := range m.rho {
+		if !held[a] {
+			held[a] = true
+			missing = append(missing, a)
+		}
+	}
+	if len(missing) > maxMissingAttributeSearch {
+		return nil, fmt.Errorf("%w: %d missing, limit is %d", ErrTooManyMissingAttributes, len(missing), maxMissingAttributeSearch)
+	}
+
+	// 按大小从小到大枚举子集（以位掩码表示），已找到的极小集合的超集直接跳过
+	var found []uint32
+	for size := 1; size <= len(missing); size++ {
+		for mask := uint32(1)<<size - 1; mask < 1<<len(missing); mask = nextMaskOfSameSize(mask) {
+			if containsFoundSet(found, mask) {
+				continue
+			}
+			attrs := append([]fr.Element(nil), userAttrs...)
+			for i := range missing {
+				if mask&(1<<i) != 0 {
+					attrs = append(attrs, missing[i])
+				}
+			}
+			if rows, _ := m.FindLinearCombinationWeight(attrs); rows != nil {
+				found = append(found, mask)
+			}
+		}
+	}
+
+	result := make([][]fr.Element, 0, len(found))
+	for _, mask := range found {
+		set := make([]fr.Element, 0, bits.OnesCount32(mask))
+		for i := range missing {
+			if mask&(1<<i) != 0 {
+				set = append(set, missing[i])
+			}
+		}
+		result = append(result, set)
+	}
+	return result, nil
+}
+
+// nextMaskOfSameSize 返回数值上大于 mask、且 1 的个数与 mask 相同的最小掩码（Gosper 方法），
+// 从 (1<<size)-1 开始反复调用即可按数值升序直接生成 size 元组合，无需扫描全部 2^n 个掩码
+func nextMaskOfSameSize(mask uint32) uint32 {
+	lowest := mask & -mask
+	ripple := mask + lowest
+	return ripple | ((ripple^mask)>>2)/lowest
+}
+
+// containsFoundSet 判断 mask 是否包含 found 中的某个集合
+func containsFoundSet(found []uint32, mask uint32) bool {
+	for _, f := range found {
+		if f&^mask == 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,109 @@
+package lsss
+
+import (
+	"errors"
+	"fmt"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"math/bits"
+	"testing"
+)
+
+// attributeSetNames 把属性集合还原为名称，便于比较
+func attributeSetNames(set []fr.Element, names []string) []string {
+	var result []string
+	for _, a := range set {
+		for _, name := range names {
+			if LeafFromString(name).Attribute == a {
+				result = append(result, name)
+			}
+		}
+	}
+	return result
+}
+
+func TestMissingAttributes(t *testing.T) {
+	names := []string{"A", "B", "C", "D"}
+	attr := func(name string) fr.Element { return LeafFromString(name).Attribute }
+	// (A and B) or (C and D)
+	m := NewLSSSMatrixFromBinaryTree(Or(
+		And(LeafFromString("A"), LeafFromString("B")),
+		And(LeafFromString("C"), LeafFromString("D")),
+	))
+
+	tests := []struct {
+		user []fr.Element
+		want [][]string
+	}{
+		{user: []fr.Element{attr("A")}, want: [][]string{{"B"}, {"C", "D"}}},
+		{user: []fr.Element{attr("A"), attr("C")}, want: [][]string{{"B"}, {"D"}}},
+		{user: nil, want: [][]string{{"A", "B"}, {"C", "D"}}},
+		{user: []fr.Element{attr("C"), attr("D")}, want: nil},
+	}
+	for _, tt := range tests {
+		got, err := m.MissingAttributes(tt.user)
+		if err != nil {
+			t.Fatalf("用户 %v: %v", attributeSetNames(tt.user, names), err)
+		}
+		if (got == nil) != (tt.want == nil) {
+			t.Fatalf("用户 %v: 得到 %v, 期望 %v", attributeSetNames(tt.user, names), got, tt.want)
+		}
+		if len(got) != len(tt.want) {
+			t.Fatalf("用户 %v: 得到 %d 个候选集合, 期望 %d 个", attributeSetNames(tt.user, names), len(got), len(tt.want))
+		}
+		for i := range got {
+			gotNames := attributeSetNames(got[i], names)
+			if len(gotNames) != len(tt.want[i]) {
+				t.Fatalf("用户 %v: 第 %d 个候选集合为 %v, 期望 %v", attributeSetNames(tt.user, names), i, gotNames, tt.want[i])
+			}
+			for j := range gotNames {
+				if gotNames[j] != tt.want[i][j] {
+					t.Fatalf("用户 %v: 第 %d 个候选集合为 %v, 期望 %v", attributeSetNames(tt.user, names), i, gotNames, tt.want[i])
+				}
+			}
+			// 补充后必须满足矩阵
+			if rows, _ := m.FindLinearCombinationWeight(append(append([]fr.Element(nil), tt.user...), got[i]...)); rows == nil {
+				t.Fatalf("补充 %v 后仍不满足矩阵", gotNames)
+			}
+		}
+	}
+}
+
+// TestMissingAttributesTooMany 缺失属性超过上限时返回 ErrTooManyMissingAttributes，与已满足矩阵时的 (nil, nil) 区分开
+func TestMissingAttributesTooMany(t *testing.T) {
+	leaves := make([]*BinaryAccessTree, maxMissingAttributeSearch+1)
+	for i := range leaves {
+		leaves[i] = LeafFromString(fmt.Sprintf("attr%d", i))
+	}
+	m := NewLSSSMatrixFromBinaryTree(And(leaves...))
+
+	if _, err := m.MissingAttributes(nil); !errors.Is(err, ErrTooManyMissingAttributes) {
+		t.Fatalf("期望 ErrTooManyMissingAttributes, 得到 %v", err)
+	}
+	all := make([]fr.Element, len(leaves))
+	for i, leaf := range leaves {
+		all[i] = leaf.Attribute
+	}
+	if got, err := m.MissingAttributes(all); got != nil || err != nil {
+		t.Fatalf("已满足矩阵时期望 (nil, nil), 得到 (%v, %v)", got, err)
+	}
+}
+
+// TestNextMaskOfSameSize 逐个生成的组合与按数值扫描全部掩码得到的结果一致
+func TestNextMaskOfSameSize(t *testing.T) {
+	const n = 6
+	for size := 1; size <= n; size++ {
+		var want []uint32
+		for mask := uint32(0); mask < 1<<n; mask++ {
+			if bits.OnesCount32(mask) == size {
+				want = append(want, mask)
+			}
+		}
+		var got []uint32
+		for mask := uint32(1)<<size - 1; mask < 1<<n; mask = nextMaskOfSameSize(mask) {
+			got = append(got, mask)
+		}
+		if fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Fatalf("size %d: 得到 %v, 期望 %v", size, got, want)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package lsss
+
+import (
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+)
+
+// NewLSSSMatrixFromNaryTree 将访问树中连续的同类门视为一个 n 元门，直接按 n 元展开构造LSSS矩阵
+//
+// And/Or 等构造函数会把多个子节点组织成左结合（或右结合）的二叉链，该函数通过 flattenChain
+// 把整条链还原成一个 n 元门后一次性展开：
+//   - n 元 OR：每个子节点继承父节点的向量，不增加列
+//   - k 元 AND：一次增加 k-1 列 c, c+1, ..., c+k-2；第 i 个子节点（i < k）的向量在第 c+i-1 列为 -1、其余为 0，
+//     最后一个子节点的向量为父向量并在这 k-1 列上补 1。所有子节点向量之和等于父向量，缺少任何一个都无法重构
+//   - 叶子节点：成为矩阵的一行
+//
+// k = 2 时展开结果与 NewLSSSMatrixFromBinaryTree 完全一致。对 Lewko-Waters 构造而言，二叉链的每个 AND 门
+// 同样只增加一列，因此 k 元 AND 与二叉链的列数相同（均为 k-1），行数也相同；n 元展开的好处在于结果与链的
+// 结合方式无关，同一个合取式无论左结合、右结合还是混合结合都得到同一个矩阵。
+// 与 NewLSSSMatrixFromBinaryTree 不同，该函数不会修改访问树节点的 Vector 字段。
+//
+// 参数：
+//   - root: 访问树的根节点
+//
+// 返回值：
+//   - *LewkoWatersLsssMatrix: 构造好的LSSS矩阵
+func NewLSSSMatrixFromNaryTree(root *BinaryAccessTree) *LewkoWatersLsssMatrix {
+	e := newNaryExpander()
+	e.expand(root, []fr.Element{fr.NewElement(1)})
+	return e.matrix()
+}
+
+// naryExpander 保存 n 元展开过程中的列计数与已生成的行，供 NewLSSSMatrixFromNaryTree 与加权门限展开共用
+type naryExpander struct {
+	counter int            // 当前列数
+	rows    [][]fr.Element // 行向量，长度可能小于counter，缺失部分视为0
+	rho     []fr.Element   // 行索引到属性的映射
+}
+
+func newNaryExpander() *naryExpander {
+	return &naryExpander{counter: 1}
+}
+
+// expand 以 vector 为节点 node 的向量递归展开子树
+func (e *naryExpander) expand(node *BinaryAccessTree, vector []fr.Element) {
+	oneElement := fr.NewElement(1)
+	minusOneElement := *new(fr.Element).Neg(&oneElement)
+	switch node.Type {
+	case NodeTypeOr:
+		for _, child := range flattenChain(node, NodeTypeOr) {
+			e.expand(child, paddedVector(vector, len(vector)))
+		}
+	case NodeTypeAnd:
+		children := flattenChain(node, NodeTypeAnd)
+		start := e.counter
+		e.counter += len(children) - 1
+		for i, child := range children[:len(children)-1] {
+			v := make([]fr.Element, start+i+1)
+			v[start+i] = minusOneElement
+			e.expand(child, v)
+		}
+		last := paddedVector(vector, e.counter)
+		for j := start; j < e.counter; j++ {
+			last[j] = oneElement
+		}
+		e.expand(children[len(children)-1], last)
+	case NodeTypeLeave:
+		e.rows = append(e.rows, paddedVector(vector, len(vector)))
+		e.rho = append(e.rho, node.Attribute)
+	default:
+		panic("node type error")
+	}
+}
+
+// matrix 把所有行补齐到相同长度，返回展开得到的LSSS矩阵
+func (e *naryExpander) matrix() *LewkoWatersLsssMatrix {
+	for i := range e.rows {
+		e.rows[i] = paddedVector(e.rows[i], e.counter)
+	}
+	return &LewkoWatersLsssMatrix{
+		rowNumber:    len(e.rows),
+		columnNumber: e.counter,
+		accessMatrix: e.rows,
+		rho:          e.rho,
+	}
+}
+
+// paddedVector 返回 v 的副本，并用 0 补齐到 length 列
+func paddedVector(v []fr.Element, length int) []fr.Element {
+	result := make([]fr.Element, length)
+	copy(result, v)
+	return result
+}
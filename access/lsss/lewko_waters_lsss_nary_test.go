@@ -0,0 +1,85 @@
+package lsss
+
+import (
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"testing"
+)
+
+// TestNaryFourWayAnd 4 元 AND 的 n 元展开与二叉链矩阵维度相同，均可由完整属性集满足、缺少任一属性时不满足
+func TestNaryFourWayAnd(t *testing.T) {
+	attrs := []fr.Element{fr.NewElement(1), fr.NewElement(2), fr.NewElement(3), fr.NewElement(4)}
+	leaves := func() []*BinaryAccessTree {
+		return []*BinaryAccessTree{Leaf(attrs[0]), Leaf(attrs[1]), Leaf(attrs[2]), Leaf(attrs[3])}
+	}
+	nary := NewLSSSMatrixFromNaryTree(And(leaves()...))
+	binary := NewLSSSMatrixFromBinaryTree(And(leaves()...))
+
+	if nary.RowNumber() != 4 || nary.ColumnNumber() != 4 {
+		t.Fatalf("n-ary 4-way AND should be 4x4, got %dx%d", nary.RowNumber(), nary.ColumnNumber())
+	}
+	if nary.RowNumber() != binary.RowNumber() || nary.ColumnNumber() != binary.ColumnNumber() {
+		t.Fatalf("n-ary %dx%d and binary %dx%d dimensions differ", nary.RowNumber(), nary.ColumnNumber(), binary.RowNumber(), binary.ColumnNumber())
+	}
+
+	for name, m := range map[string]*LewkoWatersLsssMatrix{"n-ary": nary, "binary": binary} {
+		if !satisfiedBySpan(m, attrs) {
+			t.Fatalf("%s: full attribute set should satisfy the policy", name)
+		}
+		if rows, _ := m.FindLinearCombinationWeight(attrs); rows == nil {
+			t.Fatalf("%s: solver should find weights for the full attribute set", name)
+		}
+		for skip := range attrs {
+			var subset []fr.Element
+			for i, a := range attrs {
+				if i != skip {
+					subset = append(subset, a)
+				}
+			}
+			if satisfiedBySpan(m, subset) {
+				t.Fatalf("%s: attribute set without %d should not satisfy the policy", name, skip+1)
+			}
+		}
+	}
+
+	// 结合方式不影响 n 元展开
+	right := NewLSSSMatrixFromNaryTree(AndRight(leaves()...))
+	if right.Fingerprint() != nary.Fingerprint() {
+		t.Fatal("left- and right-associated chains should expand to the same n-ary matrix")
+	}
+}
+
+// TestNaryMatchesBinaryForBinaryGates 不含连续同类门的访问树，n 元展开与二叉展开完全一致
+func TestNaryMatchesBinaryForBinaryGates(t *testing.T) {
+	tree, _ := GetExample12()
+	if NewLSSSMatrixFromNaryTree(tree).Fingerprint() != NewLSSSMatrixFromBinaryTree(tree.Copy()).Fingerprint() {
+		t.Fatal("n-ary and binary expansions should agree when every gate has two children of a different type")
+	}
+}
+
+// TestNaryMixedPolicy n 元展开的矩阵与访问树的布尔语义一致
+func TestNaryMixedPolicy(t *testing.T) {
+	a, b, c, d, e := fr.NewElement(1), fr.NewElement(2), fr.NewElement(3), fr.NewElement(4), fr.NewElement(5)
+	tree := Or(And(Leaf(a), Leaf(b), Leaf(c)), And(Leaf(d), Or(Leaf(e), Leaf(a), Leaf(b))))
+	m := NewLSSSMatrixFromNaryTree(tree)
+	if m.RowNumber() != 7 || m.ColumnNumber() != 4 {
+		t.Fatalf("expected a 7x4 matrix, got %dx%d", m.RowNumber(), m.ColumnNumber())
+	}
+	universe := []fr.Element{a, b, c, d, e}
+	for mask := 0; mask < 1<<len(universe); mask++ {
+		var subset []fr.Element
+		set := make(map[fr.Element]struct{})
+		for i, x := range universe {
+			if mask&(1<<i) != 0 {
+				subset = append(subset, x)
+				set[x] = struct{}{}
+			}
+		}
+		want, err := tree.satisfiedBy(set)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := satisfiedBySpan(m, subset); got != want {
+			t.Fatalf("subset mask %05b: matrix gives %v, tree gives %v", mask, got, want)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package lsss
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"sort"
+)
+
+// ErrAttributesNotSatisfying 表示属性集合不满足访问矩阵，无法构造解密计划
+var ErrAttributesNotSatisfying = errors.New("attributes do not satisfy the access matrix")
+
+// DecryptionPlan 缓存某个用户属性集合在某个访问矩阵上的线性组合 (rows, weights)。
+//
+// FindLinearCombinationWeight 每次都要做一次高斯消元；当同一用户解密大量共用同一策略的密文时，
+// 可以先用 PrecomputeDecryption 求解一次，再把计划交给各方案的 DecryptWithPlan 复用。
+// 计划以矩阵指纹和属性集合指纹为键，Matches 检查二者以防把计划用在其他策略或其他用户上。
+type DecryptionPlan struct {
+	matrix                *LewkoWatersLsssMatrix // 构造计划时的矩阵，用于指针快速比较
+	matrixFingerprint     [32]byte
+	attributesFingerprint [32]byte
+	rows                  []int
+	weights               []fr.Element
+}
+
+// PrecomputeDecryption 为属性集合在访问矩阵上求解线性组合，并构造可复用的解密计划
+//
+// 参数：
+//   - matrix: 访问矩阵
+//   - attributes: 用户拥有的属性集合
+//
+// 返回值：
+//   - *DecryptionPlan: 解密计划
+//   - error: 属性集合不满足访问矩阵时返回 ErrAttributesNotSatisfying
+func PrecomputeDecryption(matrix *LewkoWatersLsssMatrix, attributes []fr.Element) (*DecryptionPlan, error) {
+	rows, weights := matrix.FindLinearCombinationWeight(attributes)
+	if rows == nil {
+		return nil, ErrAttributesNotSatisfying
+	}
+	return &DecryptionPlan{
+		matrix:                matrix,
+		matrixFingerprint:     matrix.Fingerprint(),
+		attributesFingerprint: attributeSetFingerprint(attributes),
+		rows:                  rows,
+		weights:               weights,
+	}, nil
+}
+
+// Matches 判断计划是否由该矩阵和该属性集合构造；属性集合与顺序、重复无关
+//
+// 参数：
+//   - matrix: 密文的访问矩阵
+//   - attributes: 用户拥有的属性集合
+//
+// 返回值：
+//   - bool: 计划可用于该矩阵和属性集合时返回 true
+func (p *DecryptionPlan) Matches(matrix *LewkoWatersLsssMatrix, attributes []fr.Element) bool {
+	if p.matrix != matrix && p.matrixFingerprint != matrix.Fingerprint() {
+		return false
+	}
+	return p.attributesFingerprint == attributeSetFingerprint(attributes)
+}
+
+// Rows 返回参与线性组合的行索引（相对于原矩阵）
+func (p *DecryptionPlan) Rows() []int {
+	return append([]int(nil), p.rows...)
+}
+
+// Weights 返回与 Rows 一一对应的权重系数
+func (p *DecryptionPlan) Weights() []fr.Element {
+	return append([]fr.Element(nil), p.weights...)
+}
+
+// attributeSetFingerprint 对去重并排序后的属性集合求 SHA-256 摘要
+func attributeSetFingerprint(attributes []fr.Element) [32]byte {
+	encoded := make([][fr.Bytes]byte, 0, len(attributes))
+	seen := make(map[fr.Element]struct{}, len(attributes))
+	for _, a := range attributes {
+		if _, ok := seen[a]; ok {
+			continue
+		}
+		seen[a] = struct{}{}
+		encoded = append(encoded, a.Bytes())
+	}
+	sort.Slice(encoded, func(i, j int) bool {
+		return bytes.Compare(encoded[i][:], encoded[j][:]) < 0
+	})
+	h := sha256.New()
+	for i := range encoded {
+		h.Write(encoded[i][:])
+	}
+	var fingerprint [32]byte
+	copy(fingerprint[:], h.Sum(nil))
+	return fingerprint
+}
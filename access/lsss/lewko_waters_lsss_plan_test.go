@@ -0,0 +1,39 @@
+package lsss
+
+import (
+	"errors"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"testing"
+)
+
+func TestDecryptionPlanMatches(t *testing.T) {
+	a, b, c := fr.NewElement(1), fr.NewElement(2), fr.NewElement(3)
+	m := NewLSSSMatrixFromBinaryTree(And(Leaf(a), Or(Leaf(b), Leaf(c))))
+	rebuilt := NewLSSSMatrixFromBinaryTree(And(Leaf(a), Or(Leaf(b), Leaf(c))))
+	if m.Fingerprint() != rebuilt.Fingerprint() {
+		t.Fatal("matrices built from the same tree should have the same fingerprint")
+	}
+
+	plan, err := PrecomputeDecryption(m, []fr.Element{a, b})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !plan.Matches(rebuilt, []fr.Element{b, a, b}) {
+		t.Fatal("plan should match an equal matrix and the same attribute set in any order")
+	}
+	if plan.Matches(m, []fr.Element{a, c}) {
+		t.Fatal("plan should not match a different attribute set")
+	}
+	if plan.Matches(NewLSSSMatrixFromBinaryTree(Or(Leaf(a), Leaf(b))), []fr.Element{a, b}) {
+		t.Fatal("plan should not match a different matrix")
+	}
+
+	rows, weights := m.FindLinearCombinationWeight([]fr.Element{a, b})
+	if len(plan.Rows()) != len(rows) || len(plan.Weights()) != len(weights) {
+		t.Fatal("plan should cache the solver result")
+	}
+
+	if _, err := PrecomputeDecryption(m, []fr.Element{b, c}); !errors.Is(err, ErrAttributesNotSatisfying) {
+		t.Fatalf("expected ErrAttributesNotSatisfying, got %v", err)
+	}
+}
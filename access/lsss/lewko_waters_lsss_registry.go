@@ -0,0 +1,64 @@
+package lsss
+
+import (
+	"errors"
+	"sync"
+)
+
+// ErrUnknownPolicy 表示策略注册表中没有与指纹对应的访问矩阵
+var ErrUnknownPolicy = errors.New("access policy not found in registry")
+
+// PolicyRegistry 按 Fingerprint 保存访问矩阵，使大量共用同一策略的密文只需保存 32 字节的指纹，
+// 由注册表持有唯一一份矩阵。结构相同的矩阵指纹相同，重复注册只保留第一次注册的实例。
+// PolicyRegistry 可以被多个 goroutine 同时使用。
+type PolicyRegistry struct {
+	mu       sync.RWMutex
+	policies map[[32]byte]*LewkoWatersLsssMatrix
+}
+
+// NewPolicyRegistry 创建一个空的策略注册表
+func NewPolicyRegistry() *PolicyRegistry {
+	return &PolicyRegistry{policies: make(map[[32]byte]*LewkoWatersLsssMatrix)}
+}
+
+// Register 把访问矩阵加入注册表并返回其指纹。注册表保存的是 m 本身，注册后不应再修改 m。
+//
+// 参数：
+//   - m: 访问矩阵
+//
+// 返回值：
+//   - [32]byte: 矩阵指纹，即 m.Fingerprint()
+func (r *PolicyRegistry) Register(m *LewkoWatersLsssMatrix) [32]byte {
+	fingerprint := m.Fingerprint()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.policies[fingerprint]; !ok {
+		r.policies[fingerprint] = m
+	}
+	return fingerprint
+}
+
+// Lookup 返回指纹对应的访问矩阵
+//
+// 参数：
+//   - fingerprint: Register 返回的矩阵指纹
+//
+// 返回值：
+//   - *LewkoWatersLsssMatrix: 注册表中的访问矩阵，多个调用方共享，不应修改
+//   - error: 指纹未注册时返回 ErrUnknownPolicy
+func (r *PolicyRegistry) Lookup(fingerprint [32]byte) (*LewkoWatersLsssMatrix, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	m, ok := r.policies[fingerprint]
+	if !ok {
+		return nil, ErrUnknownPolicy
+	}
+	return m, nil
+}
+
+// Len 返回注册表中不同访问矩阵的个数
+func (r *PolicyRegistry) Len() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.policies)
+}
@@ -0,0 +1,32 @@
+package lsss
+
+import (
+	"errors"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"testing"
+)
+
+// TestPolicyRegistry 分别构造的相同策略只登记一份，查找返回第一次登记的实例；未登记的指纹返回 ErrUnknownPolicy
+func TestPolicyRegistry(t *testing.T) {
+	build := func() *LewkoWatersLsssMatrix {
+		return NewLSSSMatrixFromBinaryTree(And(Leaf(fr.NewElement(1)), Or(Leaf(fr.NewElement(2)), Leaf(fr.NewElement(3)))))
+	}
+	registry := NewPolicyRegistry()
+	first := build()
+	fingerprint := registry.Register(first)
+	if registry.Register(build()) != fingerprint || registry.Len() != 1 {
+		t.Fatal("structurally identical matrices should share one registry entry")
+	}
+	got, err := registry.Lookup(fingerprint)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != first {
+		t.Fatal("Lookup should return the first registered matrix")
+	}
+
+	other := NewLSSSMatrixFromBinaryTree(Or(Leaf(fr.NewElement(1)), Leaf(fr.NewElement(2))))
+	if _, err := registry.Lookup(other.Fingerprint()); !errors.Is(err, ErrUnknownPolicy) {
+		t.Fatalf("expected ErrUnknownPolicy, got %v", err)
+	}
+}
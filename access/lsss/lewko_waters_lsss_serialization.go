@@ -0,0 +1,98 @@
+package lsss
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/mmsyan/GoPairingBasedCryptography/serialization"
+)
+
+// MarshalBinary 将LSSS矩阵序列化为规范字节串
+//
+// 布局：头部(serialization.HeaderSize 字节) || 行数(4字节大端序) || 列数(4字节大端序) || 逐行的 (列数个矩阵元素 || rho)，
+// 每个域元素使用 32 字节大端序的规范编码。
+//
+// 返回值：
+//   - []byte: 序列化后的矩阵
+//   - error: 理论上不会失败，保留用于与 encoding.BinaryMarshaler 一致
+func (m *LewkoWatersLsssMatrix) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 0, serialization.HeaderSize+8+m.rowNumber*(m.columnNumber+1)*fr.Bytes)
+	data = serialization.AppendHeader(data, serialization.SchemeLSSSMatrix)
+	data = binary.BigEndian.AppendUint32(data, uint32(m.rowNumber))
+	data = binary.BigEndian.AppendUint32(data, uint32(m.columnNumber))
+	for i := 0; i < m.rowNumber; i++ {
+		for j := 0; j < m.columnNumber; j++ {
+			b := m.accessMatrix[i][j].Bytes()
+			data = append(data, b[:]...)
+		}
+		b := m.rho[i].Bytes()
+		data = append(data, b[:]...)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary 从 MarshalBinary 的输出恢复LSSS矩阵
+//
+// 参数：
+//   - data: 序列化后的矩阵
+//
+// 返回值：
+//   - error: 长度不符或域元素编码不规范时返回错误；行数或列数超过 MaxPolicyNodes 时包装 ErrPolicyTooLarge
+func (m *LewkoWatersLsssMatrix) UnmarshalBinary(data []byte) error {
+	data, err := serialization.CheckHeader(data, serialization.SchemeLSSSMatrix)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal lsss matrix: %w", err)
+	}
+	if len(data) < 8 {
+		return errors.New("failed to unmarshal lsss matrix: not enough bytes")
+	}
+	rows := int(binary.BigEndian.Uint32(data[0:4]))
+	columns := int(binary.BigEndian.Uint32(data[4:8]))
+	if rows == 0 || columns == 0 {
+		return errors.New("failed to unmarshal lsss matrix: empty matrix")
+	}
+	if rows > MaxPolicyNodes || columns > MaxPolicyNodes {
+		return fmt.Errorf("failed to unmarshal lsss matrix: %d rows of %d columns: %w", rows, columns, ErrPolicyTooLarge)
+	}
+	if uint64(len(data)-8) != uint64(rows)*uint64(columns+1)*fr.Bytes {
+		return fmt.Errorf("failed to unmarshal lsss matrix: expected %d rows of %d columns", rows, columns)
+	}
+	data = data[8:]
+
+	readElement := func() (fr.Element, error) {
+		var e fr.Element
+		err := e.SetBytesCanonical(data[:fr.Bytes])
+		data = data[fr.Bytes:]
+		return e, err
+	}
+	matrix := make([][]fr.Element, rows)
+	rho := make([]fr.Element, rows)
+	for i := 0; i < rows; i++ {
+		matrix[i] = make([]fr.Element, columns)
+		for j := 0; j < columns; j++ {
+			e, err := readElement()
+			if err != nil {
+				return fmt.Errorf("failed to unmarshal lsss matrix: %w", err)
+			}
+			matrix[i][j] = e
+		}
+		e, err := readElement()
+		if err != nil {
+			return fmt.Errorf("failed to unmarshal lsss matrix: %w", err)
+		}
+		rho[i] = e
+	}
+	m.rowNumber, m.columnNumber, m.accessMatrix, m.rho = rows, columns, matrix, rho
+	return nil
+}
+
+// Fingerprint 返回矩阵规范序列化的 SHA-256 摘要，相同结构的矩阵指纹相同
+//
+// 返回值：
+//   - [32]byte: 矩阵指纹
+func (m *LewkoWatersLsssMatrix) Fingerprint() [32]byte {
+	data, _ := m.MarshalBinary()
+	return sha256.Sum256(data)
+}
@@ -0,0 +1,24 @@
+package lsss
+
+import (
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"testing"
+)
+
+func TestLSSSMatrixMarshalBinary(t *testing.T) {
+	m := NewLSSSMatrixFromBinaryTree(And(Leaf(fr.NewElement(1)), Or(Leaf(fr.NewElement(2)), Leaf(fr.NewElement(3)))))
+	data, err := m.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded LewkoWatersLsssMatrix
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Fingerprint() != m.Fingerprint() || decoded.RowNumber() != m.RowNumber() || decoded.ColumnNumber() != m.ColumnNumber() {
+		t.Fatal("decoded matrix differs from the original")
+	}
+	if err := decoded.UnmarshalBinary(data[:len(data)-1]); err == nil {
+		t.Fatal("truncated data should be rejected")
+	}
+}
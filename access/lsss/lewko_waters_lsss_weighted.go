@@ -0,0 +1,155 @@
+package lsss
+
+import (
+	"fmt"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+)
+
+// WeightedChild 是加权门限节点的一个子策略及其权重
+type WeightedChild struct {
+	Policy *BinaryAccessTree // 子策略，可以是叶子或任意 AND/OR 子树
+	Weight int               // 子策略满足时计入的权重，至少为 1
+}
+
+// WeightedThresholdNode 表示加权门限策略：所有满足的子策略权重之和不小于门限时策略满足，
+// 例如 CEO 计 3、Manager 计 2、Staff 计 1，权重之和至少为 4。
+//
+// 访问树只有 AND/OR 两种门，用 AtLeast 表达加权门限需要按权重组合枚举子集，叶子数量呈组合增长。
+// 加权门限节点直接展开为 Shamir 门限：门限为 T 时新增 T-1 列，第 j 份分享的向量为父向量并在这些列上
+// 依次填入 j, j², ..., j^(T-1)，即多项式 q(x) = s + a₁x + ... + a_{T-1}x^(T-1) 在 x = j 处的取值。
+// 权重为 w 的子策略获得 w 份分享（子树相应地被复制 w 次），任意 T 份分享可由拉格朗日插值重构 s，
+// 少于 T 份则无法重构。
+type WeightedThresholdNode struct {
+	threshold int
+	children  []WeightedChild
+}
+
+// WeightedThreshold 创建加权门限节点，例如
+// WeightedThreshold(4, []WeightedChild{{Policy: LeafFromString("CEO"), Weight: 3}, {Policy: LeafFromString("Manager"), Weight: 2}})。
+// 与 AtLeast 相同，参数不合法时 panic。
+//
+// 单个子策略的权重超过门限时按门限计算，这不改变策略的语义，但可以减少矩阵行数。
+// 矩阵行数为各子策略叶子数与其权重之积的和，来自不可信输入的策略应使用 NewLSSSMatrixFromWeightedThresholdChecked。
+//
+// 参数:
+//   - threshold: 门限值，需满足 1 <= threshold <= 权重之和
+//   - children: 子策略及其权重，子策略不能为 nil，权重至少为 1
+//
+// 返回值:
+//   - *WeightedThresholdNode: 加权门限节点，子策略在展开时不会被修改
+func WeightedThreshold(threshold int, children []WeightedChild) *WeightedThresholdNode {
+	total := 0
+	for _, child := range children {
+		if child.Policy == nil || child.Weight < 1 {
+			panic("WeightedThreshold() requires non-nil children with weight >= 1")
+		}
+		total += child.Weight
+	}
+	if threshold < 1 || threshold > total {
+		panic("WeightedThreshold() requires 1 <= threshold <= total weight")
+	}
+	return &WeightedThresholdNode{
+		threshold: threshold,
+		children:  append([]WeightedChild(nil), children...),
+	}
+}
+
+// Threshold 返回门限值
+func (w *WeightedThresholdNode) Threshold() int {
+	return w.threshold
+}
+
+// SatisfiedBy 按布尔语义判断属性集是否满足加权门限策略，即满足的子策略权重之和是否不小于门限
+//
+// 参数:
+//   - attributes: 用户属性集合
+//
+// 返回值:
+//   - bool: 是否满足策略
+//   - error: 子策略中出现未知节点类型时返回错误
+func (w *WeightedThresholdNode) SatisfiedBy(attributes []fr.Element) (bool, error) {
+	set := make(map[fr.Element]struct{}, len(attributes))
+	for _, attr := range attributes {
+		set[attr] = struct{}{}
+	}
+	sum := 0
+	for _, child := range w.children {
+		ok, err := child.Policy.satisfiedBy(set)
+		if err != nil {
+			return false, err
+		}
+		if ok {
+			sum += child.Weight
+		}
+	}
+	return sum >= w.threshold, nil
+}
+
+// shares 返回每个子策略实际获得的分享份数，即 min(权重, 门限)
+func (w *WeightedThresholdNode) shares() []int {
+	shares := make([]int, len(w.children))
+	for i, child := range w.children {
+		shares[i] = min(child.Weight, w.threshold)
+	}
+	return shares
+}
+
+// NewLSSSMatrixFromWeightedThreshold 将加权门限策略展开为LSSS矩阵
+//
+// 门限 T 新增 T-1 列，第 j（j = 1, 2, ...）份分享的向量在这些列上为 (j, j², ..., j^(T-1))；
+// 子策略按 NewLSSSMatrixFromNaryTree 的规则以分享向量为根向量展开，每份分享展开一次。
+// 同一属性会出现在多行上，与 AtLeast 展开的访问树相同，各方案按行而不是按属性处理密文分量。
+//
+// 参数：
+//   - node: 加权门限节点
+//
+// 返回值：
+//   - *LewkoWatersLsssMatrix: 构造好的LSSS矩阵
+func NewLSSSMatrixFromWeightedThreshold(node *WeightedThresholdNode) *LewkoWatersLsssMatrix {
+	e := newNaryExpander()
+	start := e.counter
+	e.counter += node.threshold - 1
+
+	x := 0
+	for i, shares := range node.shares() {
+		for k := 0; k < shares; k++ {
+			x++
+			v := make([]fr.Element, start+node.threshold-1)
+			v[0].SetOne()
+			xElement := fr.NewElement(uint64(x))
+			power := xElement
+			for j := start; j < len(v); j++ {
+				v[j] = power
+				power.Mul(&power, &xElement)
+			}
+			e.expand(node.children[i].Policy, v)
+		}
+	}
+	return e.matrix()
+}
+
+// NewLSSSMatrixFromWeightedThresholdChecked 与 NewLSSSMatrixFromWeightedThreshold 相同，
+// 但在构造矩阵之前先检查规模：每个子策略都要通过 CheckLimits，子策略按分享份数复制后的节点总数不能超过 MaxPolicyNodes。
+//
+// 参数：
+//   - node: 加权门限节点
+//
+// 返回值：
+//   - *LewkoWatersLsssMatrix: 构造好的LSSS矩阵
+//   - error: 超过 MaxPolicyNodes 时包装 ErrPolicyTooLarge，子策略过深时包装 ErrPolicyTooDeep
+func NewLSSSMatrixFromWeightedThresholdChecked(node *WeightedThresholdNode) (*LewkoWatersLsssMatrix, error) {
+	nodes := 1
+	for i, shares := range node.shares() {
+		policy := node.children[i].Policy
+		if err := policy.CheckLimits(); err != nil {
+			return nil, err
+		}
+		count := 0
+		policy.Walk(func(*BinaryAccessTree, int) { count++ })
+		nodes += count * shares
+		if nodes > MaxPolicyNodes {
+			return nil, fmt.Errorf("%w: limit is %d", ErrPolicyTooLarge, MaxPolicyNodes)
+		}
+	}
+	return NewLSSSMatrixFromWeightedThreshold(node), nil
+}
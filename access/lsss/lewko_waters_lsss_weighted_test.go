@@ -0,0 +1,112 @@
+package lsss
+
+import (
+	"errors"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"testing"
+)
+
+// weightedOrgChart 返回 CEO 计 3、两名 Manager 各计 2、三名 Staff 各计 1、门限为 4 的加权门限策略及其属性名称
+func weightedOrgChart() (*WeightedThresholdNode, []string) {
+	weights := map[string]int{"CEO": 3, "Manager_A": 2, "Manager_B": 2, "Staff_A": 1, "Staff_B": 1, "Staff_C": 1}
+	names := []string{"CEO", "Manager_A", "Manager_B", "Staff_A", "Staff_B", "Staff_C"}
+	children := make([]WeightedChild, len(names))
+	for i, name := range names {
+		children[i] = WeightedChild{Policy: LeafFromString(name), Weight: weights[name]}
+	}
+	return WeightedThreshold(4, children), names
+}
+
+// TestWeightedThreshold 两名 Manager（权重 4）满足策略，三名 Staff（权重 3）不满足；所有属性子集的可满足性与权重之和一致
+func TestWeightedThreshold(t *testing.T) {
+	node, names := weightedOrgChart()
+	m := NewLSSSMatrixFromWeightedThreshold(node)
+	// 每个子策略获得 min(权重, 门限) 份分享：3 + 2 + 2 + 1 + 1 + 1 行，门限 4 新增 3 列
+	if m.RowNumber() != 10 || m.ColumnNumber() != 4 {
+		t.Fatalf("expected a 10x4 matrix, got %dx%d", m.RowNumber(), m.ColumnNumber())
+	}
+
+	attrs := func(names ...string) []fr.Element {
+		elements := make([]fr.Element, len(names))
+		for i, name := range names {
+			elements[i] = LeafFromString(name).Attribute
+		}
+		return elements
+	}
+	if rows, _ := m.FindLinearCombinationWeight(attrs("Manager_A", "Manager_B")); rows == nil {
+		t.Fatal("two managers (weight 4) should satisfy the policy")
+	}
+	if rows, _ := m.FindLinearCombinationWeight(attrs("Staff_A", "Staff_B", "Staff_C")); rows != nil {
+		t.Fatal("three staff (weight 3) should not satisfy the policy")
+	}
+
+	for _, subset := range allAttributeSubsets(names) {
+		want, err := node.SatisfiedBy(subset)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := satisfiedBySpan(m, subset); got != want {
+			t.Fatalf("subset %v: span says %v, weights say %v", subset, got, want)
+		}
+		rows, _ := m.FindLinearCombinationWeight(subset)
+		if (rows != nil) != want {
+			t.Fatalf("subset %v: solver found weights = %v, want %v", subset, rows != nil, want)
+		}
+	}
+}
+
+// TestWeightedThresholdSubtrees 子策略为 AND 子树时按分享份数复制，门限为 1 时与析取式等价
+func TestWeightedThresholdSubtrees(t *testing.T) {
+	names := []string{"A", "B", "C"}
+	node := WeightedThreshold(2, []WeightedChild{
+		{Policy: And(LeafFromString("A"), LeafFromString("B")), Weight: 2},
+		{Policy: LeafFromString("C"), Weight: 1},
+		{Policy: LeafFromString("B"), Weight: 1},
+	})
+	m := NewLSSSMatrixFromWeightedThreshold(node)
+	for _, subset := range allAttributeSubsets(names) {
+		want, _ := node.SatisfiedBy(subset)
+		if got := satisfiedBySpan(m, subset); got != want {
+			t.Fatalf("subset %v: span says %v, weights say %v", subset, got, want)
+		}
+	}
+
+	or := WeightedThreshold(1, []WeightedChild{{Policy: LeafFromString("A"), Weight: 5}, {Policy: LeafFromString("B"), Weight: 1}})
+	if NewLSSSMatrixFromWeightedThreshold(or).Fingerprint() != NewLSSSMatrixFromNaryTree(Or(LeafFromString("A"), LeafFromString("B"))).Fingerprint() {
+		t.Fatal("a weighted threshold of 1 should expand to the same matrix as a disjunction")
+	}
+}
+
+// TestWeightedThresholdChecked 按分享份数复制后超过 MaxPolicyNodes 的策略被拒绝
+func TestWeightedThresholdChecked(t *testing.T) {
+	node, _ := weightedOrgChart()
+	if _, err := NewLSSSMatrixFromWeightedThresholdChecked(node); err != nil {
+		t.Fatalf("small policy should pass the limits: %v", err)
+	}
+
+	saved := MaxPolicyNodes
+	defer func() { MaxPolicyNodes = saved }()
+	MaxPolicyNodes = 8
+	if _, err := NewLSSSMatrixFromWeightedThresholdChecked(node); !errors.Is(err, ErrPolicyTooLarge) {
+		t.Fatalf("expected ErrPolicyTooLarge, got %v", err)
+	}
+}
+
+// TestWeightedThresholdInvalid 门限超过权重之和或权重小于 1 时 panic
+func TestWeightedThresholdInvalid(t *testing.T) {
+	for name, build := range map[string]func(){
+		"threshold above total": func() { WeightedThreshold(3, []WeightedChild{{Policy: LeafFromString("A"), Weight: 2}}) },
+		"zero threshold":        func() { WeightedThreshold(0, []WeightedChild{{Policy: LeafFromString("A"), Weight: 2}}) },
+		"zero weight":           func() { WeightedThreshold(1, []WeightedChild{{Policy: LeafFromString("A"), Weight: 0}}) },
+		"nil policy":            func() { WeightedThreshold(1, []WeightedChild{{Weight: 1}}) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: expected panic", name)
+				}
+			}()
+			build()
+		}()
+	}
+}
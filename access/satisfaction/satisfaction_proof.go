@@ -0,0 +1,263 @@
+// Package satisfaction 提供"我的属性满足某个访问策略"的非交互零知识证明，证明不泄露用户持有哪些属性、
+// 使用了矩阵的哪些行以及线性组合的权重。
+//
+// 构造是 Cramer-Damgård-Schoenmakers 的部分知识证明（CRYPTO'94）在 LSSS 矩阵上的实例，经 Fiat-Shamir 变换：
+// 属性 x 对应公开密钥 Y_x = g1^{s_x}，持有属性即知道 s_x。对矩阵的每一行 i 都给出一个 Schnorr 证明
+// (A_i, c_i, z_i)，满足 g1^{z_i} = A_i · Y_{ρ(i)}^{c_i}；同时要求挑战向量满足 Σ c_i · M_i = (c, 0, ..., 0)，
+// 其中 c 是对整个陈述和全部 A_i 的哈希。
+//
+// 证明者预先为不持有的行模拟 Schnorr 证明（先选 c_i、z_i 再反推 A_i），这些 c_i 在 c 确定前就已固定；
+// 持有的行的 c_i 在得到 c 后由 FindLinearCombinationWeight 的权重补齐。只有当持有的行满足策略时，
+// 固定了其余行的挑战后仍能对任意 c 凑出 (c, 0, ..., 0)，因此不满足策略的证明者无法伪造证明。
+// 挑战向量是左零空间中的均匀随机元素加上 c 倍的权重向量，其分布与具体使用了哪些行无关，
+// 所有 z_i 也都是均匀随机的，因此证明不泄露持有的属性。
+//
+// 这里的属性密钥由同一属性的所有持有者共享，证明的是"知道满足策略的属性密钥"，并不绑定用户身份；
+// 属性密钥可以被复制和转交，防止共谋与转交需要匿名凭证等更重的构造，不在本包的范围内。
+package satisfaction
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/mmsyan/GoPairingBasedCryptography/access/lsss"
+	"math/big"
+)
+
+// challengeDST 是 Fiat-Shamir 挑战哈希使用的域分离标签
+var challengeDST = []byte("LSSS-SATISFACTION-PROOF-V1")
+
+var (
+	// ErrPolicyNotSatisfied 表示用户持有的属性密钥不满足访问策略，无法生成证明
+	ErrPolicyNotSatisfied = errors.New("access policy is not satisfied")
+
+	// ErrUnknownAttribute 表示矩阵中的属性或要签发的属性没有对应的属性密钥
+	ErrUnknownAttribute = errors.New("attribute has no attribute key")
+)
+
+// AttributePublicKeys 是属性机构公开的属性密钥 Y_x = g1^{s_x}
+type AttributePublicKeys struct {
+	keys map[fr.Element]bn254.G1Affine
+}
+
+// AttributeSecretKeys 是属性密钥 s_x 的集合。属性机构持有全部属性的密钥，用户持有由 Issue 签发的子集。
+type AttributeSecretKeys struct {
+	keys map[fr.Element]fr.Element
+}
+
+// SatisfactionProof 是属性满足访问策略的非交互证明，矩阵的每一行对应一个挑战和一个响应，共 2l 个域元素。
+type SatisfactionProof struct {
+	// C 是各行的挑战 c_i，满足 Σ c_i · M_i = (c, 0, ..., 0)
+	C []fr.Element
+	// Z 是各行的响应 z_i，满足 g1^{z_i} = A_i · Y_{ρ(i)}^{c_i}
+	Z []fr.Element
+}
+
+// Setup 为属性宇宙中的每个属性生成属性密钥。
+//
+// 参数:
+//   - attributes: 属性宇宙
+//
+// 返回值:
+//   - *AttributePublicKeys: 公开的属性密钥，验证方用它验证证明
+//   - *AttributeSecretKeys: 属性机构持有的全部属性密钥，用 Issue 为用户签发
+//   - error: 随机数生成失败时返回错误
+func Setup(attributes []fr.Element) (*AttributePublicKeys, *AttributeSecretKeys, error) {
+	pk := &AttributePublicKeys{keys: make(map[fr.Element]bn254.G1Affine, len(attributes))}
+	sk := &AttributeSecretKeys{keys: make(map[fr.Element]fr.Element, len(attributes))}
+	for _, x := range attributes {
+		if _, ok := sk.keys[x]; ok {
+			continue
+		}
+		s, err := new(fr.Element).SetRandom()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to set up attribute keys: %w", err)
+		}
+		sk.keys[x] = *s
+		pk.keys[x] = *new(bn254.G1Affine).ScalarMultiplicationBase(s.BigInt(new(big.Int)))
+	}
+	return pk, sk, nil
+}
+
+// Issue 从属性机构的密钥中取出用户获准持有的属性密钥。
+//
+// 参数:
+//   - attributes: 用户获准持有的属性
+//
+// 返回值:
+//   - *AttributeSecretKeys: 用户的属性密钥
+//   - error: 某个属性不在属性宇宙中时返回包装了 ErrUnknownAttribute 的错误
+func (sk *AttributeSecretKeys) Issue(attributes []fr.Element) (*AttributeSecretKeys, error) {
+	user := &AttributeSecretKeys{keys: make(map[fr.Element]fr.Element, len(attributes))}
+	for _, x := range attributes {
+		s, ok := sk.keys[x]
+		if !ok {
+			return nil, fmt.Errorf("failed to issue attribute keys: %w", ErrUnknownAttribute)
+		}
+		user.keys[x] = s
+	}
+	return user, nil
+}
+
+// Attributes 返回密钥集合中的属性，顺序不固定
+func (sk *AttributeSecretKeys) Attributes() []fr.Element {
+	attributes := make([]fr.Element, 0, len(sk.keys))
+	for x := range sk.keys {
+		attributes = append(attributes, x)
+	}
+	return attributes
+}
+
+// ProveSatisfies 证明用户的属性密钥满足访问矩阵，证明绑定在 context 上（例如验证方给出的随机数），
+// 不同 context 下的证明不能互相替代。
+//
+// 参数:
+//   - matrix: 访问矩阵
+//   - pk: 公开的属性密钥，必须包含矩阵中出现的全部属性
+//   - userKeys: 用户持有的属性密钥
+//   - context: 证明绑定的上下文
+//
+// 返回值:
+//   - *SatisfactionProof: 证明
+//   - error: 用户属性不满足策略时返回包装了 ErrPolicyNotSatisfied 的错误；
+//     矩阵中的属性没有公开密钥时返回包装了 ErrUnknownAttribute 的错误
+func ProveSatisfies(matrix *lsss.LewkoWatersLsssMatrix, pk *AttributePublicKeys, userKeys *AttributeSecretKeys, context []byte) (*SatisfactionProof, error) {
+	publicKeys, err := rowKeys(matrix, pk)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prove: %w", err)
+	}
+	rows, weights := matrix.FindLinearCombinationWeight(userKeys.Attributes())
+	if rows == nil {
+		return nil, fmt.Errorf("failed to prove: %w", ErrPolicyNotSatisfied)
+	}
+	l := matrix.RowNumber()
+	a := make([]fr.Element, l)
+	for k, i := range rows {
+		a[i] = weights[k]
+	}
+
+	// 挑战向量的随机部分：左零空间中的均匀随机元素，Σ ĉ_i · M_i = 0
+	challenges := make([]fr.Element, l)
+	for _, basisVector := range matrix.LeftKernelBasis() {
+		var coefficient fr.Element
+		if _, err := coefficient.SetRandom(); err != nil {
+			return nil, fmt.Errorf("failed to prove: %w", err)
+		}
+		for i := range challenges {
+			var temp fr.Element
+			temp.Mul(&coefficient, &basisVector[i])
+			challenges[i].Add(&challenges[i], &temp)
+		}
+	}
+
+	// 持有的行：A_i = g1^{r_i}；不持有的行：先选 z_i，A_i = g1^{z_i} · Y_i^{-ĉ_i}
+	commitments := make([]bn254.G1Affine, l)
+	responses := make([]fr.Element, l)
+	nonces := make([]fr.Element, l)
+	for i := 0; i < l; i++ {
+		if _, held := userKeys.keys[matrix.Rho(i)]; held {
+			if _, err := nonces[i].SetRandom(); err != nil {
+				return nil, fmt.Errorf("failed to prove: %w", err)
+			}
+			commitments[i].ScalarMultiplicationBase(nonces[i].BigInt(new(big.Int)))
+			continue
+		}
+		if _, err := responses[i].SetRandom(); err != nil {
+			return nil, fmt.Errorf("failed to prove: %w", err)
+		}
+		commitments[i] = schnorrCommitment(&publicKeys[i], &challenges[i], &responses[i])
+	}
+
+	// c_i = ĉ_i + c · a_i，不持有的行上 a_i = 0，挑战保持预先选定的值
+	c := challenge(matrix, publicKeys, commitments, context)
+	for i := 0; i < l; i++ {
+		var temp fr.Element
+		temp.Mul(&c, &a[i])
+		challenges[i].Add(&challenges[i], &temp)
+		if s, held := userKeys.keys[matrix.Rho(i)]; held {
+			// z_i = r_i + c_i · s_{ρ(i)}
+			responses[i].Mul(&challenges[i], &s)
+			responses[i].Add(&responses[i], &nonces[i])
+		}
+	}
+	return &SatisfactionProof{C: challenges, Z: responses}, nil
+}
+
+// VerifySatisfies 验证属性满足访问矩阵的证明。
+//
+// 参数:
+//   - matrix: 访问矩阵
+//   - pk: 公开的属性密钥
+//   - proof: ProveSatisfies 生成的证明
+//   - context: 证明绑定的上下文，必须与证明者使用的相同
+//
+// 返回值:
+//   - bool: 证明有效时返回 true；证明格式错误或矩阵中的属性没有公开密钥时返回 false
+func VerifySatisfies(matrix *lsss.LewkoWatersLsssMatrix, pk *AttributePublicKeys, proof *SatisfactionProof, context []byte) bool {
+	l := matrix.RowNumber()
+	if proof == nil || len(proof.C) != l || len(proof.Z) != l {
+		return false
+	}
+	publicKeys, err := rowKeys(matrix, pk)
+	if err != nil {
+		return false
+	}
+	commitments := make([]bn254.G1Affine, l)
+	for i := 0; i < l; i++ {
+		commitments[i] = schnorrCommitment(&publicKeys[i], &proof.C[i], &proof.Z[i])
+	}
+	c := challenge(matrix, publicKeys, commitments, context)
+
+	combined := matrix.CombineRows(proof.C)
+	if !combined[0].Equal(&c) {
+		return false
+	}
+	for j := 1; j < len(combined); j++ {
+		if !combined[j].IsZero() {
+			return false
+		}
+	}
+	return true
+}
+
+// rowKeys 返回矩阵每一行属性的公开密钥 Y_{ρ(i)}
+func rowKeys(matrix *lsss.LewkoWatersLsssMatrix, pk *AttributePublicKeys) ([]bn254.G1Affine, error) {
+	keys := make([]bn254.G1Affine, matrix.RowNumber())
+	for i := range keys {
+		y, ok := pk.keys[matrix.Rho(i)]
+		if !ok {
+			return nil, ErrUnknownAttribute
+		}
+		keys[i] = y
+	}
+	return keys, nil
+}
+
+// schnorrCommitment 由挑战与响应反推承诺 A = g1^z · Y^{-c}
+func schnorrCommitment(y *bn254.G1Affine, c, z *fr.Element) bn254.G1Affine {
+	var gz, yc, commitment bn254.G1Affine
+	gz.ScalarMultiplicationBase(z.BigInt(new(big.Int)))
+	yc.ScalarMultiplication(y, c.BigInt(new(big.Int)))
+	commitment.Sub(&gz, &yc)
+	return commitment
+}
+
+// challenge 计算 Fiat-Shamir 挑战 c = H(matrix || Y_1..Y_l || A_1..A_l || len(context) || context)。
+// 矩阵的规范序列化同时包含 ρ，点使用定长的压缩编码，上下文带长度前缀，因此拼接没有歧义。
+func challenge(matrix *lsss.LewkoWatersLsssMatrix, publicKeys, commitments []bn254.G1Affine, context []byte) fr.Element {
+	transcript, _ := matrix.MarshalBinary()
+	for i := range publicKeys {
+		b := publicKeys[i].Bytes()
+		transcript = append(transcript, b[:]...)
+	}
+	for i := range commitments {
+		b := commitments[i].Bytes()
+		transcript = append(transcript, b[:]...)
+	}
+	transcript = binary.BigEndian.AppendUint32(transcript, uint32(len(context)))
+	transcript = append(transcript, context...)
+	elements, _ := fr.Hash(transcript, challengeDST, 1)
+	return elements[0]
+}
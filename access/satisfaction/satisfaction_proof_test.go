@@ -0,0 +1,104 @@
+package satisfaction
+
+import (
+	"errors"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/mmsyan/GoPairingBasedCryptography/access/lsss"
+	"testing"
+)
+
+// proofFixture 生成属性 A..E 的属性密钥以及策略 (A and B) or (C and (D or E)) 的访问矩阵
+func proofFixture(t *testing.T) (*lsss.LewkoWatersLsssMatrix, *AttributePublicKeys, *AttributeSecretKeys, []fr.Element) {
+	attributes := make([]fr.Element, 5)
+	for i := range attributes {
+		attributes[i] = fr.NewElement(uint64(i + 1))
+	}
+	pk, msk, err := Setup(attributes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, b, c, d, e := attributes[0], attributes[1], attributes[2], attributes[3], attributes[4]
+	matrix := lsss.NewLSSSMatrixFromBinaryTree(lsss.Or(
+		lsss.And(lsss.Leaf(a), lsss.Leaf(b)),
+		lsss.And(lsss.Leaf(c), lsss.Or(lsss.Leaf(d), lsss.Leaf(e))),
+	))
+	return matrix, pk, msk, attributes
+}
+
+// TestProveSatisfies 不同的满足方式都能生成有效证明，证明长度只取决于矩阵
+func TestProveSatisfies(t *testing.T) {
+	matrix, pk, msk, attributes := proofFixture(t)
+	context := []byte("nonce-1")
+	for _, held := range [][]int{{0, 1}, {2, 3}, {2, 4}, {0, 1, 2, 3, 4}} {
+		var userAttributes []fr.Element
+		for _, i := range held {
+			userAttributes = append(userAttributes, attributes[i])
+		}
+		userKeys, err := msk.Issue(userAttributes)
+		if err != nil {
+			t.Fatal(err)
+		}
+		proof, err := ProveSatisfies(matrix, pk, userKeys, context)
+		if err != nil {
+			t.Fatalf("attributes %v: %v", held, err)
+		}
+		if len(proof.C) != matrix.RowNumber() || len(proof.Z) != matrix.RowNumber() {
+			t.Fatalf("attributes %v: proof size depends on the attribute set", held)
+		}
+		if !VerifySatisfies(matrix, pk, proof, context) {
+			t.Fatalf("attributes %v: valid proof rejected", held)
+		}
+	}
+}
+
+// TestProveSatisfiesRejectsUnsatisfied 不满足策略的用户无法生成证明；伪造属性密钥或篡改证明都无法通过验证
+func TestProveSatisfiesRejectsUnsatisfied(t *testing.T) {
+	matrix, pk, msk, attributes := proofFixture(t)
+	context := []byte("nonce-2")
+
+	userKeys, err := msk.Issue([]fr.Element{attributes[0], attributes[2]})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ProveSatisfies(matrix, pk, userKeys, context); !errors.Is(err, ErrPolicyNotSatisfied) {
+		t.Fatalf("expected ErrPolicyNotSatisfied, got %v", err)
+	}
+
+	// 持有 A、C 的用户为 B 编造一个属性密钥，按诚实流程生成的证明不能通过验证
+	forged := &AttributeSecretKeys{keys: map[fr.Element]fr.Element{
+		attributes[0]: userKeys.keys[attributes[0]],
+		attributes[1]: fr.NewElement(42),
+		attributes[2]: userKeys.keys[attributes[2]],
+	}}
+	proof, err := ProveSatisfies(matrix, pk, forged, context)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if VerifySatisfies(matrix, pk, proof, context) {
+		t.Fatal("proof with a forged attribute key must not verify")
+	}
+
+	honest, err := msk.Issue([]fr.Element{attributes[0], attributes[1]})
+	if err != nil {
+		t.Fatal(err)
+	}
+	proof, err = ProveSatisfies(matrix, pk, honest, context)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if VerifySatisfies(matrix, pk, proof, []byte("nonce-3")) {
+		t.Fatal("proof must not verify under a different context")
+	}
+	other := lsss.NewLSSSMatrixFromBinaryTree(lsss.And(lsss.Leaf(attributes[0]), lsss.Leaf(attributes[1])))
+	if VerifySatisfies(other, pk, proof, context) {
+		t.Fatal("proof must not verify against a different matrix")
+	}
+	tampered := &SatisfactionProof{C: append([]fr.Element(nil), proof.C...), Z: append([]fr.Element(nil), proof.Z...)}
+	tampered.Z[0].Add(&tampered.Z[0], new(fr.Element).SetOne())
+	if VerifySatisfies(matrix, pk, tampered, context) {
+		t.Fatal("tampered proof must not verify")
+	}
+	if VerifySatisfies(matrix, pk, &SatisfactionProof{C: proof.C[:1], Z: proof.Z[:1]}, context) {
+		t.Fatal("truncated proof must not verify")
+	}
+}
@@ -0,0 +1,94 @@
+// Package access 提供基于签名的解密授权：签名者对上下文字符串的签名作为令牌，持有令牌即可解密发往该签名者的
+// AGKA09 密文。
+//
+// 这是 AGKA09 中"签名即解密密钥"用法的封装：AGKA09 的公钥 (R, A) = (g2^{-r}, e(X, g2)) 与签名
+// σ = X·H(s)^r 共用同一组私钥，任何字符串 s 上的合法签名都能消去密文中的 A^t。令牌因此只能由 agka09 私钥签发；
+// ZSS04、BB04 等其他方案的签名与 AGKA09 公钥没有这种代数关系，不能充当解密令牌。
+//
+// 原始的 AGKA09 密文不记录上下文，任何上下文上的签名都能解密。Encrypt 把加密方要求的上下文写入密文，
+// DecryptWithToken 先确认令牌的上下文与之相同，再用令牌中的签名解密，从而把授权限定在指定的上下文上。
+package access
+
+import (
+	"errors"
+	"fmt"
+	"github.com/mmsyan/GoPairingBasedCryptography/gka/agka09"
+)
+
+// ErrContextMismatch 表示令牌签名的上下文与密文要求的上下文不同
+var ErrContextMismatch = errors.New("token context does not match ciphertext context")
+
+// Token 是解密授权令牌：私钥持有者对上下文字符串的 AGKA09 签名
+type Token struct {
+	// Context 是被签名的上下文字符串，例如 "read:/reports/2026-Q3"
+	Context string
+	// Signature 是 Context 上的签名 σ = X·H(Context)^r
+	Signature agka09.Signature
+}
+
+// Ciphertext 是限定了授权上下文的 AGKA09 密文
+type Ciphertext struct {
+	// Context 是加密方要求的上下文，只有该上下文上的令牌才能解密
+	Context string
+	// CipherText 是底层的 AGKA09 密文
+	CipherText agka09.CipherText
+}
+
+// IssueToken 用 AGKA09 私钥对上下文签名，签发解密令牌。
+// 对聚合公钥加密的密文，可以用 agka09.AggregateSignatures 聚合各成员对同一上下文签发的令牌中的签名。
+//
+// 参数:
+//   - sk: 密文接收者的 AGKA09 私钥
+//   - context: 授权的上下文
+//
+// 返回值:
+//   - *Token: 解密令牌
+//   - error: 签名失败时返回错误
+func IssueToken(sk *agka09.PrivateKey, context string) (*Token, error) {
+	sigma, err := agka09.Sign(&agka09.SignMessage{S: []byte(context)}, sk)
+	if err != nil {
+		return nil, fmt.Errorf("failed to issue token: %w", err)
+	}
+	return &Token{Context: context, Signature: *sigma}, nil
+}
+
+// Encrypt 用 AGKA09 公钥加密明文，并在密文中记录解密所需的上下文。
+//
+// 参数:
+//   - plaintext: 明文
+//   - pk: 接收者（或聚合后的群组）公钥
+//   - context: 解密令牌必须签名的上下文
+//
+// 返回值:
+//   - *Ciphertext: 密文
+//   - error: 加密失败时返回错误
+func Encrypt(plaintext *agka09.PlainText, pk *agka09.PublicKey, context string) (*Ciphertext, error) {
+	ct, err := agka09.Encrypt(plaintext, pk)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt: %w", err)
+	}
+	return &Ciphertext{Context: context, CipherText: *ct}, nil
+}
+
+// DecryptWithToken 用解密令牌解密密文。
+//
+// 上下文一致时用令牌中的签名执行 AGKA09 解密。AGKA09 本身没有完整性校验，
+// 签名不是由接收者私钥签发的令牌会得到与明文无关的结果而不会报错。
+//
+// 参数:
+//   - ct: Encrypt 生成的密文
+//   - token: IssueToken 签发的令牌
+//
+// 返回值:
+//   - *agka09.PlainText: 解密得到的明文
+//   - error: 令牌的上下文与密文不同时返回 ErrContextMismatch，解密失败时返回错误
+func DecryptWithToken(ct *Ciphertext, token *Token) (*agka09.PlainText, error) {
+	if token.Context != ct.Context {
+		return nil, fmt.Errorf("failed to decrypt with token: token for %q, ciphertext requires %q: %w", token.Context, ct.Context, ErrContextMismatch)
+	}
+	plaintext, err := agka09.Decrypt(ct.CipherText, &agka09.SignMessage{S: []byte(ct.Context)}, &token.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt with token: %w", err)
+	}
+	return plaintext, nil
+}
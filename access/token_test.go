@@ -0,0 +1,86 @@
+package access
+
+import (
+	"errors"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/mmsyan/GoPairingBasedCryptography/gka/agka09"
+	"testing"
+)
+
+func newTokenFixture(t *testing.T) (*agka09.PublicKey, *agka09.PrivateKey, *agka09.PlainText) {
+	t.Helper()
+	pp, err := agka09.ParaGen()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pk, sk, err := agka09.KeyGen(pp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := new(bn254.GT).SetRandom()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pk, sk, &agka09.PlainText{M: *m}
+}
+
+// TestDecryptWithToken 对密文要求的上下文签发的令牌可以解密
+func TestDecryptWithToken(t *testing.T) {
+	pk, sk, plaintext := newTokenFixture(t)
+	ct, err := Encrypt(plaintext, pk, "Authorized Access")
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, err := IssueToken(sk, "Authorized Access")
+	if err != nil {
+		t.Fatal(err)
+	}
+	decrypted, err := DecryptWithToken(ct, token)
+	if err != nil {
+		t.Fatalf("DecryptWithToken failed: %v", err)
+	}
+	if !decrypted.M.Equal(&plaintext.M) {
+		t.Fatal("Decrypted plaintext does not match the original")
+	}
+}
+
+// TestDecryptWithTokenWrongContext 对其他上下文签发的令牌返回 ErrContextMismatch；
+// 把令牌的上下文改成密文要求的上下文也无法解密，因为签名仍是对原上下文的签名
+func TestDecryptWithTokenWrongContext(t *testing.T) {
+	pk, sk, plaintext := newTokenFixture(t)
+	ct, err := Encrypt(plaintext, pk, "Message B")
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, err := IssueToken(sk, "Message A")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := DecryptWithToken(ct, token); !errors.Is(err, ErrContextMismatch) {
+		t.Fatalf("expected ErrContextMismatch, got %v", err)
+	}
+
+	relabeled := &Token{Context: ct.Context, Signature: token.Signature}
+	decrypted, err := DecryptWithToken(ct, relabeled)
+	if err == nil && decrypted.M.Equal(&plaintext.M) {
+		t.Fatal("Security flaw: Decrypted successfully with a token signed over another context")
+	}
+}
+
+// TestDecryptWithTokenUnauthorizedSigner 其他私钥对同一上下文签发的令牌不能解密
+func TestDecryptWithTokenUnauthorizedSigner(t *testing.T) {
+	pk, _, plaintext := newTokenFixture(t)
+	_, otherSK, _ := newTokenFixture(t)
+	ct, err := Encrypt(plaintext, pk, "I am the owner")
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, err := IssueToken(otherSK, "I am the owner")
+	if err != nil {
+		t.Fatal(err)
+	}
+	decrypted, err := DecryptWithToken(ct, token)
+	if err == nil && decrypted.M.Equal(&plaintext.M) {
+		t.Fatal("Security flaw: Decrypted ciphertext using an unauthorized signer's token")
+	}
+}
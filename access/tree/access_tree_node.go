@@ -1,9 +1,11 @@
 package tree
 
 import (
+	"crypto/sha256"
 	"fmt"
 	"github.com/consensys/gnark-crypto/ecc/bn254"
 	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/mmsyan/GoPairingBasedCryptography/metrics"
 	"github.com/mmsyan/GoPairingBasedCryptography/utils"
 	"math/big"
 )
@@ -23,6 +25,10 @@ type AccessTreeNode struct {
 	children   []*AccessTreeNode
 	parent     *AccessTreeNode
 	childIndex int
+	// negated 为 true 时表示该叶子节点由 NewNotNode 创建，Attribute 为否定属性，
+	// negatedFrom 保存取反前的原始属性
+	negated     bool
+	negatedFrom fr.Element
 
 	secret fr.Element
 	Poly   []fr.Element
@@ -51,6 +57,60 @@ func NewThresholdNode(threshold int, children ...*AccessTreeNode) *AccessTreeNod
 	return node
 }
 
+// NegateAttribute 返回属性 attr 对应的否定属性 ¬attr。
+// BSW07 只支持单调访问结构，否定通过"显式否定属性"实现：
+// 属性授权机构为不持有 attr 的用户额外颁发 NegateAttribute(attr)，
+// 这样 NOT attr 就成为一个普通的（单调的）叶子节点。
+//
+// 参数:
+//   - attr: 原始属性
+//
+// 返回值:
+//   - fr.Element: 确定性派生的否定属性
+func NegateAttribute(attr fr.Element) fr.Element {
+	attrBytes := attr.Bytes()
+	digest := sha256.Sum256(append([]byte("access tree NOT:"), attrBytes[:]...))
+	return *new(fr.Element).SetBytes(digest[:])
+}
+
+// NewNotNode 为叶子节点创建 NOT 包装，返回以 NegateAttribute(leaf.Attribute) 为属性的叶子节点。
+// 只能包装叶子节点，对门限节点取反无法用单调秘密共享表示，此时 panic。
+//
+// 参数:
+//   - leaf: 待取反的叶子节点
+//
+// 返回值:
+//   - *AccessTreeNode: 否定属性叶子节点
+func NewNotNode(leaf *AccessTreeNode) *AccessTreeNode {
+	if !leaf.isLeaf() {
+		panic("NOT can only wrap a leaf node")
+	}
+	if leaf.negated {
+		// ¬¬a = a
+		return NewLeafNode(leaf.originalAttribute())
+	}
+	return &AccessTreeNode{
+		nodeType:    NodeTypeLeave,
+		Attribute:   NegateAttribute(leaf.Attribute),
+		negated:     true,
+		negatedFrom: leaf.Attribute,
+		children:    nil,
+	}
+}
+
+// originalAttribute 返回否定叶子节点包装前的原始属性
+func (node *AccessTreeNode) originalAttribute() fr.Element {
+	if node.negated {
+		return node.negatedFrom
+	}
+	return node.Attribute
+}
+
+// IsNegated 返回该节点是否为 NOT 包装的否定属性叶子节点
+func (node *AccessTreeNode) IsNegated() bool {
+	return node.negated
+}
+
 func (node *AccessTreeNode) isLeaf() bool {
 	return node.nodeType == NodeTypeLeave
 }
@@ -103,11 +163,11 @@ func (node *AccessTreeNode) DecryptNode(attributes map[fr.Element]struct{}, dj m
 			cxPrime := cyPrime[node.LeafId]
 			diPrime := djPrime[node.Attribute]
 			fmt.Println(cx, cxPrime, di, diPrime)
-			eDiCx, err := bn254.Pair([]bn254.G1Affine{cx}, []bn254.G2Affine{di})
+			eDiCx, err := metrics.Pair([]bn254.G1Affine{cx}, []bn254.G2Affine{di})
 			if err != nil {
 				panic(err)
 			}
-			eDiPrimeCxPrime, err := bn254.Pair([]bn254.G1Affine{cxPrime}, []bn254.G2Affine{diPrime})
+			eDiPrimeCxPrime, err := metrics.Pair([]bn254.G1Affine{cxPrime}, []bn254.G2Affine{diPrime})
 			if err != nil {
 				panic(err)
 			}
@@ -116,7 +176,7 @@ func (node *AccessTreeNode) DecryptNode(attributes map[fr.Element]struct{}, dj m
 			qx0 := utils.ComputePolynomialValue(node.Poly, fr.NewElement(0))
 			qx0MulR := new(fr.Element).Mul(&qx0, &r)
 			_, _, g1, g2 := bn254.Generators()
-			eG1G2, err := bn254.Pair([]bn254.G1Affine{g1}, []bn254.G2Affine{g2})
+			eG1G2, err := metrics.Pair([]bn254.G1Affine{g1}, []bn254.G2Affine{g2})
 			if err != nil {
 				panic(err)
 			}
@@ -130,34 +190,26 @@ func (node *AccessTreeNode) DecryptNode(attributes map[fr.Element]struct{}, dj m
 		return nil
 	}
 
-	indexToChild := make(map[int]*AccessTreeNode)
-	indexToSecret := make(map[int]bn254.GT)
+	indexToSecret := make(map[fr.Element]bn254.GT)
+	var s []fr.Element
 
 	for i, c := range node.children {
 		childSecret := c.DecryptNode(attributes, dj, djPrime, cy, cyPrime, r)
 		// 修复：只有当子节点解密成功（不等于零元素）时才添加
 		if childSecret != nil {
-			indexToChild[i+1] = c
-			indexToSecret[i+1] = *childSecret
-			if len(indexToChild) == node.threshold {
+			index := fr.NewElement(uint64(i + 1))
+			indexToSecret[index] = *childSecret
+			s = append(s, index)
+			if len(s) == node.threshold {
 				break
 			}
 		}
 	}
 
-	if len(indexToChild) == node.threshold {
-		result := new(bn254.GT).SetOne()
-		var s []fr.Element
-		for index := range indexToChild {
-			s = append(s, fr.NewElement(uint64(index)))
-		}
-		for index := range indexToChild {
-			delta := utils.ComputeLagrangeBasis(fr.NewElement(uint64(index)), s, fr.NewElement(0))
-			fz := new(bn254.GT).Exp(indexToSecret[index], delta.BigInt(new(big.Int)))
-			result = new(bn254.GT).Mul(result, fz)
-		}
-		fmt.Println("e(g,g)^rs result:", *result)
-		return result
+	if len(s) == node.threshold {
+		result := utils.LagrangeCombineGT(indexToSecret, s, fr.NewElement(0))
+		fmt.Println("e(g,g)^rs result:", result)
+		return &result
 	}
 
 	return nil
@@ -173,3 +225,52 @@ func (node *AccessTreeNode) GetLeafNodes() []*AccessTreeNode {
 	}
 	return leafNodes
 }
+
+// AttributeSet 返回访问树中出现的所有叶子属性（去重，按首次出现的顺序）。
+// 否定叶子返回其否定属性 NegateAttribute(a)。
+//
+// 返回值:
+//   - []fr.Element: 访问树涉及的属性集合
+func (node *AccessTreeNode) AttributeSet() []fr.Element {
+	seen := make(map[fr.Element]struct{})
+	var attributes []fr.Element
+	for _, leaf := range node.GetLeafNodes() {
+		if _, ok := seen[leaf.Attribute]; ok {
+			continue
+		}
+		seen[leaf.Attribute] = struct{}{}
+		attributes = append(attributes, leaf.Attribute)
+	}
+	return attributes
+}
+
+// Satisfiable 判断用户属性集合是否满足访问树，无需执行任何配对运算。
+// 叶子节点在用户持有其属性时满足；门限节点递归统计满足的子节点个数，
+// 不少于门限值时满足。否定叶子要求用户持有对应的否定属性，与 Decrypt 的行为一致。
+//
+// 参数:
+//   - userAttrs: 用户属性集合
+//
+// 返回值:
+//   - bool: 是否满足访问树
+func (node *AccessTreeNode) Satisfiable(userAttrs []fr.Element) bool {
+	attributes := make(map[fr.Element]struct{}, len(userAttrs))
+	for _, a := range userAttrs {
+		attributes[a] = struct{}{}
+	}
+	return node.satisfiable(attributes)
+}
+
+func (node *AccessTreeNode) satisfiable(attributes map[fr.Element]struct{}) bool {
+	if node.isLeaf() {
+		_, ok := attributes[node.Attribute]
+		return ok
+	}
+	satisfied := 0
+	for _, child := range node.children {
+		if child.satisfiable(attributes) {
+			satisfied++
+		}
+	}
+	return satisfied >= node.threshold
+}
@@ -0,0 +1,109 @@
+// Package aead 为混合加密（KEM/DEM）中的对称加密部分提供可替换的 AEAD 构造。
+//
+// 以 GT 元素为明文的方案通过 KEM 封装会话密钥，再用 AEAD 加密任意字节数据。
+// 默认的 AES-256-GCM 在有 AES 硬件指令的平台上最快；没有硬件加速的环境（部分 ARM、嵌入式设备）
+// 可以改用 ChaCha20-Poly1305。所选构造的 ID 写入密文头部，解密时据此选择相同的构造。
+package aead
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"errors"
+	"fmt"
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+// ID 标识一种 AEAD 构造，写入密文头部；取值一经发布不再改变
+type ID byte
+
+const (
+	// IDAES256GCM 对应 AES-256-GCM
+	IDAES256GCM ID = 1
+	// IDChaCha20Poly1305 对应 RFC 8439 的 ChaCha20-Poly1305
+	IDChaCha20Poly1305 ID = 2
+)
+
+var (
+	// ErrUnknownAEAD 表示密文头部中的 AEAD ID 不对应任何已知构造
+	ErrUnknownAEAD = errors.New("unknown AEAD")
+
+	// ErrAEADMismatch 表示解密时指定的 AEAD 与密文头部记录的 AEAD 不一致
+	ErrAEADMismatch = errors.New("AEAD does not match ciphertext header")
+)
+
+// Factory 由对称密钥构造 cipher.AEAD
+type Factory interface {
+	// ID 返回写入密文头部的构造标识
+	ID() ID
+	// Name 返回构造名称，可用于密钥派生的域分隔标签
+	Name() string
+	// KeySize 返回 New 需要的密钥字节数
+	KeySize() int
+	// New 由长度为 KeySize 的密钥构造 AEAD 实例
+	New(key []byte) (cipher.AEAD, error)
+}
+
+var (
+	// AES256GCM 是 AES-256-GCM 的 Factory，也是混合加密的默认构造
+	AES256GCM Factory = aes256GCM{}
+
+	// ChaCha20Poly1305 是 ChaCha20-Poly1305 的 Factory
+	ChaCha20Poly1305 Factory = chaCha20Poly1305{}
+
+	// Default 是未指定 Factory 时使用的构造
+	Default = AES256GCM
+)
+
+// Lookup 返回 ID 对应的 Factory
+//
+// 参数:
+//   - id: 密文头部记录的 AEAD ID
+//
+// 返回值:
+//   - Factory: 对应的构造
+//   - error: ID 未知时返回包装 ErrUnknownAEAD 的错误
+func Lookup(id ID) (Factory, error) {
+	switch id {
+	case IDAES256GCM:
+		return AES256GCM, nil
+	case IDChaCha20Poly1305:
+		return ChaCha20Poly1305, nil
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrUnknownAEAD, byte(id))
+	}
+}
+
+type aes256GCM struct{}
+
+func (aes256GCM) ID() ID { return IDAES256GCM }
+
+func (aes256GCM) Name() string { return "aes-256-gcm" }
+
+func (aes256GCM) KeySize() int { return 32 }
+
+func (aes256GCM) New(key []byte) (cipher.AEAD, error) {
+	if len(key) != 32 {
+		return nil, fmt.Errorf("failed to create AES-256-GCM: key must be 32 bytes, got %d", len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create AES-256-GCM: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+type chaCha20Poly1305 struct{}
+
+func (chaCha20Poly1305) ID() ID { return IDChaCha20Poly1305 }
+
+func (chaCha20Poly1305) Name() string { return "chacha20-poly1305" }
+
+func (chaCha20Poly1305) KeySize() int { return chacha20poly1305.KeySize }
+
+func (chaCha20Poly1305) New(key []byte) (cipher.AEAD, error) {
+	aead, err := chacha20poly1305.New(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create ChaCha20-Poly1305: %w", err)
+	}
+	return aead, nil
+}
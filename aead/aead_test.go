@@ -0,0 +1,54 @@
+package aead
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"testing"
+)
+
+// TestFactoriesRoundTrip - 每种构造都能加解密，Lookup 按 ID 找回同一构造
+func TestFactoriesRoundTrip(t *testing.T) {
+	plaintext := []byte("hybrid DEM payload")
+	additionalData := []byte{0x01}
+
+	for _, factory := range []Factory{AES256GCM, ChaCha20Poly1305} {
+		t.Run(factory.Name(), func(t *testing.T) {
+			found, err := Lookup(factory.ID())
+			if err != nil || found != factory {
+				t.Fatalf("Lookup(%d) 应返回 %s: %v", factory.ID(), factory.Name(), err)
+			}
+
+			key := make([]byte, factory.KeySize())
+			if _, err := rand.Read(key); err != nil {
+				t.Fatal(err)
+			}
+			aead, err := factory.New(key)
+			if err != nil {
+				t.Fatal("创建 AEAD 失败:", err)
+			}
+			nonce := make([]byte, aead.NonceSize())
+			sealed := aead.Seal(nil, nonce, plaintext, additionalData)
+			opened, err := aead.Open(nil, nonce, sealed, additionalData)
+			if err != nil || !bytes.Equal(opened, plaintext) {
+				t.Fatal("解密失败:", err)
+			}
+			if _, err := aead.Open(nil, nonce, sealed, []byte{0x02}); err == nil {
+				t.Fatal("附加数据不同时不应解密成功")
+			}
+
+			if _, err := factory.New(key[:len(key)-1]); err == nil {
+				t.Fatal("密钥长度错误时应返回错误")
+			}
+		})
+	}
+}
+
+// TestLookupUnknown - 未知 ID 返回 ErrUnknownAEAD
+func TestLookupUnknown(t *testing.T) {
+	for _, id := range []ID{0, 3, 0xff} {
+		if _, err := Lookup(id); !errors.Is(err, ErrUnknownAEAD) {
+			t.Fatalf("Lookup(%d) 期望 ErrUnknownAEAD，实际为 %v", id, err)
+		}
+	}
+}
@@ -23,9 +23,11 @@
 package afp25_bibe
 
 import (
+	"errors"
 	"fmt"
 	"github.com/consensys/gnark-crypto/ecc/bn254"
 	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/mmsyan/GoPairingBasedCryptography/metrics"
 	"math/big"
 )
 
@@ -61,10 +63,58 @@ type Identity struct {
 // BatchLabel 表示批量操作的标签。
 // 标签用于将多个密文关联到同一个批量上下文,使得可以使用单个密钥解密。
 // 标签可以是时间戳、会话ID或其他上下文信息。
+//
+// 应使用 NewBatchLabel 构造标签：它拒绝空标签，并把标签确定性地哈希为域元素后存入 T，
+// 因此不同长度的标签也不会相互碰撞。Encrypt 与 ComputeKey 拒绝 T 为空的标签。
 type BatchLabel struct {
 	T []byte
 }
 
+// batchLabelDST 是 NewBatchLabel 哈希标签时使用的域分离标签
+var batchLabelDST = []byte("AFP25-BIBE-BatchLabel")
+
+// ErrEmptyBatchLabel 表示批标签为空。空标签在语义上与零摘要混淆，可能导致不同批次的密钥被混用
+var ErrEmptyBatchLabel = errors.New("batch label must not be empty")
+
+// ErrParamsMismatch 表示密文携带的主公钥指纹与解密时使用的主公钥不同，即密文是在另一组主密钥下加密的。
+// 不做该检查时，用另一组主密钥的解密密钥解密不会报错，只会得到与明文无关的消息
+var ErrParamsMismatch = errors.New("ciphertext was encrypted under different parameters")
+
+// ErrBatchTooLarge 表示身份列表的长度超过了 Setup 确定的批量大小 B，这样的批次不可能得到解密密钥
+var ErrBatchTooLarge = errors.New("batch exceeds the maximum batch size")
+
+// ErrIdentityNotInBatch 表示目标身份不在给定的身份列表中
+var ErrIdentityNotInBatch = errors.New("target identity not in batch")
+
+// NewBatchLabel 由任意非空字节串创建批标签，标签内容被哈希为域元素，T 为其 32 字节规范编码
+//
+// 参数:
+//   - data: 标签内容，例如时间戳或会话ID
+//
+// 返回值:
+//   - *BatchLabel: 批标签，相同的 data 总是得到相同的标签
+//   - error: data 为空时返回 ErrEmptyBatchLabel
+func NewBatchLabel(data []byte) (*BatchLabel, error) {
+	if len(data) == 0 {
+		return nil, ErrEmptyBatchLabel
+	}
+	// hash_to_field (RFC 9380) 对消息长度编码，"A" 与 "\x00A" 这类仅相差前导零的标签不会映射到同一元素
+	tag, err := fr.Hash(data, batchLabelDST, 1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash batch label: %w", err)
+	}
+	b := tag[0].Bytes()
+	return &BatchLabel{T: b[:]}, nil
+}
+
+// checkBatchLabel 拒绝 nil 或内容为空的批标签
+func checkBatchLabel(t *BatchLabel) error {
+	if t == nil || len(t.T) == 0 {
+		return ErrEmptyBatchLabel
+	}
+	return nil
+}
+
 // BatchDigest 表示一批身份的批量摘要。
 // 摘要是对身份集合的密码学承诺,用于生成批量解密密钥。
 // 摘要的计算涉及多项式运算,确保只有正确的身份集合才能解密。
@@ -75,7 +125,8 @@ type BatchDigest struct {
 // Message 表示待加密的明文消息。
 // 消息被表示为GT群(目标群)中的元素,这是配对运算的输出群。
 type Message struct {
-	M bn254.GT
+	M      bn254.GT
+	sealed []byte // SetBytes 以会话密钥加密的字节数据
 }
 
 // Ciphertext 表示加密后的密文。
@@ -84,6 +135,8 @@ type Message struct {
 type Ciphertext struct {
 	C1 [3]bn254.G2Affine
 	C2 bn254.GT
+	// PkFingerprint 是加密时所用主公钥的 Fingerprint，解密时据此拒绝在其他主公钥下生成的密文
+	PkFingerprint [32]byte
 }
 
 // SecretKey 表示用户的解密密钥(Secret Key)。
@@ -146,11 +199,11 @@ func Setup(B int) (*BatchIBEParams, error) {
 func KeyGen(params *BatchIBEParams) (*MasterPublicKey, *MasterSecretKey, error) {
 	msk, err := new(fr.Element).SetRandom()
 	if err != nil {
-		return nil, nil, fmt.Errorf("unable to generate master secret key: %s", err)
+		return nil, nil, fmt.Errorf("unable to generate master secret key: %w", err)
 	}
 	tau, err := new(fr.Element).SetRandom()
 	if err != nil {
-		return nil, nil, fmt.Errorf("unable to generate tau value: %s", err)
+		return nil, nil, fmt.Errorf("unable to generate tau value: %w", err)
 	}
 
 	// [τ]1, [τ^2]1, ..., [τ^B]1
@@ -189,7 +242,7 @@ func KeyGen(params *BatchIBEParams) (*MasterPublicKey, *MasterSecretKey, error)
 //
 // 返回值:
 //   - *Ciphertext: 生成的密文,包含C1和C2两部分
-//   - error: 如果配对计算失败则返回错误
+//   - error: 如果批标签为空或配对计算失败则返回错误
 //
 // 密文结构:
 //   - C1[0] = r₁·g2 + r₂·(g2^msk)
@@ -209,6 +262,9 @@ func KeyGen(params *BatchIBEParams) (*MasterPublicKey, *MasterSecretKey, error)
 //	    return fmt.Errorf("加密失败: %w", err)
 //	}
 func Encrypt(pk *MasterPublicKey, m *Message, id *Identity, t *BatchLabel) (*Ciphertext, error) {
+	if err := checkBatchLabel(t); err != nil {
+		return nil, fmt.Errorf("failed to encrypt: %w", err)
+	}
 	var a [2][3]bn254.G2Affine
 	_, _, _, g2 := bn254.Generators()
 	a[0][0] = g2 // [1]2
@@ -224,7 +280,7 @@ func Encrypt(pk *MasterPublicKey, m *Message, id *Identity, t *BatchLabel) (*Cip
 	var b [2]bn254.GT
 	b[0] = *new(bn254.GT).SetOne() // [0]T
 
-	eHtG2ExpMsk, err := bn254.Pair(
+	eHtG2ExpMsk, err := metrics.Pair(
 		[]bn254.G1Affine{h(t)},
 		[]bn254.G2Affine{pk.G2ExpMsk},
 	)
@@ -262,8 +318,9 @@ func Encrypt(pk *MasterPublicKey, m *Message, id *Identity, t *BatchLabel) (*Cip
 	c2.Mul(&c2, &m.M)
 
 	return &Ciphertext{
-		C1: c1,
-		C2: c2,
+		C1:            c1,
+		C2:            c2,
+		PkFingerprint: pk.Fingerprint(),
 	}, nil
 
 }
@@ -295,7 +352,7 @@ func Digest(pk *MasterPublicKey, identities []*Identity) (*BatchDigest, error) {
 		return nil, fmt.Errorf("identities is empty")
 	}
 	if len(identities) > len(pk.G1ExpTauPowers) {
-		return nil, fmt.Errorf("too many identities for batch size")
+		return nil, fmt.Errorf("too many identities for batch size: %w", ErrBatchTooLarge)
 	}
 	coef := computePolynomialCoeffs(identities)
 	d := computeG1PolynomialTau(pk.G1ExpTauPowers, coef)
@@ -316,7 +373,7 @@ func Digest(pk *MasterPublicKey, identities []*Identity) (*BatchDigest, error) {
 //
 // 返回值:
 //   - *SecretKey: 生成的解密密钥
-//   - error: 理论上不会失败,保留用于一致性
+//   - error: 批标签为空时返回包装 ErrEmptyBatchLabel 的错误
 //
 // 示例:
 //
@@ -325,6 +382,9 @@ func Digest(pk *MasterPublicKey, identities []*Identity) (*BatchDigest, error) {
 //	    return fmt.Errorf("密钥计算失败: %w", err)
 //	}
 func ComputeKey(msk *MasterSecretKey, d *BatchDigest, t *BatchLabel) (*SecretKey, error) {
+	if err := checkBatchLabel(t); err != nil {
+		return nil, fmt.Errorf("failed to compute key: %w", err)
+	}
 	ht := h(t)
 	dMulHt := new(bn254.G1Affine).Add(&d.D, &ht)
 	sk := *new(bn254.G1Affine).ScalarMultiplication(dMulHt, msk.Msk.BigInt(new(big.Int)))
@@ -349,11 +409,12 @@ func ComputeKey(msk *MasterSecretKey, d *BatchDigest, t *BatchLabel) (*SecretKey
 //   - identities: 完整的身份列表,包含解密者身份
 //   - id: 解密者的身份,必须在identities中
 //   - t: 批量标签,必须与加密时使用的标签一致
-//   - pk: 主公钥,用于计算商多项式
+//   - pk: 主公钥,用于计算商多项式,必须与加密时使用的主公钥一致
 //
 // 返回值:
 //   - *Message: 解密得到的明文消息
-//   - error: 如果身份不在列表中或配对计算失败则返回错误
+//   - error: 如果密文是在其他主公钥下加密的,返回 ErrParamsMismatch;身份列表超过批量大小时返回 ErrBatchTooLarge;
+//     身份不在列表中时返回 ErrIdentityNotInBatch;配对计算失败时返回错误
 //
 // 商多项式构造原理:
 //   - 完整多项式f(X) = (X-id₁)(X-id₂)...(X-id_n)在所有身份处为零
@@ -367,6 +428,37 @@ func ComputeKey(msk *MasterSecretKey, d *BatchDigest, t *BatchLabel) (*SecretKey
 //	    return fmt.Errorf("解密失败: %w", err)
 //	}
 func Decrypt(c *Ciphertext, sk *SecretKey, d *BatchDigest, identities []*Identity, id *Identity, t *BatchLabel, pk *MasterPublicKey) (*Message, error) {
+	// 1-3. 计算 c1 ∘ w 中与解密密钥无关的部分 e(c1[0], w[0]) * e(c1[1], w[1])
+	c1DotW, err := digestPairing(c, d, identities, id, pk)
+	if err != nil {
+		return nil, err
+	}
+	// 4. 乘上 e(c1[2], w[2])，w[2] = sk
+	pairing3, err := metrics.Pair([]bn254.G1Affine{sk.Sk}, []bn254.G2Affine{c.C1[2]})
+	if err != nil {
+		return nil, err
+	}
+	c1DotW.Mul(&c1DotW, &pairing3)
+
+	// 5. 计算 m = c2 / (c1 ∘ w)
+	var m bn254.GT
+	m.Div(&c.C2, &c1DotW)
+
+	return &Message{
+		M: m,
+	}, nil
+}
+
+// digestPairing 计算 c1 ∘ w 中只依赖批量摘要和身份列表的两项 e(D, C1[0]) · e(π, C1[1])，
+// 其中 π = g1^q(τ)，q(X) = f(X) / (X - id)。Decrypt 与 ThresholdDecrypt 共用该计算，
+// 计算前先确认密文是在 pk 下加密的
+func digestPairing(c *Ciphertext, d *BatchDigest, identities []*Identity, id *Identity, pk *MasterPublicKey) (bn254.GT, error) {
+	if c.PkFingerprint != pk.Fingerprint() {
+		return bn254.GT{}, fmt.Errorf("failed to decrypt: %w", ErrParamsMismatch)
+	}
+	if len(identities) > pk.BatchSize() {
+		return bn254.GT{}, fmt.Errorf("failed to decrypt: %w", ErrBatchTooLarge)
+	}
 	// 1. 构造商多项式 q(X) = f(X) / (X - id)
 	// q(X) 的根为 identities \ {id}
 	var rootsWithoutId []*Identity
@@ -377,42 +469,23 @@ func Decrypt(c *Ciphertext, sk *SecretKey, d *BatchDigest, identities []*Identit
 	}
 
 	if len(rootsWithoutId) != len(identities)-1 {
-		return nil, fmt.Errorf("identity not found in identity list")
+		return bn254.GT{}, fmt.Errorf("identity not found in identity list: %w", ErrIdentityNotInBatch)
 	}
 	qxCoef := computePolynomialCoeffs(rootsWithoutId)
 
 	// 2. 计算 π = g1^q(τ)
 	pi := computeG1PolynomialTau(pk.G1ExpTauPowers, qxCoef)
 
-	// 3. 构造向量 w = (d, π, sk) ∈ (G1)^3
-	w := [3]bn254.G1Affine{
-		d.D,
-		pi,
-		sk.Sk,
-	}
-
-	// 4. 计算 c1 ∘ w = e(c1[0], w[0]) * e(c1[1], w[1]) * e(c1[2], w[2])
-	pairing1, err := bn254.Pair([]bn254.G1Affine{w[0]}, []bn254.G2Affine{c.C1[0]})
+	// 3. 以 w = (d, π, sk) 的前两个分量计算 e(c1[0], d) * e(c1[1], π)
+	pairing1, err := metrics.Pair([]bn254.G1Affine{d.D}, []bn254.G2Affine{c.C1[0]})
 	if err != nil {
-		return nil, err
+		return bn254.GT{}, err
 	}
-	pairing2, err := bn254.Pair([]bn254.G1Affine{w[1]}, []bn254.G2Affine{c.C1[1]})
+	pairing2, err := metrics.Pair([]bn254.G1Affine{pi}, []bn254.G2Affine{c.C1[1]})
 	if err != nil {
-		return nil, err
-	}
-	pairing3, err := bn254.Pair([]bn254.G1Affine{w[2]}, []bn254.G2Affine{c.C1[2]})
-	if err != nil {
-		return nil, err
+		return bn254.GT{}, err
 	}
-	var c1DotW bn254.GT
-	c1DotW.Mul(&pairing1, &pairing2)
-	c1DotW.Mul(&c1DotW, &pairing3)
-
-	// 5. 计算 m = c2 / (c1 ∘ w)
-	var m bn254.GT
-	m.Div(&c.C2, &c1DotW)
-
-	return &Message{
-		M: m,
-	}, nil
+	var result bn254.GT
+	result.Mul(&pairing1, &pairing2)
+	return result, nil
 }
@@ -0,0 +1,80 @@
+package afp25_bibe
+
+import (
+	"fmt"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/mmsyan/GoPairingBasedCryptography/metrics"
+)
+
+// DecryptContext 保存同一解密方在同一批次中解密多份密文时可以共用的预计算结果。
+//
+// Decrypt 每次都要构造商多项式 q(X) = f(X) / (X - id)、计算 π = g1^q(τ)，并重新计算主公钥的 Fingerprint，
+// 这些只依赖 (pk, identities, id)，与密文无关。DecryptContext 把它们计算一次，
+// 之后每份密文的 c1 ∘ w 只需一次三项的多重配对，而不是三次单独的配对。
+type DecryptContext struct {
+	// w 是取负后的解密向量 (-D, -π, -sk)，使 ∏ e(w[i], C1[i]) = 1 / (c1 ∘ w)
+	w [3]bn254.G1Affine
+	// pkFingerprint 是主公钥的 Fingerprint，用于拒绝在其他主公钥下生成的密文
+	pkFingerprint [32]byte
+}
+
+// PrepareDecrypt 为身份 id 在批次中的多次解密做预计算。批量摘要由身份列表直接计算，不需要单独传入。
+//
+// 参数:
+//   - pk: 主公钥,必须与加密时使用的主公钥一致
+//   - sk: 解密密钥,由 ComputeKey 针对 identities 的摘要生成
+//   - identities: 完整的身份列表,包含解密者身份
+//   - id: 解密者的身份,必须在identities中
+//   - t: 批量标签,必须与加密时使用的标签一致
+//
+// 返回值:
+//   - *DecryptContext: 解密上下文,可交给 DecryptWith 反复使用
+//   - error: 批标签为空时返回包装 ErrEmptyBatchLabel 的错误;身份列表不合法或身份不在列表中时返回错误
+func PrepareDecrypt(pk *MasterPublicKey, sk *SecretKey, identities []*Identity, id *Identity, t *BatchLabel) (*DecryptContext, error) {
+	if err := checkBatchLabel(t); err != nil {
+		return nil, fmt.Errorf("failed to prepare decrypt: %w", err)
+	}
+	d, err := Digest(pk, identities)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare decrypt: %w", err)
+	}
+	var rootsWithoutId []*Identity
+	for _, identity := range identities {
+		if !identity.Id.Equal(&id.Id) {
+			rootsWithoutId = append(rootsWithoutId, identity)
+		}
+	}
+	if len(rootsWithoutId) != len(identities)-1 {
+		return nil, fmt.Errorf("identity not found in identity list: %w", ErrIdentityNotInBatch)
+	}
+	pi := computeG1PolynomialTau(pk.G1ExpTauPowers, computePolynomialCoeffs(rootsWithoutId))
+
+	ctx := &DecryptContext{pkFingerprint: pk.Fingerprint()}
+	ctx.w[0].Neg(&d.D)
+	ctx.w[1].Neg(&pi)
+	ctx.w[2].Neg(&sk.Sk)
+	return ctx, nil
+}
+
+// DecryptWith 使用 PrepareDecrypt 得到的上下文解密一份密文，结果与 Decrypt 相同:
+// m = c2 / (c1 ∘ w) = c2 · e(-D, c1[0]) · e(-π, c1[1]) · e(-sk, c1[2])。
+//
+// 参数:
+//   - ctx: 解密上下文
+//   - c: 发给上下文对应身份的密文
+//
+// 返回值:
+//   - *Message: 解密得到的明文消息
+//   - error: 如果密文是在其他主公钥下加密的,返回 ErrParamsMismatch;配对计算失败时返回错误
+func DecryptWith(ctx *DecryptContext, c *Ciphertext) (*Message, error) {
+	if c.PkFingerprint != ctx.pkFingerprint {
+		return nil, fmt.Errorf("failed to decrypt: %w", ErrParamsMismatch)
+	}
+	prod, err := metrics.Pair(ctx.w[:], c.C1[:])
+	if err != nil {
+		return nil, err
+	}
+	var m bn254.GT
+	m.Mul(&c.C2, &prod)
+	return &Message{M: m}, nil
+}
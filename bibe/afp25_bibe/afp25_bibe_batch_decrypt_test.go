@@ -0,0 +1,116 @@
+package afp25_bibe
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+)
+
+// TestDecryptWith 同一上下文解密多份密文，结果与 Decrypt 一致；其他主公钥下的密文被拒绝
+func TestDecryptWith(t *testing.T) {
+	params, err := Setup(8)
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+	mpk, msk, err := KeyGen(params)
+	if err != nil {
+		t.Fatalf("KeyGen failed: %v", err)
+	}
+	var identities []*Identity
+	for i := int64(1); i <= 4; i++ {
+		identities = append(identities, NewIdentity(big.NewInt(100*i)))
+	}
+	batchLabel := mustNewBatchLabel(t, []byte("batch-decrypt"))
+	digest, err := Digest(mpk, identities)
+	if err != nil {
+		t.Fatalf("Digest failed: %v", err)
+	}
+	sk, err := ComputeKey(msk, digest, batchLabel)
+	if err != nil {
+		t.Fatalf("ComputeKey failed: %v", err)
+	}
+
+	id := identities[1]
+	ctx, err := PrepareDecrypt(mpk, sk, identities, id, batchLabel)
+	if err != nil {
+		t.Fatalf("PrepareDecrypt failed: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		msg, err := NewRandomMessage()
+		if err != nil {
+			t.Fatalf("NewRandomMessage failed: %v", err)
+		}
+		ct, err := Encrypt(mpk, msg, id, batchLabel)
+		if err != nil {
+			t.Fatalf("Encrypt failed: %v", err)
+		}
+		got, err := DecryptWith(ctx, ct)
+		if err != nil {
+			t.Fatalf("DecryptWith failed: %v", err)
+		}
+		want, err := Decrypt(ct, sk, digest, identities, id, batchLabel, mpk)
+		if err != nil {
+			t.Fatalf("Decrypt failed: %v", err)
+		}
+		if !got.M.Equal(&msg.M) || !got.M.Equal(&want.M) {
+			t.Fatal("DecryptWith result differs from the original message or Decrypt")
+		}
+	}
+
+	if _, err := PrepareDecrypt(mpk, sk, identities, NewIdentity(big.NewInt(999)), batchLabel); err == nil {
+		t.Error("expected error for identity outside the batch")
+	}
+	if _, err := PrepareDecrypt(mpk, sk, identities, id, &BatchLabel{}); !errors.Is(err, ErrEmptyBatchLabel) {
+		t.Errorf("expected ErrEmptyBatchLabel, got %v", err)
+	}
+
+	otherMpk, _, err := KeyGen(params)
+	if err != nil {
+		t.Fatalf("KeyGen failed: %v", err)
+	}
+	msg, _ := NewRandomMessage()
+	ct, err := Encrypt(otherMpk, msg, id, batchLabel)
+	if err != nil {
+		t.Fatalf("Encrypt failed: %v", err)
+	}
+	if _, err := DecryptWith(ctx, ct); !errors.Is(err, ErrParamsMismatch) {
+		t.Errorf("expected ErrParamsMismatch, got %v", err)
+	}
+}
+
+// BenchmarkDecryptBatch 对比同一身份解密 50 份密文时反复调用 Decrypt 与 PrepareDecrypt + DecryptWith 的耗时
+func BenchmarkDecryptBatch(b *testing.B) {
+	const numCiphertexts = 50
+	params, _ := Setup(100)
+	mpk, msk, _ := KeyGen(params)
+	identities := make([]*Identity, 10)
+	for i := range identities {
+		identities[i] = NewIdentity(big.NewInt(int64(1000 + i)))
+	}
+	id := identities[0]
+	batchLabel := mustNewBatchLabel(b, []byte("benchmark-batch"))
+	digest, _ := Digest(mpk, identities)
+	sk, _ := ComputeKey(msk, digest, batchLabel)
+	cts := make([]*Ciphertext, numCiphertexts)
+	for i := range cts {
+		msg, _ := NewRandomMessage()
+		cts[i], _ = Encrypt(mpk, msg, id, batchLabel)
+	}
+
+	b.Run(fmt.Sprintf("Decrypt/n=%d", numCiphertexts), func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, ct := range cts {
+				_, _ = Decrypt(ct, sk, digest, identities, id, batchLabel, mpk)
+			}
+		}
+	})
+	b.Run(fmt.Sprintf("DecryptWith/n=%d", numCiphertexts), func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			ctx, _ := PrepareDecrypt(mpk, sk, identities, id, batchLabel)
+			for _, ct := range cts {
+				_, _ = DecryptWith(ctx, ct)
+			}
+		}
+	})
+}
@@ -0,0 +1,45 @@
+package afp25_bibe
+
+import "fmt"
+
+// BatchSize 返回主公钥支持的最大批量大小 B，即 Setup 时确定的 τ 幂次个数。
+// 加密方可以据此与聚合者协商批次划分：身份数超过 B 的批次无法计算摘要，也就无法得到解密密钥。
+func (pk *MasterPublicKey) BatchSize() int {
+	return len(pk.G1ExpTauPowers)
+}
+
+// EncryptToBatch 在已知接收方所属批次的情况下加密，加密前检查批次与批量大小 B 及接收方身份一致。
+//
+// Encrypt 只需要接收方身份和批量标签，无法发现接收方所在的批次超过了 B、因而永远拿不到解密密钥；
+// EncryptToBatch 把 Digest 与 Decrypt 对批次的要求提前到加密时检查，使三者对批量大小的约束一致。
+//
+// 参数:
+//   - pk: 主公钥
+//   - m: 待加密的明文消息
+//   - id: 接收者的身份
+//   - identities: 接收者所属批量的完整身份列表
+//   - t: 批量标签
+//
+// 返回值:
+//   - *Ciphertext: 发给 id 的密文,与 Encrypt 的结果格式相同
+//   - error: 列表为空时返回错误;长度超过 B 时返回 ErrBatchTooLarge;id 不在列表中时返回 ErrIdentityNotInBatch;
+//     其余错误与 Encrypt 相同
+func EncryptToBatch(pk *MasterPublicKey, m *Message, id *Identity, identities []*Identity, t *BatchLabel) (*Ciphertext, error) {
+	if len(identities) == 0 {
+		return nil, fmt.Errorf("failed to encrypt: identities is empty")
+	}
+	if len(identities) > pk.BatchSize() {
+		return nil, fmt.Errorf("failed to encrypt: %w", ErrBatchTooLarge)
+	}
+	found := false
+	for _, identity := range identities {
+		if identity.Id.Equal(&id.Id) {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("failed to encrypt: %w", ErrIdentityNotInBatch)
+	}
+	return Encrypt(pk, m, id, t)
+}
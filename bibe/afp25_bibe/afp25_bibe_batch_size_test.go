@@ -0,0 +1,71 @@
+package afp25_bibe
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+)
+
+// TestBatchSizeInvariant 恰好 B 个身份的批次可以正常加解密；B+1 个身份时 Digest、EncryptToBatch、Decrypt 与
+// PrepareDecrypt 都返回 ErrBatchTooLarge，不会出现某一步接受而另一步拒绝的情况
+func TestBatchSizeInvariant(t *testing.T) {
+	const batchSize = 4
+	params, err := Setup(batchSize)
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+	mpk, msk, err := KeyGen(params)
+	if err != nil {
+		t.Fatalf("KeyGen failed: %v", err)
+	}
+	if mpk.BatchSize() != batchSize {
+		t.Fatalf("BatchSize() = %d, want %d", mpk.BatchSize(), batchSize)
+	}
+	batchLabel := mustNewBatchLabel(t, []byte("batch-size"))
+	identities := make([]*Identity, batchSize+1)
+	for i := range identities {
+		identities[i] = NewIdentity(big.NewInt(int64(100 + i)))
+	}
+	full, oversized := identities[:batchSize], identities
+	id := identities[0]
+
+	digest, err := Digest(mpk, full)
+	if err != nil {
+		t.Fatalf("Digest failed: %v", err)
+	}
+	sk, err := ComputeKey(msk, digest, batchLabel)
+	if err != nil {
+		t.Fatalf("ComputeKey failed: %v", err)
+	}
+	msg, err := NewRandomMessage()
+	if err != nil {
+		t.Fatalf("NewRandomMessage failed: %v", err)
+	}
+	ct, err := EncryptToBatch(mpk, msg, id, full, batchLabel)
+	if err != nil {
+		t.Fatalf("EncryptToBatch failed: %v", err)
+	}
+	decrypted, err := Decrypt(ct, sk, digest, full, id, batchLabel, mpk)
+	if err != nil {
+		t.Fatalf("Decrypt failed: %v", err)
+	}
+	if !decrypted.M.Equal(&msg.M) {
+		t.Fatal("Decrypted message does not match original message")
+	}
+
+	if _, err := Digest(mpk, oversized); !errors.Is(err, ErrBatchTooLarge) {
+		t.Errorf("Digest: expected ErrBatchTooLarge, got %v", err)
+	}
+	if _, err := EncryptToBatch(mpk, msg, id, oversized, batchLabel); !errors.Is(err, ErrBatchTooLarge) {
+		t.Errorf("EncryptToBatch: expected ErrBatchTooLarge, got %v", err)
+	}
+	if _, err := Decrypt(ct, sk, digest, oversized, id, batchLabel, mpk); !errors.Is(err, ErrBatchTooLarge) {
+		t.Errorf("Decrypt: expected ErrBatchTooLarge, got %v", err)
+	}
+	if _, err := PrepareDecrypt(mpk, sk, oversized, id, batchLabel); !errors.Is(err, ErrBatchTooLarge) {
+		t.Errorf("PrepareDecrypt: expected ErrBatchTooLarge, got %v", err)
+	}
+	if _, err := EncryptToBatch(mpk, msg, identities[batchSize], full, batchLabel); !errors.Is(err, ErrIdentityNotInBatch) {
+		t.Errorf("EncryptToBatch: expected ErrIdentityNotInBatch, got %v", err)
+	}
+}
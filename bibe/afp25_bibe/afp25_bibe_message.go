@@ -0,0 +1,63 @@
+package afp25_bibe
+
+import (
+	"fmt"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/mmsyan/GoPairingBasedCryptography/metrics"
+	"github.com/mmsyan/GoPairingBasedCryptography/serialization"
+	"math/big"
+)
+
+// NewRandomMessage 生成一个在 GT 中均匀随机的明文消息。
+// 消息取 e(g1^r, g2)，其中 r 为随机标量；由于 e(g1, g2) 是 GT 的生成元，结果在 GT 中均匀分布。
+//
+// 注意:
+//   - 不能用 GT.SetOne() 再做指数运算来构造随机消息：1^r 恒等于 1，
+//     得到的永远是单位元。用单位元做明文时密文分量 M·K 就等于 K，
+//     加解密中的许多错误（例如密钥分量被错误地约掉）都会被掩盖。
+//
+// 返回值:
+//   - *Message: 随机消息
+//   - error: 随机数生成或配对运算失败时返回错误
+func NewRandomMessage() (*Message, error) {
+	r, err := new(fr.Element).SetRandom()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate random scalar: %w", err)
+	}
+	_, _, g1, g2 := bn254.Generators()
+	var g1ExpR bn254.G1Affine
+	g1ExpR.ScalarMultiplication(&g1, r.BigInt(new(big.Int)))
+	m, err := metrics.Pair([]bn254.G1Affine{g1ExpR}, []bn254.G2Affine{g2})
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute random message: %w", err)
+	}
+	return &Message{M: m}, nil
+}
+
+var _ serialization.Message = (*Message)(nil)
+
+// Bytes 用 serialization.OpenBytes 以明文中的会话密钥解出 SetBytes 加密的字节数据
+//
+// 返回值:
+//   - []byte: SetBytes 加密的字节数据
+//   - error: 消息不是由 SetBytes 设置（例如解密得到或随机选取的明文）时返回包装 serialization.ErrInvalidSealedBytes 的错误
+func (m *Message) Bytes() ([]byte, error) {
+	return serialization.OpenBytes(m.M, m.sealed)
+}
+
+// SetBytes 用 serialization.SealBytes 把明文设为随机会话密钥，并在消息中保存以它加密的字节数据
+//
+// 参数:
+//   - data: 待加密的字节数据
+//
+// 返回值:
+//   - error: 随机数生成失败时返回错误
+func (m *Message) SetBytes(data []byte) error {
+	k, sealed, err := serialization.SealBytes(data)
+	if err != nil {
+		return err
+	}
+	m.M, m.sealed = k, sealed
+	return nil
+}
@@ -0,0 +1,12 @@
+package afp25_bibe
+
+import (
+	"github.com/mmsyan/GoPairingBasedCryptography/internal/msgtest"
+	"github.com/mmsyan/GoPairingBasedCryptography/serialization"
+	"testing"
+)
+
+// TestMessageBytes 检查 Message 的 Bytes/SetBytes 往返
+func TestMessageBytes(t *testing.T) {
+	msgtest.CheckGTRoundTrip(t, func() serialization.Message { return new(Message) })
+}
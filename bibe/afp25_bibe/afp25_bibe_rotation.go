@@ -0,0 +1,113 @@
+package afp25_bibe
+
+import (
+	"errors"
+	"fmt"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/mmsyan/GoPairingBasedCryptography/serialization"
+	"math/big"
+)
+
+// ErrInvalidRotationToken 表示轮换令牌没有把给定的两把主公钥关联起来
+var ErrInvalidRotationToken = errors.New("invalid rotation token")
+
+// RotationToken 记录一次主密钥轮换，把轮换前后的主公钥关联起来。
+// 令牌只包含两把主公钥的指纹，不含任何秘密，可以与新主公钥一起公开发布。
+type RotationToken struct {
+	From [32]byte // 轮换前主公钥的指纹
+	To   [32]byte // 轮换后主公钥的指纹
+}
+
+// RotateMasterKey 轮换主密钥，生成新的主密钥对 (mpk', msk') 和轮换令牌。
+//
+// 批量摘要 D = g1^f(τ) 是对身份多项式的承诺，只依赖陷门 τ 的幂次，与 msk 无关；
+// 真正决定解密能力的是 msk（sk = msk · (D + h(t))）。因此轮换时保留 τ 的幂次与 [τ]2，
+// 只重新随机选取 msk' 并更新 [msk']2：
+//  1. 随机选择新的主密钥 msk'
+//  2. 复制 mpk 中的 [τ^i]1 与 [τ]2，计算 [msk']2
+//  3. 令牌记录 mpk 与 mpk' 的指纹
+//
+// 轮换后旧的解密密钥对新主公钥下的密文失效，已发布的摘要经 RotateDigest 核对令牌后可继续使用，无需重新计算。
+// 注意 τ 并未轮换，若 τ 泄露必须重新执行 KeyGen 并为所有批次重新计算摘要。
+//
+// 参数:
+//   - params: 系统参数,包含批量大小B
+//   - mpk: 轮换前的主公钥
+//
+// 返回值:
+//   - *MasterPublicKey: 轮换后的主公钥
+//   - *MasterSecretKey: 轮换后的主密钥
+//   - *RotationToken: 关联新旧主公钥的轮换令牌
+//   - error: 如果主公钥与批量大小不一致或随机数生成失败则返回错误
+func RotateMasterKey(params *BatchIBEParams, mpk *MasterPublicKey) (*MasterPublicKey, *MasterSecretKey, *RotationToken, error) {
+	if len(mpk.G1ExpTauPowers) != params.B {
+		return nil, nil, nil, fmt.Errorf("unable to rotate master key: public key has %d tau powers, want %d", len(mpk.G1ExpTauPowers), params.B)
+	}
+	msk, err := new(fr.Element).SetRandom()
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("unable to rotate master secret key: %w", err)
+	}
+
+	g1ExpTauPower := make([]bn254.G1Affine, len(mpk.G1ExpTauPowers))
+	copy(g1ExpTauPower, mpk.G1ExpTauPowers)
+	rotated := &MasterPublicKey{
+		G1ExpTauPowers: g1ExpTauPower,
+		G2ExpTau:       mpk.G2ExpTau,
+		G2ExpMsk:       *new(bn254.G2Affine).ScalarMultiplicationBase(msk.BigInt(new(big.Int))), // [msk']2
+	}
+	return rotated, &MasterSecretKey{
+		Msk: *msk,
+	}, &RotationToken{
+		From: mpk.Fingerprint(),
+		To:   rotated.Fingerprint(),
+	}, nil
+}
+
+// RotateDigest 把轮换前发布的批量摘要关联到轮换后的主公钥。
+//
+// 摘要 D = g1^f(τ) 只依赖 τ 的幂次，本身不记录它是在哪把主公钥下计算的，因此摘要的群元素保持不变；
+// 该函数检查的是"摘要可以沿用"这一结论：令牌的 From、To 必须分别是 from、to 的指纹，
+// 且 to 与 from 的 [τ^i]1、[τ]2 完全相同。任意令牌、或来自另一次轮换的令牌都会被拒绝。
+// 调用方须确认 digest 是在 from 下计算的（例如用 Digest(from, identities) 重新核对），函数无法从 D 本身看出这一点。
+//
+// 参数:
+//   - digest: 在 from 下计算的批量摘要
+//   - token: RotateMasterKey 返回的轮换令牌
+//   - from: 轮换前的主公钥
+//   - to: 轮换后的主公钥
+//
+// 返回值:
+//   - *BatchDigest: 可在 to 下用于 ComputeKey 和 Decrypt 的摘要副本
+//   - error: 令牌与 from、to 不对应或两把主公钥的 τ 幂次不同时返回包装 ErrInvalidRotationToken 的错误；
+//     摘要不是合法的 G1 元素时返回包装 serialization.ErrInvalidG1Point 的错误
+func RotateDigest(digest *BatchDigest, token *RotationToken, from, to *MasterPublicKey) (*BatchDigest, error) {
+	if token == nil || token.From == token.To {
+		return nil, fmt.Errorf("unable to rotate digest: %w", ErrInvalidRotationToken)
+	}
+	if from.Fingerprint() != token.From || to.Fingerprint() != token.To {
+		return nil, fmt.Errorf("unable to rotate digest: token does not link these master public keys: %w", ErrInvalidRotationToken)
+	}
+	if !sameTauPowers(from, to) {
+		return nil, fmt.Errorf("unable to rotate digest: master public keys use different tau powers: %w", ErrInvalidRotationToken)
+	}
+	if !digest.D.IsInSubGroup() {
+		return nil, fmt.Errorf("unable to rotate digest: %w", serialization.ErrInvalidG1Point)
+	}
+	return &BatchDigest{
+		D: digest.D,
+	}, nil
+}
+
+// sameTauPowers 判断两把主公钥的 [τ^i]1 与 [τ]2 是否相同，即在其中一把下计算的摘要对另一把同样有效
+func sameTauPowers(a, b *MasterPublicKey) bool {
+	if len(a.G1ExpTauPowers) != len(b.G1ExpTauPowers) || !a.G2ExpTau.Equal(&b.G2ExpTau) {
+		return false
+	}
+	for i := range a.G1ExpTauPowers {
+		if !a.G1ExpTauPowers[i].Equal(&b.G1ExpTauPowers[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,139 @@
+package afp25_bibe
+
+import (
+	"errors"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/mmsyan/GoPairingBasedCryptography/serialization"
+	"math/big"
+	"testing"
+)
+
+// TestRotateMasterKey 轮换后用 mpk' 加密的密文可由 msk' 在轮换后的摘要上计算的密钥解密，旧密钥失效
+func TestRotateMasterKey(t *testing.T) {
+	params, err := Setup(4)
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+	mpk, msk, err := KeyGen(params)
+	if err != nil {
+		t.Fatalf("KeyGen failed: %v", err)
+	}
+	identities := []*Identity{NewIdentity(big.NewInt(1)), NewIdentity(big.NewInt(2)), NewIdentity(big.NewInt(3))}
+	batchLabel := mustNewBatchLabel(t, []byte("batch-rotation"))
+	digest, err := Digest(mpk, identities)
+	if err != nil {
+		t.Fatalf("Digest failed: %v", err)
+	}
+	oldKey, err := ComputeKey(msk, digest, batchLabel)
+	if err != nil {
+		t.Fatalf("ComputeKey failed: %v", err)
+	}
+
+	newMpk, newMsk, token, err := RotateMasterKey(params, mpk)
+	if err != nil {
+		t.Fatalf("RotateMasterKey failed: %v", err)
+	}
+	if token.From != mpk.Fingerprint() || token.To != newMpk.Fingerprint() {
+		t.Fatal("rotation token should link the old and new master public keys")
+	}
+	rotatedDigest, err := RotateDigest(digest, token, mpk, newMpk)
+	if err != nil {
+		t.Fatalf("RotateDigest failed: %v", err)
+	}
+	newKey, err := ComputeKey(newMsk, rotatedDigest, batchLabel)
+	if err != nil {
+		t.Fatalf("ComputeKey failed: %v", err)
+	}
+
+	m, _ := new(bn254.GT).SetRandom()
+	ct, err := Encrypt(newMpk, NewMessage(*m), identities[1], batchLabel)
+	if err != nil {
+		t.Fatalf("Encrypt failed: %v", err)
+	}
+	decrypted, err := Decrypt(ct, newKey, rotatedDigest, identities, identities[1], batchLabel, newMpk)
+	if err != nil {
+		t.Fatalf("Decrypt failed: %v", err)
+	}
+	if !decrypted.M.Equal(m) {
+		t.Fatal("decrypted message does not match after rotation")
+	}
+
+	stale, err := Decrypt(ct, oldKey, rotatedDigest, identities, identities[1], batchLabel, newMpk)
+	if err != nil {
+		t.Fatalf("Decrypt failed: %v", err)
+	}
+	if stale.M.Equal(m) {
+		t.Fatal("key derived from the old msk should not decrypt ciphertexts under the rotated key")
+	}
+
+	if _, err := RotateDigest(digest, &RotationToken{}, mpk, newMpk); !errors.Is(err, ErrInvalidRotationToken) {
+		t.Fatalf("RotateDigest should reject an empty token: %v", err)
+	}
+}
+
+// TestRotateDigestRejectsUnrelatedToken 来自另一次轮换的令牌、以及与主公钥顺序不符的调用都被拒绝
+func TestRotateDigestRejectsUnrelatedToken(t *testing.T) {
+	params, err := Setup(4)
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+	mpk, _, err := KeyGen(params)
+	if err != nil {
+		t.Fatalf("KeyGen failed: %v", err)
+	}
+	digest, err := Digest(mpk, []*Identity{NewIdentity(big.NewInt(1)), NewIdentity(big.NewInt(2))})
+	if err != nil {
+		t.Fatalf("Digest failed: %v", err)
+	}
+	newMpk, _, token, err := RotateMasterKey(params, mpk)
+	if err != nil {
+		t.Fatalf("RotateMasterKey failed: %v", err)
+	}
+
+	// 另一套系统的一次轮换
+	otherMpk, _, err := KeyGen(params)
+	if err != nil {
+		t.Fatalf("KeyGen failed: %v", err)
+	}
+	_, _, otherToken, err := RotateMasterKey(params, otherMpk)
+	if err != nil {
+		t.Fatalf("RotateMasterKey failed: %v", err)
+	}
+
+	cases := map[string]struct {
+		token    *RotationToken
+		from, to *MasterPublicKey
+	}{
+		"无关令牌":    {otherToken, mpk, newMpk},
+		"交换新旧主公钥": {token, newMpk, mpk},
+		"伪造令牌":    {&RotationToken{From: mpk.Fingerprint(), To: otherMpk.Fingerprint()}, mpk, otherMpk},
+	}
+	for name, c := range cases {
+		if _, err := RotateDigest(digest, c.token, c.from, c.to); !errors.Is(err, ErrInvalidRotationToken) {
+			t.Errorf("%s: 期望 ErrInvalidRotationToken, 实际为 %v", name, err)
+		}
+	}
+}
+
+// TestRotateDigestRejectsInvalidDigest 不在曲线上的摘要被拒绝，并包装 serialization.ErrInvalidG1Point
+func TestRotateDigestRejectsInvalidDigest(t *testing.T) {
+	params, err := Setup(4)
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+	mpk, _, err := KeyGen(params)
+	if err != nil {
+		t.Fatalf("KeyGen failed: %v", err)
+	}
+	newMpk, _, token, err := RotateMasterKey(params, mpk)
+	if err != nil {
+		t.Fatalf("RotateMasterKey failed: %v", err)
+	}
+
+	var digest BatchDigest
+	digest.D.X.SetOne()
+	digest.D.Y.SetOne()
+	if _, err := RotateDigest(&digest, token, mpk, newMpk); !errors.Is(err, serialization.ErrInvalidG1Point) {
+		t.Errorf("期望 ErrInvalidG1Point, 实际为 %v", err)
+	}
+}
@@ -0,0 +1,65 @@
+package afp25_bibe
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"github.com/mmsyan/GoPairingBasedCryptography/serialization"
+)
+
+// MarshalBinary 将主公钥序列化为规范字节串（压缩点编码）。
+// 布局: 头部 || G1ExpTauPowers (长度前缀 + G1...) || G2ExpTau || G2ExpMsk。
+//
+// 返回值:
+//   - []byte: 序列化后的主公钥
+//   - error: 序列化失败时返回错误
+func (pk *MasterPublicKey) MarshalBinary() ([]byte, error) {
+	data := serialization.AppendHeader(nil, serialization.SchemeAFP25BIBE)
+	data = append(data, serialization.EncodeG1Slice(pk.G1ExpTauPowers)...)
+	data = append(data, serialization.EncodeG2(pk.G2ExpTau, serialization.Compressed)...)
+	data = append(data, serialization.EncodeG2(pk.G2ExpMsk, serialization.Compressed)...)
+	return data, nil
+}
+
+// UnmarshalBinary 从 MarshalBinary 的输出恢复主公钥。
+//
+// 参数:
+//   - data: 序列化后的主公钥
+//
+// 返回值:
+//   - error: 数据格式不正确时返回错误
+func (pk *MasterPublicKey) UnmarshalBinary(data []byte) error {
+	data, err := serialization.CheckHeader(data, serialization.SchemeAFP25BIBE)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal master public key: %w", err)
+	}
+	tauPowers, n, err := serialization.DecodeG1Slice(data)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal master public key: %w", err)
+	}
+	data = data[n:]
+	g2ExpTau, n, err := serialization.DecodeValidG2(data)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal master public key: %w", err)
+	}
+	data = data[n:]
+	g2ExpMsk, n, err := serialization.DecodeValidG2(data)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal master public key: %w", err)
+	}
+	if len(data) != n {
+		return errors.New("failed to unmarshal master public key: trailing bytes")
+	}
+	pk.G1ExpTauPowers, pk.G2ExpTau, pk.G2ExpMsk = tauPowers, g2ExpTau, g2ExpMsk
+	return nil
+}
+
+// Fingerprint 返回主公钥规范序列化的 SHA-256 摘要。
+// 参与批量加密的各方可以先比较指纹，确认使用的是同一套公开参数，再执行开销较大的运算。
+//
+// 返回值:
+//   - [32]byte: 主公钥指纹
+func (pk *MasterPublicKey) Fingerprint() [32]byte {
+	data, _ := pk.MarshalBinary()
+	return sha256.Sum256(data)
+}
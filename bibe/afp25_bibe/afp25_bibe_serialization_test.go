@@ -0,0 +1,70 @@
+package afp25_bibe
+
+import (
+	"bytes"
+	"github.com/mmsyan/GoPairingBasedCryptography/internal/invalidpoints"
+	"testing"
+)
+
+// TestMasterPublicKeyFingerprint 独立生成的两套主公钥指纹不同，序列化后重新加载的主公钥指纹不变
+func TestMasterPublicKeyFingerprint(t *testing.T) {
+	params, err := Setup(8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pk1, _, err := KeyGen(params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pk2, _, err := KeyGen(params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pk1.Fingerprint() == pk2.Fingerprint() {
+		t.Fatal("independently generated master public keys should have different fingerprints")
+	}
+
+	data, err := pk1.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	reloaded := new(MasterPublicKey)
+	if err := reloaded.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if reloaded.Fingerprint() != pk1.Fingerprint() {
+		t.Fatal("reloaded master public key should have the same fingerprint")
+	}
+
+	if err := reloaded.UnmarshalBinary(data[:len(data)-1]); err == nil {
+		t.Fatal("truncated data should fail to unmarshal")
+	}
+}
+
+// TestMasterPublicKeyRejectInvalidG2 把序列化结果中的 g2^τ 替换为无穷远点、不在曲线上或不在子群中的编码后，UnmarshalBinary 必须拒绝
+func TestMasterPublicKeyRejectInvalidG2(t *testing.T) {
+	params, err := Setup(8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pk, _, err := KeyGen(params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := pk.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	point := pk.G2ExpTau.Bytes()
+	offset := bytes.Index(data, point[:])
+	if offset < 0 {
+		t.Fatal("序列化结果中找不到目标 G2 点")
+	}
+	for name, invalid := range invalidpoints.G2Encodings() {
+		tampered := bytes.Clone(data)
+		copy(tampered[offset:], invalid)
+		if err := new(MasterPublicKey).UnmarshalBinary(tampered); err == nil {
+			t.Fatalf("%s: 不合法的 G2 点应被拒绝", name)
+		}
+	}
+}
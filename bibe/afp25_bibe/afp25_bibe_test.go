@@ -1,6 +1,8 @@
 package afp25_bibe
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 	"github.com/consensys/gnark-crypto/ecc/bn254"
 	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
@@ -15,9 +17,14 @@ func NewIdentity(id *big.Int) *Identity {
 	return &Identity{Id: idElem}
 }
 
-// NewBatchLabel - 创建批标签
-func NewBatchLabel(data []byte) *BatchLabel {
-	return &BatchLabel{T: data}
+// mustNewBatchLabel - 创建批标签，失败时终止测试
+func mustNewBatchLabel(tb testing.TB, data []byte) *BatchLabel {
+	tb.Helper()
+	label, err := NewBatchLabel(data)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return label
 }
 
 // NewMessage - 从 GT 元素创建消息
@@ -25,28 +32,6 @@ func NewMessage(m bn254.GT) *Message {
 	return &Message{M: m}
 }
 
-// RandomMessage - 生成随机消息 (用于测试)
-func RandomMessage() (*Message, error) {
-	// 生成一个随机的 GT 元素
-	// GT 元素通常通过配对生成
-	_, _, g1Gen, g2Gen := bn254.Generators()
-
-	r, err := new(fr.Element).SetRandom()
-	if err != nil {
-		return nil, err
-	}
-
-	var g1Rand bn254.G1Affine
-	g1Rand.ScalarMultiplication(&g1Gen, r.BigInt(new(big.Int)))
-
-	gt, err := bn254.Pair([]bn254.G1Affine{g1Rand}, []bn254.G2Affine{g2Gen})
-	if err != nil {
-		return nil, err
-	}
-
-	return &Message{M: gt}, nil
-}
-
 // TestBasicEncryptionDecryption 测试基本的加密解密流程
 func TestBasicEncryptionDecryption(t *testing.T) {
 	// 1. Setup
@@ -69,7 +54,7 @@ func TestBasicEncryptionDecryption(t *testing.T) {
 	id4 := NewIdentity(big.NewInt(400))
 	identities := []*Identity{id1, id2, id3, id4}
 
-	batchLabel := NewBatchLabel([]byte("batch-2025-01-12"))
+	batchLabel := mustNewBatchLabel(t, []byte("batch-2025-01-12"))
 
 	// 4. 生成批摘要
 	digest, err := Digest(mpk, identities)
@@ -84,9 +69,9 @@ func TestBasicEncryptionDecryption(t *testing.T) {
 	}
 
 	// 6. 加密消息给 id4
-	msg, err := RandomMessage()
+	msg, err := NewRandomMessage()
 	if err != nil {
-		t.Fatalf("RandomMessage failed: %v", err)
+		t.Fatalf("NewRandomMessage failed: %v", err)
 	}
 
 	ct, err := Encrypt(mpk, msg, id4, batchLabel)
@@ -121,7 +106,7 @@ func TestSingleIdentity1(t *testing.T) {
 
 	id1 := NewIdentity(big.NewInt(100))
 	identities := []*Identity{id1}
-	batchLabel := NewBatchLabel([]byte("batch-2026-01-13"))
+	batchLabel := mustNewBatchLabel(t, []byte("batch-2026-01-13"))
 
 	digest, err := Digest(mpk, identities)
 	if err != nil {
@@ -133,9 +118,9 @@ func TestSingleIdentity1(t *testing.T) {
 		t.Fatalf("ComputeKey failed: %v", err)
 	}
 
-	msg, err := RandomMessage()
+	msg, err := NewRandomMessage()
 	if err != nil {
-		t.Fatalf("RandomMessage failed: %v", err)
+		t.Fatalf("NewRandomMessage failed: %v", err)
 	}
 
 	ct, err := Encrypt(mpk, msg, id1, batchLabel)
@@ -169,7 +154,7 @@ func TestSingleIdentity2(t *testing.T) {
 	id1 := NewIdentity(big.NewInt(100))
 	id2 := NewIdentity(big.NewInt(200))
 	identities := []*Identity{id1}
-	batchLabel := NewBatchLabel([]byte("batch-2026-01-13"))
+	batchLabel := mustNewBatchLabel(t, []byte("batch-2026-01-13"))
 
 	digest, err := Digest(mpk, identities)
 	if err != nil {
@@ -181,9 +166,9 @@ func TestSingleIdentity2(t *testing.T) {
 		t.Fatalf("ComputeKey failed: %v", err)
 	}
 
-	msg, err := RandomMessage()
+	msg, err := NewRandomMessage()
 	if err != nil {
-		t.Fatalf("RandomMessage failed: %v", err)
+		t.Fatalf("NewRandomMessage failed: %v", err)
 	}
 
 	ct, err := Encrypt(mpk, msg, id1, batchLabel)
@@ -220,7 +205,7 @@ func TestMultiIdentities1(t *testing.T) {
 	id5 := NewIdentity(big.NewInt(500))
 	id6 := NewIdentity(big.NewInt(600))
 	identities := []*Identity{id1, id2, id3, id4, id5, id6}
-	batchLabel := NewBatchLabel([]byte("batch-2026-01-13"))
+	batchLabel := mustNewBatchLabel(t, []byte("batch-2026-01-13"))
 
 	digest, err := Digest(mpk, identities)
 	if err != nil {
@@ -232,9 +217,9 @@ func TestMultiIdentities1(t *testing.T) {
 		t.Fatalf("ComputeKey failed: %v", err)
 	}
 
-	msg, err := RandomMessage()
+	msg, err := NewRandomMessage()
 	if err != nil {
-		t.Fatalf("RandomMessage failed: %v", err)
+		t.Fatalf("NewRandomMessage failed: %v", err)
 	}
 
 	ct, err := Encrypt(mpk, msg, id6, batchLabel)
@@ -263,7 +248,7 @@ func TestMultipleRecipientsInBatch(t *testing.T) {
 		identities[i] = NewIdentity(big.NewInt(int64(1000 + i*100)))
 	}
 
-	batchLabel := NewBatchLabel([]byte("multi-recipient-batch"))
+	batchLabel := mustNewBatchLabel(t, []byte("multi-recipient-batch"))
 	digest, _ := Digest(mpk, identities)
 	sk, _ := ComputeKey(msk, digest, batchLabel)
 
@@ -272,7 +257,7 @@ func TestMultipleRecipientsInBatch(t *testing.T) {
 	ciphertexts := make([]*Ciphertext, 5)
 
 	for i := 0; i < 5; i++ {
-		msg, _ := RandomMessage()
+		msg, _ := NewRandomMessage()
 		messages[i] = msg
 		ct, err := Encrypt(mpk, msg, identities[i], batchLabel)
 		if err != nil {
@@ -303,17 +288,20 @@ func TestDifferentBatchLabels(t *testing.T) {
 	identities := []*Identity{id}
 
 	// 两个不同的批标签
-	label1 := NewBatchLabel([]byte("batch-morning"))
-	label2 := NewBatchLabel([]byte("batch-evening"))
+	label1 := mustNewBatchLabel(t, []byte("batch-morning"))
+	label2 := mustNewBatchLabel(t, []byte("batch-evening"))
 
 	digest, _ := Digest(mpk, identities)
 
-	// 使用 label1 和 label2 生成密钥
+	// 使用 label1 和 label2 生成密钥，不同标签的密钥必须不同
 	sk1, _ := ComputeKey(msk, digest, label1)
 	sk2, _ := ComputeKey(msk, digest, label2)
+	if sk1.Sk.Equal(&sk2.Sk) {
+		t.Fatal("different batch labels should yield different keys")
+	}
 
 	// 使用 label1 加密
-	msg, _ := RandomMessage()
+	msg, _ := NewRandomMessage()
 	ct1, _ := Encrypt(mpk, msg, id, label1)
 
 	// 使用 label1 的密钥和 label1 应该能解密
@@ -335,6 +323,54 @@ func TestDifferentBatchLabels(t *testing.T) {
 	}
 }
 
+// TestNewBatchLabel 测试空标签被拒绝，以及仅相差前导零的标签得到不同的标签与密钥
+func TestNewBatchLabel(t *testing.T) {
+	for _, data := range [][]byte{nil, {}} {
+		if _, err := NewBatchLabel(data); !errors.Is(err, ErrEmptyBatchLabel) {
+			t.Fatalf("NewBatchLabel(%q): expected ErrEmptyBatchLabel, got %v", data, err)
+		}
+	}
+
+	label := mustNewBatchLabel(t, []byte("A"))
+	if again := mustNewBatchLabel(t, []byte("A")); !bytes.Equal(label.T, again.T) {
+		t.Fatal("NewBatchLabel should be deterministic")
+	}
+	padded := mustNewBatchLabel(t, []byte("\x00A"))
+	if bytes.Equal(label.T, padded.T) {
+		t.Fatal("labels of different lengths should not collide")
+	}
+
+	params, _ := Setup(10)
+	mpk, msk, _ := KeyGen(params)
+	digest, err := Digest(mpk, []*Identity{NewIdentity(big.NewInt(500))})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sk1, err := ComputeKey(msk, digest, label)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sk2, err := ComputeKey(msk, digest, padded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sk1.Sk.Equal(&sk2.Sk) {
+		t.Fatal("distinct labels should yield distinct keys")
+	}
+
+	// 直接构造的空标签同样被拒绝
+	msg, _ := NewRandomMessage()
+	if _, err := Encrypt(mpk, msg, NewIdentity(big.NewInt(500)), &BatchLabel{}); !errors.Is(err, ErrEmptyBatchLabel) {
+		t.Fatalf("Encrypt: expected ErrEmptyBatchLabel, got %v", err)
+	}
+	if _, err := ComputeKey(msk, digest, nil); !errors.Is(err, ErrEmptyBatchLabel) {
+		t.Fatalf("ComputeKey: expected ErrEmptyBatchLabel, got %v", err)
+	}
+	if _, err := ComputeKeyShares(msk, digest, &BatchLabel{}, 2, 3); !errors.Is(err, ErrEmptyBatchLabel) {
+		t.Fatalf("ComputeKeyShares: expected ErrEmptyBatchLabel, got %v", err)
+	}
+}
+
 // TestIdentityNotInBatch 测试不在批量中的身份无法解密
 func TestIdentityNotInBatch(t *testing.T) {
 	params, _ := Setup(10)
@@ -345,13 +381,13 @@ func TestIdentityNotInBatch(t *testing.T) {
 	id3 := NewIdentity(big.NewInt(300)) // 不在批量中
 
 	identities := []*Identity{id1, id2} // 只包含 id1 和 id2
-	batchLabel := NewBatchLabel([]byte("exclusive-batch"))
+	batchLabel := mustNewBatchLabel(t, []byte("exclusive-batch"))
 
 	digest, _ := Digest(mpk, identities)
 	sk, _ := ComputeKey(msk, digest, batchLabel)
 
 	// 加密给 id1
-	msg, _ := RandomMessage()
+	msg, _ := NewRandomMessage()
 	ct, _ := Encrypt(mpk, msg, id1, batchLabel)
 
 	// id1 可以解密
@@ -381,7 +417,7 @@ func TestLargeBatchSize(t *testing.T) {
 		identities[i] = NewIdentity(big.NewInt(int64(10000 + i*10)))
 	}
 
-	batchLabel := NewBatchLabel([]byte("large-batch"))
+	batchLabel := mustNewBatchLabel(t, []byte("large-batch"))
 	digest, err := Digest(mpk, identities)
 	if err != nil {
 		t.Fatalf("Digest for large batch failed: %v", err)
@@ -392,7 +428,7 @@ func TestLargeBatchSize(t *testing.T) {
 	// 随机选择几个身份进行测试
 	testIndices := []int{0, 10, 25, 40, 49}
 	for _, idx := range testIndices {
-		msg, _ := RandomMessage()
+		msg, _ := NewRandomMessage()
 		ct, _ := Encrypt(mpk, msg, identities[idx], batchLabel)
 
 		decrypted, err := Decrypt(ct, sk, digest, identities, identities[idx], batchLabel, mpk)
@@ -425,7 +461,7 @@ func TestSingleIdentityBatch(t *testing.T) {
 	id3 := NewIdentity(big.NewInt(300))
 	identities := []*Identity{id1, id2, id3}
 
-	batchLabel := NewBatchLabel([]byte("single-id-batch"))
+	batchLabel := mustNewBatchLabel(t, []byte("single-id-batch"))
 
 	digest, err := Digest(mpk, identities)
 	if err != nil {
@@ -437,9 +473,9 @@ func TestSingleIdentityBatch(t *testing.T) {
 		t.Fatalf("ComputeKey for single identity failed: %v", err)
 	}
 
-	msg, err := RandomMessage()
+	msg, err := NewRandomMessage()
 	if err != nil {
-		t.Fatalf("RandomMessage failed: %v", err)
+		t.Fatalf("NewRandomMessage failed: %v", err)
 	}
 	ct, err := Encrypt(mpk, msg, id1, batchLabel)
 	if err != nil {
@@ -514,19 +550,19 @@ func TestMultipleBatchesWithSameIdentities(t *testing.T) {
 	identities := []*Identity{id1, id2}
 
 	// 批次1
-	label1 := NewBatchLabel([]byte("batch-1"))
+	label1 := mustNewBatchLabel(t, []byte("batch-1"))
 	digest1, _ := Digest(mpk, identities)
 	sk1, _ := ComputeKey(msk, digest1, label1)
 
-	msg1, _ := RandomMessage()
+	msg1, _ := NewRandomMessage()
 	ct1, _ := Encrypt(mpk, msg1, id1, label1)
 
 	// 批次2（相同身份，不同标签）
-	label2 := NewBatchLabel([]byte("batch-2"))
+	label2 := mustNewBatchLabel(t, []byte("batch-2"))
 	digest2, _ := Digest(mpk, identities)
 	sk2, _ := ComputeKey(msk, digest2, label2)
 
-	msg2, _ := RandomMessage()
+	msg2, _ := NewRandomMessage()
 	ct2, _ := Encrypt(mpk, msg2, id1, label2)
 
 	// 批次1的密钥应该只能解密批次1的密文
@@ -564,7 +600,7 @@ func TestSequentialEncryption(t *testing.T) {
 
 	id := NewIdentity(big.NewInt(777))
 	identities := []*Identity{id}
-	batchLabel := NewBatchLabel([]byte("sequential-test"))
+	batchLabel := mustNewBatchLabel(t, []byte("sequential-test"))
 
 	digest, _ := Digest(mpk, identities)
 	sk, _ := ComputeKey(msk, digest, batchLabel)
@@ -575,7 +611,7 @@ func TestSequentialEncryption(t *testing.T) {
 
 	// 连续加密多条消息
 	for i := 0; i < numMessages; i++ {
-		msg, _ := RandomMessage()
+		msg, _ := NewRandomMessage()
 		messages[i] = msg
 		ct, err := Encrypt(mpk, msg, id, batchLabel)
 		if err != nil {
@@ -608,13 +644,13 @@ func TestDifferentMasterKeys(t *testing.T) {
 
 	id := NewIdentity(big.NewInt(888))
 	identities := []*Identity{id}
-	batchLabel := NewBatchLabel([]byte("cross-key-test"))
+	batchLabel := mustNewBatchLabel(t, []byte("cross-key-test"))
 
 	// 使用第一组密钥
 	digest1, _ := Digest(mpk1, identities)
 	sk1, _ := ComputeKey(msk1, digest1, batchLabel)
 
-	msg, _ := RandomMessage()
+	msg, _ := NewRandomMessage()
 	ct1, _ := Encrypt(mpk1, msg, id, batchLabel)
 
 	// 使用第一组密钥解密应该成功
@@ -626,16 +662,32 @@ func TestDifferentMasterKeys(t *testing.T) {
 		t.Errorf("Message mismatch with correct keys")
 	}
 
-	// 使用第二组密钥的解密密钥尝试解密应该失败（得到错误消息）
+	// 使用第二组密钥的解密密钥尝试解密应该明确报错，而不是得到错误消息
 	digest2, _ := Digest(mpk2, identities)
 	sk2, _ := ComputeKey(msk2, digest2, batchLabel)
 
-	wrongDecrypt, err := Decrypt(ct1, sk2, digest2, identities, id, batchLabel, mpk2)
+	if _, err := Decrypt(ct1, sk2, digest2, identities, id, batchLabel, mpk2); !errors.Is(err, ErrParamsMismatch) {
+		t.Fatalf("Cross-key decrypt should return ErrParamsMismatch, got %v", err)
+	}
+}
+
+// TestNewRandomMessage 测试随机消息不是 GT 单位元，且两次生成的消息不同
+func TestNewRandomMessage(t *testing.T) {
+	var one bn254.GT
+	one.SetOne()
+	msg1, err := NewRandomMessage()
 	if err != nil {
-		t.Fatalf("Cross-key decrypt returned error: %v", err)
+		t.Fatalf("NewRandomMessage failed: %v", err)
+	}
+	msg2, err := NewRandomMessage()
+	if err != nil {
+		t.Fatalf("NewRandomMessage failed: %v", err)
+	}
+	if msg1.M.Equal(&one) || msg2.M.Equal(&one) {
+		t.Fatal("random message must not be the GT identity")
 	}
-	if msg.M.Equal(&wrongDecrypt.M) {
-		t.Errorf("Cross-key decryption should not produce correct message")
+	if msg1.M.Equal(&msg2.M) {
+		t.Fatal("two random messages should differ")
 	}
 }
 
@@ -645,8 +697,8 @@ func BenchmarkEncrypt(b *testing.B) {
 	mpk, _, _ := KeyGen(params)
 
 	id := NewIdentity(big.NewInt(12345))
-	batchLabel := NewBatchLabel([]byte("benchmark-batch"))
-	msg, _ := RandomMessage()
+	batchLabel := mustNewBatchLabel(b, []byte("benchmark-batch"))
+	msg, _ := NewRandomMessage()
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
@@ -665,11 +717,11 @@ func BenchmarkDecrypt(b *testing.B) {
 		identities[i] = NewIdentity(big.NewInt(int64(1000 + i)))
 	}
 
-	batchLabel := NewBatchLabel([]byte("benchmark-batch"))
+	batchLabel := mustNewBatchLabel(b, []byte("benchmark-batch"))
 	digest, _ := Digest(mpk, identities)
 	sk, _ := ComputeKey(msk, digest, batchLabel)
 
-	msg, _ := RandomMessage()
+	msg, _ := NewRandomMessage()
 	ct, _ := Encrypt(mpk, msg, identities[0], batchLabel)
 
 	b.ResetTimer()
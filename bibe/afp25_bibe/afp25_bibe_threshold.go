@@ -0,0 +1,143 @@
+package afp25_bibe
+
+import (
+	"errors"
+	"fmt"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/mmsyan/GoPairingBasedCryptography/metrics"
+	"github.com/mmsyan/GoPairingBasedCryptography/utils"
+	"math/big"
+)
+
+// ErrNotEnoughPartials 表示参与门限解密的部分解密个数少于门限值
+var ErrNotEnoughPartials = errors.New("not enough partial decryptions")
+
+// ErrDuplicatePartial 表示多个部分解密使用了同一个份额下标
+var ErrDuplicatePartial = errors.New("duplicate partial decryption index")
+
+// KeyShare 是批量解密密钥的一个 Shamir 份额 sk_i = msk_i · (D + h(t))，
+// 其中 msk_i = q(i) 是主密钥在该批次上的一次 (t, n) 分割。
+// 份额交给批量中的一个成员保管，单个份额不能解密任何密文。
+type KeyShare struct {
+	Index fr.Element     // 份额下标 i（非零）
+	Sk    bn254.G1Affine // 份额 msk_i · (D + h(t))
+}
+
+// PartialDecryption 是成员用自己的份额对一个密文做的部分解密 e(sk_i, C1[2])。
+type PartialDecryption struct {
+	Index fr.Element // 所用份额的下标 i
+	P     bn254.GT   // e(sk_i, C1[2])
+}
+
+// ComputeKeyShares 为一个批次计算门限解密密钥份额，是 ComputeKey 的门限版本。
+//
+// KGC 每次调用都对主密钥做一次新的 Shamir (threshold, n) 分割 msk_i = q(i)，q(0) = msk，
+// 并计算 sk_i = msk_i · (D + h(t))。由于 sk = msk · (D + h(t)) 对 msk 是线性的，
+// 任意 threshold 个份额按拉格朗日系数组合即得到 ComputeKey 的结果，而少于 threshold 个份额得不到任何信息。
+// 完整的 sk 从不出现：成员只公开部分解密，由 ThresholdDecrypt 在 GT 中组合。
+//
+// 参数:
+//   - msk: 主密钥,必须保密
+//   - d: 批量摘要,对应特定的身份集合
+//   - t: 批量标签,定义批量上下文
+//   - threshold: 解密所需的最少成员个数,需满足 1 <= threshold <= n
+//   - n: 份额个数,即参与门限解密的成员个数
+//
+// 返回值:
+//   - []*KeyShare: n 个份额,下标依次为 1, ..., n
+//   - error: 门限参数不合法或批标签为空时返回错误
+func ComputeKeyShares(msk *MasterSecretKey, d *BatchDigest, t *BatchLabel, threshold, n int) ([]*KeyShare, error) {
+	if err := checkBatchLabel(t); err != nil {
+		return nil, fmt.Errorf("unable to split batch key: %w", err)
+	}
+	mskShares, err := utils.ShamirSplit(msk.Msk, threshold, n)
+	if err != nil {
+		return nil, fmt.Errorf("unable to split batch key: %w", err)
+	}
+	ht := h(t)
+	dMulHt := new(bn254.G1Affine).Add(&d.D, &ht)
+	shares := make([]*KeyShare, 0, n)
+	for i := 1; i <= n; i++ {
+		index := fr.NewElement(uint64(i))
+		mskShare := mskShares[index]
+		shares = append(shares, &KeyShare{
+			Index: index,
+			Sk:    *new(bn254.G1Affine).ScalarMultiplication(dMulHt, mskShare.BigInt(new(big.Int))),
+		})
+	}
+	return shares, nil
+}
+
+// PartialDecrypt 使用一个份额对密文做部分解密。
+//
+// 参数:
+//   - c: 待解密的密文
+//   - share: 成员持有的密钥份额
+//
+// 返回值:
+//   - *PartialDecryption: 部分解密结果,可以公开交给组合方
+//   - error: 配对计算失败时返回错误
+func PartialDecrypt(c *Ciphertext, share *KeyShare) (*PartialDecryption, error) {
+	p, err := metrics.Pair([]bn254.G1Affine{share.Sk}, []bn254.G2Affine{c.C1[2]})
+	if err != nil {
+		return nil, fmt.Errorf("unable to compute partial decryption: %w", err)
+	}
+	return &PartialDecryption{
+		Index: share.Index,
+		P:     p,
+	}, nil
+}
+
+// ThresholdDecrypt 组合至少 threshold 个部分解密,恢复明文消息。
+//
+// 组合方计算 e(sk, C1[2]) = ∏ P_i^{λ_i},λ_i 为下标集合在 0 处的拉格朗日系数,
+// 再与 Decrypt 中只依赖摘要的两项 e(D, C1[0]) · e(π, C1[1]) 相乘,得到 c1 ∘ w 并恢复 m = C2 / (c1 ∘ w)。
+// 部分解密个数少于 threshold 时直接拒绝;若调用方传入的 threshold 小于分割时的门限,
+// 组合结果与 e(sk, C1[2]) 无关,得到的明文是随机的 GT 元素。
+//
+// 参数:
+//   - c: 待解密的密文
+//   - partials: 各成员的部分解密,下标互不相同
+//   - threshold: ComputeKeyShares 使用的门限值
+//   - d: 批量摘要,必须与份额匹配
+//   - identities: 完整的身份列表,包含密文接收者身份
+//   - id: 密文接收者的身份,必须在identities中
+//   - pk: 主公钥,用于计算商多项式
+//
+// 返回值:
+//   - *Message: 解密得到的明文消息
+//   - error: 部分解密不足(包装 ErrNotEnoughPartials)、下标重复(包装 ErrDuplicatePartial)、下标为零、
+//     身份不在列表中或配对计算失败时返回错误
+func ThresholdDecrypt(c *Ciphertext, partials []*PartialDecryption, threshold int, d *BatchDigest, identities []*Identity, id *Identity, pk *MasterPublicKey) (*Message, error) {
+	if len(partials) < threshold {
+		return nil, fmt.Errorf("%w: got %d, need %d", ErrNotEnoughPartials, len(partials), threshold)
+	}
+	indices := make([]fr.Element, len(partials))
+	points := make(map[fr.Element]bn254.GT, len(partials))
+	for i, partial := range partials {
+		if partial.Index.IsZero() {
+			return nil, fmt.Errorf("partial decryption index must be non-zero")
+		}
+		if _, ok := points[partial.Index]; ok {
+			return nil, fmt.Errorf("%w: %s", ErrDuplicatePartial, partial.Index.String())
+		}
+		points[partial.Index] = partial.P
+		indices[i] = partial.Index
+	}
+
+	// e(sk, C1[2]) = ∏ P_i^{λ_i}
+	combined := utils.LagrangeCombineGT(points, indices, fr.NewElement(0))
+
+	c1DotW, err := digestPairing(c, d, identities, id, pk)
+	if err != nil {
+		return nil, err
+	}
+	c1DotW.Mul(&c1DotW, &combined)
+
+	var m bn254.GT
+	m.Div(&c.C2, &c1DotW)
+	return &Message{
+		M: m,
+	}, nil
+}
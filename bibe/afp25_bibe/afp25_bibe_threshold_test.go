@@ -0,0 +1,126 @@
+package afp25_bibe
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+)
+
+// TestThresholdDecrypt 5 个成员、门限 3：任意 3 个部分解密都能恢复明文，2 个不能
+func TestThresholdDecrypt(t *testing.T) {
+	params, err := Setup(8)
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+	mpk, msk, err := KeyGen(params)
+	if err != nil {
+		t.Fatalf("KeyGen failed: %v", err)
+	}
+	var identities []*Identity
+	for i := int64(1); i <= 5; i++ {
+		identities = append(identities, NewIdentity(big.NewInt(100*i)))
+	}
+	batchLabel := mustNewBatchLabel(t, []byte("threshold-batch"))
+	digest, err := Digest(mpk, identities)
+	if err != nil {
+		t.Fatalf("Digest failed: %v", err)
+	}
+	shares, err := ComputeKeyShares(msk, digest, batchLabel, 3, 5)
+	if err != nil {
+		t.Fatalf("ComputeKeyShares failed: %v", err)
+	}
+
+	msg, err := NewRandomMessage()
+	if err != nil {
+		t.Fatalf("NewRandomMessage failed: %v", err)
+	}
+	recipient := identities[1]
+	ct, err := Encrypt(mpk, msg, recipient, batchLabel)
+	if err != nil {
+		t.Fatalf("Encrypt failed: %v", err)
+	}
+	partials := make([]*PartialDecryption, len(shares))
+	for i, share := range shares {
+		if partials[i], err = PartialDecrypt(ct, share); err != nil {
+			t.Fatalf("PartialDecrypt failed: %v", err)
+		}
+	}
+
+	// 任意 3 个部分解密
+	for a := 0; a < 5; a++ {
+		for b := a + 1; b < 5; b++ {
+			for c := b + 1; c < 5; c++ {
+				subset := []*PartialDecryption{partials[a], partials[b], partials[c]}
+				decryptedMsg, err := ThresholdDecrypt(ct, subset, 3, digest, identities, recipient, mpk)
+				if err != nil {
+					t.Fatalf("ThresholdDecrypt with partials %d,%d,%d failed: %v", a, b, c, err)
+				}
+				if !msg.M.Equal(&decryptedMsg.M) {
+					t.Fatalf("partials %d,%d,%d recovered the wrong message", a, b, c)
+				}
+			}
+		}
+	}
+
+	// 全部 5 个部分解密同样可以组合
+	decryptedMsg, err := ThresholdDecrypt(ct, partials, 3, digest, identities, recipient, mpk)
+	if err != nil || !msg.M.Equal(&decryptedMsg.M) {
+		t.Fatalf("ThresholdDecrypt with all partials failed: %v", err)
+	}
+
+	// 2 个部分解密被拒绝；即使调用方谎报门限，组合结果也不是明文
+	two := []*PartialDecryption{partials[0], partials[4]}
+	if _, err := ThresholdDecrypt(ct, two, 3, digest, identities, recipient, mpk); !errors.Is(err, ErrNotEnoughPartials) {
+		t.Fatalf("expected ErrNotEnoughPartials, got %v", err)
+	}
+	decryptedMsg, err = ThresholdDecrypt(ct, two, 2, digest, identities, recipient, mpk)
+	if err != nil {
+		t.Fatalf("ThresholdDecrypt failed: %v", err)
+	}
+	if msg.M.Equal(&decryptedMsg.M) {
+		t.Fatal("2 partial decryptions recovered the message")
+	}
+
+	// 重复的部分解密不能充当第三个成员
+	duplicated := []*PartialDecryption{partials[0], partials[4], partials[0]}
+	if _, err := ThresholdDecrypt(ct, duplicated, 3, digest, identities, recipient, mpk); !errors.Is(err, ErrDuplicatePartial) {
+		t.Fatalf("expected ErrDuplicatePartial, got %v", err)
+	}
+}
+
+// TestComputeKeySharesMatchesComputeKey 份额按拉格朗日系数组合后等于 ComputeKey 的结果
+func TestComputeKeySharesMatchesComputeKey(t *testing.T) {
+	params, _ := Setup(4)
+	mpk, msk, err := KeyGen(params)
+	if err != nil {
+		t.Fatalf("KeyGen failed: %v", err)
+	}
+	identities := []*Identity{NewIdentity(big.NewInt(7)), NewIdentity(big.NewInt(11))}
+	batchLabel := mustNewBatchLabel(t, []byte("batch"))
+	digest, _ := Digest(mpk, identities)
+	shares, err := ComputeKeyShares(msk, digest, batchLabel, 2, 3)
+	if err != nil {
+		t.Fatalf("ComputeKeyShares failed: %v", err)
+	}
+	sk, _ := ComputeKey(msk, digest, batchLabel)
+
+	msg, _ := NewRandomMessage()
+	ct, _ := Encrypt(mpk, msg, identities[0], batchLabel)
+	p0, _ := PartialDecrypt(ct, shares[0])
+	p2, _ := PartialDecrypt(ct, shares[2])
+	viaShares, err := ThresholdDecrypt(ct, []*PartialDecryption{p2, p0}, 2, digest, identities, identities[0], mpk)
+	if err != nil {
+		t.Fatalf("ThresholdDecrypt failed: %v", err)
+	}
+	viaKey, err := Decrypt(ct, sk, digest, identities, identities[0], batchLabel, mpk)
+	if err != nil {
+		t.Fatalf("Decrypt failed: %v", err)
+	}
+	if !viaShares.M.Equal(&viaKey.M) || !viaKey.M.Equal(&msg.M) {
+		t.Fatal("threshold decryption does not match Decrypt")
+	}
+
+	if _, err := ComputeKeyShares(msk, digest, batchLabel, 4, 3); err == nil {
+		t.Fatal("expected an error for threshold greater than n")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/consensys/gnark-crypto/ecc/bn254"
 	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
 	hash2 "github.com/mmsyan/GoPairingBasedCryptography/hash"
+	"github.com/mmsyan/GoPairingBasedCryptography/utils"
 	"math/big"
 )
 
@@ -11,35 +12,14 @@ func h(t *BatchLabel) bn254.G1Affine {
 	return hash2.BytesToG1(t.T)
 }
 
+// computePolynomialCoeffs 计算以各身份为根的多项式 ∏(X - id) 的系数，按低次到高次排列。
+// 批次较大时 utils.PolynomialFromRoots 使用乘积树与 FFT 乘法，避免逐个相乘的 O(n^2) 开销。
 func computePolynomialCoeffs(identities []*Identity) []fr.Element {
-	// 从常数多项式 1 开始
-	coeffs := []fr.Element{*new(fr.Element).SetOne()}
-
-	// 如果没有根，直接返回 [1]
-	if len(identities) == 0 {
-		return coeffs
+	roots := make([]fr.Element, len(identities))
+	for i, identity := range identities {
+		roots[i] = identity.Id
 	}
-
-	// 逐个乘以 (X - root)
-	for _, identity := range identities {
-		newCoeffs := make([]fr.Element, len(coeffs)+1)
-
-		// 乘以 (X - root) = X * coeffs - root * coeffs
-		for i := 0; i < len(coeffs); i++ {
-			// -root * coeffs[i] 加到 newCoeffs[i]
-			var temp fr.Element
-			temp.Mul(&identity.Id, &coeffs[i])
-			temp.Neg(&temp)
-			newCoeffs[i].Add(&newCoeffs[i], &temp)
-
-			// coeffs[i] 加到 newCoeffs[i+1] (X项)
-			newCoeffs[i+1].Add(&newCoeffs[i+1], &coeffs[i])
-		}
-
-		coeffs = newCoeffs
-	}
-
-	return coeffs
+	return utils.PolynomialFromRoots(roots)
 }
 
 func computeG1PolynomialTau(g1TauPowers []bn254.G1Affine, coef []fr.Element) bn254.G1Affine {
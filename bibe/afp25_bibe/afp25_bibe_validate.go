@@ -0,0 +1,80 @@
+package afp25_bibe
+
+import (
+	"errors"
+	"fmt"
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/mmsyan/GoPairingBasedCryptography/metrics"
+	"github.com/mmsyan/GoPairingBasedCryptography/serialization"
+)
+
+// ErrInconsistentPublicKey 表示主公钥的各分量不是由同一个 τ 生成的，例如 [τ^i]1 序列被替换或截断为空
+var ErrInconsistentPublicKey = errors.New("inconsistent master public key")
+
+// Validate 检查来自不可信来源的主公钥：所有点都必须是各自素数阶子群中的非无穷远点，
+// 且 [τ]1, [τ²]1, ..., [τ^B]1 与 [τ]2 由同一个 τ 生成。
+//
+// 幂次序列的一致性需要 B 个配对等式 e([τ^(i+1)]1, g2) = e([τ^i]1, [τ]2)，
+// 这里以随机系数 ρᵢ 把它们合并为一次两项的配对检查 e(Σρᵢ[τ^(i+1)]1, g2) = e(Σρᵢ[τ^i]1, [τ]2)，
+// 序列不一致时检查通过的概率可以忽略。UnmarshalBinary 只做逐点检查，序列一致性需要调用 Validate。
+//
+// 返回值:
+//   - error: 点不合法时返回指明分量、并包装 serialization.ErrInvalidG1Point 或 serialization.ErrInvalidG2Point 的错误；
+//     幂次序列为空或不一致时返回包装 ErrInconsistentPublicKey 的错误
+func (pk *MasterPublicKey) Validate() error {
+	if len(pk.G1ExpTauPowers) == 0 {
+		return fmt.Errorf("invalid master public key: %w: empty [τ^i]1 sequence", ErrInconsistentPublicKey)
+	}
+	for i := range pk.G1ExpTauPowers {
+		if err := serialization.ValidateG1(&pk.G1ExpTauPowers[i]); err != nil {
+			return fmt.Errorf("invalid master public key: [τ^%d]1: %w", i+1, err)
+		}
+	}
+	if err := serialization.ValidateG2(&pk.G2ExpTau); err != nil {
+		return fmt.Errorf("invalid master public key: [τ]2: %w", err)
+	}
+	if err := serialization.ValidateG2(&pk.G2ExpMsk); err != nil {
+		return fmt.Errorf("invalid master public key: [msk]2: %w", err)
+	}
+
+	// prev = (g1, [τ]1, ..., [τ^(B-1)]1)，next = ([τ]1, ..., [τ^B]1)，要求 next = τ·prev
+	_, _, g1, g2 := bn254.Generators()
+	prev := append([]bn254.G1Affine{g1}, pk.G1ExpTauPowers[:len(pk.G1ExpTauPowers)-1]...)
+	rho := make([]fr.Element, len(prev))
+	for i := range rho {
+		if _, err := rho[i].SetRandom(); err != nil {
+			return fmt.Errorf("failed to validate master public key: %w", err)
+		}
+	}
+	// 默认配置下 MultiExp 不会返回错误。
+	next, _ := new(bn254.G1Affine).MultiExp(pk.G1ExpTauPowers, rho, ecc.MultiExpConfig{})
+	base, _ := new(bn254.G1Affine).MultiExp(prev, rho, ecc.MultiExpConfig{})
+	base.Neg(base)
+	check, err := metrics.Pair([]bn254.G1Affine{*next, *base}, []bn254.G2Affine{g2, pk.G2ExpTau})
+	if err != nil {
+		return fmt.Errorf("failed to validate master public key: %w", err)
+	}
+	if !check.IsOne() {
+		return fmt.Errorf("invalid master public key: %w: [τ^i]1 sequence does not match [τ]2", ErrInconsistentPublicKey)
+	}
+	return nil
+}
+
+// Validate 检查来自不可信来源的密文：C1 的三个分量都必须是 G2 素数阶子群中的非无穷远点，C2 不能为零。
+// C2 = m·e(...) 中的明文 m 可以是任意 GT 元素，因此 C2 不做子群检查。
+//
+// 返回值:
+//   - error: 分量不合法时返回指明分量、并包装 serialization.ErrInvalidG2Point 或 serialization.ErrInvalidGTElement 的错误
+func (c *Ciphertext) Validate() error {
+	for i := range c.C1 {
+		if err := serialization.ValidateG2(&c.C1[i]); err != nil {
+			return fmt.Errorf("invalid ciphertext: C1[%d]: %w", i, err)
+		}
+	}
+	if c.C2.IsZero() {
+		return fmt.Errorf("invalid ciphertext: C2: zero element: %w", serialization.ErrInvalidGTElement)
+	}
+	return nil
+}
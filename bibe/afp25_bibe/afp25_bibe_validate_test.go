@@ -0,0 +1,80 @@
+package afp25_bibe
+
+import (
+	"errors"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/mmsyan/GoPairingBasedCryptography/internal/invalidpoints"
+	"github.com/mmsyan/GoPairingBasedCryptography/serialization"
+	"math/big"
+	"slices"
+	"testing"
+)
+
+// TestMasterPublicKeyValidate KeyGen 得到的主公钥通过检查；无穷远点、子群外的点、被替换或清空的 [τ^i]1 序列被拒绝
+func TestMasterPublicKeyValidate(t *testing.T) {
+	params, _ := Setup(4)
+	mpk, _, err := KeyGen(params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := mpk.Validate(); err != nil {
+		t.Fatalf("合法主公钥被拒绝: %v", err)
+	}
+
+	tamper := func(f func(pk *MasterPublicKey)) *MasterPublicKey {
+		pk := *mpk
+		pk.G1ExpTauPowers = slices.Clone(mpk.G1ExpTauPowers)
+		f(&pk)
+		return &pk
+	}
+	_, _, g1, _ := bn254.Generators()
+	cases := []struct {
+		name string
+		pk   *MasterPublicKey
+		want error
+	}{
+		{"无穷远 [τ^2]1", tamper(func(pk *MasterPublicKey) { pk.G1ExpTauPowers[1].SetInfinity() }), serialization.ErrInvalidG1Point},
+		{"子群外 [msk]2", tamper(func(pk *MasterPublicKey) { pk.G2ExpMsk = invalidpoints.NonSubgroupG2() }), serialization.ErrInvalidG2Point},
+		{"替换 [τ^3]1", tamper(func(pk *MasterPublicKey) { pk.G1ExpTauPowers[2] = g1 }), ErrInconsistentPublicKey},
+		{"交换 [τ]1 与 [τ^2]1", tamper(func(pk *MasterPublicKey) {
+			pk.G1ExpTauPowers[0], pk.G1ExpTauPowers[1] = pk.G1ExpTauPowers[1], pk.G1ExpTauPowers[0]
+		}), ErrInconsistentPublicKey},
+		{"空序列", tamper(func(pk *MasterPublicKey) { pk.G1ExpTauPowers = nil }), ErrInconsistentPublicKey},
+	}
+	for _, tc := range cases {
+		if err := tc.pk.Validate(); !errors.Is(err, tc.want) {
+			t.Errorf("%s: 期望 %v, 实际为 %v", tc.name, tc.want, err)
+		}
+	}
+}
+
+// TestCiphertextValidate Encrypt 得到的密文通过检查；C1 分量为无穷远点或子群外的点、C2 为零时被拒绝
+func TestCiphertextValidate(t *testing.T) {
+	params, _ := Setup(4)
+	mpk, _, _ := KeyGen(params)
+	m, _ := new(bn254.GT).SetRandom()
+	ct, err := Encrypt(mpk, NewMessage(*m), NewIdentity(big.NewInt(7)), mustNewBatchLabel(t, []byte("validate")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ct.Validate(); err != nil {
+		t.Fatalf("合法密文被拒绝: %v", err)
+	}
+
+	infinity, nonSubgroup, zero := *ct, *ct, *ct
+	infinity.C1[0].SetInfinity()
+	nonSubgroup.C1[2] = invalidpoints.NonSubgroupG2()
+	zero.C2 = bn254.GT{}
+	for name, tc := range map[string]struct {
+		ct   *Ciphertext
+		want error
+	}{
+		"无穷远 C1[0]": {&infinity, serialization.ErrInvalidG2Point},
+		"子群外 C1[2]": {&nonSubgroup, serialization.ErrInvalidG2Point},
+		"零 C2":      {&zero, serialization.ErrInvalidGTElement},
+	} {
+		if err := tc.ct.Validate(); !errors.Is(err, tc.want) {
+			t.Errorf("%s: 期望 %v, 实际为 %v", name, tc.want, err)
+		}
+	}
+}
@@ -17,6 +17,7 @@ import (
 	"fmt"
 	"github.com/consensys/gnark-crypto/ecc/bn254"
 	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/mmsyan/GoPairingBasedCryptography/metrics"
 	"math/big"
 )
 
@@ -54,7 +55,8 @@ type BatchDigest struct {
 }
 
 type Message struct {
-	M bn254.GT
+	M      bn254.GT
+	sealed []byte // SetBytes 以会话密钥加密的字节数据
 }
 
 type Ciphertext struct {
@@ -68,6 +70,7 @@ type SecretKey struct {
 	Y  fr.Element
 	U1 bn254.G2Affine
 	U2 bn254.G2Affine
+	D  BatchDigest // 生成该密钥时使用的批量摘要，Decrypt 用它校验身份列表
 }
 
 func Setup(B int) (*BatchIBEParams, error) {
@@ -98,7 +101,7 @@ func KeyGen(params *BatchIBEParams) (*MasterPublicKey, *MasterSecretKey, error)
 	g1ExpH := new(bn254.G1Affine).ScalarMultiplicationBase(h.BigInt(new(big.Int)))          // [h]1
 
 	_, _, g1, g2 := bn254.Generators()
-	eG1G2, err := bn254.Pair([]bn254.G1Affine{g1}, []bn254.G2Affine{g2})
+	eG1G2, err := metrics.Pair([]bn254.G1Affine{g1}, []bn254.G2Affine{g2})
 	if err != nil {
 		return nil, nil, err
 	}
@@ -164,7 +167,7 @@ func Digest(mpk *MasterPublicKey, identities []*Identity) (*BatchDigest, error)
 		return nil, fmt.Errorf("identities is empty")
 	}
 	if len(identities) > len(mpk.G2ExpTauPowers) {
-		return nil, fmt.Errorf("too many identities for batch size")
+		return nil, fmt.Errorf("too many identities for batch size: %w", ErrBatchTooLarge)
 	}
 	// Fs(x)=(x-id)
 	coef := computePolynomialCoeffs(identities)
@@ -175,6 +178,34 @@ func Digest(mpk *MasterPublicKey, identities []*Identity) (*BatchDigest, error)
 	}, nil
 }
 
+// VerifyDigest 根据身份列表独立重新计算批量摘要，并检查其是否与给定摘要一致。
+// Decrypt 分别接收摘要（通过密钥间接使用）与身份列表，二者可能不一致；
+// 解密方在信任密文所声明的批量成员关系之前，应先用该函数确认身份列表确实对应该摘要。
+// 摘要是以各身份为根的多项式在 τ 处的承诺，根集合相同则摘要相同，与身份顺序无关。
+//
+// 参数:
+//   - mpk: 主公钥
+//   - digest: 待验证的批量摘要
+//   - identities: 声称的批量身份列表
+//
+// 返回值:
+//   - bool: 摘要与身份列表一致时返回 true
+//   - error: 身份列表不合法时返回错误；摘要不匹配时返回包装了 ErrIdentityListMismatch 的错误
+func VerifyDigest(mpk *MasterPublicKey, digest *BatchDigest, identities []*Identity) (bool, error) {
+	if len(identities) == 0 {
+		return false, fmt.Errorf("identities is empty")
+	}
+	if len(identities) > len(mpk.G2ExpTauPowers) {
+		return false, fmt.Errorf("too many identities for batch size: %w", ErrBatchTooLarge)
+	}
+	coef := computePolynomialCoeffs(identities)
+	d := computeG2PolynomialTau(mpk.G2ExpTauPowers, coef)
+	if !d.Equal(&digest.D) {
+		return false, fmt.Errorf("digest does not match identities: %w", ErrIdentityListMismatch)
+	}
+	return true, nil
+}
+
 func ComputeKey(msk *MasterSecretKey, d *BatchDigest, t *BatchLabel) (*SecretKey, error) {
 	r, err := new(fr.Element).SetRandom()
 	if err != nil {
@@ -205,10 +236,34 @@ func ComputeKey(msk *MasterSecretKey, d *BatchDigest, t *BatchLabel) (*SecretKey
 		Y:  *y,
 		U1: *g2ExpR,
 		U2: *u2,
+		D:  *d,
 	}, nil
 }
 
+// Decrypt 使用批次密钥和完整的身份列表为身份 id 解密。
+//
+// 解密前先做三项检查，使错误的输入得到明确的错误而不是错误的明文:
+//  1. identities 的长度不超过批量大小 B
+//  2. id 必须在 identities 中
+//  3. identities 必须与密钥绑定的摘要 sk.D 一致（VerifyDigest，一次 G2 多标量乘法）
+//
+// 参数:
+//   - mpk: 主公钥
+//   - sk: 批次密钥（由 ComputeKey 针对 identities 的摘要生成）
+//   - identities: 该批次的完整身份列表
+//   - id: 解密方的身份
+//   - tg: 批次标签
+//   - ct: 发给 id 的密文
+//
+// 返回值:
+//   - *Message: 解密后的消息
+//   - error: 列表长度超过 B 时返回包装了 ErrBatchTooLarge 的错误；id 不在列表中时返回包装了 ErrIdentityNotInBatch 的错误；
+//     列表与摘要不一致时返回包装了 ErrIdentityListMismatch 的错误；配对计算失败时返回错误
 func Decrypt(mpk *MasterPublicKey, sk *SecretKey, identities []*Identity, id *Identity, tg *BatchLabel, ct *Ciphertext) (*Message, error) {
+	// 0. 超过批量大小的身份列表不可能对应任何批次密钥
+	if len(identities) > mpk.BatchSize() {
+		return nil, fmt.Errorf("failed to decrypt: %w", ErrBatchTooLarge)
+	}
 	// 1. 构造商多项式 q(X) = f(X) / (X - id)
 	// q(X) 的根为 identities \ {id}
 	var rootsWithoutId []*Identity
@@ -219,7 +274,10 @@ func Decrypt(mpk *MasterPublicKey, sk *SecretKey, identities []*Identity, id *Id
 	}
 
 	if len(rootsWithoutId) != len(identities)-1 {
-		return nil, fmt.Errorf("identity not found in identity list")
+		return nil, fmt.Errorf("failed to decrypt: %w", ErrIdentityNotInBatch)
+	}
+	if _, err := VerifyDigest(mpk, &sk.D, identities); err != nil {
+		return nil, fmt.Errorf("failed to decrypt: %w", err)
 	}
 	qCoef := computePolynomialCoeffs(rootsWithoutId)
 	fmt.Printf("qCoeffs: %v\n", qCoef)
@@ -227,23 +285,28 @@ func Decrypt(mpk *MasterPublicKey, sk *SecretKey, identities []*Identity, id *Id
 	// 2. 计算 π = g2^q(τ)
 	pi := computeG2PolynomialTau(mpk.G2ExpTauPowers, qCoef)
 
+	return decryptWithPi(sk, pi, ct)
+}
+
+// decryptWithPi 使用商多项式承诺 π = [q(τ)]2 完成解密，Decrypt 与 DecryptWithWitness 共用
+func decryptWithPi(sk *SecretKey, pi bn254.G2Affine, ct *Ciphertext) (*Message, error) {
 	// 3. 计算分量
 	// [ct1]1 · [u2]2
-	pairA, err := bn254.Pair([]bn254.G1Affine{ct.Ct1}, []bn254.G2Affine{sk.U2})
+	pairA, err := metrics.Pair([]bn254.G1Affine{ct.Ct1}, []bn254.G2Affine{sk.U2})
 	if err != nil {
-		return nil, fmt.Errorf("failed to calculate eCt1")
+		return nil, fmt.Errorf("failed to calculate eCt1: %w", err)
 	}
 	yct2 := *new(bn254.G1Affine).ScalarMultiplication(&ct.Ct2, sk.Y.BigInt(new(big.Int)))
 	// y[ct]1 · pi
-	pairB, err := bn254.Pair([]bn254.G1Affine{yct2}, []bn254.G2Affine{pi})
+	pairB, err := metrics.Pair([]bn254.G1Affine{yct2}, []bn254.G2Affine{pi})
 	if err != nil {
-		return nil, fmt.Errorf("failed to calculate eYct2")
+		return nil, fmt.Errorf("failed to calculate eYct2: %w", err)
 	}
 
 	// [ct3]1 · [u1]2
-	pairC, err := bn254.Pair([]bn254.G1Affine{ct.Ct3}, []bn254.G2Affine{sk.U1})
+	pairC, err := metrics.Pair([]bn254.G1Affine{ct.Ct3}, []bn254.G2Affine{sk.U1})
 	if err != nil {
-		return nil, fmt.Errorf("failed to calculate eCt3")
+		return nil, fmt.Errorf("failed to calculate eCt3: %w", err)
 	}
 
 	temp1 := new(bn254.GT).Div(&pairA, &pairB)
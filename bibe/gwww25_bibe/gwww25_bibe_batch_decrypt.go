@@ -0,0 +1,88 @@
+package gwww25_bibe
+
+import (
+	"fmt"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/mmsyan/GoPairingBasedCryptography/metrics"
+	"math/big"
+)
+
+// DecryptContext 保存同一解密方在同一批次中解密多份密文时可以共用的预计算结果。
+//
+// Decrypt 每次都要校验身份列表、构造商多项式 q(X) = f(X) / (X - id) 并计算 π = [q(τ)]2，
+// 这些只依赖 (identities, id, sk)，与密文无关。DecryptContext 把它们计算一次，
+// 并进一步把三个 G2 配对输入 U2、y·π、U1 的 Miller 循环直线系数预先算好，
+// 之后每份密文只需一次固定 G2 的多重配对，不再有 G1 标量乘法。
+type DecryptContext struct {
+	// lines 依次是 U2、y·π、U1 的 Miller 循环直线系数
+	lines [][2][len(bn254.LoopCounter)]bn254.LineEvaluationAff
+}
+
+// PrepareDecrypt 为身份 id 在批次中的多次解密做预计算，检查与 Decrypt 相同。
+//
+// 参数:
+//   - mpk: 主公钥
+//   - sk: 批次密钥（由 ComputeKey 针对 identities 的摘要生成）
+//   - identities: 该批次的完整身份列表
+//   - id: 解密方的身份
+//   - tg: 批次标签（与 Decrypt 一致，解密公式不使用）
+//
+// 返回值:
+//   - *DecryptContext: 解密上下文，可交给 DecryptWith 反复使用
+//   - error: 列表长度超过 B 时返回包装了 ErrBatchTooLarge 的错误；id 不在列表中时返回包装了 ErrIdentityNotInBatch 的错误；
+//     列表与摘要不一致时返回包装了 ErrIdentityListMismatch 的错误
+func PrepareDecrypt(mpk *MasterPublicKey, sk *SecretKey, identities []*Identity, id *Identity, tg *BatchLabel) (*DecryptContext, error) {
+	if len(identities) > mpk.BatchSize() {
+		return nil, fmt.Errorf("failed to prepare decrypt: %w", ErrBatchTooLarge)
+	}
+	rootsWithoutId := make([]*Identity, 0, len(identities))
+	for _, identity := range identities {
+		if !identity.Id.Equal(&id.Id) {
+			rootsWithoutId = append(rootsWithoutId, identity)
+		}
+	}
+	if len(rootsWithoutId) != len(identities)-1 {
+		return nil, fmt.Errorf("failed to prepare decrypt: %w", ErrIdentityNotInBatch)
+	}
+	if _, err := VerifyDigest(mpk, &sk.D, identities); err != nil {
+		return nil, fmt.Errorf("failed to prepare decrypt: %w", err)
+	}
+	pi := computeG2PolynomialTau(mpk.G2ExpTauPowers, computePolynomialCoeffs(rootsWithoutId))
+
+	// e(y·Ct2, π) = e(Ct2, y·π)，把标量乘法从每份密文移到预计算中
+	var yPi bn254.G2Affine
+	yPi.ScalarMultiplication(&pi, sk.Y.BigInt(new(big.Int)))
+	return &DecryptContext{
+		lines: [][2][len(bn254.LoopCounter)]bn254.LineEvaluationAff{
+			bn254.PrecomputeLines(sk.U2),
+			bn254.PrecomputeLines(yPi),
+			bn254.PrecomputeLines(sk.U1),
+		},
+	}, nil
+}
+
+// DecryptWith 使用 PrepareDecrypt 得到的上下文解密一份密文，结果与 Decrypt 相同。
+// 解密公式 M = Ct4 / (e(Ct1, U2) / e(y·Ct2, π) / e(Ct3, U1)) 改写为
+// M = Ct4 · e(-Ct1, U2) · e(Ct2, y·π) · e(Ct3, U1)，合并为一次多重配对。
+//
+// 参数:
+//   - ctx: 解密上下文
+//   - ct: 发给上下文对应身份的密文
+//
+// 返回值:
+//   - *Message: 解密后的消息
+//   - error: 配对计算失败时返回错误
+func DecryptWith(ctx *DecryptContext, ct *Ciphertext) (*Message, error) {
+	var negCt1 bn254.G1Affine
+	negCt1.Neg(&ct.Ct1)
+	// bn254.MillerLoopFixedQ 会原地改写传入的直线系数，因此每次都在副本上计算
+	lines := make([][2][len(bn254.LoopCounter)]bn254.LineEvaluationAff, len(ctx.lines))
+	copy(lines, ctx.lines)
+	prod, err := metrics.PairFixedQ([]bn254.G1Affine{negCt1, ct.Ct2, ct.Ct3}, lines)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt: %w", err)
+	}
+	var m bn254.GT
+	m.Mul(&ct.Ct4, &prod)
+	return &Message{M: m}, nil
+}
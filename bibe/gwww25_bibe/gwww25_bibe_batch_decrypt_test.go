@@ -0,0 +1,99 @@
+package gwww25_bibe
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// TestDecryptWith 同一上下文解密多份密文，结果与 Decrypt 一致；不在批次中或身份列表不一致时 PrepareDecrypt 报错
+func TestDecryptWith(t *testing.T) {
+	params, err := Setup(8)
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+	mpk, msk, err := KeyGen(params)
+	if err != nil {
+		t.Fatalf("KeyGen failed: %v", err)
+	}
+	batchLabel := NewBatchLabel(7)
+	identities := []*Identity{NewIdentity(11), NewIdentity(22), NewIdentity(33), NewIdentity(44)}
+	digest, err := Digest(mpk, identities)
+	if err != nil {
+		t.Fatalf("Digest failed: %v", err)
+	}
+	sk, err := ComputeKey(msk, digest, batchLabel)
+	if err != nil {
+		t.Fatalf("ComputeKey failed: %v", err)
+	}
+
+	id := identities[2]
+	ctx, err := PrepareDecrypt(mpk, sk, identities, id, batchLabel)
+	if err != nil {
+		t.Fatalf("PrepareDecrypt failed: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		msg, err := NewRandomMessage()
+		if err != nil {
+			t.Fatalf("NewRandomMessage failed: %v", err)
+		}
+		ct, err := Encrypt(mpk, msg, id, batchLabel)
+		if err != nil {
+			t.Fatalf("Encrypt failed: %v", err)
+		}
+		got, err := DecryptWith(ctx, ct)
+		if err != nil {
+			t.Fatalf("DecryptWith failed: %v", err)
+		}
+		want, err := Decrypt(mpk, sk, identities, id, batchLabel, ct)
+		if err != nil {
+			t.Fatalf("Decrypt failed: %v", err)
+		}
+		if !got.M.Equal(&msg.M) || !got.M.Equal(&want.M) {
+			t.Fatal("DecryptWith result differs from the original message or Decrypt")
+		}
+	}
+
+	if _, err := PrepareDecrypt(mpk, sk, identities, NewIdentity(55), batchLabel); !errors.Is(err, ErrIdentityNotInBatch) {
+		t.Errorf("expected ErrIdentityNotInBatch, got %v", err)
+	}
+	if _, err := PrepareDecrypt(mpk, sk, identities[:3], id, batchLabel); !errors.Is(err, ErrIdentityListMismatch) {
+		t.Errorf("expected ErrIdentityListMismatch, got %v", err)
+	}
+}
+
+// BenchmarkDecryptBatch 对比同一身份解密 50 份密文时反复调用 Decrypt 与 PrepareDecrypt + DecryptWith 的耗时
+func BenchmarkDecryptBatch(b *testing.B) {
+	const numCiphertexts = 50
+	params, _ := Setup(10)
+	mpk, msk, _ := KeyGen(params)
+	batchLabel := NewBatchLabel(7)
+	identities := make([]*Identity, 10)
+	for i := range identities {
+		identities[i] = NewIdentity(int64(1000 + i))
+	}
+	id := identities[0]
+	digest, _ := Digest(mpk, identities)
+	sk, _ := ComputeKey(msk, digest, batchLabel)
+	cts := make([]*Ciphertext, numCiphertexts)
+	for i := range cts {
+		msg, _ := NewRandomMessage()
+		cts[i], _ = Encrypt(mpk, msg, id, batchLabel)
+	}
+
+	b.Run(fmt.Sprintf("Decrypt/n=%d", numCiphertexts), func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, ct := range cts {
+				_, _ = Decrypt(mpk, sk, identities, id, batchLabel, ct)
+			}
+		}
+	})
+	b.Run(fmt.Sprintf("DecryptWith/n=%d", numCiphertexts), func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			ctx, _ := PrepareDecrypt(mpk, sk, identities, id, batchLabel)
+			for _, ct := range cts {
+				_, _ = DecryptWith(ctx, ct)
+			}
+		}
+	})
+}
@@ -0,0 +1,45 @@
+package gwww25_bibe
+
+import "fmt"
+
+// BatchSize 返回主公钥支持的最大批量大小 B，即 Setup 时确定的 τ 幂次个数。
+// 加密方可以据此与聚合者协商批次划分：身份数超过 B 的批次无法计算摘要，也就无法得到批次密钥。
+func (mpk *MasterPublicKey) BatchSize() int {
+	return len(mpk.G2ExpTauPowers)
+}
+
+// EncryptToBatch 在已知接收方所属批次的情况下加密，加密前检查批次与批量大小 B 及接收方身份一致。
+//
+// Encrypt 只需要接收方身份和批次标签，无法发现接收方所在的批次超过了 B、因而永远拿不到批次密钥；
+// EncryptToBatch 把 Digest 与 Decrypt 对批次的要求提前到加密时检查，使三者对批量大小的约束一致。
+//
+// 参数:
+//   - mpk: 主公钥
+//   - m: 待加密的消息
+//   - id: 接收方身份
+//   - identities: 接收方所属批次的完整身份列表
+//   - tg: 批次标签
+//
+// 返回值:
+//   - *Ciphertext: 发给 id 的密文，与 Encrypt 的结果格式相同
+//   - error: 列表为空时返回错误；长度超过 B 时返回包装了 ErrBatchTooLarge 的错误；
+//     id 不在列表中时返回包装了 ErrIdentityNotInBatch 的错误
+func EncryptToBatch(mpk *MasterPublicKey, m *Message, id *Identity, identities []*Identity, tg *BatchLabel) (*Ciphertext, error) {
+	if len(identities) == 0 {
+		return nil, fmt.Errorf("failed to encrypt: identities is empty")
+	}
+	if len(identities) > mpk.BatchSize() {
+		return nil, fmt.Errorf("failed to encrypt: %w", ErrBatchTooLarge)
+	}
+	found := false
+	for _, identity := range identities {
+		if identity.Id.Equal(&id.Id) {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("failed to encrypt: %w", ErrIdentityNotInBatch)
+	}
+	return Encrypt(mpk, m, id, tg)
+}
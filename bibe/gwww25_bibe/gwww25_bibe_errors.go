@@ -0,0 +1,17 @@
+package gwww25_bibe
+
+import "errors"
+
+var (
+	// ErrIdentityNotInBatch 表示解密的目标身份不在传入的身份列表中
+	ErrIdentityNotInBatch = errors.New("target identity not in batch")
+
+	// ErrIdentityListMismatch 表示身份列表与批次密钥绑定的摘要不一致
+	ErrIdentityListMismatch = errors.New("identity list inconsistent with digest")
+
+	// ErrBatchTooLarge 表示身份列表的长度超过了 Setup 确定的批量大小 B，这样的批次不可能得到批次密钥
+	ErrBatchTooLarge = errors.New("batch exceeds the maximum batch size")
+
+	// ErrInconsistentPublicKey 表示主公钥的各分量不是由同一组 τ、w 生成的，例如 [τ^i]2 序列被替换或截断为空
+	ErrInconsistentPublicKey = errors.New("inconsistent master public key")
+)
@@ -0,0 +1,73 @@
+package gwww25_bibe
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/mmsyan/GoPairingBasedCryptography/serialization"
+)
+
+// MarshalBinary 将主公钥序列化为规范字节串（压缩点编码）。
+// 布局: 头部 || G2ExpTauPowers (长度前缀 + G2...) || G1ExpTau || G1ExpW || G1ExpWTau || G1ExpV || G1ExpH || GTExpAlpha。
+//
+// 返回值:
+//   - []byte: 序列化后的主公钥
+//   - error: 序列化失败时返回错误
+func (mpk *MasterPublicKey) MarshalBinary() ([]byte, error) {
+	data := serialization.AppendHeader(nil, serialization.SchemeGWWW25BIBE)
+	data = append(data, serialization.EncodeG2Slice(mpk.G2ExpTauPowers)...)
+	for _, p := range []bn254.G1Affine{mpk.G1ExpTau, mpk.G1ExpW, mpk.G1ExpWTau, mpk.G1ExpV, mpk.G1ExpH} {
+		data = append(data, serialization.EncodeG1(p, serialization.Compressed)...)
+	}
+	data = append(data, serialization.MarshalGT(mpk.GTExpAlpha)...)
+	return data, nil
+}
+
+// UnmarshalBinary 从 MarshalBinary 的输出恢复主公钥。
+//
+// 参数:
+//   - data: 序列化后的主公钥
+//
+// 返回值:
+//   - error: 数据格式不正确时返回错误
+func (mpk *MasterPublicKey) UnmarshalBinary(data []byte) error {
+	data, err := serialization.CheckHeader(data, serialization.SchemeGWWW25BIBE)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal master public key: %w", err)
+	}
+	tauPowers, n, err := serialization.DecodeValidG2Slice(data)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal master public key: %w", err)
+	}
+	data = data[n:]
+	var g1Points [5]bn254.G1Affine
+	for i := range g1Points {
+		g1Points[i], n, err = serialization.DecodeG1(data)
+		if err != nil {
+			return fmt.Errorf("failed to unmarshal master public key: %w", err)
+		}
+		data = data[n:]
+	}
+	gtExpAlpha, n, err := serialization.DecodeGT(data)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal master public key: %w", err)
+	}
+	if len(data) != n {
+		return errors.New("failed to unmarshal master public key: trailing bytes")
+	}
+	mpk.G2ExpTauPowers = tauPowers
+	mpk.G1ExpTau, mpk.G1ExpW, mpk.G1ExpWTau, mpk.G1ExpV, mpk.G1ExpH = g1Points[0], g1Points[1], g1Points[2], g1Points[3], g1Points[4]
+	mpk.GTExpAlpha = gtExpAlpha
+	return nil
+}
+
+// Fingerprint 返回主公钥规范序列化的 SHA-256 摘要。
+// 参与批量加密的各方可以先比较指纹，确认使用的是同一套公开参数，再执行开销较大的运算。
+//
+// 返回值:
+//   - [32]byte: 主公钥指纹
+func (mpk *MasterPublicKey) Fingerprint() [32]byte {
+	data, _ := mpk.MarshalBinary()
+	return sha256.Sum256(data)
+}
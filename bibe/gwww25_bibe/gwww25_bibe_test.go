@@ -1,10 +1,10 @@
 package gwww25_bibe
 
 import (
+	"errors"
 	"fmt"
 	"github.com/consensys/gnark-crypto/ecc/bn254"
 	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
-	"math/big"
 	"testing"
 )
 
@@ -24,17 +24,6 @@ func NewBatchLabel(tg int64) *BatchLabel {
 	}
 }
 
-// Helper function: generate a random message
-func RandomMessage() (*Message, error) {
-	msg := new(bn254.GT).SetOne()
-	randomScalar, err := new(fr.Element).SetRandom()
-	if err != nil {
-		return nil, err
-	}
-	msg.Exp(*msg, randomScalar.BigInt(new(big.Int)))
-	return &Message{M: *msg}, nil
-}
-
 func TestSetup(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -128,9 +117,9 @@ func TestEncryptDecrypt_SingleIdentity(t *testing.T) {
 	}
 
 	// Create and encrypt message
-	originalMsg, err := RandomMessage()
+	originalMsg, err := NewRandomMessage()
 	if err != nil {
-		t.Fatalf("RandomMessage failed: %v", err)
+		t.Fatalf("NewRandomMessage failed: %v", err)
 	}
 
 	ct, err := Encrypt(mpk, originalMsg, id, batchLabel)
@@ -186,9 +175,9 @@ func TestEncryptDecrypt_MultipleIdentities1(t *testing.T) {
 	}
 
 	// Create and encrypt message
-	originalMsg, err := RandomMessage()
+	originalMsg, err := NewRandomMessage()
 	if err != nil {
-		t.Fatalf("RandomMessage failed: %v", err)
+		t.Fatalf("NewRandomMessage failed: %v", err)
 	}
 
 	ct, err := Encrypt(mpk, originalMsg, id1, batchLabel)
@@ -246,9 +235,9 @@ func TestEncryptDecrypt_MultipleIdentities2(t *testing.T) {
 	for i, id := range identities {
 		t.Run(fmt.Sprintf("Identity_%d", i+1), func(t *testing.T) {
 			// Create and encrypt message
-			originalMsg, err := RandomMessage()
+			originalMsg, err := NewRandomMessage()
 			if err != nil {
-				t.Fatalf("RandomMessage failed: %v", err)
+				t.Fatalf("NewRandomMessage failed: %v", err)
 			}
 
 			ct, err := Encrypt(mpk, originalMsg, id, batchLabel)
@@ -288,6 +277,50 @@ func TestDigest_EmptyIdentities(t *testing.T) {
 	}
 }
 
+func TestVerifyDigest(t *testing.T) {
+	params, err := Setup(10)
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+	mpk, _, err := KeyGen(params)
+	if err != nil {
+		t.Fatalf("KeyGen failed: %v", err)
+	}
+
+	identities := []*Identity{NewIdentity(1), NewIdentity(2), NewIdentity(3)}
+	digest, err := Digest(mpk, identities)
+	if err != nil {
+		t.Fatalf("Digest failed: %v", err)
+	}
+
+	ok, err := VerifyDigest(mpk, digest, identities)
+	if err != nil || !ok {
+		t.Fatalf("VerifyDigest failed for matching identities: %v", err)
+	}
+
+	// Order of identities does not matter
+	reordered := []*Identity{identities[2], identities[0], identities[1]}
+	if ok, err := VerifyDigest(mpk, digest, reordered); err != nil || !ok {
+		t.Fatalf("VerifyDigest failed for reordered identities: %v", err)
+	}
+
+	// Tampered lists must be rejected
+	tamperedLists := map[string][]*Identity{
+		"replaced": {NewIdentity(1), NewIdentity(2), NewIdentity(4)},
+		"missing":  {NewIdentity(1), NewIdentity(2)},
+		"extra":    {NewIdentity(1), NewIdentity(2), NewIdentity(3), NewIdentity(4)},
+	}
+	for name, list := range tamperedLists {
+		if ok, err := VerifyDigest(mpk, digest, list); ok || err == nil {
+			t.Errorf("%s: expected VerifyDigest to fail for tampered identities", name)
+		}
+	}
+
+	if _, err := VerifyDigest(mpk, digest, []*Identity{}); err == nil {
+		t.Error("Expected error for empty identities, got nil")
+	}
+}
+
 func TestDecrypt_IdentityNotInList(t *testing.T) {
 	// Setup
 	params, err := Setup(10)
@@ -322,9 +355,9 @@ func TestDecrypt_IdentityNotInList(t *testing.T) {
 
 	// Try to decrypt for identity not in the list
 	idNotInList := NewIdentity(99)
-	originalMsg, err := RandomMessage()
+	originalMsg, err := NewRandomMessage()
 	if err != nil {
-		t.Fatalf("RandomMessage failed: %v", err)
+		t.Fatalf("NewRandomMessage failed: %v", err)
 	}
 
 	ct, err := Encrypt(mpk, originalMsg, idNotInList, batchLabel)
@@ -334,12 +367,62 @@ func TestDecrypt_IdentityNotInList(t *testing.T) {
 
 	// This should fail
 	_, err = Decrypt(mpk, sk, identities, idNotInList, batchLabel, ct)
-	if err == nil {
-		t.Error("Expected error when decrypting for identity not in list, got nil")
+	if !errors.Is(err, ErrIdentityNotInBatch) {
+		t.Errorf("Expected ErrIdentityNotInBatch when decrypting for identity not in list, got %v", err)
 	}
 	fmt.Println(err)
 }
 
+func TestDecrypt_IdentityListInconsistentWithDigest(t *testing.T) {
+	params, err := Setup(10)
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+	mpk, msk, err := KeyGen(params)
+	if err != nil {
+		t.Fatalf("KeyGen failed: %v", err)
+	}
+	batchLabel := NewBatchLabel(5)
+	identities := []*Identity{NewIdentity(1), NewIdentity(2), NewIdentity(3)}
+	digest, err := Digest(mpk, identities)
+	if err != nil {
+		t.Fatalf("Digest failed: %v", err)
+	}
+	sk, err := ComputeKey(msk, digest, batchLabel)
+	if err != nil {
+		t.Fatalf("ComputeKey failed: %v", err)
+	}
+	msg, err := NewRandomMessage()
+	if err != nil {
+		t.Fatalf("NewRandomMessage failed: %v", err)
+	}
+	ct, err := Encrypt(mpk, msg, identities[0], batchLabel)
+	if err != nil {
+		t.Fatalf("Encrypt failed: %v", err)
+	}
+
+	// The target is in the list, but the list is not the one the digest was built from
+	for name, list := range map[string][]*Identity{
+		"missing member": {NewIdentity(1), NewIdentity(2)},
+		"extra member":   {NewIdentity(1), NewIdentity(2), NewIdentity(3), NewIdentity(4)},
+		"replaced":       {NewIdentity(1), NewIdentity(2), NewIdentity(7)},
+	} {
+		if _, err := Decrypt(mpk, sk, list, identities[0], batchLabel, ct); !errors.Is(err, ErrIdentityListMismatch) {
+			t.Errorf("%s: expected ErrIdentityListMismatch, got %v", name, err)
+		}
+	}
+
+	// A reordered list has the same roots and still decrypts
+	reordered := []*Identity{NewIdentity(3), NewIdentity(1), NewIdentity(2)}
+	decrypted, err := Decrypt(mpk, sk, reordered, identities[0], batchLabel, ct)
+	if err != nil {
+		t.Fatalf("Decrypt with reordered list failed: %v", err)
+	}
+	if !decrypted.M.Equal(&msg.M) {
+		t.Error("Decrypted message does not match original")
+	}
+}
+
 func TestEncryptDecrypt_DifferentBatchLabels(t *testing.T) {
 	// Setup
 	params, err := Setup(10)
@@ -373,9 +456,9 @@ func TestEncryptDecrypt_DifferentBatchLabels(t *testing.T) {
 	}
 
 	// Encrypt with batchLabel2
-	originalMsg, err := RandomMessage()
+	originalMsg, err := NewRandomMessage()
 	if err != nil {
-		t.Fatalf("RandomMessage failed: %v", err)
+		t.Fatalf("NewRandomMessage failed: %v", err)
 	}
 
 	ct, err := Encrypt(mpk, originalMsg, id, batchLabel2)
@@ -435,12 +518,32 @@ func BenchmarkKeyGen(b *testing.B) {
 	}
 }
 
+// TestNewRandomMessage 验证随机消息不是 GT 单位元，且两次生成的消息不同
+func TestNewRandomMessage(t *testing.T) {
+	var one bn254.GT
+	one.SetOne()
+	msg1, err := NewRandomMessage()
+	if err != nil {
+		t.Fatalf("NewRandomMessage failed: %v", err)
+	}
+	msg2, err := NewRandomMessage()
+	if err != nil {
+		t.Fatalf("NewRandomMessage failed: %v", err)
+	}
+	if msg1.M.Equal(&one) || msg2.M.Equal(&one) {
+		t.Fatal("random message must not be the GT identity")
+	}
+	if msg1.M.Equal(&msg2.M) {
+		t.Fatal("two random messages should differ")
+	}
+}
+
 func BenchmarkEncrypt(b *testing.B) {
 	params, _ := Setup(10)
 	mpk, _, _ := KeyGen(params)
 	id := NewIdentity(42)
 	batchLabel := NewBatchLabel(7)
-	msg, _ := RandomMessage()
+	msg, _ := NewRandomMessage()
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
@@ -457,7 +560,7 @@ func BenchmarkDecrypt(b *testing.B) {
 
 	digest, _ := Digest(mpk, identities)
 	sk, _ := ComputeKey(msk, digest, batchLabel)
-	msg, _ := RandomMessage()
+	msg, _ := NewRandomMessage()
 	ct, _ := Encrypt(mpk, msg, id, batchLabel)
 
 	b.ResetTimer()
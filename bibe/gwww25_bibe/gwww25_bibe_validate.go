@@ -0,0 +1,95 @@
+package gwww25_bibe
+
+import (
+	"fmt"
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/mmsyan/GoPairingBasedCryptography/metrics"
+	"github.com/mmsyan/GoPairingBasedCryptography/serialization"
+)
+
+// Validate 检查来自不可信来源的主公钥：所有点都必须是各自素数阶子群中的非无穷远点，[α]T 必须是 GT 的 r 阶子群中的非单位元，
+// 且 [τ]2, [τ²]2, ..., [τ^B]2 与 [τ]1 由同一个 τ 生成、[wτ]1 与 [w]1、[τ]2 一致。
+//
+// 幂次序列的 B 个配对等式 e(g1, [τ^(i+1)]2) = e([τ]1, [τ^i]2) 以随机系数 ρᵢ 合并为一次两项的配对检查，
+// 序列不一致时检查通过的概率可以忽略。UnmarshalBinary 只做逐点检查，分量之间的一致性需要调用 Validate。
+//
+// 返回值:
+//   - error: 点或 GT 元素不合法时返回指明分量、并包装 serialization.ErrInvalidG1Point、serialization.ErrInvalidG2Point
+//     或 serialization.ErrInvalidGTElement 的错误；分量之间不一致时返回包装 ErrInconsistentPublicKey 的错误
+func (mpk *MasterPublicKey) Validate() error {
+	if len(mpk.G2ExpTauPowers) == 0 {
+		return fmt.Errorf("invalid master public key: %w: empty [τ^i]2 sequence", ErrInconsistentPublicKey)
+	}
+	for i := range mpk.G2ExpTauPowers {
+		if err := serialization.ValidateG2(&mpk.G2ExpTauPowers[i]); err != nil {
+			return fmt.Errorf("invalid master public key: [τ^%d]2: %w", i+1, err)
+		}
+	}
+	for _, p := range []struct {
+		name  string
+		point *bn254.G1Affine
+	}{{"[τ]1", &mpk.G1ExpTau}, {"[w]1", &mpk.G1ExpW}, {"[wτ]1", &mpk.G1ExpWTau}, {"[v]1", &mpk.G1ExpV}, {"[h]1", &mpk.G1ExpH}} {
+		if err := serialization.ValidateG1(p.point); err != nil {
+			return fmt.Errorf("invalid master public key: %s: %w", p.name, err)
+		}
+	}
+	if err := serialization.ValidateGT(&mpk.GTExpAlpha); err != nil {
+		return fmt.Errorf("invalid master public key: [α]T: %w", err)
+	}
+
+	// prev = (g2, [τ]2, ..., [τ^(B-1)]2)，next = ([τ]2, ..., [τ^B]2)，要求 next = τ·prev
+	_, _, g1, g2 := bn254.Generators()
+	prev := append([]bn254.G2Affine{g2}, mpk.G2ExpTauPowers[:len(mpk.G2ExpTauPowers)-1]...)
+	rho := make([]fr.Element, len(prev))
+	for i := range rho {
+		if _, err := rho[i].SetRandom(); err != nil {
+			return fmt.Errorf("failed to validate master public key: %w", err)
+		}
+	}
+	// 默认配置下 MultiExp 不会返回错误。
+	next, _ := new(bn254.G2Affine).MultiExp(mpk.G2ExpTauPowers, rho, ecc.MultiExpConfig{})
+	base, _ := new(bn254.G2Affine).MultiExp(prev, rho, ecc.MultiExpConfig{})
+	var negTau, negW bn254.G1Affine
+	negTau.Neg(&mpk.G1ExpTau)
+	negW.Neg(&mpk.G1ExpW)
+	if ok, err := pairsToOne([]bn254.G1Affine{g1, negTau}, []bn254.G2Affine{*next, *base}); err != nil {
+		return fmt.Errorf("failed to validate master public key: %w", err)
+	} else if !ok {
+		return fmt.Errorf("invalid master public key: %w: [τ^i]2 sequence does not match [τ]1", ErrInconsistentPublicKey)
+	}
+	// e([wτ]1, g2) = e([w]1, [τ]2)
+	if ok, err := pairsToOne([]bn254.G1Affine{mpk.G1ExpWTau, negW}, []bn254.G2Affine{g2, mpk.G2ExpTauPowers[0]}); err != nil {
+		return fmt.Errorf("failed to validate master public key: %w", err)
+	} else if !ok {
+		return fmt.Errorf("invalid master public key: %w: [wτ]1 does not match [w]1 and [τ]2", ErrInconsistentPublicKey)
+	}
+	return nil
+}
+
+// Validate 检查来自不可信来源的密文：Ct1、Ct2、Ct3 必须是 G1 中的非无穷远点，Ct4 不能为零。
+// Ct4 = s[α]T + [m]T 中的明文 m 可以是任意 GT 元素，因此 Ct4 不做子群检查。
+//
+// 返回值:
+//   - error: 分量不合法时返回指明分量、并包装 serialization.ErrInvalidG1Point 或 serialization.ErrInvalidGTElement 的错误
+func (c *Ciphertext) Validate() error {
+	for i, p := range []*bn254.G1Affine{&c.Ct1, &c.Ct2, &c.Ct3} {
+		if err := serialization.ValidateG1(p); err != nil {
+			return fmt.Errorf("invalid ciphertext: Ct%d: %w", i+1, err)
+		}
+	}
+	if c.Ct4.IsZero() {
+		return fmt.Errorf("invalid ciphertext: Ct4: zero element: %w", serialization.ErrInvalidGTElement)
+	}
+	return nil
+}
+
+// pairsToOne 判断 ∏ e(p[i], q[i]) 是否为单位元
+func pairsToOne(p []bn254.G1Affine, q []bn254.G2Affine) (bool, error) {
+	product, err := metrics.Pair(p, q)
+	if err != nil {
+		return false, err
+	}
+	return product.IsOne(), nil
+}
@@ -0,0 +1,71 @@
+package gwww25_bibe
+
+import (
+	"errors"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/mmsyan/GoPairingBasedCryptography/internal/invalidpoints"
+	"github.com/mmsyan/GoPairingBasedCryptography/serialization"
+	"slices"
+	"testing"
+)
+
+// TestMasterPublicKeyValidate checks that KeyGen output validates and that malformed or inconsistent components are rejected
+func TestMasterPublicKeyValidate(t *testing.T) {
+	params, _ := Setup(4)
+	mpk, _, err := KeyGen(params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := mpk.Validate(); err != nil {
+		t.Fatalf("valid master public key rejected: %v", err)
+	}
+
+	tamper := func(f func(pk *MasterPublicKey)) *MasterPublicKey {
+		pk := *mpk
+		pk.G2ExpTauPowers = slices.Clone(mpk.G2ExpTauPowers)
+		f(&pk)
+		return &pk
+	}
+	_, _, g1, g2 := bn254.Generators()
+	cases := []struct {
+		name string
+		pk   *MasterPublicKey
+		want error
+	}{
+		{"non-subgroup [τ^2]2", tamper(func(pk *MasterPublicKey) { pk.G2ExpTauPowers[1] = invalidpoints.NonSubgroupG2() }), serialization.ErrInvalidG2Point},
+		{"infinity [h]1", tamper(func(pk *MasterPublicKey) { pk.G1ExpH.SetInfinity() }), serialization.ErrInvalidG1Point},
+		{"identity [α]T", tamper(func(pk *MasterPublicKey) { pk.GTExpAlpha.SetOne() }), serialization.ErrInvalidGTElement},
+		{"replaced [τ^3]2", tamper(func(pk *MasterPublicKey) { pk.G2ExpTauPowers[2] = g2 }), ErrInconsistentPublicKey},
+		{"replaced [wτ]1", tamper(func(pk *MasterPublicKey) { pk.G1ExpWTau = g1 }), ErrInconsistentPublicKey},
+		{"empty sequence", tamper(func(pk *MasterPublicKey) { pk.G2ExpTauPowers = nil }), ErrInconsistentPublicKey},
+	}
+	for _, tc := range cases {
+		if err := tc.pk.Validate(); !errors.Is(err, tc.want) {
+			t.Errorf("%s: expected %v, got %v", tc.name, tc.want, err)
+		}
+	}
+}
+
+// TestCiphertextValidate checks that Encrypt output validates and that an infinity G1 component or a zero Ct4 is rejected
+func TestCiphertextValidate(t *testing.T) {
+	params, _ := Setup(4)
+	mpk, _, _ := KeyGen(params)
+	m, _ := new(bn254.GT).SetRandom()
+	ct, err := Encrypt(mpk, &Message{M: *m}, NewIdentity(7), NewBatchLabel(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ct.Validate(); err != nil {
+		t.Fatalf("valid ciphertext rejected: %v", err)
+	}
+
+	infinity, zero := *ct, *ct
+	infinity.Ct2.SetInfinity()
+	zero.Ct4 = bn254.GT{}
+	if err := infinity.Validate(); !errors.Is(err, serialization.ErrInvalidG1Point) {
+		t.Errorf("infinity Ct2: expected ErrInvalidG1Point, got %v", err)
+	}
+	if err := zero.Validate(); !errors.Is(err, serialization.ErrInvalidGTElement) {
+		t.Errorf("zero Ct4: expected ErrInvalidGTElement, got %v", err)
+	}
+}
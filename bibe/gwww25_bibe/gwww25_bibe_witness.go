@@ -0,0 +1,142 @@
+package gwww25_bibe
+
+import (
+	"errors"
+	"fmt"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/mmsyan/GoPairingBasedCryptography/metrics"
+	"math/big"
+)
+
+// ErrInvalidWitness 表示成员见证与批量摘要不一致（通常意味着持有者不是该批次的成员）
+var ErrInvalidWitness = errors.New("membership witness does not match digest")
+
+// AccumulatedDigest 是批量摘要的累加器形式：除摘要本身外，还保留身份集合，
+// 以便为每个成员签发简短的成员见证。
+//
+// 摘要 [f(τ)]2（f(X) = ∏(X - id)）本身就是一个双线性累加器，成员 id 的见证是
+// π = [f(τ)/(τ - id)]2，只有一个 G2 元素。Decrypt 需要的正是这个 π，
+// 因此由持有完整身份列表的一方（例如批次聚合者）为每个成员计算一次见证，
+// 解密方只需出示自己的见证，而不必拿到整个身份列表。
+// 这里没有采用 Merkle 树：ComputeKey 把密钥绑定在 [f(τ)]2 上，Merkle 根无法参与配对验证。
+type AccumulatedDigest struct {
+	digest     BatchDigest
+	identities []fr.Element
+}
+
+// MembershipWitness 是成员 Id 在某个批量摘要中的成员见证 Pi = [f(τ)/(τ - Id)]2
+type MembershipWitness struct {
+	Id fr.Element
+	Pi bn254.G2Affine
+}
+
+// DigestAccumulated 计算身份集合的批量摘要，并保留身份集合用于签发成员见证。
+// 摘要与 Digest 的结果相同，可直接交给 ComputeKey。
+//
+// 参数:
+//   - mpk: 主公钥
+//   - identities: 批量身份列表
+//
+// 返回值:
+//   - *AccumulatedDigest: 累加器形式的摘要
+//   - error: 身份列表为空、超过批量大小或含重复身份时返回错误
+func DigestAccumulated(mpk *MasterPublicKey, identities []*Identity) (*AccumulatedDigest, error) {
+	if len(identities) == 0 {
+		return nil, fmt.Errorf("identities is empty")
+	}
+	if len(identities) > len(mpk.G2ExpTauPowers) {
+		return nil, fmt.Errorf("too many identities for batch size: %w", ErrBatchTooLarge)
+	}
+	ids := make([]fr.Element, len(identities))
+	seen := make(map[fr.Element]struct{}, len(identities))
+	for i, identity := range identities {
+		if _, ok := seen[identity.Id]; ok {
+			return nil, fmt.Errorf("duplicate identity in batch")
+		}
+		seen[identity.Id] = struct{}{}
+		ids[i] = identity.Id
+	}
+	coef := computePolynomialCoeffs(identities)
+	return &AccumulatedDigest{
+		digest:     BatchDigest{D: computeG2PolynomialTau(mpk.G2ExpTauPowers, coef)},
+		identities: ids,
+	}, nil
+}
+
+// BatchDigest 返回累加器对应的批量摘要，供 ComputeKey 使用
+func (a *AccumulatedDigest) BatchDigest() *BatchDigest {
+	d := a.digest
+	return &d
+}
+
+// MembershipWitness 为批次成员 id 计算成员见证。
+//
+// 参数:
+//   - mpk: 主公钥
+//   - id: 批次成员的身份
+//
+// 返回值:
+//   - *MembershipWitness: 成员见证
+//   - error: id 不在批次中时返回错误
+func (a *AccumulatedDigest) MembershipWitness(mpk *MasterPublicKey, id *Identity) (*MembershipWitness, error) {
+	rootsWithoutId := make([]*Identity, 0, len(a.identities))
+	for i := range a.identities {
+		if !a.identities[i].Equal(&id.Id) {
+			rootsWithoutId = append(rootsWithoutId, &Identity{Id: a.identities[i]})
+		}
+	}
+	if len(rootsWithoutId) == len(a.identities) {
+		return nil, fmt.Errorf("identity not found in identity list")
+	}
+	qCoef := computePolynomialCoeffs(rootsWithoutId)
+	return &MembershipWitness{
+		Id: id.Id,
+		Pi: computeG2PolynomialTau(mpk.G2ExpTauPowers, qCoef),
+	}, nil
+}
+
+// VerifyMembershipWitness 检查 e([τ]1 - Id·[1]1, Pi) = e([1]1, D)，即 Pi 确实是 Id 在摘要 D 中的见证。
+//
+// 参数:
+//   - mpk: 主公钥
+//   - digest: 批量摘要
+//   - witness: 成员见证
+//
+// 返回值:
+//   - error: 见证不成立时返回包装 ErrInvalidWitness 的错误
+func VerifyMembershipWitness(mpk *MasterPublicKey, digest *BatchDigest, witness *MembershipWitness) error {
+	_, _, g1, _ := bn254.Generators()
+	var g1ExpId, g1ExpTauMinusId, negG1 bn254.G1Affine
+	g1ExpId.ScalarMultiplicationBase(witness.Id.BigInt(new(big.Int)))
+	g1ExpTauMinusId.Sub(&mpk.G1ExpTau, &g1ExpId)
+	negG1.Neg(&g1)
+	check, err := metrics.Pair([]bn254.G1Affine{g1ExpTauMinusId, negG1}, []bn254.G2Affine{witness.Pi, digest.D})
+	if err != nil {
+		return fmt.Errorf("failed to verify membership witness: %w", err)
+	}
+	if !check.IsOne() {
+		return ErrInvalidWitness
+	}
+	return nil
+}
+
+// DecryptWithWitness 使用成员见证代替完整身份列表进行解密。
+// 见证先与摘要做配对验证，因此非成员无法凭借伪造或他人的见证解密。
+//
+// 参数:
+//   - mpk: 主公钥
+//   - sk: 批次密钥（由 ComputeKey 针对 digest 生成）
+//   - digest: 批量摘要
+//   - witness: 解密方的成员见证
+//   - ct: 发给 witness.Id 的密文
+//
+// 返回值:
+//   - *Message: 解密后的消息
+//   - error: 见证无效或配对计算失败时返回错误
+func DecryptWithWitness(mpk *MasterPublicKey, sk *SecretKey, digest *BatchDigest, witness *MembershipWitness, ct *Ciphertext) (*Message, error) {
+	if err := VerifyMembershipWitness(mpk, digest, witness); err != nil {
+		return nil, err
+	}
+	return decryptWithPi(sk, witness.Pi, ct)
+}
@@ -0,0 +1,81 @@
+package gwww25_bibe
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDecryptWithWitness(t *testing.T) {
+	params, err := Setup(8)
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+	mpk, msk, err := KeyGen(params)
+	if err != nil {
+		t.Fatalf("KeyGen failed: %v", err)
+	}
+	batchLabel := NewBatchLabel(7)
+	identities := []*Identity{NewIdentity(11), NewIdentity(22), NewIdentity(33), NewIdentity(44)}
+
+	acc, err := DigestAccumulated(mpk, identities)
+	if err != nil {
+		t.Fatalf("DigestAccumulated failed: %v", err)
+	}
+	digest, err := Digest(mpk, identities)
+	if err != nil {
+		t.Fatalf("Digest failed: %v", err)
+	}
+	if !acc.BatchDigest().D.Equal(&digest.D) {
+		t.Fatal("accumulated digest differs from Digest")
+	}
+	sk, err := ComputeKey(msk, acc.BatchDigest(), batchLabel)
+	if err != nil {
+		t.Fatalf("ComputeKey failed: %v", err)
+	}
+
+	for _, id := range identities {
+		msg, err := NewRandomMessage()
+		if err != nil {
+			t.Fatalf("NewRandomMessage failed: %v", err)
+		}
+		ct, err := Encrypt(mpk, msg, id, batchLabel)
+		if err != nil {
+			t.Fatalf("Encrypt failed: %v", err)
+		}
+		witness, err := acc.MembershipWitness(mpk, id)
+		if err != nil {
+			t.Fatalf("MembershipWitness failed: %v", err)
+		}
+		decrypted, err := DecryptWithWitness(mpk, sk, acc.BatchDigest(), witness, ct)
+		if err != nil {
+			t.Fatalf("DecryptWithWitness failed: %v", err)
+		}
+		if !msg.M.Equal(&decrypted.M) {
+			t.Error("Decrypted message does not match original message")
+		}
+	}
+
+	// 非成员拿不到见证；挪用成员的见证、或使用其他批次的见证都无法通过验证
+	outsider := NewIdentity(55)
+	if _, err := acc.MembershipWitness(mpk, outsider); err == nil {
+		t.Fatal("MembershipWitness should fail for a non-member")
+	}
+	msg, _ := NewRandomMessage()
+	ct, err := Encrypt(mpk, msg, outsider, batchLabel)
+	if err != nil {
+		t.Fatalf("Encrypt failed: %v", err)
+	}
+	memberWitness, _ := acc.MembershipWitness(mpk, identities[0])
+	stolen := &MembershipWitness{Id: outsider.Id, Pi: memberWitness.Pi}
+	if _, err := DecryptWithWitness(mpk, sk, acc.BatchDigest(), stolen, ct); !errors.Is(err, ErrInvalidWitness) {
+		t.Fatalf("stolen witness should be rejected with ErrInvalidWitness, got %v", err)
+	}
+	otherBatch, err := DigestAccumulated(mpk, []*Identity{outsider, NewIdentity(66)})
+	if err != nil {
+		t.Fatalf("DigestAccumulated failed: %v", err)
+	}
+	foreign, _ := otherBatch.MembershipWitness(mpk, outsider)
+	if _, err := DecryptWithWitness(mpk, sk, acc.BatchDigest(), foreign, ct); !errors.Is(err, ErrInvalidWitness) {
+		t.Fatalf("witness from another batch should be rejected with ErrInvalidWitness, got %v", err)
+	}
+}
@@ -0,0 +1,286 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	mrand "math/rand"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/mmsyan/GoPairingBasedCryptography/ibe/bb04_ibe"
+	"github.com/mmsyan/GoPairingBasedCryptography/ibe/bb04_sibe"
+	"github.com/mmsyan/GoPairingBasedCryptography/ibe/bf01_ibe"
+	"github.com/mmsyan/GoPairingBasedCryptography/ibe/gentry06_cpa_ibe"
+	"github.com/mmsyan/GoPairingBasedCryptography/ibe/gentry06_ibe"
+	"github.com/mmsyan/GoPairingBasedCryptography/ibe/waters05_ibe"
+)
+
+// bf01MessageSize 是 BF01 基准测试使用的明文长度（字节）
+const bf01MessageSize = 32
+
+// ibeSchemes 是参与基准测试的 IBE 方案
+var ibeSchemes = []scheme{
+	{name: "bb04", newRound: bb04Round},
+	{name: "bb04s", newRound: bb04sRound},
+	{name: "bf01", newRound: bf01Round},
+	{name: "gentry06cpa", newRound: gentry06CPARound},
+	{name: "gentry06", newRound: gentry06Round},
+	{name: "waters05", newRound: waters05Round},
+}
+
+// randomGT 由 rng 导出 m = e(g1^s, g2)，保证相同 seed 下明文一致
+func randomGT(rng *mrand.Rand) bn254.GT {
+	seed := make([]byte, fr.Bytes)
+	rng.Read(seed)
+	var s fr.Element
+	s.SetBytes(seed)
+	_, _, _, g2 := bn254.Generators()
+	g1ExpS := new(bn254.G1Affine).ScalarMultiplicationBase(s.BigInt(new(big.Int)))
+	m, err := bn254.Pair([]bn254.G1Affine{*g1ExpS}, []bn254.G2Affine{g2})
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// checkGT 检查解密结果与原始明文是否一致
+func checkGT(got, want *bn254.GT) error {
+	if !got.Equal(want) {
+		return fmt.Errorf("decrypted message does not match")
+	}
+	return nil
+}
+
+func bb04Round(identity []byte, rng *mrand.Rand) *round {
+	var (
+		instance *bb04_ibe.BB04IBEInstance
+		pp       *bb04_ibe.BB04IBEPublicParams
+		id       *bb04_ibe.BB04IBEIdentity
+		sk       *bb04_ibe.BB04IBESecretKey
+		ct       *bb04_ibe.BB04IBECiphertext
+	)
+	message := &bb04_ibe.BB04IBEMessage{Message: randomGT(rng)}
+	return &round{
+		setup: func() (err error) {
+			if instance, err = bb04_ibe.NewBB04IBEInstance(); err != nil {
+				return err
+			}
+			pp, err = instance.SetUp()
+			return err
+		},
+		keyGenerate: func() (err error) {
+			if id, err = bb04_ibe.NewBB04IBEIdentity(string(identity)); err != nil {
+				return err
+			}
+			sk, err = instance.KeyGenerate(id, pp)
+			return err
+		},
+		encrypt: func() (err error) {
+			ct, err = instance.Encrypt(id, message, pp)
+			return err
+		},
+		decrypt: func() error {
+			m, err := instance.Decrypt(ct, sk, pp)
+			if err != nil {
+				return err
+			}
+			return checkGT(&m.Message, &message.Message)
+		},
+	}
+}
+
+func bb04sRound(identity []byte, rng *mrand.Rand) *round {
+	var (
+		instance *bb04_sibe.BB04sIBEInstance
+		pp       *bb04_sibe.BB04sIBEPublicParams
+		id       *bb04_sibe.BB04sIBEIdentity
+		sk       *bb04_sibe.BB04sIBESecretKey
+		ct       *bb04_sibe.BB04sIBECiphertext
+	)
+	message := &bb04_sibe.BB04sIBEMessage{Message: randomGT(rng)}
+	return &round{
+		setup: func() (err error) {
+			if instance, err = bb04_sibe.NewBB04sIBEInstance(); err != nil {
+				return err
+			}
+			pp, err = instance.SetUp()
+			return err
+		},
+		keyGenerate: func() (err error) {
+			if id, err = bb04_sibe.NewBB04sIBEIdentity(new(big.Int).SetBytes(identity)); err != nil {
+				return err
+			}
+			sk, err = instance.KeyGenerate(id, pp)
+			return err
+		},
+		encrypt: func() (err error) {
+			ct, err = instance.Encrypt(message, id, pp)
+			return err
+		},
+		decrypt: func() error {
+			m, err := instance.Decrypt(ct, sk, pp)
+			if err != nil {
+				return err
+			}
+			return checkGT(&m.Message, &message.Message)
+		},
+	}
+}
+
+func bf01Round(identity []byte, rng *mrand.Rand) *round {
+	var (
+		instance *bf01_ibe.BFIBEInstance
+		pp       *bf01_ibe.BFIBEPublicParams
+		id       *bf01_ibe.BFIBEIdentity
+		sk       *bf01_ibe.BFIBESecretKey
+		ct       *bf01_ibe.BFIBECiphertext
+	)
+	plain := make([]byte, bf01MessageSize)
+	rng.Read(plain)
+	message := &bf01_ibe.BFIBEMessage{Message: plain}
+	return &round{
+		setup: func() (err error) {
+			if instance, err = bf01_ibe.NewBFIBEInstance(); err != nil {
+				return err
+			}
+			pp, err = instance.SetUp()
+			return err
+		},
+		keyGenerate: func() (err error) {
+			if id, err = bf01_ibe.NewBF01Identity(string(identity)); err != nil {
+				return err
+			}
+			sk, err = instance.KeyGenerate(id, pp)
+			return err
+		},
+		encrypt: func() (err error) {
+			ct, err = instance.Encrypt(id, message, pp)
+			return err
+		},
+		decrypt: func() error {
+			m, err := instance.Decrypt(ct, sk, pp)
+			if err != nil {
+				return err
+			}
+			if !bytes.Equal(m.Message, message.Message) {
+				return fmt.Errorf("decrypted message does not match")
+			}
+			return nil
+		},
+	}
+}
+
+func gentry06CPARound(identity []byte, rng *mrand.Rand) *round {
+	var (
+		instance *gentry06_cpa_ibe.Gentry06CPAIBEInstance
+		pp       *gentry06_cpa_ibe.Gentry06CPAIBEPublicParams
+		id       *gentry06_cpa_ibe.Gentry06CPAIBEIdentity
+		sk       *gentry06_cpa_ibe.Gentry06CPAIBESecretKey
+		ct       *gentry06_cpa_ibe.Gentry06CPAIBECiphertext
+	)
+	message := &gentry06_cpa_ibe.Gentry06CPAIBEMessage{Message: randomGT(rng)}
+	return &round{
+		setup: func() (err error) {
+			if instance, err = gentry06_cpa_ibe.NewGentry06CPAIBEInstance(); err != nil {
+				return err
+			}
+			pp, err = instance.SetUp()
+			return err
+		},
+		keyGenerate: func() (err error) {
+			if id, err = gentry06_cpa_ibe.NewGentry06CPAIBEIdentity(new(big.Int).SetBytes(identity)); err != nil {
+				return err
+			}
+			sk, err = instance.KeyGenerate(id, pp)
+			return err
+		},
+		encrypt: func() (err error) {
+			ct, err = instance.Encrypt(message, id, pp)
+			return err
+		},
+		decrypt: func() error {
+			m, err := instance.Decrypt(ct, sk, pp)
+			if err != nil {
+				return err
+			}
+			return checkGT(&m.Message, &message.Message)
+		},
+	}
+}
+
+func gentry06Round(identity []byte, rng *mrand.Rand) *round {
+	var (
+		instance *gentry06_ibe.Gentry06IBEInstance
+		pp       *gentry06_ibe.Gentry06IBEPublicParams
+		id       *gentry06_ibe.Gentry06IBEIdentity
+		sk       *gentry06_ibe.Gentry06IBESecretKey
+		ct       *gentry06_ibe.Gentry06IBECiphertext
+	)
+	message := &gentry06_ibe.Gentry06IBEMessage{Message: randomGT(rng)}
+	return &round{
+		setup: func() (err error) {
+			if instance, err = gentry06_ibe.NewGentry06IBEInstance(); err != nil {
+				return err
+			}
+			pp, err = instance.SetUp()
+			return err
+		},
+		keyGenerate: func() (err error) {
+			if id, err = gentry06_ibe.NewGentry06IBEIdentity(new(big.Int).SetBytes(identity)); err != nil {
+				return err
+			}
+			sk, err = instance.KeyGenerate(id, pp)
+			return err
+		},
+		encrypt: func() (err error) {
+			ct, err = instance.Encrypt(message, id, pp)
+			return err
+		},
+		decrypt: func() error {
+			m, err := instance.Decrypt(ct, sk, pp)
+			if err != nil {
+				return err
+			}
+			return checkGT(&m.Message, &message.Message)
+		},
+	}
+}
+
+func waters05Round(identity []byte, rng *mrand.Rand) *round {
+	var (
+		instance *waters05_ibe.Waters05IBEInstance
+		pp       *waters05_ibe.Waters05IBEPublicParams
+		id       *waters05_ibe.Waters05IBEIdentity
+		sk       *waters05_ibe.Waters05IBESecretKey
+		ct       *waters05_ibe.Waters05IBECiphertext
+	)
+	message := &waters05_ibe.Waters05IBEMessage{Message: randomGT(rng)}
+	return &round{
+		setup: func() (err error) {
+			if instance, err = waters05_ibe.NewWaters05IBEInstance(); err != nil {
+				return err
+			}
+			pp, err = instance.SetUp()
+			return err
+		},
+		keyGenerate: func() (err error) {
+			if id, err = waters05_ibe.NewWaters05IBEIdentity(string(identity)); err != nil {
+				return err
+			}
+			sk, err = instance.KeyGenerate(id, pp)
+			return err
+		},
+		encrypt: func() (err error) {
+			ct, err = instance.Encrypt(message, id, pp)
+			return err
+		},
+		decrypt: func() error {
+			m, err := instance.Decrypt(ct, sk, pp)
+			if err != nil {
+				return err
+			}
+			return checkGT(&m.Message, &message.Message)
+		},
+	}
+}
@@ -0,0 +1,198 @@
+// Command benchmark 对各方案的 Setup/KeyGenerate/Encrypt/Decrypt 进行计时，并以 CSV 格式输出结果。
+//
+// 输出列为 scheme, operation, param, ns/op, pairings，其中 param 为身份长度（字节），
+// pairings 为每次操作的平均配对个数（多重配对按输入对数计），仅在使用 `-tags metrics` 构建时有效，否则恒为 0。
+//
+// 用法:
+//
+//	go run -tags metrics ./cmd/benchmark -schemes bf01,waters05 -params 16,64,256 -n 20 -seed 1 -o ibe.csv
+//
+// -seed 决定每轮使用的身份与明文，因此相同 seed 下各方案处理的输入完全一致；
+// 方案内部的随机数（主密钥、加密随机数等）仍来自 crypto/rand。
+//
+// 目前覆盖 IBE 系列方案: bb04, bb04s, bf01, gentry06cpa, gentry06, waters05。
+package main
+
+import (
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"io"
+	mrand "math/rand"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mmsyan/GoPairingBasedCryptography/metrics"
+)
+
+// operations 是每轮依次执行并计时的操作
+var operations = []string{"Setup", "KeyGenerate", "Encrypt", "Decrypt"}
+
+// round 表示一次完整的 Setup/KeyGenerate/Encrypt/Decrypt 流程，各步骤依次共享状态
+type round struct {
+	setup       func() error
+	keyGenerate func() error
+	encrypt     func() error
+	decrypt     func() error
+}
+
+// scheme 描述一个可基准测试的方案
+type scheme struct {
+	name string
+	// newRound 以给定身份与随机源构造一轮流程，rng 仅用于生成明文
+	newRound func(identity []byte, rng *mrand.Rand) *round
+}
+
+// result 表示某方案某操作在某参数下的统计结果
+type result struct {
+	scheme    string
+	operation string
+	param     int
+	nsPerOp   int64
+	pairings  int64
+}
+
+func main() {
+	schemesFlag := flag.String("schemes", "", "comma separated scheme names, empty for all")
+	paramsFlag := flag.String("params", "32", "comma separated identity lengths in bytes")
+	n := flag.Int("n", 10, "iterations per scheme and parameter")
+	seed := flag.Int64("seed", 1, "seed for identities and messages")
+	output := flag.String("o", "", "output CSV file, empty for stdout")
+	flag.Parse()
+
+	selected, err := selectSchemes(*schemesFlag)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	params, err := parseParams(*paramsFlag)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	var w io.Writer = os.Stdout
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	if err := run(w, selected, params, *n, *seed); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// run 对每个方案与参数执行 n 轮流程并写出 CSV
+func run(w io.Writer, selected []scheme, params []int, n int, seed int64) error {
+	if n <= 0 {
+		return fmt.Errorf("iterations must be positive")
+	}
+	out := csv.NewWriter(w)
+	if err := out.Write([]string{"scheme", "operation", "param", "ns/op", "pairings"}); err != nil {
+		return fmt.Errorf("failed to write csv header: %w", err)
+	}
+	for _, s := range selected {
+		for _, param := range params {
+			results, err := measure(s, param, n, seed)
+			if err != nil {
+				return err
+			}
+			for _, r := range results {
+				record := []string{
+					r.scheme,
+					r.operation,
+					strconv.Itoa(r.param),
+					strconv.FormatInt(r.nsPerOp, 10),
+					strconv.FormatInt(r.pairings, 10),
+				}
+				if err := out.Write(record); err != nil {
+					return fmt.Errorf("failed to write csv record: %w", err)
+				}
+			}
+		}
+	}
+	out.Flush()
+	return out.Error()
+}
+
+// measure 对单个方案与参数执行 n 轮流程，返回各操作的平均耗时与平均配对次数
+func measure(s scheme, param, n int, seed int64) ([]result, error) {
+	rng := mrand.New(mrand.NewSource(seed))
+	totalNs := make([]int64, len(operations))
+	totalPairings := make([]int64, len(operations))
+
+	for i := 0; i < n; i++ {
+		identity := make([]byte, param)
+		rng.Read(identity)
+		r := s.newRound(identity, rng)
+		steps := []func() error{r.setup, r.keyGenerate, r.encrypt, r.decrypt}
+		for j, step := range steps {
+			metrics.ResetPairingCount()
+			start := time.Now()
+			if err := step(); err != nil {
+				return nil, fmt.Errorf("%s %s failed: %w", s.name, operations[j], err)
+			}
+			totalNs[j] += time.Since(start).Nanoseconds()
+			totalPairings[j] += metrics.PairingCount()
+		}
+	}
+
+	results := make([]result, len(operations))
+	for j, op := range operations {
+		results[j] = result{
+			scheme:    s.name,
+			operation: op,
+			param:     param,
+			nsPerOp:   totalNs[j] / int64(n),
+			pairings:  totalPairings[j] / int64(n),
+		}
+	}
+	return results, nil
+}
+
+// selectSchemes 按名称选取方案，names 为空时返回全部方案
+func selectSchemes(names string) ([]scheme, error) {
+	if names == "" {
+		return ibeSchemes, nil
+	}
+	var selected []scheme
+	for _, name := range strings.Split(names, ",") {
+		name = strings.TrimSpace(name)
+		found := false
+		for _, s := range ibeSchemes {
+			if s.name == name {
+				selected = append(selected, s)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown scheme %q", name)
+		}
+	}
+	return selected, nil
+}
+
+// parseParams 解析以逗号分隔的身份长度列表
+func parseParams(params string) ([]int, error) {
+	var result []int
+	for _, p := range strings.Split(params, ",") {
+		v, err := strconv.Atoi(strings.TrimSpace(p))
+		if err != nil {
+			return nil, fmt.Errorf("invalid param %q: %w", p, err)
+		}
+		if v <= 0 {
+			return nil, fmt.Errorf("invalid param %q: identity length must be positive", p)
+		}
+		result = append(result, v)
+	}
+	return result, nil
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+)
+
+// TestRunIBESchemes 测试所有 IBE 方案均能完成一轮流程并输出 CSV
+func TestRunIBESchemes(t *testing.T) {
+	var buf bytes.Buffer
+	if err := run(&buf, ibeSchemes, []int{8}, 1, 1); err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to parse csv: %v", err)
+	}
+	want := 1 + len(ibeSchemes)*len(operations)
+	if len(records) != want {
+		t.Fatalf("got %d csv records, want %d", len(records), want)
+	}
+	if records[0][0] != "scheme" || records[0][3] != "ns/op" || records[0][4] != "pairings" {
+		t.Errorf("unexpected csv header: %v", records[0])
+	}
+}
+
+// TestSelectSchemes 测试方案选择与参数解析
+func TestSelectSchemes(t *testing.T) {
+	selected, err := selectSchemes("bf01, waters05")
+	if err != nil {
+		t.Fatalf("selectSchemes failed: %v", err)
+	}
+	if len(selected) != 2 || selected[0].name != "bf01" || selected[1].name != "waters05" {
+		t.Errorf("unexpected schemes selected: %v", selected)
+	}
+	if _, err := selectSchemes("unknown"); err == nil {
+		t.Error("expected error for unknown scheme")
+	}
+
+	params, err := parseParams("16,64")
+	if err != nil || len(params) != 2 || params[0] != 16 || params[1] != 64 {
+		t.Errorf("parseParams() = %v, %v", params, err)
+	}
+	if _, err := parseParams("0"); err == nil {
+		t.Error("expected error for non-positive param")
+	}
+}
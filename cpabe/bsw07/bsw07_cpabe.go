@@ -5,6 +5,7 @@ import (
 	"github.com/consensys/gnark-crypto/ecc/bn254"
 	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
 	"github.com/mmsyan/GoPairingBasedCryptography/access/tree"
+	"github.com/mmsyan/GoPairingBasedCryptography/metrics"
 	"github.com/mmsyan/GoPairingBasedCryptography/utils"
 	"math/big"
 )
@@ -39,6 +40,7 @@ type CPABEUserSecretKey struct {
 
 type CPABEMessage struct {
 	Message bn254.GT
+	sealed  []byte // SetBytes 以会话密钥加密的字节数据
 }
 
 type CPABEAccessPolicy struct {
@@ -58,11 +60,11 @@ func (instance *CPABEInstance) SetUp() (*CPABEPublicParameters, *CPABEMasterSecr
 	// alpha, beta <- Zq
 	alpha, err := new(fr.Element).SetRandom()
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to set up: %v", err)
+		return nil, nil, fmt.Errorf("failed to set up: %w", err)
 	}
 	beta, err := new(fr.Element).SetRandom()
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to set up: %v", err)
+		return nil, nil, fmt.Errorf("failed to set up: %w", err)
 	}
 
 	// h = g1^beta
@@ -72,9 +74,9 @@ func (instance *CPABEInstance) SetUp() (*CPABEPublicParameters, *CPABEMasterSecr
 	inverseBeta := new(fr.Element).Inverse(beta)
 	f := new(bn254.G2Affine).ScalarMultiplicationBase(inverseBeta.BigInt(new(big.Int)))
 
-	eG1G2, err := bn254.Pair([]bn254.G1Affine{g1}, []bn254.G2Affine{g2})
+	eG1G2, err := metrics.Pair([]bn254.G1Affine{g1}, []bn254.G2Affine{g2})
 	if err != nil {
-		return nil, nil, fmt.Errorf("error pairing : %v", err)
+		return nil, nil, fmt.Errorf("error pairing : %w", err)
 	}
 	// e(g1, g2)^alpha
 	eG1G2ExpAlpha := new(bn254.GT).Exp(eG1G2, alpha.BigInt(new(big.Int)))
@@ -97,7 +99,7 @@ func (instance *CPABEInstance) SetUp() (*CPABEPublicParameters, *CPABEMasterSecr
 func (instance *CPABEInstance) KeyGenerate(attr *CPABEUserAttributes, msk *CPABEMasterSecretKey) (*CPABEUserSecretKey, error) {
 	r, err := new(fr.Element).SetRandom()
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate user key: %v", err)
+		return nil, fmt.Errorf("failed to generate user key: %w", err)
 	}
 	// D = g2^((alpha+r)/beta)
 	g2ExpR := new(bn254.G2Affine).ScalarMultiplicationBase(r.BigInt(new(big.Int)))                   // g2^r
@@ -111,7 +113,7 @@ func (instance *CPABEInstance) KeyGenerate(attr *CPABEUserAttributes, msk *CPABE
 	for _, j := range attr.Attributes {
 		rj, err := new(fr.Element).SetRandom()
 		if err != nil {
-			return nil, fmt.Errorf("error setting random: %v", err)
+			return nil, fmt.Errorf("error setting random: %w", err)
 		}
 		hj := Hash2BSw07(j)
 		hjExpRj := new(bn254.G2Affine).ScalarMultiplication(&hj, rj.BigInt(new(big.Int)))
@@ -123,7 +125,7 @@ func (instance *CPABEInstance) KeyGenerate(attr *CPABEUserAttributes, msk *CPABE
 
 	return &CPABEUserSecretKey{
 		r:          *r,
-		attributes: attr.Attributes,
+		attributes: utils.DedupAttributes(attr.Attributes),
 		d:          *d,
 		dj:         dj,
 		djPrime:    djPrime,
@@ -133,7 +135,7 @@ func (instance *CPABEInstance) KeyGenerate(attr *CPABEUserAttributes, msk *CPABE
 func (instance *CPABEInstance) Encrypt(message *CPABEMessage, accessPolicy *CPABEAccessPolicy, pp *CPABEPublicParameters) (*CPABECiphertext, error) {
 	s, err := new(fr.Element).SetRandom()
 	if err != nil {
-		return nil, fmt.Errorf("error setting random: %v", err)
+		return nil, fmt.Errorf("error setting random: %w", err)
 	}
 
 	// 🔧 修复1：生成叶子节点ID（必须在 ShareSecret 之前调用）
@@ -177,11 +179,11 @@ func (instance *CPABEInstance) Decrypt(ciphertext *CPABECiphertext, usk *CPABEUs
 	}
 	A := ciphertext.accessPolicy.accessTree.DecryptNode(attributesMap, usk.dj, usk.djPrime, ciphertext.cy, ciphertext.cyPrime, usk.r)
 	if A == nil {
-		return nil, fmt.Errorf("error decrypting message")
+		return nil, fmt.Errorf("error decrypting message: %w", ErrPolicyNotSatisfied)
 	}
 
 	// e(C, D)
-	eCD, err := bn254.Pair([]bn254.G1Affine{ciphertext.c}, []bn254.G2Affine{usk.d})
+	eCD, err := metrics.Pair([]bn254.G1Affine{ciphertext.c}, []bn254.G2Affine{usk.d})
 	if err != nil {
 		return nil, err
 	}
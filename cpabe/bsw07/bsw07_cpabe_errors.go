@@ -0,0 +1,6 @@
+package bsw07
+
+import "errors"
+
+// ErrPolicyNotSatisfied 表示用户属性不满足密文的访问策略，可用 errors.Is 判断
+var ErrPolicyNotSatisfied = errors.New("access policy is not satisfied")
@@ -0,0 +1,12 @@
+package bsw07
+
+import (
+	"github.com/mmsyan/GoPairingBasedCryptography/internal/msgtest"
+	"github.com/mmsyan/GoPairingBasedCryptography/serialization"
+	"testing"
+)
+
+// TestCPABEMessageBytes 检查 CPABEMessage 的 Bytes/SetBytes 往返
+func TestCPABEMessageBytes(t *testing.T) {
+	msgtest.CheckGTRoundTrip(t, func() serialization.Message { return new(CPABEMessage) })
+}
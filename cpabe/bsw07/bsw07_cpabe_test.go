@@ -1,6 +1,7 @@
 package bsw07
 
 import (
+	"errors"
 	"fmt"
 	"github.com/consensys/gnark-crypto/ecc/bn254"
 	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
@@ -129,6 +130,9 @@ func TestCPABEAttributeMismatch(t *testing.T) {
 	// 尝试解密 - 应该失败或返回错误结果
 	decryptedMessage, err := instance.Decrypt(ciphertext, usk)
 	if err != nil {
+		if !errors.Is(err, ErrPolicyNotSatisfied) {
+			t.Fatalf("❌ 错误应包装 ErrPolicyNotSatisfied: %v", err)
+		}
 		fmt.Println("✓ 解密失败（符合预期）:", err)
 		return
 	}
@@ -395,3 +399,110 @@ func TestAll(t *testing.T) {
 	t.Run("MaximalThreshold", TestCPABEMaximalThreshold)
 	t.Run("PartialMatch", TestCPABEPartialMatch)
 }
+
+// TestCPABESatisfiableAgreesWithDecrypt 验证 Satisfiable 的预判结果与实际能否解密一致
+// 访问策略: 3-of-{(1 OR 2), (3 OR 4), 5}
+func TestCPABESatisfiableAgreesWithDecrypt(t *testing.T) {
+	instance := &CPABEInstance{}
+	pp, msk, err := instance.SetUp()
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+
+	root := tree.NewThresholdNode(3,
+		tree.NewThresholdNode(1, tree.NewLeafNode(fr.NewElement(1)), tree.NewLeafNode(fr.NewElement(2))),
+		tree.NewThresholdNode(1, tree.NewLeafNode(fr.NewElement(3)), tree.NewLeafNode(fr.NewElement(4))),
+		tree.NewLeafNode(fr.NewElement(5)),
+	)
+	if got := len(root.AttributeSet()); got != 5 {
+		t.Fatalf("AttributeSet() returned %d attributes, want 5", got)
+	}
+
+	_, _, g1, g2 := bn254.Generators()
+	messageGT, _ := bn254.Pair([]bn254.G1Affine{g1}, []bn254.G2Affine{g2})
+	message := &CPABEMessage{Message: messageGT}
+	ciphertext, err := instance.Encrypt(message, &CPABEAccessPolicy{accessTree: root}, pp)
+	if err != nil {
+		t.Fatalf("Encrypt failed: %v", err)
+	}
+
+	testCases := []struct {
+		name  string
+		attrs []uint64
+		want  bool
+	}{
+		{"{1, 3, 5}", []uint64{1, 3, 5}, true},
+		{"{2, 4, 5}", []uint64{2, 4, 5}, true},
+		{"{1, 2, 3, 4}", []uint64{1, 2, 3, 4}, false},
+		{"{1, 5}", []uint64{1, 5}, false},
+		{"{5}", []uint64{5}, false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			attrs := make([]fr.Element, len(tc.attrs))
+			for i, a := range tc.attrs {
+				attrs[i] = fr.NewElement(a)
+			}
+			if got := root.Satisfiable(attrs); got != tc.want {
+				t.Fatalf("Satisfiable() = %v, want %v", got, tc.want)
+			}
+
+			usk, err := instance.KeyGenerate(&CPABEUserAttributes{Attributes: attrs}, msk)
+			if err != nil {
+				t.Fatalf("KeyGenerate failed: %v", err)
+			}
+			decrypted, err := instance.Decrypt(ciphertext, usk)
+			canDecrypt := err == nil && decrypted.Message.Equal(&message.Message)
+			if canDecrypt != tc.want {
+				t.Fatalf("decryptability = %v, Satisfiable = %v", canDecrypt, tc.want)
+			}
+		})
+	}
+}
+
+// TestCPABENotNode 验证否定属性叶子: 策略 1 AND (NOT 2)
+func TestCPABENotNode(t *testing.T) {
+	instance := &CPABEInstance{}
+	pp, msk, err := instance.SetUp()
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+
+	notTwo := tree.NewNotNode(tree.NewLeafNode(fr.NewElement(2)))
+	if !notTwo.IsNegated() || notTwo.Attribute != tree.NegateAttribute(fr.NewElement(2)) {
+		t.Fatal("NewNotNode should produce a negated leaf on NegateAttribute(2)")
+	}
+	root := tree.NewThresholdNode(2, tree.NewLeafNode(fr.NewElement(1)), notTwo)
+
+	_, _, g1, g2 := bn254.Generators()
+	messageGT, _ := bn254.Pair([]bn254.G1Affine{g1}, []bn254.G2Affine{g2})
+	message := &CPABEMessage{Message: messageGT}
+	ciphertext, err := instance.Encrypt(message, &CPABEAccessPolicy{accessTree: root}, pp)
+	if err != nil {
+		t.Fatalf("Encrypt failed: %v", err)
+	}
+
+	// 不持有属性 2 的用户由授权机构颁发否定属性 ¬2
+	allowed := []fr.Element{fr.NewElement(1), tree.NegateAttribute(fr.NewElement(2))}
+	// 持有属性 2 的用户不会获得 ¬2
+	denied := []fr.Element{fr.NewElement(1), fr.NewElement(2)}
+
+	if !root.Satisfiable(allowed) || root.Satisfiable(denied) {
+		t.Fatal("Satisfiable() mismatch for NOT policy")
+	}
+
+	usk, _ := instance.KeyGenerate(&CPABEUserAttributes{Attributes: allowed}, msk)
+	decrypted, err := instance.Decrypt(ciphertext, usk)
+	if err != nil || !decrypted.Message.Equal(&message.Message) {
+		t.Fatal("user holding ¬2 should decrypt")
+	}
+
+	usk, _ = instance.KeyGenerate(&CPABEUserAttributes{Attributes: denied}, msk)
+	if decrypted, err = instance.Decrypt(ciphertext, usk); err == nil && decrypted.Message.Equal(&message.Message) {
+		t.Fatal("user holding 2 should not decrypt")
+	}
+
+	if tree.NewNotNode(notTwo).Attribute != fr.NewElement(2) {
+		t.Fatal("double negation should restore the original attribute")
+	}
+}
@@ -17,17 +17,31 @@ package waters11
 //   - 密钥生成 (KeyGenerate)
 //   - 加密 (Encrypt)
 //   - 解密 (Decrypt)
+//   - 密文策略更新 (EncryptWithUpdateToken / UpdatePolicy)
+//   - 明文承诺 (EncryptWithCommitment / VerifyCommitment)
 
 import (
 	"fmt"
 	"github.com/consensys/gnark-crypto/ecc/bn254"
 	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
 	"github.com/mmsyan/GoPairingBasedCryptography/access/lsss"
+	"github.com/mmsyan/GoPairingBasedCryptography/metrics"
+	"github.com/mmsyan/GoPairingBasedCryptography/pairing"
+	"github.com/mmsyan/GoPairingBasedCryptography/utils"
 	"math/big"
+	"sync"
 )
 
 type Waters11CPABEInstance struct {
 	universe map[fr.Element]struct{}
+
+	// g1Tables 缓存加密时固定底 h_x 与 g1^a 的窗口预计算表，首次使用时构造，由 g1TablesMu 保护
+	g1TablesMu sync.Mutex
+	g1Tables   map[bn254.G1Affine]*pairing.FixedBaseG1
+
+	// auditor 记录每一次解密尝试，由 SetDecryptionAuditor 设置，为 nil 时不记录；由 auditorMu 保护
+	auditorMu sync.RWMutex
+	auditor   lsss.DecryptionAuditor
 }
 
 type Waters11CPABEPublicParameters struct {
@@ -59,14 +73,17 @@ type Waters11CPABEAccessPolicy struct {
 
 type Waters11CPABEMessage struct {
 	Message bn254.GT
+	sealed  []byte // SetBytes 以会话密钥加密的字节数据
 }
 
 type Waters11CPABECiphertext struct {
 	accessMatrix *lsss.LewkoWatersLsssMatrix
-	c            bn254.GT
-	cPrime       bn254.G2Affine
-	cx           []bn254.G1Affine
-	dx           []bn254.G2Affine
+	// policyFingerprint 是 EncryptByReference 生成的密文所引用的访问矩阵指纹，此时 accessMatrix 为 nil
+	policyFingerprint [32]byte
+	c                 bn254.GT
+	cPrime            bn254.G2Affine
+	cx                []bn254.G1Affine
+	dx                []bn254.G2Affine
 }
 
 // SetUp 执行 CP-ABE 方案的系统初始化，生成公共参数 (PP) 和主密钥 (MSK)。
@@ -83,17 +100,17 @@ func (instance *Waters11CPABEInstance) SetUp() (*Waters11CPABEPublicParameters,
 	_, _, g1, g2 := bn254.Generators()
 	alpha, err := new(fr.Element).SetRandom()
 	if err != nil {
-		return nil, nil, fmt.Errorf("could not set up alpha Waters11CPABEPublicParameters")
+		return nil, nil, fmt.Errorf("could not set up alpha Waters11CPABEPublicParameters: %w", err)
 	}
 	a, err := new(fr.Element).SetRandom()
 	if err != nil {
-		return nil, nil, fmt.Errorf("could not set up alpha Waters11CPABEPublicParameters")
+		return nil, nil, fmt.Errorf("could not set up alpha Waters11CPABEPublicParameters: %w", err)
 	}
 	g1ExpA := new(bn254.G1Affine).ScalarMultiplicationBase(a.BigInt(new(big.Int)))
 	g1ExpAlpha := new(bn254.G1Affine).ScalarMultiplicationBase(alpha.BigInt(new(big.Int)))
-	eG1G2, err := bn254.Pair([]bn254.G1Affine{g1}, []bn254.G2Affine{g2})
+	eG1G2, err := metrics.Pair([]bn254.G1Affine{g1}, []bn254.G2Affine{g2})
 	if err != nil {
-		return nil, nil, fmt.Errorf("could not set up alpha Waters11CPABEPublicParameters")
+		return nil, nil, fmt.Errorf("could not set up alpha Waters11CPABEPublicParameters: %w", err)
 	}
 	eG1G2ExpAlpha := new(bn254.GT).Exp(eG1G2, alpha.BigInt(new(big.Int)))
 
@@ -101,7 +118,7 @@ func (instance *Waters11CPABEInstance) SetUp() (*Waters11CPABEPublicParameters,
 	for u := range instance.universe {
 		temp, err := new(fr.Element).SetRandom()
 		if err != nil {
-			return nil, nil, fmt.Errorf("could not set up alpha Waters11CPABEPublicParameters")
+			return nil, nil, fmt.Errorf("could not set up alpha Waters11CPABEPublicParameters: %w", err)
 		}
 		h[u] = *new(bn254.G1Affine).ScalarMultiplicationBase(temp.BigInt(new(big.Int)))
 	}
@@ -133,14 +150,13 @@ func (instance *Waters11CPABEInstance) SetUp() (*Waters11CPABEPublicParameters,
 //   - *Waters11CPABEUserSecretKey: 生成的用户私钥
 //   - error: 如果属性不在宇宙中或随机数生成失败，返回错误信息
 func (instance *Waters11CPABEInstance) KeyGenerate(userAttributes *Waters11CPABEAttributes, msk *Waters11CPABEMasterSecretKey, pp *Waters11CPABEPublicParameters) (*Waters11CPABEUserSecretKey, error) {
-	check := instance.checkAttributes(userAttributes.Attributes)
-	if !check {
-		return nil, fmt.Errorf("failed to pass attribute check")
+	if err := instance.checkAttributes(userAttributes.Attributes); err != nil {
+		return nil, fmt.Errorf("failed to pass attribute check: %w", err)
 	}
 
-	t, err := new(fr.Element).SetRandom()
+	t, err := utils.RandomNonZeroScalar()
 	if err != nil {
-		return nil, fmt.Errorf("could not set up alpha Waters11CPABEPublicParameters")
+		return nil, fmt.Errorf("could not set up alpha Waters11CPABEPublicParameters: %w", err)
 	}
 	// g1^(at)
 	g1ExpAT := new(bn254.G1Affine).ScalarMultiplication(&pp.g1ExpA, t.BigInt(new(big.Int)))
@@ -156,7 +172,7 @@ func (instance *Waters11CPABEInstance) KeyGenerate(userAttributes *Waters11CPABE
 	}
 
 	return &Waters11CPABEUserSecretKey{
-		userAttributes: userAttributes.Attributes,
+		userAttributes: utils.DedupAttributes(userAttributes.Attributes),
 		k:              k,
 		l:              l,
 		kx:             kx,
@@ -174,19 +190,25 @@ func (instance *Waters11CPABEInstance) KeyGenerate(userAttributes *Waters11CPABE
 //   - *Waters11CPABECiphertext: 生成的密文
 //   - error: 如果加密失败，返回错误信息
 func (instance *Waters11CPABEInstance) Encrypt(message *Waters11CPABEMessage, accessPolicy *Waters11CPABEAccessPolicy, pp *Waters11CPABEPublicParameters) (*Waters11CPABECiphertext, error) {
-	check := instance.checkAttributes(accessPolicy.matrix.Attributes())
-	if !check {
-		return nil, fmt.Errorf("failed to pass attribute check. contains invalid ciphertext attributes")
+	ciphertext, _, err := instance.encrypt(message, accessPolicy, pp)
+	return ciphertext, err
+}
+
+// encrypt 实现 Encrypt，并额外返回加密使用的秘密指数 s，供 EncryptWithUpdateToken 生成策略更新令牌
+func (instance *Waters11CPABEInstance) encrypt(message *Waters11CPABEMessage, accessPolicy *Waters11CPABEAccessPolicy, pp *Waters11CPABEPublicParameters) (*Waters11CPABECiphertext, *fr.Element, error) {
+	if err := instance.checkAttributes(accessPolicy.matrix.Attributes()); err != nil {
+		return nil, nil, fmt.Errorf("failed to pass attribute check. contains invalid ciphertext attributes: %w", err)
 	}
 
 	n := accessPolicy.matrix.ColumnNumber()
+	l := accessPolicy.matrix.RowNumber()
 
-	cx := make([]bn254.G1Affine, n)
-	dx := make([]bn254.G2Affine, n)
+	cx := make([]bn254.G1Affine, l)
+	dx := make([]bn254.G2Affine, l)
 
-	s, err := new(fr.Element).SetRandom()
+	s, err := utils.RandomNonZeroScalar()
 	if err != nil {
-		return nil, fmt.Errorf("encrypt failed: %vectorV", err)
+		return nil, nil, fmt.Errorf("encrypt failed: %w", err)
 	}
 
 	// v = [s, r2, r3, ..., rn]
@@ -195,7 +217,7 @@ func (instance *Waters11CPABEInstance) Encrypt(message *Waters11CPABEMessage, ac
 	for i := 1; i < n; i++ {
 		vi, err := new(fr.Element).SetRandom()
 		if err != nil {
-			return nil, fmt.Errorf("encrypt failed: %v", err)
+			return nil, nil, fmt.Errorf("encrypt failed: %w", err)
 		}
 		vectorV[i] = *vi
 	}
@@ -208,22 +230,23 @@ func (instance *Waters11CPABEInstance) Encrypt(message *Waters11CPABEMessage, ac
 	// c' = g2^s
 	cPrime := new(bn254.G2Affine).ScalarMultiplicationBase(s.BigInt(new(big.Int)))
 
-	for i := 0; i < n; i++ {
-		ri, err := new(fr.Element).SetRandom()
+	g1ExpATable := instance.g1Table(pp.g1ExpA)
+	// 每一行都需要 (C_i, D_i)：行数多于列数时（例如门限策略）只处理前 n 行会使其余行无法参与解密
+	for i := 0; i < l; i++ {
+		ri, err := utils.RandomNonZeroScalar()
 		if err != nil {
-			return nil, fmt.Errorf("encrypt failed: %v", err)
+			return nil, nil, fmt.Errorf("encrypt failed: %w", err)
 		}
 		lambdaI := accessPolicy.matrix.ComputeVector(i, vectorV)
 		rhoI := accessPolicy.matrix.Rho(i)
 
 		// (g1^a)^lambdaI
-		g1ExpALambdaI := new(bn254.G1Affine).ScalarMultiplication(&pp.g1ExpA, lambdaI.BigInt(new(big.Int)))
-		hRhoI := pp.h[rhoI]
+		g1ExpALambdaI := g1ExpATable.ScalarMultiplication(&lambdaI)
 		negRi := new(fr.Element).Neg(ri)
 		// h_rho(i)^(-ri)
-		hRhoIExpNegRi := new(bn254.G1Affine).ScalarMultiplication(&hRhoI, negRi.BigInt(new(big.Int)))
+		hRhoIExpNegRi := instance.g1Table(pp.h[rhoI]).ScalarMultiplication(negRi)
 
-		cx[i] = *new(bn254.G1Affine).Add(g1ExpALambdaI, hRhoIExpNegRi)
+		cx[i] = *new(bn254.G1Affine).Add(&g1ExpALambdaI, &hRhoIExpNegRi)
 		dx[i] = *new(bn254.G2Affine).ScalarMultiplicationBase(ri.BigInt(new(big.Int)))
 	}
 
@@ -233,51 +256,76 @@ func (instance *Waters11CPABEInstance) Encrypt(message *Waters11CPABEMessage, ac
 		cx:           cx,
 		dx:           dx,
 		accessMatrix: accessPolicy.matrix,
-	}, nil
+	}, s, nil
 }
 
 // Decrypt 使用用户私钥对密文进行解密。
 // 仅当用户属性集S满足密文的访问策略时才能成功解密。
+// 无论成功与否，结果都会报告给 SetDecryptionAuditor 设置的审计回调。
 // 参数:
 //   - ciphertext: 要解密的密文
 //   - usk: 用户的私钥
 //
 // 返回值:
 //   - *Waters11CPABEMessage: 解密后的明文消息
-//   - error: 如果解密失败或属性不满足策略，返回错误信息
+//   - error: 如果解密失败或属性不满足策略，返回错误信息；密文只引用了策略指纹时返回 ErrPolicyDetached
 func (instance *Waters11CPABEInstance) Decrypt(ciphertext *Waters11CPABECiphertext, usk *Waters11CPABEUserSecretKey) (*Waters11CPABEMessage, error) {
-	// e(K, C')
-	eCPrimeK, err := bn254.Pair([]bn254.G1Affine{usk.k}, []bn254.G2Affine{ciphertext.cPrime})
-	if err != nil {
-		return nil, fmt.Errorf("decrypt failed: %v", err)
+	if ciphertext.accessMatrix == nil {
+		instance.audit(ciphertext, nil, ErrPolicyDetached)
+		return nil, fmt.Errorf("decrypt failed: %w", ErrPolicyDetached)
 	}
 	iSlice, wSlice := ciphertext.accessMatrix.FindLinearCombinationWeight(usk.userAttributes)
 	if iSlice == nil || wSlice == nil {
-		return nil, fmt.Errorf("decrypt failed: access policy is not satisfied")
+		instance.audit(ciphertext, nil, ErrPolicyNotSatisfied)
+		return nil, fmt.Errorf("decrypt failed: %w", ErrPolicyNotSatisfied)
+	}
+	message, err := decryptWithWeights(ciphertext, usk, iSlice, wSlice)
+	instance.audit(ciphertext, iSlice, err)
+	return message, err
+}
+
+// decryptWithWeights 使用线性组合 (iSlice, wSlice) 解密，wSlice[k] 是第 iSlice[k] 行的权重。
+// 计算 $e(C', K) / \prod_k (e(C_i, L) \cdot e(D_i, K_{\rho(i)}))^{w_k} = e(g_1, g_2)^{\alpha s}$，再从 C 中除去。
+// 计算之前先检查所选各行的 K_{\rho(i)} 都在私钥中：缺失时 map 会返回零值点，解密得到错误的明文而不是报错。
+func decryptWithWeights(ciphertext *Waters11CPABECiphertext, usk *Waters11CPABEUserSecretKey, iSlice []int, wSlice []fr.Element) (*Waters11CPABEMessage, error) {
+	kRho := make([]bn254.G1Affine, len(iSlice))
+	for k, i := range iSlice {
+		rhoI := ciphertext.accessMatrix.Rho(i)
+		kRhoI, ok := usk.kx[rhoI]
+		if !ok {
+			b := rhoI.Bytes()
+			return nil, fmt.Errorf("decrypt failed: %w for attribute %x", ErrMissingKeyComponent, b[:])
+		}
+		kRho[k] = kRhoI
+	}
+
+	// e(K, C')
+	eCPrimeK, err := metrics.Pair([]bn254.G1Affine{usk.k}, []bn254.G2Affine{ciphertext.cPrime})
+	if err != nil {
+		return nil, fmt.Errorf("decrypt failed: %w", err)
 	}
 	denominator := new(bn254.GT).SetOne()
-	for _, i := range iSlice {
+	for k, i := range iSlice {
 		ci := ciphertext.cx[i]
 		di := ciphertext.dx[i]
-		rhoI := ciphertext.accessMatrix.Rho(i)
-		kRhoI := usk.kx[rhoI]
+		kRhoI := kRho[k]
 
 		// e(Ci, L)
-		eCiL, err := bn254.Pair([]bn254.G1Affine{ci}, []bn254.G2Affine{usk.l})
+		eCiL, err := metrics.Pair([]bn254.G1Affine{ci}, []bn254.G2Affine{usk.l})
 		if err != nil {
-			return nil, fmt.Errorf("decrypt failed: %v", err)
+			return nil, fmt.Errorf("decrypt failed: %w", err)
 		}
 
 		// e(Di, Krho(i))
-		eDiKRhoI, err := bn254.Pair([]bn254.G1Affine{kRhoI}, []bn254.G2Affine{di})
+		eDiKRhoI, err := metrics.Pair([]bn254.G1Affine{kRhoI}, []bn254.G2Affine{di})
 		if err != nil {
-			return nil, fmt.Errorf("decrypt failed: %v", err)
+			return nil, fmt.Errorf("decrypt failed: %w", err)
 		}
 
 		// e(Ci, L)*e(Di, Krho(i))
 		eCiLEDiKRhoI := new(bn254.GT).Mul(&eCiL, &eDiKRhoI)
 		// (e(Ci, L)*e(Di, Krho(i)))^wi
-		eCiLEDiKRhoIExpWi := eCiLEDiKRhoI.Exp(*eCiLEDiKRhoI, wSlice[i].BigInt(new(big.Int)))
+		eCiLEDiKRhoIExpWi := eCiLEDiKRhoI.Exp(*eCiLEDiKRhoI, wSlice[k].BigInt(new(big.Int)))
 
 		denominator.Mul(denominator, eCiLEDiKRhoIExpWi)
 
@@ -0,0 +1,31 @@
+package waters11
+
+import (
+	"github.com/mmsyan/GoPairingBasedCryptography/access/lsss"
+)
+
+// SetDecryptionAuditor 设置审计回调，此后该实例上的每一次 Decrypt、DecryptWithPlan
+// （以及基于它们的 DecryptWithRegistry、DecryptWithPolicy）都会调用 auditor.OnDecryptAttempt。
+// 传入 nil 关闭审计，这也是默认状态。
+//
+// 参数:
+//   - auditor: 审计回调，实现必须是并发安全的
+func (instance *Waters11CPABEInstance) SetDecryptionAuditor(auditor lsss.DecryptionAuditor) {
+	instance.auditorMu.Lock()
+	defer instance.auditorMu.Unlock()
+	instance.auditor = auditor
+}
+
+// audit 把一次解密尝试报告给审计回调；未设置回调时不计算策略指纹，没有额外开销
+func (instance *Waters11CPABEInstance) audit(ciphertext *Waters11CPABECiphertext, rows []int, err error) {
+	instance.auditorMu.RLock()
+	auditor := instance.auditor
+	instance.auditorMu.RUnlock()
+	if auditor == nil {
+		return
+	}
+	if err != nil {
+		rows = nil
+	}
+	auditor.OnDecryptAttempt(ciphertext.PolicyFingerprint(), err == nil, rows)
+}
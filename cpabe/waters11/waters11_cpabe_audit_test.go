@@ -0,0 +1,62 @@
+package waters11
+
+import (
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"sync"
+	"testing"
+)
+
+// auditRecord 是 recordingAuditor 记录的一次解密尝试
+type auditRecord struct {
+	fingerprint [32]byte
+	success     bool
+	rows        []int
+}
+
+// recordingAuditor 按调用顺序记录全部解密尝试
+type recordingAuditor struct {
+	mu      sync.Mutex
+	records []auditRecord
+}
+
+func (a *recordingAuditor) OnDecryptAttempt(policyFingerprint [32]byte, success bool, satisfiedRows []int) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.records = append(a.records, auditRecord{policyFingerprint, success, satisfiedRows})
+}
+
+// TestWatersCPABEDecryptionAuditor 两次解密（一次成功、一次属性不满足）各记录一条，指纹为密文策略指纹，只有成功的一次带有满足的行
+func TestWatersCPABEDecryptionAuditor(t *testing.T) {
+	instance, _, usk, ciphertexts, _ := planFixture(t, 2, 1)
+	auditor := &recordingAuditor{}
+	instance.SetDecryptionAuditor(auditor)
+
+	if _, err := instance.Decrypt(ciphertexts[0], usk); err != nil {
+		t.Fatal(err)
+	}
+	partial := *usk
+	partial.userAttributes = []fr.Element{fr.NewElement(1), fr.NewElement(3)}
+	if _, err := instance.Decrypt(ciphertexts[0], &partial); err == nil {
+		t.Fatal("user holding {1, 3} should not satisfy (1 and 2) or (3 and 4)")
+	}
+
+	if len(auditor.records) != 2 {
+		t.Fatalf("expected 2 audit records, got %d", len(auditor.records))
+	}
+	fingerprint := ciphertexts[0].PolicyFingerprint()
+	success, failure := auditor.records[0], auditor.records[1]
+	if !success.success || success.fingerprint != fingerprint || len(success.rows) != 2 {
+		t.Fatalf("unexpected success record %+v", success)
+	}
+	if failure.success || failure.fingerprint != fingerprint || failure.rows != nil {
+		t.Fatalf("unexpected failure record %+v", failure)
+	}
+
+	instance.SetDecryptionAuditor(nil)
+	if _, err := instance.Decrypt(ciphertexts[0], usk); err != nil {
+		t.Fatal(err)
+	}
+	if len(auditor.records) != 2 {
+		t.Fatal("removed auditor should not be called")
+	}
+}
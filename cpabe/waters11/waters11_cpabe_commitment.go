@@ -0,0 +1,65 @@
+package waters11
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"github.com/mmsyan/GoPairingBasedCryptography/serialization"
+)
+
+// commitmentDomain 是明文承诺哈希的域分隔前缀，避免与仓库中其他 SHA-256 摘要混淆
+const commitmentDomain = "waters11-cpabe-plaintext-commitment-v1"
+
+// PlaintextCommitment 是把明文与访问策略绑定在一起的承诺
+// $C = H(domain \| \mathrm{MarshalGT}(M) \| \mathrm{Fingerprint}(\mathbb{M}, \rho))$。
+//
+// 承诺只用于事后审计：发生争议时，加密者公开明文，任何人都可以用 VerifyCommitment
+// 确认该明文确实是在该策略下加密的，而审计方平时不需要保存明文。
+// 它不是零知识证明，不能在公开明文之前证明密文内容；承诺不含随机数，
+// 因此只有在明文本身不可猜测时（例如作为会话密钥使用的随机 GT 元素）才不泄露明文。
+type PlaintextCommitment [sha256.Size]byte
+
+// EncryptWithCommitment 与 Encrypt 相同地加密消息，并同时输出明文与访问策略的承诺。
+//
+// 参数:
+//   - message: 要加密的明文消息M
+//   - accessPolicy: 访问策略A=(M, \rho)
+//   - pp: 系统公共参数 PP
+//
+// 返回值:
+//   - *Waters11CPABECiphertext: 生成的密文
+//   - PlaintextCommitment: 明文与访问策略的承诺，可以与密文一起交给审计方保存
+//   - error: 如果加密失败，返回错误信息
+func (instance *Waters11CPABEInstance) EncryptWithCommitment(message *Waters11CPABEMessage, accessPolicy *Waters11CPABEAccessPolicy, pp *Waters11CPABEPublicParameters) (*Waters11CPABECiphertext, PlaintextCommitment, error) {
+	ciphertext, err := instance.Encrypt(message, accessPolicy, pp)
+	if err != nil {
+		return nil, PlaintextCommitment{}, err
+	}
+	return ciphertext, commitPlaintext(message, accessPolicy), nil
+}
+
+// VerifyCommitment 检查公开的明文与访问策略是否与承诺一致，比较在常数时间内完成。
+//
+// 参数:
+//   - plaintext: 公开的明文消息
+//   - policy: 声称的访问策略
+//   - commitment: EncryptWithCommitment 输出的承诺
+//
+// 返回值:
+//   - bool: 明文与策略都与承诺一致时返回 true
+func VerifyCommitment(plaintext *Waters11CPABEMessage, policy *Waters11CPABEAccessPolicy, commitment PlaintextCommitment) bool {
+	expected := commitPlaintext(plaintext, policy)
+	return subtle.ConstantTimeCompare(expected[:], commitment[:]) == 1
+}
+
+// commitPlaintext 计算 H(domain || MarshalGT(M) || Fingerprint(policy))，
+// GT 元素的编码长度固定，策略指纹也是定长摘要，因此拼接不会产生歧义
+func commitPlaintext(message *Waters11CPABEMessage, policy *Waters11CPABEAccessPolicy) PlaintextCommitment {
+	fingerprint := policy.matrix.Fingerprint()
+	h := sha256.New()
+	h.Write([]byte(commitmentDomain))
+	h.Write(serialization.MarshalGT(message.Message))
+	h.Write(fingerprint[:])
+	var commitment PlaintextCommitment
+	h.Sum(commitment[:0])
+	return commitment
+}
@@ -0,0 +1,66 @@
+package waters11
+
+import (
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	lsss2 "github.com/mmsyan/GoPairingBasedCryptography/access/lsss"
+	"testing"
+)
+
+// TestWatersCPABEEncryptWithCommitment 公开正确的明文与策略时承诺验证通过，
+// 篡改明文、换用其他策略或篡改承诺本身时验证失败
+func TestWatersCPABEEncryptWithCommitment(t *testing.T) {
+	instance, err := NewWaters11CPABEInstance([]fr.Element{fr.NewElement(1), fr.NewElement(2), fr.NewElement(3)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	pp, msk, err := instance.SetUp()
+	if err != nil {
+		t.Fatal(err)
+	}
+	policy := &Waters11CPABEAccessPolicy{matrix: lsss2.NewLSSSMatrixFromBinaryTree(lsss2.And(lsss2.Leaf(fr.NewElement(1)), lsss2.Leaf(fr.NewElement(2))))}
+	other := &Waters11CPABEAccessPolicy{matrix: lsss2.NewLSSSMatrixFromBinaryTree(lsss2.And(lsss2.Leaf(fr.NewElement(1)), lsss2.Leaf(fr.NewElement(3))))}
+
+	message, err := new(bn254.GT).SetRandom()
+	if err != nil {
+		t.Fatal(err)
+	}
+	plaintext := &Waters11CPABEMessage{Message: *message}
+	ciphertext, commitment, err := instance.EncryptWithCommitment(plaintext, policy, pp)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 密文本身与 Encrypt 的结果一样可以正常解密
+	usk, err := instance.KeyGenerate(&Waters11CPABEAttributes{Attributes: []fr.Element{fr.NewElement(1), fr.NewElement(2)}}, msk, pp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	recovered, err := instance.Decrypt(ciphertext, usk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !VerifyCommitment(recovered, &Waters11CPABEAccessPolicy{matrix: ciphertext.accessMatrix}, commitment) {
+		t.Fatal("解密得到的明文与密文中的策略应通过承诺验证")
+	}
+
+	// 结构相同但重新构造的策略指纹相同，同样通过验证
+	rebuilt := &Waters11CPABEAccessPolicy{matrix: lsss2.NewLSSSMatrixFromBinaryTree(lsss2.And(lsss2.Leaf(fr.NewElement(1)), lsss2.Leaf(fr.NewElement(2))))}
+	if !VerifyCommitment(plaintext, rebuilt, commitment) {
+		t.Fatal("重新构造的相同策略应通过承诺验证")
+	}
+
+	var tampered bn254.GT
+	tampered.Mul(message, message)
+	if VerifyCommitment(&Waters11CPABEMessage{Message: tampered}, policy, commitment) {
+		t.Fatal("篡改后的明文不应通过承诺验证")
+	}
+	if VerifyCommitment(plaintext, other, commitment) {
+		t.Fatal("换用其他策略不应通过承诺验证")
+	}
+	badCommitment := commitment
+	badCommitment[0] ^= 1
+	if VerifyCommitment(plaintext, policy, badCommitment) {
+		t.Fatal("篡改后的承诺不应通过验证")
+	}
+}
@@ -0,0 +1,27 @@
+package waters11
+
+import "errors"
+
+var (
+	// ErrPolicyNotSatisfied 表示用户属性不满足密文的访问策略，可用 errors.Is 判断
+	ErrPolicyNotSatisfied = errors.New("access policy is not satisfied")
+
+	// ErrInvalidAttribute 表示属性不在属性宇宙中
+	ErrInvalidAttribute = errors.New("invalid attribute")
+
+	// ErrKeyPairMismatch 表示公共参数与主密钥不是同一次 SetUp 生成的
+	ErrKeyPairMismatch = errors.New("public parameters and master secret key do not match")
+
+	// ErrInvalidUpdateToken 表示策略更新令牌不是为该密文签发的，或令牌本身不合法
+	ErrInvalidUpdateToken = errors.New("invalid policy update token")
+
+	// ErrPolicyDetached 表示密文只保存了访问策略的指纹，需要先用 Attach、DecryptWithRegistry 或 DecryptWithPolicy 提供策略
+	ErrPolicyDetached = errors.New("ciphertext does not carry its access policy")
+
+	// ErrPolicyMismatch 表示提供的访问策略与密文引用的策略指纹不一致
+	ErrPolicyMismatch = errors.New("ciphertext policy does not match")
+
+	// ErrMissingKeyComponent 表示解密所需的某个属性在用户私钥中没有对应的 K_x，
+	// 例如手工构造或经过穿刺、合并后不完整的私钥
+	ErrMissingKeyComponent = errors.New("missing key component")
+)
@@ -0,0 +1,44 @@
+package waters11
+
+import (
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/mmsyan/GoPairingBasedCryptography/pairing"
+)
+
+// g1Table 返回固定底 base 的窗口预计算表，首次遇到该底时构造并缓存在实例上。
+//
+// Encrypt 中每一行都要计算 (g1^a)^{λ_i} 与 h_{ρ(i)}^{-r_i}，底都是公共参数中的固定点，
+// 小属性宇宙下反复加密时同一个底会被用到很多次：构造一张表约相当于十几次普通标量乘法，
+// 此后每次标量乘法的开销降到原来的几分之一。
+//
+// 缓存以点本身为键，因此同一实例换用另一套公共参数时不会误用旧表；
+// 缓存的表数超过属性宇宙大小的两倍（说明公共参数已更换）时清空重建，避免内存无限增长。
+// 每张表约占 60 KB。
+//
+// 参数:
+//   - base: 固定底
+//
+// 返回值:
+//   - *pairing.FixedBaseG1: base 的预计算表
+func (instance *Waters11CPABEInstance) g1Table(base bn254.G1Affine) *pairing.FixedBaseG1 {
+	instance.g1TablesMu.Lock()
+	table, ok := instance.g1Tables[base]
+	instance.g1TablesMu.Unlock()
+	if ok {
+		return table
+	}
+
+	// 在锁外构造，避免阻塞其他底的查询；并发构造同一张表时以先写入的为准
+	table = pairing.NewFixedBaseG1(base)
+
+	instance.g1TablesMu.Lock()
+	defer instance.g1TablesMu.Unlock()
+	if existing, ok := instance.g1Tables[base]; ok {
+		return existing
+	}
+	if instance.g1Tables == nil || len(instance.g1Tables) >= 2*(len(instance.universe)+1) {
+		instance.g1Tables = make(map[bn254.G1Affine]*pairing.FixedBaseG1, len(instance.universe)+1)
+	}
+	instance.g1Tables[base] = table
+	return table
+}
@@ -0,0 +1,82 @@
+package waters11
+
+import (
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	lsss2 "github.com/mmsyan/GoPairingBasedCryptography/access/lsss"
+	"sync"
+	"testing"
+)
+
+// fixedBaseFixture 生成 8 个属性的 AND 策略、持有全部属性的私钥和一条随机消息
+func fixedBaseFixture(tb testing.TB) (*Waters11CPABEInstance, *Waters11CPABEPublicParameters, *Waters11CPABEAccessPolicy, *Waters11CPABEUserSecretKey, *Waters11CPABEMessage) {
+	tb.Helper()
+	attributes := make([]fr.Element, 8)
+	leaves := make([]*lsss2.BinaryAccessTree, len(attributes))
+	for i := range attributes {
+		attributes[i] = fr.NewElement(uint64(i + 1))
+		leaves[i] = lsss2.Leaf(attributes[i])
+	}
+	instance, err := NewWaters11CPABEInstance(attributes)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	pp, msk, err := instance.SetUp()
+	if err != nil {
+		tb.Fatal(err)
+	}
+	usk, err := instance.KeyGenerate(&Waters11CPABEAttributes{Attributes: attributes}, msk, pp)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	ap := &Waters11CPABEAccessPolicy{matrix: lsss2.NewLSSSMatrixFromBinaryTree(lsss2.And(leaves...))}
+	m, err := new(bn254.GT).SetRandom()
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return instance, pp, ap, usk, &Waters11CPABEMessage{Message: *m}
+}
+
+// TestEncryptFixedBaseTables 多个 goroutine 并发加密时共用惰性构造的预计算表，密文都能正确解密，
+// 且每个固定底（8 个 h_x 与 g1^a）只缓存一张表
+func TestEncryptFixedBaseTables(t *testing.T) {
+	instance, pp, ap, usk, message := fixedBaseFixture(t)
+	ciphertexts := make([]*Waters11CPABECiphertext, 8)
+	errs := make([]error, len(ciphertexts))
+	var wg sync.WaitGroup
+	for i := range ciphertexts {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			ciphertexts[i], errs[i] = instance.Encrypt(message, ap, pp)
+		}(i)
+	}
+	wg.Wait()
+	for i, ciphertext := range ciphertexts {
+		if errs[i] != nil {
+			t.Fatal(errs[i])
+		}
+		decrypted, err := instance.Decrypt(ciphertext, usk)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !decrypted.Message.Equal(&message.Message) {
+			t.Fatalf("第 %d 个密文解密结果不一致", i)
+		}
+	}
+	if len(instance.g1Tables) != 9 {
+		t.Fatalf("缓存了 %d 张预计算表, 期望 9", len(instance.g1Tables))
+	}
+}
+
+// BenchmarkWatersCPABEEncrypt 对 8 个属性的 AND 策略反复加密。
+// 预计算表在第一次加密时构造，之后的加密直接复用
+func BenchmarkWatersCPABEEncrypt(b *testing.B) {
+	instance, pp, ap, _, message := fixedBaseFixture(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := instance.Encrypt(message, ap, pp); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
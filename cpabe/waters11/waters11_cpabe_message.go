@@ -0,0 +1,30 @@
+package waters11
+
+import "github.com/mmsyan/GoPairingBasedCryptography/serialization"
+
+var _ serialization.Message = (*Waters11CPABEMessage)(nil)
+
+// Bytes 用 serialization.OpenBytes 以明文中的会话密钥解出 SetBytes 加密的字节数据
+//
+// 返回值:
+//   - []byte: SetBytes 加密的字节数据
+//   - error: 消息不是由 SetBytes 设置（例如解密得到或随机选取的明文）时返回包装 serialization.ErrInvalidSealedBytes 的错误
+func (m *Waters11CPABEMessage) Bytes() ([]byte, error) {
+	return serialization.OpenBytes(m.Message, m.sealed)
+}
+
+// SetBytes 用 serialization.SealBytes 把明文设为随机会话密钥，并在消息中保存以它加密的字节数据
+//
+// 参数:
+//   - data: 待加密的字节数据
+//
+// 返回值:
+//   - error: 随机数生成失败时返回错误
+func (m *Waters11CPABEMessage) SetBytes(data []byte) error {
+	k, sealed, err := serialization.SealBytes(data)
+	if err != nil {
+		return err
+	}
+	m.Message, m.sealed = k, sealed
+	return nil
+}
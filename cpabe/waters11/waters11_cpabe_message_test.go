@@ -0,0 +1,12 @@
+package waters11
+
+import (
+	"github.com/mmsyan/GoPairingBasedCryptography/internal/msgtest"
+	"github.com/mmsyan/GoPairingBasedCryptography/serialization"
+	"testing"
+)
+
+// TestWaters11CPABEMessageBytes 检查 Waters11CPABEMessage 的 Bytes/SetBytes 往返
+func TestWaters11CPABEMessageBytes(t *testing.T) {
+	msgtest.CheckGTRoundTrip(t, func() serialization.Message { return new(Waters11CPABEMessage) })
+}
@@ -0,0 +1,47 @@
+//go:build metrics
+
+package waters11
+
+import (
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	lsss2 "github.com/mmsyan/GoPairingBasedCryptography/access/lsss"
+	"github.com/mmsyan/GoPairingBasedCryptography/metrics"
+	"testing"
+)
+
+// TestWatersCPABEDecryptPairingCount 用户属性满足 2 行的访问矩阵时，
+// Decrypt 应执行 1 + 2*2 = 5 次配对：e(C', K) 以及每行的 e(Ci, L)、e(Di, K_ρ(i))。
+// 运行方式: go test -tags metrics ./cpabe/waters11/
+func TestWatersCPABEDecryptPairingCount(t *testing.T) {
+	universe := []fr.Element{fr.NewElement(1), fr.NewElement(2), fr.NewElement(3)}
+	instance, err := NewWaters11CPABEInstance(universe)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pp, msk, err := instance.SetUp()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ap := &Waters11CPABEAccessPolicy{
+		matrix: lsss2.NewLSSSMatrixFromBinaryTree(lsss2.And(lsss2.Leaf(fr.NewElement(1)), lsss2.Leaf(fr.NewElement(2)))),
+	}
+	usk, err := instance.KeyGenerate(&Waters11CPABEAttributes{Attributes: []fr.Element{fr.NewElement(1), fr.NewElement(2)}}, msk, pp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	message, _ := new(bn254.GT).SetRandom()
+	ciphertext, err := instance.Encrypt(&Waters11CPABEMessage{Message: *message}, ap, pp)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	metrics.ResetPairingCount()
+	if _, err := instance.Decrypt(ciphertext, usk); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := metrics.PairingCount(), int64(5); got != want {
+		t.Fatalf("Decrypt performed %d pairings, want %d", got, want)
+	}
+}
@@ -0,0 +1,50 @@
+package waters11
+
+import (
+	"errors"
+	"fmt"
+	"github.com/mmsyan/GoPairingBasedCryptography/access/lsss"
+)
+
+// PrecomputeDecryption 为用户私钥在访问策略上预先求解线性组合，得到可在多个密文间复用的解密计划。
+// 当同一用户需要解密大量共用同一策略的密文时，使用 DecryptWithPlan 可以省去每个密文一次的高斯消元。
+//
+// 参数:
+//   - ap: 密文使用的访问策略
+//   - usk: 用户的私钥
+//
+// 返回值:
+//   - *lsss.DecryptionPlan: 解密计划
+//   - error: 如果属性不满足策略，返回包装了 ErrPolicyNotSatisfied 的错误
+func (instance *Waters11CPABEInstance) PrecomputeDecryption(ap *Waters11CPABEAccessPolicy, usk *Waters11CPABEUserSecretKey) (*lsss.DecryptionPlan, error) {
+	plan, err := lsss.PrecomputeDecryption(ap.matrix, usk.userAttributes)
+	if errors.Is(err, lsss.ErrAttributesNotSatisfying) {
+		return nil, fmt.Errorf("precompute decryption failed: %w", ErrPolicyNotSatisfied)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("precompute decryption failed: %w", err)
+	}
+	return plan, nil
+}
+
+// DecryptWithPlan 使用预先计算的解密计划对密文进行解密，结果与 Decrypt 相同。
+// 计划必须由与密文相同的访问矩阵和该私钥的属性集合构造，否则返回错误。
+//
+// 参数:
+//   - ciphertext: 要解密的密文
+//   - usk: 用户的私钥
+//   - plan: PrecomputeDecryption 返回的解密计划
+//
+// 返回值:
+//   - *Waters11CPABEMessage: 解密后的明文消息
+//   - error: 如果计划与密文或私钥不匹配，或配对计算失败，返回错误信息
+func (instance *Waters11CPABEInstance) DecryptWithPlan(ciphertext *Waters11CPABECiphertext, usk *Waters11CPABEUserSecretKey, plan *lsss.DecryptionPlan) (*Waters11CPABEMessage, error) {
+	if !plan.Matches(ciphertext.accessMatrix, usk.userAttributes) {
+		err := fmt.Errorf("decrypt failed: decryption plan does not match ciphertext policy or user attributes")
+		instance.audit(ciphertext, nil, err)
+		return nil, err
+	}
+	message, err := decryptWithWeights(ciphertext, usk, plan.Rows(), plan.Weights())
+	instance.audit(ciphertext, plan.Rows(), err)
+	return message, err
+}
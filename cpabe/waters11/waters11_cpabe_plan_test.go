@@ -0,0 +1,100 @@
+package waters11
+
+import (
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	lsss2 "github.com/mmsyan/GoPairingBasedCryptography/access/lsss"
+	"testing"
+)
+
+// planFixture 生成 k 个属性对组成的策略 (1 and 2) or (3 and 4) or ...、持有全部 2k 个属性的私钥以及 n 个该策略下的密文。
+// 用户满足策略的行数越多，FindLinearCombinationWeight 的高斯消元开销越大。
+func planFixture(tb testing.TB, k, n int) (*Waters11CPABEInstance, *Waters11CPABEAccessPolicy, *Waters11CPABEUserSecretKey, []*Waters11CPABECiphertext, []bn254.GT) {
+	attributes := make([]fr.Element, 2*k)
+	for i := range attributes {
+		attributes[i] = fr.NewElement(uint64(i + 1))
+	}
+	instance, err := NewWaters11CPABEInstance(attributes)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	pp, msk, err := instance.SetUp()
+	if err != nil {
+		tb.Fatal(err)
+	}
+	usk, err := instance.KeyGenerate(&Waters11CPABEAttributes{Attributes: attributes}, msk, pp)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	tree := lsss2.And(lsss2.Leaf(attributes[0]), lsss2.Leaf(attributes[1]))
+	for i := 1; i < k; i++ {
+		tree = lsss2.Or(tree, lsss2.And(lsss2.Leaf(attributes[2*i]), lsss2.Leaf(attributes[2*i+1])))
+	}
+	ap := &Waters11CPABEAccessPolicy{matrix: lsss2.NewLSSSMatrixFromBinaryTree(tree)}
+
+	ciphertexts := make([]*Waters11CPABECiphertext, n)
+	messages := make([]bn254.GT, n)
+	for i := range ciphertexts {
+		m, err := new(bn254.GT).SetRandom()
+		if err != nil {
+			tb.Fatal(err)
+		}
+		messages[i] = *m
+		ciphertexts[i], err = instance.Encrypt(&Waters11CPABEMessage{Message: *m}, ap, pp)
+		if err != nil {
+			tb.Fatal(err)
+		}
+	}
+	return instance, ap, usk, ciphertexts, messages
+}
+
+// TestWatersCPABEDecryptWithPlan 复用同一解密计划解密多个密文，结果与原始消息一致；计划不能用于其他用户
+func TestWatersCPABEDecryptWithPlan(t *testing.T) {
+	instance, ap, usk, ciphertexts, messages := planFixture(t, 2, 5)
+	plan, err := instance.PrecomputeDecryption(ap, usk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, ct := range ciphertexts {
+		recovered, err := instance.DecryptWithPlan(ct, usk, plan)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !recovered.Message.Equal(&messages[i]) {
+			t.Fatalf("第 %d 个密文解密结果与原始消息不匹配", i)
+		}
+	}
+
+	other := &Waters11CPABEUserSecretKey{userAttributes: []fr.Element{fr.NewElement(3), fr.NewElement(4)}, kx: usk.kx}
+	if _, err := instance.DecryptWithPlan(ciphertexts[0], other, plan); err == nil {
+		t.Fatal("属性集合不同的私钥不应能使用该解密计划")
+	}
+}
+
+func BenchmarkWatersCPABEDecrypt100(b *testing.B) {
+	instance, _, usk, ciphertexts, _ := planFixture(b, 20, 100)
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		for _, ct := range ciphertexts {
+			if _, err := instance.Decrypt(ct, usk); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func BenchmarkWatersCPABEDecryptWithPlan100(b *testing.B) {
+	instance, ap, usk, ciphertexts, _ := planFixture(b, 20, 100)
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		plan, err := instance.PrecomputeDecryption(ap, usk)
+		if err != nil {
+			b.Fatal(err)
+		}
+		for _, ct := range ciphertexts {
+			if _, err := instance.DecryptWithPlan(ct, usk, plan); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
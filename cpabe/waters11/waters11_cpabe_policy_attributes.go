@@ -0,0 +1,27 @@
+package waters11
+
+import (
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/mmsyan/GoPairingBasedCryptography/access/lsss"
+)
+
+// PolicyAttributes 返回密文访问策略中出现的全部属性（去重，按矩阵行的顺序），
+// 便于依赖方展示"解密该密文需要哪些属性"而不必接触矩阵本身。
+//
+// 返回值:
+//   - []fr.Element: 策略中的属性列表
+func (ct *Waters11CPABECiphertext) PolicyAttributes() []fr.Element {
+	return ct.accessMatrix.DistinctAttributes()
+}
+
+// PolicyAttributeStrings 用属性字典把 PolicyAttributes 的结果反查为属性名称。
+//
+// 参数:
+//   - dictionary: 属性到名称的映射，通常由 hash.ToField(name) -> name 构成
+//
+// 返回值:
+//   - []string: 策略中的属性名称，顺序与 PolicyAttributes 一致
+//   - error: 某个属性不在字典中时返回包装了 lsss.ErrUnknownAttribute 的错误
+func (ct *Waters11CPABECiphertext) PolicyAttributeStrings(dictionary map[fr.Element]string) ([]string, error) {
+	return lsss.AttributeNames(ct.PolicyAttributes(), dictionary)
+}
@@ -0,0 +1,63 @@
+package waters11
+
+import (
+	"errors"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	lsss2 "github.com/mmsyan/GoPairingBasedCryptography/access/lsss"
+	"github.com/mmsyan/GoPairingBasedCryptography/hash"
+	"testing"
+)
+
+// TestPolicyAttributes 按 Example14 加密后，密文给出的策略属性恰为去重后的 {A,B,C,D}
+func TestPolicyAttributes(t *testing.T) {
+	names := []string{"A", "B", "C", "D"}
+	dictionary := make(map[fr.Element]string, len(names))
+	universe := make([]fr.Element, len(names))
+	for i, name := range names {
+		universe[i] = hash.ToField(name)
+		dictionary[universe[i]] = name
+	}
+	instance, err := NewWaters11CPABEInstance(universe)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pp, _, err := instance.SetUp()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tree, _ := lsss2.GetExample14()
+	policy := &Waters11CPABEAccessPolicy{matrix: lsss2.NewLSSSMatrixFromBinaryTree(tree)}
+	message, err := new(bn254.GT).SetRandom()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ciphertext, err := instance.Encrypt(&Waters11CPABEMessage{Message: *message}, policy, pp)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	attributes := ciphertext.PolicyAttributes()
+	if len(attributes) != len(universe) {
+		t.Fatalf("策略属性个数 %d, 期望 %d", len(attributes), len(universe))
+	}
+	for i := range universe {
+		if attributes[i] != universe[i] {
+			t.Fatalf("第 %d 个策略属性不一致", i)
+		}
+	}
+	got, err := ciphertext.PolicyAttributeStrings(dictionary)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range names {
+		if got[i] != names[i] {
+			t.Fatalf("第 %d 个属性名为 %q, 期望 %q", i, got[i], names[i])
+		}
+	}
+
+	delete(dictionary, universe[2])
+	if _, err := ciphertext.PolicyAttributeStrings(dictionary); !errors.Is(err, lsss2.ErrUnknownAttribute) {
+		t.Fatalf("字典缺少属性时应返回 ErrUnknownAttribute, 实际为 %v", err)
+	}
+}
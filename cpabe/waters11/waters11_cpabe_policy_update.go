@@ -0,0 +1,134 @@
+package waters11
+
+import (
+	"fmt"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/mmsyan/GoPairingBasedCryptography/metrics"
+	"github.com/mmsyan/GoPairingBasedCryptography/utils"
+	"math/big"
+)
+
+// PolicyUpdateToken 是加密者为某个密文签发的策略更新令牌 $T = g_1^{as}$，
+// 其中 s 是该密文的秘密指数（$C' = g_2^s$）。
+//
+// 持有令牌的一方可以在指数上重新分享 s：对新矩阵的每一行计算 $C_i = (g_1^{as})^{M_{i,1}} \cdot (g_1^a)^{M_i \cdot (0, y_2, ..., y_n)} \cdot h_{\rho(i)}^{-r_i}$，
+// 从而把密文改到新的访问策略下，而不需要明文，也不需要 s 本身。
+//
+// 信任假设:
+//   - 令牌本身不足以解密：恢复 $e(g_1, g_2)^{\alpha s}$ 需要 $g_1^\alpha$ 相关的用户私钥，令牌只给出 $g_1^{as}$。
+//   - 但令牌持有者若与任意一个用户合谋（不论其属性），可用 $e(K, C') / e(T, L) = e(g_1, g_2)^{\alpha s}$ 解密该密文，
+//     因此令牌只能交给不与用户合谋的半可信更新方（例如存储密文的云服务器），并应通过安全信道传递。
+//   - 令牌只对签发它的密文有效（以 C' 绑定），UpdatePolicy 会用一次配对检查确认 $e(T, g_2) = e(g_1^a, C')$。
+//   - 更新后的密文与原密文共享 C 与 C'，原密文若已泄露给旧策略下的用户，更新并不能撤销他们已有的访问能力。
+type PolicyUpdateToken struct {
+	cPrime  bn254.G2Affine // 所绑定密文的 C' = g2^s
+	g1ExpAS bn254.G1Affine // T = g1^(as)
+}
+
+// EncryptWithUpdateToken 与 Encrypt 相同地加密消息，并同时签发该密文的策略更新令牌。
+//
+// 参数:
+//   - message: 要加密的明文消息M
+//   - accessPolicy: 访问策略A=(M, \rho)
+//   - pp: 系统公共参数 PP
+//
+// 返回值:
+//   - *Waters11CPABECiphertext: 生成的密文
+//   - *PolicyUpdateToken: 该密文的策略更新令牌，信任假设见 PolicyUpdateToken
+//   - error: 如果加密失败，返回错误信息
+func (instance *Waters11CPABEInstance) EncryptWithUpdateToken(message *Waters11CPABEMessage, accessPolicy *Waters11CPABEAccessPolicy, pp *Waters11CPABEPublicParameters) (*Waters11CPABECiphertext, *PolicyUpdateToken, error) {
+	ciphertext, s, err := instance.encrypt(message, accessPolicy, pp)
+	if err != nil {
+		return nil, nil, err
+	}
+	// T = (g1^a)^s
+	g1ExpAS := new(bn254.G1Affine).ScalarMultiplication(&pp.g1ExpA, s.BigInt(new(big.Int)))
+	return ciphertext, &PolicyUpdateToken{
+		cPrime:  ciphertext.cPrime,
+		g1ExpAS: *g1ExpAS,
+	}, nil
+}
+
+// UpdatePolicy 使用策略更新令牌把密文改到新的访问策略下，整个过程不接触明文。
+// 步骤:
+// 1. 检查令牌绑定的 C' 与密文一致，并验证 $e(T, g_2) = e(g_1^a, C')$。
+// 2. 选取随机值 $y_2, ..., y_n \in \mathbb{Z}_p$，对新矩阵的每一行 i 选取随机值 $r_i$。
+// 3. 计算 $C_i = T^{M_{i,1}} \cdot (g_1^a)^{M_i \cdot (0, y_2, ..., y_n)} \cdot h_{\rho(i)}^{-r_i}$，$D_i = g_2^{r_i}$。
+//
+// 新密文等价于以向量 $(s, y_2, ..., y_n)$ 在新策略下加密同一消息，C 与 C' 保持不变。
+// 原密文不会被修改，调用方应在更新后丢弃原密文。
+//
+// 参数:
+//   - ciphertext: 原密文
+//   - token: 原密文的策略更新令牌
+//   - newPolicy: 新的访问策略
+//   - pp: 系统公共参数 PP
+//
+// 返回值:
+//   - *Waters11CPABECiphertext: 新策略下的密文
+//   - error: 令牌与密文不匹配时返回包装 ErrInvalidUpdateToken 的错误；新策略含不在宇宙中的属性或随机数生成失败时返回错误
+func (instance *Waters11CPABEInstance) UpdatePolicy(ciphertext *Waters11CPABECiphertext, token *PolicyUpdateToken, newPolicy *Waters11CPABEAccessPolicy, pp *Waters11CPABEPublicParameters) (*Waters11CPABECiphertext, error) {
+	if err := instance.checkAttributes(newPolicy.matrix.Attributes()); err != nil {
+		return nil, fmt.Errorf("failed to update policy. contains invalid ciphertext attributes: %w", err)
+	}
+	if !token.cPrime.Equal(&ciphertext.cPrime) {
+		return nil, fmt.Errorf("failed to update policy: token was issued for another ciphertext: %w", ErrInvalidUpdateToken)
+	}
+	// e(T, g2) * e(g1^a, C')^(-1) = 1
+	var negG1ExpA bn254.G1Affine
+	negG1ExpA.Neg(&pp.g1ExpA)
+	check, err := metrics.Pair([]bn254.G1Affine{token.g1ExpAS, negG1ExpA}, []bn254.G2Affine{pp.g2, ciphertext.cPrime})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update policy: %w", err)
+	}
+	if !check.IsOne() {
+		return nil, fmt.Errorf("failed to update policy: %w", ErrInvalidUpdateToken)
+	}
+
+	n := newPolicy.matrix.ColumnNumber()
+	l := newPolicy.matrix.RowNumber()
+
+	// e1 = [1, 0, ..., 0] 用于取出 M_{i,1}；y = [0, y2, ..., yn]
+	e1 := make([]fr.Element, n)
+	e1[0].SetOne()
+	vectorY := make([]fr.Element, n)
+	for j := 1; j < n; j++ {
+		if _, err := vectorY[j].SetRandom(); err != nil {
+			return nil, fmt.Errorf("failed to update policy: %w", err)
+		}
+	}
+
+	cx := make([]bn254.G1Affine, l)
+	dx := make([]bn254.G2Affine, l)
+	for i := 0; i < l; i++ {
+		ri, err := utils.RandomNonZeroScalar()
+		if err != nil {
+			return nil, fmt.Errorf("failed to update policy: %w", err)
+		}
+		mi1 := newPolicy.matrix.ComputeVector(i, e1)
+		yi := newPolicy.matrix.ComputeVector(i, vectorY)
+		rhoI := newPolicy.matrix.Rho(i)
+
+		// T^M_{i,1} = g1^(a * s * M_{i,1})
+		tExpMi1 := new(bn254.G1Affine).ScalarMultiplication(&token.g1ExpAS, mi1.BigInt(new(big.Int)))
+		// (g1^a)^(M_i · y)
+		g1ExpAYi := new(bn254.G1Affine).ScalarMultiplication(&pp.g1ExpA, yi.BigInt(new(big.Int)))
+		hRhoI := pp.h[rhoI]
+		negRi := new(fr.Element).Neg(ri)
+		// h_rho(i)^(-ri)
+		hRhoIExpNegRi := new(bn254.G1Affine).ScalarMultiplication(&hRhoI, negRi.BigInt(new(big.Int)))
+
+		cx[i] = *new(bn254.G1Affine).Add(tExpMi1, g1ExpAYi)
+		cx[i].Add(&cx[i], hRhoIExpNegRi)
+		dx[i] = *new(bn254.G2Affine).ScalarMultiplicationBase(ri.BigInt(new(big.Int)))
+	}
+
+	return &Waters11CPABECiphertext{
+		c:            ciphertext.c,
+		cPrime:       ciphertext.cPrime,
+		cx:           cx,
+		dx:           dx,
+		accessMatrix: newPolicy.matrix,
+	}, nil
+}
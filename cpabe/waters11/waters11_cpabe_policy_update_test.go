@@ -0,0 +1,92 @@
+package waters11
+
+import (
+	"errors"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	lsss2 "github.com/mmsyan/GoPairingBasedCryptography/access/lsss"
+	"testing"
+)
+
+// TestWatersCPABEUpdatePolicy 在 "1 and 2" 下加密，更新到 "3 and 4" 后，
+// 只满足新策略的私钥可以解密更新后的密文，只满足旧策略的私钥不再能解密
+func TestWatersCPABEUpdatePolicy(t *testing.T) {
+	instance, err := NewWaters11CPABEInstance([]fr.Element{fr.NewElement(1), fr.NewElement(2), fr.NewElement(3), fr.NewElement(4)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	pp, msk, err := instance.SetUp()
+	if err != nil {
+		t.Fatal(err)
+	}
+	p1 := &Waters11CPABEAccessPolicy{matrix: lsss2.NewLSSSMatrixFromBinaryTree(lsss2.And(lsss2.Leaf(fr.NewElement(1)), lsss2.Leaf(fr.NewElement(2))))}
+	p2 := &Waters11CPABEAccessPolicy{matrix: lsss2.NewLSSSMatrixFromBinaryTree(lsss2.And(lsss2.Leaf(fr.NewElement(3)), lsss2.Leaf(fr.NewElement(4))))}
+
+	oldUsk, err := instance.KeyGenerate(&Waters11CPABEAttributes{Attributes: []fr.Element{fr.NewElement(1), fr.NewElement(2)}}, msk, pp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	newUsk, err := instance.KeyGenerate(&Waters11CPABEAttributes{Attributes: []fr.Element{fr.NewElement(3), fr.NewElement(4)}}, msk, pp)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	message, err := new(bn254.GT).SetRandom()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ciphertext, token, err := instance.EncryptWithUpdateToken(&Waters11CPABEMessage{Message: *message}, p1, pp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := instance.Decrypt(ciphertext, newUsk); !errors.Is(err, ErrPolicyNotSatisfied) {
+		t.Fatalf("更新前 {3,4} 不应满足旧策略: %v", err)
+	}
+
+	updated, err := instance.UpdatePolicy(ciphertext, token, p2, pp)
+	if err != nil {
+		t.Fatalf("策略更新失败: %v", err)
+	}
+	recovered, err := instance.Decrypt(updated, newUsk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if recovered.Message != *message {
+		t.Fatal("更新后的密文解密结果与原始消息不一致")
+	}
+	if _, err := instance.Decrypt(updated, oldUsk); !errors.Is(err, ErrPolicyNotSatisfied) {
+		t.Fatalf("更新后 {1,2} 不应满足新策略: %v", err)
+	}
+}
+
+// TestWatersCPABEUpdatePolicyWrongToken 为其他密文签发的令牌应被拒绝
+func TestWatersCPABEUpdatePolicyWrongToken(t *testing.T) {
+	instance, err := NewWaters11CPABEInstance([]fr.Element{fr.NewElement(1), fr.NewElement(2)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	pp, _, err := instance.SetUp()
+	if err != nil {
+		t.Fatal(err)
+	}
+	policy := &Waters11CPABEAccessPolicy{matrix: lsss2.NewLSSSMatrixFromBinaryTree(lsss2.And(lsss2.Leaf(fr.NewElement(1)), lsss2.Leaf(fr.NewElement(2))))}
+	message := &Waters11CPABEMessage{Message: *new(bn254.GT).SetOne()}
+
+	ciphertext, _, err := instance.EncryptWithUpdateToken(message, policy, pp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, otherToken, err := instance.EncryptWithUpdateToken(message, policy, pp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := instance.UpdatePolicy(ciphertext, otherToken, policy, pp); !errors.Is(err, ErrInvalidUpdateToken) {
+		t.Fatalf("错误应包装 ErrInvalidUpdateToken: %v", err)
+	}
+
+	// 绑定正确 C' 但指数被篡改的令牌也应被拒绝
+	forged := &PolicyUpdateToken{cPrime: ciphertext.cPrime, g1ExpAS: otherToken.g1ExpAS}
+	if _, err := instance.UpdatePolicy(ciphertext, forged, policy, pp); !errors.Is(err, ErrInvalidUpdateToken) {
+		t.Fatalf("错误应包装 ErrInvalidUpdateToken: %v", err)
+	}
+}
@@ -0,0 +1,67 @@
+package waters11
+
+import (
+	"fmt"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"math/big"
+)
+
+// PunctureKey 从用户私钥中移除属性 attr，得到一把不再拥有该属性的新私钥，无需重新生成整把密钥。
+// 步骤:
+// 1. 删除 $K_{attr}$，并从属性列表中去掉 attr。
+// 2. 选取随机值 $t' \in \mathbb{Z}_p$，计算 $K' = K \cdot (g_1^a)^{t'}$，$L' = L \cdot g_2^{t'}$。
+// 3. 对剩余的每个属性 $x$，计算 $K'_x = K_x \cdot h_x^{t'}$。
+//
+// 重新随机化后新私钥等价于以 $t + t'$ 生成的密钥，与原私钥的分量互不相同，
+// 因此持有者无法把旧私钥中的 $K_{attr} = h_{attr}^t$ 拼接到新私钥上，
+// 打孔后的私钥在任何需要 attr 的策略下都无法解密，对不依赖 attr 的策略仍可正常解密。
+// 重新随机化需要公共参数中的 $g_1^a$ 与 $h_x$，因此调用方需同时提供 pp。
+//
+// 参数:
+//   - usk: 待打孔的用户私钥，不会被修改
+//   - attr: 要移除的属性
+//   - pp: 系统公共参数 PP
+//
+// 返回值:
+//   - *Waters11CPABEUserSecretKey: 打孔后的新私钥
+//   - error: 如果私钥不包含该属性或随机数生成失败，返回错误信息
+func (instance *Waters11CPABEInstance) PunctureKey(usk *Waters11CPABEUserSecretKey, attr fr.Element, pp *Waters11CPABEPublicParameters) (*Waters11CPABEUserSecretKey, error) {
+	if _, ok := usk.kx[attr]; !ok {
+		return nil, fmt.Errorf("failed to puncture key: attribute is not held by the key")
+	}
+
+	tPrime, err := new(fr.Element).SetRandom()
+	if err != nil {
+		return nil, fmt.Errorf("failed to puncture key: %w", err)
+	}
+	tPrimeBig := tPrime.BigInt(new(big.Int))
+
+	// k' = k * (g1^a)^t'
+	g1ExpATPrime := new(bn254.G1Affine).ScalarMultiplication(&pp.g1ExpA, tPrimeBig)
+	k := *new(bn254.G1Affine).Add(&usk.k, g1ExpATPrime)
+	// l' = l * g2^t'
+	g2ExpTPrime := new(bn254.G2Affine).ScalarMultiplicationBase(tPrimeBig)
+	l := *new(bn254.G2Affine).Add(&usk.l, g2ExpTPrime)
+
+	userAttributes := make([]fr.Element, 0, len(usk.userAttributes))
+	kx := make(map[fr.Element]bn254.G1Affine, len(usk.kx))
+	for _, x := range usk.userAttributes {
+		if x == attr {
+			continue
+		}
+		userAttributes = append(userAttributes, x)
+		// kx' = kx * hx^t'
+		hx := pp.h[x]
+		hxExpTPrime := new(bn254.G1Affine).ScalarMultiplication(&hx, tPrimeBig)
+		kxOld := usk.kx[x]
+		kx[x] = *new(bn254.G1Affine).Add(&kxOld, hxExpTPrime)
+	}
+
+	return &Waters11CPABEUserSecretKey{
+		userAttributes: userAttributes,
+		k:              k,
+		l:              l,
+		kx:             kx,
+	}, nil
+}
@@ -0,0 +1,101 @@
+package waters11
+
+import (
+	"fmt"
+	"github.com/mmsyan/GoPairingBasedCryptography/access/lsss"
+	"sort"
+)
+
+// RecordField 是结构化记录中的一个字段：字段明文及其访问策略。
+type RecordField struct {
+	Policy  *Waters11CPABEAccessPolicy
+	Message *Waters11CPABEMessage
+}
+
+// RecordEncryptor 在同一套公共参数上对记录的各个字段分别按各自的策略加密（字段级 ABE）。
+//
+// 所有字段共用 PP 中预先计算的 $e(g_1, g_2)^\alpha$，因此加密过程不需要任何配对运算。
+// 每个字段使用独立的秘密指数 s：若各字段共用 s，解密出任一字段的用户即可得到
+// $e(g_1, g_2)^{\alpha s}$ 并解密全部字段，字段级的访问控制将失效。
+// 解密时，同一记录中策略相同的字段共用一次线性组合求解（见 DecryptRecord）。
+type RecordEncryptor struct {
+	instance *Waters11CPABEInstance
+	pp       *Waters11CPABEPublicParameters
+}
+
+// NewRecordEncryptor 创建绑定到实例与公共参数的记录加密器
+//
+// 参数:
+//   - instance: Waters11 CP-ABE 实例
+//   - pp: 系统公共参数 PP
+//
+// 返回值:
+//   - *RecordEncryptor: 记录加密器
+func NewRecordEncryptor(instance *Waters11CPABEInstance, pp *Waters11CPABEPublicParameters) *RecordEncryptor {
+	return &RecordEncryptor{instance: instance, pp: pp}
+}
+
+// EncryptRecord 按字段名的字典序依次加密记录的每个字段。
+//
+// 参数:
+//   - fields: 字段名到 (策略, 明文) 的映射
+//
+// 返回值:
+//   - map[string]*Waters11CPABECiphertext: 字段名到密文的映射
+//   - error: 任一字段加密失败时返回指明字段名的错误
+func (e *RecordEncryptor) EncryptRecord(fields map[string]RecordField) (map[string]*Waters11CPABECiphertext, error) {
+	record := make(map[string]*Waters11CPABECiphertext, len(fields))
+	for _, name := range sortedFieldNames(fields) {
+		field := fields[name]
+		ciphertext, err := e.instance.Encrypt(field.Message, field.Policy, e.pp)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encrypt field %q: %w", name, err)
+		}
+		record[name] = ciphertext
+	}
+	return record, nil
+}
+
+// DecryptRecord 解密私钥有权访问的全部字段，策略不满足的字段被直接跳过。
+// 同一记录中访问矩阵相同（按 Fingerprint 判断）的字段共用一个解密计划，只做一次高斯消元。
+//
+// 参数:
+//   - record: EncryptRecord 返回的字段名到密文的映射
+//   - usk: 用户的私钥
+//
+// 返回值:
+//   - map[string]*Waters11CPABEMessage: 私钥满足策略的字段名到明文的映射
+//   - error: 除策略不满足以外的解密错误，指明字段名
+func (e *RecordEncryptor) DecryptRecord(record map[string]*Waters11CPABECiphertext, usk *Waters11CPABEUserSecretKey) (map[string]*Waters11CPABEMessage, error) {
+	plans := make(map[[32]byte]*lsss.DecryptionPlan)
+	messages := make(map[string]*Waters11CPABEMessage)
+	for _, name := range sortedFieldNames(record) {
+		ciphertext := record[name]
+		fingerprint := ciphertext.accessMatrix.Fingerprint()
+		plan, ok := plans[fingerprint]
+		if !ok {
+			// 不满足策略时缓存 nil，后续相同策略的字段直接跳过
+			plan, _ = lsss.PrecomputeDecryption(ciphertext.accessMatrix, usk.userAttributes)
+			plans[fingerprint] = plan
+		}
+		if plan == nil {
+			continue
+		}
+		message, err := e.instance.DecryptWithPlan(ciphertext, usk, plan)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt field %q: %w", name, err)
+		}
+		messages[name] = message
+	}
+	return messages, nil
+}
+
+// sortedFieldNames 返回映射的全部键并按字典序排列，保证处理顺序与错误报告确定
+func sortedFieldNames[V any](m map[string]V) []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
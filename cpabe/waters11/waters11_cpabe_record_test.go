@@ -0,0 +1,70 @@
+package waters11
+
+import (
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	lsss2 "github.com/mmsyan/GoPairingBasedCryptography/access/lsss"
+	"testing"
+)
+
+// TestRecordEncryptor 5 个字段各用不同策略加密，持有 {1,2} 的私钥恰好能解密其中 3 个字段
+func TestRecordEncryptor(t *testing.T) {
+	a1, a2, a3, a4 := fr.NewElement(1), fr.NewElement(2), fr.NewElement(3), fr.NewElement(4)
+	instance, err := NewWaters11CPABEInstance([]fr.Element{a1, a2, a3, a4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	pp, msk, err := instance.SetUp()
+	if err != nil {
+		t.Fatal(err)
+	}
+	policy := func(tree *lsss2.BinaryAccessTree) *Waters11CPABEAccessPolicy {
+		return &Waters11CPABEAccessPolicy{matrix: lsss2.NewLSSSMatrixFromBinaryTree(tree)}
+	}
+	policies := map[string]*Waters11CPABEAccessPolicy{
+		"name":    policy(lsss2.Leaf(a1)),
+		"email":   policy(lsss2.And(lsss2.Leaf(a1), lsss2.Leaf(a2))),
+		"phone":   policy(lsss2.Leaf(a2)),
+		"salary":  policy(lsss2.And(lsss2.Leaf(a3), lsss2.Leaf(a4))),
+		"address": policy(lsss2.And(lsss2.Leaf(a1), lsss2.Leaf(a3))),
+	}
+	fields := make(map[string]RecordField, len(policies))
+	for name, p := range policies {
+		message, err := new(bn254.GT).SetRandom()
+		if err != nil {
+			t.Fatal(err)
+		}
+		fields[name] = RecordField{Policy: p, Message: &Waters11CPABEMessage{Message: *message}}
+	}
+
+	encryptor := NewRecordEncryptor(instance, pp)
+	record, err := encryptor.EncryptRecord(fields)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(record) != len(fields) {
+		t.Fatalf("密文字段数 %d, 期望 %d", len(record), len(fields))
+	}
+
+	usk, err := instance.KeyGenerate(&Waters11CPABEAttributes{Attributes: []fr.Element{a1, a2}}, msk, pp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decrypted, err := encryptor.DecryptRecord(record, usk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"email", "name", "phone"}
+	if len(decrypted) != len(want) {
+		t.Fatalf("解密出 %d 个字段, 期望 %d 个", len(decrypted), len(want))
+	}
+	for _, name := range want {
+		message, ok := decrypted[name]
+		if !ok {
+			t.Fatalf("字段 %q 应当可以解密", name)
+		}
+		if message.Message != fields[name].Message.Message {
+			t.Fatalf("字段 %q 解密结果与原始消息不一致", name)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package waters11
+
+import (
+	"fmt"
+	"github.com/mmsyan/GoPairingBasedCryptography/access/lsss"
+)
+
+// EncryptByReference 与 Encrypt 相同地加密消息，但密文只保存访问矩阵的指纹，矩阵本身登记在 registry 中。
+//
+// Encrypt 生成的密文各自持有访问矩阵；当密文集合中的策略是分别构造的（例如逐条从存储中解析），
+// 同一个策略会在每个密文中重复保存一份。按引用保存时，结构相同的策略在注册表中只有一份，
+// 每个密文只多出 32 字节的指纹。这样的密文需要用 DecryptWithRegistry、DecryptWithPolicy 解密，
+// 或者先用 Attach 恢复为普通密文。
+//
+// 参数:
+//   - message: 要加密的明文消息M
+//   - accessPolicy: 访问策略A=(M, \rho)
+//   - pp: 系统公共参数 PP
+//   - registry: 策略注册表，访问矩阵在其中登记
+//
+// 返回值:
+//   - *Waters11CPABECiphertext: 只引用策略指纹的密文
+//   - error: 如果加密失败，返回错误信息
+func (instance *Waters11CPABEInstance) EncryptByReference(message *Waters11CPABEMessage, accessPolicy *Waters11CPABEAccessPolicy, pp *Waters11CPABEPublicParameters, registry *lsss.PolicyRegistry) (*Waters11CPABECiphertext, error) {
+	ciphertext, err := instance.Encrypt(message, accessPolicy, pp)
+	if err != nil {
+		return nil, err
+	}
+	ciphertext.policyFingerprint = registry.Register(accessPolicy.matrix)
+	ciphertext.accessMatrix = nil
+	return ciphertext, nil
+}
+
+// PolicyFingerprint 返回密文访问矩阵的指纹，密文按值或按引用保存策略时结果相同。
+//
+// 返回值:
+//   - [32]byte: 访问矩阵指纹
+func (ct *Waters11CPABECiphertext) PolicyFingerprint() [32]byte {
+	if ct.accessMatrix == nil {
+		return ct.policyFingerprint
+	}
+	return ct.accessMatrix.Fingerprint()
+}
+
+// Attach 从注册表中取出密文引用的访问矩阵，返回持有该矩阵的密文副本，原密文不变。
+// 副本与注册表共享矩阵，可用于 Decrypt、DecryptWithPlan、PolicyAttributes 等需要矩阵的操作。
+//
+// 参数:
+//   - registry: 加密时使用的策略注册表
+//
+// 返回值:
+//   - *Waters11CPABECiphertext: 持有访问矩阵的密文；原密文已持有矩阵时直接返回原密文
+//   - error: 注册表中没有该指纹时返回包装了 lsss.ErrUnknownPolicy 的错误
+func (ct *Waters11CPABECiphertext) Attach(registry *lsss.PolicyRegistry) (*Waters11CPABECiphertext, error) {
+	if ct.accessMatrix != nil {
+		return ct, nil
+	}
+	matrix, err := registry.Lookup(ct.policyFingerprint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to attach access policy: %w", err)
+	}
+	attached := *ct
+	attached.accessMatrix = matrix
+	return &attached, nil
+}
+
+// DecryptWithRegistry 从注册表中查找密文引用的访问矩阵后解密，也可用于按值保存策略的密文。
+//
+// 参数:
+//   - ciphertext: 要解密的密文
+//   - usk: 用户的私钥
+//   - registry: 加密时使用的策略注册表
+//
+// 返回值:
+//   - *Waters11CPABEMessage: 解密后的明文消息
+//   - error: 注册表中没有该策略、属性不满足策略或解密失败时返回错误信息
+func (instance *Waters11CPABEInstance) DecryptWithRegistry(ciphertext *Waters11CPABECiphertext, usk *Waters11CPABEUserSecretKey, registry *lsss.PolicyRegistry) (*Waters11CPABEMessage, error) {
+	attached, err := ciphertext.Attach(registry)
+	if err != nil {
+		instance.audit(ciphertext, nil, err)
+		return nil, fmt.Errorf("decrypt failed: %w", err)
+	}
+	return instance.Decrypt(attached, usk)
+}
+
+// DecryptWithPolicy 使用调用方显式提供的访问策略解密，策略必须与密文引用的指纹一致。
+//
+// 参数:
+//   - ciphertext: 要解密的密文
+//   - usk: 用户的私钥
+//   - accessPolicy: 加密时使用的访问策略
+//
+// 返回值:
+//   - *Waters11CPABEMessage: 解密后的明文消息
+//   - error: 策略与密文不一致时返回包装了 ErrPolicyMismatch 的错误；属性不满足策略或解密失败时返回错误信息
+func (instance *Waters11CPABEInstance) DecryptWithPolicy(ciphertext *Waters11CPABECiphertext, usk *Waters11CPABEUserSecretKey, accessPolicy *Waters11CPABEAccessPolicy) (*Waters11CPABEMessage, error) {
+	if accessPolicy.matrix.Fingerprint() != ciphertext.PolicyFingerprint() {
+		instance.audit(ciphertext, nil, ErrPolicyMismatch)
+		return nil, fmt.Errorf("decrypt failed: %w", ErrPolicyMismatch)
+	}
+	attached := *ciphertext
+	attached.accessMatrix = accessPolicy.matrix
+	return instance.Decrypt(&attached, usk)
+}
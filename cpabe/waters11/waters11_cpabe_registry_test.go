@@ -0,0 +1,141 @@
+package waters11
+
+import (
+	"errors"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	lsss2 "github.com/mmsyan/GoPairingBasedCryptography/access/lsss"
+	"runtime"
+	"testing"
+)
+
+// registryFixture 生成 k 个属性对组成的策略 (1 and 2) or (3 and 4) or ... 以及持有属性 1、2 的私钥。
+// newPolicy 每次调用都重新构造一份结构相同的策略，模拟逐条从存储中解析出的密文策略。
+func registryFixture(tb testing.TB, k int) (*Waters11CPABEInstance, *Waters11CPABEPublicParameters, *Waters11CPABEUserSecretKey, func() *Waters11CPABEAccessPolicy) {
+	attributes := make([]fr.Element, 2*k)
+	for i := range attributes {
+		attributes[i] = fr.NewElement(uint64(i + 1))
+	}
+	instance, err := NewWaters11CPABEInstance(attributes)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	pp, msk, err := instance.SetUp()
+	if err != nil {
+		tb.Fatal(err)
+	}
+	usk, err := instance.KeyGenerate(&Waters11CPABEAttributes{Attributes: attributes[:2]}, msk, pp)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	newPolicy := func() *Waters11CPABEAccessPolicy {
+		tree := lsss2.And(lsss2.Leaf(attributes[0]), lsss2.Leaf(attributes[1]))
+		for i := 1; i < k; i++ {
+			tree = lsss2.Or(tree, lsss2.And(lsss2.Leaf(attributes[2*i]), lsss2.Leaf(attributes[2*i+1])))
+		}
+		return &Waters11CPABEAccessPolicy{matrix: lsss2.NewLSSSMatrixFromBinaryTree(tree)}
+	}
+	return instance, pp, usk, newPolicy
+}
+
+// TestWatersCPABEEncryptByReference 在同一注册表下加密 100 条消息并逐一解密；按引用保存的密文不能直接 Decrypt，
+// 显式提供的策略必须与指纹一致
+func TestWatersCPABEEncryptByReference(t *testing.T) {
+	instance, pp, usk, newPolicy := registryFixture(t, 3)
+	registry := lsss2.NewPolicyRegistry()
+	ciphertexts := make([]*Waters11CPABECiphertext, 100)
+	messages := make([]bn254.GT, len(ciphertexts))
+	for i := range ciphertexts {
+		m, err := new(bn254.GT).SetRandom()
+		if err != nil {
+			t.Fatal(err)
+		}
+		messages[i] = *m
+		if ciphertexts[i], err = instance.EncryptByReference(&Waters11CPABEMessage{Message: *m}, newPolicy(), pp, registry); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if registry.Len() != 1 {
+		t.Fatalf("registry should hold one policy, got %d", registry.Len())
+	}
+	for i, ct := range ciphertexts {
+		recovered, err := instance.DecryptWithRegistry(ct, usk, registry)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !recovered.Message.Equal(&messages[i]) {
+			t.Fatalf("第 %d 个密文解密结果与原始消息不匹配", i)
+		}
+	}
+
+	ct := ciphertexts[0]
+	if _, err := instance.Decrypt(ct, usk); !errors.Is(err, ErrPolicyDetached) {
+		t.Fatalf("expected ErrPolicyDetached, got %v", err)
+	}
+	recovered, err := instance.DecryptWithPolicy(ct, usk, newPolicy())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !recovered.Message.Equal(&messages[0]) {
+		t.Fatal("DecryptWithPolicy 解密结果与原始消息不匹配")
+	}
+	weak := &Waters11CPABEAccessPolicy{matrix: lsss2.NewLSSSMatrixFromBinaryTree(lsss2.Leaf(fr.NewElement(1)))}
+	if _, err := instance.DecryptWithPolicy(ct, usk, weak); !errors.Is(err, ErrPolicyMismatch) {
+		t.Fatalf("expected ErrPolicyMismatch, got %v", err)
+	}
+	if _, err := instance.DecryptWithRegistry(ct, usk, lsss2.NewPolicyRegistry()); !errors.Is(err, lsss2.ErrUnknownPolicy) {
+		t.Fatalf("expected lsss.ErrUnknownPolicy, got %v", err)
+	}
+	attached, err := ct.Attach(registry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if attached.PolicyFingerprint() != ct.PolicyFingerprint() || len(attached.PolicyAttributes()) != 6 {
+		t.Fatal("attached ciphertext should carry the registered policy")
+	}
+}
+
+// retainedHeap 返回 build 的结果在垃圾回收后仍占用的堆内存字节数
+func retainedHeap(build func() any) int64 {
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	v := build()
+	runtime.GC()
+	runtime.ReadMemStats(&after)
+	runtime.KeepAlive(v)
+	return int64(after.HeapAlloc) - int64(before.HeapAlloc)
+}
+
+// TestWatersCPABEEncryptByReferenceMemory 比较 100 个密文各自持有策略与共用注册表时的常驻内存
+func TestWatersCPABEEncryptByReferenceMemory(t *testing.T) {
+	const n = 100
+	instance, pp, _, newPolicy := registryFixture(t, 10)
+	message := &Waters11CPABEMessage{Message: *new(bn254.GT).SetOne()}
+	// 预先构造固定底预计算表，使其不计入两种方式的内存
+	if _, err := instance.Encrypt(message, newPolicy(), pp); err != nil {
+		t.Fatal(err)
+	}
+
+	byValue := retainedHeap(func() any {
+		ciphertexts := make([]*Waters11CPABECiphertext, n)
+		for i := range ciphertexts {
+			ciphertexts[i], _ = instance.Encrypt(message, newPolicy(), pp)
+		}
+		return ciphertexts
+	})
+	byReference := retainedHeap(func() any {
+		registry := lsss2.NewPolicyRegistry()
+		ciphertexts := make([]*Waters11CPABECiphertext, n)
+		for i := range ciphertexts {
+			ciphertexts[i], _ = instance.EncryptByReference(message, newPolicy(), pp, registry)
+		}
+		return []any{registry, ciphertexts}
+	})
+	// 实例缓存的预计算表在两次测量之间必须保持可达，否则会在第二次测量中被回收
+	runtime.KeepAlive(instance)
+	t.Logf("%d 个密文的常驻内存: 按值 %d 字节，按引用 %d 字节", n, byValue, byReference)
+	if byReference >= byValue {
+		t.Fatalf("按引用保存策略应减少内存: 按值 %d 字节，按引用 %d 字节", byValue, byReference)
+	}
+}
@@ -0,0 +1,52 @@
+package waters11
+
+import (
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/mmsyan/GoPairingBasedCryptography/access/lsss"
+	"github.com/mmsyan/GoPairingBasedCryptography/internal/selftest"
+)
+
+// SelfTest 执行一次最小的 SetUp → KeyGenerate → Encrypt → Decrypt 往返
+// （策略 (1 and 2) or 3，用户持有 {1, 2}），解密结果与随机消息不一致时返回错误。
+// 可在服务启动时调用，尽早发现构建或运行环境的问题。
+//
+// 返回值:
+//   - error: 任一步骤失败或解密结果与原始消息不一致时返回错误
+func SelfTest() error {
+	return selftest.Run("waters11 cpabe", selfTestRoundTrip)
+}
+
+// selfTestRoundTrip 生成实例、公共参数与持有 {1, 2} 的用户私钥，并返回绑定它们的加密与解密闭包
+func selfTestRoundTrip() (*selftest.RoundTrip[bn254.GT, *Waters11CPABECiphertext], error) {
+	instance, err := NewWaters11CPABEInstanceByInt64Slice([]int64{1, 2, 3})
+	if err != nil {
+		return nil, err
+	}
+	pp, msk, err := instance.SetUp()
+	if err != nil {
+		return nil, err
+	}
+	a1, a2, a3 := fr.NewElement(1), fr.NewElement(2), fr.NewElement(3)
+	usk, err := instance.KeyGenerate(&Waters11CPABEAttributes{Attributes: []fr.Element{a1, a2}}, msk, pp)
+	if err != nil {
+		return nil, err
+	}
+	policy := &Waters11CPABEAccessPolicy{
+		matrix: lsss.NewLSSSMatrixFromBinaryTree(lsss.Or(lsss.And(lsss.Leaf(a1), lsss.Leaf(a2)), lsss.Leaf(a3))),
+	}
+	return &selftest.RoundTrip[bn254.GT, *Waters11CPABECiphertext]{
+		NewMessage: selftest.RandomGT,
+		Encrypt: func(m bn254.GT) (*Waters11CPABECiphertext, error) {
+			return instance.Encrypt(&Waters11CPABEMessage{Message: m}, policy, pp)
+		},
+		Decrypt: func(ciphertext *Waters11CPABECiphertext) (bn254.GT, error) {
+			decrypted, err := instance.Decrypt(ciphertext, usk)
+			if err != nil {
+				return bn254.GT{}, err
+			}
+			return decrypted.Message, nil
+		},
+		Equal: selftest.EqualGT,
+	}, nil
+}
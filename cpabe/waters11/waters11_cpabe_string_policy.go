@@ -0,0 +1,28 @@
+package waters11
+
+import (
+	"fmt"
+	"github.com/mmsyan/GoPairingBasedCryptography/access/lsss"
+)
+
+// EncryptString 在以布尔公式字符串表示的访问策略下加密消息，例如 "(Admin or Manager) and Active_User"。
+// 公式由 lsss.ParseBooleanFormula 解析，属性名经 hash.ToField 映射为属性元素，
+// 因此实例应由 NewWaters11CPABEInstanceFromStrings 构造，用户属性应同样由属性名经 hash.ToField 得到。
+//
+// 参数:
+//   - message: 要加密的明文消息M
+//   - policy: 布尔公式形式的访问策略
+//   - pp: 系统公共参数 PP
+//
+// 返回值:
+//   - *Waters11CPABECiphertext: 生成的密文
+//   - error: 公式解析失败时返回包装了 *lsss.ParseError（可用 errors.As 取出出错位置）或策略规模错误的错误；
+//     策略中的属性不在属性宇宙中或加密失败时返回错误信息
+func (instance *Waters11CPABEInstance) EncryptString(message *Waters11CPABEMessage, policy string, pp *Waters11CPABEPublicParameters) (*Waters11CPABECiphertext, error) {
+	tree, err := lsss.ParseBooleanFormula(policy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse access policy %q: %w", policy, err)
+	}
+	accessPolicy := &Waters11CPABEAccessPolicy{matrix: lsss.NewLSSSMatrixFromBinaryTree(tree)}
+	return instance.Encrypt(message, accessPolicy, pp)
+}
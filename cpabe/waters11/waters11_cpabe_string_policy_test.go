@@ -0,0 +1,74 @@
+package waters11
+
+import (
+	"errors"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	lsss2 "github.com/mmsyan/GoPairingBasedCryptography/access/lsss"
+	"github.com/mmsyan/GoPairingBasedCryptography/hash"
+	"testing"
+)
+
+// TestWatersCPABEEncryptString 以布尔公式字符串加密，持有 Manager、Department_Head、Active_User 的用户可以解密，缺少 Active_User 的用户不能
+func TestWatersCPABEEncryptString(t *testing.T) {
+	names := []string{"Admin", "Manager", "Department_Head", "Active_User"}
+	instance, err := NewWaters11CPABEInstanceFromStrings(names...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pp, msk, err := instance.SetUp()
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, _ := new(bn254.GT).SetRandom()
+	message := &Waters11CPABEMessage{Message: *m}
+	ciphertext, err := instance.EncryptString(message, "(Admin or (Manager and Department_Head)) and Active_User", pp)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyFor := func(attrs ...string) *Waters11CPABEUserSecretKey {
+		elements := make([]fr.Element, len(attrs))
+		for i, attr := range attrs {
+			elements[i] = hash.ToField(attr)
+		}
+		usk, err := instance.KeyGenerate(&Waters11CPABEAttributes{Attributes: elements}, msk, pp)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return usk
+	}
+	recovered, err := instance.Decrypt(ciphertext, keyFor("Manager", "Department_Head", "Active_User"))
+	if err != nil {
+		t.Fatalf("decrypt failed: %v", err)
+	}
+	if !recovered.Message.Equal(m) {
+		t.Fatal("recovered message does not match")
+	}
+	if _, err := instance.Decrypt(ciphertext, keyFor("Admin", "Manager", "Department_Head")); !errors.Is(err, ErrPolicyNotSatisfied) {
+		t.Fatalf("expected ErrPolicyNotSatisfied, got %v", err)
+	}
+}
+
+// TestWatersCPABEEncryptStringErrors 公式不合法时返回可用 errors.As 取出的 *lsss.ParseError；属性不在宇宙中时返回 ErrInvalidAttribute
+func TestWatersCPABEEncryptStringErrors(t *testing.T) {
+	instance, err := NewWaters11CPABEInstanceFromStrings("Admin", "Active_User")
+	if err != nil {
+		t.Fatal(err)
+	}
+	pp, _, err := instance.SetUp()
+	if err != nil {
+		t.Fatal(err)
+	}
+	message := &Waters11CPABEMessage{}
+	message.Message.SetOne()
+
+	_, err = instance.EncryptString(message, "Admin and (Active_User", pp)
+	var parseErr *lsss2.ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("expected *lsss.ParseError, got %v", err)
+	}
+	if _, err := instance.EncryptString(message, "Admin and Guest", pp); !errors.Is(err, ErrInvalidAttribute) {
+		t.Fatalf("expected ErrInvalidAttribute, got %v", err)
+	}
+}
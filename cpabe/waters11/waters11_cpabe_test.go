@@ -1,10 +1,13 @@
 package waters11
 
 import (
+	"errors"
 	"fmt"
 	"github.com/consensys/gnark-crypto/ecc/bn254"
 	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
 	lsss2 "github.com/mmsyan/GoPairingBasedCryptography/access/lsss"
+	"github.com/mmsyan/GoPairingBasedCryptography/hash"
+	"strings"
 	"testing"
 )
 
@@ -115,3 +118,263 @@ func TestWatersCPABE2(t *testing.T) {
 	}
 	fmt.Println(recoveredMessage.Message)
 }
+
+// TestWatersCPABEPunctureKey 持有 {A,B,C} 的私钥在 B 上打孔后，无法解密 "B and C"，仍可解密 "A or C"
+func TestWatersCPABEPunctureKey(t *testing.T) {
+	attrA, attrB, attrC := fr.NewElement(1), fr.NewElement(2), fr.NewElement(3)
+	instance, err := NewWaters11CPABEInstance([]fr.Element{attrA, attrB, attrC, fr.NewElement(4)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	pp, msk, err := instance.SetUp()
+	if err != nil {
+		t.Fatal(err)
+	}
+	usk, err := instance.KeyGenerate(&Waters11CPABEAttributes{Attributes: []fr.Element{attrA, attrB, attrC}}, msk, pp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	punctured, err := instance.PunctureKey(usk, attrB, pp)
+	if err != nil {
+		t.Fatalf("打孔失败: %v", err)
+	}
+	if _, ok := punctured.kx[attrB]; ok {
+		t.Fatal("打孔后的私钥仍包含属性 B")
+	}
+	if punctured.k.Equal(&usk.k) || punctured.l.Equal(&usk.l) {
+		t.Fatal("打孔后的私钥未重新随机化")
+	}
+
+	message, err := new(bn254.GT).SetRandom()
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := &Waters11CPABEMessage{Message: *message}
+
+	// B and C: 打孔前可解密，打孔后失败
+	andPolicy := &Waters11CPABEAccessPolicy{
+		matrix: lsss2.NewLSSSMatrixFromBinaryTree(lsss2.And(lsss2.Leaf(attrB), lsss2.Leaf(attrC))),
+	}
+	ciphertext, err := instance.Encrypt(m, andPolicy, pp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	recovered, err := instance.Decrypt(ciphertext, usk)
+	if err != nil || !recovered.Message.Equal(message) {
+		t.Fatalf("打孔前的私钥应能解密 B and C: %v", err)
+	}
+	if _, err := instance.Decrypt(ciphertext, punctured); err == nil {
+		t.Fatal("打孔后的私钥不应能解密 B and C")
+	}
+
+	// A or C: 打孔后仍可解密
+	orPolicy := &Waters11CPABEAccessPolicy{
+		matrix: lsss2.NewLSSSMatrixFromBinaryTree(lsss2.Or(lsss2.Leaf(attrA), lsss2.Leaf(attrC))),
+	}
+	ciphertext, err = instance.Encrypt(m, orPolicy, pp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	recovered, err = instance.Decrypt(ciphertext, punctured)
+	if err != nil {
+		t.Fatalf("打孔后的私钥应能解密 A or C: %v", err)
+	}
+	if !recovered.Message.Equal(message) {
+		t.Fatal("解密消息与原始消息不匹配")
+	}
+
+	if _, err := instance.PunctureKey(punctured, attrB, pp); err == nil {
+		t.Fatal("对不包含的属性打孔应返回错误")
+	}
+}
+
+// TestWatersCPABEAttributeNotInUniverse 属性集合中只有一个属性不在宇宙中时，错误信息应准确指出该属性
+func TestWatersCPABEAttributeNotInUniverse(t *testing.T) {
+	instance, err := NewWaters11CPABEInstance([]fr.Element{fr.NewElement(1), fr.NewElement(2), fr.NewElement(3)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	pp, msk, err := instance.SetUp()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bad := fr.NewElement(99)
+	badBytes := bad.Bytes()
+	want := fmt.Sprintf("attribute %x not in universe", badBytes[:])
+
+	_, err = instance.KeyGenerate(&Waters11CPABEAttributes{Attributes: []fr.Element{fr.NewElement(1), bad, fr.NewElement(3)}}, msk, pp)
+	if err == nil {
+		t.Fatal("包含非法属性的密钥生成应当失败")
+	}
+	if !errors.Is(err, ErrInvalidAttribute) {
+		t.Fatalf("错误应包装 ErrInvalidAttribute: %v", err)
+	}
+	if !strings.Contains(err.Error(), want) {
+		t.Fatalf("错误信息未指出非法属性: %v", err)
+	}
+
+	policy := &Waters11CPABEAccessPolicy{
+		matrix: lsss2.NewLSSSMatrixFromBinaryTree(lsss2.And(lsss2.Leaf(fr.NewElement(2)), lsss2.Leaf(bad))),
+	}
+	message, _ := new(bn254.GT).SetRandom()
+	_, err = instance.Encrypt(&Waters11CPABEMessage{Message: *message}, policy, pp)
+	if err == nil {
+		t.Fatal("包含非法属性的策略加密应当失败")
+	}
+	if !strings.Contains(err.Error(), want) {
+		t.Fatalf("错误信息未指出非法属性: %v", err)
+	}
+	ok := fr.NewElement(2)
+	okBytes := ok.Bytes()
+	if strings.Contains(err.Error(), fmt.Sprintf("%x", okBytes[:])) {
+		t.Fatalf("错误信息不应指向合法属性: %v", err)
+	}
+}
+
+// TestWatersCPABEPolicyNotSatisfied 属性不满足策略时，解密错误应包装 ErrPolicyNotSatisfied
+func TestWatersCPABEPolicyNotSatisfied(t *testing.T) {
+	instance, err := NewWaters11CPABEInstance([]fr.Element{fr.NewElement(1), fr.NewElement(2), fr.NewElement(3)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	pp, msk, err := instance.SetUp()
+	if err != nil {
+		t.Fatal(err)
+	}
+	usk, err := instance.KeyGenerate(&Waters11CPABEAttributes{Attributes: []fr.Element{fr.NewElement(3)}}, msk, pp)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	policy := &Waters11CPABEAccessPolicy{
+		matrix: lsss2.NewLSSSMatrixFromBinaryTree(lsss2.And(lsss2.Leaf(fr.NewElement(1)), lsss2.Leaf(fr.NewElement(2)))),
+	}
+	message, err := new(bn254.GT).SetRandom()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ciphertext, err := instance.Encrypt(&Waters11CPABEMessage{Message: *message}, policy, pp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = instance.Decrypt(ciphertext, usk)
+	if !errors.Is(err, ErrPolicyNotSatisfied) {
+		t.Fatalf("错误应包装 ErrPolicyNotSatisfied: %v", err)
+	}
+}
+
+// TestWatersCPABEAtLeastThreshold 2-of-3 门限策略的密文可被任意两个属性的私钥解密，只有一个属性的私钥无法解密
+func TestWatersCPABEAtLeastThreshold(t *testing.T) {
+	instance, err := NewWaters11CPABEInstanceFromStrings("A", "B", "C")
+	if err != nil {
+		t.Fatal(err)
+	}
+	pp, msk, err := instance.SetUp()
+	if err != nil {
+		t.Fatal(err)
+	}
+	policy := &Waters11CPABEAccessPolicy{matrix: lsss2.NewLSSSMatrixFromBinaryTree(lsss2.AtLeast(2, "A", "B", "C"))}
+	m, err := new(bn254.GT).SetRandom()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ciphertext, err := instance.Encrypt(&Waters11CPABEMessage{Message: *m}, policy, pp)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, attrs := range [][]string{{"A", "B"}, {"A", "C"}, {"B", "C"}, {"A", "B", "C"}, {"A"}, {"C"}} {
+		elements := make([]fr.Element, len(attrs))
+		for i, attr := range attrs {
+			elements[i] = hash.ToField(attr)
+		}
+		usk, err := instance.KeyGenerate(&Waters11CPABEAttributes{Attributes: elements}, msk, pp)
+		if err != nil {
+			t.Fatal(err)
+		}
+		decrypted, err := instance.Decrypt(ciphertext, usk)
+		if len(attrs) < 2 {
+			if !errors.Is(err, ErrPolicyNotSatisfied) {
+				t.Fatalf("%v: 期望 ErrPolicyNotSatisfied，得到 %v", attrs, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%v: 解密失败: %v", attrs, err)
+		}
+		if !decrypted.Message.Equal(m) {
+			t.Fatalf("%v: 解密结果与原始消息不一致", attrs)
+		}
+	}
+}
+
+// TestWatersCPABEDecryptMissingKeyComponent 私钥声称持有属性 2 但缺少 K_2 时，解密返回指明该属性的错误而不是错误的明文
+func TestWatersCPABEDecryptMissingKeyComponent(t *testing.T) {
+	instance, _, usk, ciphertexts, _ := planFixture(t, 1, 1)
+	missing := fr.NewElement(2)
+	kx := make(map[fr.Element]bn254.G1Affine, len(usk.kx))
+	for x, kX := range usk.kx {
+		if !x.Equal(&missing) {
+			kx[x] = kX
+		}
+	}
+	incomplete := *usk
+	incomplete.kx = kx
+
+	_, err := instance.Decrypt(ciphertexts[0], &incomplete)
+	if !errors.Is(err, ErrMissingKeyComponent) {
+		t.Fatalf("expected ErrMissingKeyComponent, got %v", err)
+	}
+	b := missing.Bytes()
+	if want := fmt.Sprintf("missing key component for attribute %x", b[:]); !strings.Contains(err.Error(), want) {
+		t.Fatalf("error %q does not name the missing attribute", err)
+	}
+}
+
+// TestWatersCPABEDecryptNonPrefixRows 满足的行不是矩阵的前若干行时，每一行必须使用与之对应的重构系数，
+// 否则解密得到错误的明文或越界
+func TestWatersCPABEDecryptNonPrefixRows(t *testing.T) {
+	instance, err := NewWaters11CPABEInstanceFromStrings("A", "B", "C", "D")
+	if err != nil {
+		t.Fatal(err)
+	}
+	pp, msk, err := instance.SetUp()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tree := lsss2.Or(
+		lsss2.Leaf(hash.ToField("A")),
+		lsss2.And(
+			lsss2.Leaf(hash.ToField("B")),
+			lsss2.Or(lsss2.Leaf(hash.ToField("C")), lsss2.Leaf(hash.ToField("D"))),
+		),
+	)
+	policy := &Waters11CPABEAccessPolicy{matrix: lsss2.NewLSSSMatrixFromBinaryTree(tree)}
+	m, err := new(bn254.GT).SetRandom()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ciphertext, err := instance.Encrypt(&Waters11CPABEMessage{Message: *m}, policy, pp)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, attrs := range [][]string{{"B", "C"}, {"B", "D"}, {"D", "B"}, {"A"}} {
+		elements := make([]fr.Element, len(attrs))
+		for i, attr := range attrs {
+			elements[i] = hash.ToField(attr)
+		}
+		usk, err := instance.KeyGenerate(&Waters11CPABEAttributes{Attributes: elements}, msk, pp)
+		if err != nil {
+			t.Fatal(err)
+		}
+		decrypted, err := instance.Decrypt(ciphertext, usk)
+		if err != nil {
+			t.Fatalf("%v: 解密失败: %v", attrs, err)
+		}
+		if !decrypted.Message.Equal(m) {
+			t.Fatalf("%v: 解密结果与原始消息不一致", attrs)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package waters11
 import (
 	"fmt"
 	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/mmsyan/GoPairingBasedCryptography/hash"
 )
 
 func NewWaters11CPABEInstance(universe []fr.Element) (*Waters11CPABEInstance, error) {
@@ -15,6 +16,35 @@ func NewWaters11CPABEInstance(universe []fr.Element) (*Waters11CPABEInstance, er
 	}, nil
 }
 
+// NewWaters11CPABEInstanceFromElements 用可变参数形式的属性元素构造属性宇宙，等价于 NewWaters11CPABEInstance(attrs)
+//
+// 参数:
+//   - attrs: 属性宇宙中的全部属性
+//
+// 返回值:
+//   - *Waters11CPABEInstance: CP-ABE 实例
+//   - error: 目前总是返回 nil，与其他构造函数保持一致
+func NewWaters11CPABEInstanceFromElements(attrs ...fr.Element) (*Waters11CPABEInstance, error) {
+	return NewWaters11CPABEInstance(attrs)
+}
+
+// NewWaters11CPABEInstanceFromStrings 用属性字符串构造属性宇宙，每个字符串经 hash.ToField 映射为属性元素，
+// 与 lsss.LeafFromString 的映射方式一致，因此可以直接配合基于字符串构造的访问策略使用
+//
+// 参数:
+//   - attrs: 属性宇宙中的全部属性名
+//
+// 返回值:
+//   - *Waters11CPABEInstance: CP-ABE 实例
+//   - error: 目前总是返回 nil，与其他构造函数保持一致
+func NewWaters11CPABEInstanceFromStrings(attrs ...string) (*Waters11CPABEInstance, error) {
+	universe := make([]fr.Element, len(attrs))
+	for i, attr := range attrs {
+		universe[i] = hash.ToField(attr)
+	}
+	return NewWaters11CPABEInstance(universe)
+}
+
 func NewWaters11CPABEInstanceByInt64Slice(universe []int64) (*Waters11CPABEInstance, error) {
 	attributesUniverse := make(map[fr.Element]struct{}, len(universe))
 	for _, u := range universe {
@@ -40,11 +70,13 @@ func NewWaters11CPABEInstanceByInt64Pair(start, end int64) (*Waters11CPABEInstan
 	}, nil
 }
 
-func (instance *Waters11CPABEInstance) checkAttributes(attributes []fr.Element) bool {
+// checkAttributes 检查属性是否都在属性宇宙中，返回的错误指明第一个不在宇宙中的属性（十六进制）
+func (instance *Waters11CPABEInstance) checkAttributes(attributes []fr.Element) error {
 	for _, a := range attributes {
 		if _, ok := instance.universe[a]; !ok {
-			return false
+			b := a.Bytes()
+			return fmt.Errorf("attribute %x not in universe: %w", b[:], ErrInvalidAttribute)
 		}
 	}
-	return true
+	return nil
 }
@@ -0,0 +1,73 @@
+package waters11
+
+import (
+	"errors"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	lsss2 "github.com/mmsyan/GoPairingBasedCryptography/access/lsss"
+	"github.com/mmsyan/GoPairingBasedCryptography/hash"
+	"testing"
+)
+
+// TestNewWaters11CPABEInstanceFromStrings 字符串属性宇宙下，按字符串构造的策略可以正常加解密，宇宙外的属性被拒绝
+func TestNewWaters11CPABEInstanceFromStrings(t *testing.T) {
+	instance, err := NewWaters11CPABEInstanceFromStrings("Role:Doctor", "Dept:Cardiology", "Level:Senior")
+	if err != nil {
+		t.Fatal(err)
+	}
+	pp, msk, err := instance.SetUp()
+	if err != nil {
+		t.Fatal(err)
+	}
+	usk, err := instance.KeyGenerate(&Waters11CPABEAttributes{Attributes: []fr.Element{
+		hash.ToField("Role:Doctor"), hash.ToField("Dept:Cardiology"),
+	}}, msk, pp)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	policy := &Waters11CPABEAccessPolicy{matrix: lsss2.NewLSSSMatrixFromBinaryTree(
+		lsss2.And(lsss2.LeafFromString("Role:Doctor"), lsss2.LeafFromString("Dept:Cardiology")),
+	)}
+	m, err := new(bn254.GT).SetRandom()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ciphertext, err := instance.Encrypt(&Waters11CPABEMessage{Message: *m}, policy, pp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decrypted, err := instance.Decrypt(ciphertext, usk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !decrypted.Message.Equal(m) {
+		t.Fatal("解密结果与原始消息不一致")
+	}
+
+	outside := &Waters11CPABEAccessPolicy{matrix: lsss2.NewLSSSMatrixFromBinaryTree(lsss2.LeafFromString("Role:Nurse"))}
+	if _, err := instance.Encrypt(&Waters11CPABEMessage{Message: *m}, outside, pp); !errors.Is(err, ErrInvalidAttribute) {
+		t.Fatalf("宇宙外的属性应返回 ErrInvalidAttribute, 实际为 %v", err)
+	}
+}
+
+// TestNewWaters11CPABEInstanceFromElements 可变参数构造与切片构造得到相同的属性宇宙
+func TestNewWaters11CPABEInstanceFromElements(t *testing.T) {
+	attrs := []fr.Element{fr.NewElement(1), fr.NewElement(2), fr.NewElement(3)}
+	fromElements, err := NewWaters11CPABEInstanceFromElements(attrs...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fromSlice, err := NewWaters11CPABEInstance(attrs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fromElements.universe) != len(fromSlice.universe) {
+		t.Fatal("属性宇宙大小不一致")
+	}
+	for a := range fromSlice.universe {
+		if _, ok := fromElements.universe[a]; !ok {
+			t.Fatal("属性宇宙不一致")
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package waters11
+
+import (
+	"fmt"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/mmsyan/GoPairingBasedCryptography/metrics"
+)
+
+// ValidateKeyPair 检查公共参数 PP 与主密钥 MSK 是否来自同一次 SetUp。
+// 验证等式 $e(g_1^\alpha, g_2) = e(g_1, g_2)^\alpha$。
+//
+// MSK 只保存 $g_1^\alpha$ 而不保存 $\alpha$，因此无法由 MSK 重新推导 PP，
+// 但该等式足以发现把不同 SetUp 生成的 PP 与 MSK 混用的情况：
+// 这种混用不会在 KeyGenerate 或 Encrypt 时报错，只会让解密得到错误的明文。
+//
+// 参数:
+//   - pp: 系统公共参数 PP
+//   - msk: 系统主密钥 MSK
+//
+// 返回值:
+//   - error: 如果 PP 与 MSK 不匹配返回包装了 ErrKeyPairMismatch 的错误，配对计算失败时返回相应错误
+func (instance *Waters11CPABEInstance) ValidateKeyPair(pp *Waters11CPABEPublicParameters, msk *Waters11CPABEMasterSecretKey) error {
+	if pp == nil || msk == nil {
+		return fmt.Errorf("failed to validate key pair: nil public parameters or master secret key")
+	}
+	// e(g1^alpha, g2)
+	eG1ExpAlphaG2, err := metrics.Pair([]bn254.G1Affine{msk.g1ExpAlpha}, []bn254.G2Affine{pp.g2})
+	if err != nil {
+		return fmt.Errorf("failed to validate key pair: %w", err)
+	}
+	if !eG1ExpAlphaG2.Equal(&pp.eG1G2ExpAlpha) {
+		return fmt.Errorf("failed to validate key pair: %w", ErrKeyPairMismatch)
+	}
+	return nil
+}
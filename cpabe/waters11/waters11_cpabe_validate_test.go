@@ -0,0 +1,36 @@
+package waters11
+
+import (
+	"errors"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"testing"
+)
+
+// TestWatersCPABEValidateKeyPair 同一次 SetUp 的 PP 与 MSK 验证通过，不同 SetUp 混用时返回 ErrKeyPairMismatch
+func TestWatersCPABEValidateKeyPair(t *testing.T) {
+	instance, err := NewWaters11CPABEInstance([]fr.Element{fr.NewElement(1), fr.NewElement(2)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	pp1, msk1, err := instance.SetUp()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pp2, msk2, err := instance.SetUp()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := instance.ValidateKeyPair(pp1, msk1); err != nil {
+		t.Fatalf("匹配的 PP 与 MSK 应验证通过: %v", err)
+	}
+	if err := instance.ValidateKeyPair(pp2, msk2); err != nil {
+		t.Fatalf("匹配的 PP 与 MSK 应验证通过: %v", err)
+	}
+	if err := instance.ValidateKeyPair(pp1, msk2); !errors.Is(err, ErrKeyPairMismatch) {
+		t.Fatalf("混用的 PP 与 MSK 应返回 ErrKeyPairMismatch: %v", err)
+	}
+	if err := instance.ValidateKeyPair(pp2, msk1); !errors.Is(err, ErrKeyPairMismatch) {
+		t.Fatalf("混用的 PP 与 MSK 应返回 ErrKeyPairMismatch: %v", err)
+	}
+}
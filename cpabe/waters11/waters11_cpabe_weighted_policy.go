@@ -0,0 +1,27 @@
+package waters11
+
+import (
+	"fmt"
+	"github.com/mmsyan/GoPairingBasedCryptography/access/lsss"
+)
+
+// EncryptWeighted 在加权门限策略下加密消息：用户满足的子策略权重之和不小于门限时才能解密，
+// 例如 CEO 计 3、Manager 计 2、Staff 计 1、门限为 4。策略由 lsss.NewLSSSMatrixFromWeightedThresholdChecked 展开，
+// 权重为 w 的子策略在矩阵中占 w 份分享，因此密文大小随权重之和增长。
+//
+// 参数:
+//   - message: 要加密的明文消息M
+//   - policy: 由 lsss.WeightedThreshold 构造的加权门限策略
+//   - pp: 系统公共参数 PP
+//
+// 返回值:
+//   - *Waters11CPABECiphertext: 生成的密文
+//   - error: 策略展开后超过规模上限时返回包装了 lsss.ErrPolicyTooLarge 或 lsss.ErrPolicyTooDeep 的错误；
+//     策略中的属性不在属性宇宙中或加密失败时返回错误信息
+func (instance *Waters11CPABEInstance) EncryptWeighted(message *Waters11CPABEMessage, policy *lsss.WeightedThresholdNode, pp *Waters11CPABEPublicParameters) (*Waters11CPABECiphertext, error) {
+	matrix, err := lsss.NewLSSSMatrixFromWeightedThresholdChecked(policy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build weighted access policy: %w", err)
+	}
+	return instance.Encrypt(message, &Waters11CPABEAccessPolicy{matrix: matrix}, pp)
+}
@@ -0,0 +1,65 @@
+package waters11
+
+import (
+	"errors"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	lsss2 "github.com/mmsyan/GoPairingBasedCryptography/access/lsss"
+	"github.com/mmsyan/GoPairingBasedCryptography/hash"
+	"testing"
+)
+
+// TestWatersCPABEEncryptWeighted CEO 计 3、Manager 计 2、Staff 计 1、门限为 4：两名 Manager 可以解密，三名 Staff 不能
+func TestWatersCPABEEncryptWeighted(t *testing.T) {
+	weights := []struct {
+		name   string
+		weight int
+	}{{"CEO", 3}, {"Manager_A", 2}, {"Manager_B", 2}, {"Staff_A", 1}, {"Staff_B", 1}, {"Staff_C", 1}}
+	names := make([]string, len(weights))
+	children := make([]lsss2.WeightedChild, len(weights))
+	for i, w := range weights {
+		names[i] = w.name
+		children[i] = lsss2.WeightedChild{Policy: lsss2.LeafFromString(w.name), Weight: w.weight}
+	}
+
+	instance, err := NewWaters11CPABEInstanceFromStrings(names...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pp, msk, err := instance.SetUp()
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, _ := new(bn254.GT).SetRandom()
+	message := &Waters11CPABEMessage{Message: *m}
+	ciphertext, err := instance.EncryptWeighted(message, lsss2.WeightedThreshold(4, children), pp)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyFor := func(attrs ...string) *Waters11CPABEUserSecretKey {
+		elements := make([]fr.Element, len(attrs))
+		for i, attr := range attrs {
+			elements[i] = hash.ToField(attr)
+		}
+		usk, err := instance.KeyGenerate(&Waters11CPABEAttributes{Attributes: elements}, msk, pp)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return usk
+	}
+	for _, attrs := range [][]string{{"Manager_A", "Manager_B"}, {"CEO", "Staff_C"}, {"Manager_B", "Staff_A", "Staff_B"}} {
+		recovered, err := instance.Decrypt(ciphertext, keyFor(attrs...))
+		if err != nil {
+			t.Fatalf("%v: decrypt failed: %v", attrs, err)
+		}
+		if !recovered.Message.Equal(m) {
+			t.Fatalf("%v: recovered message does not match", attrs)
+		}
+	}
+	for _, attrs := range [][]string{{"Staff_A", "Staff_B", "Staff_C"}, {"CEO"}, {"Manager_A", "Staff_A"}} {
+		if _, err := instance.Decrypt(ciphertext, keyFor(attrs...)); !errors.Is(err, ErrPolicyNotSatisfied) {
+			t.Fatalf("%v: expected ErrPolicyNotSatisfied, got %v", attrs, err)
+		}
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
 	"github.com/mmsyan/GoPairingBasedCryptography/access/lsss"
 	"github.com/mmsyan/GoPairingBasedCryptography/hash"
+	"github.com/mmsyan/GoPairingBasedCryptography/metrics"
 	"math/big"
 )
 
@@ -37,21 +38,23 @@ type LW11DABEUserKey struct {
 
 type LW11DABEMessage struct {
 	Message bn254.GT
+	sealed  []byte // SetBytes 以会话密钥加密的字节数据
 }
 
 type LW11DABECiphertext struct {
-	matrix *lsss.LewkoWatersLsssMatrix
-	c0     bn254.GT
-	c1x    []bn254.GT
-	c2x    []bn254.G2Affine
-	c3x    []bn254.G2Affine
+	matrix           *lsss.LewkoWatersLsssMatrix
+	policyCommitment [32]byte // 加密时访问矩阵规范序列化的 SHA-256 摘要
+	c0               bn254.GT
+	c1x              []bn254.GT
+	c2x              []bn254.G2Affine
+	c3x              []bn254.G2Affine
 }
 
 func GlobalSetup() (*LW11DABEGlobalParams, error) {
 	_, _, g1, g2 := bn254.Generators()
-	eG1G2, err := bn254.Pair([]bn254.G1Affine{g1}, []bn254.G2Affine{g2})
+	eG1G2, err := metrics.Pair([]bn254.G1Affine{g1}, []bn254.G2Affine{g2})
 	if err != nil {
-		return nil, fmt.Errorf("failed to global setup")
+		return nil, fmt.Errorf("failed to global setup: %w", err)
 	}
 	return &LW11DABEGlobalParams{
 		g1:    g1,
@@ -72,7 +75,7 @@ func AuthoritySetup(authorityAttributes *LW11DABEAttributes, gp *LW11DABEGlobalP
 		alphaI, err := new(fr.Element).SetRandom()
 		yi, err := new(fr.Element).SetRandom()
 		if err != nil {
-			return nil, nil, fmt.Errorf("failed to authority setup")
+			return nil, nil, fmt.Errorf("failed to authority setup: %w", err)
 		}
 		eggExpAlphaI := new(bn254.GT).Exp(gp.eG1G2, alphaI.BigInt(new(big.Int)))
 		g2ExpYi := new(bn254.G2Affine).ScalarMultiplicationBase(yi.BigInt(new(big.Int)))
@@ -109,13 +112,14 @@ func KeyGenerate(grantedAttribute *LW11DABEAttributes, userGid string, attribute
 func Encrypt(message *LW11DABEMessage, matrix *lsss.LewkoWatersLsssMatrix, gp *LW11DABEGlobalParams, pk *LW11DABEAttributePK) (*LW11DABECiphertext, error) {
 	var err error
 	n := matrix.ColumnNumber()
-	c1xSlice := make([]bn254.GT, n)
-	c2xSlice := make([]bn254.G2Affine, n)
-	c3xSlice := make([]bn254.G2Affine, n)
+	l := matrix.RowNumber()
+	c1xSlice := make([]bn254.GT, l)
+	c2xSlice := make([]bn254.G2Affine, l)
+	c3xSlice := make([]bn254.G2Affine, l)
 
 	s, err := new(fr.Element).SetRandom()
 	if err != nil {
-		return nil, fmt.Errorf("encrypt failed: %vectorV", err)
+		return nil, fmt.Errorf("encrypt failed: %w", err)
 	}
 
 	vectorV := make([]fr.Element, n)
@@ -127,19 +131,23 @@ func Encrypt(message *LW11DABEMessage, matrix *lsss.LewkoWatersLsssMatrix, gp *L
 		vi, err := new(fr.Element).SetRandom()
 		wi, err := new(fr.Element).SetRandom()
 		if err != nil {
-			return nil, fmt.Errorf("encrypt failed: %v", err)
+			return nil, fmt.Errorf("encrypt failed: %w", err)
 		}
 		vectorV[i] = *vi
 		vectorW[i] = *wi
 	}
 
-	eG1G2ExpS := new(bn254.GT).Exp(gp.eG1G2, s.BigInt(new(big.Int)))
+	// C0 = M·e(g1,g2)^{s·h}，h 由访问矩阵的指纹导出，使替换后的策略无法解出 M
+	binding := policyBinding(matrix)
+	sBound := new(fr.Element).Mul(s, &binding)
+	eG1G2ExpS := new(bn254.GT).Exp(gp.eG1G2, sBound.BigInt(new(big.Int)))
 	c0 := new(bn254.GT).Mul(&message.Message, eG1G2ExpS)
 
-	for x := 0; x < n; x++ {
+	// 每一行 x 对应一组密文分量，行数 l 可能多于列数 n（例如 OR 门产生的行）
+	for x := 0; x < l; x++ {
 		rx, err := new(fr.Element).SetRandom()
 		if err != nil {
-			return nil, fmt.Errorf("encrypt failed: %v", err)
+			return nil, fmt.Errorf("encrypt failed: %w", err)
 		}
 		lambdaX := matrix.ComputeVector(x, vectorV)
 		omegaX := matrix.ComputeVector(x, vectorW)
@@ -165,35 +173,56 @@ func Encrypt(message *LW11DABEMessage, matrix *lsss.LewkoWatersLsssMatrix, gp *L
 	var accessMatrix = *matrix
 
 	return &LW11DABECiphertext{
-		c0:     *c0,
-		matrix: &accessMatrix,
-		c1x:    c1xSlice,
-		c2x:    c2xSlice,
-		c3x:    c3xSlice,
+		c0:               *c0,
+		matrix:           &accessMatrix,
+		policyCommitment: accessMatrix.Fingerprint(),
+		c1x:              c1xSlice,
+		c2x:              c2xSlice,
+		c3x:              c3xSlice,
 	}, nil
 }
 
 func Decrypt(ciphertext *LW11DABECiphertext, userKey *LW11DABEUserKey, gp *LW11DABEGlobalParams) (*LW11DABEMessage, error) {
-	hGid := hash.ToG1(userKey.UserGid)
+	if ciphertext.matrix == nil {
+		audit(ciphertext, nil, ErrPolicyDetached)
+		return nil, fmt.Errorf("decrypt failed: %w", ErrPolicyDetached)
+	}
 	xSlice, wSlice := ciphertext.matrix.FindLinearCombinationWeight(userKey.UserAttributes.attributes)
+	if xSlice == nil {
+		audit(ciphertext, nil, ErrPolicyNotSatisfied)
+		return nil, fmt.Errorf("decrypt failed: %w", ErrPolicyNotSatisfied)
+	}
+	message, err := decryptWithWeights(ciphertext, userKey, xSlice, wSlice)
+	audit(ciphertext, xSlice, err)
+	return message, err
+}
+
+// decryptWithWeights 使用线性组合 (xSlice, wSlice) 解密，wSlice[k] 是第 xSlice[k] 行的权重。
+// 计算 C0 / (∏_k (C1x · e(H(GID), C3x) / e(K_ρ(x), C2x))^{w_k})^h，h 由密文携带的访问矩阵导出。
+func decryptWithWeights(ciphertext *LW11DABECiphertext, userKey *LW11DABEUserKey, xSlice []int, wSlice []fr.Element) (*LW11DABEMessage, error) {
+	hGid := hash.ToG1(userKey.UserGid)
 	denominator := new(bn254.GT).SetOne()
-	for _, x := range xSlice {
+	for k, x := range xSlice {
 		c1x := ciphertext.c1x[x]
-		eHGidC3x, err := bn254.Pair([]bn254.G1Affine{hGid}, []bn254.G2Affine{ciphertext.c3x[x]})
+		eHGidC3x, err := metrics.Pair([]bn254.G1Affine{hGid}, []bn254.G2Affine{ciphertext.c3x[x]})
 		if err != nil {
 			return nil, err
 		}
 
 		rhoX := ciphertext.matrix.Rho(x)
 		kRho := userKey.KIGID[rhoX]
-		eKRhoC2x, err := bn254.Pair([]bn254.G1Affine{kRho}, []bn254.G2Affine{ciphertext.c2x[x]})
-
-		denominator.Mul(denominator, &c1x)
-		denominator.Mul(denominator, &eHGidC3x)
-		denominator.Div(denominator, &eKRhoC2x)
+		eKRhoC2x, err := metrics.Pair([]bn254.G1Affine{kRho}, []bn254.G2Affine{ciphertext.c2x[x]})
+		if err != nil {
+			return nil, err
+		}
 
-		denominator.Exp(*denominator, wSlice[x].BigInt(new(big.Int)))
+		term := new(bn254.GT).Mul(&c1x, &eHGidC3x)
+		term.Div(term, &eKRhoC2x)
+		term.Exp(*term, wSlice[k].BigInt(new(big.Int)))
+		denominator.Mul(denominator, term)
 	}
+	binding := policyBinding(ciphertext.matrix)
+	denominator.Exp(*denominator, binding.BigInt(new(big.Int)))
 
 	message := *new(bn254.GT).Div(&ciphertext.c0, denominator)
 
@@ -0,0 +1,37 @@
+package dabe
+
+import (
+	"github.com/mmsyan/GoPairingBasedCryptography/access/lsss"
+	"sync"
+)
+
+var (
+	auditorMu sync.RWMutex
+	auditor   lsss.DecryptionAuditor
+)
+
+// SetDecryptionAuditor 设置包级的审计回调，此后每一次 Decrypt、DecryptWithPlan、DecryptWithRegistry、
+// DecryptWithMatrix 都会调用 auditor.OnDecryptAttempt，策略指纹为密文的 PolicyCommitment。
+// DABE 没有实例对象，因此回调对整个进程生效；传入 nil 关闭审计，这也是默认状态。
+//
+// 参数:
+//   - a: 审计回调，实现必须是并发安全的
+func SetDecryptionAuditor(a lsss.DecryptionAuditor) {
+	auditorMu.Lock()
+	defer auditorMu.Unlock()
+	auditor = a
+}
+
+// audit 把一次解密尝试报告给审计回调，失败时不报告行索引
+func audit(ciphertext *LW11DABECiphertext, rows []int, err error) {
+	auditorMu.RLock()
+	a := auditor
+	auditorMu.RUnlock()
+	if a == nil {
+		return
+	}
+	if err != nil {
+		rows = nil
+	}
+	a.OnDecryptAttempt(ciphertext.policyCommitment, err == nil, rows)
+}
@@ -0,0 +1,54 @@
+package dabe
+
+import (
+	"sync"
+	"testing"
+)
+
+// recordingAuditor 按调用顺序记录全部解密尝试
+type recordingAuditor struct {
+	mu        sync.Mutex
+	successes []bool
+	rows      [][]int
+	policies  [][32]byte
+}
+
+func (a *recordingAuditor) OnDecryptAttempt(policyFingerprint [32]byte, success bool, satisfiedRows []int) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.successes = append(a.successes, success)
+	a.rows = append(a.rows, satisfiedRows)
+	a.policies = append(a.policies, policyFingerprint)
+}
+
+// TestDecryptionAuditor 两次解密（一次成功、一次属性不满足）各记录一条，指纹为密文的策略承诺
+func TestDecryptionAuditor(t *testing.T) {
+	gp, _, userKey, ciphertexts, _ := planFixture(t, 3, 1)
+	auditor := &recordingAuditor{}
+	SetDecryptionAuditor(auditor)
+	t.Cleanup(func() { SetDecryptionAuditor(nil) })
+
+	if _, err := Decrypt(ciphertexts[0], userKey, gp); err != nil {
+		t.Fatal(err)
+	}
+	partial := *userKey
+	partial.UserAttributes = NewLW11DABEAttributesFromStrings("attr0", "attr1")
+	if _, err := Decrypt(ciphertexts[0], &partial, gp); err == nil {
+		t.Fatal("user missing attr2 should not satisfy the AND policy")
+	}
+
+	if len(auditor.successes) != 2 {
+		t.Fatalf("expected 2 audit records, got %d", len(auditor.successes))
+	}
+	if !auditor.successes[0] || len(auditor.rows[0]) != 3 {
+		t.Fatalf("unexpected success record: %v %v", auditor.successes[0], auditor.rows[0])
+	}
+	if auditor.successes[1] || auditor.rows[1] != nil {
+		t.Fatalf("unexpected failure record: %v %v", auditor.successes[1], auditor.rows[1])
+	}
+	for _, policy := range auditor.policies {
+		if policy != ciphertexts[0].PolicyCommitment() {
+			t.Fatal("audit record should carry the ciphertext policy commitment")
+		}
+	}
+}
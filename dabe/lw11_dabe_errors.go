@@ -0,0 +1,20 @@
+package dabe
+
+import "errors"
+
+var (
+	// ErrPolicyNotSatisfied 表示用户属性不满足密文的访问策略，可用 errors.Is 判断
+	ErrPolicyNotSatisfied = errors.New("access policy is not satisfied")
+
+	// ErrPolicyMismatch 表示密文携带的访问矩阵与约定的策略或其承诺不一致
+	ErrPolicyMismatch = errors.New("ciphertext policy does not match")
+
+	// ErrPolicyDetached 表示密文只保存了访问策略的指纹，需要先用 Attach、DecryptWithRegistry 或 DecryptWithMatrix 提供策略
+	ErrPolicyDetached = errors.New("ciphertext does not carry its access policy")
+
+	// ErrGIDMismatch 表示待合并的用户私钥属于不同的 GID
+	ErrGIDMismatch = errors.New("user keys belong to different GIDs")
+
+	// ErrConflictingUserKey 表示待合并的用户私钥对同一属性给出了不同的 K_{i,GID}
+	ErrConflictingUserKey = errors.New("conflicting user key components")
+)
@@ -0,0 +1,55 @@
+package dabe
+
+import (
+	"errors"
+	"fmt"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+)
+
+// MergeUserKeys 把同一 GID 从多个授权机构得到的用户私钥合并为一个私钥，合并后即可直接交给 Decrypt。
+//
+// K_{i,GID} = g1^αi · H(GID)^yi 只依赖属性 i 所属机构的密钥和 GID，不同机构签发的分量互不干扰，
+// 因此合并就是取属性集合与 KIGID 的并集。同一属性在多个私钥中出现时，分量必须完全相同
+// （例如同一机构重复签发的私钥）；GID 不同的私钥绑定不同的 H(GID)，合并后无法解密，直接拒绝。
+// 输入私钥不会被修改。
+//
+// 参数：
+//   - keys: 待合并的用户私钥，至少一个
+//
+// 返回值：
+//   - *LW11DABEUserKey: 合并后的用户私钥
+//   - error: 没有私钥或含 nil 私钥、GID 不一致（包装 ErrGIDMismatch）、
+//     同一属性的分量不一致（包装 ErrConflictingUserKey）时返回错误
+func MergeUserKeys(keys ...*LW11DABEUserKey) (*LW11DABEUserKey, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("no user keys to merge")
+	}
+	gid := ""
+	var attributes []fr.Element
+	kigid := make(map[fr.Element]bn254.G1Affine)
+	for k, key := range keys {
+		if key == nil {
+			return nil, fmt.Errorf("user key %d is nil", k)
+		}
+		if k == 0 {
+			gid = key.UserGid
+		} else if key.UserGid != gid {
+			return nil, fmt.Errorf("%w: %q and %q", ErrGIDMismatch, gid, key.UserGid)
+		}
+		for attr, component := range key.KIGID {
+			if existing, ok := kigid[attr]; ok && !existing.Equal(&component) {
+				return nil, fmt.Errorf("%w: attribute %s", ErrConflictingUserKey, attr.String())
+			}
+			kigid[attr] = component
+		}
+		if key.UserAttributes != nil {
+			attributes = append(attributes, key.UserAttributes.attributes...)
+		}
+	}
+	return &LW11DABEUserKey{
+		UserGid:        gid,
+		UserAttributes: NewLW11DABEAttributes(attributes...),
+		KIGID:          kigid,
+	}, nil
+}
@@ -0,0 +1,120 @@
+package dabe
+
+import (
+	"errors"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	lsss2 "github.com/mmsyan/GoPairingBasedCryptography/access/lsss"
+	"github.com/mmsyan/GoPairingBasedCryptography/hash"
+	"testing"
+)
+
+// TestMergeUserKeys 用户分别从两个机构取得私钥，合并后解密跨机构的策略 "A and D"；
+// 任何一个单独的私钥都不满足该策略
+func TestMergeUserKeys(t *testing.T) {
+	gp, err := GlobalSetup()
+	if err != nil {
+		t.Fatal(err)
+	}
+	A, B, C, D := hash.ToField("A"), hash.ToField("B"), hash.ToField("C"), hash.ToField("D")
+	pk1, sk1, err := AuthoritySetup(NewLW11DABEAttributes(A, B), gp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pk2, sk2, err := AuthoritySetup(NewLW11DABEAttributes(C, D), gp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 加密者合并两个机构的属性公钥
+	pk := &LW11DABEAttributePK{
+		eG1G2ExpAlphaI: make(map[fr.Element]bn254.GT),
+		g2ExpYi:        make(map[fr.Element]bn254.G2Affine),
+	}
+	for _, authority := range []*LW11DABEAttributePK{pk1, pk2} {
+		for attr, v := range authority.eG1G2ExpAlphaI {
+			pk.eG1G2ExpAlphaI[attr] = v
+		}
+		for attr, v := range authority.g2ExpYi {
+			pk.g2ExpYi[attr] = v
+		}
+	}
+
+	gid := "alice"
+	key1, err := KeyGenerate(NewLW11DABEAttributes(A), gid, sk1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key2, err := KeyGenerate(NewLW11DABEAttributes(D), gid, sk2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	message, err := NewRandomLW11DABEMessage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	matrix := lsss2.NewLSSSMatrixFromBinaryTree(lsss2.And(lsss2.Leaf(A), lsss2.Leaf(D)))
+	ciphertext, err := Encrypt(message, matrix, gp, pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []*LW11DABEUserKey{key1, key2} {
+		if _, err := Decrypt(ciphertext, key, gp); !errors.Is(err, ErrPolicyNotSatisfied) {
+			t.Fatalf("单个机构的私钥不应满足跨机构策略: %v", err)
+		}
+	}
+
+	// 重复传入同一个私钥不算冲突
+	merged, err := MergeUserKeys(key1, key2, key1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if merged.UserGid != gid || len(merged.KIGID) != 2 || len(merged.UserAttributes.attributes) != 2 {
+		t.Fatal("合并后的私钥内容不正确")
+	}
+	if len(key1.KIGID) != 1 {
+		t.Fatal("合并不应修改输入私钥")
+	}
+	decrypted, err := Decrypt(ciphertext, merged, gp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !decrypted.Message.Equal(&message.Message) {
+		t.Fatal("合并后的私钥解密结果与原始消息不一致")
+	}
+}
+
+// TestMergeUserKeysRejects GID 不同、同一属性分量冲突或没有私钥时合并失败
+func TestMergeUserKeysRejects(t *testing.T) {
+	gp, err := GlobalSetup()
+	if err != nil {
+		t.Fatal(err)
+	}
+	A := hash.ToField("A")
+	_, sk1, err := AuthoritySetup(NewLW11DABEAttributes(A), gp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 另一个机构错误地管理了同名属性，给出不同的分量
+	_, sk2, err := AuthoritySetup(NewLW11DABEAttributes(A), gp)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	alice, _ := KeyGenerate(NewLW11DABEAttributes(A), "alice", sk1)
+	bob, _ := KeyGenerate(NewLW11DABEAttributes(A), "bob", sk1)
+	aliceOther, _ := KeyGenerate(NewLW11DABEAttributes(A), "alice", sk2)
+
+	if _, err := MergeUserKeys(alice, bob); !errors.Is(err, ErrGIDMismatch) {
+		t.Fatalf("期望 ErrGIDMismatch，得到 %v", err)
+	}
+	if _, err := MergeUserKeys(alice, aliceOther); !errors.Is(err, ErrConflictingUserKey) {
+		t.Fatalf("期望 ErrConflictingUserKey，得到 %v", err)
+	}
+	if _, err := MergeUserKeys(); err == nil {
+		t.Fatal("没有私钥时应返回错误")
+	}
+	if _, err := MergeUserKeys(alice, nil); err == nil {
+		t.Fatal("含 nil 私钥时应返回错误")
+	}
+}
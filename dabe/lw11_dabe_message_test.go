@@ -0,0 +1,12 @@
+package dabe
+
+import (
+	"github.com/mmsyan/GoPairingBasedCryptography/internal/msgtest"
+	"github.com/mmsyan/GoPairingBasedCryptography/serialization"
+	"testing"
+)
+
+// TestLW11DABEMessageBytes 检查 LW11DABEMessage 的 Bytes/SetBytes 往返
+func TestLW11DABEMessageBytes(t *testing.T) {
+	msgtest.CheckGTRoundTrip(t, func() serialization.Message { return new(LW11DABEMessage) })
+}
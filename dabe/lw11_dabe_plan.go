@@ -0,0 +1,51 @@
+package dabe
+
+import (
+	"errors"
+	"fmt"
+	"github.com/mmsyan/GoPairingBasedCryptography/access/lsss"
+)
+
+// PrecomputeDecryption 为用户密钥在访问矩阵上预先求解线性组合，得到可在多个密文间复用的解密计划。
+// 当同一用户需要解密大量共用同一访问矩阵的密文时，使用 DecryptWithPlan 可以省去每个密文一次的高斯消元。
+//
+// 参数:
+//   - matrix: 密文使用的访问矩阵
+//   - userKey: 用户密钥
+//
+// 返回值:
+//   - *lsss.DecryptionPlan: 解密计划
+//   - error: 如果属性不满足策略，返回包装了 ErrPolicyNotSatisfied 的错误
+func PrecomputeDecryption(matrix *lsss.LewkoWatersLsssMatrix, userKey *LW11DABEUserKey) (*lsss.DecryptionPlan, error) {
+	plan, err := lsss.PrecomputeDecryption(matrix, userKey.UserAttributes.attributes)
+	if errors.Is(err, lsss.ErrAttributesNotSatisfying) {
+		return nil, fmt.Errorf("precompute decryption failed: %w", ErrPolicyNotSatisfied)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("precompute decryption failed: %w", err)
+	}
+	return plan, nil
+}
+
+// DecryptWithPlan 使用预先计算的解密计划对密文进行解密，结果与 Decrypt 相同。
+// 密文保存的是访问矩阵的副本，因此计划通过矩阵指纹与密文匹配。
+//
+// 参数:
+//   - ciphertext: 要解密的密文
+//   - userKey: 用户密钥
+//   - gp: 全局参数
+//   - plan: PrecomputeDecryption 返回的解密计划
+//
+// 返回值:
+//   - *LW11DABEMessage: 解密后的明文消息
+//   - error: 如果计划与密文或用户属性不匹配，或配对计算失败，返回错误信息
+func DecryptWithPlan(ciphertext *LW11DABECiphertext, userKey *LW11DABEUserKey, gp *LW11DABEGlobalParams, plan *lsss.DecryptionPlan) (*LW11DABEMessage, error) {
+	if !plan.Matches(ciphertext.matrix, userKey.UserAttributes.attributes) {
+		err := fmt.Errorf("decrypt failed: decryption plan does not match ciphertext policy or user attributes")
+		audit(ciphertext, nil, err)
+		return nil, err
+	}
+	message, err := decryptWithWeights(ciphertext, userKey, plan.Rows(), plan.Weights())
+	audit(ciphertext, plan.Rows(), err)
+	return message, err
+}
@@ -0,0 +1,99 @@
+package dabe
+
+import (
+	"fmt"
+	lsss2 "github.com/mmsyan/GoPairingBasedCryptography/access/lsss"
+	"testing"
+)
+
+// planFixture 生成 k 个属性的 AND 链策略、持有全部属性的用户密钥以及 n 个该策略下的密文
+func planFixture(tb testing.TB, k, n int) (*LW11DABEGlobalParams, *lsss2.LewkoWatersLsssMatrix, *LW11DABEUserKey, []*LW11DABECiphertext, []*LW11DABEMessage) {
+	gp, err := GlobalSetup()
+	if err != nil {
+		tb.Fatal(err)
+	}
+	names := make([]string, k)
+	for i := range names {
+		names[i] = fmt.Sprintf("attr%d", i)
+	}
+	attributes := NewLW11DABEAttributesFromStrings(names...)
+	pk, sk, err := AuthoritySetup(attributes, gp)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	userKey, err := KeyGenerate(attributes, "user-plan", sk)
+	if err != nil {
+		tb.Fatal(err)
+	}
+
+	tree := lsss2.LeafFromString(names[k-1])
+	for i := k - 2; i >= 0; i-- {
+		tree = lsss2.And(lsss2.LeafFromString(names[i]), tree)
+	}
+	matrix := lsss2.NewLSSSMatrixFromBinaryTree(tree)
+
+	ciphertexts := make([]*LW11DABECiphertext, n)
+	messages := make([]*LW11DABEMessage, n)
+	for i := range ciphertexts {
+		messages[i], err = NewRandomLW11DABEMessage()
+		if err != nil {
+			tb.Fatal(err)
+		}
+		ciphertexts[i], err = Encrypt(messages[i], matrix, gp, pk)
+		if err != nil {
+			tb.Fatal(err)
+		}
+	}
+	return gp, matrix, userKey, ciphertexts, messages
+}
+
+// TestDecryptWithPlan 复用同一解密计划解密多个密文，结果与原始消息一致
+func TestDecryptWithPlan(t *testing.T) {
+	gp, matrix, userKey, ciphertexts, messages := planFixture(t, 3, 5)
+	plan, err := PrecomputeDecryption(matrix, userKey)
+	if err != nil {
+		t.Fatalf("PrecomputeDecryption failed: %v", err)
+	}
+	for i, ct := range ciphertexts {
+		recovered, err := DecryptWithPlan(ct, userKey, gp, plan)
+		if err != nil {
+			t.Fatalf("DecryptWithPlan failed: %v", err)
+		}
+		if !recovered.Message.Equal(&messages[i].Message) {
+			t.Fatalf("ciphertext %d: decrypted message does not match", i)
+		}
+	}
+
+	otherMatrix := lsss2.NewLSSSMatrixFromBinaryTree(lsss2.LeafFromString("attr0"))
+	if plan.Matches(otherMatrix, userKey.UserAttributes.attributes) {
+		t.Fatal("plan should not match a different access matrix")
+	}
+}
+
+func BenchmarkDecrypt100(b *testing.B) {
+	gp, _, userKey, ciphertexts, _ := planFixture(b, 10, 100)
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		for _, ct := range ciphertexts {
+			if _, err := Decrypt(ct, userKey, gp); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func BenchmarkDecryptWithPlan100(b *testing.B) {
+	gp, matrix, userKey, ciphertexts, _ := planFixture(b, 10, 100)
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		plan, err := PrecomputeDecryption(matrix, userKey)
+		if err != nil {
+			b.Fatal(err)
+		}
+		for _, ct := range ciphertexts {
+			if _, err := DecryptWithPlan(ct, userKey, gp, plan); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
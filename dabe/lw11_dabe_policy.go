@@ -0,0 +1,67 @@
+package dabe
+
+import (
+	"fmt"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/mmsyan/GoPairingBasedCryptography/access/lsss"
+	"github.com/mmsyan/GoPairingBasedCryptography/hash"
+)
+
+// PolicyCommitment 返回密文在加密时记录的策略承诺，即访问矩阵规范序列化的 SHA-256 摘要。
+func (ct *LW11DABECiphertext) PolicyCommitment() [32]byte {
+	return ct.policyCommitment
+}
+
+// VerifyCiphertextPolicy 检查密文实际执行的访问策略是否就是约定的策略。
+//
+// Decrypt 直接使用密文中携带的访问矩阵；若矩阵在传输或存储中被替换为更弱的策略，
+// 用户可能解密本不应访问的内容。该函数依次检查:
+//  1. 密文携带的矩阵与加密时记录的承诺一致（矩阵未被单独替换）
+//  2. 承诺与依赖方期望的矩阵指纹一致（加密使用的就是约定的策略）
+//
+// 承诺本身没有签名，攻击者可以同时改写矩阵和承诺，这只能通过第 2 步发现，
+// 因此依赖方必须独立持有期望的策略。不过 C0 的指数绑定了加密时矩阵的指纹，
+// 即使不调用本函数，替换过矩阵的密文也无法解出原消息。
+//
+// 参数:
+//   - ct: 待检查的密文
+//   - expectedMatrix: 依赖方期望的访问矩阵
+//
+// 返回值:
+//   - error: 策略不一致时返回包装了 ErrPolicyMismatch 的错误
+func VerifyCiphertextPolicy(ct *LW11DABECiphertext, expectedMatrix *lsss.LewkoWatersLsssMatrix) error {
+	if ct.matrix.Fingerprint() != ct.policyCommitment {
+		return fmt.Errorf("ciphertext matrix does not match its policy commitment: %w", ErrPolicyMismatch)
+	}
+	if expectedMatrix.Fingerprint() != ct.policyCommitment {
+		return fmt.Errorf("ciphertext policy differs from the expected policy: %w", ErrPolicyMismatch)
+	}
+	return nil
+}
+
+// policyBinding 把访问矩阵的指纹映射为 Fr 中的元素 h，加密时 C0 使用 e(g1,g2)^{s·h}，
+// 解密时用密文携带的矩阵重新计算 h，矩阵被替换时 h 随之改变
+func policyBinding(matrix *lsss.LewkoWatersLsssMatrix) fr.Element {
+	fingerprint := matrix.Fingerprint()
+	return hash.BytesToField(fingerprint[:])
+}
+
+// PolicyAttributes 返回密文访问策略中出现的全部属性（去重，按矩阵行的顺序）。
+//
+// 返回值:
+//   - []fr.Element: 策略中的属性列表
+func (ct *LW11DABECiphertext) PolicyAttributes() []fr.Element {
+	return ct.matrix.DistinctAttributes()
+}
+
+// PolicyAttributeStrings 用属性字典把 PolicyAttributes 的结果反查为属性名称。
+//
+// 参数:
+//   - dictionary: 属性到名称的映射，通常由 hash.ToField(name) -> name 构成
+//
+// 返回值:
+//   - []string: 策略中的属性名称，顺序与 PolicyAttributes 一致
+//   - error: 某个属性不在字典中时返回包装了 lsss.ErrUnknownAttribute 的错误
+func (ct *LW11DABECiphertext) PolicyAttributeStrings(dictionary map[fr.Element]string) ([]string, error) {
+	return lsss.AttributeNames(ct.PolicyAttributes(), dictionary)
+}
@@ -0,0 +1,141 @@
+package dabe
+
+import (
+	"errors"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	lsss2 "github.com/mmsyan/GoPairingBasedCryptography/access/lsss"
+	"github.com/mmsyan/GoPairingBasedCryptography/hash"
+	"testing"
+)
+
+// TestVerifyCiphertextPolicy 约定策略验证通过；替换密文矩阵或期望不同策略时验证失败
+func TestVerifyCiphertextPolicy(t *testing.T) {
+	gp, err := GlobalSetup()
+	if err != nil {
+		t.Fatalf("GlobalSetup failed: %v", err)
+	}
+	attributes := NewLW11DABEAttributesFromStrings("A", "B")
+	pk, _, err := AuthoritySetup(attributes, gp)
+	if err != nil {
+		t.Fatalf("AuthoritySetup failed: %v", err)
+	}
+	strong := lsss2.NewLSSSMatrixFromBinaryTree(lsss2.And(lsss2.LeafFromString("A"), lsss2.LeafFromString("B")))
+	weak := lsss2.NewLSSSMatrixFromBinaryTree(lsss2.Or(lsss2.LeafFromString("A"), lsss2.LeafFromString("B")))
+
+	message, err := NewRandomLW11DABEMessage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ct, err := Encrypt(message, strong, gp, pk)
+	if err != nil {
+		t.Fatalf("Encrypt failed: %v", err)
+	}
+
+	// 重新构造的同一策略矩阵也应验证通过
+	agreed := lsss2.NewLSSSMatrixFromBinaryTree(lsss2.And(lsss2.LeafFromString("A"), lsss2.LeafFromString("B")))
+	if err := VerifyCiphertextPolicy(ct, agreed); err != nil {
+		t.Fatalf("VerifyCiphertextPolicy failed for the agreed policy: %v", err)
+	}
+	if err := VerifyCiphertextPolicy(ct, weak); !errors.Is(err, ErrPolicyMismatch) {
+		t.Fatalf("expected ErrPolicyMismatch for a different expected policy, got %v", err)
+	}
+
+	// 仅替换矩阵
+	tampered := *ct
+	tampered.matrix = weak
+	if err := VerifyCiphertextPolicy(&tampered, strong); !errors.Is(err, ErrPolicyMismatch) {
+		t.Fatalf("expected ErrPolicyMismatch for a substituted matrix, got %v", err)
+	}
+
+	// 同时替换矩阵与承诺
+	tampered.policyCommitment = weak.Fingerprint()
+	if err := VerifyCiphertextPolicy(&tampered, strong); !errors.Is(err, ErrPolicyMismatch) {
+		t.Fatalf("expected ErrPolicyMismatch for a substituted matrix and commitment, got %v", err)
+	}
+}
+
+// TestPolicyAttributes 按 Example14 加密后，密文给出的策略属性名恰为 {A,B,C,D}
+func TestPolicyAttributes(t *testing.T) {
+	gp, err := GlobalSetup()
+	if err != nil {
+		t.Fatalf("GlobalSetup failed: %v", err)
+	}
+	names := []string{"A", "B", "C", "D"}
+	pk, _, err := AuthoritySetup(NewLW11DABEAttributesFromStrings(names...), gp)
+	if err != nil {
+		t.Fatalf("AuthoritySetup failed: %v", err)
+	}
+	tree, _ := lsss2.GetExample14()
+	message, err := NewRandomLW11DABEMessage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ct, err := Encrypt(message, lsss2.NewLSSSMatrixFromBinaryTree(tree), gp, pk)
+	if err != nil {
+		t.Fatalf("Encrypt failed: %v", err)
+	}
+
+	dictionary := make(map[fr.Element]string, len(names))
+	for _, name := range names {
+		dictionary[hash.ToField(name)] = name
+	}
+	got, err := ct.PolicyAttributeStrings(dictionary)
+	if err != nil {
+		t.Fatalf("PolicyAttributeStrings failed: %v", err)
+	}
+	if len(got) != len(names) {
+		t.Fatalf("got %d policy attributes, want %d", len(got), len(names))
+	}
+	for i := range names {
+		if got[i] != names[i] {
+			t.Fatalf("policy attribute %d is %q, want %q", i, got[i], names[i])
+		}
+	}
+}
+
+// TestSubstitutedPolicyFailsToDecrypt 把 A OR B 密文的矩阵与承诺一并替换为只含 A 的矩阵后，
+// 持有 A 的用户仍能按新矩阵找到线性组合，但 C0 绑定了原矩阵的指纹，解出的不是原消息
+func TestSubstitutedPolicyFailsToDecrypt(t *testing.T) {
+	gp, err := GlobalSetup()
+	if err != nil {
+		t.Fatalf("GlobalSetup failed: %v", err)
+	}
+	attributes := NewLW11DABEAttributesFromStrings("A", "B")
+	pk, sk, err := AuthoritySetup(attributes, gp)
+	if err != nil {
+		t.Fatalf("AuthoritySetup failed: %v", err)
+	}
+	userKey, err := KeyGenerate(NewLW11DABEAttributesFromStrings("A"), "user001", sk)
+	if err != nil {
+		t.Fatalf("KeyGenerate failed: %v", err)
+	}
+	original := lsss2.NewLSSSMatrixFromBinaryTree(lsss2.Or(lsss2.LeafFromString("A"), lsss2.LeafFromString("B")))
+	substitute := lsss2.NewLSSSMatrixFromBinaryTree(lsss2.LeafFromString("A"))
+
+	message, err := NewRandomLW11DABEMessage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ct, err := Encrypt(message, original, gp, pk)
+	if err != nil {
+		t.Fatalf("Encrypt failed: %v", err)
+	}
+	decrypted, err := Decrypt(ct, userKey, gp)
+	if err != nil {
+		t.Fatalf("Decrypt failed: %v", err)
+	}
+	if !decrypted.Message.Equal(&message.Message) {
+		t.Fatal("decrypted message does not match the original message")
+	}
+
+	tampered := *ct
+	tampered.matrix = substitute
+	tampered.policyCommitment = substitute.Fingerprint()
+	decrypted, err = Decrypt(&tampered, userKey, gp)
+	if err != nil {
+		t.Fatalf("Decrypt failed: %v", err)
+	}
+	if decrypted.Message.Equal(&message.Message) {
+		t.Fatal("a ciphertext with a substituted policy must not decrypt to the original message")
+	}
+}
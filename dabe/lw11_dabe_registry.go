@@ -0,0 +1,93 @@
+package dabe
+
+import (
+	"fmt"
+	"github.com/mmsyan/GoPairingBasedCryptography/access/lsss"
+)
+
+// EncryptByReference 与 Encrypt 相同地加密消息，但密文不保存访问矩阵，只保留策略承诺（即矩阵指纹），
+// 矩阵本身登记在 registry 中。共用同一策略的大量密文因此只在注册表中保存一份矩阵。
+// 这样的密文需要用 DecryptWithRegistry、DecryptWithMatrix 解密，或者先用 Attach 恢复为普通密文。
+//
+// 参数:
+//   - message: 要加密的明文消息
+//   - matrix: 访问矩阵，登记后不应再修改
+//   - gp: 全局参数
+//   - pk: 属性授权机构的公钥
+//   - registry: 策略注册表
+//
+// 返回值:
+//   - *LW11DABECiphertext: 只引用策略指纹的密文
+//   - error: 加密失败时返回错误
+func EncryptByReference(message *LW11DABEMessage, matrix *lsss.LewkoWatersLsssMatrix, gp *LW11DABEGlobalParams, pk *LW11DABEAttributePK, registry *lsss.PolicyRegistry) (*LW11DABECiphertext, error) {
+	ciphertext, err := Encrypt(message, matrix, gp, pk)
+	if err != nil {
+		return nil, err
+	}
+	registry.Register(matrix)
+	ciphertext.matrix = nil
+	return ciphertext, nil
+}
+
+// Attach 按策略承诺从注册表中取出访问矩阵，返回持有该矩阵的密文副本，原密文不变。
+// 副本与注册表共享矩阵，可用于 Decrypt、VerifyCiphertextPolicy、PolicyAttributes 等需要矩阵的操作。
+//
+// 参数:
+//   - registry: 加密时使用的策略注册表
+//
+// 返回值:
+//   - *LW11DABECiphertext: 持有访问矩阵的密文；原密文已持有矩阵时直接返回原密文
+//   - error: 注册表中没有该策略时返回包装了 lsss.ErrUnknownPolicy 的错误
+func (ct *LW11DABECiphertext) Attach(registry *lsss.PolicyRegistry) (*LW11DABECiphertext, error) {
+	if ct.matrix != nil {
+		return ct, nil
+	}
+	matrix, err := registry.Lookup(ct.policyCommitment)
+	if err != nil {
+		return nil, fmt.Errorf("failed to attach access policy: %w", err)
+	}
+	attached := *ct
+	attached.matrix = matrix
+	return &attached, nil
+}
+
+// DecryptWithRegistry 从注册表中查找密文引用的访问矩阵后解密，也可用于按值保存策略的密文。
+//
+// 参数:
+//   - ciphertext: 要解密的密文
+//   - userKey: 用户私钥
+//   - gp: 全局参数
+//   - registry: 加密时使用的策略注册表
+//
+// 返回值:
+//   - *LW11DABEMessage: 解密得到的明文
+//   - error: 注册表中没有该策略、属性不满足策略或解密失败时返回错误
+func DecryptWithRegistry(ciphertext *LW11DABECiphertext, userKey *LW11DABEUserKey, gp *LW11DABEGlobalParams, registry *lsss.PolicyRegistry) (*LW11DABEMessage, error) {
+	attached, err := ciphertext.Attach(registry)
+	if err != nil {
+		audit(ciphertext, nil, err)
+		return nil, fmt.Errorf("decrypt failed: %w", err)
+	}
+	return Decrypt(attached, userKey, gp)
+}
+
+// DecryptWithMatrix 使用调用方显式提供的访问矩阵解密，矩阵必须与密文的策略承诺一致。
+//
+// 参数:
+//   - ciphertext: 要解密的密文
+//   - userKey: 用户私钥
+//   - gp: 全局参数
+//   - matrix: 加密时使用的访问矩阵
+//
+// 返回值:
+//   - *LW11DABEMessage: 解密得到的明文
+//   - error: 矩阵与策略承诺不一致时返回包装了 ErrPolicyMismatch 的错误；属性不满足策略或解密失败时返回错误
+func DecryptWithMatrix(ciphertext *LW11DABECiphertext, userKey *LW11DABEUserKey, gp *LW11DABEGlobalParams, matrix *lsss.LewkoWatersLsssMatrix) (*LW11DABEMessage, error) {
+	if matrix.Fingerprint() != ciphertext.policyCommitment {
+		audit(ciphertext, nil, ErrPolicyMismatch)
+		return nil, fmt.Errorf("decrypt failed: %w", ErrPolicyMismatch)
+	}
+	attached := *ciphertext
+	attached.matrix = matrix
+	return Decrypt(&attached, userKey, gp)
+}
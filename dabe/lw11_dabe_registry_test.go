@@ -0,0 +1,67 @@
+package dabe
+
+import (
+	"errors"
+	lsss2 "github.com/mmsyan/GoPairingBasedCryptography/access/lsss"
+	"testing"
+)
+
+// TestEncryptByReference 共用注册表加密的密文可以通过注册表或显式矩阵解密，直接 Decrypt 返回 ErrPolicyDetached
+func TestEncryptByReference(t *testing.T) {
+	gp, matrix, userKey, _, _ := planFixture(t, 3, 0)
+	attributes := NewLW11DABEAttributesFromStrings("attr0", "attr1", "attr2")
+	pk, sk, err := AuthoritySetup(attributes, gp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if userKey, err = KeyGenerate(attributes, userKey.UserGid, sk); err != nil {
+		t.Fatal(err)
+	}
+
+	registry := lsss2.NewPolicyRegistry()
+	ciphertexts := make([]*LW11DABECiphertext, 5)
+	messages := make([]*LW11DABEMessage, len(ciphertexts))
+	for i := range ciphertexts {
+		if messages[i], err = NewRandomLW11DABEMessage(); err != nil {
+			t.Fatal(err)
+		}
+		if ciphertexts[i], err = EncryptByReference(messages[i], matrix, gp, pk, registry); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if registry.Len() != 1 {
+		t.Fatalf("registry should hold one policy, got %d", registry.Len())
+	}
+	for i, ct := range ciphertexts {
+		recovered, err := DecryptWithRegistry(ct, userKey, gp, registry)
+		if err != nil {
+			t.Fatalf("DecryptWithRegistry failed: %v", err)
+		}
+		if !recovered.Message.Equal(&messages[i].Message) {
+			t.Fatalf("ciphertext %d decrypted to a different message", i)
+		}
+	}
+
+	ct := ciphertexts[0]
+	if _, err := Decrypt(ct, userKey, gp); !errors.Is(err, ErrPolicyDetached) {
+		t.Fatalf("expected ErrPolicyDetached, got %v", err)
+	}
+	recovered, err := DecryptWithMatrix(ct, userKey, gp, matrix)
+	if err != nil {
+		t.Fatalf("DecryptWithMatrix failed: %v", err)
+	}
+	if !recovered.Message.Equal(&messages[0].Message) {
+		t.Fatal("DecryptWithMatrix decrypted to a different message")
+	}
+	weak := lsss2.NewLSSSMatrixFromBinaryTree(lsss2.LeafFromString("attr0"))
+	if _, err := DecryptWithMatrix(ct, userKey, gp, weak); !errors.Is(err, ErrPolicyMismatch) {
+		t.Fatalf("expected ErrPolicyMismatch, got %v", err)
+	}
+	attached, err := ct.Attach(registry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifyCiphertextPolicy(attached, matrix); err != nil {
+		t.Fatalf("attached ciphertext should verify against its policy: %v", err)
+	}
+}
@@ -1,5 +1,56 @@
 package dabe
 
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"github.com/mmsyan/GoPairingBasedCryptography/serialization"
+)
+
+// MarshalBinary 将全局参数序列化为规范字节串（压缩点编码）。
+// 布局: 头部 || g1 || g2 || e(g1, g2)。
+func (gp *LW11DABEGlobalParams) MarshalBinary() ([]byte, error) {
+	data := serialization.AppendHeader(nil, serialization.SchemeLW11DABE)
+	data = append(data, serialization.EncodeG1(gp.g1, serialization.Compressed)...)
+	data = append(data, serialization.EncodeG2(gp.g2, serialization.Compressed)...)
+	data = append(data, serialization.MarshalGT(gp.eG1G2)...)
+	return data, nil
+}
+
+// UnmarshalBinary 从 MarshalBinary 的输出恢复全局参数。
+func (gp *LW11DABEGlobalParams) UnmarshalBinary(data []byte) error {
+	data, err := serialization.CheckHeader(data, serialization.SchemeLW11DABE)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal global params: %w", err)
+	}
+	g1, n, err := serialization.DecodeG1(data)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal global params: %w", err)
+	}
+	data = data[n:]
+	g2, n, err := serialization.DecodeValidG2(data)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal global params: %w", err)
+	}
+	data = data[n:]
+	eG1G2, n, err := serialization.DecodeGT(data)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal global params: %w", err)
+	}
+	if len(data) != n {
+		return errors.New("failed to unmarshal global params: trailing bytes")
+	}
+	gp.g1, gp.g2, gp.eG1G2 = g1, g2, eG1G2
+	return nil
+}
+
+// Fingerprint 返回全局参数规范序列化的 SHA-256 摘要。
+// 各授权机构与用户可以先比较指纹，确认使用的是同一套全局参数。
+func (gp *LW11DABEGlobalParams) Fingerprint() [32]byte {
+	data, _ := gp.MarshalBinary()
+	return sha256.Sum256(data)
+}
+
 func GlobalParamsToJson() {
 
 }
@@ -0,0 +1,28 @@
+package dabe
+
+import (
+	"fmt"
+	"github.com/mmsyan/GoPairingBasedCryptography/access/lsss"
+)
+
+// EncryptString 在以布尔公式字符串表示的访问策略下加密消息，例如 "(Admin or Manager) and Active_User"。
+// 公式由 lsss.ParseBooleanFormula 解析，属性名经 hash.ToField 映射为属性元素，
+// 与 NewLW11DABEAttributesFromStrings 的映射方式一致。
+//
+// 参数:
+//   - message: 要加密的明文消息
+//   - policy: 布尔公式形式的访问策略
+//   - gp: 全局参数
+//   - pk: 策略中全部属性的公钥
+//
+// 返回值:
+//   - *LW11DABECiphertext: 生成的密文
+//   - error: 公式解析失败时返回包装了 *lsss.ParseError（可用 errors.As 取出出错位置）或策略规模错误的错误；
+//     加密失败时返回错误信息
+func EncryptString(message *LW11DABEMessage, policy string, gp *LW11DABEGlobalParams, pk *LW11DABEAttributePK) (*LW11DABECiphertext, error) {
+	tree, err := lsss.ParseBooleanFormula(policy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse access policy %q: %w", policy, err)
+	}
+	return Encrypt(message, lsss.NewLSSSMatrixFromBinaryTree(tree), gp, pk)
+}
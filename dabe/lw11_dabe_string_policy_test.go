@@ -0,0 +1,69 @@
+package dabe
+
+import (
+	"errors"
+	lsss2 "github.com/mmsyan/GoPairingBasedCryptography/access/lsss"
+	"testing"
+)
+
+// TestEncryptString 以布尔公式字符串加密，持有 Manager、Department_Head、Active_User 的用户可以解密，缺少 Active_User 的用户不能
+func TestEncryptString(t *testing.T) {
+	gp, err := GlobalSetup()
+	if err != nil {
+		t.Fatal(err)
+	}
+	attributes := NewLW11DABEAttributesFromStrings("Admin", "Manager", "Department_Head", "Active_User")
+	pk, sk, err := AuthoritySetup(attributes, gp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	message, err := NewRandomLW11DABEMessage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ciphertext, err := EncryptString(message, "(Admin or (Manager and Department_Head)) and Active_User", gp, pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	userKey, err := KeyGenerate(NewLW11DABEAttributesFromStrings("Manager", "Department_Head", "Active_User"), "user-string-policy", sk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	recovered, err := Decrypt(ciphertext, userKey, gp)
+	if err != nil {
+		t.Fatalf("Decrypt failed: %v", err)
+	}
+	if !recovered.Message.Equal(&message.Message) {
+		t.Fatal("recovered message does not match")
+	}
+
+	inactiveKey, err := KeyGenerate(NewLW11DABEAttributesFromStrings("Admin", "Manager", "Department_Head"), "user-inactive", sk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Decrypt(ciphertext, inactiveKey, gp); err == nil {
+		t.Fatal("user without Active_User should not decrypt")
+	}
+}
+
+// TestEncryptStringParseError 公式不合法时返回可用 errors.As 取出的 *lsss.ParseError
+func TestEncryptStringParseError(t *testing.T) {
+	gp, err := GlobalSetup()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pk, _, err := AuthoritySetup(NewLW11DABEAttributesFromStrings("Admin"), gp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	message, err := NewRandomLW11DABEMessage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = EncryptString(message, "Admin and (Manager", gp, pk)
+	var parseErr *lsss2.ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("expected *lsss.ParseError, got %v", err)
+	}
+}
@@ -1,8 +1,11 @@
 package dabe
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 	lsss2 "github.com/mmsyan/GoPairingBasedCryptography/access/lsss"
+	"github.com/mmsyan/GoPairingBasedCryptography/internal/invalidpoints"
 	"testing"
 
 	"github.com/consensys/gnark-crypto/ecc/bn254"
@@ -123,13 +126,13 @@ func TestDABE1(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Decrypt failed: %v", err)
 	}
-	plaintext2, err := Decrypt(ciphertext2, grantedKey, gp)
-	if err != nil {
-		t.Fatalf("Decrypt failed: %v", err)
+	// 用户只有 bob 和 jack，不满足 bob and alice
+	_, err = Decrypt(ciphertext2, grantedKey, gp)
+	if !errors.Is(err, ErrPolicyNotSatisfied) {
+		t.Fatalf("Decrypt should fail with ErrPolicyNotSatisfied, got: %v", err)
 	}
 
 	fmt.Println(plaintext1.ToBytes())
-	fmt.Println(plaintext2.ToBytes())
 }
 
 // 测试简单的加密解密（单属性访问策略）
@@ -257,10 +260,8 @@ func TestDecryptWithInsufficientAttributes(t *testing.T) {
 
 	// 尝试解密（应该失败或返回错误）
 	_, err = Decrypt(ciphertext, userKey, gp)
-	// 注意：根据实现，这里可能返回错误或解密出错误的消息
-	// 如果实现会返回错误，则：
-	if err == nil {
-		t.Log("Warning: Decrypt should fail with insufficient attributes")
+	if !errors.Is(err, ErrPolicyNotSatisfied) {
+		t.Fatalf("Decrypt should fail with ErrPolicyNotSatisfied, got: %v", err)
 	}
 
 	fmt.Println("Insufficient attributes test completed")
@@ -379,3 +380,49 @@ func BenchmarkDecrypt(b *testing.B) {
 		Decrypt(ciphertext, userKey, gp)
 	}
 }
+
+// 测试全局参数指纹：序列化往返后指纹不变
+func TestGlobalParamsFingerprint(t *testing.T) {
+	gp, err := GlobalSetup()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := gp.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	reloaded := new(LW11DABEGlobalParams)
+	if err := reloaded.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if reloaded.Fingerprint() != gp.Fingerprint() {
+		t.Fatal("reloaded global params should have the same fingerprint")
+	}
+	if err := reloaded.UnmarshalBinary(data[1:]); err == nil {
+		t.Fatal("corrupted data should fail to unmarshal")
+	}
+}
+
+// TestGlobalParamsRejectInvalidG2 把序列化结果中的 g2 替换为无穷远点、不在曲线上或不在子群中的编码后，UnmarshalBinary 必须拒绝
+func TestGlobalParamsRejectInvalidG2(t *testing.T) {
+	gp, err := GlobalSetup()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := gp.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	point := gp.g2.Bytes()
+	offset := bytes.Index(data, point[:])
+	if offset < 0 {
+		t.Fatal("序列化结果中找不到目标 G2 点")
+	}
+	for name, invalid := range invalidpoints.G2Encodings() {
+		tampered := bytes.Clone(data)
+		copy(tampered[offset:], invalid)
+		if err := new(LW11DABEGlobalParams).UnmarshalBinary(tampered); err == nil {
+			t.Fatalf("%s: 不合法的 G2 点应被拒绝", name)
+		}
+	}
+}
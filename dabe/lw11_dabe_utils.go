@@ -5,6 +5,7 @@ import (
 	"github.com/consensys/gnark-crypto/ecc/bn254"
 	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
 	"github.com/mmsyan/GoPairingBasedCryptography/hash"
+	"github.com/mmsyan/GoPairingBasedCryptography/utils"
 )
 
 // NewLW11DABEAttributes 从 fr.Element 值创建新的属性集合。
@@ -14,7 +15,7 @@ import (
 //
 // 返回值：
 //   - *LW11DABEAttributes: 指向新创建的属性集合的指针。
-//     该函数会创建输入切片的深拷贝，以防止外部修改。
+//     该函数会创建输入切片的深拷贝，以防止外部修改；重复的属性只保留第一次出现。
 //
 // 示例：
 //
@@ -29,10 +30,8 @@ import (
 //	// 或创建空集合
 //	emptyAttrs := NewLW11DABEAttributes()
 func NewLW11DABEAttributes(attrs ...fr.Element) *LW11DABEAttributes {
-	copied := make([]fr.Element, len(attrs))
-	copy(copied, attrs)
 	return &LW11DABEAttributes{
-		attributes: copied,
+		attributes: utils.DedupAttributes(attrs),
 	}
 }
 
@@ -64,7 +63,7 @@ func NewLW11DABEAttributesFromStrings(attrs ...string) *LW11DABEAttributes {
 		copied[i] = hash.ToField(attr)
 	}
 	return &LW11DABEAttributes{
-		attributes: copied,
+		attributes: utils.DedupAttributes(copied),
 	}
 }
 
@@ -104,9 +103,7 @@ func NewLW11DABEAttributesFromStrings(attrs ...string) *LW11DABEAttributes {
 func (a *LW11DABEAttributes) Append(extra ...fr.Element) *LW11DABEAttributes {
 	if a == nil {
 		// 防御性编程：支持 nil 调用，等价于 NewLW11DABEAttributes(extra...)
-		copied := make([]fr.Element, len(extra))
-		copy(copied, extra)
-		return &LW11DABEAttributes{attributes: copied}
+		return &LW11DABEAttributes{attributes: utils.DedupAttributes(extra)}
 	}
 
 	newLen := len(a.attributes) + len(extra)
@@ -115,14 +112,14 @@ func (a *LW11DABEAttributes) Append(extra ...fr.Element) *LW11DABEAttributes {
 	copy(newAttrs[len(a.attributes):], extra)
 
 	return &LW11DABEAttributes{
-		attributes: newAttrs,
+		attributes: utils.DedupAttributes(newAttrs),
 	}
 }
 
 func NewRandomLW11DABEMessage() (*LW11DABEMessage, error) {
 	element, err := new(bn254.GT).SetRandom()
 	if err != nil {
-		return nil, fmt.Errorf("unable to generate random lw11 dabe message, %v", err)
+		return nil, fmt.Errorf("unable to generate random lw11 dabe message, %w", err)
 	}
 	return &LW11DABEMessage{
 		Message: *element,
@@ -1,6 +1,11 @@
 package fibe
 
-import "github.com/consensys/gnark-crypto/ecc/bn254/fr"
+import (
+	"fmt"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/mmsyan/GoPairingBasedCryptography/utils"
+	"math"
+)
 
 // SW05FIBEAttributes represents a set of attributes used in the SW05 FIBE scheme.
 //
@@ -20,6 +25,8 @@ type SW05FIBEAttributes struct {
 //
 // This function performs a defensive copy and canonical reduction, ensuring that
 // the resulting fr.Element values are normalized and safe for cryptographic use.
+// Duplicate values are dropped (keeping the first occurrence), so an attribute
+// repeated d times still counts as a single attribute towards the threshold.
 //
 // Parameters:
 //
@@ -40,6 +47,94 @@ func NewFIBEAttributes(attributes []int64) *SW05FIBEAttributes {
 		result[i] = *new(fr.Element).SetInt64(a)
 	}
 	return &SW05FIBEAttributes{
-		attributes: result,
+		attributes: utils.DedupAttributes(result),
 	}
 }
+
+// IntsToFIBEAttributes creates an attribute set from a list of int values.
+//
+// Each value is mapped exactly as NewFIBEAttributes and the NewSW05FIBEInstanceByInt64*
+// constructors map universe members, i.e. through fr.Element.SetInt64, so the result
+// can be used directly with instances built from the same integers.
+//
+// Parameters:
+//
+//	attributes - the list of attribute values
+//
+// Returns:
+//
+//	a pointer to a SW05FIBEAttributes instance with duplicates removed
+func IntsToFIBEAttributes(attributes []int) *SW05FIBEAttributes {
+	values := make([]int64, len(attributes))
+	for i, a := range attributes {
+		values[i] = int64(a)
+	}
+	return NewFIBEAttributes(values)
+}
+
+// ElementsToFIBEAttributes creates an attribute set from field elements, for callers
+// whose attributes are not small integers (for example values produced by hash.ToField).
+//
+// Parameters:
+//
+//	attributes - the list of attribute elements; the slice is copied
+//
+// Returns:
+//
+//	a pointer to a SW05FIBEAttributes instance with duplicates removed
+func ElementsToFIBEAttributes(attributes []fr.Element) *SW05FIBEAttributes {
+	return &SW05FIBEAttributes{
+		attributes: utils.DedupAttributes(append([]fr.Element(nil), attributes...)),
+	}
+}
+
+// FIBEAttributesToElements returns a copy of the field elements in the attribute set,
+// in the order they were supplied at construction.
+//
+// Parameters:
+//
+//	attributes - the attribute set
+//
+// Returns:
+//
+//	the attribute elements
+func FIBEAttributesToElements(attributes *SW05FIBEAttributes) []fr.Element {
+	return append([]fr.Element(nil), attributes.attributes...)
+}
+
+// FIBEAttributesToInts is the inverse of IntsToFIBEAttributes: it recovers the int
+// value of every attribute, including negative values, which SetInt64 maps to r - |a|.
+//
+// Parameters:
+//
+//	attributes - the attribute set
+//
+// Returns:
+//
+//	the attribute values in construction order, or an error wrapping
+//	ErrInvalidAttribute naming the first element that is not the image of an int
+func FIBEAttributesToInts(attributes *SW05FIBEAttributes) ([]int, error) {
+	result := make([]int, len(attributes.attributes))
+	for i, a := range attributes.attributes {
+		v, ok := elementToInt(a)
+		if !ok {
+			return nil, fmt.Errorf("attribute %s is not an int: %w", a.String(), ErrInvalidAttribute)
+		}
+		result[i] = v
+	}
+	return result, nil
+}
+
+// elementToInt inverts new(fr.Element).SetInt64(int64(v)) for any int v
+func elementToInt(a fr.Element) (int, bool) {
+	if a.IsUint64() && a.Uint64() <= math.MaxInt {
+		return int(a.Uint64()), true
+	}
+	var neg fr.Element
+	neg.Neg(&a)
+	// |math.MinInt| = math.MaxInt + 1; negating it as uint64 wraps back to math.MinInt
+	if neg.IsUint64() && neg.Uint64() <= uint64(math.MaxInt)+1 {
+		return int(-neg.Uint64()), true
+	}
+	return 0, false
+}
@@ -0,0 +1,42 @@
+package fibe
+
+import (
+	"errors"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/mmsyan/GoPairingBasedCryptography/hash"
+	"math"
+	"testing"
+)
+
+// TestFIBEAttributesIntRoundTrip int → element → int 是恒等映射（含负数与边界值），元素与方案内部的 SetInt64 一致
+func TestFIBEAttributesIntRoundTrip(t *testing.T) {
+	values := []int{1, 2, 42, 0, -1, -7, math.MaxInt, math.MinInt}
+	attributes := IntsToFIBEAttributes(values)
+
+	elements := FIBEAttributesToElements(attributes)
+	universe := NewSW05FIBEInstanceByInt64Slice([]int64{1, 2, 42, 0, -1, -7, math.MaxInt64, math.MinInt64}, 1).universe
+	for i, v := range values {
+		want := *new(fr.Element).SetInt64(int64(v))
+		if !elements[i].Equal(&want) {
+			t.Fatalf("attribute %d maps to %s, want SetInt64 value %s", v, elements[i].String(), want.String())
+		}
+		if _, ok := universe[elements[i]]; !ok {
+			t.Fatalf("attribute %d is not in the universe built from the same integers", v)
+		}
+	}
+
+	back, err := FIBEAttributesToInts(ElementsToFIBEAttributes(elements))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range values {
+		if back[i] != values[i] {
+			t.Fatalf("round trip of %d gave %d", values[i], back[i])
+		}
+	}
+
+	hashed := ElementsToFIBEAttributes([]fr.Element{hash.ToField("not-an-int")})
+	if _, err := FIBEAttributesToInts(hashed); !errors.Is(err, ErrInvalidAttribute) {
+		t.Fatalf("expected ErrInvalidAttribute, got %v", err)
+	}
+}
@@ -1,8 +1,11 @@
 package fibe
 
-import "github.com/consensys/gnark-crypto/ecc/bn254/fr"
+import (
+	"fmt"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+)
 
-// isValidAttributes checks whether all given attributes belong to the universe
+// checkAttributes checks whether all given attributes belong to the universe
 // defined in the SW05FIBEInstance.
 //
 // Parameters:
@@ -11,12 +14,33 @@ import "github.com/consensys/gnark-crypto/ecc/bn254/fr"
 //
 // Returns:
 //
-//	true if all attributes are in the instance's universe, false otherwise
-func (instance *SW05FIBEInstance) isValidAttributes(attributes []fr.Element) bool {
+//	nil if all attributes are in the instance's universe, otherwise an error
+//	naming the first attribute outside the universe and wrapping ErrInvalidAttribute
+func (instance *SW05FIBEInstance) checkAttributes(attributes []fr.Element) error {
 	for _, i := range attributes {
 		if _, ok := instance.universe[i]; !ok {
-			return false
+			return fmt.Errorf("attribute %s not in universe: %w", i.String(), ErrInvalidAttribute)
 		}
 	}
-	return true
+	return nil
+}
+
+// checkAttributes checks whether all given attributes lie in the range [1, n]
+// fixed by SetUp of the large universe scheme.
+//
+// Parameters:
+//
+//	attributes - slice of field elements representing the attribute set to validate
+//
+// Returns:
+//
+//	nil if every attribute is an integer in [1, n], otherwise an error naming
+//	the first out-of-range attribute and wrapping ErrInvalidAttribute
+func (publicParams *SW05FIBELargeUniversePublicParams) checkAttributes(attributes []fr.Element) error {
+	for _, i := range attributes {
+		if !i.IsUint64() || i.Uint64() < 1 || i.Uint64() > uint64(publicParams.n) {
+			return fmt.Errorf("attribute %s not in range [1, %d]: %w", i.String(), publicParams.n, ErrInvalidAttribute)
+		}
+	}
+	return nil
 }
@@ -0,0 +1,60 @@
+package fibe
+
+import (
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"testing"
+)
+
+// TestFIBEDecryptLargeIntersection 50 个公共属性、门限 d=50 时，基于配对累加器的解密仍能恢复明文
+func TestFIBEDecryptLargeIntersection(t *testing.T) {
+	attrs := make([]int64, 50)
+	for i := range attrs {
+		attrs[i] = int64(i + 1)
+	}
+	m, err := new(bn254.GT).SetRandom()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fibeInstance := NewSW05FIBEInstanceByInt64Pair(1, 60, 50)
+	publicParams, err := fibeInstance.SetUp()
+	if err != nil {
+		t.Fatal("系统初始化失败:", err)
+	}
+	secretKey, err := fibeInstance.KeyGenerate(NewFIBEAttributes(attrs), publicParams)
+	if err != nil {
+		t.Fatal("密钥生成失败:", err)
+	}
+	ciphertext, err := fibeInstance.Encrypt(NewFIBEAttributes(attrs), &SW05FIBEMessage{Message: *m}, publicParams)
+	if err != nil {
+		t.Fatal("加密失败:", err)
+	}
+	decrypted, err := fibeInstance.Decrypt(secretKey, ciphertext, publicParams)
+	if err != nil {
+		t.Fatal("解密失败:", err)
+	}
+	if !decrypted.Message.Equal(m) {
+		t.Fatal("解密消息与原始消息不匹配")
+	}
+
+	largeInstance := NewSW05FIBELargeUniverseInstance(50)
+	largeParams, err := largeInstance.SetUp(50)
+	if err != nil {
+		t.Fatal("系统初始化失败:", err)
+	}
+	largeKey, err := largeInstance.KeyGenerate(NewFIBEAttributes(attrs), largeParams)
+	if err != nil {
+		t.Fatal("密钥生成失败:", err)
+	}
+	largeCiphertext, err := largeInstance.Encrypt(NewFIBEAttributes(attrs), &SW05FIBELargeUniverseMessage{Message: *m}, largeParams)
+	if err != nil {
+		t.Fatal("加密失败:", err)
+	}
+	largeDecrypted, err := largeInstance.Decrypt(largeKey, largeCiphertext, largeParams)
+	if err != nil {
+		t.Fatal("解密失败:", err)
+	}
+	if !largeDecrypted.Message.Equal(m) {
+		t.Fatal("解密消息与原始消息不匹配")
+	}
+}
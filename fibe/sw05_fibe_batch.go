@@ -0,0 +1,70 @@
+package fibe
+
+import (
+	"errors"
+	"fmt"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/mmsyan/GoPairingBasedCryptography/pairing"
+	"github.com/mmsyan/GoPairingBasedCryptography/utils"
+	"math/big"
+)
+
+// BatchEncrypt 把多条消息分别加密到同一个属性集 S_msg，结果与逐条调用 Encrypt 等价。
+//
+// 每条消息仍使用独立的随机数 s_k；共享的只是每个属性的底 T_i：
+// 对每个属性 i 只构造一次 T_i 的固定基窗口表（pairing.FixedBaseG2），再用它批量计算全部 E_i^{(k)} = T_i^{s_k}，
+// 从而避免对同一个 T_i 重复做完整的标量乘法。
+//
+// 不提供共享同一个 s 的模式：若各密文共用 s，则 e'_k / e'_j = M_k / M_j 对任何人公开，
+// 且任一消息被解密后 Y^s 随之泄露，其余全部消息都可直接恢复。
+//
+// 参数:
+//   - messageAttributes: 全部密文共同关联的属性集 S_msg。
+//   - messages: 要加密的明文消息列表。
+//   - publicParams: 系统公共参数。
+//
+// 返回值:
+//   - []*SW05FIBECiphertext: 与 messages 一一对应的密文。
+//   - error: 如果消息列表为空、属性集无效或随机数生成失败，返回错误信息。
+func (instance *SW05FIBEInstance) BatchEncrypt(messageAttributes *SW05FIBEAttributes, messages []*SW05FIBEMessage, publicParams *SW05FIBEPublicParams) ([]*SW05FIBECiphertext, error) {
+	if len(messages) == 0 {
+		return nil, errors.New("failed to batch encrypt: no messages provided")
+	}
+	// 检查属性集是否有效。
+	if err := instance.checkAttributes(messageAttributes.attributes); err != nil {
+		return nil, fmt.Errorf("invalid message attributes: %w", err)
+	}
+	attributes := utils.DedupAttributes(messageAttributes.attributes)
+
+	// 为每条消息选择独立的随机数 s_k <- Zq。
+	s := make([]fr.Element, len(messages))
+	for k := range s {
+		sk, err := utils.RandomNonZeroScalar()
+		if err != nil {
+			return nil, fmt.Errorf("failed to batch encrypt: %w", err)
+		}
+		s[k] = *sk
+	}
+
+	ciphertexts := make([]*SW05FIBECiphertext, len(messages))
+	for k, message := range messages {
+		// e'_k = M_k * Y^{s_k}。
+		egg_ys := *(new(bn254.GT)).Exp(publicParams.pk_Y, s[k].BigInt(new(big.Int)))
+		ciphertexts[k] = &SW05FIBECiphertext{
+			messageAttributes: attributes,
+			ePrime:            *new(bn254.GT).Mul(&message.Message, &egg_ys),
+			ei:                make(map[fr.Element]bn254.G2Affine, len(attributes)),
+		}
+	}
+
+	// 对每个属性 i，用同一张 T_i 的固定基表计算 E_i^{(k)} = T_i^{s_k}。
+	for _, i := range attributes {
+		table := pairing.NewFixedBaseG2(publicParams.pk_Ti[i])
+		for k := range ciphertexts {
+			ciphertexts[k].ei[i] = table.ScalarMultiplication(&s[k])
+		}
+	}
+
+	return ciphertexts, nil
+}
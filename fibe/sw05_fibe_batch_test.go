@@ -0,0 +1,89 @@
+package fibe
+
+import (
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"testing"
+)
+
+// batchFixture 构造含 10 个属性的实例、公共参数、可解密的私钥以及 count 条随机消息
+func batchFixture(tb testing.TB, count int) (*SW05FIBEInstance, *SW05FIBEPublicParams, *SW05FIBESecretKey, *SW05FIBEAttributes, []*SW05FIBEMessage) {
+	tb.Helper()
+	instance := NewSW05FIBEInstanceByInt64Pair(1, 11, 3)
+	publicParams, err := instance.SetUp()
+	if err != nil {
+		tb.Fatal(err)
+	}
+	attributes := NewFIBEAttributes([]int64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10})
+	secretKey, err := instance.KeyGenerate(NewFIBEAttributes([]int64{1, 2, 3}), publicParams)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	messages := make([]*SW05FIBEMessage, count)
+	for k := range messages {
+		m, err := new(bn254.GT).SetRandom()
+		if err != nil {
+			tb.Fatal(err)
+		}
+		messages[k] = &SW05FIBEMessage{Message: *m}
+	}
+	return instance, publicParams, secretKey, attributes, messages
+}
+
+// TestBatchEncrypt 批量加密的每个密文都能解密出对应消息，且各密文使用不同的随机数
+func TestBatchEncrypt(t *testing.T) {
+	instance, publicParams, secretKey, attributes, messages := batchFixture(t, 5)
+	ciphertexts, err := instance.BatchEncrypt(attributes, messages, publicParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ciphertexts) != len(messages) {
+		t.Fatalf("密文个数 %d, 期望 %d", len(ciphertexts), len(messages))
+	}
+	attribute := attributes.attributes[0]
+	for k, ciphertext := range ciphertexts {
+		decrypted, err := instance.Decrypt(secretKey, ciphertext, publicParams)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if decrypted.Message != messages[k].Message {
+			t.Fatalf("第 %d 条消息解密结果不一致", k)
+		}
+		if k > 0 {
+			prev, cur := ciphertexts[k-1].ei[attribute], ciphertext.ei[attribute]
+			if prev.Equal(&cur) {
+				t.Fatal("不同密文不应共用随机数 s")
+			}
+		}
+	}
+
+	if _, err := instance.BatchEncrypt(attributes, nil, publicParams); err == nil {
+		t.Fatal("空消息列表应返回错误")
+	}
+	if _, err := instance.BatchEncrypt(NewFIBEAttributes([]int64{1, 99}), messages, publicParams); err == nil {
+		t.Fatal("不在属性宇宙中的属性应返回错误")
+	}
+}
+
+// BenchmarkBatchEncrypt 用 BatchEncrypt 把 20 条消息加密到 10 个属性
+func BenchmarkBatchEncrypt(b *testing.B) {
+	instance, publicParams, _, attributes, messages := batchFixture(b, 20)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := instance.BatchEncrypt(attributes, messages, publicParams); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkLoopedEncrypt 逐条调用 Encrypt 把 20 条消息加密到 10 个属性，作为 BenchmarkBatchEncrypt 的对照
+func BenchmarkLoopedEncrypt(b *testing.B) {
+	instance, publicParams, _, attributes, messages := batchFixture(b, 20)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, message := range messages {
+			if _, err := instance.Encrypt(attributes, message, publicParams); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
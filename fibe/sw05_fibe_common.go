@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"github.com/consensys/gnark-crypto/ecc/bn254"
 	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/mmsyan/GoPairingBasedCryptography/metrics"
+	"github.com/mmsyan/GoPairingBasedCryptography/pairing"
 	"github.com/mmsyan/GoPairingBasedCryptography/utils"
 	"math/big"
 )
@@ -33,6 +35,10 @@ import (
 
 // SW05FIBEInstance 表示模糊身份基加密 (FIBE) 方案的实例对象。
 // 包含了方案运行所需的系统参数和主密钥。
+//
+// 并发约定: SetUp 会替换实例持有的主密钥，不能与同一实例上的任何其他方法并发调用；
+// SetUp 返回之后，KeyGenerate、Encrypt、Decrypt 只读取实例，可以在多个 goroutine 中并发调用。
+// 不同实例之间不共享可变状态。
 type SW05FIBEInstance struct {
 	universe map[fr.Element]struct{}   // 属性宇宙 U（所有合法属性）
 	distance int                       // 容错距离 d（最小匹配属性数量）
@@ -60,6 +66,7 @@ type SW05FIBESecretKey struct {
 // 消息是一个 GT 群上的元素。
 type SW05FIBEMessage struct {
 	Message bn254.GT // GT 群上的消息 M。
+	sealed  []byte   // SetBytes 以会话密钥加密的字节数据
 }
 
 // SW05FIBECiphertext 表示加密后的密文。
@@ -140,6 +147,8 @@ func NewSW05FIBEInstanceByInt64Pair(start int64, end int64, distance int) *SW05F
 
 // SetUp 执行系统初始化操作，生成主密钥和公共参数。
 // 主密钥 (msk_ti, msk_y) 必须保密，公共参数 (g1, g2, pk_Ti, pk_Y) 公开发布。
+// 主密钥先在局部变量中生成，全部成功后才一次性写入实例：失败时实例保持原状，
+// 并且不会原地修改之前 SetUp 生成的映射。同一实例不可并发调用 SetUp。
 //
 // 返回值:
 //   - *SW05FIBEPublicParams: 系统公共参数指针。
@@ -149,29 +158,31 @@ func (instance *SW05FIBEInstance) SetUp() (*SW05FIBEPublicParams, error) {
 	_, _, g1, g2 := bn254.Generators()
 
 	// 随机生成属性主密钥 t_i，并计算公钥组件 T_i = g2^t_i。
-	pk_Ti := make(map[fr.Element]bn254.G2Affine)
+	msk_ti := make(map[fr.Element]fr.Element, len(instance.universe))
+	pk_Ti := make(map[fr.Element]bn254.G2Affine, len(instance.universe))
 	for i := range instance.universe {
 		temp, err := new(fr.Element).SetRandom() // t_i <- Zq
 		if err != nil {
-			return nil, fmt.Errorf("fibe instance setup failure")
+			return nil, fmt.Errorf("fibe instance setup failure: %w", err)
 		}
-		instance.msk_ti[i] = *temp
+		msk_ti[i] = *temp
 		pk_Ti[i] = *new(bn254.G2Affine).ScalarMultiplicationBase(temp.BigInt(new(big.Int))) // T_i = g2^t_i
 	}
 
 	// 随机生成主密钥 y，并计算公钥组件 Y = e(g1, g2)^y。
 	temp, err := new(fr.Element).SetRandom()
 	if err != nil {
-		return nil, fmt.Errorf("fibe instance setup failure")
+		return nil, fmt.Errorf("fibe instance setup failure: %w", err)
 	}
-	instance.msk_y = *temp                                               // y <- Zq
-	eG1G2, err := bn254.Pair([]bn254.G1Affine{g1}, []bn254.G2Affine{g2}) // e(g1, g2)
-	// Y = e(g1, g2)^y
-	pk_Y := *new(bn254.GT).Exp(eG1G2, instance.msk_y.BigInt(new(big.Int)))
-
+	msk_y := *temp                                                         // y <- Zq
+	eG1G2, err := metrics.Pair([]bn254.G1Affine{g1}, []bn254.G2Affine{g2}) // e(g1, g2)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("fibe instance setup failure: %w", err)
 	}
+	// Y = e(g1, g2)^y
+	pk_Y := *new(bn254.GT).Exp(eG1G2, msk_y.BigInt(new(big.Int)))
+
+	instance.msk_ti, instance.msk_y = msk_ti, msk_y
 
 	// 返回公共参数。
 	return &SW05FIBEPublicParams{
@@ -195,8 +206,8 @@ func (instance *SW05FIBEInstance) SetUp() (*SW05FIBEPublicParams, error) {
 //   - error: 如果属性集无效或密钥生成失败，返回错误信息。
 func (instance *SW05FIBEInstance) KeyGenerate(userAttributes *SW05FIBEAttributes, publicParams *SW05FIBEPublicParams) (*SW05FIBESecretKey, error) {
 	// 检查属性集是否有效
-	if !instance.isValidAttributes(userAttributes.attributes) {
-		return nil, fmt.Errorf("invalid user attributes")
+	if err := instance.checkAttributes(userAttributes.attributes); err != nil {
+		return nil, fmt.Errorf("invalid user attributes: %w", err)
 	}
 
 	di := make(map[fr.Element]bn254.G1Affine)
@@ -219,7 +230,7 @@ func (instance *SW05FIBEInstance) KeyGenerate(userAttributes *SW05FIBEAttributes
 	}
 
 	return &SW05FIBESecretKey{
-		userAttributes: userAttributes.attributes,
+		userAttributes: utils.DedupAttributes(userAttributes.attributes),
 		di:             di,
 	}, nil
 }
@@ -235,15 +246,31 @@ func (instance *SW05FIBEInstance) KeyGenerate(userAttributes *SW05FIBEAttributes
 //   - *SW05FIBECiphertext: 生成的密文指针。
 //   - error: 如果属性集无效或加密失败，返回错误信息。
 func (instance *SW05FIBEInstance) Encrypt(messageAttributes *SW05FIBEAttributes, message *SW05FIBEMessage, publicParams *SW05FIBEPublicParams) (*SW05FIBECiphertext, error) {
-	// 检查属性集是否有效。
-	if !instance.isValidAttributes(messageAttributes.attributes) {
-		return nil, fmt.Errorf("invalid message attributes")
-	}
-
 	// 选择一个随机数 s <- Zq。
-	s, err := new(fr.Element).SetRandom()
+	s, err := utils.RandomNonZeroScalar()
 	if err != nil {
-		return nil, fmt.Errorf("failed to encrypt MessageBytes")
+		return nil, fmt.Errorf("failed to encrypt MessageBytes: %w", err)
+	}
+	return instance.EncryptWithRandomness(messageAttributes, message, publicParams, *s)
+}
+
+// EncryptWithRandomness 与 Encrypt 相同，但使用调用方提供的随机数 s 而不是内部随机选取。
+// 仅用于已知答案测试（KAT）和与参考实现的交叉验证：重复使用同一个 s 会泄露明文之比，
+// 生产环境必须使用 Encrypt。
+//
+// 参数:
+//   - messageAttributes: 密文关联的属性集 S_msg。
+//   - message: 要加密的明文消息 M。
+//   - publicParams: 系统公共参数。
+//   - s: 加密随机数。
+//
+// 返回值:
+//   - *SW05FIBECiphertext: 生成的密文指针。
+//   - error: 如果属性集无效，返回错误信息。
+func (instance *SW05FIBEInstance) EncryptWithRandomness(messageAttributes *SW05FIBEAttributes, message *SW05FIBEMessage, publicParams *SW05FIBEPublicParams, s fr.Element) (*SW05FIBECiphertext, error) {
+	// 检查属性集是否有效。
+	if err := instance.checkAttributes(messageAttributes.attributes); err != nil {
+		return nil, fmt.Errorf("invalid message attributes: %w", err)
 	}
 
 	// 计算 Y^s = (e(g1, g2)^y)^s。
@@ -262,7 +289,7 @@ func (instance *SW05FIBEInstance) Encrypt(messageAttributes *SW05FIBEAttributes,
 	}
 
 	return &SW05FIBECiphertext{
-		messageAttributes: messageAttributes.attributes,
+		messageAttributes: utils.DedupAttributes(messageAttributes.attributes),
 		ePrime:            ePrime,
 		ei:                ei,
 	}, nil
@@ -282,44 +309,61 @@ func (instance *SW05FIBEInstance) Encrypt(messageAttributes *SW05FIBEAttributes,
 //   - *SW05FIBEMessage: 解密后的明文消息指针。
 //   - error: 如果属性集无效或交集数量不足 d，返回错误信息。
 func (instance *SW05FIBEInstance) Decrypt(userSecretKey *SW05FIBESecretKey, ciphertext *SW05FIBECiphertext, publicParams *SW05FIBEPublicParams) (*SW05FIBEMessage, error) {
+	m, err := instance.DecryptRaw(userSecretKey, ciphertext, publicParams)
+	if err != nil {
+		return nil, err
+	}
+	return &SW05FIBEMessage{Message: m}, nil
+}
+
+// DecryptRaw 与 Decrypt 的计算相同，但直接返回恢复出的 GT 元素 e' / ∏ e(D_i, E_i)^(Δ_{0, S}(i))。
+// SW05 没有完整性校验，交集足够但私钥与密文不匹配（例如来自另一个实例）时也会得到一个 GT 元素，
+// 该函数便于测试中直接用 Equal 比较群元素。
+//
+// 参数:
+//   - userSecretKey: 用户的私钥。
+//   - ciphertext: 要解密的密文。
+//   - publicParams: 系统公共参数。
+//
+// 返回值:
+//   - bn254.GT: 计算得到的 GT 元素；属性集无效、交集不足或配对失败时没有可返回的结果，为零值。
+//   - error: 如果属性集无效或交集数量不足 d，返回错误信息。
+func (instance *SW05FIBEInstance) DecryptRaw(userSecretKey *SW05FIBESecretKey, ciphertext *SW05FIBECiphertext, publicParams *SW05FIBEPublicParams) (bn254.GT, error) {
 	// 检查属性集是否有效。
-	if !instance.isValidAttributes(userSecretKey.userAttributes) {
-		return nil, fmt.Errorf("invalid user attributes")
+	if err := instance.checkAttributes(userSecretKey.userAttributes); err != nil {
+		return bn254.GT{}, fmt.Errorf("invalid user attributes: %w", err)
 	}
-	if !instance.isValidAttributes(ciphertext.messageAttributes) {
-		return nil, fmt.Errorf("invalid cipher text")
+	if err := instance.checkAttributes(ciphertext.messageAttributes); err != nil {
+		return bn254.GT{}, fmt.Errorf("invalid cipher text: %w", err)
 	}
 
 	// 查找用户属性集和密文属性集之间的公共属性集 S = S_user ∩ S_msg。
-	// 如果 |S| < d，则返回 nil，表示匹配失败。
-	s := utils.FindCommonAttributes(userSecretKey.userAttributes, ciphertext.messageAttributes, instance.distance)
-	if s == nil {
-		return nil, fmt.Errorf("failed to find enough common attributes")
+	// 使用常数时间求交，避免通过计时泄露交集大小；只在"是否满足门限"上分支。
+	s, ok := utils.FindCommonAttributesConstantTime(userSecretKey.userAttributes, ciphertext.messageAttributes, instance.distance)
+	if !ok {
+		return bn254.GT{}, fmt.Errorf("failed to find enough common attributes: %w", ErrPolicyNotSatisfied)
 	}
+	s = s[:instance.distance]
 
-	// 初始化分母 Denominator = ∏_{i ∈ S} e(D_i, E_i)^(Δ_{0, S}(i))。
-	denominator := bn254.GT{}
-	denominator.SetOne()
+	// 使用流式配对累加器计算分母 Denominator = ∏_{i ∈ S} e(D_i, E_i)^(Δ_{0, S}(i))。
+	// 指数 Δ_{0, S}(i) 被移入 G1：e(D_i, E_i)^Δ = e(D_i^Δ, E_i)，整个乘积只需一次最终幂运算。
+	acc := pairing.NewAccumulator()
 
 	// 遍历公共属性集 S 中的每个属性 i。
 	for _, i := range s {
 		di := userSecretKey.di[i] // 私钥组件 D_i = g1^(q(i)/t_i)
 		ei := ciphertext.ei[i]    // 密文组件 E_i = g2^(t_i * s)
 
-		// 计算配对 e(D_i, E_i) = e(g1^(q(i)/t_i), g2^(t_i * s)) = e(g1, g2)^(q(i) * s)。
-		eDiEi, err := bn254.Pair([]bn254.G1Affine{di}, []bn254.G2Affine{ei})
-		if err != nil {
-			return nil, fmt.Errorf("failed to decrypt MessageBytes")
-		}
-
 		// 计算拉格朗日基多项式 Δ_{0, S}(i) = ∏_{j ∈ S, j ≠ i} (0 - j) / (i - j)。
 		delta := utils.ComputeLagrangeBasis(i, s, *new(fr.Element).SetZero())
 
-		// 计算 e(D_i, E_i)^(Δ_{0, S}(i))。
-		eDiEiDelta := new(bn254.GT).Exp(eDiEi, delta.BigInt(new(big.Int)))
-
-		// 累乘到分母中。
-		denominator.Mul(&denominator, eDiEiDelta)
+		// 累加配对项 e(D_i^(Δ_{0, S}(i)), E_i) = e(g1, g2)^(q(i) * s * Δ_{0, S}(i))。
+		diDelta := new(bn254.G1Affine).ScalarMultiplication(&di, delta.BigInt(new(big.Int)))
+		acc.AddPair(*diDelta, ei)
+	}
+	denominator, err := acc.Finalize()
+	if err != nil {
+		return bn254.GT{}, fmt.Errorf("failed to decrypt MessageBytes: %w", err)
 	}
 
 	// 解密恢复 M = e' / Denominator。
@@ -327,5 +371,14 @@ func (instance *SW05FIBEInstance) Decrypt(userSecretKey *SW05FIBESecretKey, ciph
 	// Denominator = e(g1, g2)^(q(0) * s) = e(g1, g2)^(y * s) = Y^s。
 	// 因此 M = (M * Y^s) / Y^s = M。
 	decryptedMessage := new(bn254.GT).Div(&ciphertext.ePrime, &denominator)
-	return &SW05FIBEMessage{Message: *decryptedMessage}, nil
+	return *decryptedMessage, nil
+}
+
+// Zeroize 将主密钥 y 和全部 t_i 覆写为零，属性宇宙保持不变；清除范围的限制见 README 的 Key Zeroization。
+// 与 SetUp 一样，Zeroize 不能与同一实例上的其他方法并发调用。
+func (instance *SW05FIBEInstance) Zeroize() {
+	instance.msk_y.SetZero()
+	for i := range instance.msk_ti {
+		instance.msk_ti[i] = fr.Element{}
+	}
 }
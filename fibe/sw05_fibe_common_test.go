@@ -1,8 +1,10 @@
 package fibe
 
 import (
+	"errors"
 	"fmt"
 	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/mmsyan/GoPairingBasedCryptography/internal/selftest"
 	"testing"
 )
 
@@ -163,12 +165,15 @@ func TestFIBE4(t *testing.T) {
 		t.Fatal("加密失败:", err)
 	}
 
-	_, err = fibeInstance.Decrypt(secretKey, ciphertext, publicParams)
-	// 解密失败这里err不为空，如果为空则不通过这个测试案例
-	if err == nil {
-		t.Fatal("解密失败的测试案例错误")
+	decryptedMessage, ok, err := fibeInstance.TryDecrypt(secretKey, ciphertext, publicParams)
+	// 不满足阈值不是错误：应返回 (nil, false, nil)
+	if err != nil {
+		t.Fatal("不满足阈值时不应返回错误:", err)
+	}
+	if ok || decryptedMessage != nil {
+		t.Fatal("属性重叠不足时不应解密成功")
 	}
-	fmt.Println("✓ 失败测试通过：属性不匹配导致解密得到错误消息")
+	fmt.Println("✓ 失败测试通过：属性不匹配导致无法解密")
 }
 
 // TestFIBE5 - 多消息测试：同一密钥对不同消息的加解密
@@ -231,6 +236,7 @@ func TestFIBE6(t *testing.T) {
 	}{
 		{3, true, "d=3，重叠4个，应该成功"},
 		{4, true, "d=4，重叠4个，应该成功"},
+		{5, false, "d=5，重叠4个，应该失败"},
 	}
 
 	for _, tc := range testCases {
@@ -250,15 +256,16 @@ func TestFIBE6(t *testing.T) {
 			t.Fatal("加密失败:", err)
 		}
 
-		decryptedMessage, err := fibeInstance.Decrypt(secretKey, ciphertext, publicParams)
+		decryptedMessage, ok, err := fibeInstance.TryDecrypt(secretKey, ciphertext, publicParams)
 		if err != nil {
 			t.Fatal("解密失败:", err)
 		}
-
-		matched := decryptedMessage.Message == message.Message
-		if matched != tc.shouldMatch {
+		if ok != tc.shouldMatch {
 			t.Fatalf("%s - 实际结果与预期不符", tc.description)
 		}
+		if ok && decryptedMessage.Message != message.Message {
+			t.Fatalf("%s - 解密消息与原始消息不匹配", tc.description)
+		}
 
 		fmt.Printf("✓ %s\n", tc.description)
 	}
@@ -449,3 +456,133 @@ func TestFIBE10(t *testing.T) {
 	}
 	fmt.Printf("✓ 完成%d次解密操作\n", iterations)
 }
+
+// TestFIBEZeroize - 普通版本与大域版本的 Zeroize 都清除主密钥，清除后生成的私钥无法完成自检的加解密往返
+func TestFIBEZeroize(t *testing.T) {
+	tests := []struct {
+		name string
+		// setUp 生成实例与公共参数，返回清除主密钥、检查主密钥已清零与执行自检往返的函数
+		setUp func() (zeroize func(), cleared func() error, roundTrip func() error, err error)
+	}{
+		{
+			name: "common",
+			setUp: func() (func(), func() error, func() error, error) {
+				instance := NewSW05FIBEInstanceByInt64Pair(1, 6, 2)
+				publicParams, err := instance.SetUp()
+				if err != nil {
+					return nil, nil, nil, err
+				}
+				cleared := func() error {
+					if !instance.msk_y.IsZero() {
+						return errors.New("msk_y 不为零")
+					}
+					if len(instance.msk_ti) != len(instance.universe) {
+						return fmt.Errorf("t_i 的个数变为 %d", len(instance.msk_ti))
+					}
+					for i, ti := range instance.msk_ti {
+						if !ti.IsZero() {
+							return fmt.Errorf("属性 %s 的 t_i 不为零", i.String())
+						}
+					}
+					return nil
+				}
+				roundTrip := func() error {
+					return selftest.Run("zeroized", func() (*selftest.RoundTrip[bn254.GT, *SW05FIBECiphertext], error) {
+						return keyRoundTrip(instance, publicParams)
+					})
+				}
+				return instance.Zeroize, cleared, roundTrip, nil
+			},
+		},
+		{
+			name: "large universe",
+			setUp: func() (func(), func() error, func() error, error) {
+				instance := NewSW05FIBELargeUniverseInstance(2)
+				publicParams, err := instance.SetUp(5)
+				if err != nil {
+					return nil, nil, nil, err
+				}
+				cleared := func() error {
+					if !instance.msk_y.IsZero() {
+						return errors.New("msk_y 不为零")
+					}
+					return nil
+				}
+				roundTrip := func() error {
+					return selftest.Run("zeroized", func() (*selftest.RoundTrip[bn254.GT, *SW05FIBELargeUniverseCiphertext], error) {
+						return keyRoundTripLargeUniverse(instance, publicParams)
+					})
+				}
+				return instance.Zeroize, cleared, roundTrip, nil
+			},
+		},
+	}
+	for _, tt := range tests {
+		zeroize, cleared, roundTrip, err := tt.setUp()
+		if err != nil {
+			t.Fatalf("%s: 系统初始化失败: %v", tt.name, err)
+		}
+		zeroize()
+		if err := cleared(); err != nil {
+			t.Fatalf("%s: Zeroize 之后 %v", tt.name, err)
+		}
+		if roundTrip() == nil {
+			t.Fatalf("%s: 清除主密钥后生成的私钥仍能解密", tt.name)
+		}
+	}
+}
+
+// TestFIBEDecryptRaw 正确私钥的 DecryptRaw 结果等于明文；另一个实例为相同属性生成的私钥也能完成计算，
+// 但结果与正确结果不同；交集不足时返回错误
+func TestFIBEDecryptRaw(t *testing.T) {
+	m, err := new(bn254.GT).SetRandom()
+	if err != nil {
+		t.Fatal(err)
+	}
+	attributes := NewFIBEAttributes([]int64{1, 2, 3, 4})
+	instance := NewSW05FIBEInstanceByInt64Pair(1, 10, 3)
+	publicParams, err := instance.SetUp()
+	if err != nil {
+		t.Fatal(err)
+	}
+	other := NewSW05FIBEInstanceByInt64Pair(1, 10, 3)
+	otherParams, err := other.SetUp()
+	if err != nil {
+		t.Fatal(err)
+	}
+	secretKey, err := instance.KeyGenerate(attributes, publicParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wrongKey, err := other.KeyGenerate(attributes, otherParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ciphertext, err := instance.Encrypt(attributes, &SW05FIBEMessage{Message: *m}, publicParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	right, err := instance.DecryptRaw(secretKey, ciphertext, publicParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wrong, err := instance.DecryptRaw(wrongKey, ciphertext, publicParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !right.Equal(m) {
+		t.Fatal("正确私钥的 DecryptRaw 结果应等于明文")
+	}
+	if wrong.Equal(&right) {
+		t.Fatal("错误私钥的 DecryptRaw 结果不应等于正确结果")
+	}
+
+	farKey, err := instance.KeyGenerate(NewFIBEAttributes([]int64{1, 7, 8, 9}), publicParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := instance.DecryptRaw(farKey, ciphertext, publicParams); !errors.Is(err, ErrPolicyNotSatisfied) {
+		t.Fatalf("期望 ErrPolicyNotSatisfied，得到 %v", err)
+	}
+}
@@ -0,0 +1,71 @@
+package fibe
+
+import (
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"sync"
+	"testing"
+)
+
+// TestFIBEConcurrentUse 在不同实例上并发调用 SetUp，并在 SetUp 之后并发使用同一实例。
+// 该测试主要配合 go test -race 运行，用于发现实例之间或只读方法之间共享的可变状态。
+func TestFIBEConcurrentUse(t *testing.T) {
+	const workers = 8
+	var wg sync.WaitGroup
+	errs := make(chan error, 3*workers)
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			instance := NewSW05FIBEInstanceByInt64Pair(1, 10, 3)
+			if _, err := instance.SetUp(); err != nil {
+				errs <- err
+			}
+			largeUniverse := NewSW05FIBELargeUniverseInstance(3)
+			if _, err := largeUniverse.SetUp(10); err != nil {
+				errs <- err
+			}
+		}()
+	}
+	wg.Wait()
+
+	shared := NewSW05FIBEInstanceByInt64Pair(1, 10, 3)
+	publicParams, err := shared.SetUp()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			m, err := new(bn254.GT).SetRandom()
+			if err != nil {
+				errs <- err
+				return
+			}
+			secretKey, err := shared.KeyGenerate(NewFIBEAttributes([]int64{1, 2, 3, 4}), publicParams)
+			if err != nil {
+				errs <- err
+				return
+			}
+			ciphertext, err := shared.Encrypt(NewFIBEAttributes([]int64{2, 3, 4, 5}), &SW05FIBEMessage{Message: *m}, publicParams)
+			if err != nil {
+				errs <- err
+				return
+			}
+			decrypted, err := shared.Decrypt(secretKey, ciphertext, publicParams)
+			if err != nil {
+				errs <- err
+				return
+			}
+			if decrypted.Message != *m {
+				t.Error("解密消息与原始消息不匹配")
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,43 @@
+package fibe
+
+import (
+	"errors"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"testing"
+)
+
+// TestFIBEDuplicateAttributesDoNotDecrypt 用户属性 {A,A,A} 面对 3-of-{A,B,C,D} 的密文不能解密，
+// 无论属性集是经 NewFIBEAttributes 构造，还是绕过构造函数直接携带重复元素。
+func TestFIBEDuplicateAttributesDoNotDecrypt(t *testing.T) {
+	fibeInstance := NewSW05FIBEInstanceByInt64Pair(1, 10, 3)
+	publicParams, err := fibeInstance.SetUp()
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := new(bn254.GT).SetRandom()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ciphertext, err := fibeInstance.Encrypt(NewFIBEAttributes([]int64{1, 2, 3, 4}), &SW05FIBEMessage{Message: *m}, publicParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := len(NewFIBEAttributes([]int64{1, 1, 1}).attributes); got != 1 {
+		t.Fatalf("NewFIBEAttributes should drop duplicates, got %d attributes", got)
+	}
+	a := *new(fr.Element).SetInt64(1)
+	for _, userAttributes := range []*SW05FIBEAttributes{
+		NewFIBEAttributes([]int64{1, 1, 1}),
+		{attributes: []fr.Element{a, a, a}},
+	} {
+		secretKey, err := fibeInstance.KeyGenerate(userAttributes, publicParams)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := fibeInstance.Decrypt(secretKey, ciphertext, publicParams); !errors.Is(err, ErrPolicyNotSatisfied) {
+			t.Fatalf("duplicate attributes must not satisfy the threshold, got %v", err)
+		}
+	}
+}
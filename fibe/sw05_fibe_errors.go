@@ -0,0 +1,11 @@
+package fibe
+
+import "errors"
+
+var (
+	// ErrPolicyNotSatisfied 表示用户属性与密文属性的交集小于门限值 d，可用 errors.Is 判断
+	ErrPolicyNotSatisfied = errors.New("access policy is not satisfied")
+
+	// ErrInvalidAttribute 表示属性集合不在属性宇宙中或个数不合法
+	ErrInvalidAttribute = errors.New("invalid attribute")
+)
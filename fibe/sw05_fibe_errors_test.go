@@ -0,0 +1,103 @@
+package fibe
+
+import (
+	"errors"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"strings"
+	"testing"
+)
+
+// TestFIBESentinelErrors 属性不在宇宙中时返回 ErrInvalidAttribute，重叠不足门限时返回 ErrPolicyNotSatisfied
+func TestFIBESentinelErrors(t *testing.T) {
+	fibeInstance := NewSW05FIBEInstanceByInt64Pair(1, 10, 3)
+	publicParams, err := fibeInstance.SetUp()
+	if err != nil {
+		t.Fatal("系统初始化失败:", err)
+	}
+
+	_, err = fibeInstance.KeyGenerate(NewFIBEAttributes([]int64{1, 2, 42}), publicParams)
+	if !errors.Is(err, ErrInvalidAttribute) {
+		t.Fatalf("错误应包装 ErrInvalidAttribute: %v", err)
+	}
+	if !strings.Contains(err.Error(), "attribute 42 not in universe") {
+		t.Fatalf("错误信息未指出非法属性: %v", err)
+	}
+
+	m, err := new(bn254.GT).SetRandom()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = fibeInstance.Encrypt(NewFIBEAttributes([]int64{42}), &SW05FIBEMessage{Message: *m}, publicParams)
+	if !errors.Is(err, ErrInvalidAttribute) {
+		t.Fatalf("错误应包装 ErrInvalidAttribute: %v", err)
+	}
+
+	secretKey, err := fibeInstance.KeyGenerate(NewFIBEAttributes([]int64{1, 2, 3}), publicParams)
+	if err != nil {
+		t.Fatal("密钥生成失败:", err)
+	}
+	ciphertext, err := fibeInstance.Encrypt(NewFIBEAttributes([]int64{1, 2, 6, 7}), &SW05FIBEMessage{Message: *m}, publicParams)
+	if err != nil {
+		t.Fatal("加密失败:", err)
+	}
+	_, err = fibeInstance.Decrypt(secretKey, ciphertext, publicParams)
+	if !errors.Is(err, ErrPolicyNotSatisfied) {
+		t.Fatalf("错误应包装 ErrPolicyNotSatisfied: %v", err)
+	}
+}
+
+// TestFIBELargeUniversePolicyNotSatisfied 大属性宇宙方案在重叠不足门限时返回 ErrPolicyNotSatisfied
+func TestFIBELargeUniversePolicyNotSatisfied(t *testing.T) {
+	fibeInstance := NewSW05FIBELargeUniverseInstance(3)
+	publicParams, err := fibeInstance.SetUp(10)
+	if err != nil {
+		t.Fatal("系统初始化失败:", err)
+	}
+	secretKey, err := fibeInstance.KeyGenerate(NewFIBEAttributes([]int64{1, 2, 3}), publicParams)
+	if err != nil {
+		t.Fatal("密钥生成失败:", err)
+	}
+	m, err := new(bn254.GT).SetRandom()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ciphertext, err := fibeInstance.Encrypt(NewFIBEAttributes([]int64{1, 2, 6, 7}), &SW05FIBELargeUniverseMessage{Message: *m}, publicParams)
+	if err != nil {
+		t.Fatal("加密失败:", err)
+	}
+	_, err = fibeInstance.Decrypt(secretKey, ciphertext, publicParams)
+	if !errors.Is(err, ErrPolicyNotSatisfied) {
+		t.Fatalf("错误应包装 ErrPolicyNotSatisfied: %v", err)
+	}
+}
+
+// TestFIBELargeUniverseAttributeOutOfRange 大属性宇宙方案中超出 [1, n] 的属性 n+5 应使 KeyGenerate 与 Encrypt 失败，并在错误中指出该属性
+func TestFIBELargeUniverseAttributeOutOfRange(t *testing.T) {
+	const n = 10
+	fibeInstance := NewSW05FIBELargeUniverseInstance(2)
+	publicParams, err := fibeInstance.SetUp(n)
+	if err != nil {
+		t.Fatal("系统初始化失败:", err)
+	}
+	attributes := NewFIBEAttributes([]int64{1, n + 5, 3})
+
+	_, err = fibeInstance.KeyGenerate(attributes, publicParams)
+	if !errors.Is(err, ErrInvalidAttribute) {
+		t.Fatalf("错误应包装 ErrInvalidAttribute: %v", err)
+	}
+	if !strings.Contains(err.Error(), "attribute 15 not in range [1, 10]") {
+		t.Fatalf("错误信息未指出非法属性: %v", err)
+	}
+
+	m, err := new(bn254.GT).SetRandom()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = fibeInstance.Encrypt(attributes, &SW05FIBELargeUniverseMessage{Message: *m}, publicParams)
+	if !errors.Is(err, ErrInvalidAttribute) {
+		t.Fatalf("错误应包装 ErrInvalidAttribute: %v", err)
+	}
+	if !strings.Contains(err.Error(), "attribute 15 not in range [1, 10]") {
+		t.Fatalf("错误信息未指出非法属性: %v", err)
+	}
+}
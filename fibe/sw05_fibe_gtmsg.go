@@ -0,0 +1,39 @@
+package fibe
+
+import (
+	"github.com/mmsyan/GoPairingBasedCryptography/gtmsg"
+)
+
+// EncryptPlaintext 加密 gtmsg.Plaintext 形式的明文，明文的模式（RawGT、KEM）不影响密文结构，
+// 解密方需要以加密时的模式调用 DecryptPlaintext。
+//
+// 参数:
+//   - messageAttributes: 密文关联的属性集 S_msg。
+//   - plaintext: 待加密的明文，由 gtmsg.NewRawGT 或 gtmsg.NewKEM 构造。
+//   - publicParams: 系统公共参数。
+//
+// 返回值:
+//   - *SW05FIBECiphertext: 生成的密文指针。
+//   - error: 如果属性集无效，返回错误信息。
+func (instance *SW05FIBEInstance) EncryptPlaintext(messageAttributes *SW05FIBEAttributes, plaintext *gtmsg.Plaintext, publicParams *SW05FIBEPublicParams) (*SW05FIBECiphertext, error) {
+	return instance.Encrypt(messageAttributes, &SW05FIBEMessage{Message: plaintext.GT()}, publicParams)
+}
+
+// DecryptPlaintext 解密密文，并按 mode 把恢复出的 GT 元素还原为 gtmsg.Plaintext。
+//
+// 参数:
+//   - userSecretKey: 用户的私钥。
+//   - ciphertext: 要解密的密文。
+//   - publicParams: 系统公共参数。
+//   - mode: 加密时明文使用的模式。
+//
+// 返回值:
+//   - *gtmsg.Plaintext: 解密后的明文。
+//   - error: 如果属性集无效或交集数量不足 d，或 mode 未知，返回错误信息。
+func (instance *SW05FIBEInstance) DecryptPlaintext(userSecretKey *SW05FIBESecretKey, ciphertext *SW05FIBECiphertext, publicParams *SW05FIBEPublicParams, mode gtmsg.Mode) (*gtmsg.Plaintext, error) {
+	m, err := instance.DecryptRaw(userSecretKey, ciphertext, publicParams)
+	if err != nil {
+		return nil, err
+	}
+	return gtmsg.FromGT(mode, m)
+}
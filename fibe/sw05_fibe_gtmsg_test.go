@@ -0,0 +1,43 @@
+package fibe
+
+import (
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/mmsyan/GoPairingBasedCryptography/gtmsg"
+	"testing"
+)
+
+// TestFIBEPlaintextModes 两种明文模式都能经 FIBE 加密后按相同模式还原
+func TestFIBEPlaintextModes(t *testing.T) {
+	fibeInstance := NewSW05FIBEInstanceByInt64Pair(1, 10, 2)
+	publicParams, err := fibeInstance.SetUp()
+	if err != nil {
+		t.Fatal(err)
+	}
+	secretKey, err := fibeInstance.KeyGenerate(NewFIBEAttributes([]int64{1, 2, 3}), publicParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	messageAttributes := NewFIBEAttributes([]int64{2, 3, 4})
+
+	gt, _ := new(bn254.GT).SetRandom()
+	raw := gtmsg.NewRawGT(*gt)
+	kem, err := gtmsg.NewKEM()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, plaintext := range []*gtmsg.Plaintext{raw, kem} {
+		ciphertext, err := fibeInstance.EncryptPlaintext(messageAttributes, plaintext, publicParams)
+		if err != nil {
+			t.Fatalf("%s: %v", plaintext.Mode(), err)
+		}
+		decrypted, err := fibeInstance.DecryptPlaintext(secretKey, ciphertext, publicParams, plaintext.Mode())
+		if err != nil {
+			t.Fatalf("%s: %v", plaintext.Mode(), err)
+		}
+		want, got := plaintext.GT(), decrypted.GT()
+		if !got.Equal(&want) {
+			t.Fatalf("%s: plaintext did not round-trip", plaintext.Mode())
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package fibe
+
+import (
+	"crypto/cipher"
+	"crypto/rand"
+	"fmt"
+	"github.com/mmsyan/GoPairingBasedCryptography/aead"
+	"github.com/mmsyan/GoPairingBasedCryptography/gtmsg"
+)
+
+// SW05FIBEHybridCiphertext 表示 FIBE 混合加密的密文。
+// FIBE 只能加密 GT 群元素，因此采用 KEM/DEM 结构：
+// 随机选取 GT 元素 K 并用 FIBE 封装（KEM），再以 hash.DeriveKeyFromGT(K) 派生的密钥用 AEAD 加密任意字节数据（DEM）。
+// AEAD 默认为 AES-256-GCM，可通过 EncryptBytesWith 改用 ChaCha20-Poly1305 等构造。
+type SW05FIBEHybridCiphertext struct {
+	aeadID aead.ID             // 头部：DEM 使用的 AEAD 构造，同时作为 AEAD 的附加数据受认证保护。
+	kem    *SW05FIBECiphertext // 封装了 K 的 FIBE 密文。
+	nonce  []byte              // AEAD 随机数。
+	data   []byte              // AEAD 密文（含认证标签）。
+}
+
+// AEAD 返回密文头部记录的 AEAD 构造。
+func (ciphertext *SW05FIBEHybridCiphertext) AEAD() aead.ID {
+	return ciphertext.aeadID
+}
+
+// EncryptBytes 使用混合加密在属性集 messageAttributes 下加密任意字节数据，DEM 使用 aead.Default（AES-256-GCM）。
+//
+// 参数:
+//   - messageAttributes: 密文关联的属性集 S_msg。
+//   - data: 待加密的明文字节。
+//   - publicParams: 系统公共参数。
+//
+// 返回值:
+//   - *SW05FIBEHybridCiphertext: 混合加密密文。
+//   - error: 如果属性集无效或加密失败，返回错误信息。
+func (instance *SW05FIBEInstance) EncryptBytes(messageAttributes *SW05FIBEAttributes, data []byte, publicParams *SW05FIBEPublicParams) (*SW05FIBEHybridCiphertext, error) {
+	return instance.EncryptBytesWith(messageAttributes, data, publicParams, aead.Default)
+}
+
+// EncryptBytesWith 与 EncryptBytes 相同，但 DEM 使用 factory 指定的 AEAD 构造，构造的 ID 记录在密文头部。
+//
+// 参数:
+//   - messageAttributes: 密文关联的属性集 S_msg。
+//   - data: 待加密的明文字节。
+//   - publicParams: 系统公共参数。
+//   - factory: DEM 使用的 AEAD 构造，例如 aead.ChaCha20Poly1305；为 nil 时使用 aead.Default。
+//
+// 返回值:
+//   - *SW05FIBEHybridCiphertext: 混合加密密文。
+//   - error: 如果属性集无效或加密失败，返回错误信息。
+func (instance *SW05FIBEInstance) EncryptBytesWith(messageAttributes *SW05FIBEAttributes, data []byte, publicParams *SW05FIBEPublicParams, factory aead.Factory) (*SW05FIBEHybridCiphertext, error) {
+	if factory == nil {
+		factory = aead.Default
+	}
+	// KEM：随机选取 K ∈ GT 并用 FIBE 加密。
+	k, err := gtmsg.NewKEM()
+	if err != nil {
+		return nil, err
+	}
+	kem, err := instance.EncryptPlaintext(messageAttributes, k, publicParams)
+	if err != nil {
+		return nil, err
+	}
+
+	// DEM：AEAD(HKDF(K), data)，头部作为附加数据。
+	dem, err := newHybridAEAD(k, factory)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, dem.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	return &SW05FIBEHybridCiphertext{
+		aeadID: factory.ID(),
+		kem:    kem,
+		nonce:  nonce,
+		data:   dem.Seal(nil, nonce, data, hybridHeader(factory.ID())),
+	}, nil
+}
+
+// DecryptBytes 解密 EncryptBytes 或 EncryptBytesWith 生成的混合密文，AEAD 构造按密文头部选择。
+// 当用户属性与密文属性的交集不足 d 时返回错误；
+// 当恢复出的 K 不正确（例如私钥来自其他系统）时，由 AEAD 认证标签校验失败报错，而不会返回错误的明文。
+//
+// 参数:
+//   - userSecretKey: 用户的私钥。
+//   - ciphertext: 混合加密密文。
+//   - publicParams: 系统公共参数。
+//
+// 返回值:
+//   - []byte: 解密得到的明文字节。
+//   - error: 如果解密失败，返回错误信息。
+func (instance *SW05FIBEInstance) DecryptBytes(userSecretKey *SW05FIBESecretKey, ciphertext *SW05FIBEHybridCiphertext, publicParams *SW05FIBEPublicParams) ([]byte, error) {
+	return instance.DecryptBytesWith(userSecretKey, ciphertext, publicParams, nil)
+}
+
+// DecryptBytesWith 与 DecryptBytes 相同，但要求密文头部记录的 AEAD 与 factory 一致，
+// 调用方据此拒绝以非预期构造加密的密文。
+//
+// 参数:
+//   - userSecretKey: 用户的私钥。
+//   - ciphertext: 混合加密密文。
+//   - publicParams: 系统公共参数。
+//   - factory: 期望的 AEAD 构造；为 nil 时按密文头部选择。
+//
+// 返回值:
+//   - []byte: 解密得到的明文字节。
+//   - error: 头部与 factory 不一致时返回包装 aead.ErrAEADMismatch 的错误，头部 ID 未知时返回包装 aead.ErrUnknownAEAD 的错误；
+//     其他解密失败时返回错误信息。
+func (instance *SW05FIBEInstance) DecryptBytesWith(userSecretKey *SW05FIBESecretKey, ciphertext *SW05FIBEHybridCiphertext, publicParams *SW05FIBEPublicParams, factory aead.Factory) ([]byte, error) {
+	if factory == nil {
+		var err error
+		if factory, err = aead.Lookup(ciphertext.aeadID); err != nil {
+			return nil, fmt.Errorf("failed to decrypt: %w", err)
+		}
+	} else if factory.ID() != ciphertext.aeadID {
+		return nil, fmt.Errorf("failed to decrypt: %w: header %d, expected %s", aead.ErrAEADMismatch, byte(ciphertext.aeadID), factory.Name())
+	}
+	k, err := instance.DecryptPlaintext(userSecretKey, ciphertext.kem, publicParams, gtmsg.KEM)
+	if err != nil {
+		return nil, err
+	}
+	dem, err := newHybridAEAD(k, factory)
+	if err != nil {
+		return nil, err
+	}
+	if len(ciphertext.nonce) != dem.NonceSize() {
+		return nil, fmt.Errorf("invalid nonce length")
+	}
+	plaintext, err := dem.Open(nil, ciphertext.nonce, ciphertext.data, hybridHeader(ciphertext.aeadID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to authenticate ciphertext: %w", err)
+	}
+	return plaintext, nil
+}
+
+// hybridKDFInfo 是 FIBE 混合加密派生 DEM 密钥时使用的 HKDF 域分隔标签前缀，后接 AEAD 构造名称，
+// 不同构造使用不同的密钥。
+const hybridKDFInfo = "sw05-fibe-hybrid-"
+
+// newHybridAEAD 由 KEM 明文派生 factory 指定的 AEAD 实例，密钥为 HKDF-SHA256(K, hybridKDFInfo || factory.Name())。
+func newHybridAEAD(k *gtmsg.Plaintext, factory aead.Factory) (cipher.AEAD, error) {
+	key, err := k.Key([]byte(hybridKDFInfo+factory.Name()), factory.KeySize())
+	if err != nil {
+		return nil, err
+	}
+	dem, err := factory.New(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	return dem, nil
+}
+
+// hybridHeader 返回混合密文头部的编码，作为 AEAD 的附加数据，使篡改头部的密文无法通过认证。
+func hybridHeader(id aead.ID) []byte {
+	return []byte{byte(id)}
+}
@@ -0,0 +1,150 @@
+package fibe
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"github.com/mmsyan/GoPairingBasedCryptography/aead"
+	"testing"
+)
+
+// TestFIBEHybridEncryptBytes - 混合加密测试：加密 JSON 数据，属性满足门限的用户可以解密
+func TestFIBEHybridEncryptBytes(t *testing.T) {
+	data, err := json.Marshal(map[string]interface{}{
+		"patient": "alice",
+		"records": []string{"2025-01-01 checkup", "2025-02-14 blood test"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fibeInstance := NewSW05FIBEInstanceByInt64Pair(1, 10, 3)
+	publicParams, err := fibeInstance.SetUp()
+	if err != nil {
+		t.Fatal("系统初始化失败:", err)
+	}
+	ciphertext, err := fibeInstance.EncryptBytes(NewFIBEAttributes([]int64{1, 2, 3, 4}), data, publicParams)
+	if err != nil {
+		t.Fatal("加密失败:", err)
+	}
+
+	// 交集 {2, 3, 4} 满足门限 3
+	secretKey, err := fibeInstance.KeyGenerate(NewFIBEAttributes([]int64{2, 3, 4, 5}), publicParams)
+	if err != nil {
+		t.Fatal("密钥生成失败:", err)
+	}
+	decrypted, err := fibeInstance.DecryptBytes(secretKey, ciphertext, publicParams)
+	if err != nil {
+		t.Fatal("解密失败:", err)
+	}
+	if !bytes.Equal(decrypted, data) {
+		t.Fatal("解密数据与原始数据不匹配")
+	}
+
+	// 交集 {3, 4} 不满足门限 3
+	insufficientKey, err := fibeInstance.KeyGenerate(NewFIBEAttributes([]int64{3, 4, 6, 7}), publicParams)
+	if err != nil {
+		t.Fatal("密钥生成失败:", err)
+	}
+	if _, err := fibeInstance.DecryptBytes(insufficientKey, ciphertext, publicParams); err == nil {
+		t.Fatal("属性不足的私钥不应解密成功")
+	}
+}
+
+// TestFIBEHybridWrongKeyFailsAuthentication - 来自另一个系统的私钥满足门限但恢复出错误的会话密钥，
+// 必须由 AEAD 认证失败报错，而不是返回错误的明文
+func TestFIBEHybridWrongKeyFailsAuthentication(t *testing.T) {
+	data := []byte(`{"secret":"value"}`)
+
+	instance1 := NewSW05FIBEInstanceByInt64Pair(1, 10, 2)
+	publicParams1, _ := instance1.SetUp()
+	instance2 := NewSW05FIBEInstanceByInt64Pair(1, 10, 2)
+	publicParams2, _ := instance2.SetUp()
+
+	ciphertext, err := instance1.EncryptBytes(NewFIBEAttributes([]int64{1, 2, 3}), data, publicParams1)
+	if err != nil {
+		t.Fatal("加密失败:", err)
+	}
+	foreignKey, _ := instance2.KeyGenerate(NewFIBEAttributes([]int64{1, 2, 3}), publicParams2)
+
+	if _, err := instance1.DecryptBytes(foreignKey, ciphertext, publicParams1); err == nil {
+		t.Fatal("错误的私钥应导致认证失败")
+	}
+}
+
+// TestFIBEHybridPluggableAEAD - 分别以 AES-256-GCM 和 ChaCha20-Poly1305 作为 DEM 加解密，
+// 密文头部记录所选构造，DecryptBytes 按头部选择构造
+func TestFIBEHybridPluggableAEAD(t *testing.T) {
+	data := []byte(`{"device":"sensor-42","reading":17.5}`)
+
+	fibeInstance := NewSW05FIBEInstanceByInt64Pair(1, 10, 2)
+	publicParams, err := fibeInstance.SetUp()
+	if err != nil {
+		t.Fatal("系统初始化失败:", err)
+	}
+	secretKey, err := fibeInstance.KeyGenerate(NewFIBEAttributes([]int64{1, 2, 5}), publicParams)
+	if err != nil {
+		t.Fatal("密钥生成失败:", err)
+	}
+
+	for _, factory := range []aead.Factory{aead.AES256GCM, aead.ChaCha20Poly1305} {
+		t.Run(factory.Name(), func(t *testing.T) {
+			ciphertext, err := fibeInstance.EncryptBytesWith(NewFIBEAttributes([]int64{1, 2, 3}), data, publicParams, factory)
+			if err != nil {
+				t.Fatal("加密失败:", err)
+			}
+			if ciphertext.AEAD() != factory.ID() {
+				t.Fatalf("密文头部应为 %d，实际为 %d", factory.ID(), ciphertext.AEAD())
+			}
+			decrypted, err := fibeInstance.DecryptBytes(secretKey, ciphertext, publicParams)
+			if err != nil {
+				t.Fatal("解密失败:", err)
+			}
+			if !bytes.Equal(decrypted, data) {
+				t.Fatal("解密数据与原始数据不匹配")
+			}
+			decrypted, err = fibeInstance.DecryptBytesWith(secretKey, ciphertext, publicParams, factory)
+			if err != nil || !bytes.Equal(decrypted, data) {
+				t.Fatal("指定相同构造时应解密成功:", err)
+			}
+		})
+	}
+
+	// 默认构造为 AES-256-GCM
+	ciphertext, err := fibeInstance.EncryptBytes(NewFIBEAttributes([]int64{1, 2, 3}), data, publicParams)
+	if err != nil {
+		t.Fatal("加密失败:", err)
+	}
+	if ciphertext.AEAD() != aead.IDAES256GCM {
+		t.Fatal("EncryptBytes 应使用 AES-256-GCM")
+	}
+}
+
+// TestFIBEHybridAEADHeader - 指定的构造与头部不一致时返回 ErrAEADMismatch；
+// 篡改头部后由于头部受 AEAD 认证保护，解密失败
+func TestFIBEHybridAEADHeader(t *testing.T) {
+	data := []byte("header-bound payload")
+
+	fibeInstance := NewSW05FIBEInstanceByInt64Pair(1, 10, 2)
+	publicParams, _ := fibeInstance.SetUp()
+	secretKey, _ := fibeInstance.KeyGenerate(NewFIBEAttributes([]int64{1, 2}), publicParams)
+
+	ciphertext, err := fibeInstance.EncryptBytesWith(NewFIBEAttributes([]int64{1, 2, 3}), data, publicParams, aead.ChaCha20Poly1305)
+	if err != nil {
+		t.Fatal("加密失败:", err)
+	}
+	if _, err := fibeInstance.DecryptBytesWith(secretKey, ciphertext, publicParams, aead.AES256GCM); !errors.Is(err, aead.ErrAEADMismatch) {
+		t.Fatalf("期望 ErrAEADMismatch，实际为 %v", err)
+	}
+
+	tampered := *ciphertext
+	tampered.aeadID = aead.IDAES256GCM
+	if _, err := fibeInstance.DecryptBytes(secretKey, &tampered, publicParams); err == nil {
+		t.Fatal("篡改头部的密文不应解密成功")
+	}
+
+	tampered.aeadID = 0xff
+	if _, err := fibeInstance.DecryptBytes(secretKey, &tampered, publicParams); !errors.Is(err, aead.ErrUnknownAEAD) {
+		t.Fatalf("期望 ErrUnknownAEAD，实际为 %v", err)
+	}
+}
@@ -0,0 +1,70 @@
+package fibe
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"math/big"
+	"testing"
+)
+
+// fibeKATCiphertextDigest 是下面固定参数下密文 MarshalBinary 的 SHA-256 摘要。
+// 修改加密流程或序列化格式导致该值变化时，需要确认变化是有意的。
+const fibeKATCiphertextDigest = "82f4ad7eda6449dbd13e6cd6ffc48201ad9999c6349f8cf41b4b11302d484dba"
+
+// katFIBE 用固定的主密钥 t_i = 100 + i、y = 77 构造实例与公共参数，代替随机的 SetUp
+func katFIBE() (*SW05FIBEInstance, *SW05FIBEPublicParams) {
+	instance := NewSW05FIBEInstanceByInt64Pair(1, 6, 2)
+	_, _, g1, g2 := bn254.Generators()
+	publicParams := &SW05FIBEPublicParams{
+		g1:    g1,
+		g2:    g2,
+		pk_Ti: make(map[fr.Element]bn254.G2Affine),
+	}
+	for i := int64(1); i < 6; i++ {
+		attribute := *new(fr.Element).SetInt64(i)
+		ti := big.NewInt(100 + i)
+		instance.msk_ti[attribute] = *new(fr.Element).SetBigInt(ti)
+		publicParams.pk_Ti[attribute] = *new(bn254.G2Affine).ScalarMultiplicationBase(ti)
+	}
+	instance.msk_y.SetUint64(77)
+	eG1G2, _ := bn254.Pair([]bn254.G1Affine{g1}, []bn254.G2Affine{g2})
+	publicParams.pk_Y = *new(bn254.GT).Exp(eG1G2, big.NewInt(77))
+	return instance, publicParams
+}
+
+func TestFIBEKnownAnswer(t *testing.T) {
+	instance, publicParams := katFIBE()
+	_, _, g1, g2 := bn254.Generators()
+	eG1G2, err := bn254.Pair([]bn254.G1Affine{g1}, []bn254.G2Affine{g2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	message := &SW05FIBEMessage{Message: *new(bn254.GT).Exp(eG1G2, big.NewInt(42))}
+
+	ciphertext, err := instance.EncryptWithRandomness(NewFIBEAttributes([]int64{1, 2, 3}), message, publicParams, *new(fr.Element).SetUint64(123456789))
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := ciphertext.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest := sha256.Sum256(data)
+	if got := hex.EncodeToString(digest[:]); got != fibeKATCiphertextDigest {
+		t.Fatalf("ciphertext digest = %s, want %s", got, fibeKATCiphertextDigest)
+	}
+
+	secretKey, err := instance.KeyGenerate(NewFIBEAttributes([]int64{2, 3, 4}), publicParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decrypted, err := instance.Decrypt(secretKey, ciphertext, publicParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !decrypted.Message.Equal(&message.Message) {
+		t.Fatal("解密消息与原始消息不匹配")
+	}
+}
@@ -22,14 +22,20 @@ package fibe
 
 import (
 	"fmt"
+	"github.com/consensys/gnark-crypto/ecc"
 	"github.com/consensys/gnark-crypto/ecc/bn254"
 	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/mmsyan/GoPairingBasedCryptography/metrics"
+	"github.com/mmsyan/GoPairingBasedCryptography/pairing"
 	"github.com/mmsyan/GoPairingBasedCryptography/utils"
 	"math/big"
 )
 
 // SW05FIBELargeUniverseInstance 表示Sahai-Waters 2005大域模糊身份基加密(FIBE)方案的实例对象。
 // 该实例主要由密钥生成中心(PKG)维护。
+//
+// 并发约定: 主密钥 y 在构造时生成，之后所有方法（包括 SetUp）都只读取实例，
+// 因此同一实例可以在多个 goroutine 中并发使用。
 type SW05FIBELargeUniverseInstance struct {
 	distance int // **容错距离 d (门限值):** 只有当用户属性集和密文属性集的交集大小
 	// 大于等于 d 时,解密才能成功。
@@ -47,6 +53,10 @@ type SW05FIBELargeUniversePublicParams struct {
 	ti map[int64]bn254.G2Affine // **G2 群上的随机点 T_i':** 一组随机的 G2 群元素,i 属于 {1, ..., n+1}。
 	// 用于在 SetUp 阶段定义 T_i 函数 (computeT)。
 	pk_Y bn254.GT // **公开参数 pk_Y:** GT 群上的元素 Y = e(g1, g2)^y,由主密钥 y 派生。
+	// **computeT 的预计算缓存:** 由 SetUp 根据 N = {1, ..., n+1} 生成,与消息和密钥无关。
+	lagrangeNodes   []fr.Element     // 插值节点 N = {1, ..., n+1}。
+	lagrangeWeights []fr.Element     // 重心权重 $w_i = 1 / \prod_{j \in N, j \neq i} (i - j)$,与 lagrangeNodes 一一对应。
+	tiPoints        []bn254.G2Affine // 按节点顺序排列的 $T_1', \dots, T_{n+1}'$。
 }
 
 // SW05FIBELargeUniverseSecretKey 表示SW05 FIBE方案中的用户私钥。
@@ -64,6 +74,7 @@ type SW05FIBELargeUniverseSecretKey struct {
 // 明文必须是 GT 群上的元素。
 type SW05FIBELargeUniverseMessage struct {
 	Message bn254.GT // **GT 群上的消息 M。**
+	sealed  []byte   // SetBytes 以会话密钥加密的字节数据
 }
 
 // SW05FIBELargeUniverseCiphertext 表示SW05 FIBE方案中的密文。
@@ -99,7 +110,7 @@ func NewSW05FIBELargeUniverseInstance(distance int) *SW05FIBELargeUniverseInstan
 // 该方法设置属性域大小 n,并基于主密钥 y 生成公开参数 Y 和辅助参数 T_i'。
 //
 // 参数:
-//   - n: 加密的时候可以用的属性个数上限
+//   - n: 加密的时候可以用的属性个数上限；KeyGenerate 与 Encrypt 只接受 [1, n] 内的整数属性
 //
 // 返回值:
 //   - *SW05FIBELargeUniversePublicParams: 系统公共参数。
@@ -113,24 +124,34 @@ func (instance *SW05FIBELargeUniverseInstance) SetUp(n int64) (*SW05FIBELargeUni
 	for i := int64(1); i <= n+1; i++ {
 		temp, err := new(fr.Element).SetRandom() // t_i' <- Zq
 		if err != nil {
-			return nil, fmt.Errorf("fibe instance setup failure")
+			return nil, fmt.Errorf("fibe instance setup failure: %w", err)
 		}
 		ti[i] = *new(bn254.G2Affine).ScalarMultiplicationBase(temp.BigInt(new(big.Int)))
 	}
 
 	// 计算 Y = e(g1, g2)^y。
-	eG1G2, err := bn254.Pair([]bn254.G1Affine{g1}, []bn254.G2Affine{g2}) // e(g1, g2)
+	eG1G2, err := metrics.Pair([]bn254.G1Affine{g1}, []bn254.G2Affine{g2}) // e(g1, g2)
 	if err != nil {
-		return nil, fmt.Errorf("fibe instance setup failure")
+		return nil, fmt.Errorf("fibe instance setup failure: %w", err)
 	}
 	pk_Y := *new(bn254.GT).Exp(eG1G2, instance.msk_y.BigInt(new(big.Int)))
 
+	// 预计算 computeT 使用的插值节点、重心权重和 T_i' 列表。
+	nodes, weights := lagrangeBarycentricWeights(n + 1)
+	tiPoints := make([]bn254.G2Affine, n+1)
+	for i := int64(1); i <= n+1; i++ {
+		tiPoints[i-1] = ti[i]
+	}
+
 	return &SW05FIBELargeUniversePublicParams{
-		n:    n,
-		g1:   g1,
-		g2:   g2,
-		ti:   ti,
-		pk_Y: pk_Y,
+		n:               n,
+		g1:              g1,
+		g2:              g2,
+		ti:              ti,
+		pk_Y:            pk_Y,
+		lagrangeNodes:   nodes,
+		lagrangeWeights: weights,
+		tiPoints:        tiPoints,
 	}, nil
 }
 
@@ -143,8 +164,13 @@ func (instance *SW05FIBELargeUniverseInstance) SetUp(n int64) (*SW05FIBELargeUni
 //
 // 返回值:
 //   - *SW05FIBELargeUniverseSecretKey: 生成的私钥。
-//   - error: 如果密钥生成失败,返回错误信息。
+//   - error: 如果属性不在 [1, n] 内或密钥生成失败,返回错误信息。
 func (instance *SW05FIBELargeUniverseInstance) KeyGenerate(userAttributes *SW05FIBEAttributes, publicParams *SW05FIBELargeUniversePublicParams) (*SW05FIBELargeUniverseSecretKey, error) {
+	// 检查属性是否都在 [1, n] 内。
+	if err := publicParams.checkAttributes(userAttributes.attributes); err != nil {
+		return nil, fmt.Errorf("invalid user attributes: %w", err)
+	}
+
 	di := make(map[fr.Element]bn254.G1Affine)
 	Di := make(map[fr.Element]bn254.G2Affine)
 
@@ -155,9 +181,9 @@ func (instance *SW05FIBELargeUniverseInstance) KeyGenerate(userAttributes *SW05F
 	// 2. 为 S_user 中的每个属性 i 计算私钥组件。
 	for _, i := range userAttributes.attributes {
 		// 随机数 r_i <- Zq。
-		ri, err := new(fr.Element).SetRandom()
+		ri, err := utils.RandomNonZeroScalar()
 		if err != nil {
-			return nil, fmt.Errorf("fibe instance setup failure")
+			return nil, fmt.Errorf("fibe instance setup failure: %w", err)
 		}
 
 		// 计算 d_i = g1^{r_i}。
@@ -180,7 +206,7 @@ func (instance *SW05FIBELargeUniverseInstance) KeyGenerate(userAttributes *SW05F
 	}
 
 	return &SW05FIBELargeUniverseSecretKey{
-		userAttributes: userAttributes.attributes,
+		userAttributes: utils.DedupAttributes(userAttributes.attributes),
 		_di:            di,
 		_Di:            Di,
 	}, nil
@@ -195,12 +221,17 @@ func (instance *SW05FIBELargeUniverseInstance) KeyGenerate(userAttributes *SW05F
 //
 // 返回值:
 //   - *SW05FIBELargeUniverseCiphertext: 加密后的密文。
-//   - error: 如果加密失败,返回错误信息。
+//   - error: 如果属性不在 [1, n] 内或加密失败,返回错误信息。
 func (instance *SW05FIBELargeUniverseInstance) Encrypt(messageAttributes *SW05FIBEAttributes, message *SW05FIBELargeUniverseMessage, publicParams *SW05FIBELargeUniversePublicParams) (*SW05FIBELargeUniverseCiphertext, error) {
+	// 检查属性是否都在 [1, n] 内。
+	if err := publicParams.checkAttributes(messageAttributes.attributes); err != nil {
+		return nil, fmt.Errorf("invalid message attributes: %w", err)
+	}
+
 	// 1. 选择一个随机数 s <- Zq。
-	s, err := new(fr.Element).SetRandom()
+	s, err := utils.RandomNonZeroScalar()
 	if err != nil {
-		return nil, fmt.Errorf("failed to encrypt MessageBytes")
+		return nil, fmt.Errorf("failed to encrypt MessageBytes: %w", err)
 	}
 
 	// 2. 计算 Y^s。
@@ -222,7 +253,7 @@ func (instance *SW05FIBELargeUniverseInstance) Encrypt(messageAttributes *SW05FI
 	}
 
 	return &SW05FIBELargeUniverseCiphertext{
-		messageAttributes: messageAttributes.attributes,
+		messageAttributes: utils.DedupAttributes(messageAttributes.attributes),
 		ePrime:            ePrime,
 		ePrimePrime:       ePrimePrime,
 		ei:                ei,
@@ -243,56 +274,56 @@ func (instance *SW05FIBELargeUniverseInstance) Encrypt(messageAttributes *SW05FI
 //   - error: 如果解密失败(如交集属性不足 d 个),返回错误信息。
 func (instance *SW05FIBELargeUniverseInstance) Decrypt(userSecretKey *SW05FIBELargeUniverseSecretKey, ciphertext *SW05FIBELargeUniverseCiphertext, publicParams *SW05FIBELargeUniversePublicParams) (*SW05FIBELargeUniverseMessage, error) {
 	// 1. 找到 S_user 和 S_msg 之间的公共属性子集 S, 且 |S| \ge d。
-	s := utils.FindCommonAttributes(userSecretKey.userAttributes, ciphertext.messageAttributes, instance.distance)
-	if s == nil {
-		return nil, fmt.Errorf("failed to find enough common attributes")
+	// 使用常数时间求交，避免通过计时泄露交集大小；只在"是否满足门限"上分支。
+	s, ok := utils.FindCommonAttributesConstantTime(userSecretKey.userAttributes, ciphertext.messageAttributes, instance.distance)
+	if !ok {
+		return nil, fmt.Errorf("failed to find enough common attributes: %w", ErrPolicyNotSatisfied)
 	}
+	s = s[:instance.distance]
 
-	// 2. 初始化分母 D = e(g_1, g_2)^{-ys} (在配对运算后计算)。
-	denominator := new(bn254.GT).SetOne()
+	// 2. 使用流式配对累加器计算分母 D = e(g_1, g_2)^{-ys}，整个乘积只需一次最终幂运算。
+	acc := pairing.NewAccumulator()
+	ePrimePrime := ciphertext.ePrimePrime // $E'' = g_1^s$
 
 	// 3. 遍历公共属性集 S 中的每个属性 i, 利用拉格朗日插值计算 Y^s 的逆。
 	for _, i := range s {
-		di := userSecretKey._di[i]            // $d_i = g_1^{r_i}$
-		Di := userSecretKey._Di[i]            // $D_i = g_2^{q(i)} \cdot T_i^{r_i}$
-		ei := ciphertext.ei[i]                // $E_i = T_i^s$
-		ePrimePrime := ciphertext.ePrimePrime // $E'' = g_1^s$
-
-		// 计算配对项 1: $e(d_i, E_i) = e(g_1^{r_i}, T_i^s) = e(g_1, T_i)^{r_i s}$。
-		ediEi, err := bn254.Pair([]bn254.G1Affine{di}, []bn254.G2Affine{ei})
-		if err != nil {
-			return nil, fmt.Errorf("failed to decrypt MessageBytes")
-		}
-
-		// 计算配对项 2: $e(E'', D_i) = e(g_1^s, g_2^{q(i)} \cdot T_i^{r_i}) = e(g_1, g_2)^{s q(i)} \cdot e(g_1, T_i)^{s r_i}$。
-		eDiEPrimePrime, err := bn254.Pair([]bn254.G1Affine{ePrimePrime}, []bn254.G2Affine{Di})
-		if err != nil {
-			return nil, fmt.Errorf("failed to decrypt MessageBytes")
-		}
-
-		// 计算 $P_i = \frac{e(d_i, E_i)}{e(E'', D_i)} = \frac{e(g_1, T_i)^{r_i s}}{e(g_1, g_2)^{s q(i)} \cdot e(g_1, T_i)^{s r_i}} = e(g_1, g_2)^{-s q(i)}$。
-		pairDiv := new(bn254.GT).Div(&ediEi, &eDiEPrimePrime)
+		di := userSecretKey._di[i] // $d_i = g_1^{r_i}$
+		Di := userSecretKey._Di[i] // $D_i = g_2^{q(i)} \cdot T_i^{r_i}$
+		ei := ciphertext.ei[i]     // $E_i = T_i^s$
 
 		// 计算拉格朗日基多项式 $\Delta_{0, S}(i) = \prod_{j \in S, j \neq i} \frac{0 - j}{i - j}$。
 		delta := utils.ComputeLagrangeBasis(i, s, *new(fr.Element).SetZero())
+		deltaBig := delta.BigInt(new(big.Int))
+
+		// $P_i = \frac{e(d_i, E_i)}{e(E'', D_i)} = e(g_1, g_2)^{-s q(i)}$，其 $\Delta_{0, S}(i)$ 次幂可写为
+		// $e(d_i^{\Delta}, E_i) \cdot e(E''^{-\Delta}, D_i)$，两个配对项都加入累加器。
+		diDelta := new(bn254.G1Affine).ScalarMultiplication(&di, deltaBig)
+		ePrimePrimeNegDelta := new(bn254.G1Affine).ScalarMultiplication(&ePrimePrime, deltaBig)
+		ePrimePrimeNegDelta.Neg(ePrimePrimeNegDelta)
+		acc.AddPair(*diDelta, ei)
+		acc.AddPair(*ePrimePrimeNegDelta, Di)
+	}
 
-		// 计算 $P_i^{\Delta_{0, S}(i)} = e(g_1, g_2)^{-s q(i) \cdot \Delta_{0, S}(i)}$。
-		pairExpDelta := new(bn254.GT).Exp(*pairDiv, delta.BigInt(new(big.Int)))
-
-		// 累乘到分母中。根据拉格朗日插值性质, $\prod_{i \in S} e(g_1, g_2)^{-s q(i) \cdot \Delta_{0, S}(i)} = e(g_1, g_2)^{-s q(0)} = e(g_1, g_2)^{-s y} = Y^{-s}$。
-		denominator.Mul(denominator, pairExpDelta)
+	// 根据拉格朗日插值性质, $\prod_{i \in S} P_i^{\Delta_{0, S}(i)} = e(g_1, g_2)^{-s q(0)} = Y^{-s}$。
+	denominator, err := acc.Finalize()
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt MessageBytes: %w", err)
 	}
 
 	// 4. 计算 $M = e' \cdot Y^{-s} = (M \cdot Y^s) \cdot Y^{-s}$。
-	m := new(bn254.GT).Mul(&ciphertext.ePrime, denominator)
+	m := new(bn254.GT).Mul(&ciphertext.ePrime, &denominator)
 	return &SW05FIBELargeUniverseMessage{
 		Message: *m,
 	}, nil
 }
 
 // computeT 是一个辅助函数,用于计算 G2 群元素 $T_x = g_2^{t(x)}$。
-// 其中 $t(x)$ 是一个与 n 个随机点 $T_i'$ 相关的拉格朗日插值多项式。
-// $T_x = g_2^{x^n} \cdot \prod_{i=1}^{n+1} (T_i')^{\Delta_{x, N}(i)}$, 其中 $N=\{1, \dots, n+1\}$。
+// 其中 $t(x)$ 是一个与 n+1 个随机点 $T_i'$ 相关的拉格朗日插值多项式。
+// $T_x = g_2^{x^n} \cdot \prod_{i=1}^{n+1} (T_i')^{\Delta_{i, N}(x)}$, 其中 $N=\{1, \dots, n+1\}$。
+//
+// 基函数使用重心形式 $\Delta_{i, N}(x) = w_i \cdot L(x) / (x - i)$,其中 $L(x) = \prod_{j \in N} (x - j)$,
+// 重心权重 $w_i$ 在 SetUp 时预计算,$1/(x - i)$ 用一次批量求逆得到。
+// 因此每次调用只需 O(n) 次域运算和一次 n+1 项的 G2 多标量乘法,而不是逐个基函数重新求 O(n) 次逆元。
 //
 // 参数:
 //   - x: 属性值 x。
@@ -304,22 +335,64 @@ func (publicParams *SW05FIBELargeUniversePublicParams) computeT(x fr.Element) bn
 	xExpN := new(fr.Element).Exp(x, big.NewInt(publicParams.n))
 	g2ExpXExpN := new(bn254.G2Affine).ScalarMultiplicationBase(xExpN.BigInt(new(big.Int)))
 
-	// 构造集合 N = {1, ..., n+1}。
-	N := make([]fr.Element, publicParams.n+1)
-	for i := 0; i < len(N); i++ {
-		N[i] = *new(fr.Element).SetInt64(int64(i + 1))
+	// 2. 计算 $\Delta_{i, N}(x)$。
+	deltas := lagrangeBasisAt(publicParams.lagrangeNodes, publicParams.lagrangeWeights, x)
+
+	// 3. 用一次多标量乘法计算 $\prod_{i=1}^{n+1} (T_i')^{\Delta_{i, N}(x)}$ 并累加到 $g_2^{x^n}$ 上。
+	// 默认配置下 MultiExp 不会返回错误。
+	product, _ := new(bn254.G2Affine).MultiExp(publicParams.tiPoints, deltas, ecc.MultiExpConfig{})
+	g2ExpXExpN.Add(g2ExpXExpN, product)
+
+	return *g2ExpXExpN
+}
+
+// lagrangeBarycentricWeights 返回插值节点 {1, ..., size} 及其重心权重 $w_i = 1 / \prod_{j \neq i} (i - j)$。
+func lagrangeBarycentricWeights(size int64) ([]fr.Element, []fr.Element) {
+	nodes := make([]fr.Element, size)
+	for i := range nodes {
+		nodes[i].SetInt64(int64(i + 1))
 	}
+	denominators := make([]fr.Element, size)
+	for i := range nodes {
+		denominators[i].SetOne()
+		for j := range nodes {
+			if i != j {
+				var diff fr.Element
+				diff.Sub(&nodes[i], &nodes[j])
+				denominators[i].Mul(&denominators[i], &diff)
+			}
+		}
+	}
+	return nodes, fr.BatchInvert(denominators)
+}
 
-	// 2. 计算 $\prod_{i=1}^{n+1} (T_i')^{\Delta_{x, N}(i)}$ 并累加到 $g_2^{x^n}$ 上。
-	// 注意: 代码中的循环索引从 0 开始,与论文中的 $i \in \{1, \dots, n+1\}$ 可能不完全对应,
-	for i := int64(0); i < int64(len(publicParams.ti)); i++ {
-		// 计算 $\Delta_{x, N}(i) = \prod_{j \in N, j \neq i} \frac{x - j}{i - j}$。
-		// 这里的 i 应该代表 N 中的元素。
-		delta := utils.ComputeLagrangeBasis(*new(fr.Element).SetInt64(i), N, x)
-		ti := publicParams.ti[i] // $T_i'$
-		tiExpDelta := new(bn254.G2Affine).ScalarMultiplication(&ti, delta.BigInt(new(big.Int)))
-		g2ExpXExpN.Add(g2ExpXExpN, tiExpDelta)
+// lagrangeBasisAt 使用重心权重计算全部拉格朗日基函数在 x 处的值 $\Delta_{i, N}(x)$。
+// 当 x 恰好是某个节点时,对应基函数为 1,其余为 0。
+func lagrangeBasisAt(nodes, weights []fr.Element, x fr.Element) []fr.Element {
+	deltas := make([]fr.Element, len(nodes))
+	diffs := make([]fr.Element, len(nodes))
+	for i := range nodes {
+		diffs[i].Sub(&x, &nodes[i])
+		if diffs[i].IsZero() {
+			deltas[i].SetOne()
+			return deltas
+		}
 	}
+	// L(x) = \prod_{j \in N} (x - j)
+	var l fr.Element
+	l.SetOne()
+	for i := range diffs {
+		l.Mul(&l, &diffs[i])
+	}
+	invDiffs := fr.BatchInvert(diffs)
+	for i := range nodes {
+		deltas[i].Mul(&weights[i], &l)
+		deltas[i].Mul(&deltas[i], &invDiffs[i])
+	}
+	return deltas
+}
 
-	return *g2ExpXExpN
+// Zeroize 将主密钥 y 覆写为零；清除范围的限制见 README 的 Key Zeroization。
+func (instance *SW05FIBELargeUniverseInstance) Zeroize() {
+	instance.msk_y.SetZero()
 }
@@ -0,0 +1,68 @@
+package fibe
+
+import (
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/mmsyan/GoPairingBasedCryptography/utils"
+	"math/big"
+	"testing"
+)
+
+// computeTReference 按定义逐个计算基函数 $\Delta_{i, N}(x)$，作为 computeT 的参照实现
+func computeTReference(publicParams *SW05FIBELargeUniversePublicParams, x fr.Element) bn254.G2Affine {
+	xExpN := new(fr.Element).Exp(x, big.NewInt(publicParams.n))
+	result := new(bn254.G2Affine).ScalarMultiplicationBase(xExpN.BigInt(new(big.Int)))
+	N := make([]fr.Element, publicParams.n+1)
+	for i := range N {
+		N[i].SetInt64(int64(i + 1))
+	}
+	for i := int64(1); i <= publicParams.n+1; i++ {
+		delta := utils.ComputeLagrangeBasis(*new(fr.Element).SetInt64(i), N, x)
+		ti := publicParams.ti[i]
+		result.Add(result, new(bn254.G2Affine).ScalarMultiplication(&ti, delta.BigInt(new(big.Int))))
+	}
+	return *result
+}
+
+// TestComputeTMatchesReference 预计算重心权重得到的 T_x 与逐个计算基函数的结果完全一致
+func TestComputeTMatchesReference(t *testing.T) {
+	publicParams, err := NewSW05FIBELargeUniverseInstance(3).SetUp(10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	xs := []fr.Element{fr.NewElement(0), fr.NewElement(1), fr.NewElement(5), fr.NewElement(11), fr.NewElement(12)}
+	var random fr.Element
+	if _, err := random.SetRandom(); err != nil {
+		t.Fatal(err)
+	}
+	xs = append(xs, random)
+	for _, x := range xs {
+		got := publicParams.computeT(x)
+		want := computeTReference(publicParams, x)
+		if !got.Equal(&want) {
+			t.Fatalf("x = %s: 缓存计算的 T_x 与参照实现不一致", x.String())
+		}
+	}
+}
+
+func benchmarkComputeT(b *testing.B, computeT func(*SW05FIBELargeUniversePublicParams, fr.Element) bn254.G2Affine) {
+	publicParams, err := NewSW05FIBELargeUniverseInstance(10).SetUp(50)
+	if err != nil {
+		b.Fatal(err)
+	}
+	x := fr.NewElement(1000) // 不是插值节点，避免走 x ∈ N 的捷径
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		computeT(publicParams, x)
+	}
+}
+
+// BenchmarkComputeT n=50 时使用预计算重心权重的 computeT
+func BenchmarkComputeT(b *testing.B) {
+	benchmarkComputeT(b, (*SW05FIBELargeUniversePublicParams).computeT)
+}
+
+// BenchmarkComputeTReference n=50 时逐个计算基函数的参照实现
+func BenchmarkComputeTReference(b *testing.B) {
+	benchmarkComputeT(b, computeTReference)
+}
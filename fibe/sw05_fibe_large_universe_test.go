@@ -60,11 +60,12 @@ func TestFIBELargeUniverse2(t *testing.T) {
 	}
 	fmt.Println("原始消息:", message.Message)
 
-	userAttributes := NewFIBEAttributes([]int64{1, 2, 3, 4, 5, 6, 10000, 20000, 30000, 40000, 60000, 80000, 100000})
-	messageAttributes := NewFIBEAttributes([]int64{1, 2, 3, 4, 5, 6, 10000, 20000, 30000, 40000, 50000, 70000, 90000})
+	// 属性须位于 [1, n] 内：两组属性共有 10 个重叠，门限为 8
+	userAttributes := NewFIBEAttributes([]int64{1, 2, 3, 4, 5, 6, 10, 11, 12, 13, 15, 17, 19})
+	messageAttributes := NewFIBEAttributes([]int64{1, 2, 3, 4, 5, 6, 10, 11, 12, 13, 14, 16, 18})
 
 	fibeInstance := NewSW05FIBELargeUniverseInstance(8)
-	publicParams, err := fibeInstance.SetUp(2)
+	publicParams, err := fibeInstance.SetUp(20)
 	if err != nil {
 		t.Fatal("系统初始化失败:", err)
 	}
@@ -0,0 +1,17 @@
+package fibe
+
+import (
+	"github.com/mmsyan/GoPairingBasedCryptography/internal/msgtest"
+	"github.com/mmsyan/GoPairingBasedCryptography/serialization"
+	"testing"
+)
+
+// TestSW05FIBEMessageBytes 检查 SW05FIBEMessage 的 Bytes/SetBytes 往返
+func TestSW05FIBEMessageBytes(t *testing.T) {
+	msgtest.CheckGTRoundTrip(t, func() serialization.Message { return new(SW05FIBEMessage) })
+}
+
+// TestSW05FIBELargeUniverseMessageBytes 检查 SW05FIBELargeUniverseMessage 的 Bytes/SetBytes 往返
+func TestSW05FIBELargeUniverseMessageBytes(t *testing.T) {
+	msgtest.CheckGTRoundTrip(t, func() serialization.Message { return new(SW05FIBELargeUniverseMessage) })
+}
@@ -0,0 +1,63 @@
+//go:build metrics
+
+package fibe
+
+import (
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/mmsyan/GoPairingBasedCryptography/metrics"
+	"testing"
+)
+
+// TestFIBEDecryptPairingCount 门限为 d 时，Decrypt 经由 pairing.Accumulator 计算 d 个配对 e(D_i^Δ, E_i)；
+// 大属性空间方案每个属性还有一个 e(g1^{-sΔ}, D_i)，共 2d 个。d = 20 超过累加器的批大小，覆盖分批 Miller 循环。
+// 运行方式: go test -tags metrics ./fibe/
+func TestFIBEDecryptPairingCount(t *testing.T) {
+	const d = 20
+	attributes := make([]int64, d)
+	for i := range attributes {
+		attributes[i] = int64(i + 1)
+	}
+	m, _ := new(bn254.GT).SetRandom()
+
+	instance := NewSW05FIBEInstanceByInt64Pair(1, d+1, d)
+	publicParams, err := instance.SetUp()
+	if err != nil {
+		t.Fatal(err)
+	}
+	secretKey, err := instance.KeyGenerate(NewFIBEAttributes(attributes), publicParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ciphertext, err := instance.Encrypt(NewFIBEAttributes(attributes), &SW05FIBEMessage{Message: *m}, publicParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	metrics.ResetPairingCount()
+	if _, err := instance.Decrypt(secretKey, ciphertext, publicParams); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := metrics.PairingCount(), int64(d); got != want {
+		t.Fatalf("Decrypt performed %d pairings, want %d", got, want)
+	}
+
+	largeInstance := NewSW05FIBELargeUniverseInstance(d)
+	largeParams, err := largeInstance.SetUp(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	largeKey, err := largeInstance.KeyGenerate(NewFIBEAttributes(attributes), largeParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	largeCiphertext, err := largeInstance.Encrypt(NewFIBEAttributes(attributes), &SW05FIBELargeUniverseMessage{Message: *m}, largeParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	metrics.ResetPairingCount()
+	if _, err := largeInstance.Decrypt(largeKey, largeCiphertext, largeParams); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := metrics.PairingCount(), int64(2*d); got != want {
+		t.Fatalf("large-universe Decrypt performed %d pairings, want %d", got, want)
+	}
+}
@@ -0,0 +1,83 @@
+package fibe
+
+import (
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/mmsyan/GoPairingBasedCryptography/internal/selftest"
+)
+
+// SelfTest 分别对 SW05 FIBE 的普通版本和大域版本执行一次最小的
+// SetUp → KeyGenerate → Encrypt → Decrypt 往返（用户属性与密文属性的交集恰好达到门限），
+// 解密结果与随机消息不一致时返回错误。可在服务启动时调用，尽早发现构建或运行环境的问题。
+//
+// 返回值:
+//   - error: 任一步骤失败或解密结果与原始消息不一致时返回错误
+func SelfTest() error {
+	if err := selftest.Run("sw05 fibe", selfTestRoundTrip); err != nil {
+		return err
+	}
+	return selftest.Run("sw05 fibe large universe", selfTestLargeUniverseRoundTrip)
+}
+
+// selfTestRoundTrip 生成普通版本的实例与公共参数，再由 keyRoundTrip 完成往返的其余部分
+func selfTestRoundTrip() (*selftest.RoundTrip[bn254.GT, *SW05FIBECiphertext], error) {
+	instance := NewSW05FIBEInstanceByInt64Pair(1, 6, 2)
+	publicParams, err := instance.SetUp()
+	if err != nil {
+		return nil, err
+	}
+	return keyRoundTrip(instance, publicParams)
+}
+
+// keyRoundTrip 用 instance 为属性 {1, 2, 3} 生成私钥，并返回绑定实例、公共参数与该私钥的加密与解密闭包
+func keyRoundTrip(instance *SW05FIBEInstance, publicParams *SW05FIBEPublicParams) (*selftest.RoundTrip[bn254.GT, *SW05FIBECiphertext], error) {
+	secretKey, err := instance.KeyGenerate(NewFIBEAttributes([]int64{1, 2, 3}), publicParams)
+	if err != nil {
+		return nil, err
+	}
+	return &selftest.RoundTrip[bn254.GT, *SW05FIBECiphertext]{
+		NewMessage: selftest.RandomGT,
+		Encrypt: func(m bn254.GT) (*SW05FIBECiphertext, error) {
+			return instance.Encrypt(NewFIBEAttributes([]int64{2, 3, 4}), &SW05FIBEMessage{Message: m}, publicParams)
+		},
+		Decrypt: func(ciphertext *SW05FIBECiphertext) (bn254.GT, error) {
+			decrypted, err := instance.Decrypt(secretKey, ciphertext, publicParams)
+			if err != nil {
+				return bn254.GT{}, err
+			}
+			return decrypted.Message, nil
+		},
+		Equal: selftest.EqualGT,
+	}, nil
+}
+
+// selfTestLargeUniverseRoundTrip 与 selfTestRoundTrip 相同，但针对大域版本
+func selfTestLargeUniverseRoundTrip() (*selftest.RoundTrip[bn254.GT, *SW05FIBELargeUniverseCiphertext], error) {
+	instance := NewSW05FIBELargeUniverseInstance(2)
+	publicParams, err := instance.SetUp(5)
+	if err != nil {
+		return nil, err
+	}
+	return keyRoundTripLargeUniverse(instance, publicParams)
+}
+
+// keyRoundTripLargeUniverse 与 keyRoundTrip 相同，但针对大域版本
+func keyRoundTripLargeUniverse(instance *SW05FIBELargeUniverseInstance, publicParams *SW05FIBELargeUniversePublicParams) (*selftest.RoundTrip[bn254.GT, *SW05FIBELargeUniverseCiphertext], error) {
+	secretKey, err := instance.KeyGenerate(NewFIBEAttributes([]int64{1, 2, 3}), publicParams)
+	if err != nil {
+		return nil, err
+	}
+	return &selftest.RoundTrip[bn254.GT, *SW05FIBELargeUniverseCiphertext]{
+		NewMessage: selftest.RandomGT,
+		Encrypt: func(m bn254.GT) (*SW05FIBELargeUniverseCiphertext, error) {
+			return instance.Encrypt(NewFIBEAttributes([]int64{2, 3, 4}), &SW05FIBELargeUniverseMessage{Message: m}, publicParams)
+		},
+		Decrypt: func(ciphertext *SW05FIBELargeUniverseCiphertext) (bn254.GT, error) {
+			decrypted, err := instance.Decrypt(secretKey, ciphertext, publicParams)
+			if err != nil {
+				return bn254.GT{}, err
+			}
+			return decrypted.Message, nil
+		},
+		Equal: selftest.EqualGT,
+	}, nil
+}
@@ -0,0 +1,293 @@
+package fibe
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/mmsyan/GoPairingBasedCryptography/serialization"
+	"sort"
+)
+
+// sortedAttributeKeys 返回以属性为键的映射的全部键，按规范小端序字节升序排列。
+// Go 的映射遍历顺序是随机的，凡是需要遍历 D_i / E_i 等映射并产生输出（序列化、哈希）的地方
+// 都应该按该顺序遍历，保证逻辑内容相同的映射得到相同的输出。
+//
+// 参数:
+//   - m: 以属性为键的映射
+//
+// 返回值:
+//   - []fr.Element: 排好序的属性
+func sortedAttributeKeys[V any](m map[fr.Element]V) []fr.Element {
+	keys := make([]fr.Element, 0, len(m))
+	encoded := make(map[fr.Element][fr.Bytes]byte, len(m))
+	for k := range m {
+		var b [fr.Bytes]byte
+		fr.LittleEndian.PutElement(&b, k)
+		encoded[k] = b
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		bi, bj := encoded[keys[i]], encoded[keys[j]]
+		return bytes.Compare(bi[:], bj[:]) < 0
+	})
+	return keys
+}
+
+// appendAttribute 追加属性的 32 字节大端序规范编码
+func appendAttribute(data []byte, attribute fr.Element) []byte {
+	b := attribute.Bytes()
+	return append(data, b[:]...)
+}
+
+// decodeAttribute 读取 32 字节大端序规范编码的属性
+func decodeAttribute(data []byte) (fr.Element, int, error) {
+	var attribute fr.Element
+	if len(data) < fr.Bytes {
+		return attribute, 0, errors.New("not enough bytes to decode attribute")
+	}
+	if err := attribute.SetBytesCanonical(data[:fr.Bytes]); err != nil {
+		return attribute, 0, err
+	}
+	return attribute, fr.Bytes, nil
+}
+
+// decodeAttributeCount 读取 4 字节大端序的属性个数，并检查剩余数据是否足够容纳这么多条目
+func decodeAttributeCount(data []byte, minEntrySize int) (int, int, error) {
+	if len(data) < 4 {
+		return 0, 0, errors.New("not enough bytes to decode attribute count")
+	}
+	count := binary.BigEndian.Uint32(data)
+	if uint64(count)*uint64(minEntrySize) > uint64(len(data)-4) {
+		return 0, 0, fmt.Errorf("attribute count %d exceeds available data", count)
+	}
+	return int(count), 4, nil
+}
+
+// MarshalBinary 将密文序列化为规范字节串（压缩点编码）。
+// 布局: 头部 || e' || 属性个数(4字节大端序) || 按 sortedAttributeKeys 顺序的 (属性 || E_i)。
+// 逻辑内容相同的密文得到相同的字节串，与属性的插入顺序无关。
+func (ciphertext *SW05FIBECiphertext) MarshalBinary() ([]byte, error) {
+	keys := sortedAttributeKeys(ciphertext.ei)
+	data := serialization.AppendHeader(nil, serialization.SchemeSW05FIBE)
+	data = append(data, serialization.MarshalGT(ciphertext.ePrime)...)
+	data = binary.BigEndian.AppendUint32(data, uint32(len(keys)))
+	for _, i := range keys {
+		data = appendAttribute(data, i)
+		data = append(data, serialization.EncodeG2(ciphertext.ei[i], serialization.Compressed)...)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary 从 MarshalBinary 的输出恢复密文，密文属性集按序列化顺序排列。
+func (ciphertext *SW05FIBECiphertext) UnmarshalBinary(data []byte) error {
+	data, err := serialization.CheckHeader(data, serialization.SchemeSW05FIBE)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal cipher text: %w", err)
+	}
+	ePrime, n, err := serialization.DecodeGT(data)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal cipher text: %w", err)
+	}
+	data = data[n:]
+	count, n, err := decodeAttributeCount(data, fr.Bytes+bn254.SizeOfG2AffineCompressed)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal cipher text: %w", err)
+	}
+	data = data[n:]
+	attributes := make([]fr.Element, 0, count)
+	ei := make(map[fr.Element]bn254.G2Affine, count)
+	for k := 0; k < count; k++ {
+		i, n, err := decodeAttribute(data)
+		if err != nil {
+			return fmt.Errorf("failed to unmarshal cipher text: %w", err)
+		}
+		data = data[n:]
+		e, n, err := serialization.DecodeValidG2(data)
+		if err != nil {
+			return fmt.Errorf("failed to unmarshal cipher text: %w", err)
+		}
+		data = data[n:]
+		if _, ok := ei[i]; ok {
+			return errors.New("failed to unmarshal cipher text: duplicate attribute")
+		}
+		attributes = append(attributes, i)
+		ei[i] = e
+	}
+	if len(data) != 0 {
+		return errors.New("failed to unmarshal cipher text: trailing bytes")
+	}
+	ciphertext.messageAttributes, ciphertext.ePrime, ciphertext.ei = attributes, ePrime, ei
+	return nil
+}
+
+// MarshalBinary 将用户私钥序列化为规范字节串（压缩点编码）。
+// 布局: 头部 || 属性个数(4字节大端序) || 按 sortedAttributeKeys 顺序的 (属性 || D_i)。
+func (secretKey *SW05FIBESecretKey) MarshalBinary() ([]byte, error) {
+	keys := sortedAttributeKeys(secretKey.di)
+	data := serialization.AppendHeader(nil, serialization.SchemeSW05FIBE)
+	data = binary.BigEndian.AppendUint32(data, uint32(len(keys)))
+	for _, i := range keys {
+		data = appendAttribute(data, i)
+		data = append(data, serialization.EncodeG1(secretKey.di[i], serialization.Compressed)...)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary 从 MarshalBinary 的输出恢复用户私钥，用户属性集按序列化顺序排列。
+func (secretKey *SW05FIBESecretKey) UnmarshalBinary(data []byte) error {
+	data, err := serialization.CheckHeader(data, serialization.SchemeSW05FIBE)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal secret key: %w", err)
+	}
+	count, n, err := decodeAttributeCount(data, fr.Bytes+bn254.SizeOfG1AffineCompressed)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal secret key: %w", err)
+	}
+	data = data[n:]
+	attributes := make([]fr.Element, 0, count)
+	di := make(map[fr.Element]bn254.G1Affine, count)
+	for k := 0; k < count; k++ {
+		i, n, err := decodeAttribute(data)
+		if err != nil {
+			return fmt.Errorf("failed to unmarshal secret key: %w", err)
+		}
+		data = data[n:]
+		d, n, err := serialization.DecodeG1(data)
+		if err != nil {
+			return fmt.Errorf("failed to unmarshal secret key: %w", err)
+		}
+		data = data[n:]
+		if _, ok := di[i]; ok {
+			return errors.New("failed to unmarshal secret key: duplicate attribute")
+		}
+		attributes = append(attributes, i)
+		di[i] = d
+	}
+	if len(data) != 0 {
+		return errors.New("failed to unmarshal secret key: trailing bytes")
+	}
+	secretKey.userAttributes, secretKey.di = attributes, di
+	return nil
+}
+
+// MarshalBinary 将大域方案的密文序列化为规范字节串（压缩点编码）。
+// 布局: 头部 || e' || E” || 属性个数(4字节大端序) || 按 sortedAttributeKeys 顺序的 (属性 || E_i)。
+func (ciphertext *SW05FIBELargeUniverseCiphertext) MarshalBinary() ([]byte, error) {
+	keys := sortedAttributeKeys(ciphertext.ei)
+	data := serialization.AppendHeader(nil, serialization.SchemeSW05FIBELargeUniverse)
+	data = append(data, serialization.MarshalGT(ciphertext.ePrime)...)
+	data = append(data, serialization.EncodeG1(ciphertext.ePrimePrime, serialization.Compressed)...)
+	data = binary.BigEndian.AppendUint32(data, uint32(len(keys)))
+	for _, i := range keys {
+		data = appendAttribute(data, i)
+		data = append(data, serialization.EncodeG2(ciphertext.ei[i], serialization.Compressed)...)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary 从 MarshalBinary 的输出恢复大域方案的密文，密文属性集按序列化顺序排列。
+func (ciphertext *SW05FIBELargeUniverseCiphertext) UnmarshalBinary(data []byte) error {
+	data, err := serialization.CheckHeader(data, serialization.SchemeSW05FIBELargeUniverse)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal cipher text: %w", err)
+	}
+	ePrime, n, err := serialization.DecodeGT(data)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal cipher text: %w", err)
+	}
+	data = data[n:]
+	ePrimePrime, n, err := serialization.DecodeG1(data)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal cipher text: %w", err)
+	}
+	data = data[n:]
+	count, n, err := decodeAttributeCount(data, fr.Bytes+bn254.SizeOfG2AffineCompressed)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal cipher text: %w", err)
+	}
+	data = data[n:]
+	attributes := make([]fr.Element, 0, count)
+	ei := make(map[fr.Element]bn254.G2Affine, count)
+	for k := 0; k < count; k++ {
+		i, n, err := decodeAttribute(data)
+		if err != nil {
+			return fmt.Errorf("failed to unmarshal cipher text: %w", err)
+		}
+		data = data[n:]
+		e, n, err := serialization.DecodeValidG2(data)
+		if err != nil {
+			return fmt.Errorf("failed to unmarshal cipher text: %w", err)
+		}
+		data = data[n:]
+		if _, ok := ei[i]; ok {
+			return errors.New("failed to unmarshal cipher text: duplicate attribute")
+		}
+		attributes = append(attributes, i)
+		ei[i] = e
+	}
+	if len(data) != 0 {
+		return errors.New("failed to unmarshal cipher text: trailing bytes")
+	}
+	ciphertext.messageAttributes, ciphertext.ePrime, ciphertext.ePrimePrime, ciphertext.ei = attributes, ePrime, ePrimePrime, ei
+	return nil
+}
+
+// MarshalBinary 将大域方案的用户私钥序列化为规范字节串（压缩点编码）。
+// 布局: 头部 || 属性个数(4字节大端序) || 按 sortedAttributeKeys 顺序的 (属性 || d_i || D_i)。
+func (secretKey *SW05FIBELargeUniverseSecretKey) MarshalBinary() ([]byte, error) {
+	keys := sortedAttributeKeys(secretKey._di)
+	data := serialization.AppendHeader(nil, serialization.SchemeSW05FIBELargeUniverse)
+	data = binary.BigEndian.AppendUint32(data, uint32(len(keys)))
+	for _, i := range keys {
+		data = appendAttribute(data, i)
+		data = append(data, serialization.EncodeG1(secretKey._di[i], serialization.Compressed)...)
+		data = append(data, serialization.EncodeG2(secretKey._Di[i], serialization.Compressed)...)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary 从 MarshalBinary 的输出恢复大域方案的用户私钥，用户属性集按序列化顺序排列。
+func (secretKey *SW05FIBELargeUniverseSecretKey) UnmarshalBinary(data []byte) error {
+	data, err := serialization.CheckHeader(data, serialization.SchemeSW05FIBELargeUniverse)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal secret key: %w", err)
+	}
+	count, n, err := decodeAttributeCount(data, fr.Bytes+bn254.SizeOfG1AffineCompressed+bn254.SizeOfG2AffineCompressed)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal secret key: %w", err)
+	}
+	data = data[n:]
+	attributes := make([]fr.Element, 0, count)
+	di := make(map[fr.Element]bn254.G1Affine, count)
+	Di := make(map[fr.Element]bn254.G2Affine, count)
+	for k := 0; k < count; k++ {
+		i, n, err := decodeAttribute(data)
+		if err != nil {
+			return fmt.Errorf("failed to unmarshal secret key: %w", err)
+		}
+		data = data[n:]
+		d, n, err := serialization.DecodeG1(data)
+		if err != nil {
+			return fmt.Errorf("failed to unmarshal secret key: %w", err)
+		}
+		data = data[n:]
+		D, n, err := serialization.DecodeValidG2(data)
+		if err != nil {
+			return fmt.Errorf("failed to unmarshal secret key: %w", err)
+		}
+		data = data[n:]
+		if _, ok := di[i]; ok {
+			return errors.New("failed to unmarshal secret key: duplicate attribute")
+		}
+		attributes = append(attributes, i)
+		di[i], Di[i] = d, D
+	}
+	if len(data) != 0 {
+		return errors.New("failed to unmarshal secret key: trailing bytes")
+	}
+	secretKey.userAttributes, secretKey._di, secretKey._Di = attributes, di, Di
+	return nil
+}
@@ -0,0 +1,150 @@
+package fibe
+
+import (
+	"bytes"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/mmsyan/GoPairingBasedCryptography/internal/invalidpoints"
+	"testing"
+)
+
+// TestFIBESerializationDeterministic 以不同插入顺序构造内容相同的映射，序列化结果应逐字节一致
+func TestFIBESerializationDeterministic(t *testing.T) {
+	fibeInstance := NewSW05FIBEInstanceByInt64Pair(1, 20, 3)
+	publicParams, err := fibeInstance.SetUp()
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := new(bn254.GT).SetRandom()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ciphertext, err := fibeInstance.Encrypt(NewFIBEAttributes([]int64{1, 3, 5, 7, 9, 11, 13}), &SW05FIBEMessage{Message: *m}, publicParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	forward := &SW05FIBECiphertext{messageAttributes: ciphertext.messageAttributes, ePrime: ciphertext.ePrime, ei: map[fr.Element]bn254.G2Affine{}}
+	backward := &SW05FIBECiphertext{messageAttributes: ciphertext.messageAttributes, ePrime: ciphertext.ePrime, ei: map[fr.Element]bn254.G2Affine{}}
+	attributes := ciphertext.messageAttributes
+	for k := range attributes {
+		forward.ei[attributes[k]] = ciphertext.ei[attributes[k]]
+		backward.ei[attributes[len(attributes)-1-k]] = ciphertext.ei[attributes[len(attributes)-1-k]]
+	}
+	a, err := forward.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for round := 0; round < 10; round++ {
+		b, err := backward.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(a, b) {
+			t.Fatal("相同内容的密文序列化结果不一致")
+		}
+	}
+
+	// 反序列化后的密文应能正常解密，且再次序列化结果不变
+	var restored SW05FIBECiphertext
+	if err := restored.UnmarshalBinary(a); err != nil {
+		t.Fatal(err)
+	}
+	again, _ := restored.MarshalBinary()
+	if !bytes.Equal(a, again) {
+		t.Fatal("序列化往返结果不一致")
+	}
+	secretKey, err := fibeInstance.KeyGenerate(NewFIBEAttributes([]int64{9, 5, 1, 2}), publicParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyBytes, _ := secretKey.MarshalBinary()
+	var restoredKey SW05FIBESecretKey
+	if err := restoredKey.UnmarshalBinary(keyBytes); err != nil {
+		t.Fatal(err)
+	}
+	decrypted, err := fibeInstance.Decrypt(&restoredKey, &restored, publicParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decrypted.Message != *m {
+		t.Fatal("解密消息与原始消息不匹配")
+	}
+
+	if err := restored.UnmarshalBinary(a[:len(a)-1]); err == nil {
+		t.Fatal("截断的密文应当反序列化失败")
+	}
+}
+
+// TestFIBELargeUniverseSerializationRoundTrip 大域方案的密文与私钥序列化往返后仍能解密
+func TestFIBELargeUniverseSerializationRoundTrip(t *testing.T) {
+	fibeInstance := NewSW05FIBELargeUniverseInstance(2)
+	publicParams, err := fibeInstance.SetUp(10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := new(bn254.GT).SetRandom()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ciphertext, err := fibeInstance.Encrypt(NewFIBEAttributes([]int64{1, 2, 3}), &SW05FIBELargeUniverseMessage{Message: *m}, publicParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	secretKey, err := fibeInstance.KeyGenerate(NewFIBEAttributes([]int64{3, 2, 9}), publicParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctBytes, _ := ciphertext.MarshalBinary()
+	keyBytes, _ := secretKey.MarshalBinary()
+	var restored SW05FIBELargeUniverseCiphertext
+	if err := restored.UnmarshalBinary(ctBytes); err != nil {
+		t.Fatal(err)
+	}
+	var restoredKey SW05FIBELargeUniverseSecretKey
+	if err := restoredKey.UnmarshalBinary(keyBytes); err != nil {
+		t.Fatal(err)
+	}
+	decrypted, err := fibeInstance.Decrypt(&restoredKey, &restored, publicParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decrypted.Message != *m {
+		t.Fatal("解密消息与原始消息不匹配")
+	}
+}
+
+// TestFIBECiphertextRejectInvalidG2 把序列化结果中的某个 E_i 替换为无穷远点、不在曲线上或不在子群中的编码后，UnmarshalBinary 必须拒绝
+func TestFIBECiphertextRejectInvalidG2(t *testing.T) {
+	instance := NewSW05FIBEInstanceByInt64Pair(1, 10, 2)
+	publicParams, err := instance.SetUp()
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := new(bn254.GT).SetRandom()
+	if err != nil {
+		t.Fatal(err)
+	}
+	attributes := NewFIBEAttributes([]int64{1, 2, 3})
+	ciphertext, err := instance.Encrypt(attributes, &SW05FIBEMessage{Message: *m}, publicParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := ciphertext.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := ciphertext.ei[attributes.attributes[1]]
+	point := e.Bytes()
+	offset := bytes.Index(data, point[:])
+	if offset < 0 {
+		t.Fatal("序列化结果中找不到目标 G2 点")
+	}
+	for name, invalid := range invalidpoints.G2Encodings() {
+		tampered := bytes.Clone(data)
+		copy(tampered[offset:], invalid)
+		if err := new(SW05FIBECiphertext).UnmarshalBinary(tampered); err == nil {
+			t.Fatalf("%s: 不合法的 G2 点应被拒绝", name)
+		}
+	}
+}
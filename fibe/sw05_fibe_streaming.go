@@ -0,0 +1,162 @@
+package fibe
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/mmsyan/GoPairingBasedCryptography/pairing"
+	"math/big"
+)
+
+// DecryptStreaming 与 Decrypt 的结果相同，但内存占用与属性交集的大小无关。
+//
+// Decrypt 先求出完整的交集 S_user ∩ S_msg，再截取前 d 个属性逐个计算拉格朗日系数，
+// 每个系数都重新分配 O(d) 个临时域元素。DecryptStreaming 逐个扫描密文属性，只把前 d 个公共属性
+// 写入长度为 d 的缓冲区；d_i、E_i 在用到时才从私钥与密文中取出，计算完配对输入后立即丢弃。
+// 拉格朗日系数用一次批量求逆得到，标量乘法复用同一组临时变量。除配对累加器外，额外内存只有
+// 与门限 d 成正比的几个缓冲区（重构秘密本身就需要这 d 个属性值）。
+//
+// 属性选取与 Decrypt 一样是常数时间的：扫描总是完整地比较全部属性，计时只泄露是否满足门限。
+//
+// 参数:
+//   - userSecretKey: 用户的私钥。
+//   - ciphertext: 要解密的密文。
+//   - publicParams: 系统公共参数。
+//
+// 返回值:
+//   - *SW05FIBEMessage: 解密后的明文消息指针。
+//   - error: 如果属性集无效或交集数量不足 d，返回错误信息。
+func (instance *SW05FIBEInstance) DecryptStreaming(userSecretKey *SW05FIBESecretKey, ciphertext *SW05FIBECiphertext, publicParams *SW05FIBEPublicParams) (*SW05FIBEMessage, error) {
+	if err := instance.checkAttributes(userSecretKey.userAttributes); err != nil {
+		return nil, fmt.Errorf("invalid user attributes: %w", err)
+	}
+	if err := instance.checkAttributes(ciphertext.messageAttributes); err != nil {
+		return nil, fmt.Errorf("invalid cipher text: %w", err)
+	}
+
+	s, ok := selectCommonAttributes(userSecretKey.userAttributes, ciphertext.messageAttributes, instance.distance)
+	if !ok {
+		return nil, fmt.Errorf("failed to find enough common attributes: %w", ErrPolicyNotSatisfied)
+	}
+	deltas := lagrangeBasisAtZero(s)
+
+	// 分母 ∏_{i ∈ S} e(D_i^(Δ_{0, S}(i)), E_i) = Y^s
+	acc := pairing.NewAccumulator()
+	var deltaBig big.Int
+	var diDelta bn254.G1Affine
+	for k := range s {
+		di := userSecretKey.di[s[k]]
+		deltas[k].BigInt(&deltaBig)
+		diDelta.ScalarMultiplication(&di, &deltaBig)
+		acc.AddPair(diDelta, ciphertext.ei[s[k]])
+	}
+	denominator, err := acc.Finalize()
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt MessageBytes: %w", err)
+	}
+
+	var m bn254.GT
+	m.Div(&ciphertext.ePrime, &denominator)
+	return &SW05FIBEMessage{Message: m}, nil
+}
+
+// DecryptStreaming 与 Decrypt 的结果相同，但内存占用与属性交集的大小无关，
+// 适用于属性很多、私钥很大的场合。实现方式见 SW05FIBEInstance.DecryptStreaming。
+//
+// 参数:
+//   - userSecretKey: 用户的私钥。
+//   - ciphertext: 要解密的密文。
+//   - publicParams: 系统公共参数。
+//
+// 返回值:
+//   - *SW05FIBELargeUniverseMessage: 解密后的明文消息 M。
+//   - error: 如果交集属性不足 d 个或配对计算失败，返回错误信息。
+func (instance *SW05FIBELargeUniverseInstance) DecryptStreaming(userSecretKey *SW05FIBELargeUniverseSecretKey, ciphertext *SW05FIBELargeUniverseCiphertext, publicParams *SW05FIBELargeUniversePublicParams) (*SW05FIBELargeUniverseMessage, error) {
+	s, ok := selectCommonAttributes(userSecretKey.userAttributes, ciphertext.messageAttributes, instance.distance)
+	if !ok {
+		return nil, fmt.Errorf("failed to find enough common attributes: %w", ErrPolicyNotSatisfied)
+	}
+	deltas := lagrangeBasisAtZero(s)
+
+	// ∏_{i ∈ S} (e(d_i, E_i) / e(E'', D_i))^(Δ_{0, S}(i)) = Y^{-s}
+	acc := pairing.NewAccumulator()
+	var deltaBig big.Int
+	var diDelta, ePrimePrimeNegDelta bn254.G1Affine
+	for k := range s {
+		deltas[k].BigInt(&deltaBig)
+		di := userSecretKey._di[s[k]]
+		diDelta.ScalarMultiplication(&di, &deltaBig)
+		ePrimePrimeNegDelta.ScalarMultiplication(&ciphertext.ePrimePrime, &deltaBig)
+		ePrimePrimeNegDelta.Neg(&ePrimePrimeNegDelta)
+		acc.AddPair(diDelta, ciphertext.ei[s[k]])
+		acc.AddPair(ePrimePrimeNegDelta, userSecretKey._Di[s[k]])
+	}
+	denominator, err := acc.Finalize()
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt MessageBytes: %w", err)
+	}
+
+	var m bn254.GT
+	m.Mul(&ciphertext.ePrime, &denominator)
+	return &SW05FIBELargeUniverseMessage{Message: m}, nil
+}
+
+// selectCommonAttributes 以常数时间选出 messageAttributes 中前 d 个（去重后）同时出现在 userAttributes 中的属性，
+// 并返回公共属性是否达到 d 个。选出的属性与 utils.FindCommonAttributesConstantTime 结果的前 d 个相同，
+// 但不保存完整的交集，去重也只需与已选出的属性比较。
+func selectCommonAttributes(userAttributes, messageAttributes []fr.Element, d int) ([]fr.Element, bool) {
+	// 多出的最后一个位置接收不需要保留的写入，使每个属性都执行一次写入且不依赖分支
+	selected := make([]fr.Element, d+1)
+	count := 0
+	for j := range messageAttributes {
+		matched := 0
+		for i := range userAttributes {
+			matched |= constantTimeElementEqual(&userAttributes[i], &messageAttributes[j])
+		}
+		duplicate := 0
+		for k := 0; k < d; k++ {
+			duplicate |= constantTimeElementEqual(&selected[k], &messageAttributes[j]) & subtle.ConstantTimeLessOrEq(k+1, count)
+		}
+		full := subtle.ConstantTimeLessOrEq(d, count)
+		selected[subtle.ConstantTimeSelect(full, d, count)] = messageAttributes[j]
+		count += matched &^ duplicate &^ full
+	}
+	return selected[:d], count == d
+}
+
+// constantTimeElementEqual 在 a == b 时返回 1，否则返回 0，运行时间与取值无关
+func constantTimeElementEqual(a, b *fr.Element) int {
+	var diff uint64
+	for i := range a {
+		diff |= a[i] ^ b[i]
+	}
+	// diff | -diff 的最高位在 diff != 0 时为 1
+	return int(((diff | -diff) >> 63) ^ 1)
+}
+
+// lagrangeBasisAtZero 返回 Δ_{0, S}(i) = ∏_{j ∈ S, j ≠ i} (0 - j) / (i - j)，与 s 一一对应。
+// 分子与分母分别累乘，分母统一用一次批量求逆，共 O(d^2) 次乘法、一次求逆。
+func lagrangeBasisAtZero(s []fr.Element) []fr.Element {
+	numerators := make([]fr.Element, len(s))
+	denominators := make([]fr.Element, len(s))
+	for i := range s {
+		numerators[i].SetOne()
+		denominators[i].SetOne()
+		for j := range s {
+			if j == i {
+				continue
+			}
+			var negJ, diff fr.Element
+			negJ.Neg(&s[j])
+			numerators[i].Mul(&numerators[i], &negJ)
+			diff.Sub(&s[i], &s[j])
+			denominators[i].Mul(&denominators[i], &diff)
+		}
+	}
+	deltas := fr.BatchInvert(denominators)
+	for i := range deltas {
+		deltas[i].Mul(&deltas[i], &numerators[i])
+	}
+	return deltas
+}
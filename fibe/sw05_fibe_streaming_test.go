@@ -0,0 +1,183 @@
+package fibe
+
+import (
+	"errors"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/mmsyan/GoPairingBasedCryptography/utils"
+	"testing"
+)
+
+func int64Range(start, end int64) []int64 {
+	values := make([]int64, 0, end-start)
+	for v := start; v < end; v++ {
+		values = append(values, v)
+	}
+	return values
+}
+
+// TestDecryptStreamingMatchesDecrypt 小宇宙方案中 DecryptStreaming 与 Decrypt 得到相同明文，
+// 属性 0 参与插值时同样正确，交集不足时两者都返回 ErrPolicyNotSatisfied
+func TestDecryptStreamingMatchesDecrypt(t *testing.T) {
+	instance := NewSW05FIBEInstanceByInt64Slice(int64Range(0, 30), 5)
+	publicParams, err := instance.SetUp()
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := new(bn254.GT).SetRandom()
+	if err != nil {
+		t.Fatal(err)
+	}
+	secretKey, err := instance.KeyGenerate(NewFIBEAttributes([]int64{0, 3, 1, 4, 1, 5, 9, 2, 6}), publicParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ciphertext, err := instance.Encrypt(NewFIBEAttributes([]int64{9, 0, 2, 20, 6, 5, 21}), &SW05FIBEMessage{Message: *m}, publicParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decrypted, err := instance.Decrypt(secretKey, ciphertext, publicParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	streamed, err := instance.DecryptStreaming(secretKey, ciphertext, publicParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !streamed.Message.Equal(m) || !streamed.Message.Equal(&decrypted.Message) {
+		t.Fatal("DecryptStreaming 的结果应与 Decrypt 相同且等于明文")
+	}
+
+	farKey, err := instance.KeyGenerate(NewFIBEAttributes([]int64{0, 2, 6, 9, 10, 11}), publicParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := instance.DecryptStreaming(farKey, ciphertext, publicParams); !errors.Is(err, ErrPolicyNotSatisfied) {
+		t.Fatalf("期望 ErrPolicyNotSatisfied，得到 %v", err)
+	}
+}
+
+// TestDecryptStreamingLargeUniverse 大宇宙方案中 DecryptStreaming 与 Decrypt 得到相同明文
+func TestDecryptStreamingLargeUniverse(t *testing.T) {
+	instance := NewSW05FIBELargeUniverseInstance(4)
+	publicParams, err := instance.SetUp(10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := new(bn254.GT).SetRandom()
+	if err != nil {
+		t.Fatal(err)
+	}
+	secretKey, err := instance.KeyGenerate(NewFIBEAttributes([]int64{1, 2, 3, 4, 5, 6}), publicParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ciphertext, err := instance.Encrypt(NewFIBEAttributes([]int64{6, 5, 7, 3, 2, 8}), &SW05FIBELargeUniverseMessage{Message: *m}, publicParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decrypted, err := instance.Decrypt(secretKey, ciphertext, publicParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	streamed, err := instance.DecryptStreaming(secretKey, ciphertext, publicParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !streamed.Message.Equal(m) || !streamed.Message.Equal(&decrypted.Message) {
+		t.Fatal("DecryptStreaming 的结果应与 Decrypt 相同且等于明文")
+	}
+
+	farKey, err := instance.KeyGenerate(NewFIBEAttributes([]int64{1, 2, 3, 9}), publicParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := instance.DecryptStreaming(farKey, ciphertext, publicParams); !errors.Is(err, ErrPolicyNotSatisfied) {
+		t.Fatalf("期望 ErrPolicyNotSatisfied，得到 %v", err)
+	}
+}
+
+// TestSelectCommonAttributes 选出的属性与常数时间求交结果的前 d 个相同，重复属性只计一次
+func TestSelectCommonAttributes(t *testing.T) {
+	user := NewFIBEAttributes([]int64{1, 2, 3, 4, 5, 6, 7, 8}).attributes
+	message := NewFIBEAttributes([]int64{9, 3, 3, 1, 10, 8, 8, 2, 6}).attributes
+	common, _ := utils.FindCommonAttributesConstantTime(user, message, 0)
+	for d := 1; d <= len(common)+1; d++ {
+		selected, ok := selectCommonAttributes(user, message, d)
+		if ok != (d <= len(common)) {
+			t.Fatalf("d=%d: 是否满足门限的结果为 %v", d, ok)
+		}
+		if !ok {
+			continue
+		}
+		for k := range selected {
+			if !selected[k].Equal(&common[k]) {
+				t.Fatalf("d=%d: 第 %d 个属性不同", d, k)
+			}
+		}
+	}
+}
+
+// TestDecryptStreamingAllocations 60 个公共属性、门限 60 时 DecryptStreaming 的内存分配次数少于 Decrypt
+func TestDecryptStreamingAllocations(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping allocation comparison in short mode")
+	}
+	instance, secretKey, ciphertext, publicParams := streamingFixture(t, 60)
+	decryptAllocs := testing.AllocsPerRun(2, func() {
+		if _, err := instance.Decrypt(secretKey, ciphertext, publicParams); err != nil {
+			t.Fatal(err)
+		}
+	})
+	streamingAllocs := testing.AllocsPerRun(2, func() {
+		if _, err := instance.DecryptStreaming(secretKey, ciphertext, publicParams); err != nil {
+			t.Fatal(err)
+		}
+	})
+	t.Logf("Decrypt: %.0f allocs, DecryptStreaming: %.0f allocs", decryptAllocs, streamingAllocs)
+	if streamingAllocs >= decryptAllocs {
+		t.Fatalf("DecryptStreaming 分配了 %.0f 次，不少于 Decrypt 的 %.0f 次", streamingAllocs, decryptAllocs)
+	}
+}
+
+// streamingFixture 构造用户属性与密文属性恰有 n 个公共属性、门限为 n 的小宇宙实例
+func streamingFixture(tb testing.TB, n int64) (*SW05FIBEInstance, *SW05FIBESecretKey, *SW05FIBECiphertext, *SW05FIBEPublicParams) {
+	instance := NewSW05FIBEInstanceByInt64Pair(1, 2*n+1, int(n))
+	publicParams, err := instance.SetUp()
+	if err != nil {
+		tb.Fatal(err)
+	}
+	secretKey, err := instance.KeyGenerate(NewFIBEAttributes(int64Range(1, n+1)), publicParams)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	m, err := new(bn254.GT).SetRandom()
+	if err != nil {
+		tb.Fatal(err)
+	}
+	ciphertext, err := instance.Encrypt(NewFIBEAttributes(int64Range(1, 2*n+1)), &SW05FIBEMessage{Message: *m}, publicParams)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return instance, secretKey, ciphertext, publicParams
+}
+
+// BenchmarkDecryptStreaming 对比 200 个公共属性、门限 200 时两种解密方式的耗时与内存分配
+func BenchmarkDecryptStreaming(b *testing.B) {
+	instance, secretKey, ciphertext, publicParams := streamingFixture(b, 200)
+	b.Run("Decrypt", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := instance.Decrypt(secretKey, ciphertext, publicParams); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("DecryptStreaming", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := instance.DecryptStreaming(secretKey, ciphertext, publicParams); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
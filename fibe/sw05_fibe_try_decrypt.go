@@ -0,0 +1,48 @@
+package fibe
+
+import "errors"
+
+// TryDecrypt 与 Decrypt 相同，但把"交集不足门限 d"作为正常结果而不是错误返回，
+// 调用方只需检查布尔值，而不必比较明文或用 errors.Is 区分 ErrPolicyNotSatisfied。
+//
+// 参数:
+//   - userSecretKey: 用户的私钥。
+//   - ciphertext: 要解密的密文。
+//   - publicParams: 系统公共参数。
+//
+// 返回值:
+//   - *SW05FIBEMessage: 满足门限时为解密后的明文，否则为 nil。
+//   - bool: |S_user ∩ S_msg| >= d 时为 true。
+//   - error: 属性集无效或配对计算失败时返回错误，此时布尔值为 false；交集不足不视为错误。
+func (instance *SW05FIBEInstance) TryDecrypt(userSecretKey *SW05FIBESecretKey, ciphertext *SW05FIBECiphertext, publicParams *SW05FIBEPublicParams) (*SW05FIBEMessage, bool, error) {
+	message, err := instance.Decrypt(userSecretKey, ciphertext, publicParams)
+	return tryResult(message, err)
+}
+
+// TryDecrypt 与 Decrypt 相同，但把"交集不足门限 d"作为正常结果而不是错误返回。
+//
+// 参数:
+//   - userSecretKey: 用户的私钥。
+//   - ciphertext: 要解密的密文。
+//   - publicParams: 系统公共参数。
+//
+// 返回值:
+//   - *SW05FIBELargeUniverseMessage: 满足门限时为解密后的明文，否则为 nil。
+//   - bool: |S_user ∩ S_msg| >= d 时为 true。
+//   - error: 配对计算失败时返回错误，此时布尔值为 false；交集不足不视为错误。
+func (instance *SW05FIBELargeUniverseInstance) TryDecrypt(userSecretKey *SW05FIBELargeUniverseSecretKey, ciphertext *SW05FIBELargeUniverseCiphertext, publicParams *SW05FIBELargeUniversePublicParams) (*SW05FIBELargeUniverseMessage, bool, error) {
+	message, err := instance.Decrypt(userSecretKey, ciphertext, publicParams)
+	return tryResult(message, err)
+}
+
+// tryResult 把 Decrypt 的结果转换为 TryDecrypt 的约定：ErrPolicyNotSatisfied 映射为 (nil, false, nil)
+func tryResult[M any](message *M, err error) (*M, bool, error) {
+	switch {
+	case err == nil:
+		return message, true, nil
+	case errors.Is(err, ErrPolicyNotSatisfied):
+		return nil, false, nil
+	default:
+		return nil, false, err
+	}
+}
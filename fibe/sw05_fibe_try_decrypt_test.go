@@ -0,0 +1,57 @@
+package fibe
+
+import (
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"testing"
+)
+
+// TestFIBELargeUniverseTryDecrypt - 大属性空间：重叠数达到门限时返回 true，不足时返回 (nil, false, nil)
+func TestFIBELargeUniverseTryDecrypt(t *testing.T) {
+	m, err := new(bn254.GT).SetRandom()
+	if err != nil {
+		t.Fatal(err)
+	}
+	message := &SW05FIBELargeUniverseMessage{Message: *m}
+
+	// 重叠 4 个属性
+	userAttributes := NewFIBEAttributes([]int64{1, 2, 3, 4, 5, 6})
+	messageAttributes := NewFIBEAttributes([]int64{1, 2, 3, 4, 7, 8})
+
+	testCases := []struct {
+		d           int
+		shouldMatch bool
+	}{
+		{3, true},
+		{4, true},
+		{5, false},
+	}
+	for _, tc := range testCases {
+		fibeInstance := NewSW05FIBELargeUniverseInstance(tc.d)
+		publicParams, err := fibeInstance.SetUp(10)
+		if err != nil {
+			t.Fatal("系统初始化失败:", err)
+		}
+		secretKey, err := fibeInstance.KeyGenerate(userAttributes, publicParams)
+		if err != nil {
+			t.Fatal("密钥生成失败:", err)
+		}
+		ciphertext, err := fibeInstance.Encrypt(messageAttributes, message, publicParams)
+		if err != nil {
+			t.Fatal("加密失败:", err)
+		}
+
+		decryptedMessage, ok, err := fibeInstance.TryDecrypt(secretKey, ciphertext, publicParams)
+		if err != nil {
+			t.Fatalf("d=%d: 不应返回错误: %v", tc.d, err)
+		}
+		if ok != tc.shouldMatch {
+			t.Fatalf("d=%d: ok = %v, 期望 %v", tc.d, ok, tc.shouldMatch)
+		}
+		if !ok && decryptedMessage != nil {
+			t.Fatalf("d=%d: 不满足门限时应返回 nil 明文", tc.d)
+		}
+		if ok && decryptedMessage.Message != message.Message {
+			t.Fatalf("d=%d: 解密消息与原始消息不匹配", tc.d)
+		}
+	}
+}
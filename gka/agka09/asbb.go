@@ -13,6 +13,8 @@
 // Algorithms:
 // publicParameters := ParaGen()
 // (pk, sk) <- KeyGen(π)
+// (pk, sk, pop) <- KeyGenWithPoP(π)
+// 0/1 := VerifyPoP(pk, pop)
 // σ <- Sign(str, sk)
 // 0/1 := Verify(str, σ, pk)
 // ciphertext <- Encrypt(plaintext, pk)
@@ -35,6 +37,7 @@ import (
 	"github.com/consensys/gnark-crypto/ecc/bn254"
 	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
 	"github.com/mmsyan/GoPairingBasedCryptography/hash"
+	"github.com/mmsyan/GoPairingBasedCryptography/metrics"
 	"math/big"
 )
 
@@ -83,13 +86,13 @@ func KeyGen(pp *PublicParameters) (*PublicKey, *PrivateKey, error) {
 	// r <- Zp*
 	r, err := new(fr.Element).SetRandom()
 	if err != nil {
-		return nil, nil, fmt.Errorf("unable to generate random key: %v", err)
+		return nil, nil, fmt.Errorf("unable to generate random key: %w", err)
 	}
 
 	// X <- G1
 	xElement, err := new(fr.Element).SetRandom()
 	if err != nil {
-		return nil, nil, fmt.Errorf("unable to generate random key: %v", err)
+		return nil, nil, fmt.Errorf("unable to generate random key: %w", err)
 	}
 	x := *new(bn254.G1Affine).ScalarMultiplicationBase(xElement.BigInt(new(big.Int)))
 
@@ -98,7 +101,7 @@ func KeyGen(pp *PublicParameters) (*PublicKey, *PrivateKey, error) {
 	g2ExpNegR := new(bn254.G2Affine).ScalarMultiplicationBase(negR.BigInt(new(big.Int)))
 
 	// A = e(X, g2)
-	pairXG2, err := bn254.Pair([]bn254.G1Affine{x}, []bn254.G2Affine{pp.G2})
+	pairXG2, err := metrics.Pair([]bn254.G1Affine{x}, []bn254.G2Affine{pp.G2})
 	if err != nil {
 		return nil, nil, err
 	}
@@ -124,15 +127,15 @@ func Verify(s *SignMessage, sigma *Signature, pk *PublicKey) (bool, error) {
 	_, _, _, g2 := bn254.Generators()
 
 	// e(σ, g2)
-	pairSigmaG2, err := bn254.Pair([]bn254.G1Affine{sigma.Sigma}, []bn254.G2Affine{g2})
+	pairSigmaG2, err := metrics.Pair([]bn254.G1Affine{sigma.Sigma}, []bn254.G2Affine{g2})
 	if err != nil {
-		return false, fmt.Errorf("unable to verify signature: %v", err)
+		return false, fmt.Errorf("unable to verify signature: %w", err)
 	}
 
 	// e(H(s), R)
-	pairHsR, err := bn254.Pair([]bn254.G1Affine{hash.BytesToG1(s.S)}, []bn254.G2Affine{pk.R})
+	pairHsR, err := metrics.Pair([]bn254.G1Affine{hash.BytesToG1(s.S)}, []bn254.G2Affine{pk.R})
 	if err != nil {
-		return false, fmt.Errorf("unable to verify signature: %v", err)
+		return false, fmt.Errorf("unable to verify signature: %w", err)
 	}
 
 	// e(σ, g2) * e(H(s), R) ==?== A
@@ -149,7 +152,7 @@ func Encrypt(plaintext *PlainText, pk *PublicKey) (*CipherText, error) {
 	// t <- Zp*
 	t, err := new(fr.Element).SetRandom()
 	if err != nil {
-		return nil, fmt.Errorf("unable to generate random ciphertext: %v", err)
+		return nil, fmt.Errorf("unable to generate random ciphertext: %w", err)
 	}
 
 	// c1 = g2^t
@@ -172,15 +175,15 @@ func Encrypt(plaintext *PlainText, pk *PublicKey) (*CipherText, error) {
 
 func Decrypt(c CipherText, s *SignMessage, sigma *Signature) (*PlainText, error) {
 	// e(σ, c1)
-	pairSigmaC1, err := bn254.Pair([]bn254.G1Affine{sigma.Sigma}, []bn254.G2Affine{c.C1})
+	pairSigmaC1, err := metrics.Pair([]bn254.G1Affine{sigma.Sigma}, []bn254.G2Affine{c.C1})
 	if err != nil {
-		return nil, fmt.Errorf("unable to decrypt ciphertext: %v", err)
+		return nil, fmt.Errorf("unable to decrypt ciphertext: %w", err)
 	}
 
 	// e(H(s), c2)
-	pairHsC2, err := bn254.Pair([]bn254.G1Affine{hash.BytesToG1(s.S)}, []bn254.G2Affine{c.C2})
+	pairHsC2, err := metrics.Pair([]bn254.G1Affine{hash.BytesToG1(s.S)}, []bn254.G2Affine{c.C2})
 	if err != nil {
-		return nil, fmt.Errorf("unable to decrypt ciphertext: %v", err)
+		return nil, fmt.Errorf("unable to decrypt ciphertext: %w", err)
 	}
 
 	// c3 / [e(σ, c1)*e(H(s), c2)]
@@ -218,3 +221,76 @@ func AggregateSignatures(sigmas []*Signature) (*Signature, error) {
 		Sigma: aggregateSigma,
 	}, nil
 }
+
+// AggregateVerifyDistinct 验证一组成员分别对不同消息签名后聚合得到的签名。
+// 成员 i 使用私钥 sk_i 对消息 s_i 签名得到 σ_i = X_i·H(s_i)^{r_i}，聚合签名 σ = ∏σ_i。
+// 验证等式: e(σ, g2) · ∏ e(H(s_i), R_i) == ∏ A_i，
+// 每条消息对应一次配对，所有配对在一次多重配对中计算。
+//
+// 消息两两不同并不能抵御流氓公钥攻击 (rogue-key attack)：公钥中的 A 是不受约束的 GT 元素，
+// 攻击者拿到诚实成员的 (R_1, A_1) 后，任取 σ、R* 并令 A* = e(σ, g2)·e(H(s_1), R_1)·e(H(s*), R*)/A_1，
+// 即可在诚实成员从未签名的情况下伪造包含该成员的聚合签名，而 s_1 与 s* 不同。
+// 因此每个公钥都必须附带持有证明，先由 VerifyPoP 逐个检查，全部有效后才验证聚合签名。
+// 消息两两不同是该接口的约定，对同一消息签名的成员应使用 AggregatePublicKeysWithPoP 与 Verify。
+//
+// 参数:
+//   - msgs: 各成员签名的消息，msgs[i] 与 pks[i] 对应
+//   - aggSig: 聚合签名
+//   - pks: 各成员的公钥
+//   - pops: 各成员公钥的持有证明，pops[i] 与 pks[i] 对应
+//
+// 返回值:
+//   - bool: 聚合签名是否有效
+//   - error: 参数不合法、任一持有证明无效（包装 ErrInvalidPoP 并指明下标）或验证失败时返回错误
+func AggregateVerifyDistinct(msgs []*SignMessage, aggSig *Signature, pks []*PublicKey, pops []*PoP) (bool, error) {
+	if len(pks) != len(pops) {
+		return false, fmt.Errorf("number of public keys (%d) does not match number of proofs of possession (%d)", len(pks), len(pops))
+	}
+	for i := range pks {
+		if !VerifyPoP(pks[i], pops[i]) {
+			return false, fmt.Errorf("public key %d: %w", i, ErrInvalidPoP)
+		}
+	}
+	return aggregateVerifyDistinct(msgs, aggSig, pks)
+}
+
+// aggregateVerifyDistinct 是 AggregateVerifyDistinct 不检查持有证明的部分，调用方须保证公钥已通过 VerifyPoP
+func aggregateVerifyDistinct(msgs []*SignMessage, aggSig *Signature, pks []*PublicKey) (bool, error) {
+	if len(msgs) == 0 {
+		return false, fmt.Errorf("no messages provided")
+	}
+	if len(msgs) != len(pks) {
+		return false, fmt.Errorf("number of messages (%d) does not match number of public keys (%d)", len(msgs), len(pks))
+	}
+	seen := make(map[string]struct{}, len(msgs))
+	for _, m := range msgs {
+		if _, ok := seen[string(m.S)]; ok {
+			return false, fmt.Errorf("messages must be distinct")
+		}
+		seen[string(m.S)] = struct{}{}
+	}
+
+	_, _, _, g2 := bn254.Generators()
+	g1Points := make([]bn254.G1Affine, 0, len(msgs)+1)
+	g2Points := make([]bn254.G2Affine, 0, len(msgs)+1)
+	g1Points = append(g1Points, aggSig.Sigma)
+	g2Points = append(g2Points, g2)
+	aggregateA := *new(bn254.GT).SetOne()
+	for i := range msgs {
+		g1Points = append(g1Points, hash.BytesToG1(msgs[i].S))
+		g2Points = append(g2Points, pks[i].R)
+		aggregateA.Mul(&aggregateA, &pks[i].A)
+	}
+
+	// e(σ, g2) * ∏ e(H(s_i), R_i)
+	pairLeft, err := metrics.Pair(g1Points, g2Points)
+	if err != nil {
+		return false, fmt.Errorf("unable to verify signature: %w", err)
+	}
+
+	// e(σ, g2) * ∏ e(H(s_i), R_i) ==?== ∏ A_i
+	if pairLeft.Equal(&aggregateA) {
+		return true, nil
+	}
+	return false, fmt.Errorf("sigma is a not valid aggregate signature!")
+}
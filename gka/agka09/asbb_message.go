@@ -0,0 +1,26 @@
+package agka09
+
+import "github.com/mmsyan/GoPairingBasedCryptography/serialization"
+
+var _ serialization.Message = (*SignMessage)(nil)
+
+// Bytes 返回消息字节的副本
+//
+// 返回值:
+//   - []byte: 消息字节
+//   - error: 始终为 nil
+func (m *SignMessage) Bytes() ([]byte, error) {
+	return append([]byte(nil), m.S...), nil
+}
+
+// SetBytes 把消息设置为 data 的副本
+//
+// 参数:
+//   - data: 消息字节
+//
+// 返回值:
+//   - error: 始终为 nil
+func (m *SignMessage) SetBytes(data []byte) error {
+	m.S = append([]byte(nil), data...)
+	return nil
+}
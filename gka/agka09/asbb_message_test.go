@@ -0,0 +1,11 @@
+package agka09
+
+import (
+	"github.com/mmsyan/GoPairingBasedCryptography/internal/msgtest"
+	"testing"
+)
+
+// TestSignMessageBytes 检查 SignMessage 的 Bytes/SetBytes 往返
+func TestSignMessageBytes(t *testing.T) {
+	msgtest.CheckRoundTrip(t, new(SignMessage), []byte{}, []byte("attack at dawn"))
+}
@@ -0,0 +1,104 @@
+package agka09
+
+import (
+	"errors"
+	"fmt"
+)
+
+// popDomain 是持有证明所签消息的域分隔前缀，保证持有证明不会与普通消息 str 上的签名混用
+const popDomain = "agka09-proof-of-possession:"
+
+// ErrInvalidPoP 表示公钥的持有证明无效
+var ErrInvalidPoP = errors.New("invalid proof of possession")
+
+// PoP 是成员对自己公钥的持有证明（proof of possession）：
+// 用私钥对 popDomain || pk.Bytes() 的签名 σ = X·H(popDomain || pk)^r。
+//
+// AggregatePublicKeys 直接把公钥相乘，恶意成员可以在看到诚实成员公钥 (R_h, A_h) 之后提交
+// 流氓公钥 R* = g2^{-r*}·R_h^{-1}, A* = e(X*, g2)·A_h^{-1}，使聚合公钥退化为只由自己控制的 (g2^{-r*}, e(X*, g2))，
+// 从而独自伪造聚合签名、解密发给整个群组的密文。
+// 生成 PoP 需要公钥对应的私钥，而流氓公钥的私钥未知，因此要求每个公钥附带有效的 PoP 即可抵御该攻击。
+type PoP struct {
+	Signature
+}
+
+// popMessage 返回公钥持有证明所签的消息
+func popMessage(pk *PublicKey) *SignMessage {
+	return &SignMessage{S: append([]byte(popDomain), pk.Bytes()...)}
+}
+
+// KeyGenWithPoP 生成成员密钥对，并同时生成公钥的持有证明。
+//
+// 参数:
+//   - pp: 公共参数
+//
+// 返回值:
+//   - *PublicKey: 成员公钥
+//   - *PrivateKey: 成员私钥
+//   - *PoP: 公钥的持有证明，提交公钥时一并提交
+//   - error: 随机数生成或配对计算失败时返回错误
+func KeyGenWithPoP(pp *PublicParameters) (*PublicKey, *PrivateKey, *PoP, error) {
+	pk, sk, err := KeyGen(pp)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	pop, err := ProvePossession(pk, sk)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return pk, sk, pop, nil
+}
+
+// ProvePossession 用私钥为对应的公钥生成持有证明。
+//
+// 参数:
+//   - pk: 成员公钥
+//   - sk: 与 pk 对应的成员私钥
+//
+// 返回值:
+//   - *PoP: 公钥的持有证明
+//   - error: 签名失败时返回错误
+func ProvePossession(pk *PublicKey, sk *PrivateKey) (*PoP, error) {
+	sigma, err := Sign(popMessage(pk), sk)
+	if err != nil {
+		return nil, fmt.Errorf("unable to prove possession: %w", err)
+	}
+	return &PoP{Signature: *sigma}, nil
+}
+
+// VerifyPoP 验证公钥的持有证明：e(σ, g2) · e(H(popDomain || pk), R) == A。
+//
+// 参数:
+//   - pk: 成员公钥
+//   - pop: 该公钥的持有证明
+//
+// 返回值:
+//   - bool: 持有证明有效时返回 true
+func VerifyPoP(pk *PublicKey, pop *PoP) bool {
+	if pk == nil || pop == nil {
+		return false
+	}
+	ok, err := Verify(popMessage(pk), &pop.Signature, pk)
+	return ok && err == nil
+}
+
+// AggregatePublicKeysWithPoP 先逐个验证持有证明，全部有效时才聚合公钥。
+//
+// 参数:
+//   - pks: 各成员的公钥
+//   - pops: 各成员公钥的持有证明，pops[i] 与 pks[i] 对应
+//
+// 返回值:
+//   - *PublicKey: 聚合公钥
+//   - error: 数量不一致，或任一持有证明无效（包装 ErrInvalidPoP 并指明下标）时返回错误
+func AggregatePublicKeysWithPoP(pks []*PublicKey, pops []*PoP) (*PublicKey, error) {
+	if len(pks) != len(pops) {
+		return nil, fmt.Errorf("number of public keys (%d) does not match number of proofs of possession (%d)", len(pks), len(pops))
+	}
+	for i := range pks {
+		if !VerifyPoP(pks[i], pops[i]) {
+			return nil, fmt.Errorf("public key %d: %w", i, ErrInvalidPoP)
+		}
+	}
+	return AggregatePublicKeys(pks)
+}
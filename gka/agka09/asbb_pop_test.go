@@ -0,0 +1,146 @@
+package agka09
+
+import (
+	"errors"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/mmsyan/GoPairingBasedCryptography/hash"
+	"math/big"
+	"testing"
+)
+
+// TestAggregatePublicKeysWithPoP 诚实成员的持有证明通过验证，聚合公钥可正常验证聚合签名
+func TestAggregatePublicKeysWithPoP(t *testing.T) {
+	pp, _ := ParaGen()
+	pk1, sk1, pop1, err := KeyGenWithPoP(pp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pk2, sk2, pop2, err := KeyGenWithPoP(pp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !VerifyPoP(pk1, pop1) || !VerifyPoP(pk2, pop2) {
+		t.Fatal("诚实成员的持有证明应当有效")
+	}
+	if VerifyPoP(pk1, pop2) {
+		t.Fatal("持有证明不应对其他公钥有效")
+	}
+
+	aggPK, err := AggregatePublicKeysWithPoP([]*PublicKey{pk1, pk2}, []*PoP{pop1, pop2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := NewSignMessage([]byte("group session"))
+	sigma1, _ := Sign(s, sk1)
+	sigma2, _ := Sign(s, sk2)
+	aggSig, _ := AggregateSignatures([]*Signature{sigma1, sigma2})
+	if ok, err := Verify(s, aggSig, aggPK); !ok || err != nil {
+		t.Fatalf("聚合签名应当有效: %v", err)
+	}
+}
+
+// TestAggregatePublicKeysWithPoP_RogueKey 流氓公钥可以让不带持有证明的聚合退化为攻击者独占，
+// 但攻击者无法为流氓公钥给出有效的持有证明，AggregatePublicKeysWithPoP 拒绝聚合
+func TestAggregatePublicKeysWithPoP_RogueKey(t *testing.T) {
+	pp, _ := ParaGen()
+	honestPK, _, honestPoP, err := KeyGenWithPoP(pp)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 攻击者选取 (r*, X*)，构造 R* = g2^{-r*}·R_h^{-1}, A* = e(X*, g2)·A_h^{-1}
+	attackerPK, attackerSK, err := KeyGen(pp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var negHonestR bn254.G2Affine
+	negHonestR.Neg(&honestPK.R)
+	var invHonestA bn254.GT
+	invHonestA.Inverse(&honestPK.A)
+	roguePK := &PublicKey{
+		R: *new(bn254.G2Affine).Add(&attackerPK.R, &negHonestR),
+		A: *new(bn254.GT).Mul(&attackerPK.A, &invHonestA),
+	}
+
+	// 不检查持有证明时，攻击者独自签名即可通过聚合公钥的验证
+	naiveAgg, err := AggregatePublicKeys([]*PublicKey{honestPK, roguePK})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := NewSignMessage([]byte("forged group message"))
+	forged, _ := Sign(s, attackerSK)
+	if ok, _ := Verify(s, forged, naiveAgg); !ok {
+		t.Fatal("流氓公钥攻击应当在不检查持有证明时成功")
+	}
+
+	// 攻击者只能用自己已知的私钥为流氓公钥签名，持有证明无效
+	roguePoP, err := ProvePossession(roguePK, attackerSK)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if VerifyPoP(roguePK, roguePoP) {
+		t.Fatal("流氓公钥的持有证明不应通过验证")
+	}
+	// 随机的持有证明同样无效
+	var x fr.Element
+	x.SetRandom()
+	randomPoP := &PoP{Signature: Signature{Sigma: *new(bn254.G1Affine).ScalarMultiplicationBase(x.BigInt(new(big.Int)))}}
+
+	for _, pop := range []*PoP{roguePoP, randomPoP} {
+		aggPK, err := AggregatePublicKeysWithPoP([]*PublicKey{honestPK, roguePK}, []*PoP{honestPoP, pop})
+		if !errors.Is(err, ErrInvalidPoP) {
+			t.Fatalf("错误应包装 ErrInvalidPoP: %v", err)
+		}
+		if aggPK != nil {
+			t.Fatal("持有证明无效时不应返回聚合公钥")
+		}
+	}
+}
+
+// TestAggregateVerifyDistinct_RogueKey 消息两两不同不能阻止流氓公钥：攻击者令
+// A* = e(σ, g2)·e(H(s_1), R_1)·e(H(s*), R*)/A_1，便可伪造包含诚实成员的多消息聚合签名，
+// AggregateVerifyDistinct 因流氓公钥没有有效的持有证明而拒绝
+func TestAggregateVerifyDistinct_RogueKey(t *testing.T) {
+	pp, _ := ParaGen()
+	honestPK, _, honestPoP, err := KeyGenWithPoP(pp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	attackerPK, attackerSK, err := KeyGen(pp)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	honestMsg := NewSignMessage([]byte("honest member never signed this"))
+	rogueMsg := NewSignMessage([]byte("attacker message"))
+
+	// 任取 σ，R* 直接使用攻击者公钥中的 R
+	var x fr.Element
+	x.SetRandom()
+	forged := &Signature{Sigma: *new(bn254.G1Affine).ScalarMultiplicationBase(x.BigInt(new(big.Int)))}
+	_, _, _, g2 := bn254.Generators()
+	target, err := bn254.Pair(
+		[]bn254.G1Affine{forged.Sigma, hash.BytesToG1(honestMsg.S), hash.BytesToG1(rogueMsg.S)},
+		[]bn254.G2Affine{g2, honestPK.R, attackerPK.R},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	roguePK := &PublicKey{R: attackerPK.R, A: *new(bn254.GT).Div(&target, &honestPK.A)}
+
+	msgs := []*SignMessage{honestMsg, rogueMsg}
+	pks := []*PublicKey{honestPK, roguePK}
+	if ok, _ := aggregateVerifyDistinct(msgs, forged, pks); !ok {
+		t.Fatal("不检查持有证明时伪造的聚合签名应当通过验证")
+	}
+
+	roguePoP, err := ProvePossession(roguePK, attackerSK)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ok, err := AggregateVerifyDistinct(msgs, forged, pks, []*PoP{honestPoP, roguePoP})
+	if ok || !errors.Is(err, ErrInvalidPoP) {
+		t.Fatalf("流氓公钥应因持有证明无效被拒绝: ok=%v err=%v", ok, err)
+	}
+}
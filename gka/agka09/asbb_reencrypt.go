@@ -0,0 +1,142 @@
+package agka09
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/mmsyan/GoPairingBasedCryptography/hash"
+	"github.com/mmsyan/GoPairingBasedCryptography/metrics"
+	"math/big"
+)
+
+// reEncTokenContext 是生成重加密令牌时签名的固定上下文
+var reEncTokenContext = []byte("AGKA09 re-encryption token")
+
+// ReEncToken 表示把发往 A 的密文转换为 B 可解密密文的重加密令牌。
+// Sigma 是 A 对固定上下文的解密签名 σ_A 减去盲化因子 [z]1 的结果，
+// Key 是发往 B 的、封装了盲化种子 K 的密文，z = H(K)。
+//
+// 信任假设:
+//   - 代理单独持有令牌时不知道 K，无法从 Sigma 恢复 σ_A，也就无法解密原密文。
+//   - 但 B 能解密 Key 得到 K，代理若与 B 合谋即可计算 σ_A = Sigma·g1^z。
+//     σ_A 是 A 对固定上下文的有效解密签名，可以解密任何发往 A 的密文（不限于被重加密的那一个），
+//     因此合谋后双方获得 A 的全部解密能力，而不仅是被委托的密文。
+//   - 令牌只能交给不与 B 合谋的半可信代理，A 应只在愿意让 B 与代理共同获得这一能力时签发令牌。
+type ReEncToken struct {
+	Sigma bn254.G1Affine
+	Key   *CipherText
+}
+
+// ReEncCipherText 表示重加密后的密文。
+// C1 沿用原密文的 g2^t，C3 = m·e(g1, C1)^z，Key 为令牌中封装 K 的密文。
+// 由于代理无法计算 R_B^t，重加密密文不具备普通 CipherText 的结构，需使用 DecryptReEncrypted 解密。
+type ReEncCipherText struct {
+	C1  bn254.G2Affine
+	C3  bn254.GT
+	Key *CipherText
+}
+
+// GenerateReEncToken 由 A 生成把发往 A 的密文转换给 B 的重加密令牌。
+// 1. 随机选取 K ∈ GT，计算 z = H(K)，并把 K 加密给 B
+// 2. 对固定上下文 s* 签名得到 σ_A = X_A·H(s*)^{r_A}
+// 3. 令牌 Sigma = σ_A·g1^{-z}
+//
+// 令牌对代理与 B 的合谋不安全，信任假设见 ReEncToken。
+//
+// 参数:
+//   - fromSK: 原密文接收方 A 的私钥（群组场景下为可产生聚合解密签名的一方）
+//   - toPK: 新接收方 B 的公钥
+//
+// 返回值:
+//   - *ReEncToken: 重加密令牌
+//   - error: 随机数生成或加密失败时返回错误
+func GenerateReEncToken(fromSK *PrivateKey, toPK *PublicKey) (*ReEncToken, error) {
+	k, err := new(bn254.GT).SetRandom()
+	if err != nil {
+		return nil, fmt.Errorf("unable to generate re-encryption token: %w", err)
+	}
+	key, err := Encrypt(&PlainText{M: *k}, toPK)
+	if err != nil {
+		return nil, fmt.Errorf("unable to generate re-encryption token: %w", err)
+	}
+
+	sigma, err := Sign(&SignMessage{S: reEncTokenContext}, fromSK)
+	if err != nil {
+		return nil, fmt.Errorf("unable to generate re-encryption token: %w", err)
+	}
+
+	// σ_A·g1^{-z}
+	z := reEncBlindingScalar(k)
+	negZ := new(fr.Element).Neg(&z)
+	g1ExpNegZ := new(bn254.G1Affine).ScalarMultiplicationBase(negZ.BigInt(new(big.Int)))
+	blinded := new(bn254.G1Affine).Add(&sigma.Sigma, g1ExpNegZ)
+
+	return &ReEncToken{
+		Sigma: *blinded,
+		Key:   key,
+	}, nil
+}
+
+// ReEncrypt 由代理使用重加密令牌转换密文，转换过程中不会得到明文。
+// e(Sigma, c1)·e(H(s*), c2) = A^t·e(g1, c1)^{-z}，因此
+// C3' = c3 / [e(Sigma, c1)·e(H(s*), c2)] = m·e(g1, c1)^z，明文仍被只有 B 能计算的 e(g1, c1)^z 掩盖。
+//
+// 参数:
+//   - ct: 发往 A 的密文
+//   - token: A 生成的重加密令牌
+//
+// 返回值:
+//   - *ReEncCipherText: B 可解密的重加密密文
+//   - error: 配对计算失败时返回错误
+func ReEncrypt(ct *CipherText, token *ReEncToken) (*ReEncCipherText, error) {
+	// e(Sigma, c1)·e(H(s*), c2)
+	mask, err := metrics.Pair(
+		[]bn254.G1Affine{token.Sigma, hash.BytesToG1(reEncTokenContext)},
+		[]bn254.G2Affine{ct.C1, ct.C2},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to re-encrypt ciphertext: %w", err)
+	}
+	c3 := new(bn254.GT).Div(&ct.C3, &mask)
+
+	return &ReEncCipherText{
+		C1:  ct.C1,
+		C3:  *c3,
+		Key: token.Key,
+	}, nil
+}
+
+// DecryptReEncrypted 由 B 解密重加密密文。
+// 先用 B 的解密签名从 Key 中恢复 K 并计算 z = H(K)，再计算 m = C3 / e(g1^z, C1)。
+//
+// 参数:
+//   - c: 重加密密文
+//   - s: B 的签名消息
+//   - sigma: B 对 s 的签名（或群组的聚合签名）
+//
+// 返回值:
+//   - *PlainText: 解密后的明文
+//   - error: 配对计算失败时返回错误
+func DecryptReEncrypted(c *ReEncCipherText, s *SignMessage, sigma *Signature) (*PlainText, error) {
+	k, err := Decrypt(*c.Key, s, sigma)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decrypt re-encrypted ciphertext: %w", err)
+	}
+	z := reEncBlindingScalar(&k.M)
+	g1ExpZ := new(bn254.G1Affine).ScalarMultiplicationBase(z.BigInt(new(big.Int)))
+
+	// e(g1^z, c1)
+	pairZC1, err := metrics.Pair([]bn254.G1Affine{*g1ExpZ}, []bn254.G2Affine{c.C1})
+	if err != nil {
+		return nil, fmt.Errorf("unable to decrypt re-encrypted ciphertext: %w", err)
+	}
+	plainText := new(bn254.GT).Div(&c.C3, &pairZC1)
+	return &PlainText{M: *plainText}, nil
+}
+
+// reEncBlindingScalar 计算盲化因子 z = H(K)
+func reEncBlindingScalar(k *bn254.GT) fr.Element {
+	digest := sha256.Sum256(hash.FromGT(*k))
+	return hash.BytesToField(digest[:])
+}
@@ -0,0 +1,169 @@
+package agka09
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/mmsyan/GoPairingBasedCryptography/serialization"
+)
+
+// MarshalBinary 将公共参数序列化为规范字节串（压缩点编码）。
+// 布局: 头部 || G1 || G2。
+func (pp *PublicParameters) MarshalBinary() ([]byte, error) {
+	data := serialization.AppendHeader(nil, serialization.SchemeASBB)
+	data = append(data, serialization.EncodeG1(pp.G1, serialization.Compressed)...)
+	data = append(data, serialization.EncodeG2(pp.G2, serialization.Compressed)...)
+	return data, nil
+}
+
+// UnmarshalBinary 从 MarshalBinary 的输出恢复公共参数。
+func (pp *PublicParameters) UnmarshalBinary(data []byte) error {
+	data, err := serialization.CheckHeader(data, serialization.SchemeASBB)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal public parameters: %w", err)
+	}
+	g1, n, err := serialization.DecodeG1(data)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal public parameters: %w", err)
+	}
+	data = data[n:]
+	g2, n, err := serialization.DecodeValidG2(data)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal public parameters: %w", err)
+	}
+	if len(data) != n {
+		return errors.New("failed to unmarshal public parameters: trailing bytes")
+	}
+	pp.G1, pp.G2 = g1, g2
+	return nil
+}
+
+// Fingerprint 返回公共参数规范序列化的 SHA-256 摘要，
+// 群成员可以在协商前比较指纹以确认使用的是同一套参数。
+func (pp *PublicParameters) Fingerprint() [32]byte {
+	data, _ := pp.MarshalBinary()
+	return sha256.Sum256(data)
+}
+
+// Bytes 序列化成员公钥（压缩点编码）。
+// 布局: R (G2) || A (GT)，长度固定。
+// 聚合公钥与单个成员公钥使用完全相同的格式，线路上无法区分公钥是否经过聚合。
+func (pk *PublicKey) Bytes() []byte {
+	data := serialization.EncodeG2(pk.R, serialization.Compressed)
+	return append(data, serialization.MarshalGT(pk.A)...)
+}
+
+// SetBytes 从 Bytes 的输出恢复公钥。
+func (pk *PublicKey) SetBytes(data []byte) error {
+	r, n, err := serialization.DecodeValidG2(data)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal public key: %w", err)
+	}
+	data = data[n:]
+	a, n, err := serialization.DecodeGT(data)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal public key: %w", err)
+	}
+	if len(data) != n {
+		return errors.New("failed to unmarshal public key: trailing bytes")
+	}
+	pk.R, pk.A = r, a
+	return nil
+}
+
+// Bytes 序列化成员私钥。
+// 布局: r (Fr, 32 字节) || X (G1)。
+func (sk *PrivateKey) Bytes() []byte {
+	r := sk.R.Bytes()
+	return append(r[:], serialization.EncodeG1(sk.X, serialization.Compressed)...)
+}
+
+// SetBytes 从 Bytes 的输出恢复私钥，r 必须是规范编码（小于群阶）。
+func (sk *PrivateKey) SetBytes(data []byte) error {
+	if len(data) < fr.Bytes {
+		return errors.New("failed to unmarshal private key: not enough bytes")
+	}
+	var r fr.Element
+	if err := r.SetBytesCanonical(data[:fr.Bytes]); err != nil {
+		return fmt.Errorf("failed to unmarshal private key: %w", err)
+	}
+	data = data[fr.Bytes:]
+	x, n, err := serialization.DecodeG1(data)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal private key: %w", err)
+	}
+	if len(data) != n {
+		return errors.New("failed to unmarshal private key: trailing bytes")
+	}
+	sk.R, sk.X = r, x
+	return nil
+}
+
+// Bytes 序列化签名（压缩点编码）。
+// 聚合签名与单个签名格式相同。
+func (s *Signature) Bytes() []byte {
+	return serialization.EncodeG1(s.Sigma, serialization.Compressed)
+}
+
+// SetBytes 从 Bytes 的输出恢复签名。
+func (s *Signature) SetBytes(data []byte) error {
+	sigma, n, err := serialization.DecodeG1(data)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal signature: %w", err)
+	}
+	if len(data) != n {
+		return errors.New("failed to unmarshal signature: trailing bytes")
+	}
+	s.Sigma = sigma
+	return nil
+}
+
+// Bytes 序列化明文。
+func (p *PlainText) Bytes() []byte {
+	return serialization.MarshalGT(p.M)
+}
+
+// SetBytes 从 Bytes 的输出恢复明文。
+func (p *PlainText) SetBytes(data []byte) error {
+	m, n, err := serialization.DecodeGT(data)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal plaintext: %w", err)
+	}
+	if len(data) != n {
+		return errors.New("failed to unmarshal plaintext: trailing bytes")
+	}
+	p.M = m
+	return nil
+}
+
+// Bytes 序列化密文（压缩点编码）。
+// 布局: C1 (G2) || C2 (G2) || C3 (GT)。
+func (c *CipherText) Bytes() []byte {
+	data := serialization.EncodeG2(c.C1, serialization.Compressed)
+	data = append(data, serialization.EncodeG2(c.C2, serialization.Compressed)...)
+	return append(data, serialization.MarshalGT(c.C3)...)
+}
+
+// SetBytes 从 Bytes 的输出恢复密文。
+func (c *CipherText) SetBytes(data []byte) error {
+	c1, n, err := serialization.DecodeValidG2(data)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal ciphertext: %w", err)
+	}
+	data = data[n:]
+	c2, n, err := serialization.DecodeValidG2(data)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal ciphertext: %w", err)
+	}
+	data = data[n:]
+	c3, n, err := serialization.DecodeGT(data)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal ciphertext: %w", err)
+	}
+	if len(data) != n {
+		return errors.New("failed to unmarshal ciphertext: trailing bytes")
+	}
+	c.C1, c.C2, c.C3 = c1, c2, c3
+	return nil
+}
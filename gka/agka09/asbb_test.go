@@ -1,8 +1,11 @@
 package agka09
 
 import (
+	"bytes"
+	"fmt"
 	"github.com/consensys/gnark-crypto/ecc/bn254"
 	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/mmsyan/GoPairingBasedCryptography/internal/invalidpoints"
 	"math/big"
 	"testing"
 )
@@ -337,3 +340,274 @@ func TestAggregateVerify_Mismatch(t *testing.T) {
 		t.Fatal("Security flaw: Verified an aggregate signature from a different set of participants")
 	}
 }
+
+// TestAggregateVerifyDistinct 验证成员对不同消息签名后的聚合签名
+func TestAggregateVerifyDistinct(t *testing.T) {
+	pp, _ := ParaGen()
+	numParties := 4
+	pks := make([]*PublicKey, numParties)
+	pops := make([]*PoP, numParties)
+	msgs := make([]*SignMessage, numParties)
+	sigmas := make([]*Signature, numParties)
+
+	for i := 0; i < numParties; i++ {
+		pk, sk, pop, _ := KeyGenWithPoP(pp)
+		pks[i] = pk
+		pops[i] = pop
+		msgs[i] = NewSignMessage([]byte(fmt.Sprintf("member %d commitment", i)))
+		sigmas[i], _ = Sign(msgs[i], sk)
+	}
+
+	aggSigma, err := AggregateSignatures(sigmas)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	isValid, err := AggregateVerifyDistinct(msgs, aggSigma, pks, pops)
+	if err != nil {
+		t.Fatalf("Aggregate verification error: %v", err)
+	}
+	if !isValid {
+		t.Fatal("Aggregate signature over distinct messages failed to verify")
+	}
+
+	// 交换两个成员的消息，预期验证失败
+	swapped := []*SignMessage{msgs[1], msgs[0], msgs[2], msgs[3]}
+	isValid, _ = AggregateVerifyDistinct(swapped, aggSigma, pks, pops)
+	if isValid {
+		t.Fatal("Security flaw: Verified aggregate signature with swapped messages")
+	}
+}
+
+// TestAggregateVerifyDistinct_DuplicateMessages 重复消息必须被拒绝
+func TestAggregateVerifyDistinct_DuplicateMessages(t *testing.T) {
+	pp, _ := ParaGen()
+	pk1, sk1, pop1, _ := KeyGenWithPoP(pp)
+	pk2, sk2, pop2, _ := KeyGenWithPoP(pp)
+	pops := []*PoP{pop1, pop2}
+
+	msg := NewSignMessage([]byte("same message"))
+	sigma1, _ := Sign(msg, sk1)
+	sigma2, _ := Sign(msg, sk2)
+	aggSigma, _ := AggregateSignatures([]*Signature{sigma1, sigma2})
+
+	isValid, err := AggregateVerifyDistinct([]*SignMessage{msg, msg}, aggSigma, []*PublicKey{pk1, pk2}, pops)
+	if err == nil || isValid {
+		t.Fatal("duplicate messages should be rejected")
+	}
+
+	_, err = AggregateVerifyDistinct([]*SignMessage{msg}, aggSigma, []*PublicKey{pk1, pk2}, pops)
+	if err == nil {
+		t.Fatal("length mismatch should be rejected")
+	}
+}
+
+// TestPublicParametersFingerprint 序列化往返后指纹不变
+func TestPublicParametersFingerprint(t *testing.T) {
+	pp, _ := ParaGen()
+	data, err := pp.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	reloaded := new(PublicParameters)
+	if err := reloaded.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if reloaded.Fingerprint() != pp.Fingerprint() {
+		t.Fatal("reloaded public parameters should have the same fingerprint")
+	}
+
+	// 替换 G1 生成元后指纹必须改变
+	other := *pp
+	other.G1.Double(&pp.G1)
+	if other.Fingerprint() == pp.Fingerprint() {
+		t.Fatal("different public parameters should have different fingerprints")
+	}
+}
+
+// TestSerializationRoundTrip 聚合公钥、密文、聚合签名经序列化往返后仍可解密
+func TestSerializationRoundTrip(t *testing.T) {
+	pp, _ := ParaGen()
+	numParties := 3
+	pks := make([]*PublicKey, numParties)
+	sks := make([]*PrivateKey, numParties)
+	for i := 0; i < numParties; i++ {
+		pks[i], sks[i], _ = KeyGen(pp)
+	}
+
+	aggPK, _ := AggregatePublicKeys(pks)
+	if len(aggPK.Bytes()) != len(pks[0].Bytes()) {
+		t.Fatal("aggregate public key must serialize to the same length as a fresh one")
+	}
+	reloadedPK := new(PublicKey)
+	if err := reloadedPK.SetBytes(aggPK.Bytes()); err != nil {
+		t.Fatalf("failed to reload aggregate public key: %v", err)
+	}
+
+	m, _ := new(bn254.GT).SetRandom()
+	plaintext := &PlainText{M: *m}
+	cipher, err := Encrypt(plaintext, reloadedPK)
+	if err != nil {
+		t.Fatalf("Encryption failed: %v", err)
+	}
+	reloadedCipher := new(CipherText)
+	if err := reloadedCipher.SetBytes(cipher.Bytes()); err != nil {
+		t.Fatalf("failed to reload ciphertext: %v", err)
+	}
+
+	msg := NewSignMessage([]byte("Session Context 2024"))
+	sigmas := make([]*Signature, numParties)
+	for i := 0; i < numParties; i++ {
+		reloadedSK := new(PrivateKey)
+		if err := reloadedSK.SetBytes(sks[i].Bytes()); err != nil {
+			t.Fatalf("failed to reload private key: %v", err)
+		}
+		sigmas[i], _ = Sign(msg, reloadedSK)
+	}
+	aggSigma, _ := AggregateSignatures(sigmas)
+	if len(aggSigma.Bytes()) != len(sigmas[0].Bytes()) {
+		t.Fatal("aggregate signature must serialize to the same length as a fresh one")
+	}
+	reloadedSigma := new(Signature)
+	if err := reloadedSigma.SetBytes(aggSigma.Bytes()); err != nil {
+		t.Fatalf("failed to reload aggregate signature: %v", err)
+	}
+
+	decrypted, err := Decrypt(*reloadedCipher, msg, reloadedSigma)
+	if err != nil {
+		t.Fatalf("Aggregate decryption failed: %v", err)
+	}
+	reloadedPlaintext := new(PlainText)
+	if err := reloadedPlaintext.SetBytes(decrypted.Bytes()); err != nil {
+		t.Fatalf("failed to reload plaintext: %v", err)
+	}
+	if !reloadedPlaintext.M.Equal(&plaintext.M) {
+		t.Fatal("Decrypted plaintext does not match after serialization round trip")
+	}
+
+	// 截断或追加字节都应被拒绝
+	data := cipher.Bytes()
+	if err := new(CipherText).SetBytes(data[:len(data)-1]); err == nil {
+		t.Fatal("truncated ciphertext should be rejected")
+	}
+	if err := new(Signature).SetBytes(append(aggSigma.Bytes(), 0)); err == nil {
+		t.Fatal("signature with trailing bytes should be rejected")
+	}
+}
+
+// TestReEncrypt 验证发往 A 的密文经代理重加密后可由 B 解密，且代理只接触群元素
+func TestReEncrypt(t *testing.T) {
+	pp, _ := ParaGen()
+	pkA, skA, _ := KeyGen(pp)
+	pkB, skB, _ := KeyGen(pp)
+
+	// 1. 准备非单位元的明文并加密给 A
+	r, _ := new(fr.Element).SetRandom()
+	g1ExpR := new(bn254.G1Affine).ScalarMultiplicationBase(r.BigInt(new(big.Int)))
+	m, _ := bn254.Pair([]bn254.G1Affine{*g1ExpR}, []bn254.G2Affine{pp.G2})
+	originalPlaintext := &PlainText{M: m}
+	cipher, err := Encrypt(originalPlaintext, pkA)
+	if err != nil {
+		t.Fatalf("Encryption failed: %v", err)
+	}
+
+	// 2. A 生成重加密令牌，代理执行重加密
+	token, err := GenerateReEncToken(skA, pkB)
+	if err != nil {
+		t.Fatalf("GenerateReEncToken failed: %v", err)
+	}
+	reCipher, err := ReEncrypt(cipher, token)
+	if err != nil {
+		t.Fatalf("ReEncrypt failed: %v", err)
+	}
+	if reCipher.C3.Equal(&originalPlaintext.M) {
+		t.Fatal("proxy should not obtain the plaintext")
+	}
+
+	// 3. B 使用自己的解密签名解密
+	msg := NewSignMessage([]byte("Bob Session"))
+	sigmaB, _ := Sign(msg, skB)
+	decryptedPlaintext, err := DecryptReEncrypted(reCipher, msg, sigmaB)
+	if err != nil {
+		t.Fatalf("DecryptReEncrypted failed: %v", err)
+	}
+	if !decryptedPlaintext.M.Equal(&originalPlaintext.M) {
+		t.Fatal("Re-encrypted plaintext does not match the original")
+	}
+
+	// 4. A 的签名无法解密重加密密文
+	sigmaA, _ := Sign(msg, skA)
+	wrongPlaintext, err := DecryptReEncrypted(reCipher, msg, sigmaA)
+	if err != nil {
+		t.Fatalf("DecryptReEncrypted failed: %v", err)
+	}
+	if wrongPlaintext.M.Equal(&originalPlaintext.M) {
+		t.Fatal("A should not decrypt the re-encrypted ciphertext")
+	}
+}
+
+// TestPublicParametersRejectInvalidG2 把序列化结果中的 G2 生成元替换为无穷远点、不在曲线上或不在子群中的编码后，UnmarshalBinary 必须拒绝
+func TestPublicParametersRejectInvalidG2(t *testing.T) {
+	pp, _ := ParaGen()
+	data, err := pp.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	point := pp.G2.Bytes()
+	offset := bytes.Index(data, point[:])
+	if offset < 0 {
+		t.Fatal("序列化结果中找不到目标 G2 点")
+	}
+	for name, invalid := range invalidpoints.G2Encodings() {
+		tampered := bytes.Clone(data)
+		copy(tampered[offset:], invalid)
+		if err := new(PublicParameters).UnmarshalBinary(tampered); err == nil {
+			t.Fatalf("%s: 不合法的 G2 点应被拒绝", name)
+		}
+	}
+}
+
+// TestReEncTokenCollusion 说明 ReEncToken 的信任假设：代理与 B 合谋时可从令牌恢复 σ_A，
+// 并用它解密任何发往 A 的密文，而不只是被重加密的那一个
+func TestReEncTokenCollusion(t *testing.T) {
+	pp, _ := ParaGen()
+	pkA, skA, _ := KeyGen(pp)
+	pkB, skB, _ := KeyGen(pp)
+
+	token, err := GenerateReEncToken(skA, pkB)
+	if err != nil {
+		t.Fatalf("GenerateReEncToken failed: %v", err)
+	}
+
+	// 1. B 解密 Key 得到 K，计算 z = H(K)，代理提供 Sigma，合谋恢复 σ_A = Sigma·g1^z
+	msgB := NewSignMessage([]byte("Bob Session"))
+	sigmaB, _ := Sign(msgB, skB)
+	k, err := Decrypt(*token.Key, msgB, sigmaB)
+	if err != nil {
+		t.Fatalf("Decrypt failed: %v", err)
+	}
+	z := reEncBlindingScalar(&k.M)
+	g1ExpZ := new(bn254.G1Affine).ScalarMultiplicationBase(z.BigInt(new(big.Int)))
+	recovered := &Signature{Sigma: *new(bn254.G1Affine).Add(&token.Sigma, g1ExpZ)}
+
+	ctx := &SignMessage{S: reEncTokenContext}
+	if ok, err := Verify(ctx, recovered, pkA); err != nil || !ok {
+		t.Fatal("colluding proxy and B should recover a valid signature of A")
+	}
+
+	// 2. 恢复的 σ_A 可以解密 A 在签发令牌之后收到的、从未被重加密的密文
+	r, _ := new(fr.Element).SetRandom()
+	g1ExpR := new(bn254.G1Affine).ScalarMultiplicationBase(r.BigInt(new(big.Int)))
+	m, _ := bn254.Pair([]bn254.G1Affine{*g1ExpR}, []bn254.G2Affine{pp.G2})
+	cipher, err := Encrypt(&PlainText{M: m}, pkA)
+	if err != nil {
+		t.Fatalf("Encryption failed: %v", err)
+	}
+	decrypted, err := Decrypt(*cipher, ctx, recovered)
+	if err != nil {
+		t.Fatalf("Decrypt failed: %v", err)
+	}
+	if !decrypted.M.Equal(&m) {
+		t.Fatal("recovered σ_A should decrypt other ciphertexts sent to A")
+	}
+}
@@ -0,0 +1,50 @@
+package agka09
+
+import (
+	"fmt"
+	"github.com/mmsyan/GoPairingBasedCryptography/serialization"
+)
+
+// Validate 检查来自不可信来源的成员公钥或聚合公钥：R 必须是 G2 素数阶子群中的非无穷远点，
+// A 必须是 GT 的 r 阶子群中的非单位元。A 为单位元时 Encrypt 得到的 c3 就是明文本身。
+//
+// 返回值:
+//   - error: 分量不合法时返回指明分量、并包装 serialization.ErrInvalidG2Point 或 serialization.ErrInvalidGTElement 的错误
+func (pk *PublicKey) Validate() error {
+	if err := serialization.ValidateG2(&pk.R); err != nil {
+		return fmt.Errorf("invalid public key: R: %w", err)
+	}
+	if err := serialization.ValidateGT(&pk.A); err != nil {
+		return fmt.Errorf("invalid public key: A: %w", err)
+	}
+	return nil
+}
+
+// Validate 检查来自不可信来源的签名或聚合签名：σ 必须是 G1 中的非无穷远点。
+//
+// 返回值:
+//   - error: σ 不合法时返回包装 serialization.ErrInvalidG1Point 的错误
+func (sigma *Signature) Validate() error {
+	if err := serialization.ValidateG1(&sigma.Sigma); err != nil {
+		return fmt.Errorf("invalid signature: Sigma: %w", err)
+	}
+	return nil
+}
+
+// Validate 检查来自不可信来源的密文：c1、c2 必须是 G2 素数阶子群中的非无穷远点，c3 不能为零。
+// c3 = m·A^t 中的明文 m 可以是任意 GT 元素，因此 c3 不做子群检查。
+//
+// 返回值:
+//   - error: 分量不合法时返回指明分量、并包装 serialization.ErrInvalidG2Point 或 serialization.ErrInvalidGTElement 的错误
+func (c *CipherText) Validate() error {
+	if err := serialization.ValidateG2(&c.C1); err != nil {
+		return fmt.Errorf("invalid ciphertext: C1: %w", err)
+	}
+	if err := serialization.ValidateG2(&c.C2); err != nil {
+		return fmt.Errorf("invalid ciphertext: C2: %w", err)
+	}
+	if c.C3.IsZero() {
+		return fmt.Errorf("invalid ciphertext: C3: zero element: %w", serialization.ErrInvalidGTElement)
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package agka09
+
+import (
+	"errors"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/mmsyan/GoPairingBasedCryptography/internal/invalidpoints"
+	"github.com/mmsyan/GoPairingBasedCryptography/serialization"
+	"testing"
+)
+
+// TestValidate 合法的公钥、聚合公钥、签名与密文通过检查；把某个分量替换为无穷远点、子群外的点或零后返回对应的错误
+func TestValidate(t *testing.T) {
+	pp, _ := ParaGen()
+	pk1, sk1, _ := KeyGen(pp)
+	pk2, _, _ := KeyGen(pp)
+	aggregatePk, err := AggregatePublicKeys([]*PublicKey{pk1, pk2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sigma, _ := Sign(NewSignMessage([]byte("validate")), sk1)
+	ciphertext, err := Encrypt(NewRandomPlainText(), aggregatePk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, validate := range map[string]func() error{"公钥": pk1.Validate, "聚合公钥": aggregatePk.Validate, "签名": sigma.Validate, "密文": ciphertext.Validate} {
+		if err := validate(); err != nil {
+			t.Fatalf("%s: 合法值被拒绝: %v", name, err)
+		}
+	}
+
+	infinityR, nonSubgroupR, identityA := *pk1, *pk1, *pk1
+	infinityR.R.SetInfinity()
+	nonSubgroupR.R = invalidpoints.NonSubgroupG2()
+	identityA.A.SetOne()
+	infinitySigma := *sigma
+	infinitySigma.Sigma.SetInfinity()
+	nonSubgroupC2, zeroC3 := *ciphertext, *ciphertext
+	nonSubgroupC2.C2 = invalidpoints.NonSubgroupG2()
+	zeroC3.C3 = bn254.GT{}
+
+	cases := []struct {
+		name     string
+		validate func() error
+		want     error
+	}{
+		{"无穷远 R", infinityR.Validate, serialization.ErrInvalidG2Point},
+		{"子群外 R", nonSubgroupR.Validate, serialization.ErrInvalidG2Point},
+		{"单位元 A", identityA.Validate, serialization.ErrInvalidGTElement},
+		{"无穷远 σ", infinitySigma.Validate, serialization.ErrInvalidG1Point},
+		{"子群外 c2", nonSubgroupC2.Validate, serialization.ErrInvalidG2Point},
+		{"零 c3", zeroC3.Validate, serialization.ErrInvalidGTElement},
+	}
+	for _, tc := range cases {
+		if err := tc.validate(); !errors.Is(err, tc.want) {
+			t.Errorf("%s: 期望 %v, 实际为 %v", tc.name, tc.want, err)
+		}
+	}
+}
@@ -2,7 +2,11 @@ module github.com/mmsyan/GoPairingBasedCryptography
 
 go 1.23.0
 
-require github.com/consensys/gnark-crypto v0.19.0
+require (
+	github.com/consensys/gnark-crypto v0.19.0
+	golang.org/x/crypto v0.35.0
+	golang.org/x/text v0.24.0
+)
 
 require (
 	github.com/bits-and-blooms/bitset v1.20.0 // indirect
@@ -0,0 +1,120 @@
+// Package gtmsg 统一以 GT 元素为明文的方案（FIBE、Waters05、Gentry06、CP-ABE 等）对明文的处理方式。
+//
+// 这些方案的加密算法只接受 GT 元素，调用方真正想加密的却可能是一个 GT 元素或者一把对称密钥。
+// Plaintext 把"GT 元素 ↔ 调用方数据"的转换集中在一处，方案只需在 Encrypt 时取出 GT 元素、
+// 在 Decrypt 后按 Mode 还原 Plaintext，而不必各自实现密钥派生。
+//
+// 字节数据不直接嵌入 GT 元素：写入 Fp12 系数得到的元素不在 r 阶子群中，密文 C = M·Y^s 满足 C^r = M^r，会泄露明文。
+// 加密字节数据应使用 KEM 模式，由 Key 派生的密钥以 AEAD 加密数据（见 FIBE 的 EncryptBytes）。
+package gtmsg
+
+import (
+	"errors"
+	"fmt"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/mmsyan/GoPairingBasedCryptography/hash"
+)
+
+// Mode 表示明文与 GT 元素之间的对应方式
+type Mode int
+
+const (
+	// RawGT 直接以调用方给出的 GT 元素为明文，即各方案原有的行为
+	RawGT Mode = iota
+	// KEM 随机选取 GT 元素作为封装的会话密钥，调用方通过 Key 由它派生对称密钥
+	KEM
+)
+
+var (
+	// ErrUnknownMode 表示 Mode 不是 RawGT、KEM 之一
+	ErrUnknownMode = errors.New("unknown GT message mode")
+
+	// ErrWrongMode 表示对明文调用了其模式不支持的操作，例如对 RawGT 明文调用 Key
+	ErrWrongMode = errors.New("operation not supported in this GT message mode")
+)
+
+// String 返回模式名称
+func (mode Mode) String() string {
+	switch mode {
+	case RawGT:
+		return "RawGT"
+	case KEM:
+		return "KEM"
+	default:
+		return fmt.Sprintf("Mode(%d)", int(mode))
+	}
+}
+
+// Plaintext 是以 GT 元素为明文的方案的统一明文类型，记录明文的模式以及对应的 GT 元素
+type Plaintext struct {
+	mode Mode
+	gt   bn254.GT
+}
+
+// NewRawGT 以 GT 元素 gt 本身作为明文
+//
+// 参数:
+//   - gt: 明文 GT 元素
+//
+// 返回值:
+//   - *Plaintext: RawGT 模式的明文
+func NewRawGT(gt bn254.GT) *Plaintext {
+	return &Plaintext{mode: RawGT, gt: gt}
+}
+
+// NewKEM 随机选取一个 GT 元素作为待封装的会话密钥
+//
+// 返回值:
+//   - *Plaintext: KEM 模式的明文，加密后用 Key 派生对称密钥
+//   - error: 随机数生成失败时返回错误
+func NewKEM() (*Plaintext, error) {
+	gt, err := new(bn254.GT).SetRandom()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate session key: %w", err)
+	}
+	return &Plaintext{mode: KEM, gt: *gt}, nil
+}
+
+// FromGT 按模式 mode 把解密得到的 GT 元素还原为明文
+//
+// 参数:
+//   - mode: 加密时明文使用的模式
+//   - gt: 解密得到的 GT 元素
+//
+// 返回值:
+//   - *Plaintext: 与加密时的明文相同模式的明文
+//   - error: 模式未知时返回 ErrUnknownMode
+func FromGT(mode Mode, gt bn254.GT) (*Plaintext, error) {
+	switch mode {
+	case RawGT, KEM:
+		return &Plaintext{mode: mode, gt: gt}, nil
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrUnknownMode, int(mode))
+	}
+}
+
+// Mode 返回明文的模式
+func (p *Plaintext) Mode() Mode {
+	return p.mode
+}
+
+// GT 返回交给方案加密的 GT 元素
+func (p *Plaintext) GT() bn254.GT {
+	return p.gt
+}
+
+// Key 由 KEM 模式明文中的 GT 元素派生对称密钥，即 hash.DeriveKeyFromGT(gt, info, keyLen)
+//
+// 参数:
+//   - info: 域分隔标签，不同方案、不同用途应使用不同的标签
+//   - keyLen: 派生密钥的字节数，取值范围 [1, 8160]
+//
+// 返回值:
+//   - []byte: 长度为 keyLen 的对称密钥
+//   - error: 明文不是 KEM 模式时返回 ErrWrongMode
+func (p *Plaintext) Key(info []byte, keyLen int) ([]byte, error) {
+	if p.mode != KEM {
+		return nil, fmt.Errorf("%w: Key on %s plaintext", ErrWrongMode, p.mode)
+	}
+	return hash.DeriveKeyFromGT(p.gt, info, keyLen), nil
+}
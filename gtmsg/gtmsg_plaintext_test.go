@@ -0,0 +1,53 @@
+package gtmsg
+
+import (
+	"bytes"
+	"errors"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/mmsyan/GoPairingBasedCryptography/hash"
+	"testing"
+)
+
+// TestRawGT RawGT 模式原样保存 GT 元素，不支持 Key
+func TestRawGT(t *testing.T) {
+	gt, _ := new(bn254.GT).SetRandom()
+	p := NewRawGT(*gt)
+	restored, err := FromGT(RawGT, p.GT())
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := restored.GT()
+	if restored.Mode() != RawGT || !got.Equal(gt) {
+		t.Fatal("RawGT plaintext did not round-trip")
+	}
+	if _, err := p.Key(nil, 32); !errors.Is(err, ErrWrongMode) {
+		t.Fatalf("expected ErrWrongMode, got %v", err)
+	}
+}
+
+// TestKEM KEM 模式两端由同一个 GT 元素派生出相同的密钥，与 hash.DeriveKeyFromGT 一致
+func TestKEM(t *testing.T) {
+	p, err := NewKEM()
+	if err != nil {
+		t.Fatal(err)
+	}
+	restored, err := FromGT(KEM, p.GT())
+	if err != nil {
+		t.Fatal(err)
+	}
+	info := []byte("gtmsg-test")
+	k1, err := p.Key(info, 32)
+	if err != nil {
+		t.Fatal(err)
+	}
+	k2, err := restored.Key(info, 32)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(k1, k2) || !bytes.Equal(k1, hash.DeriveKeyFromGT(p.GT(), info, 32)) {
+		t.Fatal("KEM keys differ")
+	}
+	if _, err := FromGT(Mode(7), p.GT()); !errors.Is(err, ErrUnknownMode) {
+		t.Fatalf("expected ErrUnknownMode, got %v", err)
+	}
+}
@@ -0,0 +1,57 @@
+package hash
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"fmt"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+)
+
+// maxDerivedKeyLength 是 HKDF-SHA256 单次扩展输出的最大字节数 255·HashLen
+const maxDerivedKeyLength = 255 * sha256.Size
+
+// DeriveKeyFromGT 用 HKDF-SHA256（RFC 5869）从 GT 元素派生对称密钥。
+// 各方案的混合加密都应通过它由 KEM 封装的 GT 元素得到对称密钥，保证派生方式一致。
+//
+// 输入密钥材料是 gt.Bytes() 的规范编码，salt 为空（按 RFC 5869 取全零），
+// info 是调用方的域分隔标签，不同方案、不同用途应使用不同的标签，
+// 这样同一个 GT 元素在不同场景下派生出的密钥彼此独立。
+//
+// 参数:
+//   - gt: 输入的 GT 元素
+//   - info: 域分隔标签，例如 "sw05-fibe-hybrid-aes-256-gcm"
+//   - keyLen: 派生密钥的字节数，取值范围 [1, 8160]
+//
+// 返回值:
+//   - []byte: 长度为 keyLen 的派生密钥
+//
+// Panic:
+//   - keyLen 超出 [1, 8160] 时 panic，这属于调用方的编程错误
+func DeriveKeyFromGT(gt bn254.GT, info []byte, keyLen int) []byte {
+	if keyLen <= 0 || keyLen > maxDerivedKeyLength {
+		panic(fmt.Sprintf("hash: invalid derived key length %d", keyLen))
+	}
+	return hkdfSHA256(FromGT(gt), info, keyLen)
+}
+
+// hkdfSHA256 计算 salt 为空的 HKDF-SHA256(ikm, info)，输出 keyLen 字节
+func hkdfSHA256(ikm, info []byte, keyLen int) []byte {
+	// HKDF-Extract: PRK = HMAC(salt, IKM)
+	extractor := hmac.New(sha256.New, make([]byte, sha256.Size))
+	extractor.Write(ikm)
+	prk := extractor.Sum(nil)
+
+	// HKDF-Expand: T(i) = HMAC(PRK, T(i-1) || info || i)
+	expander := hmac.New(sha256.New, prk)
+	okm := make([]byte, 0, keyLen+sha256.Size)
+	var block []byte
+	for counter := byte(1); len(okm) < keyLen; counter++ {
+		expander.Reset()
+		expander.Write(block)
+		expander.Write(info)
+		expander.Write([]byte{counter})
+		block = expander.Sum(nil)
+		okm = append(okm, block...)
+	}
+	return okm[:keyLen]
+}
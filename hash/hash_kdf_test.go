@@ -0,0 +1,61 @@
+package hash
+
+import (
+	"bytes"
+	"encoding/hex"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"testing"
+)
+
+// TestHKDFSHA256Vector RFC 5869 附录 A.3（salt 与 info 均为空）
+func TestHKDFSHA256Vector(t *testing.T) {
+	ikm := bytes.Repeat([]byte{0x0b}, 22)
+	want, _ := hex.DecodeString("8da4e775a563c18f715f802a063c5a31b8a11f5c5ee1879ec3454e5f3c738d2d9d201395faa4b61a96c8")
+	if got := hkdfSHA256(ikm, nil, 42); !bytes.Equal(got, want) {
+		t.Fatalf("hkdfSHA256 = %x, want %x", got, want)
+	}
+}
+
+func TestDeriveKeyFromGT(t *testing.T) {
+	gt1, err := new(bn254.GT).SetRandom()
+	if err != nil {
+		t.Fatal(err)
+	}
+	gt2, err := new(bn254.GT).SetRandom()
+	if err != nil {
+		t.Fatal(err)
+	}
+	info := []byte("test-kdf")
+
+	if !bytes.Equal(DeriveKeyFromGT(*gt1, info, 32), DeriveKeyFromGT(*gt1, info, 32)) {
+		t.Error("identical GT inputs should derive identical keys")
+	}
+	if bytes.Equal(DeriveKeyFromGT(*gt1, info, 32), DeriveKeyFromGT(*gt2, info, 32)) {
+		t.Error("different GT inputs should derive different keys")
+	}
+	if bytes.Equal(DeriveKeyFromGT(*gt1, info, 32), DeriveKeyFromGT(*gt1, []byte("other-kdf"), 32)) {
+		t.Error("different info labels should derive different keys")
+	}
+
+	for _, n := range []int{1, 16, 32, 33, 100, maxDerivedKeyLength} {
+		key := DeriveKeyFromGT(*gt1, info, n)
+		if len(key) != n {
+			t.Errorf("keyLen %d: got %d bytes", n, len(key))
+		}
+		// 较短的输出是较长输出的前缀
+		if !bytes.HasPrefix(DeriveKeyFromGT(*gt1, info, maxDerivedKeyLength), key) {
+			t.Errorf("keyLen %d: output is not a prefix of the longest output", n)
+		}
+	}
+
+	for _, n := range []int{0, -1, maxDerivedKeyLength + 1} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("keyLen %d should panic", n)
+				}
+			}()
+			DeriveKeyFromGT(*gt1, info, n)
+		}()
+	}
+}
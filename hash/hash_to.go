@@ -113,7 +113,7 @@ func BytesToField(bytes []byte) fr.Element {
 func ToG1(str string) bn254.G1Affine {
 	result, err := bn254.HashToG1([]byte(str), []byte("Hash String To Element In G1"))
 	if err != nil {
-		panic(fmt.Errorf("failed to hash string to g1: %v", err))
+		panic(fmt.Errorf("failed to hash string to g1: %w", err))
 	}
 	return result
 }
@@ -169,7 +169,7 @@ func ToG1(str string) bn254.G1Affine {
 func BytesToG1(bytes []byte) bn254.G1Affine {
 	result, err := bn254.HashToG1(bytes, []byte("Hash Bytes To Element In G1"))
 	if err != nil {
-		panic(fmt.Errorf("failed to hash string to g1: %v", err))
+		panic(fmt.Errorf("failed to hash string to g1: %w", err))
 	}
 	return result
 }
@@ -190,6 +190,7 @@ func BytesToG1(bytes []byte) bn254.G1Affine {
 //   - 相同的输入字符串总是产生相同的 G2 点（确定性映射）
 //   - 使用域分离标签 "Hash String To Element In G2" 确保与其他用途的哈希独立
 //   - 生成的点保证在 G2 群中，可直接用于配对运算
+//   - bn254.HashToG2 内部已清除辅因子；自行使用 bn254.MapToCurve2 时需调用 pairing.ClearCofactorG2
 //
 // 常用用法:
 //
@@ -204,7 +205,7 @@ func BytesToG1(bytes []byte) bn254.G1Affine {
 func ToG2(str string) bn254.G2Affine {
 	result, err := bn254.HashToG2([]byte(str), []byte("Hash String To Element In G2"))
 	if err != nil {
-		panic(fmt.Errorf("failed to hash string to g2: %v", err))
+		panic(fmt.Errorf("failed to hash string to g2: %w", err))
 	}
 	return result
 }
@@ -271,7 +272,7 @@ func ToG2(str string) bn254.G2Affine {
 func BytesToG2(bytes []byte) bn254.G2Affine {
 	result, err := bn254.HashToG2(bytes, []byte("Hash Bytes To Element In G2"))
 	if err != nil {
-		panic(fmt.Errorf("failed to hash string to g2: %v", err))
+		panic(fmt.Errorf("failed to hash string to g2: %w", err))
 	}
 	return result
 }
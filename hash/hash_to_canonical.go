@@ -0,0 +1,83 @@
+package hash
+
+import (
+	"strings"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"golang.org/x/text/unicode/norm"
+)
+
+// CanonicalOptions 控制属性字符串规范化时的大小写处理。
+// 属性字符串被视为 "键<KeySeparator>值" 的形式，例如 "Role:Admin"；不含分隔符时整个字符串都视为键。
+type CanonicalOptions struct {
+	// KeySeparator 是键与值之间的分隔符，为空时视为 ":"
+	KeySeparator string
+	// LowercaseKey 为 true 时将键转换为小写
+	LowercaseKey bool
+	// LowercaseValue 为 true 时将值转换为小写
+	LowercaseValue bool
+}
+
+// DefaultCanonicalOptions 是 ToFieldCanonical 使用的默认选项：分隔符为 ":"，不改变大小写
+var DefaultCanonicalOptions = CanonicalOptions{KeySeparator: ":"}
+
+// Canonicalize 按以下规则依次规范化属性字符串:
+//  1. Unicode NFC 规范化，使组合字符序列与预组合字符一致（如 "é" 与 "é"）
+//  2. 去除首尾空白字符
+//  3. 去除第一个分隔符两侧的空白，"Role : Admin" 与 "Role:Admin" 一致
+//  4. 按 opts 将键和/或值转换为小写
+//
+// 规范化不会合并字符串内部的其它空白，也不做大小写之外的字符折叠，
+// 因此 "Role:Admin" 与 "Role:Admins"、"Role:Ad min" 仍是不同的属性。
+//
+// 参数:
+//   - s: 原始属性字符串
+//   - opts: 规范化选项
+//
+// 返回值:
+//   - string: 规范化后的属性字符串
+func Canonicalize(s string, opts CanonicalOptions) string {
+	sep := opts.KeySeparator
+	if sep == "" {
+		sep = ":"
+	}
+
+	s = strings.TrimSpace(norm.NFC.String(s))
+	key, value, found := strings.Cut(s, sep)
+	key = strings.TrimSpace(key)
+	value = strings.TrimSpace(value)
+	if opts.LowercaseKey {
+		key = strings.ToLower(key)
+	}
+	if opts.LowercaseValue {
+		value = strings.ToLower(value)
+	}
+	if !found {
+		return key
+	}
+	return key + sep + value
+}
+
+// ToFieldCanonical 先按 DefaultCanonicalOptions 规范化属性字符串，再调用 ToField 映射到 Fr。
+// 与 ToField 不同，"Role:Admin " 与 "Role:Admin" 会得到相同的域元素；需要逐字节精确映射时应使用 ToField。
+//
+// 参数:
+//   - s: 原始属性字符串
+//
+// 返回值:
+//   - fr.Element: 规范化后字符串对应的域元素
+func ToFieldCanonical(s string) fr.Element {
+	return ToField(Canonicalize(s, DefaultCanonicalOptions))
+}
+
+// ToFieldCanonicalWith 与 ToFieldCanonical 相同，但使用调用方指定的规范化选项。
+//
+// 参数:
+//   - s: 原始属性字符串
+//   - opts: 规范化选项
+//
+// 返回值:
+//   - fr.Element: 规范化后字符串对应的域元素
+func ToFieldCanonicalWith(s string, opts CanonicalOptions) fr.Element {
+	return ToField(Canonicalize(s, opts))
+}
@@ -0,0 +1,58 @@
+package hash
+
+import "testing"
+
+func TestToFieldCanonicalEqualVariants(t *testing.T) {
+	want := ToFieldCanonical("Role:Admin")
+	variants := []string{
+		"Role:Admin ",
+		"  Role:Admin",
+		"\tRole:Admin\n",
+		"Role : Admin",
+	}
+	for _, v := range variants {
+		if got := ToFieldCanonical(v); got != want {
+			t.Errorf("ToFieldCanonical(%q) differs from ToFieldCanonical(%q)", v, "Role:Admin")
+		}
+	}
+
+	// 组合字符序列与预组合字符规范化后一致
+	if ToFieldCanonical("Dept:Cafe\u0301") != ToFieldCanonical("Dept:Caf\u00e9") {
+		t.Error("NFC variants should canonicalize to the same element")
+	}
+
+	// ToField 保持逐字节精确
+	if ToField("Role:Admin ") == ToField("Role:Admin") {
+		t.Error("ToField should stay byte-exact")
+	}
+}
+
+func TestToFieldCanonicalDistinct(t *testing.T) {
+	distinct := []string{"Role:Admin", "Role:Admins", "Role:admin", "Role:Ad min", "Rol:eAdmin", "RoleAdmin"}
+	seen := make(map[string]string)
+	for _, s := range distinct {
+		got := ToFieldCanonical(s)
+		key := got.String()
+		if prev, ok := seen[key]; ok {
+			t.Errorf("%q and %q should stay distinct", prev, s)
+		}
+		seen[key] = s
+	}
+}
+
+func TestCanonicalizeLowercase(t *testing.T) {
+	opts := CanonicalOptions{LowercaseKey: true}
+	if got := Canonicalize(" ROLE : Admin", opts); got != "role:Admin" {
+		t.Errorf("Canonicalize with LowercaseKey = %q, want %q", got, "role:Admin")
+	}
+	opts = CanonicalOptions{KeySeparator: "=", LowercaseKey: true, LowercaseValue: true}
+	if got := Canonicalize("Role = ADMIN", opts); got != "role=admin" {
+		t.Errorf("Canonicalize with custom separator = %q, want %q", got, "role=admin")
+	}
+	if ToFieldCanonicalWith("ROLE:Admin", CanonicalOptions{LowercaseKey: true}) != ToFieldCanonical("role:Admin") {
+		t.Error("ToFieldCanonicalWith should hash the canonical form")
+	}
+	if got := Canonicalize("  NoSeparator ", DefaultCanonicalOptions); got != "NoSeparator" {
+		t.Errorf("Canonicalize without separator = %q", got)
+	}
+}
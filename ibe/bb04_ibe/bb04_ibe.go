@@ -25,6 +25,8 @@ import (
 	"fmt"
 	"github.com/consensys/gnark-crypto/ecc/bn254"
 	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/mmsyan/GoPairingBasedCryptography/metrics"
+	"github.com/mmsyan/GoPairingBasedCryptography/utils"
 	"math/big"
 )
 
@@ -74,6 +76,7 @@ type BB04IBESecretKey struct {
 // 明文 M 必须是目标群 GT 上的元素。
 type BB04IBEMessage struct {
 	Message bn254.GT
+	sealed  []byte // SetBytes 以会话密钥加密的字节数据
 }
 
 // BB04IBECiphertext 代表密文。
@@ -118,7 +121,7 @@ func (instance *BB04IBEInstance) SetUp() (*BB04IBEPublicParams, error) {
 		for j := 0; j < s; j++ {
 			uRandom, err := new(fr.Element).SetRandom()
 			if err != nil {
-				return nil, fmt.Errorf("failed to set up")
+				return nil, fmt.Errorf("failed to set up: %w", err)
 			}
 			// 计算 uij[i][j] = g2^{随机数}
 			uij[i][j] = *new(bn254.G2Affine).ScalarMultiplicationBase(uRandom.BigInt(new(big.Int)))
@@ -140,9 +143,9 @@ func (instance *BB04IBEInstance) KeyGenerate(identity *BB04IBEIdentity, publicPa
 	dj := [n]bn254.G1Affine{}
 	for i := 0; i < n; i++ {
 		// 随机选取 r_i
-		temp, err := new(fr.Element).SetRandom()
+		temp, err := utils.RandomNonZeroScalar()
 		if err != nil {
-			return nil, fmt.Errorf("failed to generate key")
+			return nil, fmt.Errorf("failed to generate key: %w", err)
 		}
 		r[i] = *temp
 		// 计算 d_i = g1^{r_i} (这里使用 ScalarMultiplicationBase 隐含以 G1 的基点进行运算)
@@ -172,15 +175,15 @@ func (instance *BB04IBEInstance) KeyGenerate(identity *BB04IBEIdentity, publicPa
 // Encrypt 使用指定身份 V 对明文 M 进行加密，生成密文 (a, b, {c_i})。
 func (instance *BB04IBEInstance) Encrypt(identity *BB04IBEIdentity, message *BB04IBEMessage, publicParams *BB04IBEPublicParams) (*BB04IBECiphertext, error) {
 	// 随机选取 t (临时会话密钥)
-	t, err := new(fr.Element).SetRandom()
+	t, err := utils.RandomNonZeroScalar()
 	if err != nil {
-		return nil, fmt.Errorf("failed to encrypt message")
+		return nil, fmt.Errorf("failed to encrypt message: %w", err)
 	}
 
 	// 计算 K_t = e(g1^alpha, g2)^t = e(g1, g2)^{alpha*t} (密钥封装的基元)
-	eG1AlphaG2, err := bn254.Pair([]bn254.G1Affine{publicParams.g1ExpAlpha}, []bn254.G2Affine{publicParams.g2})
+	eG1AlphaG2, err := metrics.Pair([]bn254.G1Affine{publicParams.g1ExpAlpha}, []bn254.G2Affine{publicParams.g2})
 	if err != nil {
-		return nil, fmt.Errorf("failed to encrypt message")
+		return nil, fmt.Errorf("failed to encrypt message: %w", err)
 	}
 	// 得到 K = e(g1, g2)^{alpha*t}
 	eG1AlphaG2ExpT := new(bn254.GT).Exp(eG1AlphaG2, t.BigInt(new(big.Int)))
@@ -212,9 +215,9 @@ func (instance *BB04IBEInstance) Decrypt(ciphertext *BB04IBECiphertext, secretKe
 	// e(dj, cj) = e(g1^{r_j}, u_{j, a_j}^t) = Product(e(g1, u_{j, a_j})^{r_j t})
 	prod := new(bn254.GT).SetOne()
 	for j := 0; j < n; j++ {
-		eDjCj, err := bn254.Pair([]bn254.G1Affine{secretKey.dj[j]}, []bn254.G2Affine{ciphertext.c[j]})
+		eDjCj, err := metrics.Pair([]bn254.G1Affine{secretKey.dj[j]}, []bn254.G2Affine{ciphertext.c[j]})
 		if err != nil {
-			return nil, fmt.Errorf("failed to decrypt message")
+			return nil, fmt.Errorf("failed to decrypt message: %w", err)
 		}
 		prod.Mul(prod, &eDjCj)
 	}
@@ -222,9 +225,9 @@ func (instance *BB04IBEInstance) Decrypt(ciphertext *BB04IBECiphertext, secretKe
 	// 2. 计算分母 e(b, d0)
 	// e(b, d0) = e(g1^t, g2^alpha * Product(u_{i, a_i}^{r_i}))
 	//          = e(g1, g2)^{alpha t} * Product(e(g1, u_{i, a_i})^{t r_i})
-	eBD0, err := bn254.Pair([]bn254.G1Affine{ciphertext.b}, []bn254.G2Affine{secretKey.d0})
+	eBD0, err := metrics.Pair([]bn254.G1Affine{ciphertext.b}, []bn254.G2Affine{secretKey.d0})
 	if err != nil {
-		return nil, fmt.Errorf("failed to decrypt message")
+		return nil, fmt.Errorf("failed to decrypt message: %w", err)
 	}
 
 	// 3. 计算 M = a * Prod_pair
@@ -269,3 +272,9 @@ func NewBB04IBEIdentity(identity string) (*BB04IBEIdentity, error) {
 	}
 	return wId, nil
 }
+
+// Zeroize 清除可信中心持有的主私钥：alpha 覆写为零，g2^alpha 重置为无穷远点；清除范围的限制见 README 的 Key Zeroization。
+func (instance *BB04IBEInstance) Zeroize() {
+	instance.alpha.SetZero()
+	instance.g2ExpAlpha = bn254.G2Affine{}
+}
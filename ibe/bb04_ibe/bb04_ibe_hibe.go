@@ -0,0 +1,185 @@
+package bb04_ibe
+
+import (
+	"fmt"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/mmsyan/GoPairingBasedCryptography/metrics"
+	"github.com/mmsyan/GoPairingBasedCryptography/utils"
+	"math/big"
+)
+
+// 两层 HIBE 扩展（BB04 论文中层次化构造的两层特例）
+//
+// 每一层有独立的身份编码矩阵：第一层沿用 BB04IBEPublicParams.uij，第二层为新采样的 uij2。
+// 层次身份 (ID1, ID2)（例如 "org/dept" 与 "alice"）对应的私钥与密文为：
+//   - 私钥: d0 = g2^alpha * Product_i(u_{i, a_i}^{r_i}) * Product_i(u2_{i, b_i}^{r'_i})，
+//     dj[0][i] = g1^{r_i}，dj[1][i] = g1^{r'_i}
+//   - 密文: a = M * e(g1^alpha, g2)^t，b = g1^t，c[0][i] = u_{i, a_i}^t，c[1][i] = u2_{i, b_i}^t
+//
+// 第一层私钥就是 KeyGenerate 生成的 BB04IBESecretKey；持有它的用户无需主私钥即可用 DelegateKey
+// 为下一层身份派生私钥。
+
+// BB04HIBEPublicParams 是两层 HIBE 的公开参数，在 BB04 公开参数的基础上增加第二层的身份编码矩阵。
+type BB04HIBEPublicParams struct {
+	// base 是第一层（即普通 BB04 IBE）的公开参数
+	base *BB04IBEPublicParams
+	// uij2 是第二层的身份编码矩阵 (n x s)，位于 G2 群
+	uij2 [n][s]bn254.G2Affine
+}
+
+// BB04HIBESecretKey 是第二层身份 (ID1, ID2) 的私钥，由 DelegateKey 生成。
+type BB04HIBESecretKey struct {
+	// d0 = g2^alpha * Product(u_{i, a_i}^{r_i}) * Product(u2_{i, b_i}^{r'_i})
+	d0 bn254.G2Affine
+	// dj[0][i] = g1^{r_i}，dj[1][i] = g1^{r'_i}
+	dj [2][n]bn254.G1Affine
+}
+
+// BB04HIBECiphertext 是发送给第二层身份 (ID1, ID2) 的密文。
+type BB04HIBECiphertext struct {
+	// a = M * e(g1^alpha, g2)^t
+	a bn254.GT
+	// b = g1^t
+	b bn254.G1Affine
+	// c[0][i] = u_{i, a_i}^t，c[1][i] = u2_{i, b_i}^t
+	c [2][n]bn254.G2Affine
+}
+
+// SetUpHIBE 执行两层 HIBE 的系统初始化：生成普通 BB04 公开参数，并随机生成第二层的身份编码矩阵。
+//
+// 返回值:
+//   - *BB04HIBEPublicParams: 两层 HIBE 的公开参数，Base() 可直接用于 KeyGenerate 生成第一层私钥
+//   - error: 随机数生成失败时返回错误
+func (instance *BB04IBEInstance) SetUpHIBE() (*BB04HIBEPublicParams, error) {
+	base, err := instance.SetUp()
+	if err != nil {
+		return nil, err
+	}
+	publicParams := &BB04HIBEPublicParams{base: base}
+	for i := 0; i < n; i++ {
+		for j := 0; j < s; j++ {
+			uRandom, err := new(fr.Element).SetRandom()
+			if err != nil {
+				return nil, fmt.Errorf("failed to set up: %w", err)
+			}
+			publicParams.uij2[i][j] = *new(bn254.G2Affine).ScalarMultiplicationBase(uRandom.BigInt(new(big.Int)))
+		}
+	}
+	return publicParams, nil
+}
+
+// Base 返回第一层的公开参数，用于 KeyGenerate 生成第一层私钥，也可用于普通 BB04 IBE 的加解密。
+func (publicParams *BB04HIBEPublicParams) Base() *BB04IBEPublicParams {
+	return publicParams.base
+}
+
+// DelegateKey 由第一层身份的私钥派生第二层身份 (parentIdentity, childIdentity) 的私钥，不需要主私钥。
+//
+// 除了为第二层选取新的随机数外，还会用新的随机数重新随机化第一层分量，使派生出的私钥与父私钥
+// 统计独立，兄弟节点的私钥之间也不共享随机数。重新随机化需要知道父私钥对应的身份，因此 parentIdentity
+// 必须与生成 parentKey 时使用的身份一致，否则派生出的私钥无法解密。
+//
+// 参数:
+//   - parentKey: 第一层身份的私钥，由 KeyGenerate 使用 publicParams.Base() 生成
+//   - parentIdentity: 第一层身份
+//   - childIdentity: 第二层身份分量
+//   - publicParams: 两层 HIBE 的公开参数
+//
+// 返回值:
+//   - *BB04HIBESecretKey: 第二层身份的私钥
+//   - error: 随机数生成失败时返回错误
+func DelegateKey(parentKey *BB04IBESecretKey, parentIdentity, childIdentity *BB04IBEIdentity, publicParams *BB04HIBEPublicParams) (*BB04HIBESecretKey, error) {
+	secretKey := &BB04HIBESecretKey{d0: parentKey.d0}
+	for level, identity := range [2]*BB04IBEIdentity{parentIdentity, childIdentity} {
+		uij := &publicParams.base.uij
+		if level == 1 {
+			uij = &publicParams.uij2
+		}
+		for i := 0; i < n; i++ {
+			r, err := utils.RandomNonZeroScalar()
+			if err != nil {
+				return nil, fmt.Errorf("failed to delegate key: %w", err)
+			}
+			gR := new(bn254.G1Affine).ScalarMultiplicationBase(r.BigInt(new(big.Int)))
+			if level == 0 {
+				// dj[0][i] = g1^{r_i} * g1^{delta_i}
+				secretKey.dj[0][i].Add(&parentKey.dj[i], gR)
+			} else {
+				secretKey.dj[1][i] = *gR
+			}
+			// d0 *= u_{i, a_i}^{delta_i}（第一层）或 u2_{i, b_i}^{r'_i}（第二层）
+			uR := new(bn254.G2Affine).ScalarMultiplication(&uij[i][identity.Id[i]], r.BigInt(new(big.Int)))
+			secretKey.d0.Add(&secretKey.d0, uR)
+		}
+	}
+	return secretKey, nil
+}
+
+// EncryptHIBE 对第二层身份 (parentIdentity, childIdentity) 加密明文 M，生成密文 (a, b, {c[0][i]}, {c[1][i]})。
+//
+// 参数:
+//   - parentIdentity: 第一层身份
+//   - childIdentity: 第二层身份分量
+//   - message: 明文消息
+//   - publicParams: 两层 HIBE 的公开参数
+//
+// 返回值:
+//   - *BB04HIBECiphertext: 密文
+//   - error: 随机数生成或配对计算失败时返回错误
+func (instance *BB04IBEInstance) EncryptHIBE(parentIdentity, childIdentity *BB04IBEIdentity, message *BB04IBEMessage, publicParams *BB04HIBEPublicParams) (*BB04HIBECiphertext, error) {
+	t, err := utils.RandomNonZeroScalar()
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt message: %w", err)
+	}
+	base := publicParams.base
+	eG1AlphaG2, err := metrics.Pair([]bn254.G1Affine{base.g1ExpAlpha}, []bn254.G2Affine{base.g2})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt message: %w", err)
+	}
+	tBig := t.BigInt(new(big.Int))
+
+	ciphertext := &BB04HIBECiphertext{}
+	// a = M * e(g1, g2)^{alpha*t}
+	ciphertext.a.Exp(eG1AlphaG2, tBig)
+	ciphertext.a.Mul(&ciphertext.a, &message.Message)
+	// b = g1^t
+	ciphertext.b.ScalarMultiplicationBase(tBig)
+	for i := 0; i < n; i++ {
+		ciphertext.c[0][i].ScalarMultiplication(&base.uij[i][parentIdentity.Id[i]], tBig)
+		ciphertext.c[1][i].ScalarMultiplication(&publicParams.uij2[i][childIdentity.Id[i]], tBig)
+	}
+	return ciphertext, nil
+}
+
+// DecryptHIBE 使用第二层私钥解密密文。
+// 解密公式: M = a * Product_{l, j}(e(dj[l][j], c[l][j])) / e(b, d0)，所有配对合并为一次多重配对计算。
+//
+// 参数:
+//   - ciphertext: 发送给第二层身份的密文
+//   - secretKey: 由 DelegateKey 派生的私钥
+//   - publicParams: 两层 HIBE 的公开参数（未使用，保留以与 Decrypt 一致）
+//
+// 返回值:
+//   - *BB04IBEMessage: 解密得到的明文；私钥与密文身份不一致时得到与明文无关的 GT 元素
+//   - error: 配对计算失败时返回错误
+func (instance *BB04IBEInstance) DecryptHIBE(ciphertext *BB04HIBECiphertext, secretKey *BB04HIBESecretKey, publicParams *BB04HIBEPublicParams) (*BB04IBEMessage, error) {
+	g1s := make([]bn254.G1Affine, 0, 2*n+1)
+	g2s := make([]bn254.G2Affine, 0, 2*n+1)
+	for level := 0; level < 2; level++ {
+		g1s = append(g1s, secretKey.dj[level][:]...)
+		g2s = append(g2s, ciphertext.c[level][:]...)
+	}
+	// 1 / e(b, d0) = e(-b, d0)
+	var negB bn254.G1Affine
+	negB.Neg(&ciphertext.b)
+	g1s = append(g1s, negB)
+	g2s = append(g2s, secretKey.d0)
+
+	prod, err := metrics.Pair(g1s, g2s)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt message: %w", err)
+	}
+	m := new(bn254.GT).Mul(&ciphertext.a, &prod)
+	return &BB04IBEMessage{Message: *m}, nil
+}
@@ -0,0 +1,87 @@
+package bb04_ibe
+
+import (
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"testing"
+)
+
+// TestBB04HIBEDelegateKey "org/dept" 的私钥派生出 "org/dept/alice" 的私钥，可以解密发送给 alice 的密文；
+// 为 bob 派生的私钥以及其他部门派生的 alice 私钥都不能解密
+func TestBB04HIBEDelegateKey(t *testing.T) {
+	instance, err := NewBB04IBEInstance()
+	if err != nil {
+		t.Fatal(err)
+	}
+	publicParams, err := instance.SetUpHIBE()
+	if err != nil {
+		t.Fatal(err)
+	}
+	identities := make(map[string]*BB04IBEIdentity)
+	for _, name := range []string{"org/dept", "org/other", "alice", "bob"} {
+		if identities[name], err = NewBB04IBEIdentity(name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	deptKey, err := instance.KeyGenerate(identities["org/dept"], publicParams.Base())
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKey, err := instance.KeyGenerate(identities["org/other"], publicParams.Base())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	aliceKey, err := DelegateKey(deptKey, identities["org/dept"], identities["alice"], publicParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if aliceKey.dj[0][0].Equal(&deptKey.dj[0]) {
+		t.Fatal("派生私钥的第一层分量应被重新随机化")
+	}
+
+	m, err := new(bn254.GT).SetRandom()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ciphertext, err := instance.EncryptHIBE(identities["org/dept"], identities["alice"], &BB04IBEMessage{Message: *m}, publicParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decrypted, err := instance.DecryptHIBE(ciphertext, aliceKey, publicParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !decrypted.Message.Equal(m) {
+		t.Fatal("派生私钥应能解密发送给子身份的密文")
+	}
+
+	// 同一父私钥再次派生的私钥随机数不同，但同样可以解密
+	again, err := DelegateKey(deptKey, identities["org/dept"], identities["alice"], publicParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again.d0.Equal(&aliceKey.d0) {
+		t.Fatal("两次派生的私钥不应相同")
+	}
+	if decrypted, err = instance.DecryptHIBE(ciphertext, again, publicParams); err != nil || !decrypted.Message.Equal(m) {
+		t.Fatalf("再次派生的私钥应能解密: %v", err)
+	}
+
+	bobKey, err := DelegateKey(deptKey, identities["org/dept"], identities["bob"], publicParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherAliceKey, err := DelegateKey(otherKey, identities["org/other"], identities["alice"], publicParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, key := range map[string]*BB04HIBESecretKey{"org/dept/bob": bobKey, "org/other/alice": otherAliceKey} {
+		decrypted, err := instance.DecryptHIBE(ciphertext, key, publicParams)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if decrypted.Message.Equal(m) {
+			t.Fatalf("%s 的私钥不应能解密发送给 org/dept/alice 的密文", name)
+		}
+	}
+}
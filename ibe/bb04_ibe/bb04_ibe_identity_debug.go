@@ -0,0 +1,22 @@
+package bb04_ibe
+
+import "github.com/mmsyan/GoPairingBasedCryptography/utils"
+
+// BitString 返回身份向量的二进制表示，第 i 个字符对应 Id[i]，便于排查解密失败时两个身份哈希后是否一致。
+//
+// 返回值:
+//   - string: 长度为 256 的二进制字符串
+func (identity *BB04IBEIdentity) BitString() string {
+	return utils.BitString(identity.Id[:])
+}
+
+// HammingDistance 计算两个身份向量之间不同的位数；相同的身份字符串距离为 0。
+//
+// 参数:
+//   - a, b: 待比较的身份
+//
+// 返回值:
+//   - int: 取值不同的位数
+func HammingDistance(a, b *BB04IBEIdentity) int {
+	return utils.HammingDistance(a.Id[:], b.Id[:])
+}
@@ -0,0 +1,30 @@
+package bb04_ibe
+
+import "testing"
+
+// TestIdentityBitString 相同字符串得到相同的比特向量，不同字符串经 SHA-256 扩散后汉明距离应接近 128
+func TestIdentityBitString(t *testing.T) {
+	alice1, err := NewBB04IBEIdentity("alice@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	alice2, err := NewBB04IBEIdentity("alice@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := NewBB04IBEIdentity("alice@example.con")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(alice1.BitString()) != 256 {
+		t.Fatalf("比特串长度应为 256，实际为 %d", len(alice1.BitString()))
+	}
+	if alice1.BitString() != alice2.BitString() || HammingDistance(alice1, alice2) != 0 {
+		t.Fatal("相同的身份字符串应得到相同的比特向量")
+	}
+	// 256 位独立均匀比特的汉明距离期望为 128，标准差为 8；64 远低于正常波动范围
+	if d := HammingDistance(alice1, other); d < 64 {
+		t.Fatalf("仅差一个字符的身份汉明距离过小: %d", d)
+	}
+}
@@ -0,0 +1,12 @@
+package bb04_ibe
+
+import (
+	"github.com/mmsyan/GoPairingBasedCryptography/internal/msgtest"
+	"github.com/mmsyan/GoPairingBasedCryptography/serialization"
+	"testing"
+)
+
+// TestBB04IBEMessageBytes 检查 BB04IBEMessage 的 Bytes/SetBytes 往返
+func TestBB04IBEMessageBytes(t *testing.T) {
+	msgtest.CheckGTRoundTrip(t, func() serialization.Message { return new(BB04IBEMessage) })
+}
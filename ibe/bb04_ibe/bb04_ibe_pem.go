@@ -0,0 +1,45 @@
+package bb04_ibe
+
+import (
+	"fmt"
+	"github.com/mmsyan/GoPairingBasedCryptography/serialization"
+)
+
+// SecretKeyPEMType 是 BB04 用户私钥的 PEM 块类型
+const SecretKeyPEMType = "BB04 IBE PRIVATE KEY"
+
+// ExportSecretKeyPEM 将用户私钥导出为 PEM 格式，正文为 MarshalBinary 结果的 base64 编码。
+//
+// 参数:
+//   - sk: 用户私钥
+//
+// 返回值:
+//   - []byte: PEM 编码的私钥
+//   - error: 序列化失败时返回错误
+func ExportSecretKeyPEM(sk *BB04IBESecretKey) ([]byte, error) {
+	data, err := sk.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("failed to export secret key: %w", err)
+	}
+	return serialization.EncodePEM(SecretKeyPEMType, data), nil
+}
+
+// ImportSecretKeyPEM 从 PEM 格式导入用户私钥，PEM 块类型必须为 SecretKeyPEMType。
+//
+// 参数:
+//   - data: ExportSecretKeyPEM 的输出
+//
+// 返回值:
+//   - *BB04IBESecretKey: 导入的用户私钥
+//   - error: PEM 类型不匹配或数据格式不正确时返回错误
+func ImportSecretKeyPEM(data []byte) (*BB04IBESecretKey, error) {
+	der, err := serialization.DecodePEM(SecretKeyPEMType, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to import secret key: %w", err)
+	}
+	sk := new(BB04IBESecretKey)
+	if err := sk.UnmarshalBinary(der); err != nil {
+		return nil, fmt.Errorf("failed to import secret key: %w", err)
+	}
+	return sk, nil
+}
@@ -0,0 +1,35 @@
+package bb04_ibe
+
+import (
+	"errors"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/mmsyan/GoPairingBasedCryptography/internal/pemtest"
+	"testing"
+)
+
+// TestBB04SecretKeyPEM 测试私钥导出为 PEM 后可以重新导入并正确解密
+func TestBB04SecretKeyPEM(t *testing.T) {
+	identity, _ := NewBB04IBEIdentity("alice@example.com")
+	instance, _ := NewBB04IBEInstance()
+	publicParams, _ := instance.SetUp()
+	secretKey, err := instance.KeyGenerate(identity, publicParams)
+	if err != nil {
+		t.Fatalf("密钥生成失败: %v", err)
+	}
+
+	pemtest.CheckSecretKey(t, SecretKeyPEMType, secretKey, ExportSecretKeyPEM, ImportSecretKeyPEM, func(imported *BB04IBESecretKey) error {
+		m, _ := new(bn254.GT).SetRandom()
+		ciphertext, err := instance.Encrypt(identity, &BB04IBEMessage{Message: *m}, publicParams)
+		if err != nil {
+			return err
+		}
+		decrypted, err := instance.Decrypt(ciphertext, imported, publicParams)
+		if err != nil {
+			return err
+		}
+		if !decrypted.Message.Equal(m) {
+			return errors.New("解密结果不正确")
+		}
+		return nil
+	})
+}
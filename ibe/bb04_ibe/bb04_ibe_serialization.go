@@ -0,0 +1,67 @@
+package bb04_ibe
+
+import (
+	"errors"
+	"fmt"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/mmsyan/GoPairingBasedCryptography/serialization"
+)
+
+// MarshalBinary 使用默认的压缩点编码序列化用户私钥。
+// 布局: 头部 || d0 (G2) || dj[0] (G1) || ... || dj[n-1] (G1)。
+//
+// 返回值:
+//   - []byte: 序列化后的私钥
+//   - error: 序列化失败时返回错误
+func (sk *BB04IBESecretKey) MarshalBinary() ([]byte, error) {
+	return sk.MarshalBinaryWithEncoding(serialization.Compressed)
+}
+
+// MarshalBinaryWithEncoding 使用指定的点编码方式序列化用户私钥。
+//
+// 参数:
+//   - encoding: 点编码方式（serialization.Compressed 或 serialization.Uncompressed）
+//
+// 返回值:
+//   - []byte: 序列化后的私钥
+//   - error: 序列化失败时返回错误
+func (sk *BB04IBESecretKey) MarshalBinaryWithEncoding(encoding serialization.PointEncoding) ([]byte, error) {
+	data := serialization.AppendHeader(nil, serialization.SchemeBB04IBE)
+	data = append(data, serialization.EncodeG2(sk.d0, encoding)...)
+	for i := range sk.dj {
+		data = append(data, serialization.EncodeG1(sk.dj[i], encoding)...)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary 从字节串恢复用户私钥，自动识别压缩/非压缩点编码。
+//
+// 参数:
+//   - data: MarshalBinary 或 MarshalBinaryWithEncoding 的输出
+//
+// 返回值:
+//   - error: 数据格式不正确时返回错误
+func (sk *BB04IBESecretKey) UnmarshalBinary(data []byte) error {
+	data, err := serialization.CheckHeader(data, serialization.SchemeBB04IBE)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal secret key: %w", err)
+	}
+	d0, k, err := serialization.DecodeValidG2(data)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal secret key: %w", err)
+	}
+	data = data[k:]
+	var dj [n]bn254.G1Affine
+	for i := range dj {
+		dj[i], k, err = serialization.DecodeG1(data)
+		if err != nil {
+			return fmt.Errorf("failed to unmarshal secret key: %w", err)
+		}
+		data = data[k:]
+	}
+	if len(data) != 0 {
+		return errors.New("failed to unmarshal secret key: trailing bytes")
+	}
+	sk.d0, sk.dj = d0, dj
+	return nil
+}
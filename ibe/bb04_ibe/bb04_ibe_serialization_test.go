@@ -0,0 +1,43 @@
+package bb04_ibe
+
+import (
+	"bytes"
+	"github.com/mmsyan/GoPairingBasedCryptography/internal/invalidpoints"
+	"testing"
+)
+
+// TestBB04SecretKeyRejectInvalidG2 把私钥序列化结果中的 d0 替换为无穷远点、不在曲线上或不在子群中的编码后，UnmarshalBinary 必须拒绝
+func TestBB04SecretKeyRejectInvalidG2(t *testing.T) {
+	identity, err := NewBB04IBEIdentity("alice@example.com")
+	if err != nil {
+		t.Fatalf("创建身份失败: %v", err)
+	}
+	instance, err := NewBB04IBEInstance()
+	if err != nil {
+		t.Fatalf("创建IBE实例失败: %v", err)
+	}
+	publicParams, err := instance.SetUp()
+	if err != nil {
+		t.Fatalf("系统初始化失败: %v", err)
+	}
+	secretKey, err := instance.KeyGenerate(identity, publicParams)
+	if err != nil {
+		t.Fatalf("密钥生成失败: %v", err)
+	}
+	data, err := secretKey.MarshalBinary()
+	if err != nil {
+		t.Fatalf("私钥序列化失败: %v", err)
+	}
+	if err := new(BB04IBESecretKey).UnmarshalBinary(data); err != nil {
+		t.Fatalf("私钥反序列化失败: %v", err)
+	}
+
+	// d0 位于序列化结果的开头
+	for name, invalid := range invalidpoints.G2Encodings() {
+		tampered := bytes.Clone(data)
+		copy(tampered, invalid)
+		if err := new(BB04IBESecretKey).UnmarshalBinary(tampered); err == nil {
+			t.Fatalf("%s: 不合法的 G2 点应被拒绝", name)
+		}
+	}
+}
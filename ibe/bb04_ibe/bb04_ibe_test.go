@@ -3,7 +3,9 @@ package bb04_ibe
 import (
 	"fmt"
 	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/mmsyan/GoPairingBasedCryptography/internal/selftest"
 	"testing"
+	"time"
 )
 
 // TestBB04Ibe1 测试正确的情况
@@ -323,3 +325,66 @@ func TestBB04Ibe4(t *testing.T) {
 
 	fmt.Println("\n✅ 测试通过：所有身份编码和特殊身份值都能正常工作")
 }
+
+// TestBB04TimedIdentity 测试带有效期的身份
+// 场景：时间段 N 的私钥可以解密时间段 N 的密文，但不能解密时间段 N+1 的密文
+func TestBB04TimedIdentity(t *testing.T) {
+	period := 24 * time.Hour
+	now := time.Date(2025, time.January, 15, 12, 0, 0, 0, time.UTC)
+
+	idEpochN, err := NewTimedIdentity("bob", now, period)
+	if err != nil {
+		t.Fatalf("创建身份失败: %v", err)
+	}
+	idEpochNext, err := NewTimedIdentity("bob", now.Add(period), period)
+	if err != nil {
+		t.Fatalf("创建身份失败: %v", err)
+	}
+	if _, err := CurrentEpochIdentity("", period); err == nil {
+		t.Fatal("空身份应返回错误")
+	}
+
+	instance, _ := NewBB04IBEInstance()
+	publicParams, _ := instance.SetUp()
+	secretKey, err := instance.KeyGenerate(idEpochN, publicParams)
+	if err != nil {
+		t.Fatalf("密钥生成失败: %v", err)
+	}
+
+	m, _ := new(bn254.GT).SetRandom()
+	message := &BB04IBEMessage{Message: *m}
+
+	ctEpochN, _ := instance.Encrypt(idEpochN, message, publicParams)
+	decrypted, err := instance.Decrypt(ctEpochN, secretKey, publicParams)
+	if err != nil || !decrypted.Message.Equal(&message.Message) {
+		t.Fatal("时间段 N 的私钥应能解密时间段 N 的密文")
+	}
+
+	ctEpochNext, _ := instance.Encrypt(idEpochNext, message, publicParams)
+	decrypted, err = instance.Decrypt(ctEpochNext, secretKey, publicParams)
+	if err == nil && decrypted.Message.Equal(&message.Message) {
+		t.Fatal("时间段 N 的私钥不应解密时间段 N+1 的密文")
+	}
+}
+
+// TestBB04IbeZeroize 测试 Zeroize 清除主私钥，且清除后生成的私钥无法完成自检的加解密往返
+func TestBB04IbeZeroize(t *testing.T) {
+	instance, err := NewBB04IBEInstance()
+	if err != nil {
+		t.Fatal(err)
+	}
+	publicParams, err := instance.SetUp()
+	if err != nil {
+		t.Fatal(err)
+	}
+	instance.Zeroize()
+	if !instance.alpha.IsZero() || !instance.g2ExpAlpha.IsInfinity() {
+		t.Fatal("Zeroize 之后主私钥未被清除")
+	}
+	err = selftest.Run("zeroized", func() (*selftest.RoundTrip[bn254.GT, *BB04IBECiphertext], error) {
+		return keyRoundTrip(instance, publicParams)
+	})
+	if err == nil {
+		t.Fatal("清除主私钥后生成的私钥仍能解密")
+	}
+}
@@ -21,9 +21,12 @@ package bb04_sibe
 // 另外一篇命名为BB04IBE (表示它是full secure)
 
 import (
+	"errors"
 	"fmt"
 	"github.com/consensys/gnark-crypto/ecc/bn254"
 	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/mmsyan/GoPairingBasedCryptography/metrics"
+	"github.com/mmsyan/GoPairingBasedCryptography/utils"
 	"math/big"
 )
 
@@ -68,6 +71,7 @@ type BB04sIBESecretKey struct {
 // 在实际应用中，通常需要将原始消息映射到GT群元素。
 type BB04sIBEMessage struct {
 	Message bn254.GT
+	sealed  []byte // SetBytes 以会话密钥加密的字节数据
 }
 
 // BB04sIBECiphertext 表示Boneh-Boyen IBE方案中的密文。
@@ -94,7 +98,7 @@ func NewBB04sIBEInstance() (*BB04sIBEInstance, error) {
 	_, err = x.SetRandom()
 	_, err = y.SetRandom()
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate identity based encryption instance: %s", err)
+		return nil, fmt.Errorf("failed to generate identity based encryption instance: %w", err)
 	}
 	return &BB04sIBEInstance{x, y}, nil
 }
@@ -131,15 +135,17 @@ func (instance *BB04sIBEInstance) SetUp() (*BB04sIBEPublicParams, error) {
 //   - *BB04sIBESecretKey: 生成的私钥，包含随机参数r和密钥元素k
 //   - error: 如果密钥生成失败，返回错误信息
 func (instance *BB04sIBEInstance) KeyGenerate(identity *BB04sIBEIdentity, publicParams *BB04sIBEPublicParams) (*BB04sIBESecretKey, error) {
-	var err error
-	var r fr.Element
-	_, err = r.SetRandom()
-
-	// 计算 1 / (ID + x + r*y) mod q
+	// 计算 1 / (ID + x + r*y) mod q，ID + x + r*y = 0 时没有逆元，重新选取 r
 	denominator := new(fr.Element)
-	denominator.Mul(&r, &instance.y)
-	denominator.Add(denominator, &instance.x)
-	denominator.Add(denominator, &identity.Id)
+	r, err := utils.RandomScalarWhere(func(r *fr.Element) bool {
+		denominator.Mul(r, &instance.y)
+		denominator.Add(denominator, &instance.x)
+		denominator.Add(denominator, &identity.Id)
+		return !r.IsZero() && !denominator.IsZero()
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate random key: %w", err)
+	}
 	denominator.Inverse(denominator)
 
 	//denominator := new(big.Int).Mul(r, instance.y) // denominator = r*y
@@ -151,13 +157,9 @@ func (instance *BB04sIBEInstance) KeyGenerate(identity *BB04sIBEIdentity, public
 	// k = g2 ^ {1 / (ID + x + r*y)}
 	k := *new(bn254.G2Affine).ScalarMultiplicationBase(denominator.BigInt(new(big.Int)))
 
-	if err != nil {
-		return nil, fmt.Errorf("failed to generate random key: %s", err)
-	}
-
 	// r, k = g2^{\frac{1}{Id+x+ry}}
 	return &BB04sIBESecretKey{
-		r: r,
+		r: *r,
 		k: k,
 	}, nil
 }
@@ -175,15 +177,13 @@ func (instance *BB04sIBEInstance) KeyGenerate(identity *BB04sIBEIdentity, public
 //   - *BB04sIBECiphertext: 加密后的密文，包含a, b, c三个组件
 //   - error: 如果加密失败，返回错误信息
 func (instance *BB04sIBEInstance) Encrypt(message *BB04sIBEMessage, identity *BB04sIBEIdentity, publicParams *BB04sIBEPublicParams) (*BB04sIBECiphertext, error) {
-	var err error
-	var s fr.Element
-	_, err = s.SetRandom()
+	s, err := utils.RandomNonZeroScalar()
 	if err != nil {
-		return nil, fmt.Errorf("failed to encrypt: %s", err)
+		return nil, fmt.Errorf("failed to encrypt: %w", err)
 	}
 
 	// a = g1^{s * Id} * X^s
-	s_id := new(fr.Element).Mul(&s, &identity.Id)
+	s_id := new(fr.Element).Mul(s, &identity.Id)
 	a := new(bn254.G1Affine).ScalarMultiplicationBase(s_id.BigInt(new(big.Int)))
 	x_s := new(bn254.G1Affine).ScalarMultiplication(&publicParams.x, s.BigInt(new(big.Int)))
 	a.Add(a, x_s)
@@ -192,9 +192,9 @@ func (instance *BB04sIBEInstance) Encrypt(message *BB04sIBEMessage, identity *BB
 	b := *new(bn254.G1Affine).ScalarMultiplication(&publicParams.y, s.BigInt(new(big.Int)))
 
 	// c = e(g1, g2)^s * message
-	c, err := bn254.Pair([]bn254.G1Affine{publicParams.g1}, []bn254.G2Affine{publicParams.g2})
+	c, err := metrics.Pair([]bn254.G1Affine{publicParams.g1}, []bn254.G2Affine{publicParams.g2})
 	if err != nil {
-		return nil, fmt.Errorf("failed to encrypt message")
+		return nil, fmt.Errorf("failed to encrypt message: %w", err)
 	}
 	c.Exp(c, s.BigInt(new(big.Int)))
 	c.Mul(&c, &message.Message)
@@ -220,9 +220,9 @@ func (instance *BB04sIBEInstance) Decrypt(ciphertext *BB04sIBECiphertext, secret
 	a_br.Add(&ciphertext.a, a_br)                                                                     // A*B^r(注意gnark)是加法群
 
 	// e(A*B^r, K)
-	denominator, err := bn254.Pair([]bn254.G1Affine{*a_br}, []bn254.G2Affine{secretKey.k})
+	denominator, err := metrics.Pair([]bn254.G1Affine{*a_br}, []bn254.G2Affine{secretKey.k})
 	if err != nil {
-		return nil, fmt.Errorf("failed to decrypt")
+		return nil, fmt.Errorf("failed to decrypt: %w", err)
 	}
 
 	// m = C / e(A*B^r, K)
@@ -230,8 +230,26 @@ func (instance *BB04sIBEInstance) Decrypt(ciphertext *BB04sIBECiphertext, secret
 	return &BB04sIBEMessage{Message: decryptedMessage}, nil
 }
 
+// NewBB04sIBEIdentity 将大整数类型的 ID 转换为方案使用的 fr.Element 身份结构体。
+// identity 按标量域的阶 r 取模，负数映射到 [0, r) 中的正剩余，x 与 x + r 表示同一个身份。
+//
+// 参数:
+//   - identity: 大整数形式的用户 ID，可以为负数或超过域的阶
+//
+// 返回值:
+//   - *BB04sIBEIdentity: 身份结构体
+//   - error: identity 为 nil 时返回错误
 func NewBB04sIBEIdentity(identity *big.Int) (*BB04sIBEIdentity, error) {
+	if identity == nil {
+		return nil, errors.New("identity must not be nil")
+	}
 	return &BB04sIBEIdentity{
 		Id: *new(fr.Element).SetBigInt(identity),
 	}, nil
 }
+
+// Zeroize 将主密钥对 (x, y) 覆写为零；清除范围的限制见 README 的 Key Zeroization。
+func (instance *BB04sIBEInstance) Zeroize() {
+	instance.x.SetZero()
+	instance.y.SetZero()
+}
@@ -0,0 +1,72 @@
+package bb04_sibe
+
+import (
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"math/big"
+	"testing"
+)
+
+// TestNewIdentityReduction 负数身份映射到模 r 的正剩余，x 与 x + r 得到相同的身份
+func TestNewIdentityReduction(t *testing.T) {
+	negative, err := NewBB04sIBEIdentity(big.NewInt(-12345))
+	if err != nil {
+		t.Fatal(err)
+	}
+	residue, err := NewBB04sIBEIdentity(new(big.Int).Sub(fr.Modulus(), big.NewInt(12345)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if negative.Id != residue.Id {
+		t.Fatal("-12345 应与 r - 12345 表示同一个身份")
+	}
+
+	if _, err := NewBB04sIBEIdentity(nil); err == nil {
+		t.Fatal("nil 身份应返回错误")
+	}
+}
+
+// TestIdentityCongruentDecrypt 用 x + r 生成的私钥可以解密发给 x 的密文
+func TestIdentityCongruentDecrypt(t *testing.T) {
+	instance, err := NewBB04sIBEInstance()
+	if err != nil {
+		t.Fatal("创建实例失败:", err)
+	}
+	publicParams, err := instance.SetUp()
+	if err != nil {
+		t.Fatal("系统初始化失败:", err)
+	}
+
+	x := new(big.Int).Lsh(big.NewInt(1), 100)
+	identity, err := NewBB04sIBEIdentity(x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	congruent, err := NewBB04sIBEIdentity(new(big.Int).Add(x, fr.Modulus()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if identity.Id != congruent.Id {
+		t.Fatal("x 与 x + r 应表示同一个身份")
+	}
+
+	secretKey, err := instance.KeyGenerate(congruent, publicParams)
+	if err != nil {
+		t.Fatal("密钥生成失败:", err)
+	}
+	m, err := new(bn254.GT).SetRandom()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ciphertext, err := instance.Encrypt(&BB04sIBEMessage{Message: *m}, identity, publicParams)
+	if err != nil {
+		t.Fatal("加密失败:", err)
+	}
+	decrypted, err := instance.Decrypt(ciphertext, secretKey, publicParams)
+	if err != nil {
+		t.Fatal("解密失败:", err)
+	}
+	if decrypted.Message != *m {
+		t.Fatal("解密消息与原始消息不匹配")
+	}
+}
@@ -0,0 +1,12 @@
+package bb04_sibe
+
+import (
+	"github.com/mmsyan/GoPairingBasedCryptography/internal/msgtest"
+	"github.com/mmsyan/GoPairingBasedCryptography/serialization"
+	"testing"
+)
+
+// TestBB04sIBEMessageBytes 检查 BB04sIBEMessage 的 Bytes/SetBytes 往返
+func TestBB04sIBEMessageBytes(t *testing.T) {
+	msgtest.CheckGTRoundTrip(t, func() serialization.Message { return new(BB04sIBEMessage) })
+}
@@ -0,0 +1,55 @@
+package bb04_sibe
+
+import (
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/mmsyan/GoPairingBasedCryptography/internal/selftest"
+	"math/big"
+)
+
+// SelfTest 执行一次最小的 SetUp → KeyGenerate → Encrypt → Decrypt 往返，
+// 解密结果与随机消息不一致时返回错误。可在服务启动时调用，尽早发现构建或运行环境的问题。
+//
+// 返回值:
+//   - error: 任一步骤失败或解密结果与原始消息不一致时返回错误
+func SelfTest() error {
+	return selftest.Run("bb04 sibe", selfTestRoundTrip)
+}
+
+// selfTestRoundTrip 生成实例与公共参数，再由 keyRoundTrip 完成往返的其余部分
+func selfTestRoundTrip() (*selftest.RoundTrip[bn254.GT, *BB04sIBECiphertext], error) {
+	instance, err := NewBB04sIBEInstance()
+	if err != nil {
+		return nil, err
+	}
+	publicParams, err := instance.SetUp()
+	if err != nil {
+		return nil, err
+	}
+	return keyRoundTrip(instance, publicParams)
+}
+
+// keyRoundTrip 用 instance 为自检身份生成私钥，并返回绑定实例、公共参数与该私钥的加密与解密闭包
+func keyRoundTrip(instance *BB04sIBEInstance, publicParams *BB04sIBEPublicParams) (*selftest.RoundTrip[bn254.GT, *BB04sIBECiphertext], error) {
+	identity, err := NewBB04sIBEIdentity(big.NewInt(20040101))
+	if err != nil {
+		return nil, err
+	}
+	secretKey, err := instance.KeyGenerate(identity, publicParams)
+	if err != nil {
+		return nil, err
+	}
+	return &selftest.RoundTrip[bn254.GT, *BB04sIBECiphertext]{
+		NewMessage: selftest.RandomGT,
+		Encrypt: func(m bn254.GT) (*BB04sIBECiphertext, error) {
+			return instance.Encrypt(&BB04sIBEMessage{Message: m}, identity, publicParams)
+		},
+		Decrypt: func(ciphertext *BB04sIBECiphertext) (bn254.GT, error) {
+			decrypted, err := instance.Decrypt(ciphertext, secretKey, publicParams)
+			if err != nil {
+				return bn254.GT{}, err
+			}
+			return decrypted.Message, nil
+		},
+		Equal: selftest.EqualGT,
+	}, nil
+}
@@ -3,6 +3,7 @@ package bb04_sibe
 import (
 	"fmt"
 	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/mmsyan/GoPairingBasedCryptography/internal/selftest"
 	"math/big"
 	"testing"
 )
@@ -357,3 +358,25 @@ func TestBB04sIbe5(t *testing.T) {
 
 	fmt.Println("\n✓ 测试通过：所有边界情况和特殊身份值都能正常工作")
 }
+
+// TestBB04sIbeZeroize 测试 Zeroize 清除主密钥对，且清除后生成的私钥无法完成自检的加解密往返
+func TestBB04sIbeZeroize(t *testing.T) {
+	instance, err := NewBB04sIBEInstance()
+	if err != nil {
+		t.Fatal(err)
+	}
+	publicParams, err := instance.SetUp()
+	if err != nil {
+		t.Fatal(err)
+	}
+	instance.Zeroize()
+	if !instance.x.IsZero() || !instance.y.IsZero() {
+		t.Fatal("Zeroize 之后主密钥对未被清除")
+	}
+	err = selftest.Run("zeroized", func() (*selftest.RoundTrip[bn254.GT, *BB04sIBECiphertext], error) {
+		return keyRoundTrip(instance, publicParams)
+	})
+	if err == nil {
+		t.Fatal("清除主密钥对后生成的私钥仍能解密")
+	}
+}
@@ -18,7 +18,7 @@ package bf01_ibe
 // 与Boneh-Boyen方案的主要区别:
 //   - 使用Hash-to-Curve将身份映射到G2群元素
 //   - 采用混合加密方式,使用XOR掩码保护实际消息
-//   - 密文更加紧凑,适合加密任意长度的字节消息
+//   - 密文更加紧凑,适合加密不超过MaxMessageSize字节的消息
 
 import (
 	"crypto/rand"
@@ -27,6 +27,7 @@ import (
 	"github.com/consensys/gnark-crypto/ecc/bn254"
 	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
 	"github.com/mmsyan/GoPairingBasedCryptography/hash"
+	"github.com/mmsyan/GoPairingBasedCryptography/metrics"
 	"github.com/mmsyan/GoPairingBasedCryptography/utils"
 	"math/big"
 )
@@ -63,12 +64,17 @@ type BFIBESecretKey struct {
 }
 
 // BFIBEMessage 表示Boneh-Franklin IBE方案中的明文消息。
-// 明文是任意长度的字节数组,可以直接表示实际的消息内容。
+// 明文是长度不超过MaxMessageSize的字节数组,可以直接表示实际的消息内容。
 // 该方案使用混合加密,通过XOR操作保护消息。
 type BFIBEMessage struct {
 	Message []byte
 }
 
+// MaxMessageSize 是Encrypt支持的最大明文长度(字节)。
+// C2由明文与GT元素的编码逐字节异或得到,掩码长度即GT元素的编码长度,
+// 超出部分无法被掩码覆盖,因此Encrypt会拒绝更长的明文。
+const MaxMessageSize = bn254.SizeOfGT
+
 // BFIBECiphertext 表示Boneh-Franklin IBE方案中的密文。
 // 密文由两个部分组成:
 //   - C1: G1群上的元素,为g^r,其中r是随机数
@@ -91,7 +97,7 @@ func NewBFIBEInstance() (*BFIBEInstance, error) {
 	var err error
 	_, err = x.SetRandom()
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate identity based encryption instance")
+		return nil, fmt.Errorf("failed to generate identity based encryption instance: %w", err)
 	}
 	return &BFIBEInstance{x, []byte("ibe Encryption")}, nil
 }
@@ -152,24 +158,28 @@ func (instance *BFIBEInstance) KeyGenerate(identity *BFIBEIdentity, publicParams
 //
 // 返回值:
 //   - *BFIBECiphertext: 加密后的密文,包含C1(G1元素)和C2(字节数组)
-//   - error: 如果加密过程失败,返回错误信息
+//   - error: 如果明文长度超过MaxMessageSize或加密过程失败,返回错误信息
 func (instance *BFIBEInstance) Encrypt(identity *BFIBEIdentity, message *BFIBEMessage, publicParams *BFIBEPublicParams) (*BFIBECiphertext, error) {
+	if len(message.Message) > MaxMessageSize {
+		return nil, fmt.Errorf("message exceeds maximum length of %d bytes", MaxMessageSize)
+	}
+
 	// qid = hashToCurve(id) in G2
 	qid := hash.ToG2(identity.Id)
 
 	// r <- Zq
 	r, err := rand.Int(rand.Reader, ecc.BN254.ScalarField())
 	if err != nil {
-		return nil, fmt.Errorf("failed to encrypt message")
+		return nil, fmt.Errorf("failed to encrypt message: %w", err)
 	}
 	// c1 = g^r
 	c1 := *new(bn254.G1Affine).ScalarMultiplicationBase(r)
 
 	// c2 = m xor H2(gid)
 	// gid = e(g^x, qid)^r
-	eGxQid, err := bn254.Pair([]bn254.G1Affine{publicParams.g1x}, []bn254.G2Affine{qid})
+	eGxQid, err := metrics.Pair([]bn254.G1Affine{publicParams.g1x}, []bn254.G2Affine{qid})
 	if err != nil {
-		return nil, fmt.Errorf("failed to encrypt message")
+		return nil, fmt.Errorf("failed to encrypt message: %w", err)
 	}
 	gid := *(new(bn254.GT).Exp(eGxQid, r))
 	gidBytes := hash.FromGT(gid)
@@ -199,9 +209,9 @@ func (instance *BFIBEInstance) Encrypt(identity *BFIBEIdentity, message *BFIBEMe
 //   - error: 如果解密失败,返回错误信息
 func (instance *BFIBEInstance) Decrypt(ciphertext *BFIBECiphertext, secretKey *BFIBESecretKey, publicParams *BFIBEPublicParams) (*BFIBEMessage, error) {
 	// gid = e(c1, sk) = e(g^r, qid^x)
-	gid, err := bn254.Pair([]bn254.G1Affine{ciphertext.C1}, []bn254.G2Affine{secretKey.sk})
+	gid, err := metrics.Pair([]bn254.G1Affine{ciphertext.C1}, []bn254.G2Affine{secretKey.sk})
 	if err != nil {
-		return nil, fmt.Errorf("failed to decrypt message")
+		return nil, fmt.Errorf("failed to decrypt message: %w", err)
 	}
 	gidBytes := hash.FromGT(gid)
 	return &BFIBEMessage{
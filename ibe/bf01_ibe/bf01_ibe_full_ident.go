@@ -0,0 +1,152 @@
+package bf01_ibe
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/mmsyan/GoPairingBasedCryptography/hash"
+	"github.com/mmsyan/GoPairingBasedCryptography/metrics"
+	"github.com/mmsyan/GoPairingBasedCryptography/utils"
+	"math/big"
+)
+
+// FullIdent 使用的随机串 σ 的长度(字节),与 H2 的输出长度一致
+const fullIdentSigmaSize = sha256.Size
+
+// BFIBECCACiphertext 表示Boneh-Franklin FullIdent(CCA安全)方案中的密文。
+// 密文由三个部分组成:
+//   - U: G1群上的元素,为g^r,其中r=H3(σ, M)
+//   - V: σ ⊕ H2(e(g1x, h(Id))^r),长度固定为32字节
+//   - W: M ⊕ H4(σ),与明文等长
+type BFIBECCACiphertext struct {
+	U bn254.G1Affine
+	V []byte
+	W []byte
+}
+
+// EncryptCCA 使用Fujisaki-Okamoto变换后的FullIdent算法对消息进行加密,
+// 提供适应性选择密文攻击(IND-ID-CCA)下的安全性。
+// 1. 选择随机串σ,计算r=H3(σ, M)
+// 2. 计算U=g^r
+// 3. 计算V=σ ⊕ H2(e(g1x, Qid)^r)
+// 4. 计算W=M ⊕ H4(σ)
+//
+// 与Encrypt不同,r由σ和M确定性导出,解密方可以重新计算r并检查U,
+// 从而拒绝任何被篡改的密文。H4采用计数器模式扩展,明文长度不受GT编码长度限制。
+//
+// 参数:
+//   - identity: 接收者的身份标识符
+//   - message: 要加密的明文消息(字节数组)
+//   - publicParams: 系统公共参数
+//
+// 返回值:
+//   - *BFIBECCACiphertext: 加密后的密文
+//   - error: 如果加密过程失败,返回错误信息
+func (instance *BFIBEInstance) EncryptCCA(identity *BFIBEIdentity, message *BFIBEMessage, publicParams *BFIBEPublicParams) (*BFIBECCACiphertext, error) {
+	// qid = hashToCurve(id) in G2
+	qid := hash.ToG2(identity.Id)
+
+	// σ <- {0,1}^n
+	sigma := make([]byte, fullIdentSigmaSize)
+	if _, err := rand.Read(sigma); err != nil {
+		return nil, fmt.Errorf("failed to encrypt message: %w", err)
+	}
+
+	// r = H3(σ, M)
+	r := fullIdentH3(sigma, message.Message)
+
+	// u = g^r
+	u := *new(bn254.G1Affine).ScalarMultiplicationBase(r)
+
+	// v = σ xor H2(e(g^x, qid)^r)
+	eGxQid, err := metrics.Pair([]bn254.G1Affine{publicParams.g1x}, []bn254.G2Affine{qid})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt message: %w", err)
+	}
+	gid := *new(bn254.GT).Exp(eGxQid, r)
+	v := utils.Xor(sigma, fullIdentH2(gid))
+
+	// w = m xor H4(σ)
+	w := utils.Xor(message.Message, fullIdentH4(sigma, len(message.Message)))
+
+	return &BFIBECCACiphertext{
+		U: u,
+		V: v,
+		W: w,
+	}, nil
+}
+
+// DecryptCCA 使用私钥对FullIdent密文进行解密,并验证密文的一致性。
+// 1. 计算σ=V ⊕ H2(e(U, sk))
+// 2. 计算M=W ⊕ H4(σ)
+// 3. 计算r=H3(σ, M),检查U=g^r,不相等则拒绝密文
+//
+// 参数:
+//   - ciphertext: 要解密的密文
+//   - secretKey: 用户的私钥
+//   - publicParams: 系统公共参数
+//
+// 返回值:
+//   - *BFIBEMessage: 解密后的明文消息(字节数组)
+//   - error: 如果密文格式错误或一致性检查失败,返回错误信息
+func (instance *BFIBEInstance) DecryptCCA(ciphertext *BFIBECCACiphertext, secretKey *BFIBESecretKey, publicParams *BFIBEPublicParams) (*BFIBEMessage, error) {
+	if len(ciphertext.V) != fullIdentSigmaSize {
+		return nil, fmt.Errorf("failed to decrypt message: invalid ciphertext")
+	}
+
+	// σ = v xor H2(e(u, sk))
+	gid, err := metrics.Pair([]bn254.G1Affine{ciphertext.U}, []bn254.G2Affine{secretKey.sk})
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt message: %w", err)
+	}
+	sigma := utils.Xor(ciphertext.V, fullIdentH2(gid))
+
+	// m = w xor H4(σ)
+	m := utils.Xor(ciphertext.W, fullIdentH4(sigma, len(ciphertext.W)))
+
+	// 检查 u == g^H3(σ, m)
+	r := fullIdentH3(sigma, m)
+	expectedU := new(bn254.G1Affine).ScalarMultiplicationBase(r)
+	if !expectedU.Equal(&ciphertext.U) {
+		return nil, fmt.Errorf("failed to decrypt message: invalid ciphertext")
+	}
+
+	return &BFIBEMessage{
+		Message: m,
+	}, nil
+}
+
+// fullIdentH2 将GT元素映射为32字节串
+func fullIdentH2(gid bn254.GT) []byte {
+	h := sha256.New()
+	h.Write([]byte("BF01 FullIdent H2"))
+	h.Write(hash.FromGT(gid))
+	return h.Sum(nil)
+}
+
+// fullIdentH3 将(σ, M)映射为Zq中的标量
+func fullIdentH3(sigma, message []byte) *big.Int {
+	h := sha256.New()
+	h.Write([]byte("BF01 FullIdent H3"))
+	h.Write(sigma)
+	h.Write(message)
+	r := hash.BytesToField(h.Sum(nil))
+	return r.BigInt(new(big.Int))
+}
+
+// fullIdentH4 以计数器模式将σ扩展为length字节的掩码
+func fullIdentH4(sigma []byte, length int) []byte {
+	mask := make([]byte, 0, length+sha256.Size)
+	var counter [4]byte
+	for i := uint32(0); len(mask) < length; i++ {
+		binary.BigEndian.PutUint32(counter[:], i)
+		h := sha256.New()
+		h.Write([]byte("BF01 FullIdent H4"))
+		h.Write(counter[:])
+		h.Write(sigma)
+		mask = h.Sum(mask)
+	}
+	return mask[:length]
+}
@@ -0,0 +1,97 @@
+package bf01_ibe
+
+import (
+	"bytes"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"strings"
+	"testing"
+)
+
+func TestBF01FullIdent(t *testing.T) {
+	identity, err := NewBF01Identity("alice@google.com")
+	if err != nil {
+		t.Fatalf("NewBF01Identity failed: %v", err)
+	}
+	instance, err := NewBFIBEInstance()
+	if err != nil {
+		t.Fatalf("NewBFIBEInstance failed: %v", err)
+	}
+	publicParams, err := instance.SetUp()
+	if err != nil {
+		t.Fatalf("SetUp failed: %v", err)
+	}
+	secretKey, err := instance.KeyGenerate(identity, publicParams)
+	if err != nil {
+		t.Fatalf("KeyGenerate failed: %v", err)
+	}
+
+	messages := []*BFIBEMessage{
+		{Message: []byte("")},
+		{Message: []byte("This is Alice's first secret.")},
+		// 超过 GT 编码长度的长消息
+		{Message: []byte(strings.Repeat("long message for FullIdent ", 100))},
+	}
+	for i, m := range messages {
+		ciphertext, err := instance.EncryptCCA(identity, m, publicParams)
+		if err != nil {
+			t.Fatalf("EncryptCCA failed at index %d: %v", i, err)
+		}
+		decrypted, err := instance.DecryptCCA(ciphertext, secretKey, publicParams)
+		if err != nil {
+			t.Fatalf("DecryptCCA failed at index %d: %v", i, err)
+		}
+		if !bytes.Equal(decrypted.Message, m.Message) {
+			t.Fatalf("decrypted wrong at index %d", i)
+		}
+	}
+}
+
+func TestBF01FullIdentRejectsTampering(t *testing.T) {
+	identity, _ := NewBF01Identity("alice@google.com")
+	otherIdentity, _ := NewBF01Identity("bob@google.com")
+	instance, _ := NewBFIBEInstance()
+	publicParams, _ := instance.SetUp()
+	secretKey, _ := instance.KeyGenerate(identity, publicParams)
+	otherSecretKey, _ := instance.KeyGenerate(otherIdentity, publicParams)
+
+	message := &BFIBEMessage{Message: []byte("Meeting scheduled for 3 PM.")}
+	ciphertext, err := instance.EncryptCCA(identity, message, publicParams)
+	if err != nil {
+		t.Fatalf("EncryptCCA failed: %v", err)
+	}
+
+	tamperedU := *ciphertext
+	_, _, g, _ := bn254.Generators()
+	tamperedU.U.Add(&ciphertext.U, &g)
+
+	tamperedV := *ciphertext
+	tamperedV.V = append([]byte(nil), ciphertext.V...)
+	tamperedV.V[0] ^= 1
+
+	tamperedW := *ciphertext
+	tamperedW.W = append([]byte(nil), ciphertext.W...)
+	tamperedW.W[len(tamperedW.W)-1] ^= 1
+
+	truncatedW := *ciphertext
+	truncatedW.W = ciphertext.W[:len(ciphertext.W)-1]
+
+	shortV := *ciphertext
+	shortV.V = ciphertext.V[:len(ciphertext.V)-1]
+
+	cases := map[string]*BFIBECCACiphertext{
+		"tampered U":  &tamperedU,
+		"tampered V":  &tamperedV,
+		"tampered W":  &tamperedW,
+		"truncated W": &truncatedW,
+		"short V":     &shortV,
+	}
+	for name, ct := range cases {
+		if _, err := instance.DecryptCCA(ct, secretKey, publicParams); err == nil {
+			t.Errorf("%s: expected DecryptCCA to reject the ciphertext", name)
+		}
+	}
+
+	if _, err := instance.DecryptCCA(ciphertext, otherSecretKey, publicParams); err == nil {
+		t.Error("expected DecryptCCA to reject decryption with a wrong identity key")
+	}
+}
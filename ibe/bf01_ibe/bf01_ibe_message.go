@@ -0,0 +1,32 @@
+package bf01_ibe
+
+import (
+	"fmt"
+	"github.com/mmsyan/GoPairingBasedCryptography/serialization"
+)
+
+var _ serialization.Message = (*BFIBEMessage)(nil)
+
+// Bytes 返回消息字节的副本
+//
+// 返回值:
+//   - []byte: 消息字节
+//   - error: 始终为 nil
+func (m *BFIBEMessage) Bytes() ([]byte, error) {
+	return append([]byte(nil), m.Message...), nil
+}
+
+// SetBytes 把消息设置为 data 的副本
+//
+// 参数:
+//   - data: 消息字节
+//
+// 返回值:
+//   - error: 数据超过 MaxMessageSize 时返回错误
+func (m *BFIBEMessage) SetBytes(data []byte) error {
+	if len(data) > MaxMessageSize {
+		return fmt.Errorf("message length %d exceeds %d bytes", len(data), MaxMessageSize)
+	}
+	m.Message = append([]byte(nil), data...)
+	return nil
+}
@@ -0,0 +1,14 @@
+package bf01_ibe
+
+import (
+	"github.com/mmsyan/GoPairingBasedCryptography/internal/msgtest"
+	"testing"
+)
+
+// TestBFIBEMessageBytes 检查 BFIBEMessage 的 Bytes/SetBytes 往返，超过 MaxMessageSize 的数据被拒绝
+func TestBFIBEMessageBytes(t *testing.T) {
+	msgtest.CheckRoundTrip(t, new(BFIBEMessage), []byte{}, []byte("attack at dawn"))
+	if err := new(BFIBEMessage).SetBytes(make([]byte, MaxMessageSize+1)); err == nil {
+		t.Error("超过 MaxMessageSize 的数据应被拒绝")
+	}
+}
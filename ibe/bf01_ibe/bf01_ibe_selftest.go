@@ -0,0 +1,56 @@
+package bf01_ibe
+
+import (
+	"bytes"
+	"crypto/rand"
+	"github.com/mmsyan/GoPairingBasedCryptography/internal/selftest"
+)
+
+// SelfTest 执行一次最小的 SetUp → KeyGenerate → Encrypt → Decrypt 往返，
+// 解密结果与随机消息不一致时返回错误。可在服务启动时调用，尽早发现构建或运行环境的问题。
+//
+// 返回值:
+//   - error: 任一步骤失败或解密结果与原始消息不一致时返回错误
+func SelfTest() error {
+	return selftest.Run("bf01 ibe", selfTestRoundTrip)
+}
+
+// selfTestRoundTrip 生成实例、公共参数与自检身份的私钥，并返回绑定它们的加密与解密闭包
+func selfTestRoundTrip() (*selftest.RoundTrip[[]byte, *BFIBECiphertext], error) {
+	instance, err := NewBFIBEInstance()
+	if err != nil {
+		return nil, err
+	}
+	publicParams, err := instance.SetUp()
+	if err != nil {
+		return nil, err
+	}
+	identity, err := NewBF01Identity("self-test@localhost")
+	if err != nil {
+		return nil, err
+	}
+	secretKey, err := instance.KeyGenerate(identity, publicParams)
+	if err != nil {
+		return nil, err
+	}
+	return &selftest.RoundTrip[[]byte, *BFIBECiphertext]{
+		NewMessage: func() ([]byte, error) {
+			m := make([]byte, 32)
+			if _, err := rand.Read(m); err != nil {
+				return nil, err
+			}
+			return m, nil
+		},
+		Encrypt: func(m []byte) (*BFIBECiphertext, error) {
+			return instance.Encrypt(identity, &BFIBEMessage{Message: m}, publicParams)
+		},
+		Decrypt: func(ciphertext *BFIBECiphertext) ([]byte, error) {
+			decrypted, err := instance.Decrypt(ciphertext, secretKey, publicParams)
+			if err != nil {
+				return nil, err
+			}
+			return decrypted.Message, nil
+		},
+		Equal: bytes.Equal,
+	}, nil
+}
@@ -103,21 +103,55 @@ func TestBF01IBE3(t *testing.T) {
 	message := &BFIBEMessage{
 		Message: []byte("hajimilaluomeiduoaxigaaxsajdhfsgbhjnashsdgvbjnhvcfdxrcfghajimilaluomeiduoaxigaaxsajdhfsgbhjnashsdgvbjnhvcfdxrcfg hfdrxcftvgbhnhbgvfctdrtfvgbhj nhbgvfcdrhajimilaluomeiduoaxigaaxsajdhfsgbhjnashsdgvbjnhvcfdxrcfg hfdrxcftvgbhnhbgvfctdrtfvgbhj nhbgvfcdrhajimilaluomeiduoaxigaaxsajdhfsgbhjnashsdgvbjnhvcfdxrcfg hfdrxcftvgbhnhbgvfctdrtfvgbhj nhbgvfcdrhajimilaluomeiduoaxigaaxsajdhfsgbhjnashsdgvbjnhvcfdxrcfg hfdrxcftvgbhnhbgvfctdrtfvgbhj nhbgvfcdrhajimilaluomeiduoaxigaaxsajdhfsgbhjnashsdgvbjnhvcfdxrcfg hfdrxcftvgbhnhbgvfctdrtfvgbhj nhbgvfcdrhajimilaluomeiduoaxigaaxsajdhfsgbhjnashsdgvbjnhvcfdxrcfg hfdrxcftvgbhnhbgvfctdrtfvgbhj nhbgvfcdrhajimilaluomeiduoaxigaaxsajdhfsgbhjnashsdgvbjnhvcfdxrcfg hfdrxcftvgbhnhbgvfctdrtfvgbhj nhbgvfcdrhajimilaluomeiduoaxigaaxsajdhfsgbhjnashsdgvbjnhvcfdxrcfg hfdrxcftvgbhnhbgvfctdrtfvgbhj nhbgvfcdrhajimilaluomeiduoaxigaaxsajdhfsgbhjnashsdgvbjnhvcfdxrcfg hfdrxcftvgbhnhbgvfctdrtfvgbhj nhbgvfcdrhajimilaluomeiduoaxigaaxsajdhfsgbhjnashsdgvbjnhvcfdxrcfg hfdrxcftvgbhnhbgvfctdrtfvgbhj nhbgvfcdrhajimilaluomeiduoaxigaaxsajdhfsgbhjnashsdgvbjnhvcfdxrcfg hfdrxcftvgbhnhbgvfctdrtfvgbhj nhbgvfcdrhajimilaluomeiduoaxigaaxsajdhfsgbhjnashsdgvbjnhvcfdxrcfg hfdrxcftvgbhnhbgvfctdrtfvgbhj nhbgvfcdrhajimilaluomeiduoaxigaaxsajdhfsgbhjnashsdgvbjnhvcfdxrcfg hfdrxcftvgbhnhbgvfctdrtfvgbhj nhbgvfcdrhajimilaluomeiduoaxigaaxsajdhfsgbhjnashsdgvbjnhvcfdxrcfg hfdrxcftvgbhnhbgvfctdrtfvgbhj nhbgvfcdrhajimilaluomeiduoaxigaaxsajdhfsgbhjnashsdgvbjnhvcfdxrcfg hfdrxcftvgbhnhbgvfctdrtfvgbhj nhbgvfcdrhajimilaluomeiduoaxigaaxsajdhfsgbhjnashsdgvbjnhvcfdxrcfg hfdrxcftvgbhnhbgvfctdrtfvgbhj nhbgvfcdrhajimilaluomeiduoaxigaaxsajdhfsgbhjnashsdgvbjnhvcfdxrcfg hfdrxcftvgbhnhbgvfctdrtfvgbhj nhbgvfcdrhajimilaluomeiduoaxigaaxsajdhfsgbhjnashsdgvbjnhvcfdxrcfg hfdrxcftvgbhnhbgvfctdrtfvgbhj nhbgvfcdrhajimilaluomeiduoaxigaaxsajdhfsgbhjnashsdgvbjnhvcfdxrcfg hfdrxcftvgbhnhbgvfctdrtfvgbhj nhbgvfcdrhajimilaluomeiduoaxigaaxsajdhfsgbhjnashsdgvbjnhvcfdxrcfg hfdrxcftvgbhnhbgvfctdrtfvgbhj nhbgvfcdrhajimilaluomeiduoaxigaaxsajdhfsgbhjnashsdgvbjnhvcfdxrcfg hfdrxcftvgbhnhbgvfctdrtfvgbhj nhbgvfcdrhajimilaluomeiduoaxigaaxsajdhfsgbhjnashsdgvbjnhvcfdxrcfg hfdrxcftvgbhnhbgvfctdrtfvgbhj nhbgvfcdrhajimilaluomeiduoaxigaaxsajdhfsgbhjnashsdgvbjnhvcfdxrcfg hfdrxcftvgbhnhbgvfctdrtfvgbhj nhbgvfcdrhajimilaluomeiduoaxigaaxsajdhfsgbhjnashsdgvbjnhvcfdxrcfg hfdrxcftvgbhnhbgvfctdrtfvgbhj nhbgvfcdrhajimilaluomeiduoaxigaaxsajdhfsgbhjnashsdgvbjnhvcfdxrcfg hfdrxcftvgbhnhbgvfctdrtfvgbhj nhbgvfcdrhajimilaluomeiduoaxigaaxsajdhfsgbhjnashsdgvbjnhvcfdxrcfg hfdrxcftvgbhnhbgvfctdrtfvgbhj nhbgvfcdrhajimilaluomeiduoaxigaaxsajdhfsgbhjnashsdgvbjnhvcfdxrcfg hfdrxcftvgbhnhbgvfctdrtfvgbhj nhbgvfcdrhajimilaluomeiduoaxigaaxsajdhfsgbhjnashsdgvbjnhvcfdxrcfg hfdrxcftvgbhnhbgvfctdrtfvgbhj nhbgvfcdrhajimilaluomeiduoaxigaaxsajdhfsgbhjnashsdgvbjnhvcfdxrcfg hfdrxcftvgbhnhbgvfctdrtfvgbhj nhbgvfcdrhajimilaluomeiduoaxigaaxsajdhfsgbhjnashsdgvbjnhvcfdxrcfg hfdrxcftvgbhnhbgvfctdrtfvgbhj nhbgvfcdrhajimilaluomeiduoaxigaaxsajdhfsgbhjnashsdgvbjnhvcfdxrcfg hfdrxcftvgbhnhbgvfctdrtfvgbhj nhbgvfcdrhajimilaluomeiduoaxigaaxsajdhfsgbhjnashsdgvbjnhvcfdxrcfg hfdrxcftvgbhnhbgvfctdrtfvgbhj nhbgvfcdrhajimilaluomeiduoaxigaaxsajdhfsgbhjnashsdgvbjnhvcfdxrcfg hfdrxcftvgbhnhbgvfctdrtfvgbhj nhbgvfcdrhajimilaluomeiduoaxigaaxsajdhfsgbhjnashsdgvbjnhvcfdxrcfg hfdrxcftvgbhnhbgvfctdrtfvgbhj nhbgvfcdrhajimilaluomeiduoaxigaaxsajdhfsgbhjnashsdgvbjnhvcfdxrcfg hfdrxcftvgbhnhbgvfctdrtfvgbhj nhbgvfcdrhajimilaluomeiduoaxigaaxsajdhfsgbhjnashsdgvbjnhvcfdxrcfg hfdrxcftvgbhnhbgvfctdrtfvgbhj nhbgvfcdrhajimilaluomeiduoaxigaaxsajdhfsgbhjnashsdgvbjnhvcfdxrcfg hfdrxcftvgbhnhbgvfctdrtfvgbhj nhbgvfcdrhajimilaluomeiduoaxigaaxsajdhfsgbhjnashsdgvbjnhvcfdxrcfg hfdrxcftvgbhnhbgvfctdrtfvgbhj nhbgvfcdrhajimilaluomeiduoaxigaaxsajdhfsgbhjnashsdgvbjnhvcfdxrcfg hfdrxcftvgbhnhbgvfctdrtfvgbhj nhbgvfcdrhajimilaluomeiduoaxigaaxsajdhfsgbhjnashsdgvbjnhvcfdxrcfg hfdrxcftvgbhnhbgvfctdrtfvgbhj nhbgvfcdrhajimilaluomeiduoaxigaaxsajdhfsgbhjnashsdgvbjnhvcfdxrcfg hfdrxcftvgbhnhbgvfctdrtfvgbhj nhbgvfcdrhajimilaluomeiduoaxigaaxsajdhfsgbhjnashsdgvbjnhvcfdxrcfg hfdrxcftvgbhnhbgvfctdrtfvgbhj nhbgvfcdrhajimilaluomeiduoaxigaaxsajdhfsgbhjnashsdgvbjnhvcfdxrcfg hfdrxcftvgbhnhbgvfctdrtfvgbhj nhbgvfcdrhajimilaluomeiduoaxigaaxsajdhfsgbhjnashsdgvbjnhvcfdxrcfg hfdrxcftvgbhnhbgvfctdrtfvgbhj nhbgvfcdrhajimilaluomeiduoaxigaaxsajdhfsgbhjnashsdgvbjnhvcfdxrcfg hfdrxcftvgbhnhbgvfctdrtfvgbhj nhbgvfcdrhajimilaluomeiduoaxigaaxsajdhfsgbhjnashsdgvbjnhvcfdxrcfg hfdrxcftvgbhnhbgvfctdrtfvgbhj nhbgvfcdrhajimilaluomeiduoaxigaaxsajdhfsgbhjnashsdgvbjnhvcfdxrcfg hfdrxcftvgbhnhbgvfctdrtfvgbhj nhbgvfcdrhajimilaluomeiduoaxigaaxsajdhfsgbhjnashsdgvbjnhvcfdxrcfg hfdrxcftvgbhnhbgvfctdrtfvgbhj nhbgvfcdrhajimilaluomeiduoaxigaaxsajdhfsgbhjnashsdgvbjnhvcfdxrcfg hfdrxcftvgbhnhbgvfctdrtfvgbhj nhbgvfcdrhajimilaluomeiduoaxigaaxsajdhfsgbhjnashsdgvbjnhvcfdxrcfg hfdrxcftvgbhnhbgvfctdrtfvgbhj nhbgvfcdrhajimilaluomeiduoaxigaaxsajdhfsgbhjnashsdgvbjnhvcfdxrcfg hfdrxcftvgbhnhbgvfctdrtfvgbhj nhbgvfcdrhfdrxcftvgbhnhbgvfctdrtfvgbhj nhbgvfcdrctfvgbhnjhbgvqswdefrgthyjukhgfdsasdfghhgtfredwaswdfghhgtreasdfr"),
 	}
-	fmt.Println("测试不通过，因为明文长度太长导致异或步骤失效；建议对一个对称加密密钥进行")
 	instance, err := NewBFIBEInstance()
+	if err != nil {
+		t.Fatal(err)
+	}
 	publicParams, err := instance.SetUp()
-	secretKey, err := instance.KeyGenerate(identity, publicParams)
-	ciphertext, err := instance.Encrypt(identity, message, publicParams)
-	decryptedMessage, err := instance.Decrypt(ciphertext, secretKey, publicParams)
+	if err != nil {
+		t.Fatal(err)
+	}
 
-	fmt.Printf("message before encrypt: %s \n", string(message.Message))
-	fmt.Printf("message after decrypt: %s \n", string(decryptedMessage.Message))
-	if string(decryptedMessage.Message) != string(message.Message) {
-		t.Fatalf("decrypted wrong, %s", string(decryptedMessage.Message))
+	// 明文长度超过掩码长度时应直接拒绝,而不是生成无法正确解密的密文
+	if len(message.Message) <= MaxMessageSize {
+		t.Fatalf("test message should be longer than %d bytes", MaxMessageSize)
+	}
+	_, err = instance.Encrypt(identity, message, publicParams)
+	want := fmt.Sprintf("message exceeds maximum length of %d bytes", MaxMessageSize)
+	if err == nil || err.Error() != want {
+		t.Fatalf("expected error %q, got %v", want, err)
+	}
+}
 
+// TestBF01IBEMaxMessageSize 测试长度恰好为MaxMessageSize的明文可以正确加解密
+func TestBF01IBEMaxMessageSize(t *testing.T) {
+	identity, _ := NewBF01Identity("alice")
+	message := &BFIBEMessage{Message: make([]byte, MaxMessageSize)}
+	for i := range message.Message {
+		message.Message[i] = byte(i)
 	}
 
+	instance, err := NewBFIBEInstance()
 	if err != nil {
 		t.Fatal(err)
 	}
+	publicParams, err := instance.SetUp()
+	if err != nil {
+		t.Fatal(err)
+	}
+	secretKey, err := instance.KeyGenerate(identity, publicParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ciphertext, err := instance.Encrypt(identity, message, publicParams)
+	if err != nil {
+		t.Fatalf("Encrypt failed for at-limit message: %v", err)
+	}
+	decryptedMessage, err := instance.Decrypt(ciphertext, secretKey, publicParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(decryptedMessage.Message) != string(message.Message) {
+		t.Fatal("decrypted at-limit message does not match")
+	}
 }
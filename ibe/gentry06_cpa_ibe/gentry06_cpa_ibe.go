@@ -18,9 +18,12 @@ package gentry06_cpa_ibe
 // 该实现基于的方案是IND-ID-CPA安全的
 
 import (
+	"errors"
 	"fmt"
 	"github.com/consensys/gnark-crypto/ecc/bn254"
 	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/mmsyan/GoPairingBasedCryptography/metrics"
+	"github.com/mmsyan/GoPairingBasedCryptography/utils"
 	"math/big"
 )
 
@@ -60,6 +63,7 @@ type Gentry06CPAIBESecretKey struct {
 // 明文 M 被编码为 GT 群（配对运算的目标群）上的一个元素。
 type Gentry06CPAIBEMessage struct {
 	Message bn254.GT // 明文 M 属于 GT
+	sealed  []byte   // SetBytes 以会话密钥加密的字节数据
 }
 
 // Gentry06CPAIBECiphertext 表示 IBE 方案中的密文 $C = (u, v, w)$。
@@ -112,7 +116,7 @@ func (instance *Gentry06CPAIBEInstance) SetUp() (*Gentry06CPAIBEPublicParams, er
 	h := new(bn254.G2Affine).ScalarMultiplicationBase(hRandom.BigInt(new(big.Int)))
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to set up")
+		return nil, fmt.Errorf("failed to set up: %w", err)
 	}
 	return &Gentry06CPAIBEPublicParams{
 		g1:      g1,
@@ -134,8 +138,10 @@ func (instance *Gentry06CPAIBEInstance) SetUp() (*Gentry06CPAIBEPublicParams, er
 //   - *Gentry06IBESecretKey: 生成的私钥
 //   - error: 如果密钥生成失败或 ID = alpha，返回错误信息
 func (instance *Gentry06CPAIBEInstance) KeyGenerate(identity *Gentry06CPAIBEIdentity, publicParams *Gentry06CPAIBEPublicParams) (*Gentry06CPAIBESecretKey, error) {
-	var err error
-	rid, err := new(fr.Element).SetRandom()                                               // 1. 随机选取 r_ID 属于 Zp
+	rid, err := utils.RandomNonZeroScalar() // 1. 随机选取 r_ID 属于 Zp
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate key: %w", err)
+	}
 	negRid := new(fr.Element).Neg(rid)                                                    // 计算 -r_ID
 	g2InvRid := new(bn254.G2Affine).ScalarMultiplicationBase(negRid.BigInt(new(big.Int))) // 计算 $g_2^{-r_{ID}}$
 
@@ -144,14 +150,11 @@ func (instance *Gentry06CPAIBEInstance) KeyGenerate(identity *Gentry06CPAIBEIden
 	alphaMinusId := new(fr.Element).Sub(&instance.alpha, &identity.Id) // 3. 计算 $\alpha - ID$
 	invAlphaMinusId := new(fr.Element).Inverse(alphaMinusId)           // 计算 $1 / (\alpha - ID)$
 	if invAlphaMinusId.IsZero() {
-		return nil, fmt.Errorf("your identity is invalid (ID equals alpha)") // ID = alpha 时无逆元
+		return nil, fmt.Errorf("your identity is invalid: %w", ErrIdentityEqualsMaster) // ID = alpha 时无逆元
 	}
 
 	// 4. 计算 $h_{ID} = (h g_2^{-r_{ID}})^{\frac{1}{\alpha - ID}}$
 	hid := new(bn254.G2Affine).ScalarMultiplication(hAddG2InvRid, invAlphaMinusId.BigInt(new(big.Int)))
-	if err != nil {
-		return nil, fmt.Errorf("failed to generate key")
-	}
 	return &Gentry06CPAIBESecretKey{
 		rid: *rid,
 		hid: *hid,
@@ -170,8 +173,10 @@ func (instance *Gentry06CPAIBEInstance) KeyGenerate(identity *Gentry06CPAIBEIden
 //   - *Gentry06IBECiphertext: 加密后的密文
 //   - error: 如果加密失败，返回错误信息
 func (instance *Gentry06CPAIBEInstance) Encrypt(message *Gentry06CPAIBEMessage, identity *Gentry06CPAIBEIdentity, publicParams *Gentry06CPAIBEPublicParams) (*Gentry06CPAIBECiphertext, error) {
-	var err error
-	s, err := new(fr.Element).SetRandom() // 1. 随机选取 s 属于 Zp
+	s, err := utils.RandomNonZeroScalar() // 1. 随机选取 s 属于 Zp
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt message: %w", err)
+	}
 
 	// 计算 $g_1^{\alpha s}$
 	g1AlphaS := new(bn254.G1Affine).ScalarMultiplication(&publicParams.g1Alpha, s.BigInt(new(big.Int)))
@@ -183,18 +188,22 @@ func (instance *Gentry06CPAIBEInstance) Encrypt(message *Gentry06CPAIBEMessage,
 
 	// 2. 计算 $u = g_1^{\alpha s} \cdot g_1^{-s \cdot ID}$
 	u := new(bn254.G1Affine).Add(g1AlphaS, g1NegSId)
+	if u.IsInfinity() {
+		// s 非零时 u 为单位元当且仅当 ID = alpha，重新选取 s 也无济于事
+		return nil, fmt.Errorf("failed to encrypt message: %w", ErrIdentityEqualsMaster)
+	}
 
 	// 3. 计算 $v = e(g_1, g_2)^s$
-	eG1G2, err := bn254.Pair([]bn254.G1Affine{publicParams.g1}, []bn254.G2Affine{publicParams.g2})
+	eG1G2, err := metrics.Pair([]bn254.G1Affine{publicParams.g1}, []bn254.G2Affine{publicParams.g2})
 	if err != nil {
-		return nil, fmt.Errorf("failed to encrypt message")
+		return nil, fmt.Errorf("failed to encrypt message: %w", err)
 	}
 	v := new(bn254.GT).Exp(eG1G2, s.BigInt(new(big.Int)))
 
 	// 4. 计算 $w = M \cdot e(g_1, h)^{-s}$
-	eG1H, err := bn254.Pair([]bn254.G1Affine{publicParams.g1}, []bn254.G2Affine{publicParams.h})
+	eG1H, err := metrics.Pair([]bn254.G1Affine{publicParams.g1}, []bn254.G2Affine{publicParams.h})
 	if err != nil {
-		return nil, fmt.Errorf("failed to encrypt message")
+		return nil, fmt.Errorf("failed to encrypt message: %w", err)
 	}
 	negS := new(fr.Element).Neg(s)
 	// 计算 $e(g_1, h)^{-s}$
@@ -226,11 +235,31 @@ func (instance *Gentry06CPAIBEInstance) Encrypt(message *Gentry06CPAIBEMessage,
 //   - *Gentry06IBEMessage: 解密后的明文消息
 //   - error: 如果解密失败，返回错误信息
 func (instance *Gentry06CPAIBEInstance) Decrypt(ciphertext *Gentry06CPAIBECiphertext, secretKey *Gentry06CPAIBESecretKey, publicParams *Gentry06CPAIBEPublicParams) (*Gentry06CPAIBEMessage, error) {
-	var err error
+	m, err := instance.DecryptRaw(ciphertext, secretKey, publicParams)
+	if err != nil {
+		return nil, err
+	}
+	return &Gentry06CPAIBEMessage{
+		Message: m,
+	}, nil
+}
+
+// DecryptRaw 与 Decrypt 的计算相同，但直接返回恢复出的 GT 元素 $w \cdot e(u, h_{ID}) \cdot v^{r_{ID}}$。
+// CPA 版本没有完整性校验，错误私钥的解密结果只能通过比较群元素发现，该函数便于测试中直接用 Equal 比较。
+//
+// 参数:
+//   - ciphertext: 要解密的密文
+//   - secretKey: 用户的私钥
+//   - publicParams: 系统公共参数
+//
+// 返回值:
+//   - bn254.GT: 计算得到的 GT 元素；配对失败时为零值
+//   - error: 如果配对计算失败，返回错误信息
+func (instance *Gentry06CPAIBEInstance) DecryptRaw(ciphertext *Gentry06CPAIBECiphertext, secretKey *Gentry06CPAIBESecretKey, publicParams *Gentry06CPAIBEPublicParams) (bn254.GT, error) {
 	// 1. 计算 $e(u, h_{ID})$
-	eUHid, err := bn254.Pair([]bn254.G1Affine{ciphertext.u}, []bn254.G2Affine{secretKey.hid})
+	eUHid, err := metrics.Pair([]bn254.G1Affine{ciphertext.u}, []bn254.G2Affine{secretKey.hid})
 	if err != nil {
-		return nil, fmt.Errorf("failed to decrypt ciphertext")
+		return bn254.GT{}, fmt.Errorf("failed to decrypt ciphertext: %w", err)
 	}
 	// 2. 计算 $v^{r_{ID}}$
 	vRid := new(bn254.GT).Exp(ciphertext.v, secretKey.rid.BigInt(new(big.Int)))
@@ -241,14 +270,22 @@ func (instance *Gentry06CPAIBEInstance) Decrypt(ciphertext *Gentry06CPAIBECipher
 	// m = (w * e(u, h_ID)) * v^r_ID
 	m.Mul(m, vRid)
 
-	return &Gentry06CPAIBEMessage{
-		Message: *m,
-	}, nil
+	return *m, nil
 }
 
 // NewGentry06CPAIBEIdentity 将大整数类型的 ID 转换为 IBE 方案使用的 fr.Element 身份结构体。
+// identity 按标量域的阶 r 取模，负数映射到 [0, r) 中的正剩余，x 与 x + r 表示同一个身份；
+// 约减后等于主密钥 alpha 的身份在 KeyGenerate 时以 ErrIdentityEqualsMaster 拒绝。
 func NewGentry06CPAIBEIdentity(identity *big.Int) (*Gentry06CPAIBEIdentity, error) {
+	if identity == nil {
+		return nil, errors.New("identity must not be nil")
+	}
 	return &Gentry06CPAIBEIdentity{
-		Id: *new(fr.Element).SetBigInt(identity), // 将 big.Int 映射到 Zp 域元素
+		Id: *new(fr.Element).SetBigInt(identity), // 将 big.Int 映射到 Zp 域元素（取模 r）
 	}, nil
 }
+
+// Zeroize 将实例持有的主密钥 alpha 覆写为零；清除范围的限制见 README 的 Key Zeroization。
+func (instance *Gentry06CPAIBEInstance) Zeroize() {
+	instance.alpha.SetZero()
+}
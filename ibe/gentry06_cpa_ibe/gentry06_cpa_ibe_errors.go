@@ -0,0 +1,6 @@
+package gentry06_cpa_ibe
+
+import "errors"
+
+// ErrIdentityEqualsMaster 表示身份 ID 恰好等于主密钥 α，此时 1/(α - ID) 不存在，无法为该身份生成私钥
+var ErrIdentityEqualsMaster = errors.New("identity equals master secret alpha")
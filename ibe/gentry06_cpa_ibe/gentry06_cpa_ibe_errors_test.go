@@ -0,0 +1,22 @@
+package gentry06_cpa_ibe
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestKeyGenerateIdentityEqualsMaster 身份等于主密钥 alpha 时，密钥生成错误应包装 ErrIdentityEqualsMaster
+func TestKeyGenerateIdentityEqualsMaster(t *testing.T) {
+	instance, err := NewGentry06CPAIBEInstance()
+	if err != nil {
+		t.Fatal("创建IBE实例失败:", err)
+	}
+	publicParams, err := instance.SetUp()
+	if err != nil {
+		t.Fatal("系统初始化失败:", err)
+	}
+	_, err = instance.KeyGenerate(&Gentry06CPAIBEIdentity{Id: instance.alpha}, publicParams)
+	if !errors.Is(err, ErrIdentityEqualsMaster) {
+		t.Fatalf("错误应包装 ErrIdentityEqualsMaster: %v", err)
+	}
+}
@@ -0,0 +1,47 @@
+package gentry06_cpa_ibe
+
+import (
+	"errors"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"math/big"
+	"testing"
+)
+
+// TestIdentityCongruentKeys 身份按 r 约减：为 x + r 生成的私钥能解密发给 x 的密文，约减后等于 alpha 的身份在密钥生成时被拒绝
+func TestIdentityCongruentKeys(t *testing.T) {
+	if _, err := NewGentry06CPAIBEIdentity(nil); err == nil {
+		t.Fatal("nil 身份应返回错误")
+	}
+	instance, err := NewGentry06CPAIBEInstance()
+	if err != nil {
+		t.Fatal(err)
+	}
+	publicParams, err := instance.SetUp()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	x := new(big.Int).Lsh(big.NewInt(1), 100)
+	identity, _ := NewGentry06CPAIBEIdentity(x)
+	congruent, _ := NewGentry06CPAIBEIdentity(new(big.Int).Add(x, fr.Modulus()))
+	secretKey, err := instance.KeyGenerate(congruent, publicParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, _ := new(bn254.GT).SetRandom()
+	ciphertext, err := instance.Encrypt(&Gentry06CPAIBEMessage{Message: *m}, identity, publicParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decrypted, err := instance.Decrypt(ciphertext, secretKey, publicParams)
+	if err != nil || !decrypted.Message.Equal(m) {
+		t.Fatalf("x + r 的私钥应能解密发给 x 的密文: %v", err)
+	}
+
+	alpha := instance.alpha.BigInt(new(big.Int))
+	master, _ := NewGentry06CPAIBEIdentity(alpha.Add(alpha, fr.Modulus()))
+	if _, err := instance.KeyGenerate(master, publicParams); !errors.Is(err, ErrIdentityEqualsMaster) {
+		t.Fatalf("错误应包装 ErrIdentityEqualsMaster: %v", err)
+	}
+}
@@ -0,0 +1,12 @@
+package gentry06_cpa_ibe
+
+import (
+	"github.com/mmsyan/GoPairingBasedCryptography/internal/msgtest"
+	"github.com/mmsyan/GoPairingBasedCryptography/serialization"
+	"testing"
+)
+
+// TestGentry06CPAIBEMessageBytes 检查 Gentry06CPAIBEMessage 的 Bytes/SetBytes 往返
+func TestGentry06CPAIBEMessageBytes(t *testing.T) {
+	msgtest.CheckGTRoundTrip(t, func() serialization.Message { return new(Gentry06CPAIBEMessage) })
+}
@@ -0,0 +1,60 @@
+package gentry06_cpa_ibe
+
+import (
+	"bytes"
+	"github.com/mmsyan/GoPairingBasedCryptography/serialization"
+	"math/big"
+	"testing"
+)
+
+// TestGentry06CPAIbeSealedBytes 用 serialization.SealBytes 加密字节：正确密钥恢复原文，错误密钥在 OpenBytes 时报错
+func TestGentry06CPAIbeSealedBytes(t *testing.T) {
+	instance, err := NewGentry06CPAIBEInstance()
+	if err != nil {
+		t.Fatal("创建IBE实例失败:", err)
+	}
+	publicParams, err := instance.SetUp()
+	if err != nil {
+		t.Fatal("系统初始化失败:", err)
+	}
+	alice, _ := NewGentry06CPAIBEIdentity(big.NewInt(1001))
+	bob, _ := NewGentry06CPAIBEIdentity(big.NewInt(1002))
+	aliceKey, err := instance.KeyGenerate(alice, publicParams)
+	if err != nil {
+		t.Fatal("密钥生成失败:", err)
+	}
+	bobKey, err := instance.KeyGenerate(bob, publicParams)
+	if err != nil {
+		t.Fatal("密钥生成失败:", err)
+	}
+
+	plaintext := []byte("hello, alice")
+	k, sealed, err := serialization.SealBytes(plaintext)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ciphertext, err := instance.Encrypt(&Gentry06CPAIBEMessage{Message: k}, alice, publicParams)
+	if err != nil {
+		t.Fatal("加密失败:", err)
+	}
+
+	decrypted, err := instance.Decrypt(ciphertext, aliceKey, publicParams)
+	if err != nil {
+		t.Fatal("解密失败:", err)
+	}
+	recovered, err := serialization.OpenBytes(decrypted.Message, sealed)
+	if err != nil {
+		t.Fatal("解码失败:", err)
+	}
+	if !bytes.Equal(recovered, plaintext) {
+		t.Fatal("解码消息与原始消息不匹配")
+	}
+
+	wrong, err := instance.Decrypt(ciphertext, bobKey, publicParams)
+	if err != nil {
+		t.Fatal("解密失败:", err)
+	}
+	if _, err := serialization.OpenBytes(wrong.Message, sealed); err == nil {
+		t.Fatal("错误密钥解密的结果应当解码失败")
+	}
+}
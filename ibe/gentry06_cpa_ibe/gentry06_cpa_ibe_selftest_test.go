@@ -0,0 +1,9 @@
+package gentry06_cpa_ibe
+
+import "testing"
+
+func TestSelfTest(t *testing.T) {
+	if err := SelfTest(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -3,6 +3,7 @@ package gentry06_cpa_ibe
 import (
 	"fmt"
 	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/mmsyan/GoPairingBasedCryptography/internal/selftest"
 	"math/big"
 	"testing"
 )
@@ -356,3 +357,76 @@ func TestGentry06CPAIbe5(t *testing.T) {
 
 	fmt.Println("\n✓ 测试通过：所有边界情况和特殊身份值都能正常工作")
 }
+
+// TestGentry06CPAIbeZeroize 测试 Zeroize 清除主密钥，且清除后生成的私钥无法完成自检的加解密往返
+func TestGentry06CPAIbeZeroize(t *testing.T) {
+	instance, err := NewGentry06CPAIBEInstance()
+	if err != nil {
+		t.Fatal(err)
+	}
+	publicParams, err := instance.SetUp()
+	if err != nil {
+		t.Fatal(err)
+	}
+	instance.Zeroize()
+	if !instance.alpha.IsZero() {
+		t.Fatal("Zeroize 之后主密钥 alpha 不为零")
+	}
+	err = selftest.Run("zeroized", func() (*selftest.RoundTrip[bn254.GT, *Gentry06CPAIBECiphertext], error) {
+		return keyRoundTrip(instance, publicParams)
+	})
+	if err == nil {
+		t.Fatal("清除主密钥后生成的私钥仍能解密")
+	}
+}
+
+// TestGentry06CPADecryptRaw 正确私钥的 DecryptRaw 结果等于明文，其他身份私钥的结果与之不同
+func TestGentry06CPADecryptRaw(t *testing.T) {
+	instance, err := NewGentry06CPAIBEInstance()
+	if err != nil {
+		t.Fatal(err)
+	}
+	publicParams, err := instance.SetUp()
+	if err != nil {
+		t.Fatal(err)
+	}
+	alice, err := NewGentry06CPAIBEIdentity(big.NewInt(123456))
+	if err != nil {
+		t.Fatal(err)
+	}
+	bob, err := NewGentry06CPAIBEIdentity(big.NewInt(654321))
+	if err != nil {
+		t.Fatal(err)
+	}
+	aliceKey, err := instance.KeyGenerate(alice, publicParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bobKey, err := instance.KeyGenerate(bob, publicParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := new(bn254.GT).SetRandom()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ciphertext, err := instance.Encrypt(&Gentry06CPAIBEMessage{Message: *m}, alice, publicParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	right, err := instance.DecryptRaw(ciphertext, aliceKey, publicParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wrong, err := instance.DecryptRaw(ciphertext, bobKey, publicParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !right.Equal(m) {
+		t.Fatal("正确私钥的 DecryptRaw 结果应等于明文")
+	}
+	if wrong.Equal(&right) {
+		t.Fatal("错误私钥的 DecryptRaw 结果不应等于正确结果")
+	}
+}
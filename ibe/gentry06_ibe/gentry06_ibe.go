@@ -18,9 +18,12 @@ package gentry06_ibe
 // 该方案通过使用额外的密钥组件 h2, h3 和密文组件 y, 以及哈希函数 h() 实现了 CCA 安全性。
 import (
 	"crypto/sha256"
+	"errors"
 	"fmt"
 	"github.com/consensys/gnark-crypto/ecc/bn254"
 	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/mmsyan/GoPairingBasedCryptography/metrics"
+	"github.com/mmsyan/GoPairingBasedCryptography/utils"
 	"math/big"
 )
 
@@ -61,6 +64,7 @@ type Gentry06IBESecretKey struct {
 // 明文 $M$ 被编码为 $G_T$ 群（配对运算的目标群）上的一个元素。
 type Gentry06IBEMessage struct {
 	Message bn254.GT // 明文 $M \in G_T$
+	sealed  []byte   // SetBytes 以会话密钥加密的字节数据
 }
 
 // Gentry06IBECiphertext 表示 IBE 方案中的密文 $C = (u, v, w, y)$ (CCA安全版本)。
@@ -69,11 +73,14 @@ type Gentry06IBEMessage struct {
 //   - $v$: $G_T$ 群上的元素。
 //   - $w$: $G_T$ 群上的元素，包含加密后的消息 $M$。
 //   - $y$: $G_T$ 群上的元素，用于 CCA 安全性检查。
+//
+// 此外密文还携带加密时所用公共参数的指纹，解密时据此拒绝在其他公共参数下生成的密文。
 type Gentry06IBECiphertext struct {
-	u bn254.G1Affine // $u \in G_1$
-	v bn254.GT       // $v \in G_T$
-	w bn254.GT       // $w \in G_T$
-	y bn254.GT       // $y \in G_T$
+	u                 bn254.G1Affine // $u \in G_1$
+	v                 bn254.GT       // $v \in G_T$
+	w                 bn254.GT       // $w \in G_T$
+	y                 bn254.GT       // $y \in G_T$
+	paramsFingerprint [32]byte       // 公共参数的 Fingerprint
 }
 
 // NewGentry06IBEInstance 创建一个新的 Gentry IBE 方案实例。
@@ -115,14 +122,14 @@ func (instance *Gentry06IBEInstance) SetUp() (*Gentry06IBEPublicParams, error) {
 		// 随机选取 $h_i$
 		hRandom, err := new(fr.Element).SetRandom()
 		if err != nil {
-			return nil, fmt.Errorf("failed to set up")
+			return nil, fmt.Errorf("failed to set up: %w", err)
 		}
 		h := new(bn254.G2Affine).ScalarMultiplicationBase(hRandom.BigInt(new(big.Int)))
 		hs[i] = *h
 	}
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to set up")
+		return nil, fmt.Errorf("failed to set up: %w", err)
 	}
 	return &Gentry06IBEPublicParams{
 		g1:      g1,
@@ -150,27 +157,27 @@ func (instance *Gentry06IBEInstance) KeyGenerate(identity *Gentry06IBEIdentity,
 	alphaMinusId := new(fr.Element).Sub(&instance.alpha, &identity.Id) // 1. 计算 $\alpha - ID$
 	invAlphaMinusId := new(fr.Element).Inverse(alphaMinusId)           // 计算 $\frac{1}{\alpha - ID}$
 	if invAlphaMinusId.IsZero() {
-		return nil, fmt.Errorf("your identity is invalid (ID equals alpha)") // $ID = \alpha$ 时无逆元
+		return nil, fmt.Errorf("your identity is invalid: %w", ErrIdentityEqualsMaster) // $ID = \alpha$ 时无逆元
 	}
 
 	for i := 0; i < 3; i++ {
-		rid, err := new(fr.Element).SetRandom()                                               // 1. 随机选取 $r_{(ID,i)} \in \mathbb{Z}_p$
+		rid, err := utils.RandomNonZeroScalar() // 1. 随机选取 $r_{(ID,i)} \in \mathbb{Z}_p$
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate key: %w", err)
+		}
 		negRid := new(fr.Element).Neg(rid)                                                    // 计算 $-r_{(ID,i)}$
 		g2InvRid := new(bn254.G2Affine).ScalarMultiplicationBase(negRid.BigInt(new(big.Int))) // 计算 $g_2^{-r_{(ID,i)}}$
 
 		hAddG2InvRid := new(bn254.G2Affine).Add(&publicParams.hs[i], g2InvRid) // 3. 计算 $h_i g_2^{-r_{(ID,i)}}$
 		// 4. 计算 $h_{(ID,i)} = (h_i g_2^{-r_{(ID,i)}})^{\frac{1}{\alpha - ID}}$
 		hid := new(bn254.G2Affine).ScalarMultiplication(hAddG2InvRid, invAlphaMinusId.BigInt(new(big.Int)))
-		if err != nil {
-			return nil, fmt.Errorf("failed to generate key")
-		}
 
 		rids[i] = *rid
 		hids[i] = *hid
 	}
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate key")
+		return nil, fmt.Errorf("failed to generate key: %w", err)
 	}
 
 	return &Gentry06IBESecretKey{
@@ -190,33 +197,58 @@ func (instance *Gentry06IBEInstance) KeyGenerate(identity *Gentry06IBEIdentity,
 //   - *Gentry06IBECiphertext: 加密后的密文 $C=(u, v, w, y)$
 //   - error: 如果加密失败，返回错误信息
 func (instance *Gentry06IBEInstance) Encrypt(message *Gentry06IBEMessage, identity *Gentry06IBEIdentity, publicParams *Gentry06IBEPublicParams) (*Gentry06IBECiphertext, error) {
-	var err error
-	s, err := new(fr.Element).SetRandom() // 1. 随机选取 $s \in \mathbb{Z}_p$
+	s, err := utils.RandomNonZeroScalar() // 1. 随机选取 $s \in \mathbb{Z}_p$
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt message: %w", err)
+	}
+	return instance.EncryptWithRandomness(message, identity, publicParams, *s)
+}
 
+// EncryptWithRandomness 与 Encrypt 相同，但使用调用方提供的随机数 $s$ 而不是内部随机选取。
+// 仅用于已知答案测试（KAT）和与参考实现的交叉验证：重复使用同一个 $s$ 会破坏方案的安全性，
+// 生产环境必须使用 Encrypt。
+//
+// 参数:
+//   - message: 要加密的明文消息
+//   - identity: 接收者的身份标识符
+//   - publicParams: 系统公共参数
+//   - s: 加密随机数 $s \in \mathbb{Z}_p$
+//
+// 返回值:
+//   - *Gentry06IBECiphertext: 加密后的密文 $C=(u, v, w, y)$
+//   - error: 如果加密失败，或 $u = g_1^{s(\alpha - ID)}$ 退化为单位元（s 为零或 $ID = \alpha$），返回错误信息
+func (instance *Gentry06IBEInstance) EncryptWithRandomness(message *Gentry06IBEMessage, identity *Gentry06IBEIdentity, publicParams *Gentry06IBEPublicParams, s fr.Element) (*Gentry06IBECiphertext, error) {
 	// 计算 $g_1^{\alpha s}$
 	g1AlphaS := new(bn254.G1Affine).ScalarMultiplication(&publicParams.g1Alpha, s.BigInt(new(big.Int)))
 
 	// 计算 $g_1^{-s \cdot ID}$
-	sId := new(fr.Element).Mul(s, &identity.Id) // $s \cdot ID$
-	negSId := new(fr.Element).Neg(sId)          // $-s \cdot ID$
+	sId := new(fr.Element).Mul(&s, &identity.Id) // $s \cdot ID$
+	negSId := new(fr.Element).Neg(sId)           // $-s \cdot ID$
 	g1NegSId := new(bn254.G1Affine).ScalarMultiplicationBase(negSId.BigInt(new(big.Int)))
 
 	// 2. 计算 $u = g_1^{\alpha s} \cdot g_1^{-s \cdot ID} = g_1^{s(\alpha - ID)}$
 	u := *new(bn254.G1Affine).Add(g1AlphaS, g1NegSId)
+	if u.IsInfinity() {
+		if s.IsZero() {
+			return nil, fmt.Errorf("failed to encrypt message: %w", utils.ErrDegenerateRandomness)
+		}
+		// s 非零时 u 为单位元当且仅当 ID = alpha，重新选取 s 也无济于事
+		return nil, fmt.Errorf("failed to encrypt message: %w", ErrIdentityEqualsMaster)
+	}
 
 	// 3. 计算 $v = e(g_1, g_2)^s$
-	eG1G2, err := bn254.Pair([]bn254.G1Affine{publicParams.g1}, []bn254.G2Affine{publicParams.g2})
+	eG1G2, err := metrics.Pair([]bn254.G1Affine{publicParams.g1}, []bn254.G2Affine{publicParams.g2})
 	if err != nil {
-		return nil, fmt.Errorf("failed to encrypt message")
+		return nil, fmt.Errorf("failed to encrypt message: %w", err)
 	}
 	v := *new(bn254.GT).Exp(eG1G2, s.BigInt(new(big.Int)))
 
 	// 4. 计算 $w = M \cdot e(g_1, h_1)^{-s}$
-	eG1H, err := bn254.Pair([]bn254.G1Affine{publicParams.g1}, []bn254.G2Affine{publicParams.hs[0]})
+	eG1H, err := metrics.Pair([]bn254.G1Affine{publicParams.g1}, []bn254.G2Affine{publicParams.hs[0]})
 	if err != nil {
-		return nil, fmt.Errorf("failed to encrypt message")
+		return nil, fmt.Errorf("failed to encrypt message: %w", err)
 	}
-	negS := new(fr.Element).Neg(s)
+	negS := new(fr.Element).Neg(&s)
 	// 计算 $e(g_1, h_1)^{-s}$
 	w := *new(bn254.GT).Exp(eG1H, negS.BigInt(new(big.Int)))
 	// 计算 $w = M \cdot e(g_1, h_1)^{-s}$
@@ -227,20 +259,21 @@ func (instance *Gentry06IBEInstance) Encrypt(message *Gentry06IBEMessage, identi
 
 	// 6. 计算 $y = e(g_1, h_2)^s e(g_1, h_3)^{s\beta}$
 	// 计算 $e(g_1, h_2)^s$
-	eG1H2, err := bn254.Pair([]bn254.G1Affine{publicParams.g1}, []bn254.G2Affine{publicParams.hs[1]})
+	eG1H2, err := metrics.Pair([]bn254.G1Affine{publicParams.g1}, []bn254.G2Affine{publicParams.hs[1]})
 	eG1H2S := new(bn254.GT).Exp(eG1H2, s.BigInt(new(big.Int)))
 	// 计算 $e(g_1, h_3)^{s\beta}$
-	eG1H3, err := bn254.Pair([]bn254.G1Affine{publicParams.g1}, []bn254.G2Affine{publicParams.hs[2]})
-	sMulBeta := new(fr.Element).Mul(s, &beta)
+	eG1H3, err := metrics.Pair([]bn254.G1Affine{publicParams.g1}, []bn254.G2Affine{publicParams.hs[2]})
+	sMulBeta := new(fr.Element).Mul(&s, &beta)
 	eGH3SBeta := new(bn254.GT).Exp(eG1H3, sMulBeta.BigInt(new(big.Int)))
 	// 计算 $y$
 	y := *new(bn254.GT).Mul(eG1H2S, eGH3SBeta)
 
 	return &Gentry06IBECiphertext{
-		u: u,
-		v: v,
-		w: w,
-		y: y,
+		u:                 u,
+		v:                 v,
+		w:                 w,
+		y:                 y,
+		paramsFingerprint: publicParams.Fingerprint(),
 	}, nil
 }
 
@@ -260,9 +293,12 @@ func (instance *Gentry06IBEInstance) Encrypt(message *Gentry06IBEMessage, identi
 //
 // 返回值:
 //   - *Gentry06IBEMessage: 解密后的明文消息
-//   - error: 如果解密检查失败或解密操作失败，返回错误信息
+//   - error: 如果密文是在其他公共参数下加密的，返回 ErrParamsMismatch；解密检查失败或解密操作失败时返回错误信息
 func (instance *Gentry06IBEInstance) Decrypt(ciphertext *Gentry06IBECiphertext, secretKey *Gentry06IBESecretKey, publicParams *Gentry06IBEPublicParams) (*Gentry06IBEMessage, error) {
 	var err error
+	if ciphertext.paramsFingerprint != publicParams.Fingerprint() {
+		return nil, fmt.Errorf("failed to decrypt ciphertext: %w", ErrParamsMismatch)
+	}
 	beta := h(ciphertext.u, ciphertext.v, ciphertext.w)
 
 	// --- CCA 安全性检查 (Check) ---
@@ -279,9 +315,9 @@ func (instance *Gentry06IBEInstance) Decrypt(ciphertext *Gentry06IBECiphertext,
 	hId2AddHId3ExpBeta := new(bn254.G2Affine).Add(&secretKey.hids[1], hId3ExpBeta)
 
 	// 计算 $p = e(u, h_{(ID,2)} \cdot h_{(ID,3)}^\beta)$
-	p, err := bn254.Pair([]bn254.G1Affine{ciphertext.u}, []bn254.G2Affine{*hId2AddHId3ExpBeta})
+	p, err := metrics.Pair([]bn254.G1Affine{ciphertext.u}, []bn254.G2Affine{*hId2AddHId3ExpBeta})
 	if err != nil {
-		return nil, fmt.Errorf("failed to perform check pairing")
+		return nil, fmt.Errorf("failed to perform check pairing: %w", err)
 	}
 
 	// 计算 $y' = e(u, h_{(ID,2)} \cdot h_{(ID,3)}^\beta) \cdot v^{r_{(ID,2)}+r_{(ID,3)}\beta}$
@@ -295,9 +331,9 @@ func (instance *Gentry06IBEInstance) Decrypt(ciphertext *Gentry06IBECiphertext,
 	// --- 明文恢复 (Recovery) ---
 
 	// 1. 计算 $e(u, h_{(ID,1)})$
-	eUHid, err := bn254.Pair([]bn254.G1Affine{ciphertext.u}, []bn254.G2Affine{secretKey.hids[0]})
+	eUHid, err := metrics.Pair([]bn254.G1Affine{ciphertext.u}, []bn254.G2Affine{secretKey.hids[0]})
 	if err != nil {
-		return nil, fmt.Errorf("failed to decrypt ciphertext")
+		return nil, fmt.Errorf("failed to decrypt ciphertext: %w", err)
 	}
 	// 2. 计算 $v^{r_{(ID,1)}}$
 	vRid := new(bn254.GT).Exp(ciphertext.v, secretKey.rids[0].BigInt(new(big.Int)))
@@ -343,14 +379,26 @@ func h(u bn254.G1Affine, v bn254.GT, w bn254.GT) fr.Element {
 }
 
 // NewGentry06IBEIdentity 将大整数类型的 ID 转换为 IBE 方案使用的 fr.Element 身份结构体。
+// identity 按 BN254 标量域的阶 r（fr.Modulus()）取模：负数映射到 [0, r) 中的正剩余，
+// 大于等于 r 的值同样被约减，因此 x 与 x + r 表示同一个身份，得到的私钥可以互相解密。
+// 若约减结果恰好等于主密钥 α，由于 α 保密，只能在 KeyGenerate 时以 ErrIdentityEqualsMaster 拒绝。
 //
 // 输入:
-//   - identity: 大整数形式的用户 ID
+//   - identity: 大整数形式的用户 ID，可以为负数或超过域的阶
 //
 // 返回值:
 //   - *Gentry06IBEIdentity: 包含 $\mathbb{Z}_p$ 元素的身份结构体
+//   - error: identity 为 nil 时返回错误
 func NewGentry06IBEIdentity(identity *big.Int) (*Gentry06IBEIdentity, error) {
+	if identity == nil {
+		return nil, errors.New("identity must not be nil")
+	}
 	return &Gentry06IBEIdentity{
-		Id: *new(fr.Element).SetBigInt(identity), // 将 big.Int 映射到 $\mathbb{Z}_p$ 域元素
+		Id: *new(fr.Element).SetBigInt(identity), // 将 big.Int 映射到 $\mathbb{Z}_p$ 域元素（取模 r）
 	}, nil
 }
+
+// Zeroize 将实例持有的主密钥 $\alpha$ 覆写为零；清除范围的限制见 README 的 Key Zeroization。
+func (instance *Gentry06IBEInstance) Zeroize() {
+	instance.alpha.SetZero()
+}
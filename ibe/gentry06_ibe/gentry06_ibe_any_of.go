@@ -0,0 +1,100 @@
+package gentry06_ibe
+
+import "fmt"
+
+// Gentry06IBEAnyOfCiphertext 表示加密给"identities[0] 或 identities[1] 或 ..."的密文，
+// 任何一个接收者都可以用自己的私钥解密。
+//
+// 与 Waters05 不同，各接收者不能共用加密随机数 s：$u_j = g_1^{s(\alpha - ID_j)}$ 随身份变化，
+// 任意两个份额满足 $u_j \cdot u_k^{-1} = g_1^{s(ID_k - ID_j)}$，而身份是公开的，
+// 任何人都能由此求出 $g_1^s$，再用 $w \cdot e(g_1^s, h_1) = M$ 解密。
+// 因此每个接收者的份额都是以独立随机数加密的普通 Gentry06 密文，密文大小与分别加密 N 次相同，
+// 这个类型只提供按身份取出份额的统一接口。
+//
+// 接收者列表随密文明文保存，密文不隐藏接收者身份。
+type Gentry06IBEAnyOfCiphertext struct {
+	// shares[j] 是以独立随机数加密给 recipients[j] 的密文
+	shares []Gentry06IBECiphertext
+	// recipients 是接收者身份列表，解密时据此找到自己的 shares[j]
+	recipients []Gentry06IBEIdentity
+}
+
+// EncryptToAnyOf 把消息加密给一组身份中的任意一个：列表中的每个身份都能用自己的私钥解密。
+// 每个接收者使用独立的随机数 s，原因见 Gentry06IBEAnyOfCiphertext。
+//
+// 参数:
+//   - message: 要加密的明文消息
+//   - identities: 接收者身份列表，不能为空
+//   - publicParams: 系统公共参数
+//
+// 返回值:
+//   - *Gentry06IBEAnyOfCiphertext: 多接收者密文
+//   - error: 身份列表为空时返回 ErrNoRecipients；任一份额加密失败时返回错误信息
+func (instance *Gentry06IBEInstance) EncryptToAnyOf(message *Gentry06IBEMessage, identities []*Gentry06IBEIdentity, publicParams *Gentry06IBEPublicParams) (*Gentry06IBEAnyOfCiphertext, error) {
+	if len(identities) == 0 {
+		return nil, fmt.Errorf("failed to encrypt message: %w", ErrNoRecipients)
+	}
+	ciphertext := &Gentry06IBEAnyOfCiphertext{
+		shares:     make([]Gentry06IBECiphertext, len(identities)),
+		recipients: make([]Gentry06IBEIdentity, len(identities)),
+	}
+	for j, identity := range identities {
+		share, err := instance.Encrypt(message, identity, publicParams)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encrypt message for recipient %d: %w", j, err)
+		}
+		ciphertext.shares[j] = *share
+		ciphertext.recipients[j] = *identity
+	}
+	return ciphertext, nil
+}
+
+// ForRecipient 取出身份 identity 对应的普通 Gentry06 密文，可直接交给 Decrypt。
+//
+// 参数:
+//   - identity: 接收者身份
+//
+// 返回值:
+//   - *Gentry06IBECiphertext: 该接收者的单接收者密文
+//   - error: identity 不在接收者列表中时返回 ErrNotARecipient
+func (ct *Gentry06IBEAnyOfCiphertext) ForRecipient(identity *Gentry06IBEIdentity) (*Gentry06IBECiphertext, error) {
+	for j := range ct.recipients {
+		if ct.recipients[j].Id == identity.Id {
+			share := ct.shares[j]
+			return &share, nil
+		}
+	}
+	return nil, ErrNotARecipient
+}
+
+// Recipients 返回密文的接收者身份列表的副本。
+//
+// 返回值:
+//   - []*Gentry06IBEIdentity: 加密时传入的身份列表，顺序不变
+func (ct *Gentry06IBEAnyOfCiphertext) Recipients() []*Gentry06IBEIdentity {
+	recipients := make([]*Gentry06IBEIdentity, len(ct.recipients))
+	for j := range ct.recipients {
+		identity := ct.recipients[j]
+		recipients[j] = &identity
+	}
+	return recipients
+}
+
+// DecryptAnyOf 使用接收者之一的私钥解密多接收者密文。
+//
+// 参数:
+//   - ciphertext: EncryptToAnyOf 生成的密文
+//   - identity: 解密者的身份，必须在接收者列表中
+//   - secretKey: 该身份的私钥
+//   - publicParams: 系统公共参数
+//
+// 返回值:
+//   - *Gentry06IBEMessage: 解密后的明文消息
+//   - error: 身份不在接收者列表中时返回包装了 ErrNotARecipient 的错误；其余错误同 Decrypt
+func (instance *Gentry06IBEInstance) DecryptAnyOf(ciphertext *Gentry06IBEAnyOfCiphertext, identity *Gentry06IBEIdentity, secretKey *Gentry06IBESecretKey, publicParams *Gentry06IBEPublicParams) (*Gentry06IBEMessage, error) {
+	single, err := ciphertext.ForRecipient(identity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt message: %w", err)
+	}
+	return instance.Decrypt(single, secretKey, publicParams)
+}
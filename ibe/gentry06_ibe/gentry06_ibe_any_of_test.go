@@ -0,0 +1,117 @@
+package gentry06_ibe
+
+import (
+	"errors"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"math/big"
+	"testing"
+)
+
+// TestEncryptToAnyOf 三个候选身份都能解密，第四个身份不在接收者列表中，用它的私钥解密任一份额都无法通过检查
+func TestEncryptToAnyOf(t *testing.T) {
+	instance, err := NewGentry06IBEInstance()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pp, err := instance.SetUp()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var identities []*Gentry06IBEIdentity
+	var keys []*Gentry06IBESecretKey
+	for _, id := range []int64{101, 202, 303, 404} {
+		identity, err := NewGentry06IBEIdentity(big.NewInt(id))
+		if err != nil {
+			t.Fatal(err)
+		}
+		key, err := instance.KeyGenerate(identity, pp)
+		if err != nil {
+			t.Fatal(err)
+		}
+		identities = append(identities, identity)
+		keys = append(keys, key)
+	}
+	m, _ := new(bn254.GT).SetRandom()
+	message := &Gentry06IBEMessage{Message: *m}
+
+	ciphertext, err := instance.EncryptToAnyOf(message, identities[:3], pp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ciphertext.Recipients()) != 3 {
+		t.Fatalf("got %d recipients, want 3", len(ciphertext.Recipients()))
+	}
+	for j := 0; j < 3; j++ {
+		decrypted, err := instance.DecryptAnyOf(ciphertext, identities[j], keys[j], pp)
+		if err != nil {
+			t.Fatalf("recipient %d: %v", j, err)
+		}
+		if !decrypted.Message.Equal(&message.Message) {
+			t.Fatalf("recipient %d decrypted a different message", j)
+		}
+	}
+	// 各份额使用独立的随机数
+	if ciphertext.shares[0].v.Equal(&ciphertext.shares[1].v) {
+		t.Fatal("shares of different recipients must not reuse s")
+	}
+
+	if _, err := instance.DecryptAnyOf(ciphertext, identities[3], keys[3], pp); !errors.Is(err, ErrNotARecipient) {
+		t.Fatalf("expected ErrNotARecipient, got %v", err)
+	}
+	for j := 0; j < 3; j++ {
+		single, err := ciphertext.ForRecipient(identities[j])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := instance.Decrypt(single, keys[3], pp); err == nil {
+			t.Fatalf("non-recipient key passed the check on the share of recipient %d", j)
+		}
+	}
+
+	if _, err := instance.EncryptToAnyOf(message, nil, pp); !errors.Is(err, ErrNoRecipients) {
+		t.Fatalf("expected ErrNoRecipients, got %v", err)
+	}
+}
+
+// TestSharedRandomnessLeaksMessage 说明 EncryptToAnyOf 为何不共用 s：
+// 同一个 s 加密给两个公开身份时，任何人都能由 u_1 / u_2 求出 g1^s 并解密
+func TestSharedRandomnessLeaksMessage(t *testing.T) {
+	instance, err := NewGentry06IBEInstance()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pp, err := instance.SetUp()
+	if err != nil {
+		t.Fatal(err)
+	}
+	id1, _ := NewGentry06IBEIdentity(big.NewInt(101))
+	id2, _ := NewGentry06IBEIdentity(big.NewInt(202))
+	m, _ := new(bn254.GT).SetRandom()
+	message := &Gentry06IBEMessage{Message: *m}
+	s, _ := new(fr.Element).SetRandom()
+	c1, err := instance.EncryptWithRandomness(message, id1, pp, *s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c2, err := instance.EncryptWithRandomness(message, id2, pp, *s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// u_1 / u_2 = g1^{s(ID_2 - ID_1)}，再乘以 1/(ID_2 - ID_1) 得到 g1^s
+	diff := new(bn254.G1Affine).Sub(&c1.u, &c2.u)
+	idDiff := new(fr.Element).Sub(&id2.Id, &id1.Id)
+	idDiff.Inverse(idDiff)
+	g1ExpS := new(bn254.G1Affine).ScalarMultiplication(diff, idDiff.BigInt(new(big.Int)))
+
+	// M = w · e(g1^s, h1)
+	mask, err := bn254.Pair([]bn254.G1Affine{*g1ExpS}, []bn254.G2Affine{pp.hs[0]})
+	if err != nil {
+		t.Fatal(err)
+	}
+	recovered := new(bn254.GT).Mul(&c1.w, &mask)
+	if !recovered.Equal(&message.Message) {
+		t.Fatal("shared s should let anyone recover the message")
+	}
+}
@@ -0,0 +1,91 @@
+package gentry06_ibe
+
+import (
+	"errors"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/mmsyan/GoPairingBasedCryptography/utils"
+	"math/big"
+	"testing"
+)
+
+// TestKeyGenerateIdentityEqualsMaster 身份等于主密钥 alpha 时，密钥生成错误应包装 ErrIdentityEqualsMaster
+func TestKeyGenerateIdentityEqualsMaster(t *testing.T) {
+	instance, err := NewGentry06IBEInstance()
+	if err != nil {
+		t.Fatal("创建IBE实例失败:", err)
+	}
+	publicParams, err := instance.SetUp()
+	if err != nil {
+		t.Fatal("系统初始化失败:", err)
+	}
+	_, err = instance.KeyGenerate(&Gentry06IBEIdentity{Id: instance.alpha}, publicParams)
+	if !errors.Is(err, ErrIdentityEqualsMaster) {
+		t.Fatalf("错误应包装 ErrIdentityEqualsMaster: %v", err)
+	}
+}
+
+// TestEncryptDegenerateU s 为零或身份等于 alpha 时 u = g1^{s(alpha-ID)} 退化为单位元，加密应返回错误
+func TestEncryptDegenerateU(t *testing.T) {
+	instance, err := NewGentry06IBEInstance()
+	if err != nil {
+		t.Fatal("创建IBE实例失败:", err)
+	}
+	publicParams, err := instance.SetUp()
+	if err != nil {
+		t.Fatal("系统初始化失败:", err)
+	}
+	message := &Gentry06IBEMessage{}
+	message.Message.SetOne()
+
+	_, err = instance.Encrypt(message, &Gentry06IBEIdentity{Id: instance.alpha}, publicParams)
+	if !errors.Is(err, ErrIdentityEqualsMaster) {
+		t.Fatalf("错误应包装 ErrIdentityEqualsMaster: %v", err)
+	}
+	_, err = instance.EncryptWithRandomness(message, &Gentry06IBEIdentity{Id: fr.NewElement(7)}, publicParams, fr.Element{})
+	if !errors.Is(err, utils.ErrDegenerateRandomness) {
+		t.Fatalf("错误应包装 ErrDegenerateRandomness: %v", err)
+	}
+}
+
+// TestDecryptDifferentParams 在公共参数 A 下加密的密文交给公共参数 B 及其私钥解密时，错误应包装 ErrParamsMismatch
+func TestDecryptDifferentParams(t *testing.T) {
+	identity, err := NewGentry06IBEIdentity(big.NewInt(123456))
+	if err != nil {
+		t.Fatal(err)
+	}
+	instanceA, err := NewGentry06IBEInstance()
+	if err != nil {
+		t.Fatal("创建IBE实例失败:", err)
+	}
+	publicParamsA, err := instanceA.SetUp()
+	if err != nil {
+		t.Fatal("系统初始化失败:", err)
+	}
+	instanceB, err := NewGentry06IBEInstance()
+	if err != nil {
+		t.Fatal("创建IBE实例失败:", err)
+	}
+	publicParamsB, err := instanceB.SetUp()
+	if err != nil {
+		t.Fatal("系统初始化失败:", err)
+	}
+	if publicParamsA.Fingerprint() == publicParamsB.Fingerprint() {
+		t.Fatal("两次 SetUp 的公共参数指纹不应相同")
+	}
+	keyB, err := instanceB.KeyGenerate(identity, publicParamsB)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := new(bn254.GT).SetRandom()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ciphertext, err := instanceA.Encrypt(&Gentry06IBEMessage{Message: *m}, identity, publicParamsA)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := instanceB.Decrypt(ciphertext, keyB, publicParamsB); !errors.Is(err, ErrParamsMismatch) {
+		t.Fatalf("错误应包装 ErrParamsMismatch: %v", err)
+	}
+}
@@ -0,0 +1,71 @@
+package gentry06_ibe
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"math/big"
+	"testing"
+)
+
+// gentry06KATCiphertextDigest 是下面固定参数下密文 u || v || w || y 的 SHA-256 摘要。
+// 修改加密流程导致该值变化时，需要确认变化是有意的。
+const gentry06KATCiphertextDigest = "9d9f0b69e0b6db5e429061475a48fb9708b2eecdd2a59fab2efcd90b517fad55"
+
+// katGentry06 用固定的主密钥和公共参数构造实例，代替随机的 SetUp
+func katGentry06() (*Gentry06IBEInstance, *Gentry06IBEPublicParams) {
+	_, _, g1, g2 := bn254.Generators()
+	alpha := big.NewInt(7654321)
+	instance := &Gentry06IBEInstance{alpha: *new(fr.Element).SetBigInt(alpha)}
+	publicParams := &Gentry06IBEPublicParams{
+		g1:      g1,
+		g2:      g2,
+		g1Alpha: *new(bn254.G1Affine).ScalarMultiplicationBase(alpha),
+	}
+	for i := range publicParams.hs {
+		publicParams.hs[i] = *new(bn254.G2Affine).ScalarMultiplicationBase(big.NewInt(int64(11 + i)))
+	}
+	return instance, publicParams
+}
+
+func TestGentry06IBEKnownAnswer(t *testing.T) {
+	instance, publicParams := katGentry06()
+	identity, err := NewGentry06IBEIdentity(big.NewInt(2024))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _, g1, g2 := bn254.Generators()
+	eG1G2, err := bn254.Pair([]bn254.G1Affine{g1}, []bn254.G2Affine{g2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	message := &Gentry06IBEMessage{Message: *new(bn254.GT).Exp(eG1G2, big.NewInt(42))}
+
+	ciphertext, err := instance.EncryptWithRandomness(message, identity, publicParams, *new(fr.Element).SetUint64(123456789))
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := sha256.New()
+	u := ciphertext.u.Bytes()
+	h.Write(u[:])
+	for _, gt := range []bn254.GT{ciphertext.v, ciphertext.w, ciphertext.y} {
+		b := gt.Bytes()
+		h.Write(b[:])
+	}
+	if got := hex.EncodeToString(h.Sum(nil)); got != gentry06KATCiphertextDigest {
+		t.Fatalf("ciphertext digest = %s, want %s", got, gentry06KATCiphertextDigest)
+	}
+
+	secretKey, err := instance.KeyGenerate(identity, publicParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decrypted, err := instance.Decrypt(ciphertext, secretKey, publicParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !decrypted.Message.Equal(&message.Message) {
+		t.Fatal("解密消息与原始消息不匹配")
+	}
+}
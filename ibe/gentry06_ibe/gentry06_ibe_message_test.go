@@ -0,0 +1,12 @@
+package gentry06_ibe
+
+import (
+	"github.com/mmsyan/GoPairingBasedCryptography/internal/msgtest"
+	"github.com/mmsyan/GoPairingBasedCryptography/serialization"
+	"testing"
+)
+
+// TestGentry06IBEMessageBytes 检查 Gentry06IBEMessage 的 Bytes/SetBytes 往返
+func TestGentry06IBEMessageBytes(t *testing.T) {
+	msgtest.CheckGTRoundTrip(t, func() serialization.Message { return new(Gentry06IBEMessage) })
+}
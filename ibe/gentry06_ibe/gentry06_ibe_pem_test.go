@@ -0,0 +1,36 @@
+package gentry06_ibe
+
+import (
+	"errors"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/mmsyan/GoPairingBasedCryptography/internal/pemtest"
+	"math/big"
+	"testing"
+)
+
+// TestGentry06SecretKeyPEM 测试私钥导出为 PEM 后可以重新导入并正确解密
+func TestGentry06SecretKeyPEM(t *testing.T) {
+	identity, _ := NewGentry06IBEIdentity(big.NewInt(20260113))
+	instance, _ := NewGentry06IBEInstance()
+	publicParams, _ := instance.SetUp()
+	secretKey, err := instance.KeyGenerate(identity, publicParams)
+	if err != nil {
+		t.Fatalf("密钥生成失败: %v", err)
+	}
+
+	pemtest.CheckSecretKey(t, SecretKeyPEMType, secretKey, ExportSecretKeyPEM, ImportSecretKeyPEM, func(imported *Gentry06IBESecretKey) error {
+		m, _ := new(bn254.GT).SetRandom()
+		ciphertext, err := instance.Encrypt(&Gentry06IBEMessage{Message: *m}, identity, publicParams)
+		if err != nil {
+			return err
+		}
+		decrypted, err := instance.Decrypt(ciphertext, imported, publicParams)
+		if err != nil {
+			return err
+		}
+		if !decrypted.Message.Equal(m) {
+			return errors.New("解密结果不正确")
+		}
+		return nil
+	})
+}
@@ -0,0 +1,95 @@
+package gentry06_ibe
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/mmsyan/GoPairingBasedCryptography/serialization"
+)
+
+// MarshalBinary 使用默认的压缩点编码序列化用户私钥。
+// 布局: 头部 || r_{ID,1..3} (各 32 字节规范大端编码) || h_{ID,1..3} (G2)。
+//
+// 返回值:
+//   - []byte: 序列化后的私钥
+//   - error: 序列化失败时返回错误
+func (sk *Gentry06IBESecretKey) MarshalBinary() ([]byte, error) {
+	return sk.MarshalBinaryWithEncoding(serialization.Compressed)
+}
+
+// MarshalBinaryWithEncoding 使用指定的点编码方式序列化用户私钥。
+//
+// 参数:
+//   - encoding: 点编码方式（serialization.Compressed 或 serialization.Uncompressed）
+//
+// 返回值:
+//   - []byte: 序列化后的私钥
+//   - error: 序列化失败时返回错误
+func (sk *Gentry06IBESecretKey) MarshalBinaryWithEncoding(encoding serialization.PointEncoding) ([]byte, error) {
+	data := serialization.AppendHeader(nil, serialization.SchemeGentry06IBE)
+	for i := range sk.rids {
+		b := sk.rids[i].Bytes()
+		data = append(data, b[:]...)
+	}
+	for i := range sk.hids {
+		data = append(data, serialization.EncodeG2(sk.hids[i], encoding)...)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary 从字节串恢复用户私钥，自动识别压缩/非压缩点编码。
+//
+// 参数:
+//   - data: MarshalBinary 或 MarshalBinaryWithEncoding 的输出
+//
+// 返回值:
+//   - error: 数据格式不正确时返回错误
+func (sk *Gentry06IBESecretKey) UnmarshalBinary(data []byte) error {
+	data, err := serialization.CheckHeader(data, serialization.SchemeGentry06IBE)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal secret key: %w", err)
+	}
+	var rids [3]fr.Element
+	for i := range rids {
+		if len(data) < fr.Bytes {
+			return errors.New("failed to unmarshal secret key: not enough bytes")
+		}
+		if err := rids[i].SetBytesCanonical(data[:fr.Bytes]); err != nil {
+			return fmt.Errorf("failed to unmarshal secret key: %w", err)
+		}
+		data = data[fr.Bytes:]
+	}
+	var hids [3]bn254.G2Affine
+	for i := range hids {
+		h, k, err := serialization.DecodeValidG2(data)
+		if err != nil {
+			return fmt.Errorf("failed to unmarshal secret key: %w", err)
+		}
+		hids[i] = h
+		data = data[k:]
+	}
+	if len(data) != 0 {
+		return errors.New("failed to unmarshal secret key: trailing bytes")
+	}
+	sk.rids, sk.hids = rids, hids
+	return nil
+}
+
+// Fingerprint 返回公共参数的 SHA-256 摘要，摘要的输入为
+// 头部 || g1 || g2 || g1^alpha || h_1 || h_2 || h_3（压缩点编码）。
+// 密文携带加密时所用公共参数的指纹，Decrypt 据此判断密文与公共参数是否匹配。
+//
+// 返回值:
+//   - [32]byte: 公共参数指纹
+func (pp *Gentry06IBEPublicParams) Fingerprint() [32]byte {
+	data := serialization.AppendHeader(nil, serialization.SchemeGentry06IBE)
+	data = append(data, serialization.EncodeG1(pp.g1, serialization.Compressed)...)
+	data = append(data, serialization.EncodeG2(pp.g2, serialization.Compressed)...)
+	data = append(data, serialization.EncodeG1(pp.g1Alpha, serialization.Compressed)...)
+	for i := range pp.hs {
+		data = append(data, serialization.EncodeG2(pp.hs[i], serialization.Compressed)...)
+	}
+	return sha256.Sum256(data)
+}
@@ -0,0 +1,48 @@
+package gentry06_ibe
+
+import (
+	"bytes"
+	"github.com/mmsyan/GoPairingBasedCryptography/internal/invalidpoints"
+	"math/big"
+	"testing"
+)
+
+// TestGentry06SecretKeyRejectInvalidG2 把私钥序列化结果中的 h_{ID,2} 替换为无穷远点、不在曲线上或不在子群中的编码后，UnmarshalBinary 必须拒绝
+func TestGentry06SecretKeyRejectInvalidG2(t *testing.T) {
+	identity, err := NewGentry06IBEIdentity(big.NewInt(123456))
+	if err != nil {
+		t.Fatal("创建身份失败:", err)
+	}
+	instance, err := NewGentry06IBEInstance()
+	if err != nil {
+		t.Fatal("创建IBE实例失败:", err)
+	}
+	publicParams, err := instance.SetUp()
+	if err != nil {
+		t.Fatal("系统初始化失败:", err)
+	}
+	secretKey, err := instance.KeyGenerate(identity, publicParams)
+	if err != nil {
+		t.Fatal("密钥生成失败:", err)
+	}
+	data, err := secretKey.MarshalBinary()
+	if err != nil {
+		t.Fatal("私钥序列化失败:", err)
+	}
+	if err := new(Gentry06IBESecretKey).UnmarshalBinary(data); err != nil {
+		t.Fatal("私钥反序列化失败:", err)
+	}
+
+	point := secretKey.hids[1].Bytes()
+	offset := bytes.Index(data, point[:])
+	if offset < 0 {
+		t.Fatal("序列化结果中找不到目标 G2 点")
+	}
+	for name, invalid := range invalidpoints.G2Encodings() {
+		tampered := bytes.Clone(data)
+		copy(tampered[offset:], invalid)
+		if err := new(Gentry06IBESecretKey).UnmarshalBinary(tampered); err == nil {
+			t.Fatalf("%s: 不合法的 G2 点应被拒绝", name)
+		}
+	}
+}
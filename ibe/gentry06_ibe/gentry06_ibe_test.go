@@ -3,6 +3,7 @@ package gentry06_ibe
 import (
 	"fmt"
 	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/mmsyan/GoPairingBasedCryptography/internal/selftest"
 	"math/big"
 	"testing"
 )
@@ -292,3 +293,25 @@ func TestGentry06Ibe4(t *testing.T) {
 
 	fmt.Println("\n✓ 测试通过：所有边界情况和特殊身份值都能正常工作")
 }
+
+// TestGentry06IbeZeroize 测试 Zeroize 清除主密钥，且清除后生成的私钥无法完成自检的加解密往返
+func TestGentry06IbeZeroize(t *testing.T) {
+	instance, err := NewGentry06IBEInstance()
+	if err != nil {
+		t.Fatal(err)
+	}
+	publicParams, err := instance.SetUp()
+	if err != nil {
+		t.Fatal(err)
+	}
+	instance.Zeroize()
+	if !instance.alpha.IsZero() {
+		t.Fatal("Zeroize 之后主密钥 alpha 不为零")
+	}
+	err = selftest.Run("zeroized", func() (*selftest.RoundTrip[bn254.GT, *Gentry06IBECiphertext], error) {
+		return keyRoundTrip(instance, publicParams)
+	})
+	if err == nil {
+		t.Fatal("清除主密钥后生成的私钥仍能解密")
+	}
+}
@@ -0,0 +1,105 @@
+// Package pkg 提供可直接用于密钥生成服务的 IBE 私钥生成中心（Private Key Generator, PKG）。
+//
+// Server 包装一个持有主密钥的 IBE 实例，按身份字符串提取用户私钥并返回其序列化结果，
+// 提取前先用调用方给出的策略检查该身份是否允许提取。目前支持 Waters05。
+package pkg
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"github.com/mmsyan/GoPairingBasedCryptography/ibe/waters05_ibe"
+)
+
+// ErrIdentityNotAllowed 表示策略拒绝为该身份提取私钥
+var ErrIdentityNotAllowed = errors.New("identity is not allowed to extract a key")
+
+// Policy 判断是否允许为某个身份提取私钥，必须可以被多个 goroutine 并发调用
+type Policy func(identity string) bool
+
+// AllowList 返回只允许列表中身份的策略
+//
+// 参数:
+//   - identities: 允许提取私钥的身份
+//
+// 返回值:
+//   - Policy: 身份在列表中时返回 true 的策略
+func AllowList(identities ...string) Policy {
+	allowed := make(map[string]struct{}, len(identities))
+	for _, id := range identities {
+		allowed[id] = struct{}{}
+	}
+	return func(identity string) bool {
+		_, ok := allowed[identity]
+		return ok
+	}
+}
+
+// Server 是一个 PKG：常驻内存地持有主密钥，并按策略为身份提取序列化的私钥。
+//
+// Server 构造后不再修改内部状态，各方案的密钥生成只读取主密钥和公共参数，
+// 因此 Extract 可以在多个 goroutine 中并发调用。
+type Server struct {
+	policy       Policy
+	publicParams []byte
+	extract      func(identity string) (encoding.BinaryMarshaler, error)
+}
+
+// NewWaters05Server 创建基于 Waters05 IBE 的 PKG
+//
+// 参数:
+//   - instance: 持有主密钥 alpha 的 Waters05 实例
+//   - publicParams: 该实例 SetUp 生成的公共参数
+//   - policy: 身份提取策略，为 nil 时拒绝所有身份
+//
+// 返回值:
+//   - *Server: PKG
+//   - error: 公共参数序列化失败时返回错误
+func NewWaters05Server(instance *waters05_ibe.Waters05IBEInstance, publicParams *waters05_ibe.Waters05IBEPublicParams, policy Policy) (*Server, error) {
+	encoded, err := publicParams.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create waters05 server: %w", err)
+	}
+	return &Server{
+		policy:       policy,
+		publicParams: encoded,
+		extract: func(identity string) (encoding.BinaryMarshaler, error) {
+			id, err := waters05_ibe.NewWaters05IBEIdentity(identity)
+			if err != nil {
+				return nil, err
+			}
+			return instance.KeyGenerate(id, publicParams)
+		},
+	}, nil
+}
+
+// PublicParams 返回公共参数的序列化结果，供加密方下载
+//
+// 返回值:
+//   - []byte: 公共参数的 MarshalBinary 输出（副本）
+func (s *Server) PublicParams() []byte {
+	return append([]byte(nil), s.publicParams...)
+}
+
+// Extract 检查策略后为身份生成私钥，并返回私钥的 MarshalBinary 输出
+//
+// 参数:
+//   - identity: 用户身份
+//
+// 返回值:
+//   - []byte: 序列化的用户私钥
+//   - error: 策略拒绝时返回包装了 ErrIdentityNotAllowed 的错误；密钥生成或序列化失败时返回错误
+func (s *Server) Extract(identity string) ([]byte, error) {
+	if s.policy == nil || !s.policy(identity) {
+		return nil, fmt.Errorf("identity %q: %w", identity, ErrIdentityNotAllowed)
+	}
+	key, err := s.extract(identity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract key for %q: %w", identity, err)
+	}
+	data, err := key.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize key for %q: %w", identity, err)
+	}
+	return data, nil
+}
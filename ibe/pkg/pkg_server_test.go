@@ -0,0 +1,103 @@
+package pkg
+
+import (
+	"errors"
+	"fmt"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/mmsyan/GoPairingBasedCryptography/ibe/waters05_ibe"
+	"sync"
+	"testing"
+)
+
+// newTestWaters05Server 创建允许 identities 中身份的 Waters05 PKG，并返回实例与公共参数供加解密使用
+func newTestWaters05Server(t *testing.T, identities ...string) (*Server, *waters05_ibe.Waters05IBEInstance, *waters05_ibe.Waters05IBEPublicParams) {
+	t.Helper()
+	instance, err := waters05_ibe.NewWaters05IBEInstance()
+	if err != nil {
+		t.Fatal(err)
+	}
+	publicParams, err := instance.SetUp()
+	if err != nil {
+		t.Fatal(err)
+	}
+	server, err := NewWaters05Server(instance, publicParams, AllowList(identities...))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return server, instance, publicParams
+}
+
+// TestServerConcurrentExtract 多个 goroutine 并发提取私钥，每个私钥反序列化后都能解密发给对应身份的密文
+func TestServerConcurrentExtract(t *testing.T) {
+	identities := make([]string, 16)
+	for i := range identities {
+		identities[i] = fmt.Sprintf("user-%d@example.com", i)
+	}
+	server, instance, _ := newTestWaters05Server(t, identities...)
+
+	// 加密方只使用从 PKG 下载的公共参数
+	publicParams := new(waters05_ibe.Waters05IBEPublicParams)
+	if err := publicParams.UnmarshalBinary(server.PublicParams()); err != nil {
+		t.Fatal(err)
+	}
+
+	keys := make([][]byte, len(identities))
+	errs := make([]error, len(identities))
+	var wg sync.WaitGroup
+	for i := range identities {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			keys[i], errs[i] = server.Extract(identities[i])
+		}(i)
+	}
+	wg.Wait()
+
+	for i, identity := range identities {
+		if errs[i] != nil {
+			t.Fatalf("%s: 提取私钥失败: %v", identity, errs[i])
+		}
+		secretKey := new(waters05_ibe.Waters05IBESecretKey)
+		if err := secretKey.UnmarshalBinary(keys[i]); err != nil {
+			t.Fatalf("%s: 私钥反序列化失败: %v", identity, err)
+		}
+		id, err := waters05_ibe.NewWaters05IBEIdentity(identity)
+		if err != nil {
+			t.Fatal(err)
+		}
+		m, err := new(bn254.GT).SetRandom()
+		if err != nil {
+			t.Fatal(err)
+		}
+		ciphertext, err := instance.Encrypt(&waters05_ibe.Waters05IBEMessage{Message: *m}, id, publicParams)
+		if err != nil {
+			t.Fatal(err)
+		}
+		decrypted, err := instance.Decrypt(ciphertext, secretKey, publicParams)
+		if err != nil {
+			t.Fatalf("%s: 解密失败: %v", identity, err)
+		}
+		if !decrypted.Message.Equal(m) {
+			t.Fatalf("%s: 解密结果与原始消息不一致", identity)
+		}
+	}
+}
+
+// TestServerAllowList 不在允许列表中的身份被拒绝；策略为 nil 时拒绝所有身份
+func TestServerAllowList(t *testing.T) {
+	server, instance, publicParams := newTestWaters05Server(t, "alice@example.com")
+	if _, err := server.Extract("alice@example.com"); err != nil {
+		t.Fatalf("允许的身份提取失败: %v", err)
+	}
+	if key, err := server.Extract("mallory@example.com"); !errors.Is(err, ErrIdentityNotAllowed) || key != nil {
+		t.Fatalf("未授权的身份应被拒绝, 实际为 %v", err)
+	}
+
+	closed, err := NewWaters05Server(instance, publicParams, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := closed.Extract("alice@example.com"); !errors.Is(err, ErrIdentityNotAllowed) {
+		t.Fatalf("策略为 nil 时应拒绝所有身份, 实际为 %v", err)
+	}
+}
@@ -21,6 +21,8 @@ import (
 	"fmt"
 	"github.com/consensys/gnark-crypto/ecc/bn254"
 	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/mmsyan/GoPairingBasedCryptography/metrics"
+	"github.com/mmsyan/GoPairingBasedCryptography/utils"
 	"math/big"
 )
 
@@ -72,6 +74,9 @@ type Waters05IBESecretKey struct {
 type Waters05IBEMessage struct {
 	// Message 是 GT 群上的明文元素。
 	Message bn254.GT
+
+	// sealed 是 SetBytes 以会话密钥加密的字节数据，其他方式得到的明文为 nil。
+	sealed []byte
 }
 
 // Waters05IBECiphertext 表示 Waters-05 IBE 方案中的密文。
@@ -86,6 +91,8 @@ type Waters05IBECiphertext struct {
 	// c3 是密文的第三部分，位于 G2 群。
 	// c3 = (U' * Product(U_i^(Id[i]=1)))^t
 	c3 bn254.G2Affine
+	// paramsFingerprint 是加密时所用公共参数的 Fingerprint，解密时据此拒绝在其他公共参数下生成的密文
+	paramsFingerprint [32]byte
 }
 
 // NewWaters05IBEInstance 创建一个新的 Waters-05 IBE 方案实例。
@@ -123,7 +130,7 @@ func (instance *Waters05IBEInstance) SetUp() (*Waters05IBEPublicParams, error) {
 	// 随机选取 U' 的指数
 	uPrimeRandom, err := new(fr.Element).SetRandom()
 	if err != nil {
-		return nil, fmt.Errorf("failed to set up")
+		return nil, fmt.Errorf("failed to set up: %w", err)
 	}
 
 	// 计算 U' = g2^{随机数}
@@ -135,7 +142,7 @@ func (instance *Waters05IBEInstance) SetUp() (*Waters05IBEPublicParams, error) {
 		// 随机选取 U_i 的指数
 		uRandom, err := new(fr.Element).SetRandom()
 		if err != nil {
-			return nil, fmt.Errorf("failed to set up")
+			return nil, fmt.Errorf("failed to set up: %w", err)
 		}
 		// 计算 U_i = g2^{随机数}
 		ui[i] = *new(bn254.G2Affine).ScalarMultiplicationBase(uRandom.BigInt(new(big.Int)))
@@ -162,9 +169,9 @@ func (instance *Waters05IBEInstance) SetUp() (*Waters05IBEPublicParams, error) {
 //   - error: 如果密钥生成失败，返回错误信息。
 func (instance *Waters05IBEInstance) KeyGenerate(identity *Waters05IBEIdentity, publicParams *Waters05IBEPublicParams) (*Waters05IBESecretKey, error) {
 	// 随机选取 r
-	r, err := new(fr.Element).SetRandom()
+	r, err := utils.RandomNonZeroScalar()
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate key")
+		return nil, fmt.Errorf("failed to generate key: %w", err)
 	}
 	// d2 = g1^r
 	d2 := new(bn254.G1Affine).ScalarMultiplicationBase(r.BigInt(new(big.Int)))
@@ -205,15 +212,31 @@ func (instance *Waters05IBEInstance) KeyGenerate(identity *Waters05IBEIdentity,
 //   - error: 如果加密失败，返回错误信息。
 func (instance *Waters05IBEInstance) Encrypt(message *Waters05IBEMessage, identity *Waters05IBEIdentity, publicParams *Waters05IBEPublicParams) (*Waters05IBECiphertext, error) {
 	// 随机选取 t
-	t, err := new(fr.Element).SetRandom()
+	t, err := utils.RandomNonZeroScalar()
 	if err != nil {
-		return nil, fmt.Errorf("failed to encrypt message")
+		return nil, fmt.Errorf("failed to encrypt message: %w", err)
 	}
+	return instance.EncryptWithRandomness(message, identity, publicParams, *t)
+}
 
+// EncryptWithRandomness 与 Encrypt 相同，但使用调用方提供的随机数 t 而不是内部随机选取。
+// 仅用于已知答案测试（KAT）和与参考实现的交叉验证：对不同消息重复使用同一个 t
+// 会泄露两条明文之比，生产环境必须使用 Encrypt。
+//
+// 参数:
+//   - message: 要加密的明文消息。
+//   - identity: 接收者的身份向量。
+//   - publicParams: 系统公共参数。
+//   - t: 加密随机数。
+//
+// 返回值:
+//   - *Waters05IBECiphertext: 加密后的密文 (c1, c2, c3)。
+//   - error: 如果加密失败，返回错误信息。
+func (instance *Waters05IBEInstance) EncryptWithRandomness(message *Waters05IBEMessage, identity *Waters05IBEIdentity, publicParams *Waters05IBEPublicParams, t fr.Element) (*Waters05IBECiphertext, error) {
 	// 计算 e(g1^alpha, g2)
-	eG1AlphaG2, err := bn254.Pair([]bn254.G1Affine{publicParams.g1ExpAlpha}, []bn254.G2Affine{publicParams.g2})
+	eG1AlphaG2, err := metrics.Pair([]bn254.G1Affine{publicParams.g1ExpAlpha}, []bn254.G2Affine{publicParams.g2})
 	if err != nil {
-		return nil, fmt.Errorf("failed to encrypt message")
+		return nil, fmt.Errorf("failed to encrypt message: %w", err)
 	}
 	// 计算 e(g1^alpha, g2)^t
 	eG1AlphaG2ExpT := new(bn254.GT).Exp(eG1AlphaG2, t.BigInt(new(big.Int)))
@@ -237,9 +260,10 @@ func (instance *Waters05IBEInstance) Encrypt(message *Waters05IBEMessage, identi
 	c3 = *new(bn254.G2Affine).ScalarMultiplication(&c3, t.BigInt(new(big.Int)))
 
 	return &Waters05IBECiphertext{
-		c1: c1,
-		c2: c2,
-		c3: c3,
+		c1:                c1,
+		c2:                c2,
+		c3:                c3,
+		paramsFingerprint: publicParams.Fingerprint(),
 	}, nil
 }
 
@@ -249,19 +273,48 @@ func (instance *Waters05IBEInstance) Encrypt(message *Waters05IBEMessage, identi
 // 参数:
 //   - ciphertext: 要解密的密文 (c1, c2, c3)。
 //   - secretkey: 用户的私钥 (d1, d2)。
-//   - publicParams: 系统公共参数 (未使用，但作为标准接口参数保留)。
+//   - publicParams: 系统公共参数，其指纹必须与密文加密时使用的公共参数一致。
 //
 // 返回值:
 //   - *Waters05IBEMessage: 解密后的明文消息。
-//   - error: 如果解密失败，返回错误信息。
+//   - error: 如果密文是在其他公共参数下加密的，返回 ErrParamsMismatch；解密失败时返回错误信息。
 func (instance *Waters05IBEInstance) Decrypt(ciphertext *Waters05IBECiphertext, secretKey *Waters05IBESecretKey, publicParams *Waters05IBEPublicParams) (*Waters05IBEMessage, error) {
+	m, err := instance.DecryptRaw(ciphertext, secretKey, publicParams)
+	if err != nil {
+		return nil, err
+	}
+	return &Waters05IBEMessage{
+		Message: m,
+	}, nil
+}
+
+// DecryptRaw 与 Decrypt 的计算相同，但直接返回恢复出的 GT 元素，便于调试时用 Equal 比较群元素。
+// Waters05 没有完整性校验，除公共参数指纹外不检查其他内容：使用同一公共参数下其他身份的私钥解密同样会"成功"，
+// 只是得到一个与明文无关的 GT 元素。
+//
+// 参数:
+//   - ciphertext: 要解密的密文 (c1, c2, c3)。
+//   - secretKey: 用户的私钥 (d1, d2)。
+//   - publicParams: 系统公共参数，只用于比较指纹。
+//
+// 返回值:
+//   - bn254.GT: 计算得到的 c1 * e(d2, c3) / e(c2, d1)；出错时为零值。
+//   - error: 密文的公共参数指纹不一致时返回 ErrParamsMismatch，配对计算失败时返回错误信息。
+func (instance *Waters05IBEInstance) DecryptRaw(ciphertext *Waters05IBECiphertext, secretKey *Waters05IBESecretKey, publicParams *Waters05IBEPublicParams) (bn254.GT, error) {
+	if ciphertext.paramsFingerprint != publicParams.Fingerprint() {
+		return bn254.GT{}, fmt.Errorf("failed to decrypt message: %w", ErrParamsMismatch)
+	}
+
 	// eD2C3 = e(d2, c3) = e(g1^r, (Product)^t) = e(g1, Product)^{rt}
-	eD2C3, err := bn254.Pair([]bn254.G1Affine{secretKey.d2}, []bn254.G2Affine{ciphertext.c3})
+	eD2C3, err := metrics.Pair([]bn254.G1Affine{secretKey.d2}, []bn254.G2Affine{ciphertext.c3})
+	if err != nil {
+		return bn254.GT{}, fmt.Errorf("failed to decrypt message: %w", err)
+	}
 
 	// eC2D1 = e(c2, d1) = e(g1^t, g2^alpha * Product^r) = e(g1, g2)^{t*alpha} * e(g1, Product)^{tr}
-	eC2D1, err := bn254.Pair([]bn254.G1Affine{ciphertext.c2}, []bn254.G2Affine{secretKey.d1})
+	eC2D1, err := metrics.Pair([]bn254.G1Affine{ciphertext.c2}, []bn254.G2Affine{secretKey.d1})
 	if err != nil {
-		return nil, fmt.Errorf("failed to decrypt message")
+		return bn254.GT{}, fmt.Errorf("failed to decrypt message: %w", err)
 	}
 
 	// 分子: c1 * e(d2, c3)
@@ -271,11 +324,9 @@ func (instance *Waters05IBEInstance) Decrypt(ciphertext *Waters05IBECiphertext,
 	// m = 分子 / e(c2, d1)
 	// m = (MessageBytes * e(g1, g2)^{t*alpha} * e(g1, Product)^{rt}) / (e(g1, g2)^{t*alpha} * e(g1, Product)^{tr})
 	// m = MessageBytes
-	m = new(bn254.GT).Div(m, &eC2D1)
+	m.Div(m, &eC2D1)
 
-	return &Waters05IBEMessage{
-		Message: *m,
-	}, nil
+	return *m, nil
 }
 
 // NewWaters05IBEIdentity 将一个字符串身份转换为 Waters-05 IBE 所需的 256 位二进制身份向量。
@@ -314,3 +365,9 @@ func NewWaters05IBEIdentity(identity string) (*Waters05IBEIdentity, error) {
 	}
 	return wId, nil
 }
+
+// Zeroize 清除实例持有的主密钥：alpha 覆写为零，g2^alpha 重置为无穷远点；清除范围的限制见 README 的 Key Zeroization。
+func (instance *Waters05IBEInstance) Zeroize() {
+	instance.alpha.SetZero()
+	instance.g2ExpAlpha = bn254.G2Affine{}
+}
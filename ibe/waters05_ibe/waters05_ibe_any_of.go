@@ -0,0 +1,138 @@
+package waters05_ibe
+
+import (
+	"fmt"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/mmsyan/GoPairingBasedCryptography/metrics"
+	"github.com/mmsyan/GoPairingBasedCryptography/utils"
+	"math/big"
+)
+
+// Waters05IBEAnyOfCiphertext 表示加密给"identities[0] 或 identities[1] 或 ..."的密文，
+// 任何一个接收者都可以用自己的私钥解密。
+//
+// 所有接收者共用同一个加密随机数 t：消息掩码 e(g1^alpha, g2)^t 的 1-of-N 秘密分享中每个份额都等于掩码本身，
+// 因此 c1 与 c2 只需保存一份，每个接收者只需要各自的 c3_j = (U' * Product(U_i^(Id_j[i]=1)))^t。
+// 密文大小为 1 个 GT 元素 + 1 个 G1 元素 + N 个 G2 元素，仍随接收者个数线性增长，但只有 G2 部分是线性的；
+// 分别加密 N 次则需要 N 个 GT、N 个 G1 和 N 个 G2 元素，加密也从 N 次配对减少为 1 次。
+//
+// 接收者列表随密文明文保存，密文不隐藏接收者身份。
+type Waters05IBEAnyOfCiphertext struct {
+	// c1 = MessageBytes * e(g1^alpha, g2)^t，所有接收者共用
+	c1 bn254.GT
+	// c2 = g1^t，所有接收者共用
+	c2 bn254.G1Affine
+	// c3[j] = (U' * Product(U_i^(Id_j[i]=1)))^t，对应 recipients[j]
+	c3 []bn254.G2Affine
+	// recipients 是接收者身份列表，解密时据此找到自己的 c3[j]
+	recipients []Waters05IBEIdentity
+	// paramsFingerprint 是加密时所用公共参数的 Fingerprint
+	paramsFingerprint [32]byte
+}
+
+// EncryptToAnyOf 把消息加密给一组身份中的任意一个：列表中的每个身份都能用自己的私钥解密。
+//
+// 参数:
+//   - identities: 接收者身份列表，不能为空。
+//   - message: 要加密的明文消息。
+//   - publicParams: 系统公共参数。
+//
+// 返回值:
+//   - *Waters05IBEAnyOfCiphertext: 多接收者密文。
+//   - error: 身份列表为空时返回 ErrNoRecipients；加密失败时返回错误信息。
+func (instance *Waters05IBEInstance) EncryptToAnyOf(identities []*Waters05IBEIdentity, message *Waters05IBEMessage, publicParams *Waters05IBEPublicParams) (*Waters05IBEAnyOfCiphertext, error) {
+	if len(identities) == 0 {
+		return nil, fmt.Errorf("failed to encrypt message: %w", ErrNoRecipients)
+	}
+	// 随机选取所有接收者共用的 t
+	t, err := utils.RandomNonZeroScalar()
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt message: %w", err)
+	}
+	tBig := t.BigInt(new(big.Int))
+
+	// c1 = MessageBytes * e(g1^alpha, g2)^t
+	eG1AlphaG2, err := metrics.Pair([]bn254.G1Affine{publicParams.g1ExpAlpha}, []bn254.G2Affine{publicParams.g2})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt message: %w", err)
+	}
+	c1 := *new(bn254.GT).Exp(eG1AlphaG2, tBig)
+	c1.Mul(&c1, &message.Message)
+
+	// c2 = g1^t
+	c2 := *new(bn254.G1Affine).ScalarMultiplicationBase(tBig)
+
+	ciphertext := &Waters05IBEAnyOfCiphertext{
+		c1:                c1,
+		c2:                c2,
+		c3:                make([]bn254.G2Affine, len(identities)),
+		recipients:        make([]Waters05IBEIdentity, len(identities)),
+		paramsFingerprint: publicParams.Fingerprint(),
+	}
+	for j, identity := range identities {
+		// c3[j] = (U' * Product(U_i^(Id_j[i]=1)))^t
+		product := publicParams.uPrime
+		for i := 0; i < len(identity.Id); i++ {
+			if identity.Id[i] == 1 {
+				product.Add(&product, &publicParams.ui[i])
+			}
+		}
+		ciphertext.c3[j].ScalarMultiplication(&product, tBig)
+		ciphertext.recipients[j] = *identity
+	}
+	return ciphertext, nil
+}
+
+// ForRecipient 取出身份 identity 对应的普通 Waters05 密文 (c1, c2, c3_j)，可直接交给 Decrypt 或 DecryptRaw。
+//
+// 参数:
+//   - identity: 接收者身份。
+//
+// 返回值:
+//   - *Waters05IBECiphertext: 该接收者的单接收者密文。
+//   - error: identity 不在接收者列表中时返回 ErrNotARecipient。
+func (ct *Waters05IBEAnyOfCiphertext) ForRecipient(identity *Waters05IBEIdentity) (*Waters05IBECiphertext, error) {
+	for j := range ct.recipients {
+		if ct.recipients[j].Id == identity.Id {
+			return &Waters05IBECiphertext{
+				c1:                ct.c1,
+				c2:                ct.c2,
+				c3:                ct.c3[j],
+				paramsFingerprint: ct.paramsFingerprint,
+			}, nil
+		}
+	}
+	return nil, ErrNotARecipient
+}
+
+// Recipients 返回密文的接收者身份列表的副本。
+//
+// 返回值:
+//   - []*Waters05IBEIdentity: 加密时传入的身份列表，顺序不变。
+func (ct *Waters05IBEAnyOfCiphertext) Recipients() []*Waters05IBEIdentity {
+	recipients := make([]*Waters05IBEIdentity, len(ct.recipients))
+	for j := range ct.recipients {
+		identity := ct.recipients[j]
+		recipients[j] = &identity
+	}
+	return recipients
+}
+
+// DecryptAnyOf 使用接收者之一的私钥解密多接收者密文。
+//
+// 参数:
+//   - ciphertext: EncryptToAnyOf 生成的密文。
+//   - identity: 解密者的身份，必须在接收者列表中。
+//   - secretKey: 该身份的私钥。
+//   - publicParams: 系统公共参数。
+//
+// 返回值:
+//   - *Waters05IBEMessage: 解密后的明文消息。
+//   - error: 身份不在接收者列表中时返回包装了 ErrNotARecipient 的错误；公共参数不一致时返回 ErrParamsMismatch。
+func (instance *Waters05IBEInstance) DecryptAnyOf(ciphertext *Waters05IBEAnyOfCiphertext, identity *Waters05IBEIdentity, secretKey *Waters05IBESecretKey, publicParams *Waters05IBEPublicParams) (*Waters05IBEMessage, error) {
+	single, err := ciphertext.ForRecipient(identity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt message: %w", err)
+	}
+	return instance.Decrypt(single, secretKey, publicParams)
+}
@@ -0,0 +1,73 @@
+package waters05_ibe
+
+import (
+	"errors"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"testing"
+)
+
+// TestEncryptToAnyOf 三个候选身份都能解密，第四个身份不在接收者列表中，用它的私钥解密任一份额也得不到明文
+func TestEncryptToAnyOf(t *testing.T) {
+	instance, err := NewWaters05IBEInstance()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pp, err := instance.SetUp()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var identities []*Waters05IBEIdentity
+	var keys []*Waters05IBESecretKey
+	for _, name := range []string{"alice", "bob", "carol", "dave"} {
+		identity, err := NewWaters05IBEIdentity(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		key, err := instance.KeyGenerate(identity, pp)
+		if err != nil {
+			t.Fatal(err)
+		}
+		identities = append(identities, identity)
+		keys = append(keys, key)
+	}
+	m, _ := new(bn254.GT).SetRandom()
+	message := &Waters05IBEMessage{Message: *m}
+
+	ciphertext, err := instance.EncryptToAnyOf(identities[:3], message, pp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ciphertext.Recipients()) != 3 {
+		t.Fatalf("got %d recipients, want 3", len(ciphertext.Recipients()))
+	}
+	for j := 0; j < 3; j++ {
+		decrypted, err := instance.DecryptAnyOf(ciphertext, identities[j], keys[j], pp)
+		if err != nil {
+			t.Fatalf("recipient %d: %v", j, err)
+		}
+		if !decrypted.Message.Equal(&message.Message) {
+			t.Fatalf("recipient %d decrypted a different message", j)
+		}
+	}
+
+	if _, err := instance.DecryptAnyOf(ciphertext, identities[3], keys[3], pp); !errors.Is(err, ErrNotARecipient) {
+		t.Fatalf("expected ErrNotARecipient, got %v", err)
+	}
+	for j := 0; j < 3; j++ {
+		single, err := ciphertext.ForRecipient(identities[j])
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := instance.DecryptRaw(single, keys[3], pp)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Equal(&message.Message) {
+			t.Fatalf("non-recipient key decrypted the share of recipient %d", j)
+		}
+	}
+
+	if _, err := instance.EncryptToAnyOf(nil, message, pp); !errors.Is(err, ErrNoRecipients) {
+		t.Fatalf("expected ErrNoRecipients, got %v", err)
+	}
+}
@@ -0,0 +1,13 @@
+package waters05_ibe
+
+import "errors"
+
+// ErrParamsMismatch 表示密文携带的公共参数指纹与解密时使用的公共参数不同，
+// 即密文是在另一次 SetUp 生成的公共参数下加密的，继续解密只会得到与明文无关的 GT 元素
+var ErrParamsMismatch = errors.New("ciphertext was encrypted under different parameters")
+
+// ErrNoRecipients 表示 EncryptToAnyOf 的接收者身份列表为空
+var ErrNoRecipients = errors.New("recipient identity list is empty")
+
+// ErrNotARecipient 表示解密者的身份不在多接收者密文的接收者列表中
+var ErrNotARecipient = errors.New("identity is not a recipient of the ciphertext")
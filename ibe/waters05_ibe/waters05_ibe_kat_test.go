@@ -0,0 +1,73 @@
+package waters05_ibe
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"math/big"
+	"testing"
+)
+
+// waters05KATCiphertextDigest 是下面固定参数下密文 MarshalBinary 的 SHA-256 摘要。
+// 修改加密流程或序列化格式导致该值变化时，需要确认变化是有意的。
+const waters05KATCiphertextDigest = "5ce6bfe1cffe56d7494a993637c21a6af8818b70e50cd97bd9072d72e6a09387"
+
+// katWaters05 用固定的主密钥和公共参数构造实例，代替随机的 SetUp
+func katWaters05() (*Waters05IBEInstance, *Waters05IBEPublicParams) {
+	_, _, g1, g2 := bn254.Generators()
+	alpha := big.NewInt(1234567)
+	instance := &Waters05IBEInstance{
+		alpha:      *new(fr.Element).SetBigInt(alpha),
+		g2ExpAlpha: *new(bn254.G2Affine).ScalarMultiplicationBase(alpha),
+	}
+	publicParams := &Waters05IBEPublicParams{
+		g1:         g1,
+		g2:         g2,
+		g1ExpAlpha: *new(bn254.G1Affine).ScalarMultiplicationBase(alpha),
+		uPrime:     *new(bn254.G2Affine).ScalarMultiplicationBase(big.NewInt(999)),
+	}
+	for i := range publicParams.ui {
+		publicParams.ui[i] = *new(bn254.G2Affine).ScalarMultiplicationBase(big.NewInt(int64(1000 + i)))
+	}
+	return instance, publicParams
+}
+
+func TestWaters05IBEKnownAnswer(t *testing.T) {
+	instance, publicParams := katWaters05()
+	identity, err := NewWaters05IBEIdentity("alice@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _, g1, g2 := bn254.Generators()
+	eG1G2, err := bn254.Pair([]bn254.G1Affine{g1}, []bn254.G2Affine{g2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	message := &Waters05IBEMessage{Message: *new(bn254.GT).Exp(eG1G2, big.NewInt(42))}
+
+	ciphertext, err := instance.EncryptWithRandomness(message, identity, publicParams, *new(fr.Element).SetUint64(123456789))
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := ciphertext.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest := sha256.Sum256(data)
+	if got := hex.EncodeToString(digest[:]); got != waters05KATCiphertextDigest {
+		t.Fatalf("ciphertext digest = %s, want %s", got, waters05KATCiphertextDigest)
+	}
+
+	secretKey, err := instance.KeyGenerate(identity, publicParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decrypted, err := instance.Decrypt(ciphertext, secretKey, publicParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !decrypted.Message.Equal(&message.Message) {
+		t.Fatal("解密消息与原始消息不匹配")
+	}
+}
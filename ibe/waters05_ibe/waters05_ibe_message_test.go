@@ -0,0 +1,12 @@
+package waters05_ibe
+
+import (
+	"github.com/mmsyan/GoPairingBasedCryptography/internal/msgtest"
+	"github.com/mmsyan/GoPairingBasedCryptography/serialization"
+	"testing"
+)
+
+// TestWaters05IBEMessageBytes 检查 Waters05IBEMessage 的 Bytes/SetBytes 往返
+func TestWaters05IBEMessageBytes(t *testing.T) {
+	msgtest.CheckGTRoundTrip(t, func() serialization.Message { return new(Waters05IBEMessage) })
+}
@@ -0,0 +1,142 @@
+package waters05_ibe
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"github.com/consensys/gnark-crypto/ecc"
+	"io"
+)
+
+// 公共参数文件格式（ExportPublicParams 的输出）:
+//
+//	魔数 "W05P"(4字节) || 文件版本(1字节) || 标志(1字节) || 曲线名长度(1字节) || 曲线名
+//	|| 载荷长度(4字节，大端序) || 载荷
+//
+// 载荷是 MarshalBinary 的输出（自带方案编号与格式版本头部），标志位 paramsFlagGzip 置位时为其 gzip 压缩结果。
+// 曲线名与文件版本写在载荷之外，无需解压即可判断文件是否适用于当前实现。
+
+// paramsStreamMagic 是公共参数文件的前四个字节
+var paramsStreamMagic = [4]byte{'W', '0', '5', 'P'}
+
+const (
+	// paramsStreamVersion 是当前的公共参数文件版本
+	paramsStreamVersion byte = 1
+	// paramsFlagGzip 表示载荷经过 gzip 压缩
+	paramsFlagGzip byte = 1 << 0
+	// maxParamsPayloadSize 是接受的最大载荷长度，防止恶意长度前缀导致过量内存分配。
+	// 未压缩的载荷约为 4+32+64+32+64*257 字节，2 倍余量足以容纳 gzip 的额外开销。
+	maxParamsPayloadSize = 2 * (4 + 32 + 64 + 32 + 64*257)
+)
+
+// ErrInvalidParamsStream 表示公共参数文件的魔数、文件版本、曲线或长度前缀不正确
+var ErrInvalidParamsStream = errors.New("invalid public params stream")
+
+// ExportPublicParams 将公共参数写入 w，格式见本文件开头的说明，供 PKG 以文件形式发布公共参数。
+//
+// 点已采用压缩编码，而点的坐标是均匀随机的，因此 gzip 通常只能再节省很少的空间；
+// 需要与其他文件统一走 gzip 流程时可以设置 compress。
+//
+// 参数:
+//   - w: 输出流
+//   - compress: 是否对载荷进行 gzip 压缩
+//
+// 返回值:
+//   - error: 序列化、压缩或写入失败时返回错误
+func (pp *Waters05IBEPublicParams) ExportPublicParams(w io.Writer, compress bool) error {
+	payload, err := pp.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("failed to export public params: %w", err)
+	}
+	var flags byte
+	if compress {
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		if _, err := zw.Write(payload); err != nil {
+			return fmt.Errorf("failed to export public params: %w", err)
+		}
+		if err := zw.Close(); err != nil {
+			return fmt.Errorf("failed to export public params: %w", err)
+		}
+		payload = buf.Bytes()
+		flags |= paramsFlagGzip
+	}
+
+	curveName := ecc.BN254.String()
+	header := append([]byte{}, paramsStreamMagic[:]...)
+	header = append(header, paramsStreamVersion, flags, byte(len(curveName)))
+	header = append(header, curveName...)
+	header = binary.BigEndian.AppendUint32(header, uint32(len(payload)))
+	if _, err := w.Write(header); err != nil {
+		return fmt.Errorf("failed to export public params: %w", err)
+	}
+	if _, err := w.Write(payload); err != nil {
+		return fmt.Errorf("failed to export public params: %w", err)
+	}
+	return nil
+}
+
+// ImportPublicParams 从 r 读取 ExportPublicParams 写出的公共参数。
+// 除文件头外，还会通过 UnmarshalBinary 校验载荷的方案编号与格式版本，并检查每个 G2 点都在素数阶子群中。
+// 只读取一份公共参数，r 中其后的数据保持未读。
+//
+// 参数:
+//   - r: 输入流
+//
+// 返回值:
+//   - *Waters05IBEPublicParams: 导入的公共参数
+//   - error: 文件头不正确、曲线不匹配、数据被截断或公共参数不合法时返回错误
+func ImportPublicParams(r io.Reader) (*Waters05IBEPublicParams, error) {
+	var fixed [7]byte
+	if _, err := io.ReadFull(r, fixed[:]); err != nil {
+		return nil, fmt.Errorf("failed to import public params: %w", err)
+	}
+	if !bytes.Equal(fixed[:4], paramsStreamMagic[:]) {
+		return nil, fmt.Errorf("failed to import public params: bad magic: %w", ErrInvalidParamsStream)
+	}
+	if fixed[4] != paramsStreamVersion {
+		return nil, fmt.Errorf("failed to import public params: stream version %d: %w", fixed[4], ErrInvalidParamsStream)
+	}
+	flags := fixed[5]
+	if flags&^paramsFlagGzip != 0 {
+		return nil, fmt.Errorf("failed to import public params: unknown flags %#x: %w", flags, ErrInvalidParamsStream)
+	}
+
+	rest := make([]byte, int(fixed[6])+4)
+	if _, err := io.ReadFull(r, rest); err != nil {
+		return nil, fmt.Errorf("failed to import public params: %w", err)
+	}
+	if curveName := string(rest[:fixed[6]]); curveName != ecc.BN254.String() {
+		return nil, fmt.Errorf("failed to import public params: curve %q: %w", curveName, ErrInvalidParamsStream)
+	}
+	size := binary.BigEndian.Uint32(rest[fixed[6]:])
+	if size > maxParamsPayloadSize {
+		return nil, fmt.Errorf("failed to import public params: payload of %d bytes: %w", size, ErrInvalidParamsStream)
+	}
+
+	payload := make([]byte, size)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return nil, fmt.Errorf("failed to import public params: %w", err)
+	}
+	if flags&paramsFlagGzip != 0 {
+		zr, err := gzip.NewReader(bytes.NewReader(payload))
+		if err != nil {
+			return nil, fmt.Errorf("failed to import public params: %w", err)
+		}
+		payload, err = io.ReadAll(io.LimitReader(zr, maxParamsPayloadSize+1))
+		if err != nil {
+			return nil, fmt.Errorf("failed to import public params: %w", err)
+		}
+		if len(payload) > maxParamsPayloadSize {
+			return nil, fmt.Errorf("failed to import public params: decompressed payload too large: %w", ErrInvalidParamsStream)
+		}
+	}
+
+	pp := new(Waters05IBEPublicParams)
+	if err := pp.UnmarshalBinary(payload); err != nil {
+		return nil, fmt.Errorf("failed to import public params: %w", err)
+	}
+	return pp, nil
+}
@@ -0,0 +1,110 @@
+package waters05_ibe
+
+import (
+	"bytes"
+	"errors"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/mmsyan/GoPairingBasedCryptography/serialization"
+	"testing"
+)
+
+// TestWaters05ExportImportPublicParams 公共参数写入 bytes.Buffer 后重新载入，用载入的参数加密、用原参数生成的私钥解密得到原消息，
+// 并报告 gzip 压缩前后的文件大小
+func TestWaters05ExportImportPublicParams(t *testing.T) {
+	instance, _ := NewWaters05IBEInstance()
+	pp, err := instance.SetUp()
+	if err != nil {
+		t.Fatalf("系统初始化失败: %v", err)
+	}
+	identity, err := NewWaters05IBEIdentity("alice@example.com")
+	if err != nil {
+		t.Fatalf("创建身份失败: %v", err)
+	}
+	secretKey, err := instance.KeyGenerate(identity, pp)
+	if err != nil {
+		t.Fatalf("密钥生成失败: %v", err)
+	}
+
+	sizes := make(map[bool]int)
+	for _, compress := range []bool{false, true} {
+		var buf bytes.Buffer
+		if err := pp.ExportPublicParams(&buf, compress); err != nil {
+			t.Fatalf("compress=%v: 导出失败: %v", compress, err)
+		}
+		sizes[compress] = buf.Len()
+		buf.WriteString("trailing")
+
+		imported, err := ImportPublicParams(&buf)
+		if err != nil {
+			t.Fatalf("compress=%v: 导入失败: %v", compress, err)
+		}
+		if imported.Fingerprint() != pp.Fingerprint() {
+			t.Fatalf("compress=%v: 导入的公共参数与原参数不同", compress)
+		}
+		if buf.String() != "trailing" {
+			t.Fatalf("compress=%v: 导入不应读取公共参数之后的数据", compress)
+		}
+
+		m, _ := new(bn254.GT).SetRandom()
+		ciphertext, err := instance.Encrypt(&Waters05IBEMessage{Message: *m}, identity, imported)
+		if err != nil {
+			t.Fatalf("compress=%v: 加密失败: %v", compress, err)
+		}
+		decrypted, err := instance.Decrypt(ciphertext, secretKey, imported)
+		if err != nil {
+			t.Fatalf("compress=%v: 解密失败: %v", compress, err)
+		}
+		if !decrypted.Message.Equal(m) {
+			t.Fatalf("compress=%v: 解密消息与原始消息不匹配", compress)
+		}
+	}
+	// 点坐标均匀随机，gzip 几乎没有收益；主要的节省来自压缩点编码
+	uncompressedPoints := serialization.HeaderSize + 2*bn254.SizeOfG1AffineUncompressed + (2+len(pp.ui))*bn254.SizeOfG2AffineUncompressed
+	t.Logf("非压缩点编码: %d 字节，导出文件: %d 字节（节省 %.1f%%），gzip 导出文件: %d 字节（再节省 %.1f%%）",
+		uncompressedPoints, sizes[false], 100*(1-float64(sizes[false])/float64(uncompressedPoints)),
+		sizes[true], 100*(1-float64(sizes[true])/float64(sizes[false])))
+}
+
+// TestWaters05ImportPublicParamsRejectsInvalid 魔数、曲线、长度前缀、载荷方案编号不正确或数据被截断时 ImportPublicParams 必须拒绝
+func TestWaters05ImportPublicParamsRejectsInvalid(t *testing.T) {
+	instance, _ := NewWaters05IBEInstance()
+	pp, err := instance.SetUp()
+	if err != nil {
+		t.Fatalf("系统初始化失败: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := pp.ExportPublicParams(&buf, false); err != nil {
+		t.Fatalf("导出失败: %v", err)
+	}
+	data := buf.Bytes()
+	// 固定头部 7 字节 + 曲线名 "bn254" 5 字节 + 长度前缀 4 字节
+	payloadOffset := 7 + 5 + 4
+
+	tamper := func(f func([]byte)) []byte {
+		tampered := bytes.Clone(data)
+		f(tampered)
+		return tampered
+	}
+	cases := map[string]struct {
+		data []byte
+		want error
+	}{
+		"bad magic":       {tamper(func(b []byte) { b[0] = 'X' }), ErrInvalidParamsStream},
+		"stream version":  {tamper(func(b []byte) { b[4] = 2 }), ErrInvalidParamsStream},
+		"unknown flags":   {tamper(func(b []byte) { b[5] = 0x80 }), ErrInvalidParamsStream},
+		"curve":           {tamper(func(b []byte) { copy(b[7:], "bls12") }), ErrInvalidParamsStream},
+		"huge length":     {tamper(func(b []byte) { b[12] = 0xff }), ErrInvalidParamsStream},
+		"payload scheme":  {tamper(func(b []byte) { b[payloadOffset+3] = byte(serialization.SchemeGentry06IBE) }), serialization.ErrSchemeMismatch},
+		"payload version": {tamper(func(b []byte) { b[payloadOffset+2]++ }), serialization.ErrUnsupportedVersion},
+		"truncated":       {data[:len(data)-1], nil},
+	}
+	for name, c := range cases {
+		_, err := ImportPublicParams(bytes.NewReader(c.data))
+		if err == nil {
+			t.Fatalf("%s: 应返回错误", name)
+		}
+		if c.want != nil && !errors.Is(err, c.want) {
+			t.Fatalf("%s: 期望 %v，得到 %v", name, c.want, err)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package waters05_ibe
+
+import (
+	"errors"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/mmsyan/GoPairingBasedCryptography/internal/pemtest"
+	"testing"
+)
+
+// TestWaters05SecretKeyPEM 测试私钥导出为 PEM 后可以重新导入并正确解密
+func TestWaters05SecretKeyPEM(t *testing.T) {
+	identity, _ := NewWaters05IBEIdentity("alice@example.com")
+	instance, _ := NewWaters05IBEInstance()
+	publicParams, _ := instance.SetUp()
+	secretKey, err := instance.KeyGenerate(identity, publicParams)
+	if err != nil {
+		t.Fatalf("密钥生成失败: %v", err)
+	}
+
+	pemtest.CheckSecretKey(t, SecretKeyPEMType, secretKey, ExportSecretKeyPEM, ImportSecretKeyPEM, func(imported *Waters05IBESecretKey) error {
+		m, _ := new(bn254.GT).SetRandom()
+		ciphertext, err := instance.Encrypt(&Waters05IBEMessage{Message: *m}, identity, publicParams)
+		if err != nil {
+			return err
+		}
+		decrypted, err := instance.Decrypt(ciphertext, imported, publicParams)
+		if err != nil {
+			return err
+		}
+		if !decrypted.Message.Equal(m) {
+			return errors.New("解密结果不正确")
+		}
+		return nil
+	})
+}
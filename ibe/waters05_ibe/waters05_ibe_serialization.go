@@ -0,0 +1,201 @@
+package waters05_ibe
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"github.com/mmsyan/GoPairingBasedCryptography/serialization"
+)
+
+// MarshalBinary 使用默认的压缩点编码序列化密文。
+// 布局: 头部 || 公共参数指纹 (32 字节) || c1 (GT, 384 字节) || c2 (G1) || c3 (G2)。
+//
+// 返回值:
+//   - []byte: 序列化后的密文
+//   - error: 序列化失败时返回错误
+func (ct *Waters05IBECiphertext) MarshalBinary() ([]byte, error) {
+	return ct.MarshalBinaryWithEncoding(serialization.Compressed)
+}
+
+// MarshalBinaryWithEncoding 使用指定的点编码方式序列化密文。
+// 压缩编码下 G1/G2 点体积减半，但 UnmarshalBinary 需要额外的开平方运算。
+//
+// 参数:
+//   - encoding: 点编码方式（serialization.Compressed 或 serialization.Uncompressed）
+//
+// 返回值:
+//   - []byte: 序列化后的密文
+//   - error: 序列化失败时返回错误
+func (ct *Waters05IBECiphertext) MarshalBinaryWithEncoding(encoding serialization.PointEncoding) ([]byte, error) {
+	data := serialization.AppendHeader(nil, serialization.SchemeWaters05IBE)
+	data = append(data, ct.paramsFingerprint[:]...)
+	data = append(data, serialization.MarshalGT(ct.c1)...)
+	data = append(data, serialization.EncodeG1(ct.c2, encoding)...)
+	data = append(data, serialization.EncodeG2(ct.c3, encoding)...)
+	return data, nil
+}
+
+// UnmarshalBinary 从字节串恢复密文，自动识别压缩/非压缩点编码。
+// 对于压缩编码，每个点都需要一次开平方运算来恢复 y 坐标。
+//
+// 参数:
+//   - data: MarshalBinary 或 MarshalBinaryWithEncoding 的输出
+//
+// 返回值:
+//   - error: 数据格式不正确时返回错误
+func (ct *Waters05IBECiphertext) UnmarshalBinary(data []byte) error {
+	data, err := serialization.CheckHeader(data, serialization.SchemeWaters05IBE)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal ciphertext: %w", err)
+	}
+	if len(data) < sha256.Size {
+		return errors.New("failed to unmarshal ciphertext: not enough bytes")
+	}
+	var paramsFingerprint [32]byte
+	copy(paramsFingerprint[:], data)
+	data = data[sha256.Size:]
+	c1, n, err := serialization.DecodeGT(data)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal ciphertext: %w", err)
+	}
+	data = data[n:]
+	c2, n, err := serialization.DecodeG1(data)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal ciphertext: %w", err)
+	}
+	data = data[n:]
+	c3, n, err := serialization.DecodeValidG2(data)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal ciphertext: %w", err)
+	}
+	if len(data) != n {
+		return errors.New("failed to unmarshal ciphertext: trailing bytes")
+	}
+	ct.c1, ct.c2, ct.c3, ct.paramsFingerprint = c1, c2, c3, paramsFingerprint
+	return nil
+}
+
+// MarshalBinary 使用默认的压缩点编码序列化用户私钥。
+// 布局: 头部 || d1 (G2) || d2 (G1)。
+//
+// 返回值:
+//   - []byte: 序列化后的私钥
+//   - error: 序列化失败时返回错误
+func (sk *Waters05IBESecretKey) MarshalBinary() ([]byte, error) {
+	return sk.MarshalBinaryWithEncoding(serialization.Compressed)
+}
+
+// MarshalBinaryWithEncoding 使用指定的点编码方式序列化用户私钥。
+//
+// 参数:
+//   - encoding: 点编码方式（serialization.Compressed 或 serialization.Uncompressed）
+//
+// 返回值:
+//   - []byte: 序列化后的私钥
+//   - error: 序列化失败时返回错误
+func (sk *Waters05IBESecretKey) MarshalBinaryWithEncoding(encoding serialization.PointEncoding) ([]byte, error) {
+	data := serialization.AppendHeader(nil, serialization.SchemeWaters05IBE)
+	data = append(data, serialization.EncodeG2(sk.d1, encoding)...)
+	data = append(data, serialization.EncodeG1(sk.d2, encoding)...)
+	return data, nil
+}
+
+// UnmarshalBinary 从字节串恢复用户私钥，自动识别压缩/非压缩点编码。
+//
+// 参数:
+//   - data: MarshalBinary 或 MarshalBinaryWithEncoding 的输出
+//
+// 返回值:
+//   - error: 数据格式不正确时返回错误
+func (sk *Waters05IBESecretKey) UnmarshalBinary(data []byte) error {
+	data, err := serialization.CheckHeader(data, serialization.SchemeWaters05IBE)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal secret key: %w", err)
+	}
+	d1, n, err := serialization.DecodeValidG2(data)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal secret key: %w", err)
+	}
+	data = data[n:]
+	d2, n, err := serialization.DecodeG1(data)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal secret key: %w", err)
+	}
+	if len(data) != n {
+		return errors.New("failed to unmarshal secret key: trailing bytes")
+	}
+	sk.d1, sk.d2 = d1, d2
+	return nil
+}
+
+// MarshalBinary 将公共参数序列化为规范字节串（压缩点编码）。
+// 布局: 头部 || g1 || g2 || g1^alpha || U' || U_0 ... U_255。
+//
+// 返回值:
+//   - []byte: 序列化后的公共参数
+//   - error: 序列化失败时返回错误
+func (pp *Waters05IBEPublicParams) MarshalBinary() ([]byte, error) {
+	data := serialization.AppendHeader(nil, serialization.SchemeWaters05IBE)
+	data = append(data, serialization.EncodeG1(pp.g1, serialization.Compressed)...)
+	data = append(data, serialization.EncodeG2(pp.g2, serialization.Compressed)...)
+	data = append(data, serialization.EncodeG1(pp.g1ExpAlpha, serialization.Compressed)...)
+	data = append(data, serialization.EncodeG2(pp.uPrime, serialization.Compressed)...)
+	for i := range pp.ui {
+		data = append(data, serialization.EncodeG2(pp.ui[i], serialization.Compressed)...)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary 从 MarshalBinary 的输出恢复公共参数。
+//
+// 参数:
+//   - data: 序列化后的公共参数
+//
+// 返回值:
+//   - error: 数据格式不正确时返回错误
+func (pp *Waters05IBEPublicParams) UnmarshalBinary(data []byte) error {
+	var result Waters05IBEPublicParams
+	var n int
+	var err error
+	data, err = serialization.CheckHeader(data, serialization.SchemeWaters05IBE)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal public params: %w", err)
+	}
+	if result.g1, n, err = serialization.DecodeG1(data); err != nil {
+		return fmt.Errorf("failed to unmarshal public params: %w", err)
+	}
+	data = data[n:]
+	if result.g2, n, err = serialization.DecodeValidG2(data); err != nil {
+		return fmt.Errorf("failed to unmarshal public params: %w", err)
+	}
+	data = data[n:]
+	if result.g1ExpAlpha, n, err = serialization.DecodeG1(data); err != nil {
+		return fmt.Errorf("failed to unmarshal public params: %w", err)
+	}
+	data = data[n:]
+	if result.uPrime, n, err = serialization.DecodeValidG2(data); err != nil {
+		return fmt.Errorf("failed to unmarshal public params: %w", err)
+	}
+	data = data[n:]
+	for i := range result.ui {
+		if result.ui[i], n, err = serialization.DecodeValidG2(data); err != nil {
+			return fmt.Errorf("failed to unmarshal public params: %w", err)
+		}
+		data = data[n:]
+	}
+	if len(data) != 0 {
+		return errors.New("failed to unmarshal public params: trailing bytes")
+	}
+	*pp = result
+	return nil
+}
+
+// Fingerprint 返回公共参数规范序列化的 SHA-256 摘要，
+// 加密方与 PKG 可以借此确认双方使用的是同一套公共参数。
+//
+// 返回值:
+//   - [32]byte: 公共参数指纹
+func (pp *Waters05IBEPublicParams) Fingerprint() [32]byte {
+	data, _ := pp.MarshalBinary()
+	return sha256.Sum256(data)
+}
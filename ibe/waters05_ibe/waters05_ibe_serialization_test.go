@@ -0,0 +1,195 @@
+package waters05_ibe
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/mmsyan/GoPairingBasedCryptography/internal/invalidpoints"
+	"github.com/mmsyan/GoPairingBasedCryptography/serialization"
+	"testing"
+)
+
+// TestWaters05CiphertextCompressedVsUncompressed 比较压缩/非压缩编码下密文的体积，并验证两种编码反序列化后都能正确解密
+func TestWaters05CiphertextCompressedVsUncompressed(t *testing.T) {
+	identity, err := NewWaters05IBEIdentity("alice@example.com")
+	if err != nil {
+		t.Fatalf("创建身份失败: %v", err)
+	}
+	instance, err := NewWaters05IBEInstance()
+	if err != nil {
+		t.Fatalf("创建IBE实例失败: %v", err)
+	}
+	publicParams, err := instance.SetUp()
+	if err != nil {
+		t.Fatalf("系统初始化失败: %v", err)
+	}
+	secretKey, err := instance.KeyGenerate(identity, publicParams)
+	if err != nil {
+		t.Fatalf("密钥生成失败: %v", err)
+	}
+	m, _ := new(bn254.GT).SetRandom()
+	message := &Waters05IBEMessage{Message: *m}
+	ciphertext, err := instance.Encrypt(message, identity, publicParams)
+	if err != nil {
+		t.Fatalf("加密失败: %v", err)
+	}
+
+	compressed, err := ciphertext.MarshalBinary()
+	if err != nil {
+		t.Fatalf("压缩序列化失败: %v", err)
+	}
+	uncompressed, err := ciphertext.MarshalBinaryWithEncoding(serialization.Uncompressed)
+	if err != nil {
+		t.Fatalf("非压缩序列化失败: %v", err)
+	}
+	fmt.Printf("压缩密文长度: %d, 非压缩密文长度: %d\n", len(compressed), len(uncompressed))
+
+	wantCompressed := serialization.HeaderSize + sha256.Size + bn254.SizeOfGT + bn254.SizeOfG1AffineCompressed + bn254.SizeOfG2AffineCompressed
+	wantUncompressed := serialization.HeaderSize + sha256.Size + bn254.SizeOfGT + bn254.SizeOfG1AffineUncompressed + bn254.SizeOfG2AffineUncompressed
+	if len(compressed) != wantCompressed || len(uncompressed) != wantUncompressed {
+		t.Fatalf("密文长度不符合预期: got %d/%d, want %d/%d", len(compressed), len(uncompressed), wantCompressed, wantUncompressed)
+	}
+
+	for name, data := range map[string][]byte{"compressed": compressed, "uncompressed": uncompressed} {
+		recovered := new(Waters05IBECiphertext)
+		if err := recovered.UnmarshalBinary(data); err != nil {
+			t.Fatalf("%s: 反序列化失败: %v", name, err)
+		}
+		decrypted, err := instance.Decrypt(recovered, secretKey, publicParams)
+		if err != nil {
+			t.Fatalf("%s: 解密失败: %v", name, err)
+		}
+		if !decrypted.Message.Equal(&message.Message) {
+			t.Fatalf("%s: 解密消息与原始消息不匹配", name)
+		}
+	}
+}
+
+// TestWaters05SecretKeySerialization 验证私钥序列化往返后仍可解密
+func TestWaters05SecretKeySerialization(t *testing.T) {
+	identity, _ := NewWaters05IBEIdentity("bob@example.com")
+	instance, _ := NewWaters05IBEInstance()
+	publicParams, _ := instance.SetUp()
+	secretKey, err := instance.KeyGenerate(identity, publicParams)
+	if err != nil {
+		t.Fatalf("密钥生成失败: %v", err)
+	}
+
+	data, err := secretKey.MarshalBinary()
+	if err != nil {
+		t.Fatalf("私钥序列化失败: %v", err)
+	}
+	recovered := new(Waters05IBESecretKey)
+	if err := recovered.UnmarshalBinary(data); err != nil {
+		t.Fatalf("私钥反序列化失败: %v", err)
+	}
+
+	m, _ := new(bn254.GT).SetRandom()
+	message := &Waters05IBEMessage{Message: *m}
+	ciphertext, _ := instance.Encrypt(message, identity, publicParams)
+	decrypted, err := instance.Decrypt(ciphertext, recovered, publicParams)
+	if err != nil {
+		t.Fatalf("解密失败: %v", err)
+	}
+	if !decrypted.Message.Equal(&message.Message) {
+		t.Fatal("解密消息与原始消息不匹配")
+	}
+
+	if err := recovered.UnmarshalBinary(data[:len(data)-1]); err == nil {
+		t.Fatal("截断的私钥应当反序列化失败")
+	}
+}
+
+// TestWaters05PublicParamsFingerprint 独立生成的公共参数指纹不同，序列化往返后指纹不变
+func TestWaters05PublicParamsFingerprint(t *testing.T) {
+	instance1, _ := NewWaters05IBEInstance()
+	instance2, _ := NewWaters05IBEInstance()
+	pp1, err := instance1.SetUp()
+	if err != nil {
+		t.Fatalf("系统初始化失败: %v", err)
+	}
+	pp2, err := instance2.SetUp()
+	if err != nil {
+		t.Fatalf("系统初始化失败: %v", err)
+	}
+	if pp1.Fingerprint() == pp2.Fingerprint() {
+		t.Fatal("独立生成的公共参数指纹不应相同")
+	}
+
+	data, err := pp1.MarshalBinary()
+	if err != nil {
+		t.Fatalf("公共参数序列化失败: %v", err)
+	}
+	reloaded := new(Waters05IBEPublicParams)
+	if err := reloaded.UnmarshalBinary(data); err != nil {
+		t.Fatalf("公共参数反序列化失败: %v", err)
+	}
+	if reloaded.Fingerprint() != pp1.Fingerprint() {
+		t.Fatal("重新加载的公共参数指纹应保持一致")
+	}
+}
+
+// TestWaters05PublicParamsRejectInvalidG2 把序列化结果中 u_i 的一个点替换为无穷远点、不在曲线上或不在子群中的编码后，UnmarshalBinary 必须拒绝
+func TestWaters05PublicParamsRejectInvalidG2(t *testing.T) {
+	instance, _ := NewWaters05IBEInstance()
+	pp, err := instance.SetUp()
+	if err != nil {
+		t.Fatalf("系统初始化失败: %v", err)
+	}
+	data, err := pp.MarshalBinary()
+	if err != nil {
+		t.Fatalf("公共参数序列化失败: %v", err)
+	}
+	point := pp.ui[7].Bytes()
+	offset := bytes.Index(data, point[:])
+	if offset < 0 {
+		t.Fatal("序列化结果中找不到目标 G2 点")
+	}
+	for name, invalid := range invalidpoints.G2Encodings() {
+		tampered := bytes.Clone(data)
+		copy(tampered[offset:], invalid)
+		if err := new(Waters05IBEPublicParams).UnmarshalBinary(tampered); err == nil {
+			t.Fatalf("%s: 不合法的 G2 点应被拒绝", name)
+		}
+	}
+}
+
+// FuzzUnmarshal 向 Waters05IBEPublicParams.UnmarshalBinary 输入任意字节：不允许 panic；
+// 成功解析的参数重新序列化后必须能再次解析为相同的字节，且与输入等长时（全部为压缩编码）与输入完全相同
+func FuzzUnmarshal(f *testing.F) {
+	instance, _ := NewWaters05IBEInstance()
+	pp, err := instance.SetUp()
+	if err != nil {
+		f.Fatal(err)
+	}
+	data, err := pp.MarshalBinary()
+	if err != nil {
+		f.Fatal(err)
+	}
+	f.Add(data)
+	f.Add(data[:len(data)-1])
+	f.Add(data[:serialization.HeaderSize])
+	f.Add([]byte{})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		pp := new(Waters05IBEPublicParams)
+		if err := pp.UnmarshalBinary(data); err != nil {
+			return
+		}
+		canonical, err := pp.MarshalBinary()
+		if err != nil {
+			t.Fatalf("re-marshal failed: %v", err)
+		}
+		if len(canonical) == len(data) && !bytes.Equal(canonical, data) {
+			t.Fatalf("re-marshaled bytes differ from a compressed input")
+		}
+		again := new(Waters05IBEPublicParams)
+		if err := again.UnmarshalBinary(canonical); err != nil {
+			t.Fatalf("canonical encoding rejected: %v", err)
+		}
+		if again.Fingerprint() != pp.Fingerprint() {
+			t.Fatal("canonical encoding does not round-trip")
+		}
+	})
+}
@@ -1,9 +1,18 @@
 package waters05_ibe
 
 import (
+	"bytes"
+	"crypto/rand"
+	"errors"
 	"fmt"
 	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/mmsyan/GoPairingBasedCryptography/internal/randsource"
+	"github.com/mmsyan/GoPairingBasedCryptography/internal/selftest"
+	"github.com/mmsyan/GoPairingBasedCryptography/utils"
+	"io"
 	"testing"
+	"time"
 )
 
 // TestWaters05Ibe1 测试正确的情况
@@ -324,3 +333,234 @@ func TestWaters05Ibe4(t *testing.T) {
 
 	fmt.Println("\n✅ 测试通过：所有身份编码和特殊身份值都能正常工作")
 }
+
+// TestWaters05TimedIdentity 测试带有效期的身份
+// 场景：时间段 N 的私钥可以解密时间段 N 的密文，但不能解密时间段 N+1 的密文
+func TestWaters05TimedIdentity(t *testing.T) {
+	period := 7 * 24 * time.Hour
+	now := time.Date(2025, time.January, 15, 12, 0, 0, 0, time.UTC)
+
+	idEpochN, err := NewTimedIdentity("alice", now, period)
+	if err != nil {
+		t.Fatalf("创建身份失败: %v", err)
+	}
+	idEpochNext, err := NewTimedIdentity("alice", now.Add(period), period)
+	if err != nil {
+		t.Fatalf("创建身份失败: %v", err)
+	}
+	sameEpoch, _ := NewTimedIdentity("alice", now.Add(time.Minute), period)
+	if sameEpoch.Id != idEpochN.Id {
+		t.Fatal("同一时间段的身份应当相同")
+	}
+	if _, err := NewTimedIdentity("alice", now, 0); err == nil {
+		t.Fatal("时间段长度为 0 时应返回错误")
+	}
+	if _, err := CurrentEpochIdentity("alice", period); err != nil {
+		t.Fatalf("创建当前时间段身份失败: %v", err)
+	}
+
+	instance, _ := NewWaters05IBEInstance()
+	publicParams, _ := instance.SetUp()
+	secretKey, err := instance.KeyGenerate(idEpochN, publicParams)
+	if err != nil {
+		t.Fatalf("密钥生成失败: %v", err)
+	}
+
+	m, _ := new(bn254.GT).SetRandom()
+	message := &Waters05IBEMessage{Message: *m}
+
+	ctEpochN, _ := instance.Encrypt(message, idEpochN, publicParams)
+	decrypted, err := instance.Decrypt(ctEpochN, secretKey, publicParams)
+	if err != nil || decrypted.Message != message.Message {
+		t.Fatal("时间段 N 的私钥应能解密时间段 N 的密文")
+	}
+
+	ctEpochNext, _ := instance.Encrypt(message, idEpochNext, publicParams)
+	decrypted, err = instance.Decrypt(ctEpochNext, secretKey, publicParams)
+	if err == nil && decrypted.Message == message.Message {
+		t.Fatal("时间段 N 的私钥不应解密时间段 N+1 的密文")
+	}
+}
+
+// TestWaters05IbeZeroize 测试 Zeroize 清除主密钥，且清除后生成的私钥无法完成自检的加解密往返
+func TestWaters05IbeZeroize(t *testing.T) {
+	instance, err := NewWaters05IBEInstance()
+	if err != nil {
+		t.Fatal(err)
+	}
+	publicParams, err := instance.SetUp()
+	if err != nil {
+		t.Fatal(err)
+	}
+	instance.Zeroize()
+	if !instance.alpha.IsZero() || !instance.g2ExpAlpha.IsInfinity() {
+		t.Fatal("Zeroize 之后主密钥未被清除")
+	}
+	err = selftest.Run("zeroized", func() (*selftest.RoundTrip[bn254.GT, *Waters05IBECiphertext], error) {
+		return keyRoundTrip(instance, publicParams)
+	})
+	if err == nil {
+		t.Fatal("清除主密钥后生成的私钥仍能解密")
+	}
+}
+
+// TestWaters05IbeResamplesZeroRandomness 随机源先给出零值时，密钥生成与加密重新采样并正常完成；
+// 随机源始终给出零值时返回 ErrDegenerateRandomness 而不是生成退化的密文
+func TestWaters05IbeResamplesZeroRandomness(t *testing.T) {
+	instance, err := NewWaters05IBEInstance()
+	if err != nil {
+		t.Fatal(err)
+	}
+	publicParams, err := instance.SetUp()
+	if err != nil {
+		t.Fatal(err)
+	}
+	identity, err := NewWaters05IBEIdentity("resample@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := new(bn254.GT).SetRandom()
+	if err != nil {
+		t.Fatal(err)
+	}
+	message := &Waters05IBEMessage{Message: *m}
+
+	zeroScalar := make([]byte, fr.Bytes+16)
+	restore := randsource.Set(io.MultiReader(bytes.NewReader(zeroScalar), rand.Reader))
+	secretKey, err := instance.KeyGenerate(identity, publicParams)
+	restore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if secretKey.d2.IsInfinity() {
+		t.Fatal("r 为零时应重新采样")
+	}
+
+	restore = randsource.Set(io.MultiReader(bytes.NewReader(zeroScalar), rand.Reader))
+	ciphertext, err := instance.Encrypt(message, identity, publicParams)
+	restore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	decrypted, err := instance.Decrypt(ciphertext, secretKey, publicParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !decrypted.Message.Equal(m) {
+		t.Fatal("重新采样后的密文解密结果与原始消息不一致")
+	}
+
+	restore = randsource.Set(bytes.NewReader(make([]byte, len(zeroScalar)*utils.MaxRandomScalarAttempts)))
+	_, err = instance.Encrypt(message, identity, publicParams)
+	restore()
+	if !errors.Is(err, utils.ErrDegenerateRandomness) {
+		t.Fatalf("期望 ErrDegenerateRandomness，得到 %v", err)
+	}
+}
+
+// TestWaters05DecryptRaw 正确私钥的 DecryptRaw 结果等于明文，其他身份私钥的结果与之不同
+func TestWaters05DecryptRaw(t *testing.T) {
+	instance, err := NewWaters05IBEInstance()
+	if err != nil {
+		t.Fatal(err)
+	}
+	publicParams, err := instance.SetUp()
+	if err != nil {
+		t.Fatal(err)
+	}
+	alice, err := NewWaters05IBEIdentity("alice@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bob, err := NewWaters05IBEIdentity("bob@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	aliceKey, err := instance.KeyGenerate(alice, publicParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bobKey, err := instance.KeyGenerate(bob, publicParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := new(bn254.GT).SetRandom()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ciphertext, err := instance.Encrypt(&Waters05IBEMessage{Message: *m}, alice, publicParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	right, err := instance.DecryptRaw(ciphertext, aliceKey, publicParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wrong, err := instance.DecryptRaw(ciphertext, bobKey, publicParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !right.Equal(m) {
+		t.Fatal("正确私钥的 DecryptRaw 结果应等于明文")
+	}
+	if wrong.Equal(&right) {
+		t.Fatal("错误私钥的 DecryptRaw 结果不应等于正确结果")
+	}
+}
+
+// TestWaters05DecryptRejectsDifferentParams 在公共参数 A 下加密的密文（包括经过序列化往返的密文），
+// 用另一次 SetUp 得到的公共参数 B 及其私钥解密时返回 ErrParamsMismatch
+func TestWaters05DecryptRejectsDifferentParams(t *testing.T) {
+	identity, err := NewWaters05IBEIdentity("alice@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	instanceA, _ := NewWaters05IBEInstance()
+	publicParamsA, err := instanceA.SetUp()
+	if err != nil {
+		t.Fatal(err)
+	}
+	instanceB, _ := NewWaters05IBEInstance()
+	publicParamsB, err := instanceB.SetUp()
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyA, err := instanceA.KeyGenerate(identity, publicParamsA)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyB, err := instanceB.KeyGenerate(identity, publicParamsB)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := new(bn254.GT).SetRandom()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ciphertext, err := instanceA.Encrypt(&Waters05IBEMessage{Message: *m}, identity, publicParamsA)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := ciphertext.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	restored := new(Waters05IBECiphertext)
+	if err := restored.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, ct := range map[string]*Waters05IBECiphertext{"original": ciphertext, "restored": restored} {
+		if _, err := instanceB.Decrypt(ct, keyB, publicParamsB); !errors.Is(err, ErrParamsMismatch) {
+			t.Fatalf("%s: 期望 ErrParamsMismatch，得到 %v", name, err)
+		}
+		decrypted, err := instanceA.Decrypt(ct, keyA, publicParamsA)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if !decrypted.Message.Equal(m) {
+			t.Fatalf("%s: 解密消息与原始消息不匹配", name)
+		}
+	}
+}
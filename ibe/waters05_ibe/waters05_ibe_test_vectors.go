@@ -0,0 +1,161 @@
+package waters05_ibe
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/mmsyan/GoPairingBasedCryptography/serialization"
+	"math/big"
+	"os"
+)
+
+// testVectorScheme 是 Waters05 测试向量文件中 scheme 字段的取值
+const testVectorScheme = "waters05-ibe"
+
+// ErrTestVectorMismatch 表示按测试向量重新计算的结果与向量中记录的期望值不一致
+var ErrTestVectorMismatch = errors.New("test vector mismatch")
+
+// TestVector 是 Waters05 IBE 的一组固定测试向量，二进制字段均为十六进制字符串。
+//
+// 主密钥、公共参数与加密随机数都是固定的，因此密文可以逐字节复现；
+// KeyGenerate 内部的随机数无法固定，向量直接记录一个私钥，用于检查解密结果。
+type TestVector struct {
+	Name         string `json:"name"`
+	Alpha        string `json:"alpha"`         // 主密钥 alpha，32 字节大端序
+	PublicParams string `json:"public_params"` // Waters05IBEPublicParams.MarshalBinary 的输出
+	Identity     string `json:"identity"`      // NewWaters05IBEIdentity 的输入（原始字符串，非十六进制）
+	Randomness   string `json:"randomness"`    // EncryptWithRandomness 使用的 t，32 字节大端序
+	Message      string `json:"message"`       // 明文 GT 元素
+	SecretKey    string `json:"secret_key"`    // 该身份私钥的 MarshalBinary 输出
+	Ciphertext   string `json:"ciphertext"`    // 期望的密文 MarshalBinary 输出
+}
+
+// testVectorFile 是测试向量文件的顶层结构
+type testVectorFile struct {
+	Scheme  string       `json:"scheme"`
+	Vectors []TestVector `json:"vectors"`
+}
+
+// LoadTestVectors 读取 JSON 格式的测试向量文件（通常位于 testdata 目录）。
+//
+// 参数:
+//   - path: 测试向量文件路径
+//
+// 返回值:
+//   - []TestVector: 文件中的全部测试向量
+//   - error: 读取失败、JSON 格式错误或 scheme 字段不是 Waters05 时返回错误
+func LoadTestVectors(path string) ([]TestVector, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load test vectors: %w", err)
+	}
+	var file testVectorFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to load test vectors: %w", err)
+	}
+	if file.Scheme != testVectorScheme {
+		return nil, fmt.Errorf("failed to load test vectors: scheme is %q, want %q", file.Scheme, testVectorScheme)
+	}
+	return file.Vectors, nil
+}
+
+// Check 按测试向量重新执行加密与解密，并与记录的期望值比较：
+//  1. 由 alpha 构造实例，检查公共参数中的 g1^alpha 与之一致
+//  2. 使用固定的 t 加密明文，密文的序列化结果必须与 Ciphertext 逐字节相同
+//  3. 使用 SecretKey 解密 Ciphertext，必须恢复 Message
+//
+// 返回值:
+//   - error: 字段无法解码时返回解码错误；任一结果不一致时返回包装 ErrTestVectorMismatch 的错误
+func (v *TestVector) Check() error {
+	alpha, err := decodeTestVectorScalar("alpha", v.Alpha)
+	if err != nil {
+		return err
+	}
+	t, err := decodeTestVectorScalar("randomness", v.Randomness)
+	if err != nil {
+		return err
+	}
+	var publicParams Waters05IBEPublicParams
+	if err := decodeTestVectorField("public_params", v.PublicParams, publicParams.UnmarshalBinary); err != nil {
+		return err
+	}
+	var secretKey Waters05IBESecretKey
+	if err := decodeTestVectorField("secret_key", v.SecretKey, secretKey.UnmarshalBinary); err != nil {
+		return err
+	}
+	var expected Waters05IBECiphertext
+	if err := decodeTestVectorField("ciphertext", v.Ciphertext, expected.UnmarshalBinary); err != nil {
+		return err
+	}
+	var message Waters05IBEMessage
+	if err := decodeTestVectorField("message", v.Message, func(data []byte) error {
+		gt, n, err := serialization.DecodeGT(data)
+		if err == nil && n != len(data) {
+			err = errors.New("trailing bytes")
+		}
+		message.Message = gt
+		return err
+	}); err != nil {
+		return err
+	}
+	identity, err := NewWaters05IBEIdentity(v.Identity)
+	if err != nil {
+		return fmt.Errorf("test vector %q: %w", v.Name, err)
+	}
+
+	alphaBig := alpha.BigInt(new(big.Int))
+	instance := &Waters05IBEInstance{
+		alpha:      alpha,
+		g2ExpAlpha: *new(bn254.G2Affine).ScalarMultiplicationBase(alphaBig),
+	}
+	if g1ExpAlpha := new(bn254.G1Affine).ScalarMultiplicationBase(alphaBig); !g1ExpAlpha.Equal(&publicParams.g1ExpAlpha) {
+		return fmt.Errorf("test vector %q: public params do not match alpha: %w", v.Name, ErrTestVectorMismatch)
+	}
+
+	ciphertext, err := instance.EncryptWithRandomness(&message, identity, &publicParams, t)
+	if err != nil {
+		return fmt.Errorf("test vector %q: %w", v.Name, err)
+	}
+	got, _ := ciphertext.MarshalBinary()
+	want, _ := hex.DecodeString(v.Ciphertext)
+	if !bytes.Equal(got, want) {
+		return fmt.Errorf("test vector %q: ciphertext differs: %w", v.Name, ErrTestVectorMismatch)
+	}
+
+	decrypted, err := instance.Decrypt(&expected, &secretKey, &publicParams)
+	if err != nil {
+		return fmt.Errorf("test vector %q: %w", v.Name, err)
+	}
+	if !decrypted.Message.Equal(&message.Message) {
+		return fmt.Errorf("test vector %q: decrypted message differs: %w", v.Name, ErrTestVectorMismatch)
+	}
+	return nil
+}
+
+// decodeTestVectorField 把十六进制字段解码后交给 unmarshal，错误信息中注明字段名
+func decodeTestVectorField(name, value string, unmarshal func([]byte) error) error {
+	data, err := hex.DecodeString(value)
+	if err != nil {
+		return fmt.Errorf("test vector field %s: %w", name, err)
+	}
+	if err := unmarshal(data); err != nil {
+		return fmt.Errorf("test vector field %s: %w", name, err)
+	}
+	return nil
+}
+
+// decodeTestVectorScalar 解码 32 字节大端序的规范域元素
+func decodeTestVectorScalar(name, value string) (fr.Element, error) {
+	var e fr.Element
+	err := decodeTestVectorField(name, value, func(data []byte) error {
+		if len(data) != fr.Bytes {
+			return fmt.Errorf("expected %d bytes, got %d", fr.Bytes, len(data))
+		}
+		return e.SetBytesCanonical(data)
+	})
+	return e, err
+}
@@ -0,0 +1,115 @@
+package waters05_ibe
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/mmsyan/GoPairingBasedCryptography/serialization"
+	"math/big"
+	"os"
+	"testing"
+)
+
+// testVectorPath 是 Waters05 的黄金测试向量文件
+const testVectorPath = "testdata/waters05_ibe_vectors.json"
+
+// updateVectors 为 true 时重新生成黄金测试向量文件：go test -run TestWaters05TestVectors -update-vectors
+var updateVectors = flag.Bool("update-vectors", false, "regenerate testdata/waters05_ibe_vectors.json")
+
+// newTestVector 在 katWaters05 的固定参数下生成一组测试向量
+func newTestVector(t *testing.T, name, identityString string, messageExponent, randomness int64) TestVector {
+	t.Helper()
+	instance, publicParams := katWaters05()
+	identity, err := NewWaters05IBEIdentity(identityString)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _, g1, g2 := bn254.Generators()
+	eG1G2, err := bn254.Pair([]bn254.G1Affine{g1}, []bn254.G2Affine{g2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	message := &Waters05IBEMessage{Message: *new(bn254.GT).Exp(eG1G2, big.NewInt(messageExponent))}
+	tElement := *new(fr.Element).SetInt64(randomness)
+	ciphertext, err := instance.EncryptWithRandomness(message, identity, publicParams, tElement)
+	if err != nil {
+		t.Fatal(err)
+	}
+	secretKey, err := instance.KeyGenerate(identity, publicParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	alpha := instance.alpha.Bytes()
+	tBytes := tElement.Bytes()
+	pp, _ := publicParams.MarshalBinary()
+	sk, _ := secretKey.MarshalBinary()
+	ct, _ := ciphertext.MarshalBinary()
+	return TestVector{
+		Name:         name,
+		Alpha:        hex.EncodeToString(alpha[:]),
+		PublicParams: hex.EncodeToString(pp),
+		Identity:     identityString,
+		Randomness:   hex.EncodeToString(tBytes[:]),
+		Message:      hex.EncodeToString(serialization.MarshalGT(message.Message)),
+		SecretKey:    hex.EncodeToString(sk),
+		Ciphertext:   hex.EncodeToString(ct),
+	}
+}
+
+// TestWaters05TestVectors 加载黄金测试向量并逐一检查，防止重构时无意改变密文格式或加解密结果
+func TestWaters05TestVectors(t *testing.T) {
+	if *updateVectors {
+		file := testVectorFile{Scheme: testVectorScheme, Vectors: []TestVector{
+			newTestVector(t, "alice", "alice@example.com", 42, 123456789),
+			newTestVector(t, "bob", "bob@example.com", 7, 987654321),
+		}}
+		data, err := json.MarshalIndent(file, "", "  ")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(testVectorPath, append(data, '\n'), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	vectors, err := LoadTestVectors(testVectorPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(vectors) == 0 {
+		t.Fatal("测试向量文件为空")
+	}
+	for i := range vectors {
+		if err := vectors[i].Check(); err != nil {
+			t.Errorf("%s: %v", vectors[i].Name, err)
+		}
+	}
+}
+
+// TestWaters05TestVectorMismatch 篡改期望密文或明文后 Check 必须报告不一致
+func TestWaters05TestVectorMismatch(t *testing.T) {
+	vectors, err := LoadTestVectors(testVectorPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tampered := vectors[0]
+	tampered.Randomness = vectors[1].Randomness
+	if err := tampered.Check(); !errors.Is(err, ErrTestVectorMismatch) {
+		t.Fatalf("期望 ErrTestVectorMismatch，得到 %v", err)
+	}
+
+	tampered = vectors[0]
+	tampered.Message = vectors[1].Message
+	if err := tampered.Check(); !errors.Is(err, ErrTestVectorMismatch) {
+		t.Fatalf("期望 ErrTestVectorMismatch，得到 %v", err)
+	}
+
+	if _, err := LoadTestVectors("testdata/missing.json"); err == nil {
+		t.Fatal("不存在的文件应返回错误")
+	}
+}
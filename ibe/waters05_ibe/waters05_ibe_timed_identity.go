@@ -0,0 +1,43 @@
+package waters05_ibe
+
+import (
+	"errors"
+	"github.com/mmsyan/GoPairingBasedCryptography/utils"
+	"time"
+)
+
+// NewTimedIdentity 生成带有效期的身份，将 validUntil 所在的时间段编号编码进身份字符串 "base||epoch"。
+// 用户私钥只对该时间段有效，过期后需要向 PKG 申请下一个时间段的私钥。
+// 过期控制由 PKG 负责：PKG 应拒绝为早于当前时间段的身份签发私钥。
+//
+// 参数:
+//   - base: 基础身份字符串（例如邮箱地址）
+//   - validUntil: 私钥有效期内的任意时刻
+//   - period: 时间段长度，例如 7*24*time.Hour
+//
+// 返回值:
+//   - *Waters05IBEIdentity: 带时间段的身份向量
+//   - error: 基础身份为空或时间段长度非法时返回错误
+func NewTimedIdentity(base string, validUntil time.Time, period time.Duration) (*Waters05IBEIdentity, error) {
+	if len(base) == 0 {
+		return nil, errors.New("identity string cannot be empty")
+	}
+	epoch, err := utils.EpochOf(validUntil, period)
+	if err != nil {
+		return nil, err
+	}
+	return NewWaters05IBEIdentity(utils.EpochIdentity(base, epoch))
+}
+
+// CurrentEpochIdentity 生成当前时间段的身份，加密方应使用它作为接收者身份。
+//
+// 参数:
+//   - base: 基础身份字符串
+//   - period: 时间段长度
+//
+// 返回值:
+//   - *Waters05IBEIdentity: 当前时间段的身份向量
+//   - error: 基础身份为空或时间段长度非法时返回错误
+func CurrentEpochIdentity(base string, period time.Duration) (*Waters05IBEIdentity, error) {
+	return NewTimedIdentity(base, time.Now(), period)
+}
@@ -0,0 +1,99 @@
+// Package curve 提供对双线性配对曲线的最小抽象，
+// 使个别方案可以在 BN254 与 BLS12-381 之间切换而无需复制整套实现。
+//
+// 抽象只覆盖方案实际用到的操作：生成元、标量乘、点加、配对以及消息到标量的映射。
+// 群元素通过接口传递，具体实现分别包装 gnark-crypto 的 bn254 与 bls12-381 类型；
+// 不同曲线的元素混用时 Equal 返回 false，Add/Mul 会 panic。
+package curve
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// ID 标识一条配对友好曲线。
+type ID int
+
+const (
+	// BN254 约 100 位安全强度，运算最快，是本仓库其余方案使用的曲线。
+	BN254 ID = iota
+	// BLS12-381 约 120 位安全强度，群元素更大、配对更慢。
+	BLS12381
+)
+
+// String 返回曲线名称
+func (id ID) String() string {
+	switch id {
+	case BN254:
+		return "BN254"
+	case BLS12381:
+		return "BLS12-381"
+	default:
+		return fmt.Sprintf("unknown curve(%d)", int(id))
+	}
+}
+
+// G1 表示 G1 群中的元素
+type G1 interface {
+	Add(q G1) G1
+	ScalarMul(k *big.Int) G1
+	Equal(q G1) bool
+	IsInSubGroup() bool
+	Bytes() []byte
+}
+
+// G2 表示 G2 群中的元素
+type G2 interface {
+	Add(q G2) G2
+	ScalarMul(k *big.Int) G2
+	Equal(q G2) bool
+	IsInSubGroup() bool
+	Bytes() []byte
+}
+
+// GT 表示目标群 GT 中的元素
+type GT interface {
+	Mul(q GT) GT
+	Exp(k *big.Int) GT
+	Equal(q GT) bool
+	IsZero() bool
+	Bytes() []byte
+}
+
+// Backend 封装一条曲线上的群运算
+type Backend interface {
+	// ID 返回曲线标识
+	ID() ID
+	// Order 返回群阶 r（标量域模数）
+	Order() *big.Int
+	// BytesToScalar 将任意字节串映射为 [0, r) 内的标量
+	BytesToScalar(data []byte) *big.Int
+	// G1BaseMul 计算 k·g1
+	G1BaseMul(k *big.Int) G1
+	// G2BaseMul 计算 k·g2
+	G2BaseMul(k *big.Int) G2
+	// G1FromBytes 从 data 开头解析 G1.Bytes 输出的压缩编码，返回点及消耗的字节数；
+	// 非压缩编码、不在曲线上或不在素数阶子群中的点返回错误
+	G1FromBytes(data []byte) (G1, int, error)
+	// Pair 计算 ∏ e(p_i, q_i)
+	Pair(p []G1, q []G2) (GT, error)
+}
+
+// New 返回指定曲线的运算后端
+//
+// 参数:
+//   - id: 曲线标识
+//
+// 返回值:
+//   - Backend: 曲线运算后端
+//   - error: 不支持的曲线返回错误
+func New(id ID) (Backend, error) {
+	switch id {
+	case BN254:
+		return bn254Backend{}, nil
+	case BLS12381:
+		return bls12381Backend{}, nil
+	default:
+		return nil, fmt.Errorf("unsupported curve: %v", id)
+	}
+}
@@ -0,0 +1,149 @@
+package curve
+
+import (
+	"errors"
+	"fmt"
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"math/big"
+)
+
+type bls12381Backend struct{}
+
+type bls12381G1 struct{ p bls12381.G1Affine }
+
+type bls12381G2 struct{ p bls12381.G2Affine }
+
+type bls12381GT struct{ e bls12381.GT }
+
+func (bls12381Backend) ID() ID {
+	return BLS12381
+}
+
+func (bls12381Backend) Order() *big.Int {
+	return fr.Modulus()
+}
+
+// BytesToScalar 采用与 BN254 后端相同的映射方式（按大端序解释后模 r 约简）
+func (bls12381Backend) BytesToScalar(data []byte) *big.Int {
+	var e fr.Element
+	e.SetBytes(data)
+	return e.BigInt(new(big.Int))
+}
+
+func (bls12381Backend) G1BaseMul(k *big.Int) G1 {
+	var r bls12381G1
+	r.p.ScalarMultiplicationBase(k)
+	return &r
+}
+
+func (bls12381Backend) G2BaseMul(k *big.Int) G2 {
+	var r bls12381G2
+	r.p.ScalarMultiplicationBase(k)
+	return &r
+}
+
+func (bls12381Backend) G1FromBytes(data []byte) (G1, int, error) {
+	if len(data) < bls12381.SizeOfG1AffineCompressed {
+		return nil, 0, errors.New("not enough bytes to decode G1 point")
+	}
+	var r bls12381G1
+	if _, err := r.p.SetBytes(data[:bls12381.SizeOfG1AffineCompressed]); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode G1 point: %w", err)
+	}
+	return &r, bls12381.SizeOfG1AffineCompressed, nil
+}
+
+func (bls12381Backend) Pair(p []G1, q []G2) (GT, error) {
+	ps := make([]bls12381.G1Affine, len(p))
+	for i := range p {
+		ps[i] = p[i].(*bls12381G1).p
+	}
+	qs := make([]bls12381.G2Affine, len(q))
+	for i := range q {
+		qs[i] = q[i].(*bls12381G2).p
+	}
+	e, err := bls12381.Pair(ps, qs)
+	if err != nil {
+		return nil, err
+	}
+	return &bls12381GT{e: e}, nil
+}
+
+func (g *bls12381G1) Add(q G1) G1 {
+	var r bls12381G1
+	r.p.Add(&g.p, &q.(*bls12381G1).p)
+	return &r
+}
+
+func (g *bls12381G1) ScalarMul(k *big.Int) G1 {
+	var r bls12381G1
+	r.p.ScalarMultiplication(&g.p, k)
+	return &r
+}
+
+func (g *bls12381G1) Equal(q G1) bool {
+	o, ok := q.(*bls12381G1)
+	return ok && g.p.Equal(&o.p)
+}
+
+func (g *bls12381G1) IsInSubGroup() bool {
+	return g.p.IsInSubGroup()
+}
+
+func (g *bls12381G1) Bytes() []byte {
+	b := g.p.Bytes()
+	return b[:]
+}
+
+func (g *bls12381G2) Add(q G2) G2 {
+	var r bls12381G2
+	r.p.Add(&g.p, &q.(*bls12381G2).p)
+	return &r
+}
+
+func (g *bls12381G2) ScalarMul(k *big.Int) G2 {
+	var r bls12381G2
+	r.p.ScalarMultiplication(&g.p, k)
+	return &r
+}
+
+func (g *bls12381G2) Equal(q G2) bool {
+	o, ok := q.(*bls12381G2)
+	return ok && g.p.Equal(&o.p)
+}
+
+func (g *bls12381G2) IsInSubGroup() bool {
+	return g.p.IsInSubGroup()
+}
+
+func (g *bls12381G2) Bytes() []byte {
+	b := g.p.Bytes()
+	return b[:]
+}
+
+func (g *bls12381GT) Mul(q GT) GT {
+	var r bls12381GT
+	r.e.Mul(&g.e, &q.(*bls12381GT).e)
+	return &r
+}
+
+func (g *bls12381GT) Exp(k *big.Int) GT {
+	var r bls12381GT
+	r.e.Exp(g.e, k)
+	return &r
+}
+
+func (g *bls12381GT) Equal(q GT) bool {
+	o, ok := q.(*bls12381GT)
+	return ok && g.e.Equal(&o.e)
+}
+
+func (g *bls12381GT) IsZero() bool {
+	return g.e.IsZero()
+}
+
+func (g *bls12381GT) Bytes() []byte {
+	b := g.e.Bytes()
+	return b[:]
+}
@@ -0,0 +1,150 @@
+package curve
+
+import (
+	"errors"
+	"fmt"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/mmsyan/GoPairingBasedCryptography/hash"
+	"github.com/mmsyan/GoPairingBasedCryptography/metrics"
+	"math/big"
+)
+
+type bn254Backend struct{}
+
+type bn254G1 struct{ p bn254.G1Affine }
+
+type bn254G2 struct{ p bn254.G2Affine }
+
+type bn254GT struct{ e bn254.GT }
+
+func (bn254Backend) ID() ID {
+	return BN254
+}
+
+func (bn254Backend) Order() *big.Int {
+	return fr.Modulus()
+}
+
+// BytesToScalar 与 hash.BytesToField 保持一致，保证 BN254 下签名与原实现相同
+func (bn254Backend) BytesToScalar(data []byte) *big.Int {
+	e := hash.BytesToField(data)
+	return e.BigInt(new(big.Int))
+}
+
+func (bn254Backend) G1BaseMul(k *big.Int) G1 {
+	var r bn254G1
+	r.p.ScalarMultiplicationBase(k)
+	return &r
+}
+
+func (bn254Backend) G2BaseMul(k *big.Int) G2 {
+	var r bn254G2
+	r.p.ScalarMultiplicationBase(k)
+	return &r
+}
+
+func (bn254Backend) G1FromBytes(data []byte) (G1, int, error) {
+	if len(data) < bn254.SizeOfG1AffineCompressed {
+		return nil, 0, errors.New("not enough bytes to decode G1 point")
+	}
+	var r bn254G1
+	if _, err := r.p.SetBytes(data[:bn254.SizeOfG1AffineCompressed]); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode G1 point: %w", err)
+	}
+	return &r, bn254.SizeOfG1AffineCompressed, nil
+}
+
+func (bn254Backend) Pair(p []G1, q []G2) (GT, error) {
+	ps := make([]bn254.G1Affine, len(p))
+	for i := range p {
+		ps[i] = p[i].(*bn254G1).p
+	}
+	qs := make([]bn254.G2Affine, len(q))
+	for i := range q {
+		qs[i] = q[i].(*bn254G2).p
+	}
+	e, err := metrics.Pair(ps, qs)
+	if err != nil {
+		return nil, err
+	}
+	return &bn254GT{e: e}, nil
+}
+
+func (g *bn254G1) Add(q G1) G1 {
+	var r bn254G1
+	r.p.Add(&g.p, &q.(*bn254G1).p)
+	return &r
+}
+
+func (g *bn254G1) ScalarMul(k *big.Int) G1 {
+	var r bn254G1
+	r.p.ScalarMultiplication(&g.p, k)
+	return &r
+}
+
+func (g *bn254G1) Equal(q G1) bool {
+	o, ok := q.(*bn254G1)
+	return ok && g.p.Equal(&o.p)
+}
+
+func (g *bn254G1) IsInSubGroup() bool {
+	return g.p.IsInSubGroup()
+}
+
+func (g *bn254G1) Bytes() []byte {
+	b := g.p.Bytes()
+	return b[:]
+}
+
+func (g *bn254G2) Add(q G2) G2 {
+	var r bn254G2
+	r.p.Add(&g.p, &q.(*bn254G2).p)
+	return &r
+}
+
+func (g *bn254G2) ScalarMul(k *big.Int) G2 {
+	var r bn254G2
+	r.p.ScalarMultiplication(&g.p, k)
+	return &r
+}
+
+func (g *bn254G2) Equal(q G2) bool {
+	o, ok := q.(*bn254G2)
+	return ok && g.p.Equal(&o.p)
+}
+
+func (g *bn254G2) IsInSubGroup() bool {
+	return g.p.IsInSubGroup()
+}
+
+func (g *bn254G2) Bytes() []byte {
+	b := g.p.Bytes()
+	return b[:]
+}
+
+func (g *bn254GT) Mul(q GT) GT {
+	var r bn254GT
+	r.e.Mul(&g.e, &q.(*bn254GT).e)
+	return &r
+}
+
+func (g *bn254GT) Exp(k *big.Int) GT {
+	var r bn254GT
+	r.e.Exp(g.e, k)
+	return &r
+}
+
+func (g *bn254GT) Equal(q GT) bool {
+	o, ok := q.(*bn254GT)
+	return ok && g.e.Equal(&o.e)
+}
+
+func (g *bn254GT) IsZero() bool {
+	return g.e.IsZero()
+}
+
+func (g *bn254GT) Bytes() []byte {
+	b := g.e.Bytes()
+	return b[:]
+}
@@ -0,0 +1,70 @@
+// Package invalidpoints 构造各类不合法的 G2 点编码，供各方案测试 UnmarshalBinary 对不可信输入的拒绝。
+//
+// 所有编码都是 64 字节的压缩格式，与合法的压缩 G2 点等长，测试可以直接替换序列化结果中的某个点。
+package invalidpoints
+
+import (
+	"bytes"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
+)
+
+// G2Encodings 返回名称到不合法压缩 G2 编码的映射：
+//   - "infinity": 无穷远点
+//   - "non-residue x": x 坐标使 x³ + b' 不是平方数，不对应曲线上的任何点
+//   - "non-subgroup": 扭曲线上但不在素数阶子群中的点
+func G2Encodings() map[string][]byte {
+	var infinity bn254.G2Affine
+	infinityBytes := infinity.Bytes()
+
+	// 扭曲线 y² = x³ + b'，b' = 3 / (9 + u)
+	var b bn254.E2
+	b.A0.SetUint64(9)
+	b.A1.SetOne()
+	b.Inverse(&b)
+	var three fp.Element
+	three.SetUint64(3)
+	b.MulByElement(&b, &three)
+
+	var nonResidue, nonSubgroup []byte
+	for k := uint64(1); nonResidue == nil || nonSubgroup == nil; k++ {
+		var p bn254.G2Affine
+		p.X.A0.SetUint64(k)
+		p.X.A1.SetOne()
+		var rhs bn254.E2
+		rhs.Square(&p.X).Mul(&rhs, &p.X).Add(&rhs, &b)
+		if rhs.Legendre() != 1 {
+			if nonResidue == nil {
+				// 与合法压缩点使用相同的标志位，只是 x 坐标无法恢复出 y
+				var q bn254.G2Affine
+				q.X = p.X
+				q.Y.SetOne()
+				encoded := q.Bytes()
+				nonResidue = encoded[:]
+			}
+			continue
+		}
+		p.Y.Sqrt(&rhs)
+		if nonSubgroup == nil && p.IsOnCurve() && !p.IsInSubGroup() {
+			encoded := p.Bytes()
+			nonSubgroup = encoded[:]
+		}
+	}
+
+	return map[string][]byte{
+		"infinity":      infinityBytes[:],
+		"non-residue x": nonResidue,
+		"non-subgroup":  nonSubgroup,
+	}
+}
+
+// NonSubgroupG2 返回扭曲线上但不在素数阶子群中的 G2 点，即 G2Encodings()["non-subgroup"] 解码后的点，
+// 供测试直接构造含有不合法分量的结构体
+func NonSubgroupG2() bn254.G2Affine {
+	var p bn254.G2Affine
+	dec := bn254.NewDecoder(bytes.NewReader(G2Encodings()["non-subgroup"]), bn254.NoSubgroupChecks())
+	if err := dec.Decode(&p); err != nil {
+		panic(err)
+	}
+	return p
+}
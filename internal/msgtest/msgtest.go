@@ -0,0 +1,52 @@
+// Package msgtest 提供各方案消息类型 Bytes/SetBytes 往返共用的测试流程。
+package msgtest
+
+import (
+	"bytes"
+	"errors"
+	"github.com/mmsyan/GoPairingBasedCryptography/serialization"
+	"testing"
+)
+
+// CheckRoundTrip 对每个 input 检查 SetBytes(input) 之后 Bytes() 返回 input，且修改 input 不影响消息。
+//
+// 参数:
+//   - t: 当前测试
+//   - m: 待检查的消息
+//   - inputs: 依次设置的字节数据
+func CheckRoundTrip(t *testing.T, m serialization.Message, inputs ...[]byte) {
+	t.Helper()
+	for _, input := range inputs {
+		if err := m.SetBytes(input); err != nil {
+			t.Fatalf("SetBytes 失败: %v", err)
+		}
+		saved := append([]byte(nil), input...)
+		if len(input) > 0 {
+			input[0] ^= 1
+		}
+		got, err := m.Bytes()
+		if len(input) > 0 {
+			input[0] ^= 1
+		}
+		if err != nil {
+			t.Fatalf("Bytes 失败: %v", err)
+		}
+		if !bytes.Equal(got, saved) {
+			t.Fatalf("往返结果 %x 与输入 %x 不一致", got, saved)
+		}
+	}
+}
+
+// CheckGTRoundTrip 检查以 GT 元素为明文的消息类型：空数据、短数据与较长数据都能往返，
+// 没有经过 SetBytes 的零值消息的 Bytes 返回包装 serialization.ErrInvalidSealedBytes 的错误。
+//
+// 参数:
+//   - t: 当前测试
+//   - newMessage: 返回该方案的零值消息
+func CheckGTRoundTrip(t *testing.T, newMessage func() serialization.Message) {
+	t.Helper()
+	CheckRoundTrip(t, newMessage(), []byte{}, []byte("attack at dawn"), bytes.Repeat([]byte{0xff}, 1024))
+	if _, err := newMessage().Bytes(); !errors.Is(err, serialization.ErrInvalidSealedBytes) {
+		t.Fatalf("零值消息: 期望 ErrInvalidSealedBytes, 实际为 %v", err)
+	}
+}
@@ -0,0 +1,43 @@
+// Package pemtest 提供各 IBE 方案私钥 PEM 导出与导入共用的测试流程。
+package pemtest
+
+import (
+	"bytes"
+	"encoding/pem"
+	"testing"
+)
+
+// CheckSecretKey 检查私钥的 PEM 往返：导出结果以 pemType 类型头开始，导入后的私钥通过 decrypts 的解密检查，
+// 类型头被改成其他值的同一个 PEM 块在导入时被拒绝。
+//
+// 参数:
+//   - t: 当前测试
+//   - pemType: 方案的私钥 PEM 块类型
+//   - key: 待导出的私钥
+//   - export: 方案的 ExportSecretKeyPEM
+//   - importPEM: 方案的 ImportSecretKeyPEM
+//   - decrypts: 用导入的私钥解密一条发给 key 对应身份的密文，结果与原消息不一致时返回错误
+func CheckSecretKey[K any](t *testing.T, pemType string, key K, export func(K) ([]byte, error), importPEM func([]byte) (K, error), decrypts func(K) error) {
+	t.Helper()
+	pemData, err := export(key)
+	if err != nil {
+		t.Fatalf("导出PEM失败: %v", err)
+	}
+	if !bytes.HasPrefix(pemData, []byte("-----BEGIN "+pemType+"-----")) {
+		t.Fatalf("PEM类型头不正确: %s", pemData)
+	}
+	imported, err := importPEM(pemData)
+	if err != nil {
+		t.Fatalf("导入PEM失败: %v", err)
+	}
+	if err := decrypts(imported); err != nil {
+		t.Fatalf("导入的私钥解密失败: %v", err)
+	}
+
+	// 类型头不匹配时导入失败
+	block, _ := pem.Decode(pemData)
+	block.Type = "OTHER " + pemType
+	if _, err := importPEM(pem.EncodeToMemory(block)); err == nil {
+		t.Fatal("类型头不匹配时应当返回错误")
+	}
+}
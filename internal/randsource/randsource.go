@@ -0,0 +1,37 @@
+// Package randsource 保存 utils 采样随机标量使用的随机源，默认为 crypto/rand.Reader。
+//
+// 替换随机源只用于测试（例如注入先给出零值的随机源，检查退化标量的重新采样）。
+// 包位于 internal 下，模块以外的代码无法替换整个进程的随机源；
+// 随机源以原子值保存，读取与替换之间不存在数据竞争，但替换期间并发运行的方案同样会读到替换后的随机源。
+package randsource
+
+import (
+	"crypto/rand"
+	"io"
+	"sync/atomic"
+)
+
+// source 保存当前随机源，类型为 *io.Reader
+var source atomic.Pointer[io.Reader]
+
+func init() {
+	var r io.Reader = rand.Reader
+	source.Store(&r)
+}
+
+// Reader 返回当前随机源
+func Reader() io.Reader {
+	return *source.Load()
+}
+
+// Set 替换随机源，并返回恢复原随机源的函数。只应在测试中调用。
+//
+// 参数:
+//   - r: 新的随机源
+//
+// 返回值:
+//   - func(): 恢复原随机源的函数
+func Set(r io.Reader) func() {
+	old := source.Swap(&r)
+	return func() { source.Store(old) }
+}
@@ -0,0 +1,67 @@
+// Package selftest 实现各方案 SelfTest 共用的往返流程：生成随机消息 → 加密 → 解密 → 比较。
+//
+// 各方案只需在 setup 中完成 SetUp 与 KeyGenerate，并以闭包的形式给出加密与解密，
+// 错误包装与结果比较由 Run 统一完成。
+package selftest
+
+import (
+	"errors"
+	"fmt"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+)
+
+// ErrMismatch 表示解密结果与原始消息不一致
+var ErrMismatch = errors.New("decrypted message does not match")
+
+// RoundTrip 描述一次自检往返，M 为方案的明文类型，C 为密文类型
+type RoundTrip[M, C any] struct {
+	NewMessage func() (M, error)  // 生成随机明文
+	Encrypt    func(M) (C, error) // 使用 setup 中生成的公共参数加密
+	Decrypt    func(C) (M, error) // 使用 setup 中生成的私钥解密
+	Equal      func(a, b M) bool  // 比较两个明文
+}
+
+// Run 调用 setup 得到往返所需的闭包，加密一条随机消息后再解密，并与原消息比较。
+//
+// 参数:
+//   - name: 方案名称，用作错误信息的前缀
+//   - setup: 执行 SetUp 与 KeyGenerate，并返回绑定了其结果的 RoundTrip
+//
+// 返回值:
+//   - error: 任一步骤失败时返回包装后的错误；解密结果与原消息不一致时返回包装了 ErrMismatch 的错误
+func Run[M, C any](name string, setup func() (*RoundTrip[M, C], error)) error {
+	rt, err := setup()
+	if err != nil {
+		return fmt.Errorf("%s self test: %w", name, err)
+	}
+	m, err := rt.NewMessage()
+	if err != nil {
+		return fmt.Errorf("%s self test: %w", name, err)
+	}
+	ciphertext, err := rt.Encrypt(m)
+	if err != nil {
+		return fmt.Errorf("%s self test: %w", name, err)
+	}
+	decrypted, err := rt.Decrypt(ciphertext)
+	if err != nil {
+		return fmt.Errorf("%s self test: %w", name, err)
+	}
+	if !rt.Equal(decrypted, m) {
+		return fmt.Errorf("%s self test: %w", name, ErrMismatch)
+	}
+	return nil
+}
+
+// RandomGT 生成随机的 GT 元素，供明文为 GT 元素的方案用作 RoundTrip.NewMessage
+func RandomGT() (bn254.GT, error) {
+	m, err := new(bn254.GT).SetRandom()
+	if err != nil {
+		return bn254.GT{}, err
+	}
+	return *m, nil
+}
+
+// EqualGT 比较两个 GT 元素，供明文为 GT 元素的方案用作 RoundTrip.Equal
+func EqualGT(a, b bn254.GT) bool {
+	return a.Equal(&b)
+}
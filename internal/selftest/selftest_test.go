@@ -0,0 +1,49 @@
+package selftest
+
+import (
+	"errors"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"strings"
+	"testing"
+)
+
+// identityRoundTrip 返回一个“密文即明文”的往返，decrypt 可以替换为有缺陷的实现
+func identityRoundTrip(decrypt func(bn254.GT) (bn254.GT, error)) func() (*RoundTrip[bn254.GT, bn254.GT], error) {
+	return func() (*RoundTrip[bn254.GT, bn254.GT], error) {
+		return &RoundTrip[bn254.GT, bn254.GT]{
+			NewMessage: RandomGT,
+			Encrypt:    func(m bn254.GT) (bn254.GT, error) { return m, nil },
+			Decrypt:    decrypt,
+			Equal:      EqualGT,
+		}, nil
+	}
+}
+
+func TestRun(t *testing.T) {
+	ok := func(c bn254.GT) (bn254.GT, error) { return c, nil }
+	if err := Run("identity", identityRoundTrip(ok)); err != nil {
+		t.Fatal(err)
+	}
+
+	// 解密结果被篡改时自检必须失败
+	broken := func(c bn254.GT) (bn254.GT, error) {
+		c.Square(&c)
+		return c, nil
+	}
+	if err := Run("identity", identityRoundTrip(broken)); !errors.Is(err, ErrMismatch) {
+		t.Fatalf("expected ErrMismatch, got %v", err)
+	}
+
+	// 各步骤的错误带上方案名称后原样返回
+	errDecrypt := errors.New("decrypt failed")
+	failing := func(bn254.GT) (bn254.GT, error) { return bn254.GT{}, errDecrypt }
+	err := Run("identity", identityRoundTrip(failing))
+	if !errors.Is(err, errDecrypt) || !strings.HasPrefix(err.Error(), "identity self test: ") {
+		t.Fatalf("unexpected error %v", err)
+	}
+	errSetup := errors.New("setup failed")
+	err = Run("identity", func() (*RoundTrip[bn254.GT, bn254.GT], error) { return nil, errSetup })
+	if !errors.Is(err, errSetup) {
+		t.Fatalf("expected setup error, got %v", err)
+	}
+}
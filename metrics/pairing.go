@@ -0,0 +1,36 @@
+//go:build !metrics
+
+// Package metrics 提供性能分析用的配对计数器。
+// 默认构建下 Pair 直接调用 bn254.Pair，计数函数为空操作，没有任何额外开销；
+// 使用 `go test -tags metrics` 构建时启用全局配对计数器。
+//
+// 计数器统计的是配对个数（即 Miller 循环的输入对数），而不是调用次数：
+// 一次计算 ∏ e(P[i], Q[i]) 的多重配对计为 len(P) 个配对。
+package metrics
+
+import "github.com/consensys/gnark-crypto/ecc/bn254"
+
+// Pair 计算多重配对 ∏ e(P[i], Q[i])，等价于 bn254.Pair。
+func Pair(P []bn254.G1Affine, Q []bn254.G2Affine) (bn254.GT, error) {
+	return bn254.Pair(P, Q)
+}
+
+// PairFixedQ 使用 bn254.PrecomputeLines 预先计算的 G2 直线系数计算多重配对 ∏ e(P[i], Q[i])，等价于 bn254.PairFixedQ。
+// 注意 bn254.PairFixedQ 会原地改写 lines，需要重复使用时应传入副本。
+func PairFixedQ(P []bn254.G1Affine, lines [][2][len(bn254.LoopCounter)]bn254.LineEvaluationAff) (bn254.GT, error) {
+	return bn254.PairFixedQ(P, lines)
+}
+
+// MillerLoop 计算 ∏ e(P[i], Q[i]) 未做最终幂运算的 Miller 循环结果，等价于 bn254.MillerLoop。
+// 分批累加配对并只做一次最终幂运算的调用方（如 pairing.Accumulator）应使用它，以便配对计入计数。
+func MillerLoop(P []bn254.G1Affine, Q []bn254.G2Affine) (bn254.GT, error) {
+	return bn254.MillerLoop(P, Q)
+}
+
+// ResetPairingCount 将配对计数清零。默认构建下为空操作。
+func ResetPairingCount() {}
+
+// PairingCount 返回自上次清零以来 Pair、PairFixedQ 与 MillerLoop 计算的配对个数，多重配对按输入对数计。默认构建下恒为 0。
+func PairingCount() int64 {
+	return 0
+}
@@ -0,0 +1,41 @@
+//go:build metrics
+
+// Package metrics 提供性能分析用的配对计数器。
+// 使用 `-tags metrics` 构建时，Pair、PairFixedQ 与 MillerLoop 按输入对数 len(P) 增加全局计数器。
+package metrics
+
+import (
+	"sync/atomic"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+)
+
+var pairingCount atomic.Int64
+
+// Pair 计算多重配对 ∏ e(P[i], Q[i])，并将配对计数增加 len(P)。
+func Pair(P []bn254.G1Affine, Q []bn254.G2Affine) (bn254.GT, error) {
+	pairingCount.Add(int64(len(P)))
+	return bn254.Pair(P, Q)
+}
+
+// PairFixedQ 使用预先计算的 G2 直线系数计算多重配对，并将配对计数增加 len(P)。
+func PairFixedQ(P []bn254.G1Affine, lines [][2][len(bn254.LoopCounter)]bn254.LineEvaluationAff) (bn254.GT, error) {
+	pairingCount.Add(int64(len(P)))
+	return bn254.PairFixedQ(P, lines)
+}
+
+// MillerLoop 计算 Miller 循环结果（未做最终幂运算），并将配对计数增加 len(P)。
+func MillerLoop(P []bn254.G1Affine, Q []bn254.G2Affine) (bn254.GT, error) {
+	pairingCount.Add(int64(len(P)))
+	return bn254.MillerLoop(P, Q)
+}
+
+// ResetPairingCount 将配对计数清零。
+func ResetPairingCount() {
+	pairingCount.Store(0)
+}
+
+// PairingCount 返回自上次清零以来 Pair、PairFixedQ 与 MillerLoop 计算的配对个数，多重配对按输入对数计。
+func PairingCount() int64 {
+	return pairingCount.Load()
+}
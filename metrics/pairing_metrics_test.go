@@ -0,0 +1,34 @@
+//go:build metrics
+
+package metrics
+
+import (
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"testing"
+)
+
+// TestPairingCountCountsInputs 多重配对按输入对数计数，而不是按调用次数计数
+// 运行方式: go test -tags metrics ./metrics/
+func TestPairingCountCountsInputs(t *testing.T) {
+	_, _, g1, g2 := bn254.Generators()
+
+	ResetPairingCount()
+	if _, err := Pair([]bn254.G1Affine{g1, g1, g1}, []bn254.G2Affine{g2, g2, g2}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Pair([]bn254.G1Affine{g1}, []bn254.G2Affine{g2}); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := PairingCount(), int64(4); got != want {
+		t.Fatalf("PairingCount() = %d, want %d", got, want)
+	}
+
+	lines := [][2][len(bn254.LoopCounter)]bn254.LineEvaluationAff{bn254.PrecomputeLines(g2), bn254.PrecomputeLines(g2)}
+	ResetPairingCount()
+	if _, err := PairFixedQ([]bn254.G1Affine{g1, g1}, lines); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := PairingCount(), int64(2); got != want {
+		t.Fatalf("PairingCount() after PairFixedQ = %d, want %d", got, want)
+	}
+}
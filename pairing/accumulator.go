@@ -0,0 +1,95 @@
+package pairing
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/mmsyan/GoPairingBasedCryptography/metrics"
+)
+
+// accumulatorBatchSize 是累加器缓存的配对输入个数上限，缓存满时执行一次多重 Miller 循环
+const accumulatorBatchSize = 16
+
+// Accumulator 以流式方式计算配对乘积 ∏ e(P_i, Q_i)。
+//
+// 与把全部输入收集到切片后调用一次 bn254.Pair 不同，Accumulator 只缓存至多
+// accumulatorBatchSize 对输入：缓存满时对这一批执行 Miller 循环，并把未做最终幂的结果
+// 乘入累加值，随后清空缓存。Finalize 处理剩余输入并只做一次最终幂运算。
+// 因此内存占用与配对个数无关，计算量与一次多重配对相当。
+// Miller 循环经由 metrics.MillerLoop 计算，使用 `-tags metrics` 构建时每对输入计为一个配对。
+//
+// Accumulator 不是并发安全的。
+type Accumulator struct {
+	p   []bn254.G1Affine
+	q   []bn254.G2Affine
+	acc bn254.GT // 已处理批次的 Miller 循环结果之积
+	err error    // 第一个 Miller 循环错误，由 Finalize 返回
+}
+
+// NewAccumulator 创建一个空的配对累加器，空累加器的 Finalize 结果为 GT 中的单位元。
+//
+// 返回值:
+//   - *Accumulator: 新的累加器
+func NewAccumulator() *Accumulator {
+	a := &Accumulator{
+		p: make([]bn254.G1Affine, 0, accumulatorBatchSize),
+		q: make([]bn254.G2Affine, 0, accumulatorBatchSize),
+	}
+	a.acc.SetOne()
+	return a
+}
+
+// AddPair 向累加器中加入一对配对输入 e(p, q)。
+// 缓存满时立即执行该批次的 Miller 循环；出现的错误会保留到 Finalize 时返回。
+//
+// 参数:
+//   - p: G1 群上的元素
+//   - q: G2 群上的元素
+func (a *Accumulator) AddPair(p bn254.G1Affine, q bn254.G2Affine) {
+	if a.err != nil {
+		return
+	}
+	a.p = append(a.p, p)
+	a.q = append(a.q, q)
+	if len(a.p) == accumulatorBatchSize {
+		a.flush()
+	}
+}
+
+// Finalize 处理剩余的缓存输入，并对累加值执行一次最终幂运算。
+//
+// 返回值:
+//   - bn254.GT: 配对乘积 ∏ e(P_i, Q_i)
+//   - error: 任一批次的 Miller 循环失败时返回错误
+func (a *Accumulator) Finalize() (bn254.GT, error) {
+	a.flush()
+	if a.err != nil {
+		return bn254.GT{}, a.err
+	}
+	return bn254.FinalExponentiation(&a.acc), nil
+}
+
+// Reset 清空累加器的全部状态（已累加的结果、缓存的输入与保留的错误），使其等同于新创建的累加器。
+// 缓存的底层数组会被保留，高吞吐场景下可以对每次解密复用同一个累加器以避免重复分配。
+// Finalize 不会清空状态，复用前必须先调用 Reset；与其他方法一样，Reset 不能与同一累加器上的其他调用并发执行。
+func (a *Accumulator) Reset() {
+	a.p = a.p[:0]
+	a.q = a.q[:0]
+	a.acc.SetOne()
+	a.err = nil
+}
+
+// flush 对缓存中的输入执行 Miller 循环，把结果乘入累加值并清空缓存
+func (a *Accumulator) flush() {
+	if len(a.p) == 0 || a.err != nil {
+		return
+	}
+	ml, err := metrics.MillerLoop(a.p, a.q)
+	if err != nil {
+		a.err = fmt.Errorf("failed to accumulate pairing: %w", err)
+		return
+	}
+	a.acc.Mul(&a.acc, &ml)
+	a.p = a.p[:0]
+	a.q = a.q[:0]
+}
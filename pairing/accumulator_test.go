@@ -0,0 +1,120 @@
+package pairing
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+)
+
+// TestAccumulatorMatchesNaiveProduct 50 对输入（跨越多个批次）的累加结果应等于逐个配对后相乘的结果
+func TestAccumulatorMatchesNaiveProduct(t *testing.T) {
+	const n = 50
+	acc := NewAccumulator()
+	naive := new(bn254.GT).SetOne()
+	for i := 0; i < n; i++ {
+		a, _ := new(fr.Element).SetRandom()
+		b, _ := new(fr.Element).SetRandom()
+		p := new(bn254.G1Affine).ScalarMultiplicationBase(a.BigInt(new(big.Int)))
+		q := new(bn254.G2Affine).ScalarMultiplicationBase(b.BigInt(new(big.Int)))
+
+		acc.AddPair(*p, *q)
+		e, err := bn254.Pair([]bn254.G1Affine{*p}, []bn254.G2Affine{*q})
+		if err != nil {
+			t.Fatal(err)
+		}
+		naive.Mul(naive, &e)
+	}
+	got, err := acc.Finalize()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.Equal(naive) {
+		t.Fatal("accumulator result does not match the naive product of pairings")
+	}
+}
+
+func TestAccumulatorEmpty(t *testing.T) {
+	got, err := NewAccumulator().Finalize()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.IsOne() {
+		t.Fatal("empty accumulator should yield the identity of GT")
+	}
+}
+
+// randomPairs 生成 n 对随机的配对输入
+func randomPairs(n int) ([]bn254.G1Affine, []bn254.G2Affine) {
+	p := make([]bn254.G1Affine, n)
+	q := make([]bn254.G2Affine, n)
+	for i := 0; i < n; i++ {
+		a, _ := new(fr.Element).SetRandom()
+		b, _ := new(fr.Element).SetRandom()
+		p[i].ScalarMultiplicationBase(a.BigInt(new(big.Int)))
+		q[i].ScalarMultiplicationBase(b.BigInt(new(big.Int)))
+	}
+	return p, q
+}
+
+// TestAccumulatorReset 累加、Finalize、Reset 后再累加另一组输入，两次结果分别等于各自的多重配对
+func TestAccumulatorReset(t *testing.T) {
+	acc := NewAccumulator()
+	for _, n := range []int{20, 5} {
+		p, q := randomPairs(n)
+		for i := range p {
+			acc.AddPair(p[i], q[i])
+		}
+		got, err := acc.Finalize()
+		if err != nil {
+			t.Fatal(err)
+		}
+		want, err := bn254.Pair(p, q)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !got.Equal(&want) {
+			t.Fatalf("%d pairs: reused accumulator does not match bn254.Pair", n)
+		}
+		acc.Reset()
+	}
+	got, err := acc.Finalize()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.IsOne() {
+		t.Fatal("reset accumulator should yield the identity of GT")
+	}
+}
+
+// BenchmarkAccumulatorFresh 每次解密新建累加器
+func BenchmarkAccumulatorFresh(b *testing.B) {
+	p, q := randomPairs(4)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		acc := NewAccumulator()
+		for k := range p {
+			acc.AddPair(p[k], q[k])
+		}
+		if _, err := acc.Finalize(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkAccumulatorReuse 用 Reset 复用同一个累加器
+func BenchmarkAccumulatorReuse(b *testing.B) {
+	p, q := randomPairs(4)
+	acc := NewAccumulator()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		acc.Reset()
+		for k := range p {
+			acc.AddPair(p[k], q[k])
+		}
+		if _, err := acc.Finalize(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,24 @@
+// Package pairing 提供与 BN254 配对相关的辅助函数。
+package pairing
+
+import "github.com/consensys/gnark-crypto/ecc/bn254"
+
+// ClearCofactorG2 将 G2 曲线 E'(Fp2) 上的任意点乘以辅因子，映射到素数阶子群 G2 中。
+//
+// BN254 的 G1 辅因子为 1，曲线上的点天然位于 G1；但 G2 所在扭曲线的阶为 h·r，
+// 由 map-to-curve 或外部输入得到的点一般不在 r 阶子群中，直接参与配对会破坏方案的安全性。
+// bn254.HashToG2（以及 hash.ToG2 / hash.BytesToG2）内部已经执行了辅因子清除；
+// 自行调用 bn254.MapToCurve2 等底层映射得到的点必须先经过本函数处理。
+//
+// 参数:
+//   - p: 扭曲线上的点（Jacobian 坐标）
+//
+// 返回值:
+//   - bn254.G2Affine: 位于素数阶子群 G2 中的点
+func ClearCofactorG2(p bn254.G2Jac) bn254.G2Affine {
+	var cleared bn254.G2Jac
+	cleared.ClearCofactor(&p)
+	var result bn254.G2Affine
+	result.FromJacobian(&cleared)
+	return result
+}
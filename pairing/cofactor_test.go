@@ -0,0 +1,54 @@
+package pairing
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
+	"github.com/mmsyan/GoPairingBasedCryptography/hash"
+)
+
+// rawMapToG2 只执行 map-to-curve，不清除辅因子
+func rawMapToG2(msg []byte) bn254.G2Affine {
+	u, err := fp.Hash(msg, []byte("raw map to curve test"), 2)
+	if err != nil {
+		panic(err)
+	}
+	return bn254.MapToCurve2(&bn254.E2{A0: u[0], A1: u[1]})
+}
+
+func TestClearCofactorG2(t *testing.T) {
+	rawNotInSubgroup := 0
+	for _, msg := range []string{"alice", "bob", "charlie", "dave"} {
+		raw := rawMapToG2([]byte(msg))
+		if !raw.IsOnCurve() {
+			t.Fatalf("%s: raw mapped point should be on the curve", msg)
+		}
+		if !raw.IsInSubGroup() {
+			rawNotInSubgroup++
+		}
+
+		var rawJac bn254.G2Jac
+		rawJac.FromAffine(&raw)
+		cleared := ClearCofactorG2(rawJac)
+		if !cleared.IsInSubGroup() {
+			t.Fatalf("%s: cleared point should be in the prime-order subgroup", msg)
+		}
+	}
+	if rawNotInSubgroup == 0 {
+		t.Fatal("expected raw mapped points to lie outside the prime-order subgroup")
+	}
+}
+
+func TestHashToG2IsInSubgroup(t *testing.T) {
+	for _, msg := range []string{"alice", "bob"} {
+		p := hash.ToG2(msg)
+		if !p.IsInSubGroup() {
+			t.Fatalf("hash.ToG2(%q) should be in the prime-order subgroup", msg)
+		}
+		p = hash.BytesToG2([]byte(msg))
+		if !p.IsInSubGroup() {
+			t.Fatalf("hash.BytesToG2(%q) should be in the prime-order subgroup", msg)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package pairing
+
+import (
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+)
+
+// FixedBaseG1 是 G1 上某个固定底 P 的窗口预计算表，窗口参数与 FixedBaseG2 相同。
+//
+// 表中保存 table[j][d-1] = d·16^j·P，占用约 60 KB；此后每次标量乘法只需 64 次混合加法。
+// 与 bn254.G1Affine.ScalarMultiplication 一样，计算时间与标量有关，不是常数时间的。
+//
+// FixedBaseG1 构造后只读，可以在多个 goroutine 中并发使用。
+type FixedBaseG1 struct {
+	table [fixedBaseWindows][fixedBaseDigits]bn254.G1Affine
+}
+
+// NewFixedBaseG1 为底 base 构造窗口预计算表。
+//
+// 参数:
+//   - base: 固定底，应位于 G1 中
+//
+// 返回值:
+//   - *FixedBaseG1: 预计算表
+func NewFixedBaseG1(base bn254.G1Affine) *FixedBaseG1 {
+	t := &FixedBaseG1{}
+	if base.IsInfinity() {
+		return t // 全部表项为无穷远点
+	}
+
+	// 先求出各窗口的底 16^j·P 并统一转换为仿射坐标，使后续的累加都能使用混合加法
+	windowBases := make([]bn254.G1Jac, fixedBaseWindows)
+	windowBases[0].FromAffine(&base)
+	for j := 1; j < fixedBaseWindows; j++ {
+		windowBases[j] = windowBases[j-1]
+		for k := 0; k < fixedBaseWindowBits; k++ {
+			windowBases[j].DoubleAssign()
+		}
+	}
+	windowBasesAffine := batchJacobianToAffineG1(windowBases)
+
+	points := make([]bn254.G1Jac, 0, fixedBaseWindows*fixedBaseDigits)
+	for j := 0; j < fixedBaseWindows; j++ {
+		var acc bn254.G1Jac
+		acc.FromAffine(&windowBasesAffine[j])
+		points = append(points, acc)
+		for d := 2; d <= fixedBaseDigits; d++ {
+			acc.AddMixed(&windowBasesAffine[j])
+			points = append(points, acc)
+		}
+	}
+
+	affine := batchJacobianToAffineG1(points)
+	for j := 0; j < fixedBaseWindows; j++ {
+		copy(t.table[j][:], affine[j*fixedBaseDigits:(j+1)*fixedBaseDigits])
+	}
+	return t
+}
+
+// ScalarMultiplication 计算 s·P，结果与 bn254.G1Affine.ScalarMultiplication 相同。
+//
+// 参数:
+//   - s: 标量
+//
+// 返回值:
+//   - bn254.G1Affine: s·P
+func (t *FixedBaseG1) ScalarMultiplication(s *fr.Element) bn254.G1Affine {
+	bits := s.Bits()
+	var acc bn254.G1Jac
+	acc.X.SetOne()
+	acc.Y.SetOne() // Z = 0，即无穷远点
+	for j := 0; j < fixedBaseWindows; j++ {
+		bit := j * fixedBaseWindowBits
+		d := (bits[bit/64] >> (bit % 64)) & fixedBaseDigits
+		if d != 0 {
+			acc.AddMixed(&t.table[j][d-1])
+		}
+	}
+	var result bn254.G1Affine
+	result.FromJacobian(&acc)
+	return result
+}
+
+// batchJacobianToAffineG1 用一次批量求逆把一组 Z 坐标非零的 Jacobian 点转换为仿射坐标
+func batchJacobianToAffineG1(points []bn254.G1Jac) []bn254.G1Affine {
+	zs := make([]fp.Element, len(points))
+	for i := range points {
+		zs[i] = points[i].Z
+	}
+	zInvs := fp.BatchInvert(zs)
+
+	result := make([]bn254.G1Affine, len(points))
+	for i := range points {
+		var zInv2 fp.Element
+		zInv2.Square(&zInvs[i])
+		result[i].X.Mul(&points[i].X, &zInv2)
+		result[i].Y.Mul(&points[i].Y, &zInv2).Mul(&result[i].Y, &zInvs[i])
+	}
+	return result
+}
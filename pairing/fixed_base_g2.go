@@ -0,0 +1,116 @@
+package pairing
+
+import (
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+)
+
+const (
+	fixedBaseWindowBits = 4                               // 每个窗口的比特数
+	fixedBaseWindows    = fr.Bits/fixedBaseWindowBits + 1 // 覆盖 254 比特标量所需的窗口个数
+	fixedBaseDigits     = 1<<fixedBaseWindowBits - 1      // 每个窗口的非零取值个数
+)
+
+// FixedBaseG2 是 G2 上某个固定底 P 的窗口预计算表，用于对同一个底做大量标量乘法。
+//
+// 表中保存 table[j][d-1] = d·16^j·P（j 为窗口下标，d ∈ [1, 15]），构造需要约一千次点加，
+// 此后每次标量乘法只需每个窗口一次混合加法，不再需要倍点。
+// 当同一个底要乘以几十个以上的标量时（例如批量加密中的 T_i^{s_k}），总开销明显低于逐个调用
+// bn254.G2Affine.ScalarMultiplication。与 ScalarMultiplication 一样，计算时间与标量有关，不是常数时间的。
+//
+// FixedBaseG2 构造后只读，可以在多个 goroutine 中并发使用。
+type FixedBaseG2 struct {
+	table [fixedBaseWindows][fixedBaseDigits]bn254.G2Affine
+}
+
+// NewFixedBaseG2 为底 base 构造窗口预计算表。
+//
+// 参数:
+//   - base: 固定底，应位于 G2 的素数阶子群中
+//
+// 返回值:
+//   - *FixedBaseG2: 预计算表
+func NewFixedBaseG2(base bn254.G2Affine) *FixedBaseG2 {
+	t := &FixedBaseG2{}
+	if base.IsInfinity() {
+		return t // 全部表项为无穷远点
+	}
+
+	// 先求出各窗口的底 16^j·P 并统一转换为仿射坐标，使后续的累加都能使用更便宜的混合加法
+	windowBases := make([]bn254.G2Jac, fixedBaseWindows)
+	windowBases[0].FromAffine(&base)
+	for j := 1; j < fixedBaseWindows; j++ {
+		windowBases[j] = windowBases[j-1]
+		for k := 0; k < fixedBaseWindowBits; k++ {
+			windowBases[j].DoubleAssign()
+		}
+	}
+	windowBasesAffine := batchJacobianToAffineG2(windowBases)
+
+	// 再在 Jacobian 坐标下计算全部表项 d·16^j·P，最后用一次批量求逆统一转换为仿射坐标
+	points := make([]bn254.G2Jac, 0, fixedBaseWindows*fixedBaseDigits)
+	for j := 0; j < fixedBaseWindows; j++ {
+		var acc bn254.G2Jac
+		acc.FromAffine(&windowBasesAffine[j])
+		points = append(points, acc)
+		for d := 2; d <= fixedBaseDigits; d++ {
+			acc.AddMixed(&windowBasesAffine[j])
+			points = append(points, acc)
+		}
+	}
+
+	affine := batchJacobianToAffineG2(points)
+	for j := 0; j < fixedBaseWindows; j++ {
+		copy(t.table[j][:], affine[j*fixedBaseDigits:(j+1)*fixedBaseDigits])
+	}
+	return t
+}
+
+// ScalarMultiplication 计算 s·P，结果与 bn254.G2Affine.ScalarMultiplication 相同。
+//
+// 参数:
+//   - s: 标量
+//
+// 返回值:
+//   - bn254.G2Affine: s·P
+func (t *FixedBaseG2) ScalarMultiplication(s *fr.Element) bn254.G2Affine {
+	bits := s.Bits() // 常规（非 Montgomery）形式的小端序 64 位字
+	var acc bn254.G2Jac
+	acc.X.SetOne()
+	acc.Y.SetOne() // Z = 0，即无穷远点
+	for j := 0; j < fixedBaseWindows; j++ {
+		bit := j * fixedBaseWindowBits
+		d := (bits[bit/64] >> (bit % 64)) & fixedBaseDigits
+		if d != 0 {
+			acc.AddMixed(&t.table[j][d-1])
+		}
+	}
+	var result bn254.G2Affine
+	result.FromJacobian(&acc)
+	return result
+}
+
+// batchJacobianToAffineG2 用 Montgomery 批量求逆把一组 Z 坐标非零的 Jacobian 点转换为仿射坐标
+func batchJacobianToAffineG2(points []bn254.G2Jac) []bn254.G2Affine {
+	// prefix[i] = Z_0 · Z_1 · ... · Z_{i-1}
+	prefix := make([]bn254.E2, len(points))
+	var acc bn254.E2
+	acc.SetOne()
+	for i := range points {
+		prefix[i] = acc
+		acc.Mul(&acc, &points[i].Z)
+	}
+	acc.Inverse(&acc)
+
+	result := make([]bn254.G2Affine, len(points))
+	for i := len(points) - 1; i >= 0; i-- {
+		// zInv = 1/Z_i = prefix[i] / (Z_0 · ... · Z_i)
+		var zInv, zInv2 bn254.E2
+		zInv.Mul(&acc, &prefix[i])
+		acc.Mul(&acc, &points[i].Z)
+		zInv2.Square(&zInv)
+		result[i].X.Mul(&points[i].X, &zInv2)
+		result[i].Y.Mul(&points[i].Y, &zInv2).Mul(&result[i].Y, &zInv)
+	}
+	return result
+}
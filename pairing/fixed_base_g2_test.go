@@ -0,0 +1,43 @@
+package pairing
+
+import (
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"math/big"
+	"testing"
+)
+
+// TestFixedBaseG2 预计算表的标量乘法与 bn254 的结果一致，包括边界标量 0、1、r-1
+func TestFixedBaseG2(t *testing.T) {
+	var k fr.Element
+	if _, err := k.SetRandom(); err != nil {
+		t.Fatal(err)
+	}
+	base := *new(bn254.G2Affine).ScalarMultiplicationBase(k.BigInt(new(big.Int)))
+	table := NewFixedBaseG2(base)
+
+	scalars := []fr.Element{fr.NewElement(0), fr.NewElement(1), fr.NewElement(16)}
+	var minusOne fr.Element
+	minusOne.SetOne().Neg(&minusOne)
+	scalars = append(scalars, minusOne)
+	for i := 0; i < 20; i++ {
+		var s fr.Element
+		if _, err := s.SetRandom(); err != nil {
+			t.Fatal(err)
+		}
+		scalars = append(scalars, s)
+	}
+	for _, s := range scalars {
+		got := table.ScalarMultiplication(&s)
+		want := *new(bn254.G2Affine).ScalarMultiplication(&base, s.BigInt(new(big.Int)))
+		if !got.Equal(&want) {
+			t.Fatalf("s = %s: 预计算表的结果与 ScalarMultiplication 不一致", s.String())
+		}
+	}
+
+	var infinity bn254.G2Affine
+	got := NewFixedBaseG2(infinity).ScalarMultiplication(&scalars[4])
+	if !got.IsInfinity() {
+		t.Fatal("无穷远点的倍数应为无穷远点")
+	}
+}
@@ -0,0 +1,94 @@
+package serialization
+
+import (
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/mmsyan/GoPairingBasedCryptography/aead"
+	"github.com/mmsyan/GoPairingBasedCryptography/hash"
+)
+
+// ErrInvalidSealedBytes 表示 OpenBytes 的输入不是 SealBytes 在该 GT 元素下的输出，
+// 例如数据被篡改，或 GT 元素是用错误的密钥解密得到的
+var ErrInvalidSealedBytes = errors.New("invalid sealed bytes")
+
+// sealKDFInfo 是 SealBytes 派生 DEM 密钥时使用的 HKDF 域分隔标签前缀，后接 AEAD 构造名称
+const sealKDFInfo = "serialization-sealed-bytes-"
+
+// SealBytes 让以 GT 元素为明文的方案（Waters05、Gentry06、BB04、FIBE、CP-ABE 等）以 KEM/DEM 结构加密字节数据:
+// 随机选取 GT 元素 K 作为方案的明文（KEM），再用 hash.DeriveKeyFromGT(K) 派生的密钥以 aead.Default 加密 data（DEM）。
+//
+// 数据不嵌入 GT 元素本身：把字节直接写入 Fp12 系数得到的元素不在 r 阶子群中，
+// 方案密文 C = M·Y^s 满足 C^r = M^r，会泄露明文。调用方加密 K 后须把 sealed 与方案密文一起发送，
+// 接收方解密得到 K 后用 OpenBytes 取回 data。
+//
+// 布局: AEAD 编号(1字节) || 随机数 || AEAD 密文（含认证标签），AEAD 编号同时作为附加数据受认证保护。
+//
+// 参数:
+//   - data: 待加密的字节数据
+//
+// 返回值:
+//   - bn254.GT: 交给方案加密的会话密钥 K
+//   - []byte: DEM 密文
+//   - error: 随机数生成失败时返回错误
+func SealBytes(data []byte) (bn254.GT, []byte, error) {
+	k, err := new(bn254.GT).SetRandom()
+	if err != nil {
+		return bn254.GT{}, nil, fmt.Errorf("failed to generate session key: %w", err)
+	}
+	dem, err := newSealAEAD(*k, aead.Default)
+	if err != nil {
+		return bn254.GT{}, nil, err
+	}
+	sealed := make([]byte, 1+dem.NonceSize(), 1+dem.NonceSize()+len(data)+dem.Overhead())
+	sealed[0] = byte(aead.Default.ID())
+	if _, err := rand.Read(sealed[1:]); err != nil {
+		return bn254.GT{}, nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	return *k, dem.Seal(sealed, sealed[1:], data, sealed[:1]), nil
+}
+
+// OpenBytes 用解密得到的会话密钥 K 取回 SealBytes 加密的字节数据，AEAD 构造按 sealed 的头部选择。
+// K 不正确（例如私钥不匹配）或 sealed 被篡改时由认证标签校验失败报错，而不会返回错误的数据。
+//
+// 参数:
+//   - k: 方案解密得到的会话密钥
+//   - sealed: SealBytes 输出的 DEM 密文
+//
+// 返回值:
+//   - []byte: 原始字节数据
+//   - error: 头部 AEAD 编号未知时返回包装 aead.ErrUnknownAEAD 的错误；长度不足或认证失败时返回包装 ErrInvalidSealedBytes 的错误
+func OpenBytes(k bn254.GT, sealed []byte) ([]byte, error) {
+	if len(sealed) == 0 {
+		return nil, fmt.Errorf("failed to open sealed bytes: %w: empty input", ErrInvalidSealedBytes)
+	}
+	factory, err := aead.Lookup(aead.ID(sealed[0]))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open sealed bytes: %w", err)
+	}
+	dem, err := newSealAEAD(k, factory)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < 1+dem.NonceSize()+dem.Overhead() {
+		return nil, fmt.Errorf("failed to open sealed bytes: %w: not enough bytes", ErrInvalidSealedBytes)
+	}
+	nonce := sealed[1 : 1+dem.NonceSize()]
+	data, err := dem.Open(nil, nonce, sealed[1+dem.NonceSize():], sealed[:1])
+	if err != nil {
+		return nil, fmt.Errorf("failed to open sealed bytes: %w", ErrInvalidSealedBytes)
+	}
+	return data, nil
+}
+
+// newSealAEAD 由会话密钥派生 factory 指定的 AEAD 实例，密钥为 HKDF-SHA256(K, sealKDFInfo || factory.Name())
+func newSealAEAD(k bn254.GT, factory aead.Factory) (cipher.AEAD, error) {
+	key := hash.DeriveKeyFromGT(k, []byte(sealKDFInfo+factory.Name()), factory.KeySize())
+	dem, err := factory.New(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	return dem, nil
+}
@@ -0,0 +1,75 @@
+package serialization
+
+import (
+	"bytes"
+	"errors"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/mmsyan/GoPairingBasedCryptography/aead"
+	"testing"
+)
+
+func TestSealBytesRoundTrip(t *testing.T) {
+	for _, data := range [][]byte{nil, []byte("hello"), bytes.Repeat([]byte{0xff}, 4096)} {
+		k, sealed, err := SealBytes(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		recovered, err := OpenBytes(k, sealed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(recovered, data) {
+			t.Fatalf("round trip mismatch for %d bytes", len(data))
+		}
+	}
+
+	a, _, err := SealBytes([]byte("attack at dawn"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _, err := SealBytes([]byte("attack at dawn"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.Equal(&b) {
+		t.Fatal("session keys should be randomized")
+	}
+}
+
+// TestOpenBytesDetectsTampering 翻转 DEM 密文的任意一位或使用错误的会话密钥，OpenBytes 都应报错
+func TestOpenBytesDetectsTampering(t *testing.T) {
+	k, sealed, err := SealBytes([]byte("attack at dawn"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 1; i < len(sealed); i++ {
+		tampered := append([]byte(nil), sealed...)
+		tampered[i] ^= 1
+		if _, err := OpenBytes(k, tampered); !errors.Is(err, ErrInvalidSealedBytes) {
+			t.Fatalf("flipping byte %d: expected ErrInvalidSealedBytes, got %v", i, err)
+		}
+	}
+	if _, err := OpenBytes(k, sealed[:len(sealed)-1]); !errors.Is(err, ErrInvalidSealedBytes) {
+		t.Fatalf("truncated input: expected ErrInvalidSealedBytes, got %v", err)
+	}
+
+	// 头部改成另一个已知构造时，密钥与附加数据都随之改变，同样无法通过认证
+	switched := append([]byte(nil), sealed...)
+	switched[0] = byte(aead.IDChaCha20Poly1305)
+	if _, err := OpenBytes(k, switched); !errors.Is(err, ErrInvalidSealedBytes) {
+		t.Fatalf("switched AEAD: expected ErrInvalidSealedBytes, got %v", err)
+	}
+	switched[0] = 0
+	if _, err := OpenBytes(k, switched); !errors.Is(err, aead.ErrUnknownAEAD) {
+		t.Fatalf("unknown AEAD: expected ErrUnknownAEAD, got %v", err)
+	}
+
+	// 用错误的密钥解密相当于得到一个随机的会话密钥
+	wrong, err := new(bn254.GT).SetRandom()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := OpenBytes(*wrong, sealed); !errors.Is(err, ErrInvalidSealedBytes) {
+		t.Fatalf("wrong key: expected ErrInvalidSealedBytes, got %v", err)
+	}
+}
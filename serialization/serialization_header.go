@@ -0,0 +1,101 @@
+package serialization
+
+import (
+	"errors"
+	"fmt"
+)
+
+// HeaderSize 是 MarshalBinary 输出头部的长度: 魔数(2字节) || 版本(1字节) || 方案编号(1字节)
+const HeaderSize = 4
+
+// FormatVersion 是当前的序列化格式版本。布局发生不兼容的变化时递增，旧版本的数据会被 CheckHeader 拒绝。
+const FormatVersion byte = 1
+
+// headerMagic 是所有序列化数据的前两个字节，用于快速识别本仓库产生的数据
+var headerMagic = [2]byte{'P', 'B'}
+
+var (
+	// ErrInvalidHeader 表示数据过短或不以魔数开头，不是 MarshalBinary 的输出
+	ErrInvalidHeader = errors.New("invalid serialization header")
+
+	// ErrUnsupportedVersion 表示数据的格式版本不是 FormatVersion
+	ErrUnsupportedVersion = errors.New("unsupported version")
+
+	// ErrSchemeMismatch 表示数据属于另一个方案，例如把 Gentry06 私钥交给 Waters05 的 UnmarshalBinary
+	ErrSchemeMismatch = errors.New("scheme mismatch")
+)
+
+// SchemeID 是写入序列化头部的方案编号。编号一经分配不能修改或复用，新方案只能追加在末尾。
+type SchemeID byte
+
+const (
+	SchemeLSSSMatrix            SchemeID = 1  // access/lsss: Lewko-Waters LSSS 矩阵
+	SchemeWaters05IBE           SchemeID = 2  // ibe/waters05_ibe
+	SchemeGentry06IBE           SchemeID = 3  // ibe/gentry06_ibe
+	SchemeBB04IBE               SchemeID = 4  // ibe/bb04_ibe
+	SchemeSW05FIBE              SchemeID = 5  // fibe: 小宇宙 SW05
+	SchemeSW05FIBELargeUniverse SchemeID = 6  // fibe: 大宇宙 SW05
+	SchemeLW11DABE              SchemeID = 7  // dabe
+	SchemeGWWW25BIBE            SchemeID = 8  // bibe/gwww25_bibe
+	SchemeAFP25BIBE             SchemeID = 9  // bibe/afp25_bibe
+	SchemeASBB                  SchemeID = 10 // gka/agka09
+	SchemeZSS04Signature        SchemeID = 11 // signature/zss04_signature
+)
+
+// schemeNames 用于错误信息
+var schemeNames = map[SchemeID]string{
+	SchemeLSSSMatrix:            "lsss-matrix",
+	SchemeWaters05IBE:           "waters05-ibe",
+	SchemeGentry06IBE:           "gentry06-ibe",
+	SchemeBB04IBE:               "bb04-ibe",
+	SchemeSW05FIBE:              "sw05-fibe",
+	SchemeSW05FIBELargeUniverse: "sw05-fibe-large-universe",
+	SchemeLW11DABE:              "lw11-dabe",
+	SchemeGWWW25BIBE:            "gwww25-bibe",
+	SchemeAFP25BIBE:             "afp25-bibe",
+	SchemeASBB:                  "asbb",
+	SchemeZSS04Signature:        "zss04-signature",
+}
+
+// String 返回方案名称，未分配的编号返回 "scheme(n)"
+func (id SchemeID) String() string {
+	if name, ok := schemeNames[id]; ok {
+		return name
+	}
+	return fmt.Sprintf("scheme(%d)", byte(id))
+}
+
+// AppendHeader 在 data 末尾追加当前版本、指定方案的序列化头部
+//
+// 参数:
+//   - data: 目标字节串，通常为 nil
+//   - scheme: 方案编号
+//
+// 返回值:
+//   - []byte: 追加头部后的字节串
+func AppendHeader(data []byte, scheme SchemeID) []byte {
+	return append(data, headerMagic[0], headerMagic[1], FormatVersion, byte(scheme))
+}
+
+// CheckHeader 校验序列化头部并返回其后的数据。
+//
+// 参数:
+//   - data: MarshalBinary 的输出
+//   - scheme: 调用方期望的方案编号
+//
+// 返回值:
+//   - []byte: 去掉头部后的数据
+//   - error: 头部缺失或魔数不符时包装 ErrInvalidHeader，版本不符时包装 ErrUnsupportedVersion，
+//     方案编号不符时包装 ErrSchemeMismatch
+func CheckHeader(data []byte, scheme SchemeID) ([]byte, error) {
+	if len(data) < HeaderSize || data[0] != headerMagic[0] || data[1] != headerMagic[1] {
+		return nil, ErrInvalidHeader
+	}
+	if data[2] != FormatVersion {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedVersion, data[2])
+	}
+	if got := SchemeID(data[3]); got != scheme {
+		return nil, fmt.Errorf("%w: data is %s, want %s", ErrSchemeMismatch, got, scheme)
+	}
+	return data[HeaderSize:], nil
+}
@@ -0,0 +1,71 @@
+package serialization_test
+
+import (
+	"errors"
+	"github.com/mmsyan/GoPairingBasedCryptography/ibe/gentry06_ibe"
+	"github.com/mmsyan/GoPairingBasedCryptography/ibe/waters05_ibe"
+	"github.com/mmsyan/GoPairingBasedCryptography/serialization"
+	"math/big"
+	"testing"
+)
+
+func TestCheckHeader(t *testing.T) {
+	data := serialization.AppendHeader(nil, serialization.SchemeWaters05IBE)
+	data = append(data, 1, 2, 3)
+	rest, err := serialization.CheckHeader(data, serialization.SchemeWaters05IBE)
+	if err != nil {
+		t.Fatalf("合法头部被拒绝: %v", err)
+	}
+	if len(rest) != 3 || rest[0] != 1 {
+		t.Fatalf("去掉头部后的数据不正确: %x", rest)
+	}
+
+	if _, err := serialization.CheckHeader(data, serialization.SchemeGentry06IBE); !errors.Is(err, serialization.ErrSchemeMismatch) {
+		t.Fatalf("期望 ErrSchemeMismatch，得到 %v", err)
+	}
+
+	future := append([]byte(nil), data...)
+	future[2] = serialization.FormatVersion + 1
+	if _, err := serialization.CheckHeader(future, serialization.SchemeWaters05IBE); !errors.Is(err, serialization.ErrUnsupportedVersion) {
+		t.Fatalf("期望 ErrUnsupportedVersion，得到 %v", err)
+	}
+
+	for _, bad := range [][]byte{nil, data[:serialization.HeaderSize-1], append([]byte{'X'}, data[1:]...)} {
+		if _, err := serialization.CheckHeader(bad, serialization.SchemeWaters05IBE); !errors.Is(err, serialization.ErrInvalidHeader) {
+			t.Fatalf("%x: 期望 ErrInvalidHeader，得到 %v", bad, err)
+		}
+	}
+}
+
+// TestCrossSchemeUnmarshalRejected 把 Gentry06 私钥交给 Waters05 的 UnmarshalBinary 必须因方案编号不符被拒绝
+func TestCrossSchemeUnmarshalRejected(t *testing.T) {
+	instance, err := gentry06_ibe.NewGentry06IBEInstance()
+	if err != nil {
+		t.Fatal(err)
+	}
+	publicParams, err := instance.SetUp()
+	if err != nil {
+		t.Fatal(err)
+	}
+	identity, err := gentry06_ibe.NewGentry06IBEIdentity(big.NewInt(123456))
+	if err != nil {
+		t.Fatal(err)
+	}
+	secretKey, err := instance.KeyGenerate(identity, publicParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := secretKey.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := new(waters05_ibe.Waters05IBESecretKey).UnmarshalBinary(data); !errors.Is(err, serialization.ErrSchemeMismatch) {
+		t.Fatalf("期望 ErrSchemeMismatch，得到 %v", err)
+	}
+
+	data[2] = serialization.FormatVersion + 1
+	if err := new(gentry06_ibe.Gentry06IBESecretKey).UnmarshalBinary(data); !errors.Is(err, serialization.ErrUnsupportedVersion) {
+		t.Fatalf("期望 ErrUnsupportedVersion，得到 %v", err)
+	}
+}
@@ -0,0 +1,19 @@
+package serialization
+
+// Message 是各方案明文（或待签名消息）类型的统一接口，便于通用工具以同样的方式处理不同方案的消息。
+//
+// 不同方案的消息表示不同，Bytes/SetBytes 的含义也随之不同：
+//   - GT 明文（Waters05、Gentry06、BB04、FIBE、CP-ABE、DABE、BIBE 等）：SetBytes 用 SealBytes
+//     把明文设为随机会话密钥 K，并在消息中保存以 K 加密的数据，Bytes 用 OpenBytes 取回；
+//     方案只加密 K，因此按此发送字节数据时应直接调用 SealBytes，把它输出的 DEM 密文与方案密文一起发送。
+//     解密得到或直接随机选取的 GT 明文没有对应的 DEM 密文，Bytes 会返回错误。
+//   - 字节明文（BF01、BLS、ZSS04、签名消息等）：Bytes/SetBytes 直接复制字节。
+//   - 标量消息（BB04 签名）：Bytes 返回 32 字节大端序编码，SetBytes 只接受规范的 32 字节编码。
+//
+// 对任意实现，SetBytes(data) 成功后 Bytes() 都返回与 data 相同的字节。
+type Message interface {
+	// Bytes 返回消息承载的字节数据
+	Bytes() ([]byte, error)
+	// SetBytes 用字节数据设置消息，数据不能表示为该方案的消息时返回错误
+	SetBytes(data []byte) error
+}
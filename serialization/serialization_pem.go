@@ -0,0 +1,48 @@
+package serialization
+
+import (
+	"encoding/pem"
+	"errors"
+	"fmt"
+)
+
+// EncodePEM 将序列化后的密钥封装为 PEM 块，正文为 base64 编码。
+//
+// 参数:
+//   - blockType: PEM 块类型，例如 "WATERS05 IBE PRIVATE KEY"
+//   - data: 密钥的二进制序列化结果
+//
+// 返回值:
+//   - []byte: PEM 编码结果
+func EncodePEM(blockType string, data []byte) []byte {
+	return pem.EncodeToMemory(&pem.Block{
+		Type:  blockType,
+		Bytes: data,
+	})
+}
+
+// DecodePEM 解析 PEM 块并检查其类型，返回块中的二进制数据。
+// PEM 块之后允许有空白，但不允许出现其他数据。
+//
+// 参数:
+//   - blockType: 期望的 PEM 块类型
+//   - data: PEM 编码的数据
+//
+// 返回值:
+//   - []byte: PEM 块中的二进制数据
+//   - error: 数据不是 PEM、类型不匹配或包含多余数据时返回错误
+func DecodePEM(blockType string, data []byte) ([]byte, error) {
+	block, rest := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("failed to decode PEM: no PEM block found")
+	}
+	if block.Type != blockType {
+		return nil, fmt.Errorf("failed to decode PEM: unexpected block type %q, want %q", block.Type, blockType)
+	}
+	for _, c := range rest {
+		if c != ' ' && c != '\t' && c != '\r' && c != '\n' {
+			return nil, errors.New("failed to decode PEM: trailing data after PEM block")
+		}
+	}
+	return block.Bytes, nil
+}
@@ -0,0 +1,345 @@
+package serialization
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+)
+
+// ErrInvalidG1Point 表示 G1 点是无穷远点或不在曲线上
+var ErrInvalidG1Point = errors.New("invalid G1 point")
+
+// ErrInvalidG2Point 表示 G2 点是无穷远点、不在曲线上，或不在素数阶子群中
+var ErrInvalidG2Point = errors.New("invalid G2 point")
+
+// ErrInvalidGTElement 表示 GT 元素为零、为单位元，或不在 r 阶子群中
+var ErrInvalidGTElement = errors.New("invalid GT element")
+
+// PointEncoding 表示 G1/G2 点的序列化编码方式。
+type PointEncoding int
+
+const (
+	// Compressed 压缩编码（默认）：只保存 x 坐标与 y 的符号位，
+	// G1 点 32 字节，G2 点 64 字节。
+	// 反序列化时需要通过一次有限域开平方恢复 y 坐标，CPU 开销明显高于非压缩编码
+	// （G1 约数十微秒，G2 因为在 Fp2 上开方代价更高）。
+	Compressed PointEncoding = iota
+	// Uncompressed 非压缩编码：同时保存 x、y 坐标，G1 点 64 字节，G2 点 128 字节。
+	// 体积翻倍，但反序列化无需开平方。
+	Uncompressed
+)
+
+// EncodeG1 按照指定编码方式序列化 G1 点。
+//
+// 参数:
+//   - p: 待序列化的 G1 点
+//   - encoding: 编码方式（Compressed 或 Uncompressed）
+//
+// 返回值:
+//   - []byte: 序列化结果
+func EncodeG1(p bn254.G1Affine, encoding PointEncoding) []byte {
+	if encoding == Uncompressed {
+		b := p.RawBytes()
+		return b[:]
+	}
+	b := p.Bytes()
+	return b[:]
+}
+
+// EncodeG2 按照指定编码方式序列化 G2 点。
+//
+// 参数:
+//   - p: 待序列化的 G2 点
+//   - encoding: 编码方式（Compressed 或 Uncompressed）
+//
+// 返回值:
+//   - []byte: 序列化结果
+func EncodeG2(p bn254.G2Affine, encoding PointEncoding) []byte {
+	if encoding == Uncompressed {
+		b := p.RawBytes()
+		return b[:]
+	}
+	b := p.Bytes()
+	return b[:]
+}
+
+// DecodeG1 从 data 的开头解析一个 G1 点，根据首字节的标志位自动识别压缩/非压缩编码。
+// 对压缩编码需要一次开平方运算恢复 y 坐标。
+//
+// 参数:
+//   - data: 以 G1 点编码开头的字节串
+//
+// 返回值:
+//   - bn254.G1Affine: 解析出的点
+//   - int: 消耗的字节数
+//   - error: 数据不足或点不合法时返回错误
+func DecodeG1(data []byte) (bn254.G1Affine, int, error) {
+	var p bn254.G1Affine
+	if len(data) == 0 {
+		return p, 0, errors.New("not enough bytes to decode G1 point")
+	}
+	n, err := p.SetBytes(data)
+	if err != nil {
+		return bn254.G1Affine{}, 0, fmt.Errorf("failed to decode G1 point: %w", err)
+	}
+	return p, n, nil
+}
+
+// DecodeG2 从 data 的开头解析一个 G2 点，根据首字节的标志位自动识别压缩/非压缩编码。
+// 对压缩编码需要一次 Fp2 上的开平方运算恢复 y 坐标。
+//
+// 参数:
+//   - data: 以 G2 点编码开头的字节串
+//
+// 返回值:
+//   - bn254.G2Affine: 解析出的点
+//   - int: 消耗的字节数
+//   - error: 数据不足或点不合法时返回错误
+func DecodeG2(data []byte) (bn254.G2Affine, int, error) {
+	var p bn254.G2Affine
+	if len(data) == 0 {
+		return p, 0, errors.New("not enough bytes to decode G2 point")
+	}
+	n, err := p.SetBytes(data)
+	if err != nil {
+		return bn254.G2Affine{}, 0, fmt.Errorf("failed to decode G2 point: %w", err)
+	}
+	return p, n, nil
+}
+
+// ValidateG1 检查来自不可信来源的 G1 点：必须在曲线上且不是无穷远点。
+// BN254 的 G1 余因子为 1，曲线上的点都在素数阶子群中，这里仍然做子群检查以免依赖这一性质。
+//
+// 参数:
+//   - p: 待检查的 G1 点
+//
+// 返回值:
+//   - error: 点不合法时返回包装了 ErrInvalidG1Point 的错误
+func ValidateG1(p *bn254.G1Affine) error {
+	if p.IsInfinity() {
+		return fmt.Errorf("point at infinity: %w", ErrInvalidG1Point)
+	}
+	if !p.IsOnCurve() {
+		return fmt.Errorf("point not on curve: %w", ErrInvalidG1Point)
+	}
+	if !p.IsInSubGroup() {
+		return fmt.Errorf("point not in prime-order subgroup: %w", ErrInvalidG1Point)
+	}
+	return nil
+}
+
+// ValidateGT 检查公开参数中的 GT 元素（例如 e(g1, g2)^α）：不能为零或单位元，且必须在 r 阶子群中。
+// 单位元会使以它为底的密钥封装恒为 1，明文直接暴露在密文中。
+// 密文中的 GT 分量是明文与封装密钥之积，明文可以是任意 GT 元素，不应使用该函数检查。
+//
+// 参数:
+//   - e: 待检查的 GT 元素
+//
+// 返回值:
+//   - error: 元素不合法时返回包装了 ErrInvalidGTElement 的错误
+func ValidateGT(e *bn254.GT) error {
+	if e.IsZero() {
+		return fmt.Errorf("zero element: %w", ErrInvalidGTElement)
+	}
+	if e.IsOne() {
+		return fmt.Errorf("identity element: %w", ErrInvalidGTElement)
+	}
+	if !e.IsInSubGroup() {
+		return fmt.Errorf("element not in r-order subgroup: %w", ErrInvalidGTElement)
+	}
+	return nil
+}
+
+// ValidateG2 检查来自不可信来源的 G2 点：必须在曲线（而非其扭曲线上的其他点）上、
+// 位于素数阶子群中，且不是无穷远点。
+//
+// BN254 的 G2 余因子很大，子群外的点可能落在小阶子群中，参与配对后会泄露私钥信息或使验证方程失效；
+// 无穷远点则会使配对恒为 1。方案中的公共参数、私钥和密文分量都是 g2 的随机次幂，
+// 合法值为无穷远点的概率可以忽略，因此一律拒绝。
+//
+// 参数:
+//   - p: 待检查的 G2 点
+//
+// 返回值:
+//   - error: 点不合法时返回包装了 ErrInvalidG2Point 的错误
+func ValidateG2(p *bn254.G2Affine) error {
+	if p.IsInfinity() {
+		return fmt.Errorf("point at infinity: %w", ErrInvalidG2Point)
+	}
+	if !p.IsOnCurve() {
+		return fmt.Errorf("point not on curve: %w", ErrInvalidG2Point)
+	}
+	if !p.IsInSubGroup() {
+		return fmt.Errorf("point not in prime-order subgroup: %w", ErrInvalidG2Point)
+	}
+	return nil
+}
+
+// DecodeValidG2 与 DecodeG2 相同，但额外用 ValidateG2 检查解析出的点，
+// 供各方案的 UnmarshalBinary 解析不可信数据时使用。
+//
+// 参数:
+//   - data: 以 G2 点编码开头的字节串
+//
+// 返回值:
+//   - bn254.G2Affine: 解析出的点
+//   - int: 消耗的字节数
+//   - error: 数据不足、编码不合法时返回错误；点不合法时返回包装了 ErrInvalidG2Point 的错误
+func DecodeValidG2(data []byte) (bn254.G2Affine, int, error) {
+	var p bn254.G2Affine
+	if len(data) == 0 {
+		return p, 0, errors.New("not enough bytes to decode G2 point")
+	}
+	// 子群检查由 ValidateG2 统一完成，解码时跳过以免重复计算
+	dec := bn254.NewDecoder(bytes.NewReader(data), bn254.NoSubgroupChecks())
+	if err := dec.Decode(&p); err != nil {
+		return bn254.G2Affine{}, 0, fmt.Errorf("failed to decode G2 point: %w", err)
+	}
+	if err := ValidateG2(&p); err != nil {
+		return bn254.G2Affine{}, 0, fmt.Errorf("failed to decode G2 point: %w", err)
+	}
+	return p, int(dec.BytesRead()), nil
+}
+
+// DecodeGT 从 data 的开头解析一个 GT 元素（固定 bn254.SizeOfGT 字节）。
+//
+// 参数:
+//   - data: 以 GT 元素编码开头的字节串
+//
+// 返回值:
+//   - bn254.GT: 解析出的元素
+//   - int: 消耗的字节数
+//   - error: 数据不足或编码不合法时返回错误
+func DecodeGT(data []byte) (bn254.GT, int, error) {
+	var gt bn254.GT
+	if len(data) < bn254.SizeOfGT {
+		return gt, 0, errors.New("not enough bytes to decode GT element")
+	}
+	if err := gt.Unmarshal(data[:bn254.SizeOfGT]); err != nil {
+		return bn254.GT{}, 0, fmt.Errorf("failed to decode GT element: %w", err)
+	}
+	return gt, bn254.SizeOfGT, nil
+}
+
+// EncodeG1Slice 使用压缩编码序列化一组 G1 点，前缀为 4 字节大端序的元素个数。
+//
+// 参数:
+//   - points: 待序列化的 G1 点
+//
+// 返回值:
+//   - []byte: 序列化结果
+func EncodeG1Slice(points []bn254.G1Affine) []byte {
+	data := binary.BigEndian.AppendUint32(nil, uint32(len(points)))
+	for i := range points {
+		data = append(data, EncodeG1(points[i], Compressed)...)
+	}
+	return data
+}
+
+// DecodeG1Slice 解析 EncodeG1Slice 的输出。
+//
+// 参数:
+//   - data: 以 G1 点序列编码开头的字节串
+//
+// 返回值:
+//   - []bn254.G1Affine: 解析出的点
+//   - int: 消耗的字节数
+//   - error: 数据不足或点不合法时返回错误
+func DecodeG1Slice(data []byte) ([]bn254.G1Affine, int, error) {
+	count, offset, err := decodeSliceLength(data, bn254.SizeOfG1AffineCompressed)
+	if err != nil {
+		return nil, 0, err
+	}
+	points := make([]bn254.G1Affine, count)
+	for i := range points {
+		p, n, err := DecodeG1(data[offset:])
+		if err != nil {
+			return nil, 0, err
+		}
+		points[i] = p
+		offset += n
+	}
+	return points, offset, nil
+}
+
+// EncodeG2Slice 使用压缩编码序列化一组 G2 点，前缀为 4 字节大端序的元素个数。
+//
+// 参数:
+//   - points: 待序列化的 G2 点
+//
+// 返回值:
+//   - []byte: 序列化结果
+func EncodeG2Slice(points []bn254.G2Affine) []byte {
+	data := binary.BigEndian.AppendUint32(nil, uint32(len(points)))
+	for i := range points {
+		data = append(data, EncodeG2(points[i], Compressed)...)
+	}
+	return data
+}
+
+// DecodeG2Slice 解析 EncodeG2Slice 的输出。
+//
+// 参数:
+//   - data: 以 G2 点序列编码开头的字节串
+//
+// 返回值:
+//   - []bn254.G2Affine: 解析出的点
+//   - int: 消耗的字节数
+//   - error: 数据不足或点不合法时返回错误
+func DecodeG2Slice(data []byte) ([]bn254.G2Affine, int, error) {
+	count, offset, err := decodeSliceLength(data, bn254.SizeOfG2AffineCompressed)
+	if err != nil {
+		return nil, 0, err
+	}
+	points := make([]bn254.G2Affine, count)
+	for i := range points {
+		p, n, err := DecodeG2(data[offset:])
+		if err != nil {
+			return nil, 0, err
+		}
+		points[i] = p
+		offset += n
+	}
+	return points, offset, nil
+}
+
+// DecodeValidG2Slice 与 DecodeG2Slice 相同，但对每个点使用 DecodeValidG2。
+//
+// 参数:
+//   - data: 以 G2 点序列编码开头的字节串
+//
+// 返回值:
+//   - []bn254.G2Affine: 解析出的点
+//   - int: 消耗的字节数
+//   - error: 数据不足或任一点不合法时返回错误
+func DecodeValidG2Slice(data []byte) ([]bn254.G2Affine, int, error) {
+	count, offset, err := decodeSliceLength(data, bn254.SizeOfG2AffineCompressed)
+	if err != nil {
+		return nil, 0, err
+	}
+	points := make([]bn254.G2Affine, count)
+	for i := range points {
+		p, n, err := DecodeValidG2(data[offset:])
+		if err != nil {
+			return nil, 0, fmt.Errorf("point %d: %w", i, err)
+		}
+		points[i] = p
+		offset += n
+	}
+	return points, offset, nil
+}
+
+// decodeSliceLength 读取 4 字节长度前缀，并根据每个元素的最小编码长度检查剩余数据是否足够，
+// 避免恶意的长度前缀导致超大内存分配。
+func decodeSliceLength(data []byte, minElementSize int) (int, int, error) {
+	if len(data) < 4 {
+		return 0, 0, errors.New("not enough bytes to decode slice length")
+	}
+	count := binary.BigEndian.Uint32(data)
+	if uint64(count)*uint64(minElementSize) > uint64(len(data)-4) {
+		return 0, 0, fmt.Errorf("slice length %d exceeds available data", count)
+	}
+	return int(count), 4, nil
+}
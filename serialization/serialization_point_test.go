@@ -0,0 +1,102 @@
+package serialization
+
+import (
+	"errors"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/mmsyan/GoPairingBasedCryptography/internal/invalidpoints"
+	"math/big"
+	"testing"
+)
+
+// TestDecodeValidG2 合法点在两种编码下都能解析；无穷远点、不在曲线上和不在子群中的点都被拒绝
+func TestDecodeValidG2(t *testing.T) {
+	var s fr.Element
+	if _, err := s.SetRandom(); err != nil {
+		t.Fatal(err)
+	}
+	var p bn254.G2Affine
+	p.ScalarMultiplicationBase(s.BigInt(new(big.Int)))
+	for _, encoding := range []PointEncoding{Compressed, Uncompressed} {
+		data := EncodeG2(p, encoding)
+		got, n, err := DecodeValidG2(data)
+		if err != nil {
+			t.Fatalf("合法点解析失败: %v", err)
+		}
+		if n != len(data) || !got.Equal(&p) {
+			t.Fatal("解析结果与原始点不一致")
+		}
+	}
+
+	for name, data := range invalidpoints.G2Encodings() {
+		if _, _, err := DecodeValidG2(data); err == nil {
+			t.Fatalf("%s: 不合法的点应被拒绝", name)
+		}
+	}
+	invalid := invalidpoints.G2Encodings()
+	for _, name := range []string{"infinity", "non-subgroup"} {
+		if _, _, err := DecodeValidG2(invalid[name]); !errors.Is(err, ErrInvalidG2Point) {
+			t.Fatalf("%s: 期望 ErrInvalidG2Point, 实际为 %v", name, err)
+		}
+	}
+
+	// 非压缩编码中篡改 y 坐标得到不在曲线上的点
+	offCurve := p
+	offCurve.Y.A0.Add(&offCurve.Y.A0, &offCurve.Y.A1)
+	if _, _, err := DecodeValidG2(EncodeG2(offCurve, Uncompressed)); err == nil {
+		t.Fatal("不在曲线上的点应被拒绝")
+	}
+}
+
+// TestDecodeValidG2Slice 序列中任一点不合法时整体被拒绝
+func TestDecodeValidG2Slice(t *testing.T) {
+	_, _, _, g2 := bn254.Generators()
+	data := EncodeG2Slice([]bn254.G2Affine{g2, g2})
+	if _, n, err := DecodeValidG2Slice(data); err != nil || n != len(data) {
+		t.Fatalf("合法序列解析失败: %v", err)
+	}
+	copy(data[4+bn254.SizeOfG2AffineCompressed:], invalidpoints.G2Encodings()["non-subgroup"])
+	if _, _, err := DecodeValidG2Slice(data); !errors.Is(err, ErrInvalidG2Point) {
+		t.Fatalf("期望 ErrInvalidG2Point, 实际为 %v", err)
+	}
+}
+
+// TestValidateG1 合法点通过检查；无穷远点和不在曲线上的点被拒绝
+func TestValidateG1(t *testing.T) {
+	_, _, g1, _ := bn254.Generators()
+	if err := ValidateG1(&g1); err != nil {
+		t.Fatalf("合法点检查失败: %v", err)
+	}
+	var infinity bn254.G1Affine
+	if err := ValidateG1(&infinity); !errors.Is(err, ErrInvalidG1Point) {
+		t.Fatalf("无穷远点: 期望 ErrInvalidG1Point, 实际为 %v", err)
+	}
+	offCurve := g1
+	offCurve.Y.Double(&offCurve.Y)
+	if err := ValidateG1(&offCurve); !errors.Is(err, ErrInvalidG1Point) {
+		t.Fatalf("不在曲线上的点: 期望 ErrInvalidG1Point, 实际为 %v", err)
+	}
+}
+
+// TestValidateGT e(g1, g2) 通过检查；零、单位元和不在 r 阶子群中的元素被拒绝
+func TestValidateGT(t *testing.T) {
+	_, _, g1, g2 := bn254.Generators()
+	e, err := bn254.Pair([]bn254.G1Affine{g1}, []bn254.G2Affine{g2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ValidateGT(&e); err != nil {
+		t.Fatalf("合法元素检查失败: %v", err)
+	}
+	var zero, one bn254.GT
+	one.SetOne()
+	random, err := new(bn254.GT).SetRandom()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, element := range map[string]*bn254.GT{"zero": &zero, "identity": &one, "non-subgroup": random} {
+		if err := ValidateGT(element); !errors.Is(err, ErrInvalidGTElement) {
+			t.Fatalf("%s: 期望 ErrInvalidGTElement, 实际为 %v", name, err)
+		}
+	}
+}
@@ -26,6 +26,7 @@ import (
 	"fmt"
 	"github.com/consensys/gnark-crypto/ecc/bn254"
 	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/mmsyan/GoPairingBasedCryptography/metrics"
 	"math/big"
 )
 
@@ -136,7 +137,7 @@ func ParamsGenerate() (*PublicParams, error) {
 	_, _, g1, g2 := bn254.Generators()
 
 	// 计算基础配对 e(G1, G2)
-	eG1G2, err := bn254.Pair([]bn254.G1Affine{g1}, []bn254.G2Affine{g2})
+	eG1G2, err := metrics.Pair([]bn254.G1Affine{g1}, []bn254.G2Affine{g2})
 	if err != nil {
 		return nil, err
 	}
@@ -171,11 +172,11 @@ func ParamsGenerate() (*PublicParams, error) {
 func KeyGenerate() (*PublicKey, *PrivateKey, error) {
 	alpha, err := new(fr.Element).SetRandom()
 	if err != nil {
-		return nil, nil, fmt.Errorf("error generating alpha signature key")
+		return nil, nil, fmt.Errorf("error generating alpha signature key: %w", err)
 	}
 	beta, err := new(fr.Element).SetRandom()
 	if err != nil {
-		return nil, nil, fmt.Errorf("error generating beta signature key")
+		return nil, nil, fmt.Errorf("error generating beta signature key: %w", err)
 	}
 	y := new(bn254.G2Affine).ScalarMultiplicationBase(alpha.BigInt(new(big.Int)))
 	z := new(bn254.G2Affine).ScalarMultiplicationBase(beta.BigInt(new(big.Int)))
@@ -279,7 +280,7 @@ func Verify(pk *PublicKey, m *Message, sign *Signature, pp *PublicParams) (bool,
 
 	// 计算 e(sigma, Y + r*Z + m*G2)
 	// 这等价于检查 e(sigma, Y + r*Z + m*G2) = e(G1, G2)
-	pairLeft, err := bn254.Pair(
+	pairLeft, err := metrics.Pair(
 		[]bn254.G1Affine{sign.Sigma},
 		[]bn254.G2Affine{temp},
 	)
@@ -295,3 +296,12 @@ func Verify(pk *PublicKey, m *Message, sign *Signature, pp *PublicParams) (bool,
 		return false, fmt.Errorf("invalid signature")
 	}
 }
+
+// Zeroize 将私钥分量 Alpha 和 Beta 覆写为零,供不再需要私钥时主动清除。
+//
+// 这只是纵深防御:Go 的垃圾回收器可能已经复制过这些值,按值复制的 PrivateKey 也不会受影响。
+// 调用后私钥不再可用,生成的签名无法通过原公钥的验证。
+func (sk *PrivateKey) Zeroize() {
+	sk.Alpha.SetZero()
+	sk.Beta.SetZero()
+}
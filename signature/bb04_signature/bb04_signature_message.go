@@ -0,0 +1,34 @@
+package bb04_signature
+
+import (
+	"fmt"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/mmsyan/GoPairingBasedCryptography/serialization"
+)
+
+var _ serialization.Message = (*Message)(nil)
+
+// Bytes 返回消息标量 MessageFr 的 32 字节大端序编码
+//
+// 返回值:
+//   - []byte: 32 字节编码
+//   - error: 始终为 nil
+func (m *Message) Bytes() ([]byte, error) {
+	b := m.MessageFr.Bytes()
+	return b[:], nil
+}
+
+// SetBytes 从规范的 32 字节大端序编码设置消息标量。
+// 任意长度的消息应先用抗碰撞哈希映射为域元素，再直接设置 MessageFr。
+//
+// 参数:
+//   - data: 32 字节编码，数值必须小于标量域的模数
+//
+// 返回值:
+//   - error: 长度不是 32 字节或编码不规范时返回错误
+func (m *Message) SetBytes(data []byte) error {
+	if len(data) != fr.Bytes {
+		return fmt.Errorf("message scalar must be %d bytes, got %d", fr.Bytes, len(data))
+	}
+	return m.MessageFr.SetBytesCanonical(data)
+}
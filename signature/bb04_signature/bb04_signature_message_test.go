@@ -0,0 +1,20 @@
+package bb04_signature
+
+import (
+	"bytes"
+	"github.com/mmsyan/GoPairingBasedCryptography/internal/msgtest"
+	"testing"
+)
+
+// TestMessageBytes 检查标量消息的 Bytes/SetBytes 往返，长度不是 32 字节或不小于模数的编码被拒绝
+func TestMessageBytes(t *testing.T) {
+	scalar := make([]byte, 32)
+	scalar[31] = 42
+	msgtest.CheckRoundTrip(t, new(Message), scalar)
+	if err := new(Message).SetBytes([]byte("attack at dawn")); err == nil {
+		t.Error("长度不是 32 字节的数据应被拒绝")
+	}
+	if err := new(Message).SetBytes(bytes.Repeat([]byte{0xff}, 32)); err == nil {
+		t.Error("不小于模数的编码应被拒绝")
+	}
+}
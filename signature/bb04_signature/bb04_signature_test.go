@@ -32,12 +32,8 @@ func TestKeyGenerate(t *testing.T) {
 	}
 
 	// Check that Y and Z are valid points
-	if !pk.Y.IsInSubGroup() {
-		t.Error("Y is not in the correct subgroup")
-	}
-
-	if !pk.Z.IsInSubGroup() {
-		t.Error("Z is not in the correct subgroup")
+	if err := pk.Validate(); err != nil {
+		t.Errorf("Public key is invalid: %v", err)
 	}
 }
 
@@ -95,8 +91,8 @@ func TestSignBasic(t *testing.T) {
 	}
 
 	// Check that sigma is a valid point
-	if !sig.Sigma.IsInSubGroup() {
-		t.Error("Sigma is not in the correct subgroup")
+	if err := sig.Validate(); err != nil {
+		t.Errorf("Signature is invalid: %v", err)
 	}
 
 	// Verify the signature
@@ -373,3 +369,30 @@ func BenchmarkVerify(b *testing.B) {
 		_, _ = Verify(pk, msg, sig, pp)
 	}
 }
+
+// TestPrivateKeyZeroize tests that Zeroize clears alpha and beta and that the zeroized key no longer signs validly
+func TestPrivateKeyZeroize(t *testing.T) {
+	pp, err := ParamsGenerate()
+	if err != nil {
+		t.Fatalf("ParamsGenerate failed: %v", err)
+	}
+	pk, sk, err := KeyGenerate()
+	if err != nil {
+		t.Fatalf("KeyGenerate failed: %v", err)
+	}
+
+	sk.Zeroize()
+	if !sk.Alpha.IsZero() || !sk.Beta.IsZero() {
+		t.Fatal("Private key is not zero after Zeroize")
+	}
+
+	msg := &Message{}
+	msg.MessageFr.SetUint64(42)
+	sig, err := Sign(sk, msg)
+	if err != nil {
+		t.Fatalf("Sign failed: %v", err)
+	}
+	if valid, _ := Verify(pk, msg, sig, pp); valid {
+		t.Error("Signature from a zeroized key passed verification")
+	}
+}
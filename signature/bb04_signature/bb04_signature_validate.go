@@ -0,0 +1,33 @@
+package bb04_signature
+
+import (
+	"fmt"
+	"github.com/mmsyan/GoPairingBasedCryptography/serialization"
+)
+
+// Validate 检查来自不可信来源的公钥：Y 与 Z 都必须是 G2 素数阶子群中的非无穷远点。
+// Y 或 Z 为无穷远点意味着 alpha 或 beta 为 0，验证方程退化后可以在不知道私钥的情况下伪造签名。
+//
+// 返回值:
+//   - error: 分量不合法时返回指明分量、并包装 serialization.ErrInvalidG2Point 的错误
+func (pk *PublicKey) Validate() error {
+	if err := serialization.ValidateG2(&pk.Y); err != nil {
+		return fmt.Errorf("invalid public key: Y: %w", err)
+	}
+	if err := serialization.ValidateG2(&pk.Z); err != nil {
+		return fmt.Errorf("invalid public key: Z: %w", err)
+	}
+	return nil
+}
+
+// Validate 检查来自不可信来源的签名：Sigma 必须是 G1 中的非无穷远点。
+// R 可以是任意域元素，不做检查。
+//
+// 返回值:
+//   - error: Sigma 不合法时返回包装 serialization.ErrInvalidG1Point 的错误
+func (sign *Signature) Validate() error {
+	if err := serialization.ValidateG1(&sign.Sigma); err != nil {
+		return fmt.Errorf("invalid signature: Sigma: %w", err)
+	}
+	return nil
+}
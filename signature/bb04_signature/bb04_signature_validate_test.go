@@ -0,0 +1,51 @@
+package bb04_signature
+
+import (
+	"errors"
+	"github.com/mmsyan/GoPairingBasedCryptography/internal/invalidpoints"
+	"github.com/mmsyan/GoPairingBasedCryptography/serialization"
+	"testing"
+)
+
+// TestPublicKeyValidate tests that public keys with an infinity or non-subgroup component are rejected
+func TestPublicKeyValidate(t *testing.T) {
+	pk, _, err := KeyGenerate()
+	if err != nil {
+		t.Fatalf("KeyGenerate failed: %v", err)
+	}
+	if err := pk.Validate(); err != nil {
+		t.Fatalf("Valid public key rejected: %v", err)
+	}
+
+	infinityY := *pk
+	infinityY.Y.SetInfinity()
+	nonSubgroupZ := *pk
+	nonSubgroupZ.Z = invalidpoints.NonSubgroupG2()
+	for name, bad := range map[string]*PublicKey{"infinity Y": &infinityY, "non-subgroup Z": &nonSubgroupZ} {
+		if err := bad.Validate(); !errors.Is(err, serialization.ErrInvalidG2Point) {
+			t.Errorf("%s: expected ErrInvalidG2Point, got %v", name, err)
+		}
+	}
+}
+
+// TestSignatureValidate tests that a signature with Sigma at infinity is rejected
+func TestSignatureValidate(t *testing.T) {
+	_, sk, err := KeyGenerate()
+	if err != nil {
+		t.Fatalf("KeyGenerate failed: %v", err)
+	}
+	msg := &Message{}
+	msg.MessageFr.SetUint64(42)
+	sig, err := Sign(sk, msg)
+	if err != nil {
+		t.Fatalf("Sign failed: %v", err)
+	}
+	if err := sig.Validate(); err != nil {
+		t.Fatalf("Valid signature rejected: %v", err)
+	}
+
+	sig.Sigma.SetInfinity()
+	if err := sig.Validate(); !errors.Is(err, serialization.ErrInvalidG1Point) {
+		t.Errorf("expected ErrInvalidG1Point, got %v", err)
+	}
+}
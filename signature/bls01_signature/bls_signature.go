@@ -39,7 +39,7 @@ func KeyGenerate() (*PublicKey, *PrivateKey, error) {
 	// x <- Zq
 	x, err := new(fr.Element).SetRandom()
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to generate key pair: %v", err)
+		return nil, nil, fmt.Errorf("failed to generate key pair: %w", err)
 	}
 	// g1^x
 	g1ExpX := *new(bn254.G1Affine).ScalarMultiplicationBase(x.BigInt(new(big.Int)))
@@ -83,7 +83,13 @@ func Verify(pk *PublicKey, m *Message, sigma *Signature, pp *PublicParams) (bool
 		[]bn254.G2Affine{hm, inverseSigma},
 	)
 	if err != nil {
-		return false, fmt.Errorf("failed to verify signature: %v", err)
+		return false, fmt.Errorf("failed to verify signature: %w", err)
 	}
 	return isValid, nil
 }
+
+// Zeroize 将私钥覆写为零。Go 的垃圾回收器可能已经复制过该值，这些副本不会被清除。
+// 调用后私钥不再可用，生成的签名无法通过原公钥的验证。
+func (sk *PrivateKey) Zeroize() {
+	sk.PrivateKey.SetZero()
+}
@@ -0,0 +1,26 @@
+package bls01_signature
+
+import "github.com/mmsyan/GoPairingBasedCryptography/serialization"
+
+var _ serialization.Message = (*Message)(nil)
+
+// Bytes 返回消息字节的副本
+//
+// 返回值:
+//   - []byte: 消息字节
+//   - error: 始终为 nil
+func (m *Message) Bytes() ([]byte, error) {
+	return append([]byte(nil), m.MessageBytes...), nil
+}
+
+// SetBytes 把消息设置为 data 的副本
+//
+// 参数:
+//   - data: 消息字节
+//
+// 返回值:
+//   - error: 始终为 nil
+func (m *Message) SetBytes(data []byte) error {
+	m.MessageBytes = append([]byte(nil), data...)
+	return nil
+}
@@ -70,3 +70,29 @@ func TestInvalidSignature(t *testing.T) {
 		t.Fatal("SigmaSignature was expected to be invalid, but Verify returned true")
 	}
 }
+
+// TestPrivateKeyZeroize 测试 Zeroize 将私钥置零，且置零后的私钥生成的签名无法通过验证
+func TestPrivateKeyZeroize(t *testing.T) {
+	pp, err := ParamsGenerate()
+	if err != nil {
+		t.Fatal("Failed to generate params: ", err)
+	}
+	pk, sk, err := KeyGenerate()
+	if err != nil {
+		t.Fatalf("KeyGenerate failed: %v", err)
+	}
+
+	sk.Zeroize()
+	if !sk.PrivateKey.IsZero() {
+		t.Fatal("PrivateKey is not zero after Zeroize")
+	}
+
+	message := &Message{MessageBytes: []byte("zeroized key")}
+	signature, err := Sign(sk, message)
+	if err != nil {
+		t.Fatalf("Sign failed: %v", err)
+	}
+	if isValid, _ := Verify(pk, message, signature, pp); isValid {
+		t.Fatal("Signature from a zeroized key passed verification")
+	}
+}
@@ -7,17 +7,18 @@
 // Lecture Notes in Computer Science, vol 2947. Springer, Berlin, Heidelberg.
 // https://doi.org/10.1007/978-3-540-24632-9_20
 //
-// 该实现基于 BN254 椭圆曲线和双线性配对运算,提供了高效的短签名方案,具有以下特性:
+// 该实现基于双线性配对运算,可在 BN254 与 BLS12-381 曲线之间选择,提供了高效的短签名方案,具有以下特性:
 //   - 短签名:签名只包含一个 G1 群元素
 //   - 确定性签名:同一消息的多次签名产生相同结果
 //   - 高效验证:验证只需要一次配对运算
 //   - 强安全性:在 CDH (Computational Diffie-Hellman) 假设下可证明安全
 //
 // The signature scheme consists of four main operations:
-//   - ParamsGenerate: 生成系统公共参数
-//   - KeyGenerate: 生成公钥/私钥对
+//   - ParamsGenerate: 生成系统公共参数(BN254;ParamsGenerateOn 可选择曲线)
+//   - KeyGenerate: 生成公钥/私钥对(BN254;KeyGenerateOn 可选择曲线)
 //   - Sign: 对消息创建签名
 //   - Verify: 验证签名的有效性
+//   - AggregateSignatures / AggregatePublicKeys: 聚合多个签名者对同一消息的签名
 //
 // Security Properties:
 //   - Existential unforgeability under chosen message attack (EUF-CMA)
@@ -30,45 +31,64 @@
 package zss04_signature
 
 import (
+	"crypto/rand"
 	"fmt"
-	"github.com/consensys/gnark-crypto/ecc/bn254"
-	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
-	"github.com/mmsyan/GoPairingBasedCryptography/hash"
+	"github.com/mmsyan/GoPairingBasedCryptography/internal/curve"
 	"math/big"
 )
 
+// Curve 表示 ZSS04 方案所使用的配对曲线。
+type Curve = curve.ID
+
+const (
+	// BN254 约 100 位安全强度,运算最快
+	BN254 = curve.BN254
+	// BLS12381 约 120 位安全强度,签名与公钥更长、验证更慢
+	BLS12381 = curve.BLS12381
+)
+
 // PublicParams 表示 ZSS04 签名方案的系统公共参数。
 // 这些参数在系统初始化时生成一次,可以被所有用户共享使用。
 //
 // 公共参数包含基础生成元的配对值,用于加速签名验证过程。
 // 通过预计算 e(G1, G2),验证时只需要计算一次配对运算。
 type PublicParams struct {
+	// backend 是参数所在曲线的群运算后端,密钥与签名都必须来自同一条曲线。
+	backend curve.Backend
+
 	// eG1G2 是基础生成元 G1 和 G2 的配对值,即 e(G1, G2)。
 	// 这是 GT 群中的一个元素,用于签名验证。
 	// 预计算此值可以显著提高验证效率。
-	eG1G2 bn254.GT
+	eG1G2 curve.GT
 }
 
 // PrivateKey 表示 ZSS04 签名方案中的签名密钥(私钥)。
-// 它由一个随机域元素 x 组成,必须严格保密。
+// 它由所选曲线标量域中的一个随机元素 x 组成,必须严格保密。
 //
 // 私钥用于生成签名,绝不应该共享或传输。丢失私钥意味着无法签署消息,
 // 而私钥泄露则允许攻击者伪造签名。
 type PrivateKey struct {
-	// x 是私钥的秘密值,是 BN254 曲线标量域 Fr 中的一个随机元素。
+	backend curve.Backend
+
+	// x 是私钥的秘密值,是 [0, r) 内的随机整数,r 为所选曲线的群阶。
 	// 必须严格保密,任何获得 x 的人都可以伪造签名。
-	x fr.Element
+	x *big.Int
 }
 
 // PublicKey 表示 ZSS04 签名方案中的验证密钥(公钥)。
-// 它由 BN254 曲线 G2 群上的一个椭圆曲线点组成。
+// 它由所选曲线 G2 群上的一个椭圆曲线点组成。
 // 公钥可以自由共享,用于验证签名。
 //
 // 公钥通过私钥与 G2 生成元的标量乘法导出: P = x * G2
 type PublicKey struct {
+	backend curve.Backend
+
 	// p 是公钥点,计算为 x * G2,
-	// 其中 x 是私钥,G2 是 BN254 曲线 G2 群的生成元。
-	p bn254.G2Affine
+	// 其中 x 是私钥,G2 是所选曲线 G2 群的生成元。
+	p curve.G2
+
+	// parts 是聚合公钥包含的各签名者公钥(见 AggregatePublicKeys),普通公钥为空。
+	parts []curve.G2
 }
 
 // Message 表示 ZSS04 方案中待签名的消息。
@@ -88,13 +108,19 @@ type Message struct {
 // ZSS04 签名是确定性的:对同一消息使用同一私钥签名,
 // 总是产生相同的签名值。这与 BB04 的随机化签名不同。
 type Signature struct {
-	// S 是签名值,是 G1 群中的一个点。
+	// backend 是签名所在曲线的群运算后端。
+	backend curve.Backend
+
+	// s 是签名值,是所选曲线 G1 群中的一个点。
 	// 计算为 S = (1 / (H(m) + x)) * G1,
 	// 其中 H(m) 是消息的哈希值,x 是私钥,G1 是生成元。
-	S bn254.G1Affine
+	s curve.G1
+
+	// parts 是聚合签名包含的各签名者签名(见 AggregateSignatures),普通签名为空。
+	parts []curve.G1
 }
 
-// ParamsGenerate 生成 ZSS04 签名方案的系统公共参数。
+// ParamsGenerate 在 BN254 曲线上生成 ZSS04 签名方案的系统公共参数。
 //
 // 该函数计算基础生成元 G1 和 G2 的配对值 e(G1, G2),
 // 并将其作为公共参数。这个预计算的配对值可以被所有用户共享,
@@ -127,21 +153,48 @@ type Signature struct {
 //	}
 //	// pp 可以被所有用户共享使用
 func ParamsGenerate() (*PublicParams, error) {
-	// 获取 BN254 曲线的标准生成元
-	_, _, g1, g2 := bn254.Generators()
+	return ParamsGenerateOn(BN254)
+}
+
+// ParamsGenerateOn 与 ParamsGenerate 相同,但在指定曲线上生成公共参数。
+//
+// 参数:
+//   - c: 使用的曲线(BN254 或 BLS12381)
+//
+// 返回值:
+//   - *PublicParams: 生成的公共参数,包含 e(G1, G2)
+//   - error: 如果曲线不受支持或配对计算失败则返回错误
+func ParamsGenerateOn(c Curve) (*PublicParams, error) {
+	backend, err := curve.New(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// 获取所选曲线的标准生成元
+	one := big.NewInt(1)
+	g1, g2 := backend.G1BaseMul(one), backend.G2BaseMul(one)
 
 	// 计算基础配对 e(G1, G2)
-	eG1G2, err := bn254.Pair([]bn254.G1Affine{g1}, []bn254.G2Affine{g2})
+	eG1G2, err := backend.Pair([]curve.G1{g1}, []curve.G2{g2})
 	if err != nil {
 		return nil, err
 	}
 
 	return &PublicParams{
-		eG1G2: eG1G2,
+		backend: backend,
+		eG1G2:   eG1G2,
 	}, nil
 }
 
-// KeyGenerate 为 ZSS04 签名方案生成新的公钥/私钥对。
+// Curve 返回公共参数所在的曲线,未初始化的参数返回 BN254
+func (pp *PublicParams) Curve() Curve {
+	if pp.backend == nil {
+		return BN254
+	}
+	return pp.backend.ID()
+}
+
+// KeyGenerate 为 ZSS04 签名方案在 BN254 曲线上生成新的公钥/私钥对。
 //
 // 该函数生成一个随机域元素 x 作为私钥,
 // 并通过与 G2 生成元的标量乘法计算相应的公钥:
@@ -176,20 +229,40 @@ func ParamsGenerate() (*PublicParams, error) {
 //	// pk 现在可以分发给其他人用于验证签名
 //	// sk 必须安全保存,用于签名消息
 func KeyGenerate() (*PublicKey, *PrivateKey, error) {
+	return KeyGenerateOn(BN254)
+}
+
+// KeyGenerateOn 与 KeyGenerate 相同,但在指定曲线上生成密钥对,曲线须与 ParamsGenerateOn 一致。
+//
+// 参数:
+//   - c: 使用的曲线(BN254 或 BLS12381)
+//
+// 返回值:
+//   - *PublicKey: 生成的公钥(可以公开共享)
+//   - *PrivateKey: 生成的私钥(必须保密)
+//   - error: 如果曲线不受支持或随机数生成失败则返回错误
+func KeyGenerateOn(c Curve) (*PublicKey, *PrivateKey, error) {
+	backend, err := curve.New(c)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	// 随机选择私钥 x
-	x, err := new(fr.Element).SetRandom()
+	x, err := rand.Int(rand.Reader, backend.Order())
 	if err != nil {
 		return nil, nil, err
 	}
 
 	// 计算公钥 P = x * G2
-	p := new(bn254.G2Affine).ScalarMultiplicationBase(x.BigInt(new(big.Int)))
+	p := backend.G2BaseMul(x)
 
 	return &PublicKey{
-			p: *p,
-		}, &PrivateKey{
-			x: *x,
-		}, nil
+		backend: backend,
+		p:       p,
+	}, &PrivateKey{
+		backend: backend,
+		x:       x,
+	}, nil
 }
 
 // Sign 使用提供的私钥对消息创建 ZSS04 签名。
@@ -209,7 +282,8 @@ func KeyGenerate() (*PublicKey, *PrivateKey, error) {
 //
 // 返回值:
 //   - *Signature: 生成的签名,包含一个 G1 群元素
-//   - error: 如果签名过程失败则返回错误(通常不会发生)
+//   - error: 私钥未初始化(不是由 KeyGenerate 或 KeyGenerateOn 得到)时返回包装 ErrUninitialized 的错误;
+//     H(m) + x = 0 时返回错误(概率可忽略)
 //
 // 性能特点:
 //   - 消息首先被哈希到 Fr(标量域),这是所有群操作中最快的
@@ -238,24 +312,41 @@ func KeyGenerate() (*PublicKey, *PrivateKey, error) {
 //	}
 //	// sig 现在可以与消息一起发送
 func Sign(sk *PrivateKey, m *Message) (*Signature, error) {
+	if sk.backend == nil || sk.x == nil {
+		return nil, fmt.Errorf("unable to sign message: %w", ErrUninitialized)
+	}
+	order := sk.backend.Order()
+
 	// 将消息哈希到标量域 Fr
-	// 使用 SHA-256 确保抗碰撞性和均匀分布
-	hm := hash.BytesToField(m.MessageBytes)
+	hm := sk.backend.BytesToScalar(m.MessageBytes)
 
 	// 计算 H(m) + x
-	hmAddS := new(fr.Element).Add(&hm, &sk.x)
+	hmAddS := new(big.Int).Add(hm, sk.x)
+	hmAddS.Mod(hmAddS, order)
+	if hmAddS.Sign() == 0 {
+		return nil, fmt.Errorf("unable to sign message: H(m) + x = 0")
+	}
 
 	// 计算逆元: 1 / (H(m) + x)
-	inverseHmAddS := new(fr.Element).Inverse(hmAddS)
+	inverseHmAddS := new(big.Int).ModInverse(hmAddS, order)
 
 	// 计算签名: S = (1 / (H(m) + x)) * G1
-	s := new(bn254.G1Affine).ScalarMultiplicationBase(inverseHmAddS.BigInt(new(big.Int)))
+	s := sk.backend.G1BaseMul(inverseHmAddS)
 
 	return &Signature{
-		S: *s,
+		backend: sk.backend,
+		s:       s,
 	}, nil
 }
 
+// resolve 返回签名所在曲线的后端以及签名点(聚合签名的签名点为 nil)
+func (sigma *Signature) resolve() (curve.Backend, curve.G1, error) {
+	if sigma.backend == nil || (sigma.s == nil && len(sigma.parts) == 0) {
+		return nil, nil, ErrUninitialized
+	}
+	return sigma.backend, sigma.s, nil
+}
+
 // Verify 检查签名对于给定消息和公钥是否有效。
 //
 // 验证算法使用双线性配对来检查签名方程:
@@ -284,9 +375,12 @@ func Sign(sk *PrivateKey, m *Message) (*Signature, error) {
 //   - sigma: 要验证的签名(不能为 nil)
 //   - pp: 系统公共参数,包含预计算的 e(G1, G2)(不能为 nil)
 //
+// pk 与 sigma 为 AggregatePublicKeys / AggregateSignatures 的结果时,按聚合签名进行验证。
+//
 // 返回值:
 //   - bool: 如果签名有效则为 true,否则为 false
-//   - error: 如果配对计算失败或签名无效则返回错误
+//   - error: 仅在输入不合法(参数、公钥或签名未初始化,曲线不一致,聚合签名与聚合公钥的签名者个数不一致)或配对计算失败时返回错误;
+//     格式正确但不成立的签名返回 (false, nil)
 //
 // 性能特点:
 //   - 验证只需要一次配对运算(因为 e(G1, G2) 已预计算)
@@ -302,8 +396,12 @@ func Sign(sk *PrivateKey, m *Message) (*Signature, error) {
 //   - 不验证消息的来源或完整性,只验证签名的数学正确性
 //
 // 错误情况:
+//   - 公共参数、公钥或签名是未初始化的零值(包装 ErrUninitialized)
+//   - 公共参数、公钥与签名不在同一条曲线上
+//   - 聚合签名与聚合公钥的签名者个数不一致
 //   - 配对计算失败(极少发生)
-//   - 签名验证失败(消息被篡改或签名无效)
+//
+// 消息被篡改、公钥错误或签名无效时不返回错误,只返回 false。
 //
 // 示例:
 //
@@ -316,27 +414,60 @@ func Sign(sk *PrivateKey, m *Message) (*Signature, error) {
 //	}
 //	// 签名有效,消息确实由私钥持有者签名
 func Verify(pk *PublicKey, m *Message, sigma *Signature, pp *PublicParams) (bool, error) {
+	if pp.backend == nil || pk.backend == nil {
+		return false, fmt.Errorf("unable to verify signature: %w", ErrUninitialized)
+	}
+	sigBackend, s, err := sigma.resolve()
+	if err != nil {
+		return false, fmt.Errorf("unable to verify signature: %w", err)
+	}
+	c := pp.backend.ID()
+	if pk.backend.ID() != c || sigBackend.ID() != c {
+		return false, fmt.Errorf("curve mismatch: public params on %v, public key on %v, signature on %v", c, pk.backend.ID(), sigBackend.ID())
+	}
+	if len(pk.parts) > 0 || len(sigma.parts) > 0 {
+		return verifyAggregate(pk, m, sigma, pp)
+	}
+	if pk.p == nil {
+		return false, fmt.Errorf("unable to verify signature: %w", ErrUninitialized)
+	}
+
 	// 将消息哈希到标量域 Fr
 	// 必须使用与签名时相同的哈希方式
-	hm := hash.BytesToField(m.MessageBytes)
+	hm := pp.backend.BytesToScalar(m.MessageBytes)
 
 	// 计算 H(m) * G2
-	g2ExpHm := new(bn254.G2Affine).ScalarMultiplicationBase(hm.BigInt(new(big.Int)))
+	g2ExpHm := pp.backend.G2BaseMul(hm)
 
 	// 计算 H(m)*G2 + P (其中 P 是公钥)
-	g2ExpHmAddPk := new(bn254.G2Affine).Add(g2ExpHm, &pk.p)
+	g2ExpHmAddPk := g2ExpHm.Add(pk.p)
 
 	// 计算配对 e(S, H(m)*G2 + P)
-	pairLeft, err := bn254.Pair([]bn254.G1Affine{sigma.S}, []bn254.G2Affine{*g2ExpHmAddPk})
+	pairLeft, err := pp.backend.Pair([]curve.G1{s}, []curve.G2{g2ExpHmAddPk})
 	if err != nil {
 		return false, err
 	}
 
 	// 检查 e(S, H(m)*G2 + P) = e(G1, G2)
 	// 如果相等,则签名有效
-	if pairLeft.Equal(&pp.eG1G2) {
+	if pairLeft.Equal(pp.eG1G2) {
 		return true, nil
 	} else {
-		return false, fmt.Errorf("invalid signature")
+		return false, nil
+	}
+}
+
+// Zeroize 将私钥标量 x 覆写为零:逐个覆写 big.Int 的底层字后置为 0。
+//
+// 注意:big.Int 在运算中会重新分配底层数组,Go 的垃圾回收器也可能已经复制过这些值,
+// 旧的数组与副本不在清除范围内。调用后私钥不再可用,生成的签名无法通过原公钥的验证。
+func (sk *PrivateKey) Zeroize() {
+	if sk.x == nil {
+		return
+	}
+	words := sk.x.Bits()
+	for i := range words {
+		words[i] = 0
 	}
+	sk.x.SetInt64(0)
 }
@@ -0,0 +1,127 @@
+package zss04_signature
+
+import (
+	"crypto/rand"
+	"fmt"
+	"github.com/mmsyan/GoPairingBasedCryptography/internal/curve"
+	"math/big"
+)
+
+// AggregateSignatures 将多个签名者对同一消息的签名聚合为一个签名,配合 AggregatePublicKeys 使用。
+//
+// ZSS04 签名 S_i = (1 / (H(m) + x_i)) * G1 关于私钥不是线性的,
+// ΣS_i 不等于任何私钥 Σx_i 下的签名,因此无法像 BLS 那样压缩为单个群元素;
+// 若要求聚合签名只含一个 G1 元素,必须修改方案本身(例如由签名者联合计算 1 / (H(m) + Σx_i))。
+// 这里的聚合签名按顺序保留各分量签名,Verify 对其只做一次随机线性组合的多重配对检查:
+//
+//	∏ e(δ_i * S_i, H(m)*G2 + P_i) = e(G1, G2)^(Σδ_i)
+//
+// 其中 δ_i 由验证者随机选取,防止签名者之间相互抵消伪造分量。
+//
+// 参数:
+//   - sigs: 各签名者对同一消息的签名,顺序必须与 AggregatePublicKeys 的公钥顺序一致
+//
+// 返回值:
+//   - *Signature: 聚合签名
+//   - error: 如果签名列表为空、签名未初始化或签名来自不同曲线则返回错误
+func AggregateSignatures(sigs []*Signature) (*Signature, error) {
+	if len(sigs) == 0 {
+		return nil, fmt.Errorf("unable to aggregate signatures: empty signature list")
+	}
+	var backend curve.Backend
+	var parts []curve.G1
+	for i, sig := range sigs {
+		sigBackend, s, err := sig.resolve()
+		if err != nil {
+			return nil, fmt.Errorf("unable to aggregate signatures: signature %d: %w", i, err)
+		}
+		if backend == nil {
+			backend = sigBackend
+		} else if sigBackend.ID() != backend.ID() {
+			return nil, fmt.Errorf("unable to aggregate signatures: curve mismatch between %v and %v", backend.ID(), sigBackend.ID())
+		}
+		if len(sig.parts) > 0 {
+			parts = append(parts, sig.parts...)
+		} else {
+			parts = append(parts, s)
+		}
+	}
+	return &Signature{
+		backend: backend,
+		parts:   parts,
+	}, nil
+}
+
+// AggregatePublicKeys 将多个签名者的公钥聚合为一个公钥,用于验证 AggregateSignatures 得到的聚合签名。
+// 由于 ZSS04 的验证方程中公钥位于逆元内部,聚合公钥同样按顺序保留各分量公钥。
+//
+// 参数:
+//   - pks: 各签名者的公钥,顺序必须与 AggregateSignatures 的签名顺序一致
+//
+// 返回值:
+//   - *PublicKey: 聚合公钥
+//   - error: 如果公钥列表为空、公钥未初始化或公钥来自不同曲线则返回错误
+func AggregatePublicKeys(pks []*PublicKey) (*PublicKey, error) {
+	if len(pks) == 0 {
+		return nil, fmt.Errorf("unable to aggregate public keys: empty public key list")
+	}
+	for i, pk := range pks {
+		if pk.backend == nil || (pk.p == nil && len(pk.parts) == 0) {
+			return nil, fmt.Errorf("unable to aggregate public keys: public key %d: %w", i, ErrUninitialized)
+		}
+	}
+	backend := pks[0].backend
+	var parts []curve.G2
+	for _, pk := range pks {
+		if pk.backend.ID() != backend.ID() {
+			return nil, fmt.Errorf("unable to aggregate public keys: curve mismatch between %v and %v", backend.ID(), pk.backend.ID())
+		}
+		if len(pk.parts) > 0 {
+			parts = append(parts, pk.parts...)
+		} else {
+			parts = append(parts, pk.p)
+		}
+	}
+	return &PublicKey{
+		backend: backend,
+		parts:   parts,
+	}, nil
+}
+
+// verifyAggregate 验证聚合签名: ∏ e(δ_i * S_i, H(m)*G2 + P_i) = e(G1, G2)^(Σδ_i)
+func verifyAggregate(pk *PublicKey, m *Message, sigma *Signature, pp *PublicParams) (bool, error) {
+	if len(pk.parts) != len(sigma.parts) {
+		return false, fmt.Errorf("aggregate signature has %d signers but aggregate public key has %d", len(sigma.parts), len(pk.parts))
+	}
+
+	order := pp.backend.Order()
+	hm := pp.backend.BytesToScalar(m.MessageBytes)
+	g2ExpHm := pp.backend.G2BaseMul(hm)
+
+	g1s := make([]curve.G1, len(sigma.parts))
+	g2s := make([]curve.G2, len(pk.parts))
+	deltaSum := new(big.Int)
+	for i := range sigma.parts {
+		delta, err := rand.Int(rand.Reader, order)
+		if err != nil {
+			return false, err
+		}
+		deltaSum.Add(deltaSum, delta)
+
+		// δ_i * S_i 与 H(m)*G2 + P_i
+		g1s[i] = sigma.parts[i].ScalarMul(delta)
+		g2s[i] = g2ExpHm.Add(pk.parts[i])
+	}
+	deltaSum.Mod(deltaSum, order)
+
+	pairLeft, err := pp.backend.Pair(g1s, g2s)
+	if err != nil {
+		return false, err
+	}
+
+	if pairLeft.Equal(pp.eG1G2.Exp(deltaSum)) {
+		return true, nil
+	} else {
+		return false, nil
+	}
+}
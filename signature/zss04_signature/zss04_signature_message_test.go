@@ -0,0 +1,11 @@
+package zss04_signature
+
+import (
+	"github.com/mmsyan/GoPairingBasedCryptography/internal/msgtest"
+	"testing"
+)
+
+// TestMessageBytes 检查 Message 的 Bytes/SetBytes 往返
+func TestMessageBytes(t *testing.T) {
+	msgtest.CheckRoundTrip(t, new(Message), []byte{}, []byte("attack at dawn"))
+}
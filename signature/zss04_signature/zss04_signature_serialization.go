@@ -0,0 +1,87 @@
+package zss04_signature
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"github.com/mmsyan/GoPairingBasedCryptography/internal/curve"
+	"github.com/mmsyan/GoPairingBasedCryptography/serialization"
+)
+
+// MarshalBinary 将签名序列化为规范字节串(压缩点编码)。
+// 布局: 头部 || 曲线编号(1字节) || 分量个数 n(4字节,大端序) || 签名点。
+// 普通签名 n = 0,其后为签名点;聚合签名的 n 为分量个数,其后按顺序排列各分量签名。
+//
+// 返回值:
+//   - []byte: 序列化后的签名
+//   - error: 签名未初始化(不是由 Sign 或 AggregateSignatures 得到)时返回错误
+func (sigma *Signature) MarshalBinary() ([]byte, error) {
+	backend, s, err := sigma.resolve()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal signature: %w", err)
+	}
+	data := serialization.AppendHeader(nil, serialization.SchemeZSS04Signature)
+	data = append(data, byte(backend.ID()))
+	data = binary.BigEndian.AppendUint32(data, uint32(len(sigma.parts)))
+	if len(sigma.parts) == 0 {
+		return append(data, s.Bytes()...), nil
+	}
+	for _, part := range sigma.parts {
+		data = append(data, part.Bytes()...)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary 从 MarshalBinary 的输出恢复签名。
+// 每个签名点都必须是所在曲线 G1 素数阶子群中的点的压缩编码;
+// 分量个数先与剩余数据长度比较,恶意的长度前缀不会导致过量内存分配。
+//
+// 参数:
+//   - data: 序列化后的签名
+//
+// 返回值:
+//   - error: 头部不正确、曲线未知、数据被截断、点不合法或存在多余字节时返回错误
+func (sigma *Signature) UnmarshalBinary(data []byte) error {
+	data, err := serialization.CheckHeader(data, serialization.SchemeZSS04Signature)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal signature: %w", err)
+	}
+	if len(data) < 5 {
+		return errors.New("failed to unmarshal signature: not enough bytes")
+	}
+	backend, err := curve.New(curve.ID(data[0]))
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal signature: %w", err)
+	}
+	count := binary.BigEndian.Uint32(data[1:5])
+	data = data[5:]
+	// 每个点至少占一个字节,超过剩余长度的分量个数必然是截断或伪造的
+	if uint64(count) > uint64(len(data)) {
+		return fmt.Errorf("failed to unmarshal signature: %d parts exceed %d remaining bytes", count, len(data))
+	}
+
+	result := Signature{backend: backend}
+	if count == 0 {
+		s, n, err := backend.G1FromBytes(data)
+		if err != nil {
+			return fmt.Errorf("failed to unmarshal signature: %w", err)
+		}
+		result.s = s
+		data = data[n:]
+	} else {
+		result.parts = make([]curve.G1, count)
+		for i := range result.parts {
+			part, n, err := backend.G1FromBytes(data)
+			if err != nil {
+				return fmt.Errorf("failed to unmarshal signature: part %d: %w", i, err)
+			}
+			result.parts[i] = part
+			data = data[n:]
+		}
+	}
+	if len(data) != 0 {
+		return errors.New("failed to unmarshal signature: trailing bytes")
+	}
+	*sigma = result
+	return nil
+}
@@ -0,0 +1,100 @@
+package zss04_signature
+
+import (
+	"bytes"
+	"encoding/binary"
+	"github.com/mmsyan/GoPairingBasedCryptography/serialization"
+	"testing"
+)
+
+// signatureSamples 在两条曲线上各生成一个普通签名和一个由两个签名聚合而成的签名，以及对应的公钥
+func signatureSamples(tb testing.TB) (map[string]*Signature, map[string]*PublicKey, map[string]*PublicParams, *Message) {
+	msg := &Message{MessageBytes: []byte("serialized signature")}
+	sigs := make(map[string]*Signature)
+	pks := make(map[string]*PublicKey)
+	pps := make(map[string]*PublicParams)
+	for _, c := range []Curve{BN254, BLS12381} {
+		pp := mustParams(c)
+		var parts []*Signature
+		var partKeys []*PublicKey
+		for i := 0; i < 2; i++ {
+			pk, sk, err := KeyGenerateOn(c)
+			if err != nil {
+				tb.Fatalf("KeyGenerate on %v failed: %v", c, err)
+			}
+			sig, err := Sign(sk, msg)
+			if err != nil {
+				tb.Fatalf("Sign on %v failed: %v", c, err)
+			}
+			parts, partKeys = append(parts, sig), append(partKeys, pk)
+		}
+		aggSig, err := AggregateSignatures(parts)
+		if err != nil {
+			tb.Fatalf("AggregateSignatures on %v failed: %v", c, err)
+		}
+		aggPK, err := AggregatePublicKeys(partKeys)
+		if err != nil {
+			tb.Fatalf("AggregatePublicKeys on %v failed: %v", c, err)
+		}
+		sigs[c.String()], pks[c.String()], pps[c.String()] = parts[0], partKeys[0], pp
+		sigs[c.String()+"/aggregate"], pks[c.String()+"/aggregate"], pps[c.String()+"/aggregate"] = aggSig, aggPK, pp
+	}
+	return sigs, pks, pps, msg
+}
+
+// TestSignatureMarshalRoundTrip 普通签名与聚合签名序列化往返后字节不变，且仍能通过验证
+func TestSignatureMarshalRoundTrip(t *testing.T) {
+	sigs, pks, pps, msg := signatureSamples(t)
+	for name, sig := range sigs {
+		data, err := sig.MarshalBinary()
+		if err != nil {
+			t.Fatalf("%s: MarshalBinary failed: %v", name, err)
+		}
+		restored := new(Signature)
+		if err := restored.UnmarshalBinary(data); err != nil {
+			t.Fatalf("%s: UnmarshalBinary failed: %v", name, err)
+		}
+		again, err := restored.MarshalBinary()
+		if err != nil || !bytes.Equal(again, data) {
+			t.Fatalf("%s: re-marshaled signature differs: %v", name, err)
+		}
+		if valid, err := Verify(pks[name], msg, restored, pps[name]); err != nil || !valid {
+			t.Fatalf("%s: restored signature should verify: %v", name, err)
+		}
+	}
+	if _, err := new(Signature).MarshalBinary(); err == nil {
+		t.Error("marshaling an uninitialized signature should fail")
+	}
+}
+
+// FuzzUnmarshal 向 Signature.UnmarshalBinary 输入任意字节：不允许 panic，成功解析的输入必须重新序列化为相同的字节
+func FuzzUnmarshal(f *testing.F) {
+	sigs, _, _, _ := signatureSamples(f)
+	for _, sig := range sigs {
+		data, err := sig.MarshalBinary()
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(data)
+		f.Add(data[:len(data)-1])
+	}
+	// 分量个数的长度前缀取最大值，后面没有数据
+	huge := serialization.AppendHeader(nil, serialization.SchemeZSS04Signature)
+	huge = append(huge, byte(BN254))
+	f.Add(binary.BigEndian.AppendUint32(huge, ^uint32(0)))
+	f.Add([]byte{})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		sig := new(Signature)
+		if err := sig.UnmarshalBinary(data); err != nil {
+			return
+		}
+		again, err := sig.MarshalBinary()
+		if err != nil {
+			t.Fatalf("re-marshal failed: %v", err)
+		}
+		if !bytes.Equal(again, data) {
+			t.Fatalf("re-marshaled bytes differ:\n got %x\nwant %x", again, data)
+		}
+	})
+}
@@ -2,9 +2,19 @@ package zss04_signature
 
 import (
 	"crypto/rand"
+	"errors"
 	"testing"
 )
 
+// mustParams 生成指定曲线的公共参数，失败时 panic
+func mustParams(c Curve) *PublicParams {
+	pp, err := ParamsGenerateOn(c)
+	if err != nil {
+		panic(err)
+	}
+	return pp
+}
+
 // TestParamsGenerate 测试公共参数生成
 func TestParamsGenerate(t *testing.T) {
 	pp, err := ParamsGenerate()
@@ -32,7 +42,7 @@ func TestParamsGenerateConsistency(t *testing.T) {
 	}
 
 	// 公共参数应该是一致的（固定的生成元配对）
-	if !pp1.eG1G2.Equal(&pp2.eG1G2) {
+	if !pp1.eG1G2.Equal(pp2.eG1G2) {
 		t.Error("多次生成的公共参数不一致")
 	}
 }
@@ -54,13 +64,13 @@ func TestKeyGenerate(t *testing.T) {
 	}
 
 	// 验证私钥不是零
-	if sk.x.IsZero() {
+	if sk.x.Sign() == 0 {
 		t.Error("私钥 x 是零")
 	}
 
 	// 验证公钥点在正确的子群中
-	if !pk.p.IsInSubGroup() {
-		t.Error("公钥点不在正确的子群中")
+	if err := pk.Validate(); err != nil {
+		t.Errorf("公钥不合法: %v", err)
 	}
 }
 
@@ -74,11 +84,11 @@ func TestKeyGenerateUniqueness(t *testing.T) {
 	}
 
 	// 两次生成的密钥应该不同
-	if sk1.x.Equal(&sk2.x) {
+	if sk1.x.Cmp(sk2.x) == 0 {
 		t.Error("两次密钥生成产生了相同的私钥")
 	}
 
-	if pk1.p.Equal(&pk2.p) {
+	if pk1.p.Equal(pk2.p) {
 		t.Error("两次密钥生成产生了相同的公钥")
 	}
 }
@@ -104,8 +114,8 @@ func TestSignBasic(t *testing.T) {
 	}
 
 	// 验证签名点在正确的子群中
-	if !sig.S.IsInSubGroup() {
-		t.Error("签名点不在正确的子群中")
+	if err := sig.Validate(); err != nil {
+		t.Errorf("签名不合法: %v", err)
 	}
 }
 
@@ -216,8 +226,8 @@ func TestVerifyWrongMessage(t *testing.T) {
 	}
 
 	valid, err := Verify(pk, msg2, sig, pp)
-	if err == nil {
-		t.Error("期望验证失败但没有返回错误")
+	if err != nil {
+		t.Errorf("格式正确的无效签名不应返回错误: %v", err)
 	}
 
 	if valid {
@@ -262,8 +272,8 @@ func TestVerifyWrongPublicKey(t *testing.T) {
 
 	// 用错误的公钥验证应该失败
 	valid, err = Verify(pk2, msg, sig, pp)
-	if err == nil {
-		t.Error("期望验证失败但没有返回错误")
+	if err != nil {
+		t.Errorf("格式正确的无效签名不应返回错误: %v", err)
 	}
 	if valid {
 		t.Error("使用错误公钥的签名验证通过")
@@ -333,7 +343,7 @@ func TestSignDeterminism(t *testing.T) {
 	}
 
 	// ZSS04 签名是确定性的，同一消息应产生相同的签名
-	if !sig1.S.Equal(&sig2.S) {
+	if !sig1.s.Equal(sig2.s) {
 		t.Error("同一消息的两次签名产生了不同的结果")
 	}
 }
@@ -379,11 +389,71 @@ func TestCompleteWorkflow(t *testing.T) {
 	}
 
 	valid, err = Verify(pk, tamperedMsg, sig, pp)
-	if valid || err == nil {
+	if valid || err != nil {
 		t.Error("完整工作流程：未能检测到消息篡改")
 	}
 }
 
+// TestWorkflowOnCurves 在 BN254 与 BLS12-381 上分别运行完整的签名/验证流程
+func TestWorkflowOnCurves(t *testing.T) {
+	for _, c := range []Curve{BN254, BLS12381} {
+		t.Run(c.String(), func(t *testing.T) {
+			pp, err := ParamsGenerateOn(c)
+			if err != nil {
+				t.Fatalf("生成公共参数失败: %v", err)
+			}
+			if pp.Curve() != c {
+				t.Fatalf("公共参数曲线错误: got %v, want %v", pp.Curve(), c)
+			}
+			pk, sk, err := KeyGenerateOn(c)
+			if err != nil {
+				t.Fatalf("生成密钥失败: %v", err)
+			}
+			if err := pk.Validate(); err != nil {
+				t.Errorf("公钥不合法: %v", err)
+			}
+
+			msg := &Message{MessageBytes: []byte("multi-curve workflow")}
+			sig, err := Sign(sk, msg)
+			if err != nil {
+				t.Fatalf("签名失败: %v", err)
+			}
+			if err := sig.Validate(); err != nil {
+				t.Errorf("签名不合法: %v", err)
+			}
+			valid, err := Verify(pk, msg, sig, pp)
+			if err != nil || !valid {
+				t.Fatalf("签名验证失败: %v", err)
+			}
+
+			tampered := &Message{MessageBytes: []byte("tampered")}
+			if valid, err := Verify(pk, tampered, sig, pp); valid || err != nil {
+				t.Error("未能检测到消息篡改")
+			}
+		})
+	}
+}
+
+// TestVerifyCurveMismatch 混用不同曲线的参数、公钥和签名时验证失败
+func TestVerifyCurveMismatch(t *testing.T) {
+	ppBLS := mustParams(BLS12381)
+	pk, sk, err := KeyGenerate()
+	if err != nil {
+		t.Fatalf("生成密钥失败: %v", err)
+	}
+	msg := &Message{MessageBytes: []byte("curve mismatch")}
+	sig, err := Sign(sk, msg)
+	if err != nil {
+		t.Fatalf("签名失败: %v", err)
+	}
+	if valid, err := Verify(pk, msg, sig, ppBLS); valid || err == nil {
+		t.Error("不同曲线的公共参数不应通过验证")
+	}
+	if _, err := ParamsGenerateOn(Curve(42)); err == nil {
+		t.Error("不支持的曲线应返回错误")
+	}
+}
+
 // BenchmarkParamsGenerate 性能测试：公共参数生成
 func BenchmarkParamsGenerate(b *testing.B) {
 	for i := 0; i < b.N; i++ {
@@ -457,3 +527,135 @@ func BenchmarkVerifyLargeMessage(b *testing.B) {
 		_, _ = Verify(pk, msg, sig, pp)
 	}
 }
+
+// TestAggregateVerify 测试多个签名者对同一消息的聚合签名可以通过验证
+func TestAggregateVerify(t *testing.T) {
+	for _, c := range []Curve{BN254, BLS12381} {
+		pp := mustParams(c)
+		msg := &Message{MessageBytes: []byte("multi-signer message")}
+
+		const numSigners = 3
+		pks := make([]*PublicKey, numSigners)
+		sigs := make([]*Signature, numSigners)
+		for i := 0; i < numSigners; i++ {
+			pk, sk, err := KeyGenerateOn(c)
+			if err != nil {
+				t.Fatalf("KeyGenerate on %v failed: %v", c, err)
+			}
+			sig, err := Sign(sk, msg)
+			if err != nil {
+				t.Fatalf("Sign on %v failed: %v", c, err)
+			}
+			pks[i], sigs[i] = pk, sig
+		}
+
+		aggPK, err := AggregatePublicKeys(pks)
+		if err != nil {
+			t.Fatalf("AggregatePublicKeys on %v failed: %v", c, err)
+		}
+		aggSig, err := AggregateSignatures(sigs)
+		if err != nil {
+			t.Fatalf("AggregateSignatures on %v failed: %v", c, err)
+		}
+		valid, err := Verify(aggPK, msg, aggSig, pp)
+		if err != nil || !valid {
+			t.Fatalf("aggregate signature on %v should verify: %v", c, err)
+		}
+
+		// 消息被篡改时验证失败
+		wrong := &Message{MessageBytes: []byte("another message")}
+		if valid, _ := Verify(aggPK, wrong, aggSig, pp); valid {
+			t.Errorf("aggregate signature on %v should not verify a different message", c)
+		}
+	}
+}
+
+// TestAggregateVerifyMissingSigner 测试缺少一个签名者的签名时聚合验证失败
+func TestAggregateVerifyMissingSigner(t *testing.T) {
+	pp := mustParams(BN254)
+	msg := &Message{MessageBytes: []byte("multi-signer message")}
+
+	pks := make([]*PublicKey, 3)
+	sigs := make([]*Signature, 3)
+	for i := range pks {
+		pk, sk, _ := KeyGenerate()
+		sig, _ := Sign(sk, msg)
+		pks[i], sigs[i] = pk, sig
+	}
+	aggPK, _ := AggregatePublicKeys(pks)
+
+	// 缺少最后一个签名者
+	partial, _ := AggregateSignatures(sigs[:2])
+	if valid, err := Verify(aggPK, msg, partial, pp); valid || err == nil {
+		t.Error("aggregate signature missing a signer should not verify")
+	}
+
+	// 用第一个签名者的签名冒充最后一个签名者
+	forged, _ := AggregateSignatures([]*Signature{sigs[0], sigs[1], sigs[0]})
+	if valid, err := Verify(aggPK, msg, forged, pp); valid || err != nil {
+		t.Error("aggregate signature with a substituted signer should not verify")
+	}
+
+	// 单个签名不能通过聚合公钥验证
+	if valid, err := Verify(aggPK, msg, sigs[0], pp); valid || err == nil {
+		t.Error("single signature should not verify against aggregate public key")
+	}
+}
+
+// TestPrivateKeyZeroize 测试 Zeroize 将私钥置零，且置零后的私钥生成的签名无法通过验证
+func TestPrivateKeyZeroize(t *testing.T) {
+	pp := mustParams(BN254)
+	pk, sk, err := KeyGenerate()
+	if err != nil {
+		t.Fatalf("KeyGenerate 失败: %v", err)
+	}
+
+	sk.Zeroize()
+	if sk.x.Sign() != 0 {
+		t.Fatal("Zeroize 之后私钥 x 不为零")
+	}
+
+	msg := &Message{MessageBytes: []byte("Hello, World!")}
+	sig, err := Sign(sk, msg)
+	if err != nil {
+		t.Fatalf("Sign 失败: %v", err)
+	}
+	if valid, _ := Verify(pk, msg, sig, pp); valid {
+		t.Fatal("置零后的私钥生成的签名通过了验证")
+	}
+}
+
+// TestUninitializedValues 零值的参数、密钥与签名返回 ErrUninitialized 而不是 panic;
+// 直接构造、只给出 S 的 BN254 签名仍按原接口验证
+func TestUninitializedValues(t *testing.T) {
+	pp, _ := ParamsGenerate()
+	pk, sk, _ := KeyGenerate()
+	msg := &Message{MessageBytes: []byte("uninitialized")}
+	sig, err := Sign(sk, msg)
+	if err != nil {
+		t.Fatalf("Sign 失败: %v", err)
+	}
+
+	if _, err := Sign(new(PrivateKey), msg); !errors.Is(err, ErrUninitialized) {
+		t.Errorf("零值私钥: 期望 ErrUninitialized, 实际为 %v", err)
+	}
+	for name, args := range map[string]struct {
+		pk  *PublicKey
+		sig *Signature
+		pp  *PublicParams
+	}{
+		"零值公钥": {new(PublicKey), sig, pp},
+		"零值签名": {pk, new(Signature), pp},
+		"零值参数": {pk, sig, new(PublicParams)},
+	} {
+		if valid, err := Verify(args.pk, msg, args.sig, args.pp); valid || !errors.Is(err, ErrUninitialized) {
+			t.Errorf("%s: 期望 ErrUninitialized, 实际为 (%v, %v)", name, valid, err)
+		}
+	}
+	if _, err := AggregateSignatures([]*Signature{sig, new(Signature)}); !errors.Is(err, ErrUninitialized) {
+		t.Errorf("聚合零值签名: 期望 ErrUninitialized, 实际为 %v", err)
+	}
+	if _, err := AggregatePublicKeys([]*PublicKey{pk, new(PublicKey)}); !errors.Is(err, ErrUninitialized) {
+		t.Errorf("聚合零值公钥: 期望 ErrUninitialized, 实际为 %v", err)
+	}
+}
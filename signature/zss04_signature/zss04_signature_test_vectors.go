@@ -0,0 +1,114 @@
+package zss04_signature
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+)
+
+// testVectorScheme 是 ZSS04 测试向量文件中 scheme 字段的取值
+const testVectorScheme = "zss04-signature"
+
+// ErrTestVectorMismatch 表示按测试向量重新计算的结果与向量中记录的期望值不一致
+var ErrTestVectorMismatch = errors.New("test vector mismatch")
+
+// TestVector 是 ZSS04 签名的一组固定测试向量,二进制字段均为十六进制字符串。
+// ZSS04 签名是确定性的,给定私钥与消息即可逐字节复现公钥和签名。
+type TestVector struct {
+	Name       string `json:"name"`
+	Curve      string `json:"curve"`       // 曲线名称,取 Curve.String() 的值
+	PrivateKey string `json:"private_key"` // 私钥 x,大端序
+	PublicKey  string `json:"public_key"`  // 期望的公钥点 P = x * G2 的压缩编码
+	Message    string `json:"message"`     // 消息字节
+	Signature  string `json:"signature"`   // 期望的签名点 S 的压缩编码
+}
+
+// testVectorFile 是测试向量文件的顶层结构
+type testVectorFile struct {
+	Scheme  string       `json:"scheme"`
+	Vectors []TestVector `json:"vectors"`
+}
+
+// LoadTestVectors 读取 JSON 格式的测试向量文件(通常位于 testdata 目录)。
+//
+// 参数:
+//   - path: 测试向量文件路径
+//
+// 返回值:
+//   - []TestVector: 文件中的全部测试向量
+//   - error: 读取失败、JSON 格式错误或 scheme 字段不是 ZSS04 时返回错误
+func LoadTestVectors(path string) ([]TestVector, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load test vectors: %w", err)
+	}
+	var file testVectorFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to load test vectors: %w", err)
+	}
+	if file.Scheme != testVectorScheme {
+		return nil, fmt.Errorf("failed to load test vectors: scheme is %q, want %q", file.Scheme, testVectorScheme)
+	}
+	return file.Vectors, nil
+}
+
+// Check 按测试向量重新计算公钥与签名,并与记录的期望值逐字节比较,最后验证签名。
+//
+// 返回值:
+//   - error: 曲线未知或字段无法解码时返回错误;任一结果不一致时返回包装 ErrTestVectorMismatch 的错误
+func (v *TestVector) Check() error {
+	var c Curve
+	switch v.Curve {
+	case BN254.String():
+		c = BN254
+	case BLS12381.String():
+		c = BLS12381
+	default:
+		return fmt.Errorf("test vector %q: unknown curve %q", v.Name, v.Curve)
+	}
+	pp, err := ParamsGenerateOn(c)
+	if err != nil {
+		return fmt.Errorf("test vector %q: %w", v.Name, err)
+	}
+	fields := make(map[string][]byte, 4)
+	for name, value := range map[string]string{
+		"private_key": v.PrivateKey,
+		"public_key":  v.PublicKey,
+		"message":     v.Message,
+		"signature":   v.Signature,
+	} {
+		if fields[name], err = hex.DecodeString(value); err != nil {
+			return fmt.Errorf("test vector %q field %s: %w", v.Name, name, err)
+		}
+	}
+	x := new(big.Int).SetBytes(fields["private_key"])
+	if x.Sign() == 0 || x.Cmp(pp.backend.Order()) >= 0 {
+		return fmt.Errorf("test vector %q: private key out of range", v.Name)
+	}
+
+	sk := &PrivateKey{backend: pp.backend, x: x}
+	pk := &PublicKey{backend: pp.backend, p: pp.backend.G2BaseMul(x)}
+	if !bytes.Equal(pk.p.Bytes(), fields["public_key"]) {
+		return fmt.Errorf("test vector %q: public key differs: %w", v.Name, ErrTestVectorMismatch)
+	}
+	m := &Message{MessageBytes: fields["message"]}
+	sigma, err := Sign(sk, m)
+	if err != nil {
+		return fmt.Errorf("test vector %q: %w", v.Name, err)
+	}
+	if _, s, _ := sigma.resolve(); !bytes.Equal(s.Bytes(), fields["signature"]) {
+		return fmt.Errorf("test vector %q: signature differs: %w", v.Name, ErrTestVectorMismatch)
+	}
+	ok, err := Verify(pk, m, sigma, pp)
+	if err != nil {
+		return fmt.Errorf("test vector %q: %w", v.Name, err)
+	}
+	if !ok {
+		return fmt.Errorf("test vector %q: signature does not verify: %w", v.Name, ErrTestVectorMismatch)
+	}
+	return nil
+}
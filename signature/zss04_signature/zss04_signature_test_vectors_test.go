@@ -0,0 +1,94 @@
+package zss04_signature
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"math/big"
+	"os"
+	"testing"
+)
+
+// testVectorPath 是 ZSS04 的黄金测试向量文件
+const testVectorPath = "testdata/zss04_signature_vectors.json"
+
+// updateVectors 为 true 时重新生成黄金测试向量文件：go test -run TestZSS04TestVectors -update-vectors
+var updateVectors = flag.Bool("update-vectors", false, "regenerate testdata/zss04_signature_vectors.json")
+
+// newTestVector 用固定私钥 x 对消息签名，生成一组测试向量
+func newTestVector(t *testing.T, name string, c Curve, x int64, message string) TestVector {
+	t.Helper()
+	pp := mustParams(c)
+	sk := &PrivateKey{backend: pp.backend, x: big.NewInt(x)}
+	sigma, err := Sign(sk, &Message{MessageBytes: []byte(message)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, point, _ := sigma.resolve()
+	return TestVector{
+		Name:       name,
+		Curve:      c.String(),
+		PrivateKey: hex.EncodeToString(sk.x.Bytes()),
+		PublicKey:  hex.EncodeToString(pp.backend.G2BaseMul(sk.x).Bytes()),
+		Message:    hex.EncodeToString([]byte(message)),
+		Signature:  hex.EncodeToString(point.Bytes()),
+	}
+}
+
+// TestZSS04TestVectors 加载黄金测试向量并逐一检查，防止重构时无意改变签名结果
+func TestZSS04TestVectors(t *testing.T) {
+	if *updateVectors {
+		file := testVectorFile{Scheme: testVectorScheme, Vectors: []TestVector{
+			newTestVector(t, "bn254-hello", BN254, 1234567, "Hello, World!"),
+			newTestVector(t, "bn254-empty", BN254, 7654321, ""),
+			newTestVector(t, "bls12381-hello", BLS12381, 1234567, "Hello, World!"),
+		}}
+		data, err := json.MarshalIndent(file, "", "  ")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(testVectorPath, append(data, '\n'), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	vectors, err := LoadTestVectors(testVectorPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(vectors) == 0 {
+		t.Fatal("测试向量文件为空")
+	}
+	for i := range vectors {
+		if err := vectors[i].Check(); err != nil {
+			t.Errorf("%s: %v", vectors[i].Name, err)
+		}
+	}
+}
+
+// TestZSS04TestVectorMismatch 篡改消息或私钥后 Check 必须报告不一致
+func TestZSS04TestVectorMismatch(t *testing.T) {
+	vectors, err := LoadTestVectors(testVectorPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tampered := vectors[0]
+	tampered.Message = hex.EncodeToString([]byte("Hello, World?"))
+	if err := tampered.Check(); !errors.Is(err, ErrTestVectorMismatch) {
+		t.Fatalf("期望 ErrTestVectorMismatch，得到 %v", err)
+	}
+
+	tampered = vectors[0]
+	tampered.PrivateKey = vectors[1].PrivateKey
+	if err := tampered.Check(); !errors.Is(err, ErrTestVectorMismatch) {
+		t.Fatalf("期望 ErrTestVectorMismatch，得到 %v", err)
+	}
+
+	tampered = vectors[0]
+	tampered.Curve = "P-256"
+	if err := tampered.Check(); err == nil {
+		t.Fatal("未知曲线应返回错误")
+	}
+}
@@ -0,0 +1,84 @@
+package zss04_signature
+
+import (
+	"errors"
+	"fmt"
+	"github.com/mmsyan/GoPairingBasedCryptography/internal/curve"
+	"github.com/mmsyan/GoPairingBasedCryptography/serialization"
+	"math/big"
+)
+
+// ErrUninitialized 表示公共参数、密钥或签名是零值结构体,而不是由 ParamsGenerate、KeyGenerate、Sign、Aggregate* 或 UnmarshalBinary 得到的
+var ErrUninitialized = errors.New("uninitialized ZSS04 parameters, key or signature")
+
+// Validate 检查来自不可信来源的公钥:公钥点(聚合公钥为每个分量公钥)必须是 G2 素数阶子群中的非无穷远点。
+// 无穷远点对应私钥 x = 0,任何人都可以为它伪造签名 S = (1 / H(m)) * G1。
+//
+// 返回值:
+//   - error: 公钥未初始化时返回 ErrUninitialized;公钥点不合法时返回指明分量、并包装 serialization.ErrInvalidG2Point 的错误
+func (pk *PublicKey) Validate() error {
+	if pk.backend == nil || (pk.p == nil && len(pk.parts) == 0) {
+		return fmt.Errorf("invalid public key: %w", ErrUninitialized)
+	}
+	if len(pk.parts) == 0 {
+		if err := validateG2(pk.backend, pk.p); err != nil {
+			return fmt.Errorf("invalid public key: %w", err)
+		}
+		return nil
+	}
+	for i, part := range pk.parts {
+		if err := validateG2(pk.backend, part); err != nil {
+			return fmt.Errorf("invalid public key: part %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// Validate 检查来自不可信来源的签名:签名点(聚合签名为每个分量签名)必须是 G1 素数阶子群中的非无穷远点。
+//
+// 返回值:
+//   - error: 签名未初始化时返回 ErrUninitialized;签名点不合法时返回指明分量、并包装 serialization.ErrInvalidG1Point 的错误
+func (sigma *Signature) Validate() error {
+	backend, s, err := sigma.resolve()
+	if err != nil {
+		return fmt.Errorf("invalid signature: %w", err)
+	}
+	if len(sigma.parts) == 0 {
+		if err := validateG1(backend, s); err != nil {
+			return fmt.Errorf("invalid signature: %w", err)
+		}
+		return nil
+	}
+	for i, part := range sigma.parts {
+		if err := validateG1(backend, part); err != nil {
+			return fmt.Errorf("invalid signature: part %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// validateG1 按 serialization.ValidateG1 的规则检查所选曲线上的 G1 点
+func validateG1(backend curve.Backend, p curve.G1) error {
+	switch {
+	case p == nil:
+		return fmt.Errorf("missing point: %w", serialization.ErrInvalidG1Point)
+	case p.Equal(backend.G1BaseMul(new(big.Int))):
+		return fmt.Errorf("point at infinity: %w", serialization.ErrInvalidG1Point)
+	case !p.IsInSubGroup():
+		return fmt.Errorf("point not in prime-order subgroup: %w", serialization.ErrInvalidG1Point)
+	}
+	return nil
+}
+
+// validateG2 按 serialization.ValidateG2 的规则检查所选曲线上的 G2 点
+func validateG2(backend curve.Backend, p curve.G2) error {
+	switch {
+	case p == nil:
+		return fmt.Errorf("missing point: %w", serialization.ErrInvalidG2Point)
+	case p.Equal(backend.G2BaseMul(new(big.Int))):
+		return fmt.Errorf("point at infinity: %w", serialization.ErrInvalidG2Point)
+	case !p.IsInSubGroup():
+		return fmt.Errorf("point not in prime-order subgroup: %w", serialization.ErrInvalidG2Point)
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package zss04_signature
+
+import (
+	"errors"
+	"github.com/mmsyan/GoPairingBasedCryptography/internal/curve"
+	"github.com/mmsyan/GoPairingBasedCryptography/serialization"
+	"math/big"
+	"testing"
+)
+
+// TestValidate 合法的公钥、签名及其聚合通过检查;未初始化或含无穷远点的公钥与签名被拒绝
+func TestValidate(t *testing.T) {
+	for _, c := range []Curve{BN254, BLS12381} {
+		t.Run(c.String(), func(t *testing.T) {
+			pp, err := ParamsGenerateOn(c)
+			if err != nil {
+				t.Fatalf("生成公共参数失败: %v", err)
+			}
+			pk, sk, err := KeyGenerateOn(c)
+			if err != nil {
+				t.Fatalf("生成密钥失败: %v", err)
+			}
+			sig, err := Sign(sk, &Message{MessageBytes: []byte("validate")})
+			if err != nil {
+				t.Fatalf("签名失败: %v", err)
+			}
+			aggPk, _ := AggregatePublicKeys([]*PublicKey{pk, pk})
+			aggSig, _ := AggregateSignatures([]*Signature{sig, sig})
+			for name, validate := range map[string]func() error{"公钥": pk.Validate, "签名": sig.Validate, "聚合公钥": aggPk.Validate, "聚合签名": aggSig.Validate} {
+				if err := validate(); err != nil {
+					t.Errorf("%s: 合法值被拒绝: %v", name, err)
+				}
+			}
+
+			zero := new(big.Int)
+			infinityPk := &PublicKey{backend: pp.backend, p: pp.backend.G2BaseMul(zero)}
+			infinityPart := &PublicKey{backend: pp.backend, parts: []curve.G2{pk.p, pp.backend.G2BaseMul(zero)}}
+			for name, bad := range map[string]*PublicKey{"无穷远公钥": infinityPk, "含无穷远分量的聚合公钥": infinityPart} {
+				if err := bad.Validate(); !errors.Is(err, serialization.ErrInvalidG2Point) {
+					t.Errorf("%s: 期望 ErrInvalidG2Point, 实际为 %v", name, err)
+				}
+			}
+			infinitySig := &Signature{backend: pp.backend, s: pp.backend.G1BaseMul(zero)}
+			if err := infinitySig.Validate(); !errors.Is(err, serialization.ErrInvalidG1Point) {
+				t.Errorf("无穷远签名: 期望 ErrInvalidG1Point, 实际为 %v", err)
+			}
+		})
+	}
+
+	if err := new(PublicKey).Validate(); !errors.Is(err, ErrUninitialized) {
+		t.Errorf("零值公钥: 期望 ErrUninitialized, 实际为 %v", err)
+	}
+	if err := new(Signature).Validate(); !errors.Is(err, ErrUninitialized) {
+		t.Errorf("零值签名: 期望 ErrUninitialized, 实际为 %v", err)
+	}
+}
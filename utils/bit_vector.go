@@ -0,0 +1,43 @@
+package utils
+
+import "strings"
+
+// BitString 将 0/1 向量转换为二进制字符串，非零元素记为 '1'
+//
+// 参数:
+//   - bits: 0/1 向量
+//
+// 返回值:
+//   - string: 长度与 bits 相同的二进制字符串
+func BitString(bits []int) string {
+	var sb strings.Builder
+	sb.Grow(len(bits))
+	for _, b := range bits {
+		if b != 0 {
+			sb.WriteByte('1')
+		} else {
+			sb.WriteByte('0')
+		}
+	}
+	return sb.String()
+}
+
+// HammingDistance 计算两个 0/1 向量的汉明距离；长度不同时，较长向量多出的位均计为不同
+//
+// 参数:
+//   - a, b: 0/1 向量
+//
+// 返回值:
+//   - int: 取值不同的位数
+func HammingDistance(a, b []int) int {
+	if len(a) < len(b) {
+		a, b = b, a
+	}
+	distance := len(a) - len(b)
+	for i := range b {
+		if (a[i] != 0) != (b[i] != 0) {
+			distance++
+		}
+	}
+	return distance
+}
@@ -0,0 +1,25 @@
+package utils
+
+import "github.com/consensys/gnark-crypto/ecc/bn254/fr"
+
+// DedupAttributes 返回去除重复元素后的属性集合，保留每个属性第一次出现的位置。
+// 属性集合在语义上是集合：同一属性出现多次不应被计为多个属性，
+// 否则在门限判断中一个属性重复 d 次就可能冒充 d 个不同属性。
+//
+// 参数:
+//   - attributes: 可能包含重复元素的属性列表
+//
+// 返回值:
+//   - []fr.Element: 去重后的新切片，不与输入共享底层数组
+func DedupAttributes(attributes []fr.Element) []fr.Element {
+	result := make([]fr.Element, 0, len(attributes))
+	seen := make(map[fr.Element]struct{}, len(attributes))
+	for _, a := range attributes {
+		if _, ok := seen[a]; ok {
+			continue
+		}
+		seen[a] = struct{}{}
+		result = append(result, a)
+	}
+	return result
+}
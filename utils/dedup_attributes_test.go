@@ -0,0 +1,31 @@
+package utils
+
+import "testing"
+
+func TestDedupAttributes(t *testing.T) {
+	got := DedupAttributes(int64sToElements(3, 1, 3, 2, 1, 1))
+	want := int64sToElements(3, 1, 2)
+	if len(got) != len(want) {
+		t.Fatalf("got %d attributes, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if !got[i].Equal(&want[i]) {
+			t.Fatalf("got[%d] = %v, want %v", i, got[i].String(), want[i].String())
+		}
+	}
+}
+
+// TestFindCommonAttributesDuplicates 重复的属性只能计为一个：{A,A,A} 与 {A,B,C,D} 的交集大小为 1
+func TestFindCommonAttributesDuplicates(t *testing.T) {
+	user := int64sToElements(1, 1, 1)
+	policy := int64sToElements(1, 2, 3, 4)
+	if common := FindCommonAttributes(user, policy, 3); common != nil {
+		t.Fatalf("duplicates must not satisfy the threshold, got %d common attributes", len(common))
+	}
+	if _, ok := FindCommonAttributesConstantTime(user, policy, 3); ok {
+		t.Fatal("duplicates must not satisfy the threshold")
+	}
+	if _, ok := FindCommonAttributesConstantTime(policy, user, 3); ok {
+		t.Fatal("duplicates must not satisfy the threshold")
+	}
+}
@@ -0,0 +1,29 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// EpochOf 返回时间 t 所在的时间段编号 floor(t / period)，时间段从 Unix 纪元开始计数。
+// 纪元之前的时间向下取整，例如纪元前 1 纳秒属于时间段 -1 而不是 0，
+// 因此每个时间段都恰好覆盖 period 长的区间。
+func EpochOf(t time.Time, period time.Duration) (int64, error) {
+	if period <= 0 {
+		return 0, errors.New("epoch period must be positive")
+	}
+	nanos, p := t.UnixNano(), int64(period)
+	epoch := nanos / p
+	// Go 的整数除法向零截断，负数且不能整除时需要再减一
+	if nanos%p != 0 && nanos < 0 {
+		epoch--
+	}
+	return epoch, nil
+}
+
+// EpochIdentity 将基础身份与时间段编号拼接为带有效期的身份字符串 "base||epoch"。
+// 同一 base、同一时间段总是得到相同的字符串，因此加密方与 PKG 可以各自独立推导。
+func EpochIdentity(base string, epoch int64) string {
+	return fmt.Sprintf("%s||%d", base, epoch)
+}
@@ -0,0 +1,35 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+// TestEpochOf 纪元前后的时间都按 floor(t / period) 划分时间段
+func TestEpochOf(t *testing.T) {
+	epoch0 := time.Unix(0, 0)
+	tests := []struct {
+		t    time.Time
+		want int64
+	}{
+		{epoch0, 0},
+		{epoch0.Add(time.Hour - 1), 0},
+		{epoch0.Add(time.Hour), 1},
+		{epoch0.Add(-1), -1},
+		{epoch0.Add(-time.Hour), -1},
+		{epoch0.Add(-time.Hour - 1), -2},
+	}
+	for _, tt := range tests {
+		got, err := EpochOf(tt.t, time.Hour)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tt.want {
+			t.Errorf("EpochOf(%v) = %d, want %d", tt.t.UTC(), got, tt.want)
+		}
+	}
+
+	if _, err := EpochOf(epoch0, 0); err == nil {
+		t.Fatal("non-positive period should be rejected")
+	}
+}
@@ -1,6 +1,9 @@
 package utils
 
-import "github.com/consensys/gnark-crypto/ecc/bn254/fr"
+import (
+	"crypto/subtle"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+)
 
 // FindCommonAttributes
 // 如果attribute1和attribute2当中相同的元素超过指定的requiredCount个，返回长度为requiredCount的相同元素，否则返回nil
@@ -33,3 +36,56 @@ func FindCommonAttributes(attributes1 []fr.Element, attributes2 []fr.Element, re
 	// 如果不满足，返回 nil
 	return nil
 }
+
+// FindCommonAttributesConstantTime 是 FindCommonAttributes 的常数时间版本。
+// 它总是完整地两两比较 attributes1 与 attributes2 中的全部元素（不使用 map，不提前退出），
+// 逐字节比较采用 crypto/subtle，使得运行时间只依赖于两个集合的长度，
+// 而与匹配元素出现的位置、交集大小无关。
+//
+// 威胁模型：在 FIBE 访问控制中，攻击者可以观测解密耗时。若求交过程因匹配位置或提前满足门限而耗时不同，
+// 攻击者即可推断用户属性与密文属性的重叠程度。使用本函数后，计时只泄露最终的"是否满足门限"这一比特，
+// 而这一比特本身就会由解密成功与否暴露。
+//
+// 参数:
+//   - attributes1: 第一个属性集合（例如用户属性）
+//   - attributes2: 第二个属性集合（例如密文属性），返回结果按该集合的顺序排列并去重
+//   - requiredCount: 门限值
+//
+// 返回值:
+//   - []fr.Element: 两个集合的交集
+//   - bool: 交集大小是否达到 requiredCount
+func FindCommonAttributesConstantTime(attributes1 []fr.Element, attributes2 []fr.Element, requiredCount int) ([]fr.Element, bool) {
+	if requiredCount < 0 {
+		requiredCount = 0
+	}
+	bytes1 := make([][fr.Bytes]byte, len(attributes1))
+	for i := range attributes1 {
+		bytes1[i] = attributes1[i].Bytes()
+	}
+	bytes2 := make([][fr.Bytes]byte, len(attributes2))
+	for j := range attributes2 {
+		bytes2[j] = attributes2[j].Bytes()
+	}
+
+	commonAttributes := make([]fr.Element, 0, len(attributes2))
+	count := 0
+	for j := range bytes2 {
+		// matched = 1 当且仅当 attributes2[j] 出现在 attributes1 中
+		matched := 0
+		for i := range bytes1 {
+			matched |= subtle.ConstantTimeCompare(bytes1[i][:], bytes2[j][:])
+		}
+		// duplicate = 1 当且仅当 attributes2[j] 在 attributes2 中更早出现过
+		duplicate := 0
+		for k := 0; k < j; k++ {
+			duplicate |= subtle.ConstantTimeCompare(bytes2[k][:], bytes2[j][:])
+		}
+		include := matched &^ duplicate
+		// 始终执行一次追加，再根据 include 决定是否保留，避免分支
+		commonAttributes = append(commonAttributes[:count], attributes2[j])
+		count += include
+	}
+	commonAttributes = commonAttributes[:count]
+
+	return commonAttributes, subtle.ConstantTimeLessOrEq(requiredCount, count) == 1
+}
@@ -0,0 +1,71 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+)
+
+func int64sToElements(values ...int64) []fr.Element {
+	elements := make([]fr.Element, len(values))
+	for i, v := range values {
+		elements[i].SetInt64(v)
+	}
+	return elements
+}
+
+func TestFindCommonAttributesConstantTime(t *testing.T) {
+	a := int64sToElements(1, 2, 3, 4, 5)
+	b := int64sToElements(9, 5, 3, 3, 7, 1)
+
+	common, ok := FindCommonAttributesConstantTime(a, b, 3)
+	if !ok {
+		t.Fatal("expected threshold 3 to be met")
+	}
+	want := int64sToElements(5, 3, 1)
+	if len(common) != len(want) {
+		t.Fatalf("got %d common attributes, want %d", len(common), len(want))
+	}
+	for i := range want {
+		if !common[i].Equal(&want[i]) {
+			t.Fatalf("common[%d] = %v, want %v", i, common[i].String(), want[i].String())
+		}
+	}
+
+	// 与原实现的结果保持一致
+	legacy := FindCommonAttributes(a, b, 3)
+	for i := range legacy {
+		if !legacy[i].Equal(&common[i]) {
+			t.Fatal("constant-time variant disagrees with FindCommonAttributes")
+		}
+	}
+
+	if _, ok := FindCommonAttributesConstantTime(a, b, 4); ok {
+		t.Fatal("expected threshold 4 not to be met")
+	}
+}
+
+// BenchmarkFindCommonAttributesConstantTime 对比匹配元素出现在切片开头、结尾以及完全不匹配时的耗时，
+// 三者应当基本一致。
+func BenchmarkFindCommonAttributesConstantTime(b *testing.B) {
+	const n = 64
+	universe := make([]int64, n)
+	for i := range universe {
+		universe[i] = int64(i + 1)
+	}
+	user := int64sToElements(universe...)
+
+	cases := map[string][]fr.Element{
+		"matches-at-front": int64sToElements(append(universe[:8:8], 1001, 1002, 1003, 1004, 1005, 1006, 1007, 1008)...),
+		"matches-at-back":  int64sToElements(append([]int64{1001, 1002, 1003, 1004, 1005, 1006, 1007, 1008}, universe[n-8:]...)...),
+		"no-matches":       int64sToElements(1001, 1002, 1003, 1004, 1005, 1006, 1007, 1008, 1009, 1010, 1011, 1012, 1013, 1014, 1015, 1016),
+	}
+	for _, name := range []string{"matches-at-front", "matches-at-back", "no-matches"} {
+		message := cases[name]
+		b.Run(name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				FindCommonAttributesConstantTime(user, message, 4)
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package utils
+
+import (
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"math/big"
+)
+
+// LagrangeCombineGT 在指数上做拉格朗日插值：∏_{i ∈ S} points[i]^{Δ_{i,S}(target)}。
+// 若 points[i] = g^{q(i)}（q 的次数小于 |S|），结果为 g^{q(target)}；门限解密中 target 通常为 0。
+//
+// 参数:
+//   - points: 插值点，键为横坐标 i，值为 GT 中的 g^{q(i)}；可以包含 S 之外的点，它们不参与计算
+//   - S: 参与插值的横坐标，互不相同且都在 points 中
+//   - target: 插值的目标横坐标
+//
+// 返回值:
+//   - bn254.GT: 插值结果；S 为空时为单位元
+func LagrangeCombineGT(points map[fr.Element]bn254.GT, S []fr.Element, target fr.Element) bn254.GT {
+	var result bn254.GT
+	result.SetOne()
+	for _, i := range S {
+		delta := ComputeLagrangeBasis(i, S, target)
+		point := points[i]
+		var term bn254.GT
+		term.Exp(point, delta.BigInt(new(big.Int)))
+		result.Mul(&result, &term)
+	}
+	return result
+}
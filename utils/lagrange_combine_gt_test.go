@@ -0,0 +1,49 @@
+package utils
+
+import (
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"math/big"
+	"testing"
+)
+
+// TestLagrangeCombineGT 对 q(x) = 5 + 3x + 2x² 的三个点 g^{q(i)} 插值，结果等于逐项计算的乘积且等于 g^{q(0)}、g^{q(4)}
+func TestLagrangeCombineGT(t *testing.T) {
+	// 指数按 r 取模，g 必须在 r 阶子群中，因此取 e(g1, g2) 而不是随机的 Fp12 元素
+	_, _, g1, g2 := bn254.Generators()
+	g, err := bn254.Pair([]bn254.G1Affine{g1}, []bn254.G2Affine{g2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	polynomial := []fr.Element{fr.NewElement(5), fr.NewElement(3), fr.NewElement(2)}
+	points := make(map[fr.Element]bn254.GT)
+	for x := uint64(1); x <= 4; x++ {
+		qx := ComputePolynomialValue(polynomial, fr.NewElement(x))
+		points[fr.NewElement(x)] = *new(bn254.GT).Exp(g, qx.BigInt(new(big.Int)))
+	}
+	s := []fr.Element{fr.NewElement(1), fr.NewElement(2), fr.NewElement(3)}
+
+	zero := fr.NewElement(0)
+	manual := new(bn254.GT).SetOne()
+	for _, i := range s {
+		delta := ComputeLagrangeBasis(i, s, zero)
+		term := new(bn254.GT).Exp(points[i], delta.BigInt(new(big.Int)))
+		manual.Mul(manual, term)
+	}
+	combined := LagrangeCombineGT(points, s, zero)
+	if !combined.Equal(manual) {
+		t.Fatal("LagrangeCombineGT differs from the manual loop")
+	}
+	q0 := ComputePolynomialValue(polynomial, zero)
+	if want := new(bn254.GT).Exp(g, q0.BigInt(new(big.Int))); !combined.Equal(want) {
+		t.Fatal("LagrangeCombineGT at 0 should give g^{q(0)}")
+	}
+	// 点 4 不在 S 中，插值到 4 得到的仍是 q(4)
+	at4 := LagrangeCombineGT(points, s, fr.NewElement(4))
+	if want := points[fr.NewElement(4)]; !at4.Equal(&want) {
+		t.Fatal("LagrangeCombineGT at 4 should give g^{q(4)}")
+	}
+	if empty := LagrangeCombineGT(points, nil, zero); !empty.IsOne() {
+		t.Fatal("empty S should give the identity")
+	}
+}
@@ -0,0 +1,95 @@
+package utils
+
+import (
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/fft"
+)
+
+// FFTMultiplicationThreshold 是 MultiplyPolynomials 改用 FFT 的最小长度：两个因子的系数个数都不小于该值时
+// 使用 FFT 乘法，否则使用朴素卷积。较短的多项式用朴素卷积更快，FFT 需要准备单位根并做三次变换。
+const FFTMultiplicationThreshold = 64
+
+// PolynomialFromRootsNaive 逐个乘以 (X - root) 构造以 roots 为根的首一多项式，需要 O(n^2) 次乘法。
+// 系数按低次到高次排列，例如 roots = {1, 2} 时返回 {2, -3, 1}，即 X^2 - 3X + 2；roots 为空时返回 {1}。
+//
+// 参数:
+//   - roots: 多项式的根，可以重复
+//
+// 返回值:
+//   - []fr.Element: len(roots)+1 个系数
+func PolynomialFromRootsNaive(roots []fr.Element) []fr.Element {
+	coeffs := make([]fr.Element, 1, len(roots)+1)
+	coeffs[0].SetOne()
+
+	// 原地乘以 (X - root)：新系数 c'_i = c_{i-1} - root * c_i，从高次向低次更新
+	for _, root := range roots {
+		coeffs = append(coeffs, fr.Element{})
+		for i := len(coeffs) - 1; i > 0; i-- {
+			var temp fr.Element
+			temp.Mul(&root, &coeffs[i])
+			coeffs[i].Sub(&coeffs[i-1], &temp)
+		}
+		coeffs[0].Mul(&coeffs[0], &root)
+		coeffs[0].Neg(&coeffs[0])
+	}
+	return coeffs
+}
+
+// PolynomialFromRoots 构造以 roots 为根的首一多项式，结果与 PolynomialFromRootsNaive 完全相同。
+//
+// 根的个数不足 FFTMultiplicationThreshold 时直接使用朴素方法；否则构造乘积树：把根分成两半分别递归求积，
+// 再用 MultiplyPolynomials 相乘。每层的 FFT 乘法总代价为 O(n log n)，共 O(log n) 层，总计 O(n log^2 n)。
+//
+// 参数:
+//   - roots: 多项式的根，可以重复
+//
+// 返回值:
+//   - []fr.Element: len(roots)+1 个系数，按低次到高次排列
+func PolynomialFromRoots(roots []fr.Element) []fr.Element {
+	if len(roots) < FFTMultiplicationThreshold {
+		return PolynomialFromRootsNaive(roots)
+	}
+	mid := len(roots) / 2
+	return MultiplyPolynomials(PolynomialFromRoots(roots[:mid]), PolynomialFromRoots(roots[mid:]))
+}
+
+// MultiplyPolynomials 计算两个多项式的乘积，系数均按低次到高次排列。
+// 两个因子都不短于 FFTMultiplicationThreshold 时在 2 的幂大小的单位根子群上做 FFT 乘法，否则使用朴素卷积。
+//
+// 参数:
+//   - a, b: 两个因子的系数
+//
+// 返回值:
+//   - []fr.Element: len(a)+len(b)-1 个系数；任一因子为空时返回 nil
+func MultiplyPolynomials(a, b []fr.Element) []fr.Element {
+	if len(a) == 0 || len(b) == 0 {
+		return nil
+	}
+	n := len(a) + len(b) - 1
+	if min(len(a), len(b)) < FFTMultiplicationThreshold {
+		result := make([]fr.Element, n)
+		for i := range a {
+			for j := range b {
+				var temp fr.Element
+				temp.Mul(&a[i], &b[j])
+				result[i+j].Add(&result[i+j], &temp)
+			}
+		}
+		return result
+	}
+
+	// 乘积的次数小于子群大小，因此点值相乘后做逆变换不会发生循环卷积的回绕
+	domain := fft.NewDomain(uint64(n))
+	pa := make([]fr.Element, domain.Cardinality)
+	pb := make([]fr.Element, domain.Cardinality)
+	copy(pa, a)
+	copy(pb, b)
+	// DIF 输出位反转顺序的点值，DIT 逆变换接受位反转顺序的输入，中间不需要重排
+	domain.FFT(pa, fft.DIF)
+	domain.FFT(pb, fft.DIF)
+	for i := range pa {
+		pa[i].Mul(&pa[i], &pb[i])
+	}
+	domain.FFTInverse(pa, fft.DIT)
+	return pa[:n]
+}
@@ -0,0 +1,88 @@
+package utils
+
+import (
+	"fmt"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"testing"
+)
+
+func randomRoots(t testing.TB, n int) []fr.Element {
+	roots := make([]fr.Element, n)
+	for i := range roots {
+		if _, err := roots[i].SetRandom(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return roots
+}
+
+func equalCoeffs(a, b []fr.Element) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].Equal(&b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// TestPolynomialFromRootsSmall (x-1)(x-2)(x-3) = x^3 - 6x^2 + 11x - 6，朴素方法、乘积树与 FFT 乘法结果一致
+func TestPolynomialFromRootsSmall(t *testing.T) {
+	roots := []fr.Element{fr.NewElement(1), fr.NewElement(2), fr.NewElement(3)}
+	want := make([]fr.Element, 4)
+	want[0].SetInt64(-6)
+	want[1].SetInt64(11)
+	want[2].SetInt64(-6)
+	want[3].SetOne()
+
+	if got := PolynomialFromRootsNaive(roots); !equalCoeffs(got, want) {
+		t.Fatal("朴素方法的系数错误")
+	}
+	if got := PolynomialFromRoots(roots); !equalCoeffs(got, want) {
+		t.Fatal("PolynomialFromRoots 的系数错误")
+	}
+
+	// 把因子补零到阈值长度以强制走 FFT 路径，乘积去掉末尾的零后应相同
+	a := make([]fr.Element, FFTMultiplicationThreshold)
+	b := make([]fr.Element, FFTMultiplicationThreshold)
+	copy(a, PolynomialFromRootsNaive(roots[:1]))
+	copy(b, PolynomialFromRootsNaive(roots[1:]))
+	if got := MultiplyPolynomials(a, b); !equalCoeffs(got[:len(want)], want) {
+		t.Fatal("FFT 乘法的系数错误")
+	}
+}
+
+// TestPolynomialFromRootsMatchesNaive 跨过阈值的多种规模下乘积树与朴素方法结果完全相同，且每个根都是零点
+func TestPolynomialFromRootsMatchesNaive(t *testing.T) {
+	for _, n := range []int{0, 1, FFTMultiplicationThreshold - 1, FFTMultiplicationThreshold, 257} {
+		roots := randomRoots(t, n)
+		got := PolynomialFromRoots(roots)
+		if !equalCoeffs(got, PolynomialFromRootsNaive(roots)) {
+			t.Fatalf("n=%d: 乘积树与朴素方法结果不同", n)
+		}
+		for _, root := range roots {
+			if v := ComputePolynomialValue(got, root); !v.IsZero() {
+				t.Fatalf("n=%d: 根处的多项式值不为零", n)
+			}
+		}
+	}
+}
+
+// BenchmarkPolynomialFromRoots 对比朴素方法与乘积树在不同批次大小下构造摘要多项式的耗时
+func BenchmarkPolynomialFromRoots(b *testing.B) {
+	for _, n := range []int{10, 100, 1000} {
+		roots := randomRoots(b, n)
+		b.Run(fmt.Sprintf("naive/n=%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				PolynomialFromRootsNaive(roots)
+			}
+		})
+		b.Run(fmt.Sprintf("subproduct/n=%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				PolynomialFromRoots(roots)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/mmsyan/GoPairingBasedCryptography/internal/randsource"
+	"io"
+)
+
+// MaxRandomScalarAttempts 是 RandomNonZeroScalar 与 RandomScalarWhere 的最大采样次数。
+// 正常的随机源连续抽到退化值的概率约为 2^{-254·8}，达到上限几乎必然意味着随机源已经损坏。
+const MaxRandomScalarAttempts = 8
+
+// ErrDegenerateRandomness 表示随机源连续 MaxRandomScalarAttempts 次都给出了被拒绝的标量
+var ErrDegenerateRandomness = errors.New("random source keeps producing degenerate scalars")
+
+// RandomNonZeroScalar 从 crypto/rand 均匀随机选取一个非零标量，抽到零时重新采样。
+// 加密与密钥生成中的随机指数为零时，g^t 之类的分量退化为单位元，密文或私钥不再依赖随机数，
+// 因此这些采样点都应使用该函数代替 SetRandom。
+//
+// 返回值:
+//   - *fr.Element: 非零随机标量
+//   - error: 读取随机源失败，或超过 MaxRandomScalarAttempts 次仍为零时返回包装 ErrDegenerateRandomness 的错误
+func RandomNonZeroScalar() (*fr.Element, error) {
+	return RandomScalarWhere(func(e *fr.Element) bool { return !e.IsZero() })
+}
+
+// RandomScalarWhere 均匀随机选取一个满足 accept 的标量，不满足时重新采样。
+// 用于除零以外还有其他退化值的场合，例如要求 ID + x + r·y ≠ 0 的私钥随机数。
+//
+// 参数:
+//   - accept: 判断标量是否可用，返回 false 时重新采样
+//
+// 返回值:
+//   - *fr.Element: 满足 accept 的随机标量
+//   - error: 读取随机源失败，或超过 MaxRandomScalarAttempts 次仍不满足时返回包装 ErrDegenerateRandomness 的错误
+func RandomScalarWhere(accept func(*fr.Element) bool) (*fr.Element, error) {
+	// 多读 16 字节再模 r 约减，与均匀分布的统计距离小于 2^{-128}
+	var buf [fr.Bytes + 16]byte
+	for attempt := 0; attempt < MaxRandomScalarAttempts; attempt++ {
+		if _, err := io.ReadFull(randsource.Reader(), buf[:]); err != nil {
+			return nil, fmt.Errorf("unable to sample random scalar: %w", err)
+		}
+		e := new(fr.Element).SetBytes(buf[:])
+		if accept(e) {
+			return e, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %d attempts", ErrDegenerateRandomness, MaxRandomScalarAttempts)
+}
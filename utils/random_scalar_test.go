@@ -0,0 +1,54 @@
+package utils
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/mmsyan/GoPairingBasedCryptography/internal/randsource"
+	"io"
+	"testing"
+)
+
+// zeroFirstReader 先给出 zeros 字节的零值，之后转交 crypto/rand
+func zeroFirstReader(zeros int) io.Reader {
+	return io.MultiReader(bytes.NewReader(make([]byte, zeros)), rand.Reader)
+}
+
+func TestRandomNonZeroScalarResamples(t *testing.T) {
+	defer randsource.Set(zeroFirstReader(fr.Bytes + 16))()
+	e, err := RandomNonZeroScalar()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.IsZero() {
+		t.Fatal("抽到零后应重新采样")
+	}
+}
+
+func TestRandomNonZeroScalarBrokenSource(t *testing.T) {
+	defer randsource.Set(bytes.NewReader(make([]byte, (fr.Bytes+16)*MaxRandomScalarAttempts)))()
+	if _, err := RandomNonZeroScalar(); !errors.Is(err, ErrDegenerateRandomness) {
+		t.Fatalf("期望 ErrDegenerateRandomness，得到 %v", err)
+	}
+
+	// 随机源耗尽时返回读取错误
+	if _, err := RandomNonZeroScalar(); !errors.Is(err, io.EOF) {
+		t.Fatalf("期望 io.EOF，得到 %v", err)
+	}
+}
+
+func TestRandomScalarWhere(t *testing.T) {
+	one := fr.NewElement(1)
+	attempts := 0
+	e, err := RandomScalarWhere(func(e *fr.Element) bool {
+		attempts++
+		return attempts == 3 && !e.Equal(&one)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if attempts != 3 || e.Equal(&one) {
+		t.Fatalf("应在第 3 次采样时接受，实际 %d 次", attempts)
+	}
+}
@@ -0,0 +1,69 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+)
+
+// ShamirSplit 使用 Shamir (t,n) 门限方案分割秘密。
+// 随机选取 t-1 次多项式 q(x)，q(0) = secret，份额为 (i, q(i))，i = 1, ..., n。
+// 任意 t 个份额可以恢复秘密，少于 t 个份额不泄露秘密的任何信息。
+//
+// 参数:
+//   - secret: 要分割的秘密
+//   - t: 门限值，需满足 1 <= t <= n
+//   - n: 份额个数
+//
+// 返回值:
+//   - map[fr.Element]fr.Element: 份额，键为横坐标 i，值为 q(i)
+//   - error: 参数不合法时返回错误
+func ShamirSplit(secret fr.Element, t, n int) (map[fr.Element]fr.Element, error) {
+	if t < 1 {
+		return nil, fmt.Errorf("threshold must be positive, got %d", t)
+	}
+	if n < t {
+		return nil, fmt.Errorf("number of shares %d is less than threshold %d", n, t)
+	}
+
+	polynomial := GenerateRandomPolynomial(t, secret)
+	shares := make(map[fr.Element]fr.Element, n)
+	for i := 1; i <= n; i++ {
+		x := fr.NewElement(uint64(i))
+		shares[x] = ComputePolynomialValue(polynomial, x)
+	}
+	return shares, nil
+}
+
+// ShamirReconstruct 使用拉格朗日插值 secret = Σ q(i)·Δ_{i,S}(0) 从份额中恢复秘密。
+// 份额个数少于门限值时仍会返回一个域元素，但该值与秘密无关，调用方需自行保证份额数量。
+//
+// 参数:
+//   - shares: 份额，键为横坐标 i，值为 q(i)
+//
+// 返回值:
+//   - fr.Element: 恢复出的秘密
+//   - error: 份额为空或包含横坐标为 0 的份额时返回错误
+func ShamirReconstruct(shares map[fr.Element]fr.Element) (fr.Element, error) {
+	if len(shares) == 0 {
+		return fr.Element{}, errors.New("no shares provided")
+	}
+
+	s := make([]fr.Element, 0, len(shares))
+	for x := range shares {
+		if x.IsZero() {
+			return fr.Element{}, errors.New("share index must be non-zero")
+		}
+		s = append(s, x)
+	}
+
+	zero := fr.NewElement(0)
+	var secret fr.Element
+	for _, i := range s {
+		delta := ComputeLagrangeBasis(i, s, zero)
+		y := shares[i]
+		term := new(fr.Element).Mul(&y, &delta)
+		secret.Add(&secret, term)
+	}
+	return secret, nil
+}
@@ -0,0 +1,86 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+)
+
+// subsetsOf 返回 keys 中所有大小为 k 的子集
+func subsetsOf(keys []fr.Element, k int) [][]fr.Element {
+	if k == 0 {
+		return [][]fr.Element{{}}
+	}
+	if len(keys) < k {
+		return nil
+	}
+	var result [][]fr.Element
+	for _, rest := range subsetsOf(keys[1:], k-1) {
+		result = append(result, append([]fr.Element{keys[0]}, rest...))
+	}
+	return append(result, subsetsOf(keys[1:], k)...)
+}
+
+func TestShamirSplitReconstruct(t *testing.T) {
+	const threshold, n = 3, 5
+	secret, _ := new(fr.Element).SetRandom()
+
+	shares, err := ShamirSplit(*secret, threshold, n)
+	if err != nil {
+		t.Fatalf("ShamirSplit failed: %v", err)
+	}
+	if len(shares) != n {
+		t.Fatalf("got %d shares, want %d", len(shares), n)
+	}
+	keys := make([]fr.Element, 0, n)
+	for x := range shares {
+		keys = append(keys, x)
+	}
+
+	// 任意 t 个份额都能恢复秘密
+	for _, subset := range subsetsOf(keys, threshold) {
+		partial := make(map[fr.Element]fr.Element, len(subset))
+		for _, x := range subset {
+			partial[x] = shares[x]
+		}
+		got, err := ShamirReconstruct(partial)
+		if err != nil {
+			t.Fatalf("ShamirReconstruct failed: %v", err)
+		}
+		if !got.Equal(secret) {
+			t.Errorf("%d shares failed to reconstruct the secret", threshold)
+		}
+	}
+
+	// 任意 t-1 个份额都无法恢复秘密
+	for _, subset := range subsetsOf(keys, threshold-1) {
+		partial := make(map[fr.Element]fr.Element, len(subset))
+		for _, x := range subset {
+			partial[x] = shares[x]
+		}
+		got, err := ShamirReconstruct(partial)
+		if err != nil {
+			t.Fatalf("ShamirReconstruct failed: %v", err)
+		}
+		if got.Equal(secret) {
+			t.Errorf("%d shares should not reconstruct the secret", threshold-1)
+		}
+	}
+}
+
+func TestShamirInvalidParameters(t *testing.T) {
+	secret := fr.NewElement(42)
+	if _, err := ShamirSplit(secret, 0, 3); err == nil {
+		t.Error("expected error for zero threshold")
+	}
+	if _, err := ShamirSplit(secret, 4, 3); err == nil {
+		t.Error("expected error when n < t")
+	}
+	if _, err := ShamirReconstruct(nil); err == nil {
+		t.Error("expected error for empty shares")
+	}
+	zeroShare := map[fr.Element]fr.Element{fr.NewElement(0): secret}
+	if _, err := ShamirReconstruct(zeroShare); err == nil {
+		t.Error("expected error for share at x = 0")
+	}
+}